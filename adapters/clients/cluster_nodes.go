@@ -0,0 +1,67 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+type ClusterNodes struct {
+	client *http.Client
+}
+
+func NewClusterNodes(httpClient *http.Client) *ClusterNodes {
+	return &ClusterNodes{client: httpClient}
+}
+
+func (c *ClusterNodes) GetNodeStatus(ctx context.Context,
+	hostName string,
+) (*models.NodeStatus, error) {
+	path := "/nodes/status"
+	method := http.MethodGet
+	url := url.URL{Scheme: "http", Host: hostName, Path: path}
+
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "open http request")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send http request")
+	}
+
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read body")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d (%s)", res.StatusCode,
+			body)
+	}
+
+	var status models.NodeStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+
+	return &status, nil
+}
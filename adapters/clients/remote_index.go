@@ -26,7 +26,9 @@ import (
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/clusterapi"
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
+	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/searchparams"
 	"github.com/semi-technologies/weaviate/entities/storobj"
@@ -404,12 +406,12 @@ func (c *RemoteIndex) MultiGetObjects(ctx context.Context, hostName, indexName,
 }
 
 func (c *RemoteIndex) SearchShard(ctx context.Context, hostName, indexName,
-	shardName string, vector []float32, limit int, filters *filters.LocalFilter,
-	keywordRanking *searchparams.KeywordRanking, sort []filters.Sort,
-	additional additional.Properties,
+	shardName string, vector []float32, targetVector string, limit int,
+	filters *filters.LocalFilter, keywordRanking *searchparams.KeywordRanking,
+	sort []filters.Sort, additional additional.Properties,
 ) ([]*storobj.Object, []float32, error) {
 	paramsBytes, err := clusterapi.IndicesPayloads.SearchParams.
-		Marshal(vector, limit, filters, keywordRanking, sort, additional)
+		Marshal(vector, targetVector, limit, filters, keywordRanking, sort, additional)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "marshal request payload")
 	}
@@ -650,6 +652,47 @@ func (c *RemoteIndex) GetShardStatus(ctx context.Context,
 	return status, nil
 }
 
+func (c *RemoteIndex) GetShardReindexStatus(ctx context.Context,
+	hostName, indexName, shardName string,
+) ([]*models.PropertyReindexStatus, error) {
+	path := fmt.Sprintf("/indices/%s/shards/%s/_reindex", indexName, shardName)
+	method := http.MethodGet
+	url := url.URL{Scheme: "http", Host: hostName, Path: path}
+
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "open http request")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send http request")
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return nil, errors.Errorf("unexpected status code %d (%s)", res.StatusCode,
+			body)
+	}
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read body")
+	}
+
+	ct, ok := clusterapi.IndicesPayloads.GetShardReindexStatusResults.CheckContentTypeHeader(res)
+	if !ok {
+		return nil, errors.Errorf("unexpected content type: %s", ct)
+	}
+
+	status, err := clusterapi.IndicesPayloads.GetShardReindexStatusResults.Unmarshal(resBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	return status, nil
+}
+
 func (c *RemoteIndex) UpdateShardStatus(ctx context.Context, hostName, indexName, shardName,
 	targetStatus string,
 ) error {
@@ -693,3 +736,144 @@ func (c *RemoteIndex) UpdateShardStatus(ctx context.Context, hostName, indexName
 
 	return nil
 }
+
+func (c *RemoteIndex) CreateShardBackup(ctx context.Context, hostName, indexName,
+	shardName, snapshotID string,
+) (*backup.ShardSnapshot, error) {
+	paramsBytes, err := clusterapi.IndicesPayloads.CreateShardBackupParams.Marshal(snapshotID)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal request payload")
+	}
+
+	path := fmt.Sprintf("/indices/%s/shards/%s/_backup", indexName, shardName)
+	method := http.MethodPost
+	url := url.URL{Scheme: "http", Host: hostName, Path: path}
+
+	req, err := http.NewRequestWithContext(ctx, method, url.String(),
+		bytes.NewReader(paramsBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "open http request")
+	}
+
+	clusterapi.IndicesPayloads.CreateShardBackupParams.SetContentTypeHeaderReq(req)
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send http request")
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return nil, errors.Errorf("unexpected status code %d (%s)", res.StatusCode,
+			body)
+	}
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read body")
+	}
+
+	ct, ok := clusterapi.IndicesPayloads.ShardSnapshot.CheckContentTypeHeader(res)
+	if !ok {
+		return nil, errors.Errorf("unexpected content type: %s", ct)
+	}
+
+	snap, err := clusterapi.IndicesPayloads.ShardSnapshot.Unmarshal(resBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	return snap, nil
+}
+
+func (c *RemoteIndex) ReleaseShardBackup(ctx context.Context, hostName, indexName,
+	shardName, snapshotID string,
+) error {
+	path := fmt.Sprintf("/indices/%s/shards/%s/_backup", indexName, shardName)
+	method := http.MethodDelete
+	url := url.URL{Scheme: "http", Host: hostName, Path: path}
+	q := url.Query()
+	q.Set("id", snapshotID)
+	url.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "open http request")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send http request")
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(res.Body)
+		return errors.Errorf("unexpected status code %d (%s)", res.StatusCode,
+			body)
+	}
+
+	return nil
+}
+
+// GetShardBackupFile streams a single file of a shard from the remote node.
+// The caller is responsible for closing the returned reader.
+func (c *RemoteIndex) GetShardBackupFile(ctx context.Context, hostName, indexName,
+	shardName, relPath string,
+) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/indices/%s/shards/%s/files/%s", indexName, shardName, relPath)
+	method := http.MethodGet
+	url := url.URL{Scheme: "http", Host: hostName, Path: path}
+
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "open http request")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send http request")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		return nil, errors.Errorf("unexpected status code %d (%s)", res.StatusCode,
+			body)
+	}
+
+	ct, ok := clusterapi.IndicesPayloads.ShardBackupFile.CheckContentTypeHeader(res)
+	if !ok {
+		res.Body.Close()
+		return nil, errors.Errorf("unexpected content type: %s", ct)
+	}
+
+	return res.Body, nil
+}
+
+func (c *RemoteIndex) PutShardBackupFile(ctx context.Context, hostName, indexName,
+	shardName, relPath string, r io.Reader,
+) error {
+	path := fmt.Sprintf("/indices/%s/shards/%s/files/%s", indexName, shardName, relPath)
+	method := http.MethodPut
+	url := url.URL{Scheme: "http", Host: hostName, Path: path}
+
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), r)
+	if err != nil {
+		return errors.Wrap(err, "open http request")
+	}
+
+	clusterapi.IndicesPayloads.ShardBackupFile.SetContentTypeHeaderReq(req)
+	res, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send http request")
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(res.Body)
+		return errors.Errorf("unexpected status code %d (%s)", res.StatusCode,
+			body)
+	}
+
+	return nil
+}
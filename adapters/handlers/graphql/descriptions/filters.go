@@ -68,6 +68,18 @@ const (
 	WhereValueRangeDistanceMax             = "The maximum distance from the point specified geoCoordinates."
 	WhereValueText                         = "Specify a Text value that the target property will be compared to"
 	WhereValueDate                         = "Specify a Date value that the target property will be compared to"
+	WhereValueUUID                         = "Specify a UUID value that the target property will be compared to"
+)
+
+// Value lists, used by the ContainsAny and ContainsAll operators
+const (
+	WhereValueIntArray     = "Specify a list of Integer values that the target property will be compared to"
+	WhereValueNumberArray  = "Specify a list of Float values that the target property will be compared to"
+	WhereValueBooleanArray = "Specify a list of Boolean values that the target property will be compared to"
+	WhereValueStringArray  = "Specify a list of String values that the target property will be compared to"
+	WhereValueTextArray    = "Specify a list of Text values that the target property will be compared to"
+	WhereValueDateArray    = "Specify a list of Date values that the target property will be compared to"
+	WhereValueUUIDArray    = "Specify a list of UUID values that the target property will be compared to"
 )
 
 // Properties and Classes filter elements (used by Fetch and Introspect Where filters)
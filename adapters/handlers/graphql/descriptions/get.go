@@ -43,3 +43,11 @@ const (
 )
 
 const NetworkGetClassUUID = "The UUID of a Object, assigned by the Weaviate network" // TODO check this with @lauraham
+
+const (
+	HybridQuery      = "The query string, it is used for the keyword-based (bm25) part and vectorized for the vector part if no vector is provided"
+	HybridVector     = "Vector to be used for the vector part of the hybrid search instead of vectorizing the query"
+	HybridAlpha      = "Weight of the vector search against the keyword search, between 0 (pure keyword search) and 1 (pure vector search). Defaults to 0.75"
+	HybridFusionType = "How to fuse the keyword and vector rankings: rankedFusion (reciprocal rank fusion, default) or relativeScoreFusion (normalized scores)"
+	HybridProperties = "The properties to use for the keyword-based (bm25) part, a property can be boosted with a suffix such as \"title^3\""
+)
@@ -223,6 +223,9 @@ func classPropertyField(dataType schema.DataType, class *models.Class, property
 	case schema.DataTypePhoneNumber:
 		// skipping for now, see gh-1088 where it was outscoped
 		return nil, nil
+	case schema.DataTypeObject, schema.DataTypeObjectArray:
+		// nested objects cannot be aggregated
+		return nil, nil
 	case schema.DataTypeBlob:
 		return makePropertyField(class, property, stringPropertyFields)
 	case schema.DataTypeStringArray, schema.DataTypeTextArray:
@@ -233,6 +236,8 @@ func classPropertyField(dataType schema.DataType, class *models.Class, property
 		return makePropertyField(class, property, booleanPropertyFields)
 	case schema.DataTypeDateArray:
 		return makePropertyField(class, property, datePropertyFields)
+	case schema.DataTypeUUID, schema.DataTypeUUIDArray:
+		return makePropertyField(class, property, stringPropertyFields)
 	default:
 		return nil, fmt.Errorf(schema.ErrorNoSuchDatatype+": %s", dataType)
 	}
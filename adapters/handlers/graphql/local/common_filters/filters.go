@@ -28,6 +28,8 @@ func BuildNew(path string) graphql.InputObjectConfigFieldMap {
 				Values: graphql.EnumValueConfigMap{
 					"And":              &graphql.EnumValueConfig{},
 					"Like":             &graphql.EnumValueConfig{},
+					"Phrase":           &graphql.EnumValueConfig{},
+					"Fuzzy":            &graphql.EnumValueConfig{},
 					"Or":               &graphql.EnumValueConfig{},
 					"Equal":            &graphql.EnumValueConfig{},
 					"Not":              &graphql.EnumValueConfig{},
@@ -37,6 +39,8 @@ func BuildNew(path string) graphql.InputObjectConfigFieldMap {
 					"LessThan":         &graphql.EnumValueConfig{},
 					"LessThanEqual":    &graphql.EnumValueConfig{},
 					"WithinGeoRange":   &graphql.EnumValueConfig{},
+					"ContainsAny":      &graphql.EnumValueConfig{},
+					"ContainsAll":      &graphql.EnumValueConfig{},
 				},
 				Description: descriptions.WhereOperatorEnum,
 			}),
@@ -70,6 +74,38 @@ func BuildNew(path string) graphql.InputObjectConfigFieldMap {
 			Type:        graphql.String,
 			Description: descriptions.WhereValueString,
 		},
+		"valueUuid": &graphql.InputObjectFieldConfig{
+			Type:        graphql.String,
+			Description: descriptions.WhereValueUUID,
+		},
+		"valueIntArray": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.Int),
+			Description: descriptions.WhereValueIntArray,
+		},
+		"valueNumberArray": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.Float),
+			Description: descriptions.WhereValueNumberArray,
+		},
+		"valueBooleanArray": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.Boolean),
+			Description: descriptions.WhereValueBooleanArray,
+		},
+		"valueStringArray": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.String),
+			Description: descriptions.WhereValueStringArray,
+		},
+		"valueTextArray": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.String),
+			Description: descriptions.WhereValueTextArray,
+		},
+		"valueDateArray": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.String),
+			Description: descriptions.WhereValueDateArray,
+		},
+		"valueUuidArray": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.String),
+			Description: descriptions.WhereValueUUIDArray,
+		},
 		"valueGeoRange": &graphql.InputObjectFieldConfig{
 			Type:        newGeoRangeInputObject(path),
 			Description: descriptions.WhereValueRange,
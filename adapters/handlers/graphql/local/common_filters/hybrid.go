@@ -0,0 +1,61 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package common_filters
+
+import "github.com/semi-technologies/weaviate/entities/searchparams"
+
+// ExtractHybrid arguments, such as "query", "alpha" and "fusionType"
+func ExtractHybrid(source map[string]interface{}) searchparams.HybridSearch {
+	args := searchparams.HybridSearch{
+		Alpha: searchparams.HybridDefaultAlpha,
+	}
+
+	query, ok := source["query"]
+	if ok {
+		args.Query = query.(string)
+	}
+
+	vector, ok := source["vector"]
+	if ok {
+		rawSlice := vector.([]interface{})
+		args.Vector = make([]float32, len(rawSlice))
+		for i, raw := range rawSlice {
+			args.Vector[i] = float32(raw.(float64))
+		}
+	}
+
+	alpha, ok := source["alpha"]
+	if ok {
+		args.Alpha = alpha.(float64)
+	}
+
+	fusionType, ok := source["fusionType"]
+	if ok {
+		args.FusionType = fusionType.(string)
+	}
+
+	p, ok := source["properties"]
+	if ok {
+		rawSlice := p.([]interface{})
+		args.Properties = make([]string, len(rawSlice))
+		for i, raw := range rawSlice {
+			args.Properties[i] = raw.(string)
+		}
+	}
+
+	targetVector, ok := source["targetVector"]
+	if ok {
+		args.TargetVector = targetVector.(string)
+	}
+
+	return args
+}
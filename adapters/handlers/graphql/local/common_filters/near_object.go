@@ -47,5 +47,10 @@ func ExtractNearObject(source map[string]interface{}) (searchparams.NearObject,
 			fmt.Errorf("cannot provide distance and certainty")
 	}
 
+	targetVector, ok := source["targetVector"]
+	if ok {
+		args.TargetVector = targetVector.(string)
+	}
+
 	return args, nil
 }
@@ -108,6 +108,33 @@ func TestExtractFilterLike_ValueText(t *testing.T) {
 	resolver.AssertResolve(t, query)
 }
 
+func TestExtractFilterContainsAny(t *testing.T) {
+	t.Parallel()
+
+	resolver := newMockResolver(t, mockParams{reportFilter: true})
+	expectedParams := &filters.LocalFilter{Root: &filters.Clause{
+		Operator: filters.OperatorContainsAny,
+		On: &filters.Path{
+			Class:    schema.AssertValidClassName("SomeAction"),
+			Property: schema.AssertValidPropertyName("intField"),
+		},
+		Value: &filters.Value{
+			Value: []int{42, 43},
+			Type:  schema.DataTypeIntArray,
+		},
+	}}
+
+	resolver.On("ReportFilters", expectedParams).
+		Return(test_helper.EmptyList(), nil).Once()
+
+	query := `{ SomeAction(where: {
+			path: ["intField"],
+			operator: ContainsAny,
+			valueIntArray: [42, 43],
+		}) }`
+	resolver.AssertResolve(t, query)
+}
+
 func TestExtractFilterGeoLocation(t *testing.T) {
 	t.Parallel()
 
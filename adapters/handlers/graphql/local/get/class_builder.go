@@ -109,6 +109,9 @@ func (b *classBuilder) classObject(class *models.Class) *graphql.Object {
 				if propertyType.IsPrimitive() {
 					classProperties[property.Name] = b.primitiveField(propertyType, property,
 						class.Class)
+				} else if propertyType.IsNested() {
+					classProperties[property.Name] = b.nestedField(propertyType, property,
+						class.Class, property.Name)
 				} else {
 					classProperties[property.Name] = b.referenceField(propertyType, property,
 						class.Class)
@@ -126,7 +129,12 @@ func (b *classBuilder) additionalFields(classProperties graphql.Fields, class *m
 	additionalProperties["classification"] = b.additionalClassificationField(class)
 	additionalProperties["certainty"] = b.additionalCertaintyField(class)
 	additionalProperties["distance"] = b.additionalDistanceField(class)
+	additionalProperties["score"] = b.additionalScoreField(class)
+	additionalProperties["explainScore"] = b.additionalExplainScoreField(class)
 	additionalProperties["vector"] = b.additionalVectorField(class)
+	if len(class.VectorConfig) > 0 {
+		additionalProperties["vectors"] = b.additionalVectorsField(class)
+	}
 	additionalProperties["id"] = b.additionalIDField()
 	additionalProperties["creationTimeUnix"] = b.additionalCreationTimeUnix()
 	additionalProperties["lastUpdateTimeUnix"] = b.additionalLastUpdateTimeUnix()
@@ -178,12 +186,38 @@ func (b *classBuilder) additionalDistanceField(class *models.Class) *graphql.Fie
 	}
 }
 
+func (b *classBuilder) additionalScoreField(class *models.Class) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.Float,
+	}
+}
+
+func (b *classBuilder) additionalExplainScoreField(class *models.Class) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.String,
+	}
+}
+
 func (b *classBuilder) additionalVectorField(class *models.Class) *graphql.Field {
 	return &graphql.Field{
 		Type: graphql.NewList(graphql.Float),
 	}
 }
 
+func (b *classBuilder) additionalVectorsField(class *models.Class) *graphql.Field {
+	fields := graphql.Fields{}
+	for name := range class.VectorConfig {
+		fields[name] = &graphql.Field{Type: graphql.NewList(graphql.Float)}
+	}
+
+	return &graphql.Field{
+		Type: graphql.NewObject(graphql.ObjectConfig{
+			Name:   fmt.Sprintf("%sAdditionalVectors", class.Class),
+			Fields: fields,
+		}),
+	}
+}
+
 func (b *classBuilder) additionalCreationTimeUnix() *graphql.Field {
 	return &graphql.Field{
 		Type: graphql.String,
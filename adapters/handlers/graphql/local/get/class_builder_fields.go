@@ -99,6 +99,12 @@ func (b *classBuilder) primitiveField(propertyType schema.PropertyDataType,
 			Name:        property.Name,
 			Type:        graphql.String,
 		}
+	case schema.DataTypeUUID:
+		return &graphql.Field{
+			Description: property.Description,
+			Name:        property.Name,
+			Type:        graphql.String, // serialized in the canonical uuid form
+		}
 	case schema.DataTypeStringArray, schema.DataTypeTextArray:
 		return &graphql.Field{
 			Description: property.Description,
@@ -129,12 +135,63 @@ func (b *classBuilder) primitiveField(propertyType schema.PropertyDataType,
 			Name:        property.Name,
 			Type:        graphql.NewList(graphql.String), // String since no graphql date datatype exists
 		}
+	case schema.DataTypeUUIDArray:
+		return &graphql.Field{
+			Description: property.Description,
+			Name:        property.Name,
+			Type:        graphql.NewList(graphql.String), // serialized in the canonical uuid form
+		}
 	default:
 		panic(fmt.Sprintf("buildGetClass: unknown primitive type for %s.%s; %s",
 			className, property.Name, propertyType.AsPrimitive()))
 	}
 }
 
+// nestedField builds an object from the nested props of an object or object[]
+// prop. Its name contains the path of the prop, as nested props of different
+// props can share their names.
+func (b *classBuilder) nestedField(propertyType schema.PropertyDataType,
+	property *models.Property, className string, path string,
+) *graphql.Field {
+	obj := graphql.NewObject(graphql.ObjectConfig{
+		Name: fmt.Sprintf("%s%sNestedObj", className,
+			strings.ReplaceAll(path, schema.NestedPropertySeparator, "__")),
+		Fields: (graphql.FieldsThunk)(func() graphql.Fields {
+			fields := graphql.Fields{}
+
+			for _, nested := range property.NestedProperties {
+				nestedType, err := b.schema.FindPropertyDataType(nested.DataType)
+				if err != nil {
+					// We can't return an error in this FieldsThunk function, so we need to panic
+					panic(fmt.Sprintf("buildGetClass: wrong propertyType for %s.%s; %s",
+						className, schema.NestedPropertyPath(path, nested.Name), err.Error()))
+				}
+
+				if nestedType.IsNested() {
+					fields[nested.Name] = b.nestedField(nestedType, nested, className,
+						schema.NestedPropertyPath(path, nested.Name))
+				} else {
+					fields[nested.Name] = b.primitiveField(nestedType, nested, className)
+				}
+			}
+
+			return fields
+		}),
+		Description: property.Description,
+	})
+
+	var fieldType graphql.Output = obj
+	if propertyType.AsNested() == schema.DataTypeObjectArray {
+		fieldType = graphql.NewList(obj)
+	}
+
+	return &graphql.Field{
+		Description: property.Description,
+		Name:        property.Name,
+		Type:        fieldType,
+	}
+}
+
 func newGeoCoordinatesObject(className string, propertyName string) *graphql.Object {
 	return graphql.NewObject(graphql.ObjectConfig{
 		Description: "GeoCoordinates as latitude and longitude in decimal form",
@@ -226,6 +283,7 @@ func buildGetClassField(classObject *graphql.Object,
 	// hacky way to temporarily check feature flag
 	if os.Getenv("ENABLE_EXPERIMENTAL_BM25") != "" {
 		field.Args["bm25"] = bm25Argument(class.Class)
+		field.Args["hybrid"] = hybridArgument(class.Class)
 	}
 
 	if modulesProvider != nil {
@@ -368,6 +426,12 @@ func (r *resolver) makeResolveGetClass(className string) graphql.FieldResolveFn
 			keywordRankingParams = &p
 		}
 
+		var hybridParams *searchparams.HybridSearch
+		if hybrid, ok := p.Args["hybrid"]; ok {
+			p := common_filters.ExtractHybrid(hybrid.(map[string]interface{}))
+			hybridParams = &p
+		}
+
 		group := extractGroup(p.Args)
 
 		params := traverser.GetParams{
@@ -382,6 +446,7 @@ func (r *resolver) makeResolveGetClass(className string) graphql.FieldResolveFn
 			ModuleParams:         moduleParams,
 			AdditionalProperties: additional,
 			KeywordRanking:       keywordRankingParams,
+			HybridSearch:         hybridParams,
 		}
 
 		// need to perform vector search by distance
@@ -465,10 +530,12 @@ func isPrimitive(selectionSet *ast.SelectionSet) bool {
 	}
 
 	// if there is a selection set it could either be a cross-ref or a map-type
-	// field like GeoCoordinates or PhoneNumber
+	// field like GeoCoordinates, PhoneNumber or a nested object. Apart from
+	// __typename, the selections of a cross-ref are fragments on the
+	// referenced classes, so any other field is a map-type field.
 	for _, subSelection := range selectionSet.Selections {
 		if subsectionField, ok := subSelection.(*ast.Field); ok {
-			if fieldNameIsOfObjectButNonReferenceType(subsectionField.Name.Value) {
+			if subsectionField.Name.Value != "__typename" {
 				return true
 			}
 		}
@@ -484,7 +551,8 @@ type additionalCheck struct {
 
 func (ac *additionalCheck) isAdditional(name string) bool {
 	if name == "classification" || name == "certainty" ||
-		name == "distance" || name == "id" || name == "vector" ||
+		name == "distance" || name == "score" || name == "explainScore" ||
+		name == "id" || name == "vector" || name == "vectors" ||
 		name == "creationTimeUnix" || name == "lastUpdateTimeUnix" {
 		return true
 	}
@@ -507,20 +575,6 @@ func (ac *additionalCheck) isModuleAdditional(name string) bool {
 	return false
 }
 
-func fieldNameIsOfObjectButNonReferenceType(field string) bool {
-	switch field {
-	case "latitude", "longitude":
-		// must be a geo prop
-		return true
-	case "input", "internationalFormatted", "nationalFormatted", "national",
-		"valid", "countryCode", "defaultCountry":
-		// must be a phone number
-		return true
-	default:
-		return false
-	}
-}
-
 func extractProperties(className string, selections *ast.SelectionSet,
 	fragments map[string]ast.Definition,
 	modulesProvider ModulesProvider,
@@ -534,7 +588,8 @@ func extractProperties(className string, selections *ast.SelectionSet,
 		name := field.Name.Value
 		property := search.SelectProperty{Name: name}
 
-		property.IsPrimitive = isPrimitive(field.SelectionSet)
+		// the fields of _additional are extracted like the ones of a cross-ref
+		property.IsPrimitive = name != "_additional" && isPrimitive(field.SelectionSet)
 		if !property.IsPrimitive {
 			// We can interpret this property in different ways
 			for _, subSelection := range field.SelectionSet.Selections {
@@ -560,6 +615,14 @@ func extractProperties(className string, selections *ast.SelectionSet,
 							continue
 						}
 
+						if additionalProperty == "score" {
+							additionalProps.Score = true
+							continue
+						}
+						if additionalProperty == "explainScore" {
+							additionalProps.ExplainScore = true
+							continue
+						}
 						if additionalProperty == "id" {
 							additionalProps.ID = true
 							continue
@@ -568,6 +631,10 @@ func extractProperties(className string, selections *ast.SelectionSet,
 							additionalProps.Vector = true
 							continue
 						}
+						if additionalProperty == "vectors" {
+							additionalProps.Vectors = true
+							continue
+						}
 						if additionalProperty == "creationTimeUnix" {
 							additionalProps.CreationTimeUnix = true
 							continue
@@ -95,13 +95,53 @@ func TestExtractGeoCoordinatesField(t *testing.T) {
 	assert.Equal(t, expectedLocation, result.Get("Get", "SomeAction").Result.([]interface{})[0])
 }
 
+func TestExtractNestedObjectField(t *testing.T) {
+	t.Parallel()
+
+	resolver := newMockResolver()
+
+	expectedParams := traverser.GetParams{
+		ClassName:  "SomeAction",
+		Properties: []search.SelectProperty{{Name: "address", IsPrimitive: true}},
+	}
+
+	resolverReturn := []interface{}{
+		map[string]interface{}{
+			"address": map[string]interface{}{
+				"city": "Amsterdam",
+				"pets": []interface{}{
+					map[string]interface{}{"name": "Bello"},
+					map[string]interface{}{"name": "Garfield"},
+				},
+			},
+		},
+	}
+
+	resolver.On("GetClass", expectedParams).
+		Return(resolverReturn, nil).Once()
+
+	query := "{ Get { SomeAction { address { city pets { name } } } } }"
+	result := resolver.AssertResolve(t, query)
+
+	expectedAddress := map[string]interface{}{
+		"address": map[string]interface{}{
+			"city": "Amsterdam",
+			"pets": []interface{}{
+				map[string]interface{}{"name": "Bello"},
+				map[string]interface{}{"name": "Garfield"},
+			},
+		},
+	}
+
+	assert.Equal(t, expectedAddress, result.Get("Get", "SomeAction").Result.([]interface{})[0])
+}
+
 func TestExtractPhoneNumberField(t *testing.T) {
 	// We need to explicitly test all cases of asking for just one sub-property
-	// at a time, because the AST-parsing uses known fields of known props to
+	// at a time, because the AST-parsing uses the fields of a selection to
 	// distinguish a complex primitive prop from a reference prop
 	//
-	// See "isPrimitive()" and "fieldNameIsOfObjectButNonReferenceType" in
-	// class_builder_fields.go for more details
+	// See "isPrimitive()" in class_builder_fields.go for more details
 
 	type test struct {
 		name           string
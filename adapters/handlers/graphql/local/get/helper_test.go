@@ -144,6 +144,7 @@ type nearCustomTextParams struct {
 	Certainty    float64
 	Distance     float64
 	WithDistance bool
+	TargetVector string
 }
 
 // implements the modulecapabilities.NearParam interface
@@ -159,6 +160,10 @@ func (n nearCustomTextParams) SimilarityMetricProvided() bool {
 	return n.Certainty != 0 || n.WithDistance
 }
 
+func (n nearCustomTextParams) GetTargetVector() string {
+	return n.TargetVector
+}
+
 type nearExploreMove struct {
 	Values  []string
 	Force   float32
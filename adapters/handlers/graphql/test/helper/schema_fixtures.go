@@ -59,6 +59,26 @@ func CreateSimpleSchema(vectorizer string) schema.Schema {
 							Name:     "phone",
 							DataType: []string{"phoneNumber"},
 						},
+						{
+							Name:     "address",
+							DataType: []string{"object"},
+							NestedProperties: []*models.Property{
+								{
+									Name:     "city",
+									DataType: []string{"string"},
+								},
+								{
+									Name:     "pets",
+									DataType: []string{"object[]"},
+									NestedProperties: []*models.Property{
+										{
+											Name:     "name",
+											DataType: []string{"text"},
+										},
+									},
+								},
+							},
+						},
 						{
 							Name:     "hasAction",
 							DataType: []string{"SomeAction"},
@@ -50,7 +50,11 @@ func (f fakeVectorConfig) IndexType() string {
 	return "fake"
 }
 
-func dummyParseVectorConfig(in interface{}) (schemaent.VectorIndexConfig, error) {
+func (f fakeVectorConfig) DistanceName() string {
+	return "fake"
+}
+
+func dummyParseVectorConfig(in interface{}, vectorIndexType string) (schemaent.VectorIndexConfig, error) {
 	return fakeVectorConfig(in.(map[string]interface{})), nil
 }
 
@@ -158,6 +162,24 @@ func (n *NilMigrator) UpdateShardStatus(ctx context.Context, className, shardNam
 	return nil
 }
 
+func (n *NilMigrator) TransferShards(ctx context.Context, className string,
+	transfers []sharding.Transfer,
+) error {
+	return nil
+}
+
+func (n *NilMigrator) LoadShards(ctx context.Context, className string,
+	state *sharding.State,
+) error {
+	return nil
+}
+
+func (n *NilMigrator) UnloadShards(ctx context.Context, className string,
+	previous *sharding.State,
+) error {
+	return nil
+}
+
 func (n *NilMigrator) AddProperty(ctx context.Context, className string, prop *models.Property) error {
 	return nil
 }
@@ -174,6 +196,14 @@ func (n *NilMigrator) DropProperty(ctx context.Context, className string, propNa
 	return nil
 }
 
+func (n *NilMigrator) ReindexProperty(ctx context.Context, className string, previous, updated *models.Property) error {
+	return nil
+}
+
+func (n *NilMigrator) GetShardsReindexStatus(ctx context.Context, className string) (map[string][]*models.PropertyReindexStatus, error) {
+	return nil, nil
+}
+
 func (n *NilMigrator) ValidateVectorIndexConfigUpdate(ctx context.Context, old, updated schemaent.VectorIndexConfig) error {
 	return nil
 }
@@ -182,6 +212,10 @@ func (n *NilMigrator) UpdateVectorIndexConfig(ctx context.Context, className str
 	return nil
 }
 
+func (n *NilMigrator) UpdateVectorIndexConfigs(ctx context.Context, className string, updated map[string]schemaent.VectorIndexConfig) error {
+	return nil
+}
+
 func (n *NilMigrator) ValidateInvertedIndexConfigUpdate(ctx context.Context, old, updated *models.InvertedIndexConfig) error {
 	return nil
 }
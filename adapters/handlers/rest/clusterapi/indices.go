@@ -24,7 +24,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
+	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/searchparams"
 	"github.com/semi-technologies/weaviate/entities/storobj"
@@ -40,6 +42,9 @@ type indices struct {
 	regexpObject              *regexp.Regexp
 	regexpReferences          *regexp.Regexp
 	regexpShards              *regexp.Regexp
+	regexpShardReindex        *regexp.Regexp
+	regexpShardBackup         *regexp.Regexp
+	regexpShardBackupFiles    *regexp.Regexp
 }
 
 const (
@@ -57,6 +62,12 @@ const (
 		`\/shards\/([A-Za-z0-9]+)\/references`
 	urlPatternShards = `\/indices\/([A-Za-z0-9_+-]+)` +
 		`\/shards\/([A-Za-z0-9]+)\/_status`
+	urlPatternShardReindex = `\/indices\/([A-Za-z0-9_+-]+)` +
+		`\/shards\/([A-Za-z0-9]+)\/_reindex`
+	urlPatternShardBackup = `\/indices\/([A-Za-z0-9_+-]+)` +
+		`\/shards\/([A-Za-z0-9]+)\/_backup`
+	urlPatternShardBackupFiles = `\/indices\/([A-Za-z0-9_+-]+)` +
+		`\/shards\/([A-Za-z0-9]+)\/files\/(.+)`
 )
 
 type shards interface {
@@ -78,7 +89,7 @@ type shards interface {
 	MultiGetObjects(ctx context.Context, indexName, shardName string,
 		id []strfmt.UUID) ([]*storobj.Object, error)
 	Search(ctx context.Context, indexName, shardName string,
-		vector []float32, distance float32, limit int, filters *filters.LocalFilter,
+		vector []float32, targetVector string, distance float32, limit int, filters *filters.LocalFilter,
 		keywordRanking *searchparams.KeywordRanking, sort []filters.Sort,
 		additional additional.Properties) ([]*storobj.Object, []float32, error)
 	Aggregate(ctx context.Context, indexName, shardName string,
@@ -88,8 +99,18 @@ type shards interface {
 	DeleteObjectBatch(ctx context.Context, indexName, shardName string,
 		docIDs []uint64, dryRun bool) objects.BatchSimpleObjects
 	GetShardStatus(ctx context.Context, indexName, shardName string) (string, error)
+	GetShardReindexStatus(ctx context.Context, indexName,
+		shardName string) ([]*models.PropertyReindexStatus, error)
 	UpdateShardStatus(ctx context.Context, indexName, shardName,
 		targetStatus string) error
+	CreateShardBackup(ctx context.Context, indexName, shardName,
+		snapshotID string) (*backup.ShardSnapshot, error)
+	ReleaseShardBackup(ctx context.Context, indexName, shardName,
+		snapshotID string) error
+	GetShardBackupFile(ctx context.Context, indexName, shardName,
+		relPath string) (io.ReadCloser, error)
+	PutShardBackupFile(ctx context.Context, indexName, shardName,
+		relPath string, r io.Reader) error
 }
 
 func NewIndices(shards shards) *indices {
@@ -101,6 +122,9 @@ func NewIndices(shards shards) *indices {
 		regexpObject:              regexp.MustCompile(urlPatternObject),
 		regexpReferences:          regexp.MustCompile(urlPatternReferences),
 		regexpShards:              regexp.MustCompile(urlPatternShards),
+		regexpShardReindex:        regexp.MustCompile(urlPatternShardReindex),
+		regexpShardBackup:         regexp.MustCompile(urlPatternShardBackup),
+		regexpShardBackupFiles:    regexp.MustCompile(urlPatternShardBackupFiles),
 		shards:                    shards,
 	}
 }
@@ -109,6 +133,19 @@ func (i *indices) Indices() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
 		switch {
+		// file paths are arbitrary and could contain any of the other patterns,
+		// so they need to be matched first
+		case i.regexpShardBackupFiles.MatchString(path):
+			if r.Method == http.MethodGet {
+				i.getShardBackupFile().ServeHTTP(w, r)
+				return
+			}
+			if r.Method == http.MethodPut {
+				i.putShardBackupFile().ServeHTTP(w, r)
+				return
+			}
+			http.Error(w, "405 Method not Allowed", http.StatusMethodNotAllowed)
+			return
 		case i.regexpObjectsSearch.MatchString(path):
 			if r.Method != http.MethodPost {
 				http.Error(w, "405 Method not Allowed", http.StatusMethodNotAllowed)
@@ -187,6 +224,27 @@ func (i *indices) Indices() http.Handler {
 			http.Error(w, "405 Method not Allowed", http.StatusMethodNotAllowed)
 			return
 
+		case i.regexpShardReindex.MatchString(path):
+			if r.Method != http.MethodGet {
+				http.Error(w, "405 Method not Allowed", http.StatusMethodNotAllowed)
+				return
+			}
+
+			i.getShardReindexStatus().ServeHTTP(w, r)
+			return
+
+		case i.regexpShardBackup.MatchString(path):
+			if r.Method == http.MethodPost {
+				i.postCreateShardBackup().ServeHTTP(w, r)
+				return
+			}
+			if r.Method == http.MethodDelete {
+				i.deleteShardBackup().ServeHTTP(w, r)
+				return
+			}
+			http.Error(w, "405 Method not Allowed", http.StatusMethodNotAllowed)
+			return
+
 		default:
 			http.NotFound(w, r)
 			return
@@ -504,7 +562,7 @@ func (i *indices) postSearchObjects() http.Handler {
 			return
 		}
 
-		vector, certainty, limit, filters, keywordRanking, sort, additional, err := IndicesPayloads.SearchParams.
+		vector, targetVector, certainty, limit, filters, keywordRanking, sort, additional, err := IndicesPayloads.SearchParams.
 			Unmarshal(reqPayload)
 		if err != nil {
 			http.Error(w, "unmarshal search params from json: "+err.Error(),
@@ -513,7 +571,7 @@ func (i *indices) postSearchObjects() http.Handler {
 		}
 
 		results, dists, err := i.shards.Search(r.Context(), index, shard,
-			vector, certainty, limit, filters, keywordRanking, sort, additional)
+			vector, targetVector, certainty, limit, filters, keywordRanking, sort, additional)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -744,6 +802,35 @@ func (i *indices) getGetShardStatus() http.Handler {
 	})
 }
 
+func (i *indices) getShardReindexStatus() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		args := i.regexpShardReindex.FindStringSubmatch(r.URL.Path)
+		if len(args) != 3 {
+			http.Error(w, "invalid URI", http.StatusBadRequest)
+			return
+		}
+
+		index, shard := args[1], args[2]
+
+		defer r.Body.Close()
+
+		status, err := i.shards.GetShardReindexStatus(r.Context(), index, shard)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		statusBytes, err := IndicesPayloads.GetShardReindexStatusResults.Marshal(status)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		IndicesPayloads.GetShardReindexStatusResults.SetContentTypeHeader(w)
+		w.Write(statusBytes)
+	})
+}
+
 func (i *indices) postUpdateShardStatus() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		args := i.regexpShards.FindStringSubmatch(r.URL.Path)
@@ -785,3 +872,132 @@ func (i *indices) postUpdateShardStatus() http.Handler {
 		}
 	})
 }
+
+func (i *indices) postCreateShardBackup() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		args := i.regexpShardBackup.FindStringSubmatch(r.URL.Path)
+		if len(args) != 3 {
+			http.Error(w, "invalid URI", http.StatusBadRequest)
+			return
+		}
+
+		index, shard := args[1], args[2]
+
+		defer r.Body.Close()
+		reqPayload, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "read request body: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		ct, ok := IndicesPayloads.CreateShardBackupParams.CheckContentTypeHeaderReq(r)
+		if !ok {
+			http.Error(w, errors.Errorf("unexpected content type: %s", ct).Error(),
+				http.StatusUnsupportedMediaType)
+			return
+		}
+
+		snapshotID, err := IndicesPayloads.CreateShardBackupParams.
+			Unmarshal(reqPayload)
+		if err != nil {
+			http.Error(w, "unmarshal create shard backup params from json: "+err.Error(),
+				http.StatusBadRequest)
+			return
+		}
+
+		snap, err := i.shards.CreateShardBackup(r.Context(), index, shard, snapshotID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		snapBytes, err := IndicesPayloads.ShardSnapshot.Marshal(snap)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		IndicesPayloads.ShardSnapshot.SetContentTypeHeader(w)
+		w.Write(snapBytes)
+	})
+}
+
+func (i *indices) deleteShardBackup() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		args := i.regexpShardBackup.FindStringSubmatch(r.URL.Path)
+		if len(args) != 3 {
+			http.Error(w, "invalid URI", http.StatusBadRequest)
+			return
+		}
+
+		index, shard := args[1], args[2]
+		snapshotID := r.URL.Query().Get("id")
+		if snapshotID == "" {
+			http.Error(w, "missing snapshot id", http.StatusBadRequest)
+			return
+		}
+
+		defer r.Body.Close()
+
+		err := i.shards.ReleaseShardBackup(r.Context(), index, shard, snapshotID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (i *indices) getShardBackupFile() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		args := i.regexpShardBackupFiles.FindStringSubmatch(r.URL.Path)
+		if len(args) != 4 {
+			http.Error(w, "invalid URI", http.StatusBadRequest)
+			return
+		}
+
+		index, shard, relPath := args[1], args[2], args[3]
+
+		defer r.Body.Close()
+
+		file, err := i.shards.GetShardBackupFile(r.Context(), index, shard, relPath)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer file.Close()
+
+		IndicesPayloads.ShardBackupFile.SetContentTypeHeader(w)
+		io.Copy(w, file)
+	})
+}
+
+func (i *indices) putShardBackupFile() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		args := i.regexpShardBackupFiles.FindStringSubmatch(r.URL.Path)
+		if len(args) != 4 {
+			http.Error(w, "invalid URI", http.StatusBadRequest)
+			return
+		}
+
+		index, shard, relPath := args[1], args[2], args[3]
+
+		defer r.Body.Close()
+
+		ct, ok := IndicesPayloads.ShardBackupFile.CheckContentTypeHeaderReq(r)
+		if !ok {
+			http.Error(w, errors.Errorf("unexpected content type: %s", ct).Error(),
+				http.StatusUnsupportedMediaType)
+			return
+		}
+
+		err := i.shards.PutShardBackupFile(r.Context(), index, shard, relPath, r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
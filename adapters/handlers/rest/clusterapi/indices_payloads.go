@@ -22,7 +22,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
+	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/searchparams"
 	"github.com/semi-technologies/weaviate/entities/storobj"
 	"github.com/semi-technologies/weaviate/usecases/objects"
@@ -31,23 +33,27 @@ import (
 var IndicesPayloads = indicesPayloads{}
 
 type indicesPayloads struct {
-	ErrorList                 errorListPayload
-	SingleObject              singleObjectPayload
-	MergeDoc                  mergeDocPayload
-	ObjectList                objectListPayload
-	SearchResults             searchResultsPayload
-	SearchParams              searchParamsPayload
-	ReferenceList             referenceListPayload
-	AggregationParams         aggregationParamsPayload
-	AggregationResult         aggregationResultPayload
-	FindDocIDsParams          findDocIDsParamsPayload
-	FindDocIDsResults         findDocIDsResultsPayload
-	BatchDeleteParams         batchDeleteParamsPayload
-	BatchDeleteResults        batchDeleteResultsPayload
-	GetShardStatusParams      getShardStatusParamsPayload
-	GetShardStatusResults     getShardStatusResultsPayload
-	UpdateShardStatusParams   updateShardStatusParamsPayload
-	UpdateShardsStatusResults updateShardsStatusResultsPayload
+	ErrorList                    errorListPayload
+	SingleObject                 singleObjectPayload
+	MergeDoc                     mergeDocPayload
+	ObjectList                   objectListPayload
+	SearchResults                searchResultsPayload
+	SearchParams                 searchParamsPayload
+	ReferenceList                referenceListPayload
+	AggregationParams            aggregationParamsPayload
+	AggregationResult            aggregationResultPayload
+	FindDocIDsParams             findDocIDsParamsPayload
+	FindDocIDsResults            findDocIDsResultsPayload
+	BatchDeleteParams            batchDeleteParamsPayload
+	BatchDeleteResults           batchDeleteResultsPayload
+	GetShardStatusParams         getShardStatusParamsPayload
+	GetShardStatusResults        getShardStatusResultsPayload
+	GetShardReindexStatusResults getShardReindexStatusResultsPayload
+	UpdateShardStatusParams      updateShardStatusParamsPayload
+	UpdateShardsStatusResults    updateShardsStatusResultsPayload
+	CreateShardBackupParams      createShardBackupParamsPayload
+	ShardSnapshot                shardSnapshotPayload
+	ShardBackupFile              shardBackupFilePayload
 }
 
 type errorListPayload struct{}
@@ -229,12 +235,13 @@ func (p mergeDocPayload) Unmarshal(in []byte) (objects.MergeDocument, error) {
 
 type searchParamsPayload struct{}
 
-func (p searchParamsPayload) Marshal(vector []float32, limit int,
+func (p searchParamsPayload) Marshal(vector []float32, targetVector string, limit int,
 	filter *filters.LocalFilter, keywordRanking *searchparams.KeywordRanking,
 	sort []filters.Sort, addP additional.Properties,
 ) ([]byte, error) {
 	type params struct {
 		SearchVector   []float32                    `json:"searchVector"`
+		TargetVector   string                       `json:"targetVector"`
 		Limit          int                          `json:"limit"`
 		Filters        *filters.LocalFilter         `json:"filters"`
 		KeywordRanking *searchparams.KeywordRanking `json:"keywordRanking"`
@@ -242,15 +249,16 @@ func (p searchParamsPayload) Marshal(vector []float32, limit int,
 		Additional     additional.Properties        `json:"additional"`
 	}
 
-	par := params{vector, limit, filter, keywordRanking, sort, addP}
+	par := params{vector, targetVector, limit, filter, keywordRanking, sort, addP}
 	return json.Marshal(par)
 }
 
-func (p searchParamsPayload) Unmarshal(in []byte) ([]float32, float32, int,
+func (p searchParamsPayload) Unmarshal(in []byte) ([]float32, string, float32, int,
 	*filters.LocalFilter, *searchparams.KeywordRanking, []filters.Sort, additional.Properties, error,
 ) {
 	type searchParametersPayload struct {
 		SearchVector   []float32                    `json:"searchVector"`
+		TargetVector   string                       `json:"targetVector"`
 		Distance       float32                      `json:"distance"`
 		Limit          int                          `json:"limit"`
 		Filters        *filters.LocalFilter         `json:"filters"`
@@ -260,7 +268,7 @@ func (p searchParamsPayload) Unmarshal(in []byte) ([]float32, float32, int,
 	}
 	var par searchParametersPayload
 	err := json.Unmarshal(in, &par)
-	return par.SearchVector, par.Distance, par.Limit,
+	return par.SearchVector, par.TargetVector, par.Distance, par.Limit,
 		par.Filters, par.KeywordRanking, par.Sort, par.Additional, err
 }
 
@@ -592,6 +600,31 @@ func (p getShardStatusResultsPayload) CheckContentTypeHeader(r *http.Response) (
 	return ct, ct == p.MIME()
 }
 
+type getShardReindexStatusResultsPayload struct{}
+
+func (p getShardReindexStatusResultsPayload) Unmarshal(in []byte) ([]*models.PropertyReindexStatus, error) {
+	var out []*models.PropertyReindexStatus
+	err := json.Unmarshal(in, &out)
+	return out, err
+}
+
+func (p getShardReindexStatusResultsPayload) Marshal(in []*models.PropertyReindexStatus) ([]byte, error) {
+	return json.Marshal(in)
+}
+
+func (p getShardReindexStatusResultsPayload) MIME() string {
+	return "application/vnd.weaviate.getshardreindexstatusresults+json"
+}
+
+func (p getShardReindexStatusResultsPayload) SetContentTypeHeader(w http.ResponseWriter) {
+	w.Header().Set("content-type", p.MIME())
+}
+
+func (p getShardReindexStatusResultsPayload) CheckContentTypeHeader(r *http.Response) (string, bool) {
+	ct := r.Header.Get("content-type")
+	return ct, ct == p.MIME()
+}
+
 type updateShardStatusParamsPayload struct{}
 
 func (p updateShardStatusParamsPayload) Marshal(targetStatus string) ([]byte, error) {
@@ -639,3 +672,89 @@ func (p updateShardsStatusResultsPayload) CheckContentTypeHeader(r *http.Respons
 	ct := r.Header.Get("content-type")
 	return ct, ct == p.MIME()
 }
+
+type createShardBackupParamsPayload struct{}
+
+func (p createShardBackupParamsPayload) Marshal(snapshotID string) ([]byte, error) {
+	type params struct {
+		SnapshotID string `json:"snapshotId"`
+	}
+
+	par := params{snapshotID}
+	return json.Marshal(par)
+}
+
+func (p createShardBackupParamsPayload) Unmarshal(in []byte) (string, error) {
+	type params struct {
+		SnapshotID string `json:"snapshotId"`
+	}
+	var par params
+	err := json.Unmarshal(in, &par)
+	return par.SnapshotID, err
+}
+
+func (p createShardBackupParamsPayload) MIME() string {
+	return "vnd.weaviate.createshardbackupparams+json"
+}
+
+func (p createShardBackupParamsPayload) CheckContentTypeHeaderReq(r *http.Request) (string, bool) {
+	ct := r.Header.Get("content-type")
+	return ct, ct == p.MIME()
+}
+
+func (p createShardBackupParamsPayload) SetContentTypeHeaderReq(r *http.Request) {
+	r.Header.Set("content-type", p.MIME())
+}
+
+type shardSnapshotPayload struct{}
+
+func (p shardSnapshotPayload) Marshal(in *backup.ShardSnapshot) ([]byte, error) {
+	return json.Marshal(in)
+}
+
+func (p shardSnapshotPayload) Unmarshal(in []byte) (*backup.ShardSnapshot, error) {
+	var out backup.ShardSnapshot
+	if err := json.Unmarshal(in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (p shardSnapshotPayload) MIME() string {
+	return "application/vnd.weaviate.shardsnapshot+json"
+}
+
+func (p shardSnapshotPayload) SetContentTypeHeader(w http.ResponseWriter) {
+	w.Header().Set("content-type", p.MIME())
+}
+
+func (p shardSnapshotPayload) CheckContentTypeHeader(r *http.Response) (string, bool) {
+	ct := r.Header.Get("content-type")
+	return ct, ct == p.MIME()
+}
+
+// shardBackupFilePayload is the raw content of a single file of a shard. It
+// is streamed as-is in both directions, so there is no (un)marshalling.
+type shardBackupFilePayload struct{}
+
+func (p shardBackupFilePayload) MIME() string {
+	return "application/vnd.weaviate.shardbackupfile+octet-stream"
+}
+
+func (p shardBackupFilePayload) SetContentTypeHeader(w http.ResponseWriter) {
+	w.Header().Set("content-type", p.MIME())
+}
+
+func (p shardBackupFilePayload) SetContentTypeHeaderReq(r *http.Request) {
+	r.Header.Set("content-type", p.MIME())
+}
+
+func (p shardBackupFilePayload) CheckContentTypeHeader(r *http.Response) (string, bool) {
+	ct := r.Header.Get("content-type")
+	return ct, ct == p.MIME()
+}
+
+func (p shardBackupFilePayload) CheckContentTypeHeaderReq(r *http.Request) (string, bool) {
+	ct := r.Header.Get("content-type")
+	return ct, ct == p.MIME()
+}
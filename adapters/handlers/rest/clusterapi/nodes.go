@@ -0,0 +1,65 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package clusterapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+type nodesManager interface {
+	LocalNodeStatus() *models.NodeStatus
+}
+
+type nodes struct {
+	manager nodesManager
+}
+
+func NewNodes(manager nodesManager) *nodes {
+	return &nodes{manager: manager}
+}
+
+func (n *nodes) Nodes() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes/status":
+			if r.Method != http.MethodGet {
+				http.Error(w, "405 Method not Allowed", http.StatusMethodNotAllowed)
+				return
+			}
+
+			n.incomingNodeStatus().ServeHTTP(w, r)
+			return
+		default:
+			http.NotFound(w, r)
+			return
+		}
+	})
+}
+
+func (n *nodes) incomingNodeStatus() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+
+		status := n.manager.LocalNodeStatus()
+		resBytes, err := json.Marshal(status)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("content-type", "application/json")
+		w.Write(resBytes)
+	})
+}
@@ -31,6 +31,7 @@ func Serve(appState *state.State) {
 	schema := NewSchema(appState.SchemaManager.TxManager())
 	indices := NewIndices(appState.RemoteIncoming)
 	classifications := NewClassifications(appState.ClassificationRepo.TxManager())
+	nodes := NewNodes(appState.NodesManager)
 
 	mux := http.NewServeMux()
 	mux.Handle("/schema/transactions/",
@@ -38,6 +39,7 @@ func Serve(appState *state.State) {
 	mux.Handle("/classifications/transactions/",
 		http.StripPrefix("/classifications/transactions/",
 			classifications.Transactions()))
+	mux.Handle("/nodes/", nodes.Nodes())
 
 	mux.Handle("/indices/", indices.Indices())
 	mux.Handle("/", schema.index())
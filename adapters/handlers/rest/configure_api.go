@@ -35,7 +35,6 @@ import (
 	"github.com/semi-technologies/weaviate/adapters/repos/classifications"
 	"github.com/semi-technologies/weaviate/adapters/repos/db"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted"
-	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	modulestorage "github.com/semi-technologies/weaviate/adapters/repos/modules"
 	schemarepo "github.com/semi-technologies/weaviate/adapters/repos/schema"
 	"github.com/semi-technologies/weaviate/entities/models"
@@ -46,6 +45,7 @@ import (
 	modner "github.com/semi-technologies/weaviate/modules/ner-transformers"
 	modqna "github.com/semi-technologies/weaviate/modules/qna-transformers"
 	modstgs3 "github.com/semi-technologies/weaviate/modules/storage-aws-s3"
+	modstgazure "github.com/semi-technologies/weaviate/modules/storage-azure"
 	modstgfs "github.com/semi-technologies/weaviate/modules/storage-filesystem"
 	modstggcs "github.com/semi-technologies/weaviate/modules/storage-gcs"
 	modsum "github.com/semi-technologies/weaviate/modules/sum-transformers"
@@ -59,6 +59,7 @@ import (
 	"github.com/semi-technologies/weaviate/usecases/config"
 	"github.com/semi-technologies/weaviate/usecases/modules"
 	"github.com/semi-technologies/weaviate/usecases/monitoring"
+	"github.com/semi-technologies/weaviate/usecases/nodes"
 	"github.com/semi-technologies/weaviate/usecases/objects"
 	schemaUC "github.com/semi-technologies/weaviate/usecases/schema"
 	"github.com/semi-technologies/weaviate/usecases/schema/migrate"
@@ -158,6 +159,7 @@ func configureAPI(api *operations.WeaviateAPI) http.Handler {
 		RootPath:                  appState.ServerConfig.Config.Persistence.DataPath,
 		QueryLimit:                appState.ServerConfig.Config.QueryDefaults.Limit,
 		QueryMaximumResults:       appState.ServerConfig.Config.QueryMaximumResults,
+		HybridCandidates:          appState.ServerConfig.Config.HybridCandidates,
 		DiskUseWarningPercentage:  appState.ServerConfig.Config.DiskUse.WarningPercentage,
 		DiskUseReadOnlyPercentage: appState.ServerConfig.Config.DiskUse.ReadOnlyPercentage,
 		MaxImportGoroutinesFactor: appState.ServerConfig.Config.MaxImportGoroutinesFactor,
@@ -195,7 +197,7 @@ func configureAPI(api *operations.WeaviateAPI) http.Handler {
 	schemaTxClient := clients.NewClusterSchema(clusterHttpClient)
 	schemaManager, err := schemaUC.NewManager(migrator, schemaRepo,
 		appState.Logger, appState.Authorizer, appState.ServerConfig.Config,
-		hnsw.ParseUserConfig, appState.Modules, inverted.ValidateConfig, appState.Modules, appState.Cluster,
+		db.ParseVectorIndexConfig, appState.Modules, inverted.ValidateConfig, appState.Modules, appState.Cluster,
 		schemaTxClient)
 	if err != nil {
 		appState.Logger.
@@ -206,6 +208,8 @@ func configureAPI(api *operations.WeaviateAPI) http.Handler {
 	appState.SchemaManager = schemaManager
 
 	appState.RemoteIncoming = sharding.NewRemoteIndexIncoming(repo)
+	appState.NodesManager = nodes.NewManager(appState.Logger, appState.Authorizer,
+		repo, schemaManager, appState.Cluster, clients.NewClusterNodes(clusterHttpClient))
 
 	go clusterapi.Serve(appState)
 
@@ -247,6 +251,7 @@ func configureAPI(api *operations.WeaviateAPI) http.Handler {
 	setupMiscHandlers(api, appState.ServerConfig, schemaManager, appState.Modules)
 	setupClassificationHandlers(api, classifier)
 	setupBackupHandlers(api, schemaManager, repo, appState)
+	setupNodesHandlers(api, appState.NodesManager)
 
 	api.ServerShutdown = func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
@@ -495,6 +500,14 @@ func registerModules(appState *state.State) error {
 			Debug("enabled module")
 	}
 
+	if _, ok := enabledModules[modstgazure.Name]; ok {
+		appState.Modules.Register(modstgazure.New())
+		appState.Logger.
+			WithField("action", "startup").
+			WithField("module", modstgazure.Name).
+			Debug("enabled module")
+	}
+
 	appState.Logger.
 		WithField("action", "startup").
 		Debug("completed registering modules")
@@ -134,6 +134,57 @@ func init() {
       }
     },
     "/backups/{storageName}": {
+      "get": {
+        "description": "Lists all backups on the storage",
+        "tags": [
+          "backups"
+        ],
+        "operationId": "backups.list",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Storage name e.g. filesystem, gcs, s3.",
+            "name": "storageName",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Existing backups successfully returned",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/BackupListItem"
+              }
+            }
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "422": {
+            "description": "Invalid backup listing attempt.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-serviceIds": [
+          "weaviate.local.backup"
+        ]
+      },
       "post": {
         "description": "Starts a process of creating a backup for a set of classes",
         "tags": [
@@ -192,6 +243,64 @@ func init() {
       }
     },
     "/backups/{storageName}/{id}": {
+      "delete": {
+        "description": "Deletes a backup from the storage",
+        "tags": [
+          "backups"
+        ],
+        "operationId": "backups.delete",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Storage name e.g. filesystem, gcs, s3.",
+            "name": "storageName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The ID of a backup. Must be URL-safe and work as a filesystem path, only lowercase, numbers, underscore, minus characters allowed.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Backup successfully deleted."
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "404": {
+            "description": "Not Found - Backup does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "422": {
+            "description": "Invalid backup deletion attempt.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-serviceIds": [
+          "weaviate.local.backup"
+        ]
+      },
       "get": {
         "description": "Returns status of backup creation attempt for a set of classes",
         "tags": [
@@ -248,6 +357,66 @@ func init() {
         ]
       }
     },
+    "/backups/{storageName}/{id}/cancel": {
+      "post": {
+        "description": "Cancels an in-flight backup creation or restoration",
+        "tags": [
+          "backups"
+        ],
+        "operationId": "backups.cancel",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Storage name e.g. filesystem, gcs, s3.",
+            "name": "storageName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The ID of a backup. Must be URL-safe and work as a filesystem path, only lowercase, numbers, underscore, minus characters allowed.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Backup creation or restoration successfully canceled."
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "404": {
+            "description": "Not Found - No backup creation or restoration in progress",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "422": {
+            "description": "Invalid backup cancellation attempt.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-serviceIds": [
+          "weaviate.local.backup"
+        ]
+      }
+    },
     "/backups/{storageName}/{id}/restore": {
       "get": {
         "description": "Returns status of a backup restoration attempt for a set of classes",
@@ -825,6 +994,44 @@ func init() {
         ]
       }
     },
+    "/nodes": {
+      "get": {
+        "description": "Returns the status of all nodes in the cluster, including their version, the shards they hold and the number of objects in each of them.",
+        "tags": [
+          "nodes"
+        ],
+        "summary": "Node status of the cluster.",
+        "operationId": "nodes.get",
+        "responses": {
+          "200": {
+            "description": "Successful response.",
+            "schema": {
+              "$ref": "#/definitions/NodesStatusResponse"
+            }
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-available-in-mqtt": false,
+        "x-available-in-websocket": false,
+        "x-serviceIds": [
+          "weaviate.local.query.meta"
+        ]
+      }
+    },
     "/objects": {
       "get": {
         "description": "Lists all Objects in reverse order of creation, owned by the user that belongs to the used token.",
@@ -2148,7 +2355,7 @@ func init() {
         ]
       },
       "put": {
-        "description": "Use this endpoint to alter an existing class in the schema. Note that not all settings are mutable. If an error about immutable fields is returned and you still need to update this particular setting, you will have to delete the class (and the underlying data) and recreate. This endpoint cannot be used to modify properties. Instead use POST /v1/schema/{className}/properties. A typical use case for this endpoint is to update configuration, such as the vectorIndexConfig. Note that even in mutable sections, such as vectorIndexConfig, some fields may be immutable.",
+        "description": "Use this endpoint to alter an existing class in the schema. Note that not all settings are mutable. If an error about immutable fields is returned and you still need to update this particular setting, you will have to delete the class (and the underlying data) and recreate. This endpoint cannot be used to add or remove properties. Instead use POST /v1/schema/{className}/properties. The tokenization and indexInverted settings of existing properties can be changed, the property is then reindexed in the background while queries keep using its previous index. The progress is reported by GET /v1/schema/{className}/shards. A typical use case for this endpoint is to update configuration, such as the vectorIndexConfig. Note that even in mutable sections, such as vectorIndexConfig, some fields may be immutable.",
         "tags": [
           "schema"
         ],
@@ -2311,6 +2518,58 @@ func init() {
         ]
       }
     },
+    "/schema/{className}/properties/{propertyName}": {
+      "delete": {
+        "tags": [
+          "schema"
+        ],
+        "summary": "Remove a property from an Object class. The values of the property are removed from all objects of the class.",
+        "operationId": "schema.objects.properties.delete",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "className",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "propertyName",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Removed the property from the Object class."
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "422": {
+            "description": "Could not delete the property.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-serviceIds": [
+          "weaviate.local.manipulate.meta"
+        ]
+      }
+    },
     "/schema/{className}/shards": {
       "get": {
         "tags": [
@@ -2360,40 +2619,26 @@ func init() {
         ]
       }
     },
-    "/schema/{className}/shards/{shardName}": {
-      "put": {
-        "description": "Update shard status of an Object Class",
+    "/schema/{className}/shards/rebalance": {
+      "post": {
+        "description": "Spread the shards of an Object Class evenly across all nodes of the cluster. Shards are split or moved to nodes which hold fewer shards than others, for example after nodes have been added. The shards being copied are read-only until the rebalancing completes.",
         "tags": [
           "schema"
         ],
-        "operationId": "schema.objects.shards.update",
+        "operationId": "schema.objects.shards.rebalance",
         "parameters": [
           {
             "type": "string",
             "name": "className",
             "in": "path",
             "required": true
-          },
-          {
-            "type": "string",
-            "name": "shardName",
-            "in": "path",
-            "required": true
-          },
-          {
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/ShardStatus"
-            }
           }
         ],
         "responses": {
           "200": {
-            "description": "Shard status was updated successfully",
+            "description": "The shards were rebalanced, the copies which have been made are returned as body",
             "schema": {
-              "$ref": "#/definitions/ShardStatus"
+              "$ref": "#/definitions/ShardTransferList"
             }
           },
           "401": {
@@ -2406,7 +2651,76 @@ func init() {
             }
           },
           "404": {
-            "description": "Shard to be updated does not exist",
+            "description": "This class does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "422": {
+            "description": "The shards cannot be rebalanced, for example because a node holding one of them is not available or not all of them are ready",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-serviceIds": [
+          "weaviate.local.manipulate.meta"
+        ]
+      }
+    },
+    "/schema/{className}/shards/{shardName}": {
+      "put": {
+        "description": "Update shard status of an Object Class",
+        "tags": [
+          "schema"
+        ],
+        "operationId": "schema.objects.shards.update",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "className",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "shardName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ShardStatus"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Shard status was updated successfully",
+            "schema": {
+              "$ref": "#/definitions/ShardStatus"
+            }
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "404": {
+            "description": "Shard to be updated does not exist",
             "schema": {
               "$ref": "#/definitions/ErrorResponse"
             }
@@ -2472,6 +2786,10 @@ func init() {
           "description": "The ID of the backup. Must be URL-safe and work as a filesystem path, only lowercase, numbers, underscore, minus characters allowed.",
           "type": "string"
         },
+        "parent": {
+          "description": "The ID of the parent backup, if this is an incremental backup",
+          "type": "string"
+        },
         "path": {
           "description": "destination path of backup files proper to selected storage",
           "type": "string"
@@ -2485,7 +2803,8 @@ func init() {
             "TRANSFERRING",
             "TRANSFERRED",
             "SUCCESS",
-            "FAILED"
+            "FAILED",
+            "CANCELED"
           ]
         },
         "storageName": {
@@ -2497,6 +2816,15 @@ func init() {
     "BackupCreateRequest": {
       "description": "Request body for creating a backup of a set of classes",
       "properties": {
+        "compression": {
+          "description": "Compression of the backup files. Unless set to none, the files are streamed into compressed chunks on the storage.",
+          "type": "string",
+          "enum": [
+            "none",
+            "gzip",
+            "zstd"
+          ]
+        },
         "config": {
           "description": "Custom configuration for the backup creation process",
           "type": "object"
@@ -2518,6 +2846,52 @@ func init() {
           "items": {
             "type": "string"
           }
+        },
+        "parent": {
+          "description": "The ID of an existing backup to use as the parent of an incremental backup. Files which did not change since the parent backup are not uploaded again, but referenced.",
+          "type": "string"
+        }
+      }
+    },
+    "BackupListItem": {
+      "description": "A backup which exists on a storage",
+      "properties": {
+        "classes": {
+          "description": "The list of classes contained in the backup",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "error": {
+          "description": "error message if the backup failed",
+          "type": "string"
+        },
+        "id": {
+          "description": "The ID of the backup. Must be URL-safe and work as a filesystem path, only lowercase, numbers, underscore, minus characters allowed.",
+          "type": "string"
+        },
+        "parent": {
+          "description": "The ID of the parent backup, if this is an incremental backup",
+          "type": "string"
+        },
+        "size": {
+          "description": "size of the backup in bytes, files referenced from a parent backup excluded",
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "description": "phase of backup creation process",
+          "type": "string",
+          "default": "STARTED",
+          "enum": [
+            "STARTED",
+            "TRANSFERRING",
+            "TRANSFERRED",
+            "SUCCESS",
+            "FAILED",
+            "CANCELED"
+          ]
         }
       }
     },
@@ -2552,7 +2926,8 @@ func init() {
             "TRANSFERRING",
             "TRANSFERRED",
             "SUCCESS",
-            "FAILED"
+            "FAILED",
+            "CANCELED"
           ]
         },
         "storageName": {
@@ -2585,6 +2960,13 @@ func init() {
           "items": {
             "type": "string"
           }
+        },
+        "rename": {
+          "description": "Map of class names in the backup to the names under which they are restored. Classes which are not listed keep their original name. Cross-references to renamed classes are renamed as well.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
         }
       }
     },
@@ -2918,6 +3300,13 @@ func init() {
           "description": "Manage how the index should be sharded and distributed in the cluster",
           "type": "object"
         },
+        "vectorConfig": {
+          "description": "Named vectors of the class in addition to its regular vector, each with its own vectorizer and vector index",
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/definitions/VectorConfig"
+          }
+        },
         "vectorIndexConfig": {
           "description": "Vector-index config, that is specific to the type of index selected in vectorIndexType",
           "type": "object"
@@ -3234,6 +3623,10 @@ func init() {
           "type": "number",
           "format": "int"
         },
+        "indexPositions": {
+          "description": "Index the positions of the terms of text and text[] properties, which is required for phrase queries. Enabling it on an existing class indexes the positions of all existing objects",
+          "type": "boolean"
+        },
         "indexTimestamps": {
           "description": "Index each object by its internal timestamps",
           "type": "boolean"
@@ -3294,6 +3687,87 @@ func init() {
         "$ref": "#/definitions/SingleRef"
       }
     },
+    "NodeShardStatus": {
+      "description": "The status of a single shard on a node",
+      "type": "object",
+      "properties": {
+        "class": {
+          "description": "Name of the class the shard belongs to",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the shard",
+          "type": "string"
+        },
+        "objectCount": {
+          "description": "Number of objects in the shard",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "NodeStats": {
+      "description": "Summary of the shards held by a node",
+      "type": "object",
+      "properties": {
+        "objectCount": {
+          "description": "Number of objects in all the shards held by the node",
+          "type": "integer",
+          "format": "int64"
+        },
+        "shardCount": {
+          "description": "Number of shards held by the node",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "NodeStatus": {
+      "description": "The status of a single node",
+      "type": "object",
+      "properties": {
+        "name": {
+          "description": "Name of the node",
+          "type": "string"
+        },
+        "shards": {
+          "description": "The shards held by the node",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NodeShardStatus"
+          }
+        },
+        "stats": {
+          "$ref": "#/definitions/NodeStats"
+        },
+        "status": {
+          "description": "Health of the node, UNAVAILABLE if it is not part of the cluster anymore, UNHEALTHY if it did not report its status",
+          "type": "string",
+          "enum": [
+            "HEALTHY",
+            "UNHEALTHY",
+            "UNAVAILABLE"
+          ]
+        },
+        "version": {
+          "description": "Version of Weaviate the node runs",
+          "type": "string"
+        }
+      }
+    },
+    "NodesStatusResponse": {
+      "description": "The status of all the nodes in the cluster",
+      "type": "object",
+      "properties": {
+        "nodes": {
+          "description": "The status of every node holding a shard or taking part in the cluster",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NodeStatus"
+          }
+        }
+      }
+    },
     "Object": {
       "type": "object",
       "properties": {
@@ -3328,6 +3802,10 @@ func init() {
         },
         "vectorWeights": {
           "$ref": "#/definitions/VectorWeights"
+        },
+        "vectors": {
+          "description": "This object's named vectors. Read-only for vectors using a vectorizer other than 'none'.",
+          "$ref": "#/definitions/Vectors"
         }
       }
     },
@@ -3549,6 +4027,9 @@ func init() {
     "Property": {
       "type": "object",
       "properties": {
+        "analyzer": {
+          "$ref": "#/definitions/PropertyAnalyzerConfig"
+        },
         "dataType": {
           "description": "Can be a reference to another type when it starts with a capital (for example Person), otherwise \"string\" or \"int\".",
           "type": "array",
@@ -3573,16 +4054,67 @@ func init() {
           "description": "Name of the property as URI relative to the schema URL.",
           "type": "string"
         },
+        "nestedProperties": {
+          "description": "The properties of the nested object(s). Required for the object and object[] data types and not supported for the remaining data types. Nested properties can be of the primitive data types except geoCoordinates, phoneNumber and blob, or be nested objects themselves",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Property"
+          },
+          "x-omitempty": true
+        },
         "tokenization": {
-          "description": "Determines tokenization of the property as separate words or whole field. Optional. Applies to string, string[], text and text[] data types. Allowed values are ` + "`" + `word` + "`" + ` (default) and ` + "`" + `field` + "`" + ` for string and string[], ` + "`" + `word` + "`" + ` (default) for text and text[]. Not supported for remaining data types",
+          "description": "Determines tokenization of the property as separate words or whole field. Optional. Applies to string, string[], text and text[] data types. Allowed values are ` + "`" + `word` + "`" + ` (default) and ` + "`" + `field` + "`" + ` for string and string[], ` + "`" + `word` + "`" + ` (default), ` + "`" + `trigram` + "`" + ` and ` + "`" + `cjk` + "`" + ` for text and text[]. ` + "`" + `trigram` + "`" + ` splits words into overlapping trigrams, which speeds up substring (like) filters. ` + "`" + `cjk` + "`" + ` splits runs of Chinese, Japanese and Korean characters into overlapping bigrams. Not supported for remaining data types",
           "type": "string",
           "enum": [
             "word",
-            "field"
+            "field",
+            "trigram",
+            "cjk"
           ]
         }
       }
     },
+    "PropertyAnalyzerConfig": {
+      "description": "Language-aware analysis of the values of a text or text[] property before they are added to the inverted index. The same analysis is applied to the values of where filters and keyword-based (bm25) searches on the property",
+      "type": "object",
+      "properties": {
+        "asciiFolding": {
+          "description": "Fold accented and other non-ASCII letters to their ASCII counterparts, e.g. \"Häuser\" becomes \"hauser\"",
+          "type": "boolean"
+        },
+        "language": {
+          "description": "Language of the values as a two-letter ISO 639-1 code, e.g. \"de\". It selects the stemmer and the default stopword preset of the property",
+          "type": "string"
+        },
+        "stemming": {
+          "description": "Reduce words to their stem with the Snowball stemmer of the language, e.g. \"Häuser\" and \"Haus\" both become \"haus\". Requires a language",
+          "type": "boolean"
+        },
+        "stopwordPreset": {
+          "description": "Stopword preset of the property, it overrides the stopwords of the class. Defaults to the preset of the language if one exists",
+          "type": "string"
+        }
+      }
+    },
+    "PropertyReindexStatus": {
+      "description": "The progress of reindexing a property of a shard",
+      "properties": {
+        "processedObjects": {
+          "description": "Number of objects which have been reindexed so far",
+          "type": "integer",
+          "format": "int64"
+        },
+        "property": {
+          "description": "Name of the property",
+          "type": "string"
+        },
+        "totalObjects": {
+          "description": "Number of objects which need to be reindexed",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "PropertySchema": {
       "description": "This is an open object, with OpenAPI Specification 3.0 this will be more detailed. See Weaviate docs for more info. In the future this will become a key/value OR a SingleRef definition.",
       "type": "object"
@@ -3687,6 +4219,13 @@ func init() {
           "description": "Name of the shard",
           "type": "string"
         },
+        "reindexing": {
+          "description": "The properties of the shard which are reindexed after their tokenization or indexInverted setting was changed",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PropertyReindexStatus"
+          }
+        },
         "status": {
           "description": "Status of the shard",
           "type": "string"
@@ -3700,6 +4239,30 @@ func init() {
         "$ref": "#/definitions/ShardStatusGetResponse"
       }
     },
+    "ShardTransfer": {
+      "description": "The copy of a shard to a node which did not hold it before",
+      "properties": {
+        "node": {
+          "description": "Name of the node the shard was copied to",
+          "type": "string"
+        },
+        "shard": {
+          "description": "Name of the shard which was copied",
+          "type": "string"
+        },
+        "targetShard": {
+          "description": "Name of the shard the copy was stored as, it differs from the copied shard if the shard was split",
+          "type": "string"
+        }
+      }
+    },
+    "ShardTransferList": {
+      "description": "The shard copies made to spread the shards of a Class across the nodes",
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/ShardTransfer"
+      }
+    },
     "SingleRef": {
       "description": "Either set beacon (direct reference) or set class and schema (concept reference)",
       "properties": {
@@ -3752,10 +4315,35 @@ func init() {
         }
       }
     },
+    "VectorConfig": {
+      "description": "Configuration of a single named vector of a class",
+      "type": "object",
+      "properties": {
+        "vectorIndexConfig": {
+          "description": "Vector-index config, that is specific to the type of index selected in vectorIndexType",
+          "type": "object"
+        },
+        "vectorIndexType": {
+          "description": "Name of the vector index to use, eg. (HNSW)",
+          "type": "string"
+        },
+        "vectorizer": {
+          "description": "The vectorizer of this vector, an object with the name of the module as its only key and the module config as its value, e.g. {\"text2vec-contextionary\": {\"vectorizeClassName\": false}}. Use {\"none\": {}} to import the vectors yourself.",
+          "type": "object"
+        }
+      }
+    },
     "VectorWeights": {
       "description": "Allow custom overrides of vector weights as math expressions. E.g. \"pancake\": \"7\" will set the weight for the word pancake to 7 in the vectorization, whereas \"w * 3\" would triple the originally calculated word. This is an open object, with OpenAPI Specification 3.0 this will be more detailed. See Weaviate docs for more info. In the future this will become a key/value (string/string) object.",
       "type": "object"
     },
+    "Vectors": {
+      "description": "A map of named vectors, one for each vector configured in the vectorConfig of the class",
+      "type": "object",
+      "additionalProperties": {
+        "$ref": "#/definitions/C11yVector"
+      }
+    },
     "WhereFilter": {
       "description": "Filter search results using a where filter",
       "type": "object",
@@ -3775,13 +4363,17 @@ func init() {
             "Or",
             "Equal",
             "Like",
+            "Phrase",
+            "Fuzzy",
             "Not",
             "NotEqual",
             "GreaterThan",
             "GreaterThanEqual",
             "LessThan",
             "LessThanEqual",
-            "WithinGeoRange"
+            "WithinGeoRange",
+            "ContainsAny",
+            "ContainsAll"
           ],
           "example": "GreaterThanEqual"
         },
@@ -3803,12 +4395,32 @@ func init() {
           "x-nullable": true,
           "example": false
         },
+        "valueBooleanArray": {
+          "description": "value as list of booleans (for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "boolean"
+          },
+          "example": [
+            true,
+            false
+          ],
+          "x-omitempty": true
+        },
         "valueDate": {
           "description": "value as date (as string)",
           "type": "string",
           "x-nullable": true,
           "example": "TODO"
         },
+        "valueDateArray": {
+          "description": "value as list of dates (as strings, for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
         "valueGeoRange": {
           "description": "value as geo coordinates and distance",
           "type": "object",
@@ -3822,6 +4434,19 @@ func init() {
           "x-nullable": true,
           "example": 2000
         },
+        "valueIntArray": {
+          "description": "value as list of integers (for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          },
+          "example": [
+            100,
+            200
+          ],
+          "x-omitempty": true
+        },
         "valueNumber": {
           "description": "value as number/float",
           "type": "number",
@@ -3829,17 +4454,70 @@ func init() {
           "x-nullable": true,
           "example": 3.14
         },
+        "valueNumberArray": {
+          "description": "value as list of numbers/floats (for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "number",
+            "format": "float64"
+          },
+          "example": [
+            3.14,
+            2.71
+          ],
+          "x-omitempty": true
+        },
         "valueString": {
           "description": "value as string",
           "type": "string",
           "x-nullable": true,
           "example": "my search term"
         },
+        "valueStringArray": {
+          "description": "value as list of strings (for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "example": [
+            "my",
+            "search",
+            "terms"
+          ],
+          "x-omitempty": true
+        },
         "valueText": {
           "description": "value as text (on text props)",
           "type": "string",
           "x-nullable": true,
           "example": "my search term"
+        },
+        "valueTextArray": {
+          "description": "value as list of texts (on text props, for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "example": [
+            "my",
+            "search",
+            "terms"
+          ],
+          "x-omitempty": true
+        },
+        "valueUuid": {
+          "description": "value as uuid (on uuid props)",
+          "type": "string",
+          "x-nullable": true,
+          "example": "28f3f61b-b524-45e0-9bbe-2c1550bf73d2"
+        },
+        "valueUuidArray": {
+          "description": "value as list of uuids (on uuid props, for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
         }
       }
     },
@@ -4030,30 +4708,81 @@ func init() {
                 }
               }
             }
-          },
-          "404": {
-            "description": "Not found, no oidc provider present"
+          },
+          "404": {
+            "description": "Not found, no oidc provider present"
+          }
+        },
+        "x-available-in-mqtt": false,
+        "x-available-in-websocket": false
+      }
+    },
+    "/.well-known/ready": {
+      "get": {
+        "description": "Determines whether the application is ready to receive traffic. Can be used for kubernetes readiness probe.",
+        "operationId": "weaviate.wellknown.readiness",
+        "responses": {
+          "200": {
+            "description": "The application has completed its start-up routine and is ready to accept traffic."
+          },
+          "503": {
+            "description": "The application is currently not able to serve traffic. If other horizontal replicas of weaviate are available and they are capable of receiving traffic, all traffic should be redirected there instead."
+          }
+        }
+      }
+    },
+    "/backups/{storageName}": {
+      "get": {
+        "description": "Lists all backups on the storage",
+        "tags": [
+          "backups"
+        ],
+        "operationId": "backups.list",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Storage name e.g. filesystem, gcs, s3.",
+            "name": "storageName",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Existing backups successfully returned",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/BackupListItem"
+              }
+            }
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "422": {
+            "description": "Invalid backup listing attempt.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
           }
         },
-        "x-available-in-mqtt": false,
-        "x-available-in-websocket": false
-      }
-    },
-    "/.well-known/ready": {
-      "get": {
-        "description": "Determines whether the application is ready to receive traffic. Can be used for kubernetes readiness probe.",
-        "operationId": "weaviate.wellknown.readiness",
-        "responses": {
-          "200": {
-            "description": "The application has completed its start-up routine and is ready to accept traffic."
-          },
-          "503": {
-            "description": "The application is currently not able to serve traffic. If other horizontal replicas of weaviate are available and they are capable of receiving traffic, all traffic should be redirected there instead."
-          }
-        }
-      }
-    },
-    "/backups/{storageName}": {
+        "x-serviceIds": [
+          "weaviate.local.backup"
+        ]
+      },
       "post": {
         "description": "Starts a process of creating a backup for a set of classes",
         "tags": [
@@ -4112,6 +4841,64 @@ func init() {
       }
     },
     "/backups/{storageName}/{id}": {
+      "delete": {
+        "description": "Deletes a backup from the storage",
+        "tags": [
+          "backups"
+        ],
+        "operationId": "backups.delete",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Storage name e.g. filesystem, gcs, s3.",
+            "name": "storageName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The ID of a backup. Must be URL-safe and work as a filesystem path, only lowercase, numbers, underscore, minus characters allowed.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Backup successfully deleted."
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "404": {
+            "description": "Not Found - Backup does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "422": {
+            "description": "Invalid backup deletion attempt.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-serviceIds": [
+          "weaviate.local.backup"
+        ]
+      },
       "get": {
         "description": "Returns status of backup creation attempt for a set of classes",
         "tags": [
@@ -4168,6 +4955,66 @@ func init() {
         ]
       }
     },
+    "/backups/{storageName}/{id}/cancel": {
+      "post": {
+        "description": "Cancels an in-flight backup creation or restoration",
+        "tags": [
+          "backups"
+        ],
+        "operationId": "backups.cancel",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Storage name e.g. filesystem, gcs, s3.",
+            "name": "storageName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The ID of a backup. Must be URL-safe and work as a filesystem path, only lowercase, numbers, underscore, minus characters allowed.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Backup creation or restoration successfully canceled."
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "404": {
+            "description": "Not Found - No backup creation or restoration in progress",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "422": {
+            "description": "Invalid backup cancellation attempt.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-serviceIds": [
+          "weaviate.local.backup"
+        ]
+      }
+    },
     "/backups/{storageName}/{id}/restore": {
       "get": {
         "description": "Returns status of a backup restoration attempt for a set of classes",
@@ -4745,6 +5592,44 @@ func init() {
         ]
       }
     },
+    "/nodes": {
+      "get": {
+        "description": "Returns the status of all nodes in the cluster, including their version, the shards they hold and the number of objects in each of them.",
+        "tags": [
+          "nodes"
+        ],
+        "summary": "Node status of the cluster.",
+        "operationId": "nodes.get",
+        "responses": {
+          "200": {
+            "description": "Successful response.",
+            "schema": {
+              "$ref": "#/definitions/NodesStatusResponse"
+            }
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-available-in-mqtt": false,
+        "x-available-in-websocket": false,
+        "x-serviceIds": [
+          "weaviate.local.query.meta"
+        ]
+      }
+    },
     "/objects": {
       "get": {
         "description": "Lists all Objects in reverse order of creation, owned by the user that belongs to the used token.",
@@ -6095,7 +6980,7 @@ func init() {
         ]
       },
       "put": {
-        "description": "Use this endpoint to alter an existing class in the schema. Note that not all settings are mutable. If an error about immutable fields is returned and you still need to update this particular setting, you will have to delete the class (and the underlying data) and recreate. This endpoint cannot be used to modify properties. Instead use POST /v1/schema/{className}/properties. A typical use case for this endpoint is to update configuration, such as the vectorIndexConfig. Note that even in mutable sections, such as vectorIndexConfig, some fields may be immutable.",
+        "description": "Use this endpoint to alter an existing class in the schema. Note that not all settings are mutable. If an error about immutable fields is returned and you still need to update this particular setting, you will have to delete the class (and the underlying data) and recreate. This endpoint cannot be used to add or remove properties. Instead use POST /v1/schema/{className}/properties. The tokenization and indexInverted settings of existing properties can be changed, the property is then reindexed in the background while queries keep using its previous index. The progress is reported by GET /v1/schema/{className}/shards. A typical use case for this endpoint is to update configuration, such as the vectorIndexConfig. Note that even in mutable sections, such as vectorIndexConfig, some fields may be immutable.",
         "tags": [
           "schema"
         ],
@@ -6226,9 +7111,110 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "Added the property.",
+            "description": "Added the property.",
+            "schema": {
+              "$ref": "#/definitions/Property"
+            }
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "422": {
+            "description": "Invalid property.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-serviceIds": [
+          "weaviate.local.manipulate.meta"
+        ]
+      }
+    },
+    "/schema/{className}/properties/{propertyName}": {
+      "delete": {
+        "tags": [
+          "schema"
+        ],
+        "summary": "Remove a property from an Object class. The values of the property are removed from all objects of the class.",
+        "operationId": "schema.objects.properties.delete",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "className",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "propertyName",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Removed the property from the Object class."
+          },
+          "401": {
+            "description": "Unauthorized or invalid credentials."
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "422": {
+            "description": "Could not delete the property.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
+          "500": {
+            "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          }
+        },
+        "x-serviceIds": [
+          "weaviate.local.manipulate.meta"
+        ]
+      }
+    },
+    "/schema/{className}/shards": {
+      "get": {
+        "tags": [
+          "schema"
+        ],
+        "summary": "Get the shards status of an Object class",
+        "operationId": "schema.objects.shards.get",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "className",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Found the status of the shards, returned as body",
             "schema": {
-              "$ref": "#/definitions/Property"
+              "$ref": "#/definitions/ShardStatusList"
             }
           },
           "401": {
@@ -6240,8 +7226,8 @@ func init() {
               "$ref": "#/definitions/ErrorResponse"
             }
           },
-          "422": {
-            "description": "Invalid property.",
+          "404": {
+            "description": "This class does not exist",
             "schema": {
               "$ref": "#/definitions/ErrorResponse"
             }
@@ -6254,17 +7240,17 @@ func init() {
           }
         },
         "x-serviceIds": [
-          "weaviate.local.manipulate.meta"
+          "weaviate.local.get.meta"
         ]
       }
     },
-    "/schema/{className}/shards": {
-      "get": {
+    "/schema/{className}/shards/rebalance": {
+      "post": {
+        "description": "Spread the shards of an Object Class evenly across all nodes of the cluster. Shards are split or moved to nodes which hold fewer shards than others, for example after nodes have been added. The shards being copied are read-only until the rebalancing completes.",
         "tags": [
           "schema"
         ],
-        "summary": "Get the shards status of an Object class",
-        "operationId": "schema.objects.shards.get",
+        "operationId": "schema.objects.shards.rebalance",
         "parameters": [
           {
             "type": "string",
@@ -6275,9 +7261,9 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "Found the status of the shards, returned as body",
+            "description": "The shards were rebalanced, the copies which have been made are returned as body",
             "schema": {
-              "$ref": "#/definitions/ShardStatusList"
+              "$ref": "#/definitions/ShardTransferList"
             }
           },
           "401": {
@@ -6295,6 +7281,12 @@ func init() {
               "$ref": "#/definitions/ErrorResponse"
             }
           },
+          "422": {
+            "description": "The shards cannot be rebalanced, for example because a node holding one of them is not available or not all of them are ready",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponse"
+            }
+          },
           "500": {
             "description": "An error has occurred while trying to fulfill the request. Most likely the ErrorResponse will contain more information about the error.",
             "schema": {
@@ -6303,7 +7295,7 @@ func init() {
           }
         },
         "x-serviceIds": [
-          "weaviate.local.get.meta"
+          "weaviate.local.manipulate.meta"
         ]
       }
     },
@@ -6419,6 +7411,10 @@ func init() {
           "description": "The ID of the backup. Must be URL-safe and work as a filesystem path, only lowercase, numbers, underscore, minus characters allowed.",
           "type": "string"
         },
+        "parent": {
+          "description": "The ID of the parent backup, if this is an incremental backup",
+          "type": "string"
+        },
         "path": {
           "description": "destination path of backup files proper to selected storage",
           "type": "string"
@@ -6432,7 +7428,8 @@ func init() {
             "TRANSFERRING",
             "TRANSFERRED",
             "SUCCESS",
-            "FAILED"
+            "FAILED",
+            "CANCELED"
           ]
         },
         "storageName": {
@@ -6444,6 +7441,15 @@ func init() {
     "BackupCreateRequest": {
       "description": "Request body for creating a backup of a set of classes",
       "properties": {
+        "compression": {
+          "description": "Compression of the backup files. Unless set to none, the files are streamed into compressed chunks on the storage.",
+          "type": "string",
+          "enum": [
+            "none",
+            "gzip",
+            "zstd"
+          ]
+        },
         "config": {
           "description": "Custom configuration for the backup creation process",
           "type": "object"
@@ -6465,6 +7471,52 @@ func init() {
           "items": {
             "type": "string"
           }
+        },
+        "parent": {
+          "description": "The ID of an existing backup to use as the parent of an incremental backup. Files which did not change since the parent backup are not uploaded again, but referenced.",
+          "type": "string"
+        }
+      }
+    },
+    "BackupListItem": {
+      "description": "A backup which exists on a storage",
+      "properties": {
+        "classes": {
+          "description": "The list of classes contained in the backup",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "error": {
+          "description": "error message if the backup failed",
+          "type": "string"
+        },
+        "id": {
+          "description": "The ID of the backup. Must be URL-safe and work as a filesystem path, only lowercase, numbers, underscore, minus characters allowed.",
+          "type": "string"
+        },
+        "parent": {
+          "description": "The ID of the parent backup, if this is an incremental backup",
+          "type": "string"
+        },
+        "size": {
+          "description": "size of the backup in bytes, files referenced from a parent backup excluded",
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "description": "phase of backup creation process",
+          "type": "string",
+          "default": "STARTED",
+          "enum": [
+            "STARTED",
+            "TRANSFERRING",
+            "TRANSFERRED",
+            "SUCCESS",
+            "FAILED",
+            "CANCELED"
+          ]
         }
       }
     },
@@ -6499,7 +7551,8 @@ func init() {
             "TRANSFERRING",
             "TRANSFERRED",
             "SUCCESS",
-            "FAILED"
+            "FAILED",
+            "CANCELED"
           ]
         },
         "storageName": {
@@ -6532,6 +7585,13 @@ func init() {
           "items": {
             "type": "string"
           }
+        },
+        "rename": {
+          "description": "Map of class names in the backup to the names under which they are restored. Classes which are not listed keep their original name. Cross-references to renamed classes are renamed as well.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
         }
       }
     },
@@ -6998,6 +8058,13 @@ func init() {
           "description": "Manage how the index should be sharded and distributed in the cluster",
           "type": "object"
         },
+        "vectorConfig": {
+          "description": "Named vectors of the class in addition to its regular vector, each with its own vectorizer and vector index",
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/definitions/VectorConfig"
+          }
+        },
         "vectorIndexConfig": {
           "description": "Vector-index config, that is specific to the type of index selected in vectorIndexType",
           "type": "object"
@@ -7340,6 +8407,10 @@ func init() {
           "type": "number",
           "format": "int"
         },
+        "indexPositions": {
+          "description": "Index the positions of the terms of text and text[] properties, which is required for phrase queries. Enabling it on an existing class indexes the positions of all existing objects",
+          "type": "boolean"
+        },
         "indexTimestamps": {
           "description": "Index each object by its internal timestamps",
           "type": "boolean"
@@ -7400,6 +8471,87 @@ func init() {
         "$ref": "#/definitions/SingleRef"
       }
     },
+    "NodeShardStatus": {
+      "description": "The status of a single shard on a node",
+      "type": "object",
+      "properties": {
+        "class": {
+          "description": "Name of the class the shard belongs to",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the shard",
+          "type": "string"
+        },
+        "objectCount": {
+          "description": "Number of objects in the shard",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "NodeStats": {
+      "description": "Summary of the shards held by a node",
+      "type": "object",
+      "properties": {
+        "objectCount": {
+          "description": "Number of objects in all the shards held by the node",
+          "type": "integer",
+          "format": "int64"
+        },
+        "shardCount": {
+          "description": "Number of shards held by the node",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "NodeStatus": {
+      "description": "The status of a single node",
+      "type": "object",
+      "properties": {
+        "name": {
+          "description": "Name of the node",
+          "type": "string"
+        },
+        "shards": {
+          "description": "The shards held by the node",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NodeShardStatus"
+          }
+        },
+        "stats": {
+          "$ref": "#/definitions/NodeStats"
+        },
+        "status": {
+          "description": "Health of the node, UNAVAILABLE if it is not part of the cluster anymore, UNHEALTHY if it did not report its status",
+          "type": "string",
+          "enum": [
+            "HEALTHY",
+            "UNHEALTHY",
+            "UNAVAILABLE"
+          ]
+        },
+        "version": {
+          "description": "Version of Weaviate the node runs",
+          "type": "string"
+        }
+      }
+    },
+    "NodesStatusResponse": {
+      "description": "The status of all the nodes in the cluster",
+      "type": "object",
+      "properties": {
+        "nodes": {
+          "description": "The status of every node holding a shard or taking part in the cluster",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NodeStatus"
+          }
+        }
+      }
+    },
     "Object": {
       "type": "object",
       "properties": {
@@ -7434,6 +8586,10 @@ func init() {
         },
         "vectorWeights": {
           "$ref": "#/definitions/VectorWeights"
+        },
+        "vectors": {
+          "description": "This object's named vectors. Read-only for vectors using a vectorizer other than 'none'.",
+          "$ref": "#/definitions/Vectors"
         }
       }
     },
@@ -7673,6 +8829,9 @@ func init() {
     "Property": {
       "type": "object",
       "properties": {
+        "analyzer": {
+          "$ref": "#/definitions/PropertyAnalyzerConfig"
+        },
         "dataType": {
           "description": "Can be a reference to another type when it starts with a capital (for example Person), otherwise \"string\" or \"int\".",
           "type": "array",
@@ -7697,16 +8856,67 @@ func init() {
           "description": "Name of the property as URI relative to the schema URL.",
           "type": "string"
         },
+        "nestedProperties": {
+          "description": "The properties of the nested object(s). Required for the object and object[] data types and not supported for the remaining data types. Nested properties can be of the primitive data types except geoCoordinates, phoneNumber and blob, or be nested objects themselves",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Property"
+          },
+          "x-omitempty": true
+        },
         "tokenization": {
-          "description": "Determines tokenization of the property as separate words or whole field. Optional. Applies to string, string[], text and text[] data types. Allowed values are ` + "`" + `word` + "`" + ` (default) and ` + "`" + `field` + "`" + ` for string and string[], ` + "`" + `word` + "`" + ` (default) for text and text[]. Not supported for remaining data types",
+          "description": "Determines tokenization of the property as separate words or whole field. Optional. Applies to string, string[], text and text[] data types. Allowed values are ` + "`" + `word` + "`" + ` (default) and ` + "`" + `field` + "`" + ` for string and string[], ` + "`" + `word` + "`" + ` (default), ` + "`" + `trigram` + "`" + ` and ` + "`" + `cjk` + "`" + ` for text and text[]. ` + "`" + `trigram` + "`" + ` splits words into overlapping trigrams, which speeds up substring (like) filters. ` + "`" + `cjk` + "`" + ` splits runs of Chinese, Japanese and Korean characters into overlapping bigrams. Not supported for remaining data types",
           "type": "string",
           "enum": [
             "word",
-            "field"
+            "field",
+            "trigram",
+            "cjk"
           ]
         }
       }
     },
+    "PropertyAnalyzerConfig": {
+      "description": "Language-aware analysis of the values of a text or text[] property before they are added to the inverted index. The same analysis is applied to the values of where filters and keyword-based (bm25) searches on the property",
+      "type": "object",
+      "properties": {
+        "asciiFolding": {
+          "description": "Fold accented and other non-ASCII letters to their ASCII counterparts, e.g. \"Häuser\" becomes \"hauser\"",
+          "type": "boolean"
+        },
+        "language": {
+          "description": "Language of the values as a two-letter ISO 639-1 code, e.g. \"de\". It selects the stemmer and the default stopword preset of the property",
+          "type": "string"
+        },
+        "stemming": {
+          "description": "Reduce words to their stem with the Snowball stemmer of the language, e.g. \"Häuser\" and \"Haus\" both become \"haus\". Requires a language",
+          "type": "boolean"
+        },
+        "stopwordPreset": {
+          "description": "Stopword preset of the property, it overrides the stopwords of the class. Defaults to the preset of the language if one exists",
+          "type": "string"
+        }
+      }
+    },
+    "PropertyReindexStatus": {
+      "description": "The progress of reindexing a property of a shard",
+      "properties": {
+        "processedObjects": {
+          "description": "Number of objects which have been reindexed so far",
+          "type": "integer",
+          "format": "int64"
+        },
+        "property": {
+          "description": "Name of the property",
+          "type": "string"
+        },
+        "totalObjects": {
+          "description": "Number of objects which need to be reindexed",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "PropertySchema": {
       "description": "This is an open object, with OpenAPI Specification 3.0 this will be more detailed. See Weaviate docs for more info. In the future this will become a key/value OR a SingleRef definition.",
       "type": "object"
@@ -7811,6 +9021,13 @@ func init() {
           "description": "Name of the shard",
           "type": "string"
         },
+        "reindexing": {
+          "description": "The properties of the shard which are reindexed after their tokenization or indexInverted setting was changed",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PropertyReindexStatus"
+          }
+        },
         "status": {
           "description": "Status of the shard",
           "type": "string"
@@ -7824,6 +9041,30 @@ func init() {
         "$ref": "#/definitions/ShardStatusGetResponse"
       }
     },
+    "ShardTransfer": {
+      "description": "The copy of a shard to a node which did not hold it before",
+      "properties": {
+        "node": {
+          "description": "Name of the node the shard was copied to",
+          "type": "string"
+        },
+        "shard": {
+          "description": "Name of the shard which was copied",
+          "type": "string"
+        },
+        "targetShard": {
+          "description": "Name of the shard the copy was stored as, it differs from the copied shard if the shard was split",
+          "type": "string"
+        }
+      }
+    },
+    "ShardTransferList": {
+      "description": "The shard copies made to spread the shards of a Class across the nodes",
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/ShardTransfer"
+      }
+    },
     "SingleRef": {
       "description": "Either set beacon (direct reference) or set class and schema (concept reference)",
       "properties": {
@@ -7876,10 +9117,35 @@ func init() {
         }
       }
     },
+    "VectorConfig": {
+      "description": "Configuration of a single named vector of a class",
+      "type": "object",
+      "properties": {
+        "vectorIndexConfig": {
+          "description": "Vector-index config, that is specific to the type of index selected in vectorIndexType",
+          "type": "object"
+        },
+        "vectorIndexType": {
+          "description": "Name of the vector index to use, eg. (HNSW)",
+          "type": "string"
+        },
+        "vectorizer": {
+          "description": "The vectorizer of this vector, an object with the name of the module as its only key and the module config as its value, e.g. {\"text2vec-contextionary\": {\"vectorizeClassName\": false}}. Use {\"none\": {}} to import the vectors yourself.",
+          "type": "object"
+        }
+      }
+    },
     "VectorWeights": {
       "description": "Allow custom overrides of vector weights as math expressions. E.g. \"pancake\": \"7\" will set the weight for the word pancake to 7 in the vectorization, whereas \"w * 3\" would triple the originally calculated word. This is an open object, with OpenAPI Specification 3.0 this will be more detailed. See Weaviate docs for more info. In the future this will become a key/value (string/string) object.",
       "type": "object"
     },
+    "Vectors": {
+      "description": "A map of named vectors, one for each vector configured in the vectorConfig of the class",
+      "type": "object",
+      "additionalProperties": {
+        "$ref": "#/definitions/C11yVector"
+      }
+    },
     "WhereFilter": {
       "description": "Filter search results using a where filter",
       "type": "object",
@@ -7899,13 +9165,17 @@ func init() {
             "Or",
             "Equal",
             "Like",
+            "Phrase",
+            "Fuzzy",
             "Not",
             "NotEqual",
             "GreaterThan",
             "GreaterThanEqual",
             "LessThan",
             "LessThanEqual",
-            "WithinGeoRange"
+            "WithinGeoRange",
+            "ContainsAny",
+            "ContainsAll"
           ],
           "example": "GreaterThanEqual"
         },
@@ -7927,12 +9197,32 @@ func init() {
           "x-nullable": true,
           "example": false
         },
+        "valueBooleanArray": {
+          "description": "value as list of booleans (for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "boolean"
+          },
+          "example": [
+            true,
+            false
+          ],
+          "x-omitempty": true
+        },
         "valueDate": {
           "description": "value as date (as string)",
           "type": "string",
           "x-nullable": true,
           "example": "TODO"
         },
+        "valueDateArray": {
+          "description": "value as list of dates (as strings, for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
         "valueGeoRange": {
           "description": "value as geo coordinates and distance",
           "type": "object",
@@ -7946,6 +9236,19 @@ func init() {
           "x-nullable": true,
           "example": 2000
         },
+        "valueIntArray": {
+          "description": "value as list of integers (for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          },
+          "example": [
+            100,
+            200
+          ],
+          "x-omitempty": true
+        },
         "valueNumber": {
           "description": "value as number/float",
           "type": "number",
@@ -7953,17 +9256,70 @@ func init() {
           "x-nullable": true,
           "example": 3.14
         },
+        "valueNumberArray": {
+          "description": "value as list of numbers/floats (for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "number",
+            "format": "float64"
+          },
+          "example": [
+            3.14,
+            2.71
+          ],
+          "x-omitempty": true
+        },
         "valueString": {
           "description": "value as string",
           "type": "string",
           "x-nullable": true,
           "example": "my search term"
         },
+        "valueStringArray": {
+          "description": "value as list of strings (for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "example": [
+            "my",
+            "search",
+            "terms"
+          ],
+          "x-omitempty": true
+        },
         "valueText": {
           "description": "value as text (on text props)",
           "type": "string",
           "x-nullable": true,
           "example": "my search term"
+        },
+        "valueTextArray": {
+          "description": "value as list of texts (on text props, for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "example": [
+            "my",
+            "search",
+            "terms"
+          ],
+          "x-omitempty": true
+        },
+        "valueUuid": {
+          "description": "value as uuid (on uuid props)",
+          "type": "string",
+          "x-nullable": true,
+          "example": "28f3f61b-b524-45e0-9bbe-2c1550bf73d2"
+        },
+        "valueUuidArray": {
+          "description": "value as list of uuids (on uuid props, for ContainsAny and ContainsAll)",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
         }
       }
     },
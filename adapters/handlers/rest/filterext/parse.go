@@ -123,6 +123,10 @@ func parseOperator(in string) (filters.Operator, error) {
 		return filters.OperatorEqual, nil
 	case models.WhereFilterOperatorLike:
 		return filters.OperatorLike, nil
+	case models.WhereFilterOperatorPhrase:
+		return filters.OperatorPhrase, nil
+	case models.WhereFilterOperatorFuzzy:
+		return filters.OperatorFuzzy, nil
 	case models.WhereFilterOperatorLessThan:
 		return filters.OperatorLessThan, nil
 	case models.WhereFilterOperatorLessThanEqual:
@@ -135,6 +139,10 @@ func parseOperator(in string) (filters.Operator, error) {
 		return filters.OperatorNotEqual, nil
 	case models.WhereFilterOperatorWithinGeoRange:
 		return filters.OperatorWithinGeoRange, nil
+	case models.WhereFilterOperatorContainsAny:
+		return filters.OperatorContainsAny, nil
+	case models.WhereFilterOperatorContainsAll:
+		return filters.OperatorContainsAll, nil
 	case models.WhereFilterOperatorAnd:
 		return filters.OperatorAnd, nil
 	case models.WhereFilterOperatorOr:
@@ -165,7 +173,15 @@ func allValuesNil(in *models.WhereFilter) bool {
 		in.ValueDate == nil &&
 		in.ValueString == nil &&
 		in.ValueText == nil &&
+		in.ValueUUID == nil &&
 		in.ValueInt == nil &&
 		in.ValueNumber == nil &&
-		in.ValueGeoRange == nil
+		in.ValueGeoRange == nil &&
+		in.ValueBooleanArray == nil &&
+		in.ValueDateArray == nil &&
+		in.ValueStringArray == nil &&
+		in.ValueTextArray == nil &&
+		in.ValueUUIDArray == nil &&
+		in.ValueIntArray == nil &&
+		in.ValueNumberArray == nil
 }
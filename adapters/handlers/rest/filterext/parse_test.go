@@ -175,6 +175,44 @@ func Test_ExtractFlatFilters(t *testing.T) {
 					},
 				}},
 			},
+			{
+				name: "valid int list filter",
+				input: &models.WhereFilter{
+					Operator:      "ContainsAny",
+					ValueIntArray: []int64{42, 43},
+					Path:          []string{"intArrayField"},
+				},
+				expectedFilter: &filters.LocalFilter{Root: &filters.Clause{
+					Operator: filters.OperatorContainsAny,
+					On: &filters.Path{
+						Class:    schema.AssertValidClassName("Todo"),
+						Property: schema.AssertValidPropertyName("intArrayField"),
+					},
+					Value: &filters.Value{
+						Value: []int{42, 43},
+						Type:  schema.DataTypeIntArray,
+					},
+				}},
+			},
+			{
+				name: "valid text list filter",
+				input: &models.WhereFilter{
+					Operator:       "ContainsAll",
+					ValueTextArray: []string{"foo", "bar"},
+					Path:           []string{"textField"},
+				},
+				expectedFilter: &filters.LocalFilter{Root: &filters.Clause{
+					Operator: filters.OperatorContainsAll,
+					On: &filters.Path{
+						Class:    schema.AssertValidClassName("Todo"),
+						Property: schema.AssertValidPropertyName("textField"),
+					},
+					Value: &filters.Value{
+						Value: []string{"foo", "bar"},
+						Type:  schema.DataTypeTextArray,
+					},
+				}},
+			},
 		}
 
 		for _, test := range tests {
@@ -272,6 +310,16 @@ func Test_ExtractFlatFilters(t *testing.T) {
 				expectedErr: fmt.Errorf("invalid where filter: " +
 					"got operator 'Equal', but no value<Type> field set"),
 			},
+			{
+				name: "contains any operator and an empty value list",
+				input: &models.WhereFilter{
+					Operator:         "ContainsAny",
+					ValueStringArray: []string{},
+					Path:             []string{"stringField"},
+				},
+				expectedErr: fmt.Errorf("invalid where filter: " +
+					"valueStringArray: must contain at least one value"),
+			},
 			{
 				name: "equal operator and no path set",
 				input: &models.WhereFilter{
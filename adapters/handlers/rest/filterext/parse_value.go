@@ -90,6 +90,14 @@ var valueExtractors = []valueExtractorFunc{
 
 		return valueFilter(*in.ValueDate, schema.DataTypeDate), nil
 	},
+	// uuid (as string)
+	func(in *models.WhereFilter) (*filters.Value, error) {
+		if in.ValueUUID == nil {
+			return nil, nil
+		}
+
+		return valueFilter(*in.ValueUUID, schema.DataTypeUUID), nil
+	},
 	// boolean
 	func(in *models.WhereFilter) (*filters.Value, error) {
 		if in.ValueBoolean == nil {
@@ -98,6 +106,73 @@ var valueExtractors = []valueExtractorFunc{
 
 		return valueFilter(*in.ValueBoolean, schema.DataTypeBoolean), nil
 	},
+	// int list
+	func(in *models.WhereFilter) (*filters.Value, error) {
+		if in.ValueIntArray == nil {
+			return nil, nil
+		}
+
+		values := make([]int, len(in.ValueIntArray))
+		for i := range in.ValueIntArray {
+			values[i] = int(in.ValueIntArray[i])
+		}
+
+		return valueListFilter("valueIntArray", values, len(values), schema.DataTypeIntArray)
+	},
+	// number list
+	func(in *models.WhereFilter) (*filters.Value, error) {
+		if in.ValueNumberArray == nil {
+			return nil, nil
+		}
+
+		return valueListFilter("valueNumberArray", in.ValueNumberArray,
+			len(in.ValueNumberArray), schema.DataTypeNumberArray)
+	},
+	// string list
+	func(in *models.WhereFilter) (*filters.Value, error) {
+		if in.ValueStringArray == nil {
+			return nil, nil
+		}
+
+		return valueListFilter("valueStringArray", in.ValueStringArray,
+			len(in.ValueStringArray), schema.DataTypeStringArray)
+	},
+	// text list
+	func(in *models.WhereFilter) (*filters.Value, error) {
+		if in.ValueTextArray == nil {
+			return nil, nil
+		}
+
+		return valueListFilter("valueTextArray", in.ValueTextArray,
+			len(in.ValueTextArray), schema.DataTypeTextArray)
+	},
+	// date list (as strings)
+	func(in *models.WhereFilter) (*filters.Value, error) {
+		if in.ValueDateArray == nil {
+			return nil, nil
+		}
+
+		return valueListFilter("valueDateArray", in.ValueDateArray,
+			len(in.ValueDateArray), schema.DataTypeDateArray)
+	},
+	// uuid list (as strings)
+	func(in *models.WhereFilter) (*filters.Value, error) {
+		if in.ValueUUIDArray == nil {
+			return nil, nil
+		}
+
+		return valueListFilter("valueUuidArray", in.ValueUUIDArray,
+			len(in.ValueUUIDArray), schema.DataTypeUUIDArray)
+	},
+	// boolean list
+	func(in *models.WhereFilter) (*filters.Value, error) {
+		if in.ValueBooleanArray == nil {
+			return nil, nil
+		}
+
+		return valueListFilter("valueBooleanArray", in.ValueBooleanArray,
+			len(in.ValueBooleanArray), schema.DataTypeBooleanArray)
+	},
 	// geo range
 	func(in *models.WhereFilter) (*filters.Value, error) {
 		if in.ValueGeoRange == nil {
@@ -133,6 +208,16 @@ func valueFilter(value interface{}, dt schema.DataType) *filters.Value {
 	}
 }
 
+func valueListFilter(field string, values interface{}, length int,
+	dt schema.DataType,
+) (*filters.Value, error) {
+	if length == 0 {
+		return nil, fmt.Errorf("%s: must contain at least one value", field)
+	}
+
+	return valueFilter(values, dt), nil
+}
+
 // Small utility function used in printing error messages.
 func jsonify(stuff interface{}) string {
 	j, _ := json.Marshal(stuff)
@@ -23,7 +23,6 @@ import (
 	"github.com/semi-technologies/weaviate/usecases/auth/authorization/errors"
 	ubak "github.com/semi-technologies/weaviate/usecases/backup"
 	schemaUC "github.com/semi-technologies/weaviate/usecases/schema"
-	"github.com/semi-technologies/weaviate/usecases/sharding"
 )
 
 func newSource(db *db.DB) ubak.SourceFactory {
@@ -48,9 +47,9 @@ type backupHandlers struct {
 func (s *backupHandlers) createBackup(params backups.BackupsCreateParams,
 	principal *models.Principal,
 ) middleware.Responder {
-	// TODO: update s.manager.CreateBackup to receive list of classes
 	meta, err := s.manager.CreateBackup(params.HTTPRequest.Context(), principal,
-		params.Body.Include[0], params.StorageName, params.Body.ID)
+		params.StorageName, params.Body.ID, params.Body.Parent, params.Body.Compression,
+		params.Body.Include, params.Body.Exclude)
 	if err != nil {
 		switch err.(type) {
 		case errors.Forbidden:
@@ -71,9 +70,8 @@ func (s *backupHandlers) createBackup(params backups.BackupsCreateParams,
 func (s *backupHandlers) createBackupStatus(params backups.BackupsCreateStatusParams,
 	principal *models.Principal,
 ) middleware.Responder {
-	// TODO: update s.manager.CreateBackupStatus to fetch the target classes internally
 	status, err := s.manager.CreateBackupStatus(params.HTTPRequest.Context(), principal,
-		"", params.StorageName, params.ID)
+		params.StorageName, params.ID)
 	if err != nil {
 		switch err.(type) {
 		case errors.Forbidden:
@@ -93,9 +91,9 @@ func (s *backupHandlers) createBackupStatus(params backups.BackupsCreateStatusPa
 func (s *backupHandlers) restoreBackup(params backups.BackupsRestoreParams,
 	principal *models.Principal,
 ) middleware.Responder {
-	// TODO: update s.manager.RestoreBackup to receive list of classes
 	meta, err := s.manager.RestoreBackup(params.HTTPRequest.Context(), principal,
-		params.Body.Include[0], params.StorageName, params.ID)
+		params.StorageName, params.ID, params.Body.Include, params.Body.Exclude,
+		params.Body.Rename)
 	if err != nil {
 		switch err.(type) {
 		case errors.Forbidden:
@@ -119,47 +117,101 @@ func (s *backupHandlers) restoreBackup(params backups.BackupsRestoreParams,
 func (s *backupHandlers) restoreBackupStatus(params backups.BackupsRestoreStatusParams,
 	principal *models.Principal,
 ) middleware.Responder {
-	// TODO: update s.manager.RestoreBackupStatus to fetch the target classes internally
-	status, restoreError, path, err := s.manager.RestoreBackupStatus(
-		params.HTTPRequest.Context(), principal, "", params.StorageName, params.ID)
+	status, err := s.manager.RestoreBackupStatus(
+		params.HTTPRequest.Context(), principal, params.StorageName, params.ID)
 	if err != nil {
 		switch err.(type) {
 		case errors.Forbidden:
-			return backups.NewBackupsRestoreForbidden().
+			return backups.NewBackupsRestoreStatusForbidden().
 				WithPayload(errPayloadFromSingleErr(err))
 		case backup.ErrNotFound:
-			return backups.NewBackupsRestoreNotFound().
+			return backups.NewBackupsRestoreStatusNotFound().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return backups.NewBackupsRestoreStatusInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return backups.NewBackupsRestoreStatusOK().WithPayload(status)
+}
+
+func (s *backupHandlers) cancelBackup(params backups.BackupsCancelParams,
+	principal *models.Principal,
+) middleware.Responder {
+	err := s.manager.CancelBackup(params.HTTPRequest.Context(), principal,
+		params.StorageName, params.ID)
+	if err != nil {
+		switch err.(type) {
+		case errors.Forbidden:
+			return backups.NewBackupsCancelForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		case backup.ErrNotFound:
+			return backups.NewBackupsCancelNotFound().
 				WithPayload(errPayloadFromSingleErr(err))
 		case backup.ErrUnprocessable:
-			return backups.NewBackupsRestoreUnprocessableEntity().
+			return backups.NewBackupsCancelUnprocessableEntity().
 				WithPayload(errPayloadFromSingleErr(err))
 		default:
-			return backups.NewBackupsRestoreInternalServerError().
+			return backups.NewBackupsCancelInternalServerError().
 				WithPayload(errPayloadFromSingleErr(err))
 		}
 	}
 
-	return backups.NewBackupsRestoreStatusOK().
-		WithPayload(&models.BackupRestoreMeta{
-			Status: &status,
-			// TODO: fetch the target classes internally
-			//       and pass them back up here to set
-			Classes:     []string{},
-			Error:       restoreError,
-			ID:          params.ID,
-			Path:        path,
-			StorageName: params.StorageName,
-		})
+	return backups.NewBackupsCancelNoContent()
 }
 
-func setupBackupHandlers(api *operations.WeaviateAPI, schemaManger *schemaUC.Manager, repo *db.DB, appState *state.State) {
-	shardingStateFunc := func(className string) *sharding.State {
-		return appState.SchemaManager.ShardingState(className)
+func (s *backupHandlers) listBackups(params backups.BackupsListParams,
+	principal *models.Principal,
+) middleware.Responder {
+	items, err := s.manager.ListBackups(params.HTTPRequest.Context(), principal,
+		params.StorageName)
+	if err != nil {
+		switch err.(type) {
+		case errors.Forbidden:
+			return backups.NewBackupsListForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		case backup.ErrUnprocessable:
+			return backups.NewBackupsListUnprocessableEntity().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return backups.NewBackupsListInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return backups.NewBackupsListOK().WithPayload(items)
+}
+
+func (s *backupHandlers) deleteBackup(params backups.BackupsDeleteParams,
+	principal *models.Principal,
+) middleware.Responder {
+	err := s.manager.DeleteBackup(params.HTTPRequest.Context(), principal,
+		params.StorageName, params.ID)
+	if err != nil {
+		switch err.(type) {
+		case errors.Forbidden:
+			return backups.NewBackupsDeleteForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		case backup.ErrNotFound:
+			return backups.NewBackupsDeleteNotFound().
+				WithPayload(errPayloadFromSingleErr(err))
+		case backup.ErrUnprocessable:
+			return backups.NewBackupsDeleteUnprocessableEntity().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return backups.NewBackupsDeleteInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
 	}
+
+	return backups.NewBackupsDeleteNoContent()
+}
+
+func setupBackupHandlers(api *operations.WeaviateAPI, schemaManger *schemaUC.Manager, repo *db.DB, appState *state.State) {
 	snapshotterProvider := newSource(repo)
 	backupManager := ubak.NewManager(appState.Logger, appState.Authorizer,
-		schemaManger, snapshotterProvider,
-		appState.Modules, shardingStateFunc)
+		schemaManger, snapshotterProvider, repo, appState.Modules)
 
 	h := &backupHandlers{backupManager}
 	api.BackupsBackupsCreateHandler = backups.
@@ -170,4 +222,10 @@ func setupBackupHandlers(api *operations.WeaviateAPI, schemaManger *schemaUC.Man
 		BackupsRestoreHandlerFunc(h.restoreBackup)
 	api.BackupsBackupsRestoreStatusHandler = backups.
 		BackupsRestoreStatusHandlerFunc(h.restoreBackupStatus)
+	api.BackupsBackupsCancelHandler = backups.
+		BackupsCancelHandlerFunc(h.cancelBackup)
+	api.BackupsBackupsListHandler = backups.
+		BackupsListHandlerFunc(h.listBackups)
+	api.BackupsBackupsDeleteHandler = backups.
+		BackupsDeleteHandlerFunc(h.deleteBackup)
 }
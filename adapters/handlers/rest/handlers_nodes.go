@@ -0,0 +1,48 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package rest
+
+import (
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations"
+	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations/nodes"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/usecases/auth/authorization/errors"
+	nodesUC "github.com/semi-technologies/weaviate/usecases/nodes"
+)
+
+type nodesHandlers struct {
+	manager *nodesUC.Manager
+}
+
+func (n *nodesHandlers) getNodesStatus(params nodes.NodesGetParams,
+	principal *models.Principal,
+) middleware.Responder {
+	status, err := n.manager.GetNodeStatuses(params.HTTPRequest.Context(), principal)
+	if err != nil {
+		switch err.(type) {
+		case errors.Forbidden:
+			return nodes.NewNodesGetForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return nodes.NewNodesGetInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return nodes.NewNodesGetOK().WithPayload(status)
+}
+
+func setupNodesHandlers(api *operations.WeaviateAPI, manager *nodesUC.Manager) {
+	h := &nodesHandlers{manager}
+	api.NodesNodesGetHandler = nodes.NodesGetHandlerFunc(h.getNodesStatus)
+}
@@ -120,6 +120,25 @@ func (s *schemaHandlers) addClassProperty(params schema.SchemaObjectsPropertiesA
 	return schema.NewSchemaObjectsPropertiesAddOK().WithPayload(params.Body)
 }
 
+func (s *schemaHandlers) deleteClassProperty(params schema.SchemaObjectsPropertiesDeleteParams,
+	principal *models.Principal,
+) middleware.Responder {
+	err := s.manager.DeleteClassProperty(params.HTTPRequest.Context(), principal,
+		params.ClassName, params.PropertyName)
+	if err != nil {
+		switch err.(type) {
+		case errors.Forbidden:
+			return schema.NewSchemaObjectsPropertiesDeleteForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return schema.NewSchemaObjectsPropertiesDeleteUnprocessableEntity().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return schema.NewSchemaObjectsPropertiesDeleteOK()
+}
+
 func (s *schemaHandlers) getSchema(params schema.SchemaDumpParams, principal *models.Principal) middleware.Responder {
 	dbSchema, err := s.manager.GetSchema(principal)
 	if err != nil {
@@ -178,6 +197,41 @@ func (s *schemaHandlers) updateShardStatus(params schema.SchemaObjectsShardsUpda
 	return schema.NewSchemaObjectsShardsUpdateOK().WithPayload(payload)
 }
 
+func (s *schemaHandlers) rebalanceShards(params schema.SchemaObjectsShardsRebalanceParams,
+	principal *models.Principal,
+) middleware.Responder {
+	transfers, err := s.manager.RebalanceShards(params.HTTPRequest.Context(), principal,
+		params.ClassName)
+	if err != nil {
+		switch err.(type) {
+		case errors.Forbidden:
+			return schema.NewSchemaObjectsShardsRebalanceForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		case schemaUC.ErrUnprocessable:
+			return schema.NewSchemaObjectsShardsRebalanceUnprocessableEntity().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			if err == schemaUC.ErrNotFound {
+				return schema.NewSchemaObjectsShardsRebalanceNotFound().
+					WithPayload(errPayloadFromSingleErr(err))
+			}
+			return schema.NewSchemaObjectsShardsRebalanceInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	payload := make(models.ShardTransferList, len(transfers))
+	for i, transfer := range transfers {
+		payload[i] = &models.ShardTransfer{
+			Shard:       transfer.Shard,
+			TargetShard: transfer.TargetShard,
+			Node:        transfer.Node,
+		}
+	}
+
+	return schema.NewSchemaObjectsShardsRebalanceOK().WithPayload(payload)
+}
+
 func setupSchemaHandlers(api *operations.WeaviateAPI, manager *schemaUC.Manager) {
 	h := &schemaHandlers{manager}
 
@@ -187,6 +241,8 @@ func setupSchemaHandlers(api *operations.WeaviateAPI, manager *schemaUC.Manager)
 		SchemaObjectsDeleteHandlerFunc(h.deleteClass)
 	api.SchemaSchemaObjectsPropertiesAddHandler = schema.
 		SchemaObjectsPropertiesAddHandlerFunc(h.addClassProperty)
+	api.SchemaSchemaObjectsPropertiesDeleteHandler = schema.
+		SchemaObjectsPropertiesDeleteHandlerFunc(h.deleteClassProperty)
 
 	api.SchemaSchemaObjectsUpdateHandler = schema.
 		SchemaObjectsUpdateHandlerFunc(h.updateClass)
@@ -200,4 +256,6 @@ func setupSchemaHandlers(api *operations.WeaviateAPI, manager *schemaUC.Manager)
 		SchemaObjectsShardsGetHandlerFunc(h.getShardsStatus)
 	api.SchemaSchemaObjectsShardsUpdateHandler = schema.
 		SchemaObjectsShardsUpdateHandlerFunc(h.updateShardStatus)
+	api.SchemaSchemaObjectsShardsRebalanceHandler = schema.
+		SchemaObjectsShardsRebalanceHandlerFunc(h.rebalanceShards)
 }
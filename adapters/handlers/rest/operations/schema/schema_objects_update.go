@@ -47,7 +47,7 @@ SchemaObjectsUpdate swagger:route PUT /schema/{className} schema schemaObjectsUp
 
 # Update settings of an existing schema class
 
-Use this endpoint to alter an existing class in the schema. Note that not all settings are mutable. If an error about immutable fields is returned and you still need to update this particular setting, you will have to delete the class (and the underlying data) and recreate. This endpoint cannot be used to modify properties. Instead use POST /v1/schema/{className}/properties. A typical use case for this endpoint is to update configuration, such as the vectorIndexConfig. Note that even in mutable sections, such as vectorIndexConfig, some fields may be immutable.
+Use this endpoint to alter an existing class in the schema. Note that not all settings are mutable. If an error about immutable fields is returned and you still need to update this particular setting, you will have to delete the class (and the underlying data) and recreate. This endpoint cannot be used to add or remove properties. Instead use POST /v1/schema/{className}/properties. The tokenization and indexInverted settings of existing properties can be changed, the property is then reindexed in the background while queries keep using its previous index. The progress is reported by GET /v1/schema/{className}/shards. A typical use case for this endpoint is to update configuration, such as the vectorIndexConfig. Note that even in mutable sections, such as vectorIndexConfig, some fields may be immutable.
 */
 type SchemaObjectsUpdate struct {
 	Context *middleware.Context
@@ -36,6 +36,7 @@ import (
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations/classifications"
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations/graphql"
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations/meta"
+	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations/nodes"
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations/objects"
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations/schema"
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations/well_known"
@@ -67,12 +68,21 @@ func NewWeaviateAPI(spec *loads.Document) *WeaviateAPI {
 		WellKnownGetWellKnownOpenidConfigurationHandler: well_known.GetWellKnownOpenidConfigurationHandlerFunc(func(params well_known.GetWellKnownOpenidConfigurationParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation well_known.GetWellKnownOpenidConfiguration has not yet been implemented")
 		}),
+		BackupsBackupsCancelHandler: backups.BackupsCancelHandlerFunc(func(params backups.BackupsCancelParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation backups.BackupsCancel has not yet been implemented")
+		}),
 		BackupsBackupsCreateHandler: backups.BackupsCreateHandlerFunc(func(params backups.BackupsCreateParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation backups.BackupsCreate has not yet been implemented")
 		}),
 		BackupsBackupsCreateStatusHandler: backups.BackupsCreateStatusHandlerFunc(func(params backups.BackupsCreateStatusParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation backups.BackupsCreateStatus has not yet been implemented")
 		}),
+		BackupsBackupsDeleteHandler: backups.BackupsDeleteHandlerFunc(func(params backups.BackupsDeleteParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation backups.BackupsDelete has not yet been implemented")
+		}),
+		BackupsBackupsListHandler: backups.BackupsListHandlerFunc(func(params backups.BackupsListParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation backups.BackupsList has not yet been implemented")
+		}),
 		BackupsBackupsRestoreHandler: backups.BackupsRestoreHandlerFunc(func(params backups.BackupsRestoreParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation backups.BackupsRestore has not yet been implemented")
 		}),
@@ -103,6 +113,9 @@ func NewWeaviateAPI(spec *loads.Document) *WeaviateAPI {
 		MetaMetaGetHandler: meta.MetaGetHandlerFunc(func(params meta.MetaGetParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation meta.MetaGet has not yet been implemented")
 		}),
+		NodesNodesGetHandler: nodes.NodesGetHandlerFunc(func(params nodes.NodesGetParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation nodes.NodesGet has not yet been implemented")
+		}),
 		ObjectsObjectsClassDeleteHandler: objects.ObjectsClassDeleteHandlerFunc(func(params objects.ObjectsClassDeleteParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation objects.ObjectsClassDelete has not yet been implemented")
 		}),
@@ -175,9 +188,15 @@ func NewWeaviateAPI(spec *loads.Document) *WeaviateAPI {
 		SchemaSchemaObjectsPropertiesAddHandler: schema.SchemaObjectsPropertiesAddHandlerFunc(func(params schema.SchemaObjectsPropertiesAddParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation schema.SchemaObjectsPropertiesAdd has not yet been implemented")
 		}),
+		SchemaSchemaObjectsPropertiesDeleteHandler: schema.SchemaObjectsPropertiesDeleteHandlerFunc(func(params schema.SchemaObjectsPropertiesDeleteParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation schema.SchemaObjectsPropertiesDelete has not yet been implemented")
+		}),
 		SchemaSchemaObjectsShardsGetHandler: schema.SchemaObjectsShardsGetHandlerFunc(func(params schema.SchemaObjectsShardsGetParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation schema.SchemaObjectsShardsGet has not yet been implemented")
 		}),
+		SchemaSchemaObjectsShardsRebalanceHandler: schema.SchemaObjectsShardsRebalanceHandlerFunc(func(params schema.SchemaObjectsShardsRebalanceParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation schema.SchemaObjectsShardsRebalance has not yet been implemented")
+		}),
 		SchemaSchemaObjectsShardsUpdateHandler: schema.SchemaObjectsShardsUpdateHandlerFunc(func(params schema.SchemaObjectsShardsUpdateParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation schema.SchemaObjectsShardsUpdate has not yet been implemented")
 		}),
@@ -244,10 +263,16 @@ type WeaviateAPI struct {
 
 	// WellKnownGetWellKnownOpenidConfigurationHandler sets the operation handler for the get well known openid configuration operation
 	WellKnownGetWellKnownOpenidConfigurationHandler well_known.GetWellKnownOpenidConfigurationHandler
+	// BackupsBackupsCancelHandler sets the operation handler for the backups cancel operation
+	BackupsBackupsCancelHandler backups.BackupsCancelHandler
 	// BackupsBackupsCreateHandler sets the operation handler for the backups create operation
 	BackupsBackupsCreateHandler backups.BackupsCreateHandler
 	// BackupsBackupsCreateStatusHandler sets the operation handler for the backups create status operation
 	BackupsBackupsCreateStatusHandler backups.BackupsCreateStatusHandler
+	// BackupsBackupsDeleteHandler sets the operation handler for the backups delete operation
+	BackupsBackupsDeleteHandler backups.BackupsDeleteHandler
+	// BackupsBackupsListHandler sets the operation handler for the backups list operation
+	BackupsBackupsListHandler backups.BackupsListHandler
 	// BackupsBackupsRestoreHandler sets the operation handler for the backups restore operation
 	BackupsBackupsRestoreHandler backups.BackupsRestoreHandler
 	// BackupsBackupsRestoreStatusHandler sets the operation handler for the backups restore status operation
@@ -268,6 +293,8 @@ type WeaviateAPI struct {
 	GraphqlGraphqlPostHandler graphql.GraphqlPostHandler
 	// MetaMetaGetHandler sets the operation handler for the meta get operation
 	MetaMetaGetHandler meta.MetaGetHandler
+	// NodesNodesGetHandler sets the operation handler for the nodes get operation
+	NodesNodesGetHandler nodes.NodesGetHandler
 	// ObjectsObjectsClassDeleteHandler sets the operation handler for the objects class delete operation
 	ObjectsObjectsClassDeleteHandler objects.ObjectsClassDeleteHandler
 	// ObjectsObjectsClassGetHandler sets the operation handler for the objects class get operation
@@ -316,8 +343,12 @@ type WeaviateAPI struct {
 	SchemaSchemaObjectsGetHandler schema.SchemaObjectsGetHandler
 	// SchemaSchemaObjectsPropertiesAddHandler sets the operation handler for the schema objects properties add operation
 	SchemaSchemaObjectsPropertiesAddHandler schema.SchemaObjectsPropertiesAddHandler
+	// SchemaSchemaObjectsPropertiesDeleteHandler sets the operation handler for the schema objects properties delete operation
+	SchemaSchemaObjectsPropertiesDeleteHandler schema.SchemaObjectsPropertiesDeleteHandler
 	// SchemaSchemaObjectsShardsGetHandler sets the operation handler for the schema objects shards get operation
 	SchemaSchemaObjectsShardsGetHandler schema.SchemaObjectsShardsGetHandler
+	// SchemaSchemaObjectsShardsRebalanceHandler sets the operation handler for the schema objects shards rebalance operation
+	SchemaSchemaObjectsShardsRebalanceHandler schema.SchemaObjectsShardsRebalanceHandler
 	// SchemaSchemaObjectsShardsUpdateHandler sets the operation handler for the schema objects shards update operation
 	SchemaSchemaObjectsShardsUpdateHandler schema.SchemaObjectsShardsUpdateHandler
 	// SchemaSchemaObjectsUpdateHandler sets the operation handler for the schema objects update operation
@@ -404,12 +435,21 @@ func (o *WeaviateAPI) Validate() error {
 	if o.WellKnownGetWellKnownOpenidConfigurationHandler == nil {
 		unregistered = append(unregistered, "well_known.GetWellKnownOpenidConfigurationHandler")
 	}
+	if o.BackupsBackupsCancelHandler == nil {
+		unregistered = append(unregistered, "backups.BackupsCancelHandler")
+	}
 	if o.BackupsBackupsCreateHandler == nil {
 		unregistered = append(unregistered, "backups.BackupsCreateHandler")
 	}
 	if o.BackupsBackupsCreateStatusHandler == nil {
 		unregistered = append(unregistered, "backups.BackupsCreateStatusHandler")
 	}
+	if o.BackupsBackupsDeleteHandler == nil {
+		unregistered = append(unregistered, "backups.BackupsDeleteHandler")
+	}
+	if o.BackupsBackupsListHandler == nil {
+		unregistered = append(unregistered, "backups.BackupsListHandler")
+	}
 	if o.BackupsBackupsRestoreHandler == nil {
 		unregistered = append(unregistered, "backups.BackupsRestoreHandler")
 	}
@@ -440,6 +480,9 @@ func (o *WeaviateAPI) Validate() error {
 	if o.MetaMetaGetHandler == nil {
 		unregistered = append(unregistered, "meta.MetaGetHandler")
 	}
+	if o.NodesNodesGetHandler == nil {
+		unregistered = append(unregistered, "nodes.NodesGetHandler")
+	}
 	if o.ObjectsObjectsClassDeleteHandler == nil {
 		unregistered = append(unregistered, "objects.ObjectsClassDeleteHandler")
 	}
@@ -512,9 +555,15 @@ func (o *WeaviateAPI) Validate() error {
 	if o.SchemaSchemaObjectsPropertiesAddHandler == nil {
 		unregistered = append(unregistered, "schema.SchemaObjectsPropertiesAddHandler")
 	}
+	if o.SchemaSchemaObjectsPropertiesDeleteHandler == nil {
+		unregistered = append(unregistered, "schema.SchemaObjectsPropertiesDeleteHandler")
+	}
 	if o.SchemaSchemaObjectsShardsGetHandler == nil {
 		unregistered = append(unregistered, "schema.SchemaObjectsShardsGetHandler")
 	}
+	if o.SchemaSchemaObjectsShardsRebalanceHandler == nil {
+		unregistered = append(unregistered, "schema.SchemaObjectsShardsRebalanceHandler")
+	}
 	if o.SchemaSchemaObjectsShardsUpdateHandler == nil {
 		unregistered = append(unregistered, "schema.SchemaObjectsShardsUpdateHandler")
 	}
@@ -637,11 +686,23 @@ func (o *WeaviateAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/backups/{storageName}/{id}/cancel"] = backups.NewBackupsCancel(o.context, o.BackupsBackupsCancelHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/backups/{storageName}"] = backups.NewBackupsCreate(o.context, o.BackupsBackupsCreateHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/backups/{storageName}/{id}"] = backups.NewBackupsCreateStatus(o.context, o.BackupsBackupsCreateStatusHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/backups/{storageName}/{id}"] = backups.NewBackupsDelete(o.context, o.BackupsBackupsDeleteHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/backups/{storageName}"] = backups.NewBackupsList(o.context, o.BackupsBackupsListHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -682,6 +743,10 @@ func (o *WeaviateAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/meta"] = meta.NewMetaGet(o.context, o.MetaMetaGetHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/nodes"] = nodes.NewNodesGet(o.context, o.NodesNodesGetHandler)
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
@@ -778,10 +843,18 @@ func (o *WeaviateAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/schema/{className}/properties"] = schema.NewSchemaObjectsPropertiesAdd(o.context, o.SchemaSchemaObjectsPropertiesAddHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/schema/{className}/properties/{propertyName}"] = schema.NewSchemaObjectsPropertiesDelete(o.context, o.SchemaSchemaObjectsPropertiesDeleteHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/schema/{className}/shards"] = schema.NewSchemaObjectsShardsGet(o.context, o.SchemaSchemaObjectsShardsGetHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/schema/{className}/shards/rebalance"] = schema.NewSchemaObjectsShardsRebalance(o.context, o.SchemaSchemaObjectsShardsRebalanceHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
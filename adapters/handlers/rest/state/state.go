@@ -22,6 +22,7 @@ import (
 	"github.com/semi-technologies/weaviate/usecases/locks"
 	"github.com/semi-technologies/weaviate/usecases/modules"
 	"github.com/semi-technologies/weaviate/usecases/monitoring"
+	"github.com/semi-technologies/weaviate/usecases/nodes"
 	"github.com/semi-technologies/weaviate/usecases/schema"
 	"github.com/semi-technologies/weaviate/usecases/sharding"
 	"github.com/sirupsen/logrus"
@@ -44,6 +45,7 @@ type State struct {
 	RemoteIncoming     *sharding.RemoteIndexIncoming
 	ClassificationRepo *classifications.DistributedRepo
 	Metrics            *monitoring.PrometheusMetrics
+	NodesManager       *nodes.Manager
 }
 
 // GetGraphQL is the safe way to retrieve GraphQL from the state as it can be
@@ -95,7 +95,7 @@ func (a *Aggregator) aggTypeOfProperty(
 	case schema.DataTypeBoolean, schema.DataTypeBooleanArray:
 		return aggregation.PropertyTypeBoolean, dt, nil
 	case schema.DataTypeText, schema.DataTypeString, schema.DataTypeTextArray,
-		schema.DataTypeStringArray:
+		schema.DataTypeStringArray, schema.DataTypeUUID, schema.DataTypeUUIDArray:
 		return aggregation.PropertyTypeText, dt, nil
 	case schema.DataTypeDate, schema.DataTypeDateArray:
 		return aggregation.PropertyTypeDate, dt, nil
@@ -103,6 +103,8 @@ func (a *Aggregator) aggTypeOfProperty(
 		return "", "", fmt.Errorf("dataType geoCoordinates can't be aggregated")
 	case schema.DataTypePhoneNumber:
 		return "", "", fmt.Errorf("dataType phoneNumber can't be aggregated")
+	case schema.DataTypeObject, schema.DataTypeObjectArray:
+		return "", "", fmt.Errorf("dataType %s can't be aggregated", dt)
 	default:
 		return "", "", fmt.Errorf("unrecoginzed dataType %v", schemaProp.DataType[0])
 	}
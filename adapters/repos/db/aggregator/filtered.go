@@ -17,6 +17,7 @@ import (
 
 	"github.com/semi-technologies/weaviate/entities/models"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/docid"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
@@ -218,6 +219,24 @@ func (fa *filteredAggregator) addPropValue(prop propAgg, value interface{}) erro
 					return err
 				}
 			}
+		case schema.DataTypeUUID:
+			asUUID, ok := value.(uuid.UUID)
+			if !ok {
+				return fmt.Errorf("expected property type uuid, received %T", value)
+			}
+			if err := analyzeString(asUUID.String()); err != nil {
+				return err
+			}
+		case schema.DataTypeUUIDArray:
+			asUUIDs, ok := value.([]uuid.UUID)
+			if !ok {
+				return fmt.Errorf("expected property type []uuid, received %T", value)
+			}
+			for _, val := range asUUIDs {
+				if err := analyzeString(val.String()); err != nil {
+					return err
+				}
+			}
 		default:
 			return fmt.Errorf("unknown datatype %v for aggregation %v", prop.dataType, aggregation.PropertyTypeText)
 		}
@@ -75,6 +75,14 @@ func (a *textAggregator) AddText(value string) error {
 	return nil
 }
 
+// AddTextRow adds a value which occurs count times at once, e.g. a row of
+// an inverted index
+func (a *textAggregator) AddTextRow(value string, count uint64) error {
+	a.count += count
+	a.itemCounter[value] += int(count)
+	return nil
+}
+
 func (a *textAggregator) insertOrdered(elem aggregation.TextOccurrence) {
 	if len(a.topPairs) == 0 {
 		a.topPairs = []aggregation.TextOccurrence{elem}
@@ -136,7 +136,12 @@ func (ua unfilteredAggregator) property(ctx context.Context,
 			return ua.boolProperty(ctx, prop)
 		}
 	case aggregation.PropertyTypeText:
-		return ua.textProperty(ctx, prop)
+		switch dt {
+		case schema.DataTypeUUID, schema.DataTypeUUIDArray:
+			return ua.uuidProperty(ctx, prop)
+		default:
+			return ua.textProperty(ctx, prop)
+		}
 	case aggregation.PropertyTypeDate:
 		return ua.dateProperty(ctx, prop)
 	case aggregation.PropertyTypeReference:
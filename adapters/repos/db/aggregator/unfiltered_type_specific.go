@@ -15,6 +15,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
@@ -285,6 +286,52 @@ func (ua unfilteredAggregator) textProperty(ctx context.Context,
 	return &out, nil
 }
 
+// uuidProperty aggregates the rows of the inverted index, as each row holds
+// a uuid and the objects it occurs in. Props without an inverted index are
+// aggregated from the objects, like text props.
+func (ua unfilteredAggregator) uuidProperty(ctx context.Context,
+	prop aggregation.ParamProperty,
+) (*aggregation.Property, error) {
+	b := ua.store.Bucket(helpers.BucketFromPropNameLSM(prop.Name.String()))
+	if b == nil {
+		return ua.textProperty(ctx, prop)
+	}
+
+	out := aggregation.Property{
+		Type:            aggregation.PropertyTypeText,
+		TextAggregation: aggregation.Text{},
+	}
+
+	agg := newTextAggregator(extractLimitFromTopOccs(prop.Aggregators))
+
+	c := b.SetCursor() // uuids never have a frequency, so it's always a Set
+	defer c.Close()
+
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := ua.parseAndAddUUIDRow(agg, k, v); err != nil {
+			return nil, err
+		}
+	}
+
+	out.TextAggregation = agg.Res()
+
+	return &out, nil
+}
+
+func (ua unfilteredAggregator) parseAndAddUUIDRow(agg *textAggregator, k []byte, v [][]byte) error {
+	if len(v) == 0 {
+		// all objects with this value were deleted
+		return nil
+	}
+
+	parsed, err := uuid.FromBytes(k)
+	if err != nil {
+		return errors.Wrap(err, "unexpected key on inverted index")
+	}
+
+	return agg.AddTextRow(parsed.String(), uint64(len(v)))
+}
+
 func (ua unfilteredAggregator) numberArrayProperty(ctx context.Context,
 	prop aggregation.ParamProperty,
 ) (*aggregation.Property, error) {
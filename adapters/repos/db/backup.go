@@ -12,82 +12,202 @@
 package db
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/errorcompounder"
-	"github.com/semi-technologies/weaviate/usecases/config"
+	"github.com/semi-technologies/weaviate/entities/schema"
 	"golang.org/x/sync/errgroup"
 )
 
 // CreateBackup creates a new active backup for all state in this index across
-// all its shards. It is safe to copy any file referenced in the snapshot, as the
-// active state in the snapshot guarantees that those files cannot be modified.
+// all its shards, no matter on which node they live. Local shards are
+// snapshotted directly, remote shards are snapshotted by the node which owns
+// them. It is safe to copy any file referenced in the snapshot, as the active
+// state in the snapshot guarantees that those files cannot be modified.
 //
 // There can only be one active snapshot at a time, and creating a snapshot will
 // fail on any Index that already has an active snapshot.
 //
 // Make sure to call ReleaseBackup for this snapshot's ID once you have finished
 // copying the files to make sure background and maintenance processes can resume.
-func (i *Index) CreateBackup(ctx context.Context, snap *backup.Snapshot) (*backup.Snapshot, error) {
-	if err := i.initSnapshot(snap.ID); err != nil {
+func (i *Index) CreateBackup(ctx context.Context, snapshotID string) (*backup.ClassSnapshot, error) {
+	if err := i.initSnapshot(snapshotID); err != nil {
 		return nil, err
 	}
 
-	var g errgroup.Group
+	snap := backup.NewClassSnapshot(i.Config.ClassName.String())
+	shardingState := i.getSchema.ShardingState(i.Config.ClassName.String())
+
+	var (
+		g            errgroup.Group
+		remoteLock   sync.Mutex
+		remoteShards []string
+	)
+
+	for _, shardName := range shardingState.AllPhysicalShards() {
+		name := shardName
+		if shardingState.IsShardLocal(name) {
+			g.Go(func() error {
+				shard := i.shard(name)
+				if shard == nil {
+					return errors.Errorf("local shard %q does not exist", name)
+				}
+				shardSnap, err := shard.createBackup(ctx)
+				if err != nil {
+					return err
+				}
+				snap.AddShard(name, shardSnap)
+				return nil
+			})
+			continue
+		}
 
-	for _, shard := range i.Shards {
-		s := shard
 		g.Go(func() error {
-			if err := s.createBackup(ctx, snap); err != nil {
-				return err
+			shardSnap, err := i.remote.CreateShardBackup(ctx, name, snapshotID)
+			if err != nil {
+				return errors.Wrapf(err, "create backup of remote shard %q", name)
 			}
+			remoteLock.Lock()
+			remoteShards = append(remoteShards, name)
+			remoteLock.Unlock()
+			snap.AddShard(name, shardSnap)
 			return nil
 		})
 	}
 
 	if err := g.Wait(); err != nil {
-		err = i.resetSnapshotOnFailedCreate(ctx, snap, err)
-		return nil, err
+		return nil, i.resetSnapshotOnFailedCreate(ctx, snapshotID, remoteShards, err)
 	}
 
-	shardingState, err := i.marshalShardingState()
+	shardingStateB, err := i.marshalShardingState()
 	if err != nil {
-		err = i.resetSnapshotOnFailedCreate(ctx, snap, err)
-		return nil, errors.Wrap(err, "create snapshot")
+		return nil, i.resetSnapshotOnFailedCreate(ctx, snapshotID, remoteShards,
+			errors.Wrap(err, "create snapshot"))
 	}
 
 	schema, err := i.marshalSchema()
 	if err != nil {
-		err = i.resetSnapshotOnFailedCreate(ctx, snap, err)
-		return nil, errors.Wrap(err, "create snapshot")
+		return nil, i.resetSnapshotOnFailedCreate(ctx, snapshotID, remoteShards,
+			errors.Wrap(err, "create snapshot"))
 	}
 
-	snap.ShardingState = shardingState
+	snap.ShardingState = shardingStateB
 	snap.Schema = schema
-	snap.ServerVersion = config.ServerVersion
 
 	return snap, nil
 }
 
 // ReleaseBackup marks the specified snapshot as inactive and restarts all
-// async background and maintenance processes. It errors if the snapshot does not exist
-// or is already inactive.
-func (i *Index) ReleaseBackup(ctx context.Context, id string) error {
-	if err := i.resumeMaintenanceCycles(ctx); err != nil {
-		return err
+// async background and maintenance processes, both for the local and for the
+// remote shards of this index.
+func (i *Index) ReleaseBackup(ctx context.Context, snapshotID string) error {
+	defer i.resetSnapshotState()
+
+	shardingState := i.getSchema.ShardingState(i.Config.ClassName.String())
+
+	var remoteShards []string
+	for _, shardName := range shardingState.AllPhysicalShards() {
+		if !shardingState.IsShardLocal(shardName) {
+			remoteShards = append(remoteShards, shardName)
+		}
+	}
+
+	ec := errorcompounder.ErrorCompounder{}
+	ec.Add(i.resumeMaintenanceCycles(ctx))
+	ec.Add(i.releaseRemoteShards(ctx, snapshotID, remoteShards))
+	return ec.ToError()
+}
+
+// ReadBackupFile opens a file which is part of a previously created snapshot
+// of this index. If the file belongs to a remote shard, it is streamed from
+// the node owning it. The caller is responsible for closing the reader.
+func (i *Index) ReadBackupFile(ctx context.Context, file backup.SnapshotFile) (io.ReadCloser, error) {
+	shardingState := i.getSchema.ShardingState(i.Config.ClassName.String())
+	if !shardingState.IsShardLocal(file.Shard) {
+		return i.remote.GetShardBackupFile(ctx, file.Shard, file.Path)
+	}
+
+	return i.openBackupFile(file.Shard, file.Path)
+}
+
+func (i *Index) IncomingCreateShardBackup(ctx context.Context, shardName,
+	snapshotID string,
+) (*backup.ShardSnapshot, error) {
+	shard := i.shard(shardName)
+	if shard == nil {
+		return nil, errors.Errorf("shard %q does not exist", shardName)
+	}
+
+	if err := i.initSnapshot(snapshotID); err != nil {
+		return nil, err
+	}
+
+	snap, err := shard.createBackup(ctx)
+	if err != nil {
+		defer i.resetSnapshotState()
+		ec := errorcompounder.ErrorCompounder{}
+		ec.Add(err)
+		ec.Add(shard.resumeMaintenanceCycles(ctx))
+		return nil, ec.ToError()
+	}
+
+	return snap, nil
+}
+
+func (i *Index) IncomingReleaseShardBackup(ctx context.Context, shardName,
+	snapshotID string,
+) error {
+	shard := i.shard(shardName)
+	if shard == nil {
+		return errors.Errorf("shard %q does not exist", shardName)
 	}
 
 	defer i.resetSnapshotState()
-	return nil
+	return shard.resumeMaintenanceCycles(ctx)
+}
+
+func (i *Index) IncomingGetShardBackupFile(ctx context.Context, shardName,
+	relPath string,
+) (io.ReadCloser, error) {
+	if i.shard(shardName) == nil {
+		return nil, errors.Errorf("shard %q does not exist", shardName)
+	}
+
+	return i.openBackupFile(shardName, relPath)
+}
+
+func (i *Index) openBackupFile(shardName, relPath string) (io.ReadCloser, error) {
+	absPath, err := backupFilePath(i.Config.RootPath, i.ID(), shardName, relPath)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(absPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open backup file %q", relPath)
+	}
+
+	return f, nil
 }
 
+// initSnapshot marks the index as being snapshotted. Calling it again with
+// the ID of the snapshot which is already in progress is not an error, as
+// the shards of a single index are snapshotted one by one when they are
+// spread across multiple nodes.
 func (i *Index) initSnapshot(id string) error {
 	i.snapshotStateLock.Lock()
 	defer i.snapshotStateLock.Unlock()
 
-	if i.snapshotState.InProgress {
+	if i.snapshotState.InProgress && i.snapshotState.SnapshotID != id {
 		return errors.Errorf(
 			"cannot create new snapshot, snapshot ‘%s’ is not yet released, this "+
 				"means its contents have not yet been fully copied to its destination, "+
@@ -102,12 +222,15 @@ func (i *Index) initSnapshot(id string) error {
 	return nil
 }
 
-func (i *Index) resetSnapshotOnFailedCreate(ctx context.Context, snap *backup.Snapshot, err error) error {
+func (i *Index) resetSnapshotOnFailedCreate(ctx context.Context, snapshotID string,
+	remoteShards []string, err error,
+) error {
 	defer i.resetSnapshotState()
 
 	ec := errorcompounder.ErrorCompounder{}
 	ec.Add(err)
 	ec.Add(i.resumeMaintenanceCycles(ctx))
+	ec.Add(i.releaseRemoteShards(ctx, snapshotID, remoteShards))
 	return ec.ToError()
 }
 
@@ -120,7 +243,7 @@ func (i *Index) resetSnapshotState() {
 func (i *Index) resumeMaintenanceCycles(ctx context.Context) error {
 	var g errgroup.Group
 
-	for _, shard := range i.Shards {
+	for _, shard := range i.localShards() {
 		s := shard
 		g.Go(func() error {
 			return s.resumeMaintenanceCycles(ctx)
@@ -134,6 +257,25 @@ func (i *Index) resumeMaintenanceCycles(ctx context.Context) error {
 	return nil
 }
 
+func (i *Index) releaseRemoteShards(ctx context.Context, snapshotID string,
+	shards []string,
+) error {
+	var g errgroup.Group
+
+	for _, shardName := range shards {
+		name := shardName
+		g.Go(func() error {
+			return i.remote.ReleaseShardBackup(ctx, name, snapshotID)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return errors.Wrap(err, "release remote shards")
+	}
+
+	return nil
+}
+
 func (i *Index) marshalShardingState() ([]byte, error) {
 	b, err := i.getSchema.ShardingState(i.Config.ClassName.String()).JSON()
 	if err != nil {
@@ -153,3 +295,177 @@ func (i *Index) marshalSchema() ([]byte, error) {
 
 	return b, err
 }
+
+// backupFilePath turns the path of a backup file, which is relative to the
+// root path, into an absolute path. As the relative path can come from an
+// external source, it is rejected unless it clearly belongs to the specified
+// shard.
+func backupFilePath(rootPath, indexID, shardName, relPath string) (string, error) {
+	shardID := indexID + "_" + shardName
+	relPath = filepath.Clean(relPath)
+
+	if filepath.IsAbs(relPath) || !(strings.HasPrefix(relPath, shardID+"_") ||
+		strings.HasPrefix(relPath, shardID+".")) ||
+		strings.Contains(relPath, "..") {
+		return "", errors.Errorf("backup file %q does not belong to shard %q",
+			relPath, shardName)
+	}
+
+	return filepath.Join(rootPath, relPath), nil
+}
+
+// RestoreBackupFile writes a single file of a backup to the node which owned
+// the file's shard at the time of the backup. It is used while the class
+// does not exist yet, which is why it lives on the DB rather than the Index.
+//
+// The file is restored as part of the specified class. If the class differs
+// from the one the file was backed up from, the file is renamed accordingly.
+// The objects of a renamed class are rewritten once their shard is loaded.
+func (d *DB) RestoreBackupFile(ctx context.Context, className string,
+	file backup.SnapshotFile, r io.Reader,
+) error {
+	relPath, err := renameBackupFile(file, className)
+	if err != nil {
+		return err
+	}
+
+	if err := d.putBackupFile(ctx, file.Node, className, file.Shard,
+		relPath, r); err != nil {
+		return err
+	}
+
+	if file.Class == className || !isObjectsBucketFile(className, file.Shard, relPath) {
+		return nil
+	}
+
+	// the objects still carry the name of the class they were backed up from,
+	// the marker makes the shard rewrite them once it is loaded
+	marker := indexID(schema.ClassName(className)) + "_" + file.Shard + renamedClassMarker
+	return d.putBackupFile(ctx, file.Node, className, file.Shard, marker,
+		strings.NewReader(file.Class))
+}
+
+func (d *DB) putBackupFile(ctx context.Context, nodeName, className,
+	shardName, relPath string, r io.Reader,
+) error {
+	if nodeName == "" || nodeName == d.config.NodeName {
+		return d.writeBackupFile(className, shardName, relPath, r)
+	}
+
+	host, ok := d.nodeResolver.NodeHostname(nodeName)
+	if !ok {
+		return errors.Errorf("resolve node name %q to host", nodeName)
+	}
+
+	return d.remoteClient.PutShardBackupFile(ctx, host, className, shardName,
+		relPath, r)
+}
+
+// isObjectsBucketFile returns whether the backup file is part of the objects
+// bucket of the shard
+func isObjectsBucketFile(className, shardName, relPath string) bool {
+	bucketPath := indexID(schema.ClassName(className)) + "_" + shardName +
+		"_lsm/" + helpers.ObjectsBucketLSM + "/"
+	return strings.HasPrefix(filepath.ToSlash(relPath), bucketPath)
+}
+
+// renameBackupFile returns the path of a backup file as if it had been
+// created by the specified class. All files of a shard are prefixed with the
+// index and shard name, so only that prefix needs to be replaced.
+func renameBackupFile(file backup.SnapshotFile, className string) (string, error) {
+	if file.Class == className {
+		return file.Path, nil
+	}
+
+	from := indexID(schema.ClassName(file.Class)) + "_" + file.Shard
+	if !strings.HasPrefix(file.Path, from) {
+		return "", errors.Errorf("backup file %q does not belong to shard %q of %s",
+			file.Path, file.Shard, file.Class)
+	}
+
+	to := indexID(schema.ClassName(className)) + "_" + file.Shard
+	return to + strings.TrimPrefix(file.Path, from), nil
+}
+
+// RestoreShardMetadata writes the doc id counter, the property length
+// tracker and the shard version of a backed up shard to the specified node.
+// These are not part of the snapshot's file list, as they are read into
+// memory when the snapshot is created.
+func (d *DB) RestoreShardMetadata(ctx context.Context, className, shardName,
+	nodeName string, meta *backup.ShardMetadata,
+) error {
+	if meta == nil {
+		return nil
+	}
+
+	files := shardMetadataFiles(indexID(schema.ClassName(className)), shardName, meta)
+	for relPath, contents := range files {
+		file := backup.SnapshotFile{
+			Class: className,
+			Node:  nodeName,
+			Shard: shardName,
+			Path:  relPath,
+		}
+		if err := d.RestoreBackupFile(ctx, className, file, bytes.NewReader(contents)); err != nil {
+			return errors.Wrapf(err, "restore metadata of shard %q", shardName)
+		}
+	}
+
+	return nil
+}
+
+// shardMetadataFiles returns the contents of the metadata files of a shard
+// by their path relative to the root path
+func shardMetadataFiles(indexID, shardName string,
+	meta *backup.ShardMetadata,
+) map[string][]byte {
+	shardID := indexID + "_" + shardName
+	files := map[string][]byte{}
+	for ext, contents := range map[string][]byte{
+		".indexcount":  meta.DocIDCounter,
+		".proplengths": meta.PropLengthTracker,
+		".version":     meta.ShardVersion,
+	} {
+		if contents != nil {
+			files[shardID+ext] = contents
+		}
+	}
+
+	return files
+}
+
+func (d *DB) IncomingPutBackupFile(ctx context.Context, indexName, shardName,
+	relPath string, r io.Reader,
+) error {
+	return d.writeBackupFile(indexName, shardName, relPath, r)
+}
+
+func (d *DB) writeBackupFile(className, shardName, relPath string, r io.Reader) error {
+	return writeShardFile(d.config.RootPath, indexID(schema.ClassName(className)),
+		shardName, relPath, r)
+}
+
+// writeShardFile writes a file of the specified shard, which does not need
+// to be loaded, to the root path
+func writeShardFile(rootPath, indexID, shardName, relPath string, r io.Reader) error {
+	absPath, err := backupFilePath(rootPath, indexID, shardName, relPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(absPath), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "create dir for backup file %q", relPath)
+	}
+
+	f, err := os.Create(absPath)
+	if err != nil {
+		return errors.Wrapf(err, "create backup file %q", relPath)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Wrapf(err, "write backup file %q", relPath)
+	}
+
+	return nil
+}
@@ -16,49 +16,37 @@ package db
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"io/fs"
-	"io/ioutil"
+	"io"
 	"os"
 	"path"
 	"regexp"
 	"strings"
-	"syscall"
 	"testing"
 	"time"
 
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/backup"
+	"github.com/semi-technologies/weaviate/entities/filters"
 	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/storobj"
-	"github.com/semi-technologies/weaviate/usecases/config"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestSnapshot_IndexLevel(t *testing.T) {
 	t.Run("successful snapshot creation", func(t *testing.T) {
-		t.Run("setup env", func(t *testing.T) {
-			var spec struct {
-				Info struct {
-					Version string `json:"version"`
-				} `json:"info"`
-			}
-
-			contents, err := ioutil.ReadFile("../../../openapi-specs/schema.json")
-			require.Nil(t, err)
-
-			require.Nil(t, json.Unmarshal(contents, &spec))
-			// this is normally set on server start up, so
-			// it needs to be manually set for this test
-			config.ServerVersion = spec.Info.Version
-		})
-
 		ctx := testCtx()
 		className := "IndexLevelSnapshotClass"
 		snapshotID := "index-level-snapshot-test"
 		now := time.Now()
-		snapshot := backup.NewSnapshot(className, snapshotID, now)
 
 		shard, index := testShard(t, ctx, className, withVectorIndexing(true))
 		// let the index age for a second so that
@@ -82,8 +70,8 @@ func TestSnapshot_IndexLevel(t *testing.T) {
 		})
 
 		t.Run("create snapshot", func(t *testing.T) {
-			snap, err := index.CreateBackup(ctx, snapshot)
-			assert.Nil(t, err)
+			snap, err := index.CreateBackup(ctx, snapshotID)
+			require.Nil(t, err)
 
 			t.Run("assert snapshot file contents", func(t *testing.T) {
 				// should have 4 files:
@@ -108,9 +96,27 @@ func TestSnapshot_IndexLevel(t *testing.T) {
 				assert.NotEmpty(t, snap.Schema)
 			})
 
-			t.Run("assert server version", func(t *testing.T) {
-				assert.NotEmpty(t, snap.ServerVersion)
-				assert.Equal(t, config.ServerVersion, snap.ServerVersion)
+			t.Run("read snapshot files", func(t *testing.T) {
+				for _, file := range snap.Files {
+					r, err := index.ReadBackupFile(ctx, file)
+					require.Nil(t, err)
+					content, err := io.ReadAll(r)
+					r.Close()
+					require.Nil(t, err)
+					assert.NotEmpty(t, content)
+
+					checksum := sha256.Sum256(content)
+					assert.Equal(t, hex.EncodeToString(checksum[:]), file.Checksum)
+				}
+			})
+
+			t.Run("reject files of other shards", func(t *testing.T) {
+				_, err := index.ReadBackupFile(ctx, backup.SnapshotFile{
+					Class: className,
+					Shard: shard.name,
+					Path:  "../" + snap.Files[0].Path,
+				})
+				assert.NotNil(t, err)
 			})
 		})
 
@@ -135,14 +141,13 @@ func TestSnapshot_IndexLevel(t *testing.T) {
 
 		className := "IndexLevelSnapshotClass"
 		snapshotID := "index-level-snapshot-test"
-		snapshot := backup.NewSnapshot(className, snapshotID, time.Now())
 
 		_, index := testShard(t, ctx, className, withVectorIndexing(true))
 
 		timeout, cancel := context.WithTimeout(context.Background(), 0)
 		defer cancel()
 
-		snap, err := index.CreateBackup(timeout, snapshot)
+		snap, err := index.CreateBackup(timeout, snapshotID)
 		assert.Nil(t, snap)
 
 		// due to concurrently running cycle shutdowns,
@@ -160,11 +165,6 @@ func TestSnapshot_IndexLevel(t *testing.T) {
 		assert.False(t, index.snapshotState.InProgress)
 		assert.Empty(t, index.snapshotState.SnapshotID)
 
-		snapPath := backup.BuildSnapshotPath(index.Config.RootPath, className, snapshotID)
-		_, err = os.Stat(snapPath)
-		expectedErr := &fs.PathError{Op: "stat", Path: snapPath, Err: syscall.ENOENT}
-		assert.Equal(t, err, expectedErr)
-
 		t.Run("cleanup", func(t *testing.T) {
 			err := index.Shutdown(ctx)
 			require.Nil(t, err)
@@ -178,7 +178,6 @@ func TestSnapshot_IndexLevel(t *testing.T) {
 		ctx := testCtx()
 		className := "IndexLevelSnapshotClass"
 		inProgressSnapshotID := "index-level-snapshot-test"
-		snapshot := backup.NewSnapshot(className, "some-new-snapshot", time.Now())
 
 		_, index := testShard(t, ctx, className, withVectorIndexing(true))
 
@@ -187,7 +186,7 @@ func TestSnapshot_IndexLevel(t *testing.T) {
 			InProgress: true,
 		}
 
-		snap, err := index.CreateBackup(ctx, snapshot)
+		snap, err := index.CreateBackup(ctx, "some-new-snapshot")
 		assert.Nil(t, snap)
 
 		expectedErr := fmt.Errorf("cannot create new snapshot, snapshot ‘%s’ "+
@@ -271,3 +270,217 @@ func TestSnapshot_BucketLevel(t *testing.T) {
 		require.Nil(t, os.RemoveAll(shard.index.Config.RootPath))
 	})
 }
+
+func TestBackupFilePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		relPath  string
+		expected string
+	}{
+		{
+			name:     "lsm segment",
+			relPath:  "myclass_abc_lsm/objects/segment-123.db",
+			expected: "/data/myclass_abc_lsm/objects/segment-123.db",
+		},
+		{
+			name:     "hnsw commit log",
+			relPath:  "myclass_abc.hnsw.commitlog.d/1234",
+			expected: "/data/myclass_abc.hnsw.commitlog.d/1234",
+		},
+		{
+			name:     "shard metadata",
+			relPath:  "myclass_abc.indexcount",
+			expected: "/data/myclass_abc.indexcount",
+		},
+		{
+			name:    "other shard",
+			relPath: "myclass_abcd_lsm/objects/segment-123.db",
+		},
+		{
+			name:    "other index",
+			relPath: "otherclass_abc_lsm/objects/segment-123.db",
+		},
+		{
+			name:    "outside of root path",
+			relPath: "myclass_abc_lsm/../../etc/passwd",
+		},
+		{
+			name:    "absolute path",
+			relPath: "/myclass_abc_lsm/objects/segment-123.db",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			absPath, err := backupFilePath("/data", "myclass", "abc", test.relPath)
+			if test.expected == "" {
+				assert.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, absPath)
+		})
+	}
+}
+
+func TestRenameBackupFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		className string
+		relPath   string
+		expected  string
+	}{
+		{
+			name:      "same class",
+			className: "MyClass",
+			relPath:   "myclass_abc_lsm/objects/segment-123.db",
+			expected:  "myclass_abc_lsm/objects/segment-123.db",
+		},
+		{
+			name:      "lsm segment",
+			className: "MyClass_restored",
+			relPath:   "myclass_abc_lsm/objects/segment-123.db",
+			expected:  "myclass_restored_abc_lsm/objects/segment-123.db",
+		},
+		{
+			name:      "hnsw commit log",
+			className: "OtherClass",
+			relPath:   "myclass_abc.hnsw.commitlog.d/1234",
+			expected:  "otherclass_abc.hnsw.commitlog.d/1234",
+		},
+		{
+			name:      "other index",
+			className: "OtherClass",
+			relPath:   "thirdclass_abc_lsm/objects/segment-123.db",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			relPath, err := renameBackupFile(backup.SnapshotFile{
+				Class: "MyClass",
+				Shard: "abc",
+				Path:  test.relPath,
+			}, test.className)
+			if test.expected == "" {
+				assert.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, relPath)
+		})
+	}
+}
+
+func TestRestoreBackup_RenamedClass(t *testing.T) {
+	ctx := testCtx()
+	repo, logger := setupMultiShardTest(t)
+	defer repo.Shutdown(context.Background())
+
+	sourceClass := &models.Class{
+		Class:               "RenameSourceClass",
+		VectorIndexConfig:   hnsw.NewDefaultUserConfig(),
+		InvertedIndexConfig: invertedConfig(),
+		Properties: []*models.Property{
+			{
+				Name:         "name",
+				DataType:     []string{string(schema.DataTypeText)},
+				Tokenization: models.PropertyTokenizationWord,
+			},
+		},
+	}
+	targetClass := &models.Class{
+		Class:               "RenameTargetClass",
+		VectorIndexConfig:   sourceClass.VectorIndexConfig,
+		InvertedIndexConfig: sourceClass.InvertedIndexConfig,
+		Properties:          sourceClass.Properties,
+	}
+	ids := []strfmt.UUID{
+		"5a1c3e7f-2b4d-4c6e-8f0a-1b2c3d4e5f01",
+		"6b2d4f8a-3c5e-4d7f-9a1b-2c3d4e5f6a02",
+		"7c3e5a9b-4d6f-4e8a-8b2c-3d4e5f6a7b03",
+	}
+
+	t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, sourceClass))
+
+	t.Run("insert data", func(t *testing.T) {
+		for i, id := range ids {
+			require.Nil(t, repo.PutObject(ctx, &models.Object{
+				ID:         id,
+				Class:      sourceClass.Class,
+				Properties: map[string]interface{}{"name": fmt.Sprintf("object %d", i)},
+			}, []float32{1, 2, float32(i)}))
+		}
+	})
+
+	t.Run("restore backup under a new name", func(t *testing.T) {
+		index := repo.GetIndex(schema.ClassName(sourceClass.Class))
+		snap, err := index.CreateBackup(ctx, "rename-class-snapshot")
+		require.Nil(t, err)
+
+		for _, file := range snap.Files {
+			r, err := index.ReadBackupFile(ctx, file)
+			require.Nil(t, err)
+			err = repo.RestoreBackupFile(ctx, targetClass.Class, file, r)
+			r.Close()
+			require.Nil(t, err)
+		}
+
+		for shardName, meta := range snap.ShardMetadata {
+			require.Nil(t, repo.RestoreShardMetadata(ctx, targetClass.Class,
+				shardName, "", meta))
+		}
+
+		require.Nil(t, index.ReleaseBackup(ctx, "rename-class-snapshot"))
+
+		schemaGetter := repo.schemaGetter.(*fakeSchemaGetter)
+		require.Nil(t, NewMigrator(repo, logger).AddClass(ctx, targetClass,
+			schemaGetter.shardState))
+		schemaGetter.setSchema(schema.Schema{
+			Objects: &models.Schema{
+				Classes: []*models.Class{sourceClass, targetClass},
+			},
+		})
+	})
+
+	t.Run("objects are read back with the new class name", func(t *testing.T) {
+		for _, id := range ids {
+			res, err := repo.Object(ctx, targetClass.Class, id,
+				search.SelectProperties{}, additional.Properties{})
+			require.Nil(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, targetClass.Class, res.ClassName)
+			assert.Equal(t, targetClass.Class, res.Object().Class)
+		}
+	})
+
+	t.Run("search results carry the new class name", func(t *testing.T) {
+		res, err := repo.ClassSearch(ctx, traverser.GetParams{
+			ClassName:  targetClass.Class,
+			Pagination: &filters.Pagination{Limit: 10},
+		})
+		require.Nil(t, err)
+		require.Len(t, res, len(ids))
+		for _, r := range res {
+			assert.Equal(t, targetClass.Class, r.ClassName)
+		}
+	})
+
+	t.Run("the source class keeps its name", func(t *testing.T) {
+		res, err := repo.Object(ctx, sourceClass.Class, ids[0],
+			search.SelectProperties{}, additional.Properties{})
+		require.Nil(t, err)
+		require.NotNil(t, res)
+		assert.Equal(t, sourceClass.Class, res.ClassName)
+	})
+
+	t.Run("the renamed class marker is removed", func(t *testing.T) {
+		for _, shard := range repo.GetIndex(schema.ClassName(targetClass.Class)).localShards() {
+			_, err := os.Stat(path.Join(shard.index.Config.RootPath,
+				shard.ID()+renamedClassMarker))
+			assert.True(t, os.IsNotExist(err))
+		}
+	})
+}
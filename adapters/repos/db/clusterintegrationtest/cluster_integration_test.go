@@ -66,22 +66,32 @@ const (
 func TestDistributedSetup(t *testing.T) {
 	t.Run("individual imports", func(t *testing.T) {
 		dirName := setupDirectory(t)
-		testDistributed(t, dirName, false)
+		testDistributed(t, dirName, false, 1)
 	})
 
 	t.Run("batched imports", func(t *testing.T) {
 		dirName := setupDirectory(t)
-		testDistributed(t, dirName, true)
+		testDistributed(t, dirName, true, 1)
+	})
+
+	t.Run("individual imports with replication", func(t *testing.T) {
+		dirName := setupDirectory(t)
+		testDistributed(t, dirName, false, 3)
+	})
+
+	t.Run("batched imports with replication", func(t *testing.T) {
+		dirName := setupDirectory(t)
+		testDistributed(t, dirName, true, 3)
 	})
 }
 
-func testDistributed(t *testing.T, dirName string, batch bool) {
+func testDistributed(t *testing.T, dirName string, batch bool, replicas int) {
 	var nodes []*node
 	numberOfNodes := 10
 	numberOfObjects := 200
 
 	t.Run("setup", func(t *testing.T) {
-		overallShardState := multiShardState(numberOfNodes)
+		overallShardState := multiShardState(numberOfNodes, replicas)
 		shardStateSerialized, err := json.Marshal(overallShardState)
 		require.Nil(t, err)
 
@@ -177,6 +187,19 @@ func testDistributed(t *testing.T, dirName string, batch bool) {
 		}
 	})
 
+	if replicas > 1 {
+		t.Run("query individually to check if all exist on every replica", func(t *testing.T) {
+			// nodes holding a replica of the shard serve the query locally
+			for _, obj := range data {
+				for _, node := range nodes {
+					ok, err := node.repo.Exists(context.Background(), distributedClass, obj.ID)
+					require.Nil(t, err)
+					assert.True(t, ok, "object %s on %s", obj.ID, node.name)
+				}
+			}
+		})
+	}
+
 	t.Run("query individually using random node", func(t *testing.T) {
 		for _, obj := range data {
 			node := nodes[rand.Intn(len(nodes))]
@@ -712,6 +735,7 @@ func (n *node) init(numberOfNodes int, dirName string, shardStateRaw []byte,
 		DiskUseWarningPercentage:  config.DefaultDiskUseWarningPercentage,
 		DiskUseReadOnlyPercentage: config.DefaultDiskUseReadonlyPercentage,
 		MaxImportGoroutinesFactor: 1,
+		NodeName:                  n.name,
 	}, client, nodeResolver, nil)
 	n.schemaGetter = &fakeSchemaGetter{
 		shardState: shardState,
@@ -737,9 +761,10 @@ func (n *node) init(numberOfNodes int, dirName string, shardStateRaw []byte,
 	n.hostname = u.Host
 }
 
-func multiShardState(nodeCount int) *sharding.State {
+func multiShardState(nodeCount, replicas int) *sharding.State {
 	config, err := sharding.ParseConfig(map[string]interface{}{
 		"desiredCount": json.Number(fmt.Sprintf("%d", nodeCount)),
+		"replicas":     json.Number(fmt.Sprintf("%d", replicas)),
 	}, 1)
 	if err != nil {
 		panic(err)
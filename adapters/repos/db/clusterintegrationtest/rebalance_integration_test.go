@@ -0,0 +1,223 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package clusterintegrationtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/search"
+	"github.com/semi-technologies/weaviate/entities/storagestate"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDistributedRebalance starts out with a single shard on the first node,
+// as if the other nodes had been added to the cluster after the class was
+// created, and spreads it across all nodes
+func TestDistributedRebalance(t *testing.T) {
+	dirName := setupDirectory(t)
+	ctx := context.Background()
+
+	var nodes []*node
+	numberOfNodes := 3
+	numberOfObjects := 200
+
+	t.Run("setup", func(t *testing.T) {
+		state := singleShardState()
+		stateSerialized, err := json.Marshal(state)
+		require.Nil(t, err)
+
+		for i := 0; i < numberOfNodes; i++ {
+			node := &node{
+				name: fmt.Sprintf("node-%d", i),
+			}
+
+			node.init(numberOfNodes, dirName, stateSerialized, &nodes)
+			nodes = append(nodes, node)
+		}
+	})
+
+	t.Run("apply schema", func(t *testing.T) {
+		for i := range nodes {
+			err := nodes[i].migrator.AddClass(ctx, class(),
+				nodes[i].schemaGetter.shardState)
+			require.Nil(t, err)
+			nodes[i].schemaGetter.schema.Objects.Classes = append(
+				nodes[i].schemaGetter.schema.Objects.Classes, class())
+		}
+	})
+
+	data := exampleData(numberOfObjects)
+
+	t.Run("import", func(t *testing.T) {
+		node := nodes[rand.Intn(len(nodes))]
+
+		res, err := node.repo.BatchPutObjects(ctx, dataAsBatch(data))
+		require.Nil(t, err)
+		for _, ind := range res {
+			require.Nil(t, ind.Err)
+		}
+	})
+
+	var plan *sharding.RebalancePlan
+
+	t.Run("plan", func(t *testing.T) {
+		var err error
+		plan, err = nodes[0].schemaGetter.shardState.PlanRebalance(
+			[]string{"node-0", "node-1", "node-2"})
+		require.Nil(t, err)
+		require.Len(t, plan.Transfers, 2)
+	})
+
+	t.Run("mark source shards as read-only", func(t *testing.T) {
+		for _, transfer := range plan.Transfers {
+			err := nodes[0].migrator.UpdateShardStatus(ctx, distributedClass,
+				transfer.Shard, storagestate.StatusReadOnly.String())
+			require.Nil(t, err)
+		}
+	})
+
+	t.Run("transfer shards from a node which does not hold them", func(t *testing.T) {
+		err := nodes[1].migrator.TransferShards(ctx, distributedClass, plan.Transfers)
+		require.Nil(t, err)
+	})
+
+	t.Run("apply staged state", func(t *testing.T) {
+		applyShardingState(t, nodes, plan.Staged)
+	})
+
+	t.Run("all objects are still served by the original shard", func(t *testing.T) {
+		assertAllObjectsOnce(t, nodes, data)
+	})
+
+	t.Run("apply final state", func(t *testing.T) {
+		applyShardingState(t, nodes, plan.Final)
+	})
+
+	t.Run("mark all involved shards as ready", func(t *testing.T) {
+		for _, transfer := range plan.Transfers {
+			for _, shard := range []string{transfer.Shard, transfer.TargetShard} {
+				err := nodes[0].migrator.UpdateShardStatus(ctx, distributedClass,
+					shard, storagestate.StatusReady.String())
+				require.Nil(t, err)
+			}
+		}
+	})
+
+	t.Run("every node holds a shard", func(t *testing.T) {
+		for _, node := range nodes {
+			assert.Len(t, node.schemaGetter.shardState.AllLocalPhysicalShards(), 1,
+				"shards of %s", node.name)
+		}
+	})
+
+	t.Run("all objects are served by the split shards", func(t *testing.T) {
+		assertAllObjectsOnce(t, nodes, data)
+	})
+
+	t.Run("objects can be written again", func(t *testing.T) {
+		for _, obj := range exampleData(20) {
+			node := nodes[rand.Intn(len(nodes))]
+			err := node.repo.PutObject(ctx, obj, obj.Vector)
+			require.Nil(t, err)
+			data = append(data, obj)
+		}
+
+		assertAllObjectsOnce(t, nodes, data)
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		for _, node := range nodes {
+			node.repo.Shutdown(ctx)
+		}
+	})
+}
+
+func singleShardState() *sharding.State {
+	config, err := sharding.ParseConfig(map[string]interface{}{
+		"desiredCount": json.Number("1"),
+	}, 1)
+	if err != nil {
+		panic(err)
+	}
+
+	s, err := sharding.InitState("rebalance-test-index", config,
+		fakeNodes{[]string{"node-0"}})
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// applyShardingState does what the schema manager does when a new sharding
+// state is committed, on every node
+func applyShardingState(t *testing.T, nodes []*node, state *sharding.State) {
+	stateSerialized, err := json.Marshal(state)
+	require.Nil(t, err)
+
+	for _, node := range nodes {
+		local, err := sharding.StateFromJSON(stateSerialized, nodeResolver{
+			nodes: &nodes,
+			local: node.name,
+		})
+		require.Nil(t, err)
+
+		err = node.migrator.LoadShards(context.Background(), distributedClass, local)
+		require.Nil(t, err)
+
+		previous := node.schemaGetter.shardState
+		node.schemaGetter.shardState = local
+
+		err = node.migrator.UnloadShards(context.Background(), distributedClass, previous)
+		require.Nil(t, err)
+	}
+}
+
+func assertAllObjectsOnce(t *testing.T, nodes []*node, data []*models.Object) {
+	for _, node := range nodes {
+		for _, obj := range data {
+			ok, err := node.repo.Exists(context.Background(), distributedClass, obj.ID)
+			require.Nil(t, err)
+			assert.True(t, ok, "object %s on %s", obj.ID, node.name)
+		}
+
+		res, err := node.repo.ClassSearch(context.Background(), traverser.GetParams{
+			ClassName:  distributedClass,
+			Pagination: &filters.Pagination{Limit: 10000},
+		})
+		require.Nil(t, err)
+		assert.Len(t, res, len(data), "class search on %s", node.name)
+
+		obj := data[rand.Intn(len(data))]
+		vectorRes, err := node.repo.VectorClassSearch(context.Background(), traverser.GetParams{
+			ClassName:    distributedClass,
+			SearchVector: obj.Vector,
+			Pagination:   &filters.Pagination{Limit: 1},
+			Properties:   search.SelectProperties{},
+		})
+		require.Nil(t, err)
+		require.Len(t, vectorRes, 1)
+		assert.Equal(t, obj.ID, vectorRes[0].ID, "vector search on %s", node.name)
+	}
+}
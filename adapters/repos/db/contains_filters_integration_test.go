@@ -0,0 +1,252 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/aggregation"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_ContainsFilters(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "ContainsFilters"
+
+	const (
+		idA = "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c01"
+		idB = "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d02"
+		idC = "3c4d5e6f-7a8b-4c9d-8e1f-2a3b4c5d6e03"
+	)
+
+	owner1 := uuid.MustParse("28f3f61b-b524-45e0-9bbe-2c1550bf73d2")
+	owner2 := uuid.MustParse("0ca2a9d9-6d3f-4b0b-8c4e-3f0f3c4b7a5e")
+
+	t.Run("prepare", func(t *testing.T) {
+		class := &models.Class{
+			Class:             className,
+			VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+			InvertedIndexConfig: &models.InvertedIndexConfig{
+				CleanupIntervalSeconds: 60,
+			},
+			Properties: []*models.Property{
+				{
+					Name:         "tags",
+					DataType:     []string{string(schema.DataTypeStringArray)},
+					Tokenization: models.PropertyTokenizationField,
+				},
+				{
+					Name:         "description",
+					DataType:     []string{string(schema.DataTypeText)},
+					Tokenization: models.PropertyTokenizationWord,
+				},
+				{
+					Name:     "sizes",
+					DataType: []string{string(schema.DataTypeIntArray)},
+				},
+				{
+					Name:     "ownerIds",
+					DataType: []string{string(schema.DataTypeUUIDArray)},
+				},
+			},
+		}
+
+		t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+	})
+
+	t.Run("insert data", func(t *testing.T) {
+		data := []struct {
+			id          string
+			tags        []string
+			description string
+			sizes       []float64
+			owners      []uuid.UUID
+		}{
+			{idA, []string{"red", "blue"}, "a small red car", []float64{1, 2}, []uuid.UUID{owner1}},
+			{idB, []string{"blue", "green"}, "a big blue truck", []float64{2, 3}, []uuid.UUID{owner1, owner2}},
+			{idC, []string{"yellow"}, "a yellow submarine", []float64{4}, []uuid.UUID{}},
+		}
+
+		objs := make(objects.BatchObjects, len(data))
+		for i, d := range data {
+			objs[i] = objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          strfmt.UUID(d.id),
+				Object: &models.Object{
+					ID:    strfmt.UUID(d.id),
+					Class: className,
+					Properties: map[string]interface{}{
+						"tags":        d.tags,
+						"description": d.description,
+						"sizes":       d.sizes,
+						"ownerIds":    d.owners,
+					},
+				},
+			}
+		}
+
+		_, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+	})
+
+	tests := []struct {
+		name     string
+		operator filters.Operator
+		prop     string
+		value    interface{}
+		dataType schema.DataType
+		expected []string
+	}{
+		{
+			name:     "contains any of the strings",
+			operator: filters.OperatorContainsAny,
+			prop:     "tags",
+			value:    []string{"red", "green", "purple"},
+			dataType: schema.DataTypeStringArray,
+			expected: []string{idA, idB},
+		},
+		{
+			name:     "contains all of the strings",
+			operator: filters.OperatorContainsAll,
+			prop:     "tags",
+			value:    []string{"blue", "green"},
+			dataType: schema.DataTypeStringArray,
+			expected: []string{idB},
+		},
+		{
+			name:     "contains all of the strings, where one is not present at all",
+			operator: filters.OperatorContainsAll,
+			prop:     "tags",
+			value:    []string{"blue", "purple"},
+			dataType: schema.DataTypeStringArray,
+			expected: []string{},
+		},
+		{
+			name:     "contains any of the terms of a text",
+			operator: filters.OperatorContainsAny,
+			prop:     "description",
+			value:    []string{"Car", "yellow boat"},
+			dataType: schema.DataTypeTextArray,
+			expected: []string{idA, idC},
+		},
+		{
+			name:     "contains all of the terms of a text",
+			operator: filters.OperatorContainsAll,
+			prop:     "description",
+			value:    []string{"blue", "big truck"},
+			dataType: schema.DataTypeTextArray,
+			expected: []string{idB},
+		},
+		{
+			name:     "contains any of the ints",
+			operator: filters.OperatorContainsAny,
+			prop:     "sizes",
+			value:    []int{1, 4},
+			dataType: schema.DataTypeIntArray,
+			expected: []string{idA, idC},
+		},
+		{
+			name:     "contains all of the ints, given twice",
+			operator: filters.OperatorContainsAll,
+			prop:     "sizes",
+			value:    []int{2, 3, 2},
+			dataType: schema.DataTypeIntArray,
+			expected: []string{idB},
+		},
+		{
+			name:     "contains all of the uuids",
+			operator: filters.OperatorContainsAll,
+			prop:     "ownerIds",
+			value:    []string{owner1.String(), owner2.String()},
+			dataType: schema.DataTypeUUIDArray,
+			expected: []string{idB},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := &filters.LocalFilter{Root: &filters.Clause{
+				Operator: test.operator,
+				On: &filters.Path{
+					Class:    schema.ClassName(className),
+					Property: schema.PropertyName(test.prop),
+				},
+				Value: &filters.Value{
+					Value: test.value,
+					Type:  test.dataType,
+				},
+			}}
+
+			t.Run("search objects", func(t *testing.T) {
+				res, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+					ClassName:  className,
+					Pagination: &filters.Pagination{Limit: 10},
+					Filters:    filter,
+				})
+				require.Nil(t, err)
+
+				ids := make([]string, len(res))
+				for i := range res {
+					ids[i] = res[i].ID.String()
+				}
+				assert.ElementsMatch(t, test.expected, ids)
+			})
+
+			t.Run("delete objects, as a dry run", func(t *testing.T) {
+				res, err := repo.BatchDeleteObjects(context.Background(), objects.BatchDeleteParams{
+					ClassName: schema.ClassName(className),
+					Filters:   filter,
+					DryRun:    true,
+					Output:    "verbose",
+				})
+				require.Nil(t, err)
+
+				ids := make([]string, len(res.Objects))
+				for i := range res.Objects {
+					ids[i] = res.Objects[i].UUID.String()
+				}
+				assert.ElementsMatch(t, test.expected, ids)
+			})
+
+			t.Run("count objects", func(t *testing.T) {
+				// the second aggregation is served by the filter cache
+				for i := 0; i < 2; i++ {
+					res, err := repo.Aggregate(context.Background(), aggregation.Params{
+						ClassName:        schema.ClassName(className),
+						Filters:          filter,
+						IncludeMetaCount: true,
+					})
+					require.Nil(t, err)
+					require.Len(t, res.Groups, 1)
+					assert.Equal(t, len(test.expected), res.Groups[0].Count)
+				}
+			})
+		})
+	}
+}
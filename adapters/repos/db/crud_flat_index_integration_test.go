@@ -0,0 +1,123 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/config"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRUD_FlatVectorIndex(t *testing.T) {
+	dirName := t.TempDir()
+
+	logger, _ := test.NewNullLogger()
+	class := &models.Class{
+		Class:               "FlatIndexedClass",
+		VectorIndexType:     flat.IndexType,
+		VectorIndexConfig:   flat.UserConfig{Distance: "l2-squared"},
+		InvertedIndexConfig: invertedConfig(),
+		Properties: []*models.Property{{
+			Name:         "name",
+			DataType:     []string{string(schema.DataTypeString)},
+			Tokenization: "word",
+		}},
+	}
+	schemaGetter := &fakeSchemaGetter{shardState: singleShardState()}
+	repo := New(logger, Config{
+		RootPath:                  dirName,
+		QueryMaximumResults:       10000,
+		DiskUseWarningPercentage:  config.DefaultDiskUseWarningPercentage,
+		DiskUseReadOnlyPercentage: config.DefaultDiskUseReadonlyPercentage,
+		MaxImportGoroutinesFactor: 1,
+	}, &fakeRemoteClient{}, &fakeNodeResolver{}, nil)
+	repo.SetSchemaGetter(schemaGetter)
+	require.Nil(t, repo.WaitForStartup(testCtx()))
+	defer repo.Shutdown(context.Background())
+	migrator := NewMigrator(repo, logger)
+
+	t.Run("creating the class", func(t *testing.T) {
+		require.Nil(t,
+			migrator.AddClass(context.Background(), class, schemaGetter.shardState))
+
+		schemaGetter.schema = schema.Schema{
+			Objects: &models.Schema{
+				Classes: []*models.Class{class},
+			},
+		}
+	})
+
+	ids := []strfmt.UUID{
+		"8d5a3aa2-3c8d-4589-9ae1-3f638f506970",
+		"9a0ac6f2-8e4a-4f0c-8d2e-7c1c4c0b1b8e",
+		"b2d5b5d4-6a39-4d6e-9b0f-7f6f5ab0c8a1",
+	}
+	vectors := [][]float32{{1, 0}, {0, 1}, {0.8, 0.2}}
+
+	t.Run("importing objects", func(t *testing.T) {
+		for i, id := range ids {
+			obj := &models.Object{
+				ID:         id,
+				Class:      class.Class,
+				Properties: map[string]interface{}{"name": "object"},
+			}
+			require.Nil(t, repo.PutObject(context.Background(), obj, vectors[i]))
+		}
+	})
+
+	search := func(t *testing.T) []strfmt.UUID {
+		res, err := repo.VectorClassSearch(context.Background(), traverser.GetParams{
+			SearchVector: []float32{1, 0},
+			ClassName:    class.Class,
+			Pagination:   &filters.Pagination{Limit: 10},
+		})
+		require.Nil(t, err)
+
+		var found []strfmt.UUID
+		for _, obj := range res {
+			found = append(found, obj.ID)
+		}
+		return found
+	}
+
+	t.Run("searching by vector returns exact results", func(t *testing.T) {
+		assert.Equal(t, []strfmt.UUID{ids[0], ids[2], ids[1]}, search(t))
+	})
+
+	t.Run("deleted objects are no longer found", func(t *testing.T) {
+		require.Nil(t, repo.DeleteObject(context.Background(), class.Class, ids[0]))
+		assert.Equal(t, []strfmt.UUID{ids[2], ids[1]}, search(t))
+	})
+
+	t.Run("updated vectors are searched", func(t *testing.T) {
+		obj := &models.Object{
+			ID:         ids[1],
+			Class:      class.Class,
+			Properties: map[string]interface{}{"name": "object"},
+		}
+		require.Nil(t, repo.PutObject(context.Background(), obj, []float32{1, 0.1}))
+		assert.Equal(t, []strfmt.UUID{ids[1], ids[2]}, search(t))
+	})
+}
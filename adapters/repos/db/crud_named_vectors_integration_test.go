@@ -0,0 +1,179 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/additional"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/config"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRUD_NamedVectors(t *testing.T) {
+	dirName := t.TempDir()
+
+	logger, _ := test.NewNullLogger()
+	class := &models.Class{
+		Class:               "NamedVectorsClass",
+		VectorIndexConfig:   hnsw.NewDefaultUserConfig(),
+		InvertedIndexConfig: invertedConfig(),
+		VectorConfig: map[string]models.VectorConfig{
+			"title": {
+				Vectorizer:        map[string]interface{}{"none": map[string]interface{}{}},
+				VectorIndexType:   flat.IndexType,
+				VectorIndexConfig: flat.UserConfig{Distance: "l2-squared"},
+			},
+			"image": {
+				Vectorizer:        map[string]interface{}{"none": map[string]interface{}{}},
+				VectorIndexType:   "hnsw",
+				VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+			},
+		},
+		Properties: []*models.Property{{
+			Name:         "name",
+			DataType:     []string{string(schema.DataTypeString)},
+			Tokenization: "word",
+		}},
+	}
+	schemaGetter := &fakeSchemaGetter{shardState: singleShardState()}
+	repo := New(logger, Config{
+		RootPath:                  dirName,
+		QueryMaximumResults:       10000,
+		DiskUseWarningPercentage:  config.DefaultDiskUseWarningPercentage,
+		DiskUseReadOnlyPercentage: config.DefaultDiskUseReadonlyPercentage,
+		MaxImportGoroutinesFactor: 1,
+	}, &fakeRemoteClient{}, &fakeNodeResolver{}, nil)
+	repo.SetSchemaGetter(schemaGetter)
+	require.Nil(t, repo.WaitForStartup(testCtx()))
+	defer repo.Shutdown(context.Background())
+	migrator := NewMigrator(repo, logger)
+
+	t.Run("creating the class", func(t *testing.T) {
+		require.Nil(t,
+			migrator.AddClass(context.Background(), class, schemaGetter.shardState))
+
+		schemaGetter.schema = schema.Schema{
+			Objects: &models.Schema{
+				Classes: []*models.Class{class},
+			},
+		}
+	})
+
+	ids := []strfmt.UUID{
+		"8d5a3aa2-3c8d-4589-9ae1-3f638f506970",
+		"9a0ac6f2-8e4a-4f0c-8d2e-7c1c4c0b1b8e",
+		"b2d5b5d4-6a39-4d6e-9b0f-7f6f5ab0c8a1",
+	}
+	titleVectors := [][]float32{{1, 0}, {0, 1}, {0.8, 0.2}}
+	imageVectors := [][]float32{{0, 1, 0}, {1, 0, 0}, {0.1, 0.9, 0}}
+
+	t.Run("importing objects", func(t *testing.T) {
+		for i, id := range ids {
+			obj := &models.Object{
+				ID:         id,
+				Class:      class.Class,
+				Properties: map[string]interface{}{"name": "object"},
+				Vectors: models.Vectors{
+					"title": titleVectors[i],
+					"image": imageVectors[i],
+				},
+			}
+			require.Nil(t, repo.PutObject(context.Background(), obj, nil))
+		}
+	})
+
+	search := func(t *testing.T, targetVector string, vector []float32) []strfmt.UUID {
+		res, err := repo.VectorClassSearch(context.Background(), traverser.GetParams{
+			SearchVector: vector,
+			TargetVector: targetVector,
+			ClassName:    class.Class,
+			Pagination:   &filters.Pagination{Limit: 10},
+		})
+		require.Nil(t, err)
+
+		var found []strfmt.UUID
+		for _, obj := range res {
+			found = append(found, obj.ID)
+		}
+		return found
+	}
+
+	t.Run("each named vector is searched in its own index", func(t *testing.T) {
+		assert.Equal(t, []strfmt.UUID{ids[0], ids[2], ids[1]},
+			search(t, "title", []float32{1, 0}))
+		assert.Equal(t, []strfmt.UUID{ids[1], ids[2], ids[0]},
+			search(t, "image", []float32{1, 0, 0}))
+	})
+
+	t.Run("searching an unknown named vector fails", func(t *testing.T) {
+		_, err := repo.VectorClassSearch(context.Background(), traverser.GetParams{
+			SearchVector: []float32{1, 0},
+			TargetVector: "unknown",
+			ClassName:    class.Class,
+			Pagination:   &filters.Pagination{Limit: 10},
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unknown")
+	})
+
+	t.Run("named vectors are returned with the object", func(t *testing.T) {
+		res, err := repo.Object(context.Background(), class.Class, ids[0], nil,
+			additional.Properties{Vectors: true})
+		require.Nil(t, err)
+		require.NotNil(t, res)
+		assert.Equal(t, map[string][]float32{
+			"title": titleVectors[0],
+			"image": imageVectors[0],
+		}, res.Vectors)
+	})
+
+	t.Run("merging a named vector keeps the other ones", func(t *testing.T) {
+		err := repo.Merge(context.Background(), objects.MergeDocument{
+			Class:           class.Class,
+			ID:              ids[1],
+			PrimitiveSchema: map[string]interface{}{"name": "updated"},
+			Vectors:         map[string][]float32{"title": {1, 0.1}},
+		})
+		require.Nil(t, err)
+
+		res, err := repo.Object(context.Background(), class.Class, ids[1], nil,
+			additional.Properties{Vectors: true})
+		require.Nil(t, err)
+		require.NotNil(t, res)
+		assert.Equal(t, []float32{1, 0.1}, res.Vectors["title"])
+		assert.Equal(t, imageVectors[1], res.Vectors["image"])
+
+		assert.Equal(t, ids[1], search(t, "title", []float32{1, 0.1})[0])
+		assert.Equal(t, ids[1], search(t, "image", []float32{1, 0, 0})[0])
+	})
+
+	t.Run("deleted objects are removed from every index", func(t *testing.T) {
+		require.Nil(t, repo.DeleteObject(context.Background(), class.Class, ids[1]))
+		assert.Equal(t, []strfmt.UUID{ids[0], ids[2]}, search(t, "title", []float32{1, 0}))
+		assert.Equal(t, []strfmt.UUID{ids[2], ids[0]}, search(t, "image", []float32{1, 0, 0}))
+	})
+}
@@ -48,7 +48,7 @@ func (d *DB) scanDiskUse() {
 			case <-t:
 				d.indexLock.Lock()
 				for _, i := range d.indices {
-					for _, s := range i.Shards {
+					for _, s := range i.localShards() {
 						diskPath := i.Config.RootPath
 						du := d.getDiskUse(diskPath)
 
@@ -0,0 +1,245 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/additional"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/search"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_DropProperty(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "DropPropertyProducts"
+	ids := []strfmt.UUID{
+		"1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d01",
+		"2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e02",
+		"3d4e5f6a-7b8c-4d9e-8f1a-2b3c4d5e6f03",
+	}
+	readdedID := strfmt.UUID("4e5f6a7b-8c9d-4e0f-9a2b-3c4d5e6f7a04")
+
+	class := &models.Class{
+		Class:             className,
+		VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+		InvertedIndexConfig: &models.InvertedIndexConfig{
+			CleanupIntervalSeconds: 60,
+			IndexPositions:         true,
+		},
+		Properties: []*models.Property{
+			{
+				Name:         "name",
+				DataType:     []string{string(schema.DataTypeText)},
+				Tokenization: models.PropertyTokenizationWord,
+			},
+			{
+				Name:         "description",
+				DataType:     []string{string(schema.DataTypeText)},
+				Tokenization: models.PropertyTokenizationWord,
+			},
+			{
+				Name:     "price",
+				DataType: []string{string(schema.DataTypeInt)},
+			},
+			{
+				Name:     "location",
+				DataType: []string{string(schema.DataTypeGeoCoordinates)},
+			},
+		},
+	}
+	migrator := NewMigrator(repo, logger)
+
+	t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+
+	t.Run("insert data", func(t *testing.T) {
+		var objs objects.BatchObjects
+		for i, id := range ids {
+			objs = append(objs, objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          id,
+				Object: &models.Object{
+					ID:    id,
+					Class: className,
+					Properties: map[string]interface{}{
+						"name":        "product",
+						"description": "a fine product",
+						"price":       int64(10 + i),
+						"location": &models.GeoCoordinates{
+							Latitude:  ptFloat32(52.37),
+							Longitude: ptFloat32(4.89),
+						},
+					},
+				},
+			})
+		}
+
+		res, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+		for _, r := range res {
+			require.Nil(t, r.Err)
+		}
+	})
+
+	priceFilter := func(price int) traverser.GetParams {
+		return traverser.GetParams{
+			ClassName:  className,
+			Pagination: &filters.Pagination{Limit: 10},
+			Filters:    buildFilter("price", price, gte, schema.DataTypeInt),
+		}
+	}
+
+	shards := func() map[string]*Shard {
+		return repo.GetIndex(schema.ClassName(className)).localShards()
+	}
+
+	dropProperty := func(t *testing.T, propName string) {
+		// the schema manager removes the property before the migrator runs
+		props := make([]*models.Property, 0, len(class.Properties))
+		for _, prop := range class.Properties {
+			if prop.Name != propName {
+				props = append(props, prop)
+			}
+		}
+		class.Properties = props
+
+		err := migrator.DropProperty(context.Background(), className, propName)
+		require.Nil(t, err)
+	}
+
+	objectProperties := func(t *testing.T, id strfmt.UUID) map[string]interface{} {
+		res, err := repo.ObjectByID(context.Background(), id,
+			search.SelectProperties{}, additional.Properties{})
+		require.Nil(t, err)
+		require.NotNil(t, res)
+		return res.Schema.(map[string]interface{})
+	}
+
+	t.Run("filter on the property before dropping it", func(t *testing.T) {
+		res, err := repo.ClassSearch(context.Background(), priceFilter(0))
+		require.Nil(t, err)
+		assert.Len(t, res, len(ids))
+	})
+
+	t.Run("drop the text property", func(t *testing.T) {
+		dropProperty(t, "description")
+
+		for name, shard := range shards() {
+			for _, bucket := range []string{
+				helpers.BucketFromPropNameLSM("description"),
+				helpers.HashBucketFromPropNameLSM("description"),
+				helpers.PositionsBucketFromPropNameLSM("description"),
+			} {
+				assert.Nil(t, shard.store.Bucket(bucket), "shard %s", name)
+				_, err := os.Stat(path.Join(shard.DBPathLSM(), bucket))
+				assert.True(t, os.IsNotExist(err), "shard %s", name)
+			}
+
+			mean, err := shard.propLengths.PropertyMean("description")
+			require.Nil(t, err)
+			assert.Equal(t, float32(0), mean)
+		}
+	})
+
+	t.Run("drop the int property", func(t *testing.T) {
+		dropProperty(t, "price")
+
+		for name, shard := range shards() {
+			assert.Nil(t, shard.store.Bucket(helpers.BucketFromPropNameLSM("price")),
+				"shard %s", name)
+		}
+	})
+
+	t.Run("drop the geo property", func(t *testing.T) {
+		dropProperty(t, "location")
+
+		for name, shard := range shards() {
+			_, ok := shard.propertyIndices.ByProp("location")
+			assert.False(t, ok, "shard %s", name)
+		}
+	})
+
+	t.Run("the dropped values are stripped from the objects", func(t *testing.T) {
+		assert.Eventually(t, func() bool {
+			for _, id := range ids {
+				props := objectProperties(t, id)
+				for _, dropped := range []string{"description", "price", "location"} {
+					if _, ok := props[dropped]; ok {
+						return false
+					}
+				}
+			}
+			return true
+		}, 10*time.Second, 50*time.Millisecond)
+
+		for _, id := range ids {
+			assert.Equal(t, "product", objectProperties(t, id)["name"])
+		}
+
+		for name, shard := range shards() {
+			pending, err := shard.pendingPropertyCleanups()
+			require.Nil(t, err)
+			assert.Len(t, pending, 0, "shard %s", name)
+		}
+	})
+
+	t.Run("filtering on the dropped property fails", func(t *testing.T) {
+		_, err := repo.ClassSearch(context.Background(), priceFilter(0))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("add the property again", func(t *testing.T) {
+		prop := &models.Property{
+			Name:     "price",
+			DataType: []string{string(schema.DataTypeInt)},
+		}
+		class.Properties = append(class.Properties, prop)
+		require.Nil(t, migrator.AddProperty(context.Background(), className, prop))
+
+		err := repo.PutObject(context.Background(), &models.Object{
+			ID:    readdedID,
+			Class: className,
+			Properties: map[string]interface{}{
+				"name":  "product",
+				"price": int64(20),
+			},
+		}, []float32{1, 2, 3})
+		require.Nil(t, err)
+	})
+
+	t.Run("only the new values of the property are found", func(t *testing.T) {
+		res, err := repo.ClassSearch(context.Background(), priceFilter(0))
+		require.Nil(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, readdedID, res[0].ID)
+	})
+}
@@ -17,11 +17,15 @@ package db
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"sync"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
+	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/searchparams"
@@ -31,14 +35,24 @@ import (
 )
 
 type fakeSchemaGetter struct {
+	sync.Mutex
 	schema     schema.Schema
 	shardState *sharding.State
 }
 
 func (f *fakeSchemaGetter) GetSchemaSkipAuth() schema.Schema {
+	f.Lock()
+	defer f.Unlock()
 	return f.schema
 }
 
+// setSchema replaces the schema while it might be read concurrently
+func (f *fakeSchemaGetter) setSchema(s schema.Schema) {
+	f.Lock()
+	defer f.Unlock()
+	f.schema = s
+}
+
 func (f *fakeSchemaGetter) ShardingState(class string) *sharding.State {
 	return f.shardState
 }
@@ -148,7 +162,7 @@ func (f *fakeRemoteClient) MultiGetObjects(ctx context.Context, hostName, indexN
 }
 
 func (f *fakeRemoteClient) SearchShard(ctx context.Context, hostName, indexName,
-	shardName string, vector []float32, limit int,
+	shardName string, vector []float32, targetVector string, limit int,
 	filters *filters.LocalFilter, _ *searchparams.KeywordRanking, sort []filters.Sort,
 	additional additional.Properties,
 ) ([]*storobj.Object, []float32, error) {
@@ -185,12 +199,42 @@ func (f *fakeRemoteClient) GetShardStatus(ctx context.Context,
 	return "", nil
 }
 
+func (f *fakeRemoteClient) GetShardReindexStatus(ctx context.Context,
+	hostName, indexName, shardName string,
+) ([]*models.PropertyReindexStatus, error) {
+	return nil, nil
+}
+
 func (f *fakeRemoteClient) UpdateShardStatus(ctx context.Context, hostName, indexName, shardName,
 	targetStatus string,
 ) error {
 	return nil
 }
 
+func (f *fakeRemoteClient) CreateShardBackup(ctx context.Context, hostName, indexName,
+	shardName, snapshotID string,
+) (*backup.ShardSnapshot, error) {
+	return nil, nil
+}
+
+func (f *fakeRemoteClient) ReleaseShardBackup(ctx context.Context, hostName, indexName,
+	shardName, snapshotID string,
+) error {
+	return nil
+}
+
+func (f *fakeRemoteClient) GetShardBackupFile(ctx context.Context, hostName, indexName,
+	shardName, relPath string,
+) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (f *fakeRemoteClient) PutShardBackupFile(ctx context.Context, hostName, indexName,
+	shardName, relPath string, r io.Reader,
+) error {
+	return nil
+}
+
 type fakeNodeResolver struct{}
 
 func (f *fakeNodeResolver) NodeHostname(string) (string, bool) {
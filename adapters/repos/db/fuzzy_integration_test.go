@@ -0,0 +1,206 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/searchparams"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_Fuzzy(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "FuzzyProducts"
+
+	const (
+		idA = "0e1f2a3b-4c5d-4e6f-9a7b-9c0d1e2f3a01"
+		idB = "1f2a3b4c-5d6e-4f7a-8b8c-0d1e2f3a4b02"
+		idC = "2a3b4c5d-6e7f-4a8b-9c9d-1e2f3a4b5c03"
+		idD = "3b4c5d6e-7f8a-4b9c-8d0e-2f3a4b5c6d04"
+	)
+
+	t.Run("prepare", func(t *testing.T) {
+		class := &models.Class{
+			Class:             className,
+			VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+			InvertedIndexConfig: &models.InvertedIndexConfig{
+				CleanupIntervalSeconds: 60,
+			},
+			Properties: []*models.Property{
+				{
+					Name:         "name",
+					DataType:     []string{string(schema.DataTypeText)},
+					Tokenization: models.PropertyTokenizationWord,
+				},
+				{
+					Name:         "sku",
+					DataType:     []string{string(schema.DataTypeString)},
+					Tokenization: models.PropertyTokenizationField,
+				},
+			},
+		}
+
+		t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+	})
+
+	t.Run("insert data", func(t *testing.T) {
+		data := []struct {
+			id   string
+			name string
+			sku  string
+		}{
+			{idA, "Apple iPhone 14", "APL-1234"},
+			{idB, "Samsung Galaxy phone", "SMS-5678"},
+			{idC, "Google Pixel", "GGL-9012"},
+			{idD, "iPhone case", "APL-3456"},
+		}
+
+		objs := make(objects.BatchObjects, len(data))
+		for i, d := range data {
+			objs[i] = objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          strfmt.UUID(d.id),
+				Object: &models.Object{
+					ID:    strfmt.UUID(d.id),
+					Class: className,
+					Properties: map[string]interface{}{
+						"name": d.name,
+						"sku":  d.sku,
+					},
+				},
+			}
+		}
+
+		_, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+	})
+
+	search := func(t *testing.T, params traverser.GetParams) []string {
+		params.ClassName = className
+		params.Pagination = &filters.Pagination{Limit: 10}
+		res, err := repo.ClassSearch(context.Background(), params)
+		require.Nil(t, err)
+
+		ids := make([]string, len(res))
+		for i := range res {
+			ids[i] = res[i].ID.String()
+		}
+		return ids
+	}
+
+	t.Run("fuzzy filter", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			prop     string
+			dataType schema.DataType
+			value    string
+			expected []string
+		}{
+			{
+				name:     "with the distance derived from the term length",
+				prop:     "name",
+				dataType: schema.DataTypeText,
+				value:    "iphnoe",
+				expected: []string{idA, idD},
+			},
+			{
+				name:     "with a distance too small for the typo",
+				prop:     "name",
+				dataType: schema.DataTypeText,
+				value:    "iphnoe~1",
+				expected: []string{},
+			},
+			{
+				name:     "with an explicit distance",
+				prop:     "name",
+				dataType: schema.DataTypeText,
+				value:    "pixle~2",
+				expected: []string{idC},
+			},
+			{
+				name:     "with several terms",
+				prop:     "name",
+				dataType: schema.DataTypeText,
+				value:    "samsnug galxy",
+				expected: []string{idB},
+			},
+			{
+				name:     "on a field tokenized property",
+				prop:     "sku",
+				dataType: schema.DataTypeString,
+				value:    "APL-1243~2",
+				expected: []string{idA},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				ids := search(t, traverser.GetParams{
+					Filters: &filters.LocalFilter{
+						Root: &filters.Clause{
+							Operator: filters.OperatorFuzzy,
+							On: &filters.Path{
+								Class:    schema.ClassName(className),
+								Property: schema.PropertyName(test.prop),
+							},
+							Value: &filters.Value{
+								Value: test.value,
+								Type:  test.dataType,
+							},
+						},
+					},
+				})
+				assert.ElementsMatch(t, test.expected, ids)
+			})
+		}
+	})
+
+	t.Run("fuzzy terms in a keyword search", func(t *testing.T) {
+		ids := search(t, traverser.GetParams{
+			KeywordRanking: &searchparams.KeywordRanking{
+				Query:      "iphnoe~ cse~1",
+				Properties: []string{"name"},
+			},
+		})
+		require.Len(t, ids, 2)
+		// only the case matches both terms
+		assert.Equal(t, idD, ids[0])
+		assert.Equal(t, idA, ids[1])
+	})
+
+	t.Run("terms without a suffix are not fuzzy in a keyword search", func(t *testing.T) {
+		ids := search(t, traverser.GetParams{
+			KeywordRanking: &searchparams.KeywordRanking{
+				Query:      "iphnoe",
+				Properties: []string{"name"},
+			},
+		})
+		assert.Empty(t, ids)
+	})
+}
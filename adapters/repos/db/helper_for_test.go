@@ -26,6 +26,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/storobj"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
 	"github.com/sirupsen/logrus"
 )
 
@@ -218,6 +219,8 @@ func testShard(t *testing.T, ctx context.Context, className string, indexOpts ..
 		logger:                logrus.New(),
 		getSchema:             schemaGetter,
 		Shards:                map[string]*Shard{},
+		remote: sharding.NewRemoteIndex(className, schemaGetter,
+			&fakeNodeResolver{}, &fakeRemoteClient{}),
 	}
 
 	for _, opt := range indexOpts {
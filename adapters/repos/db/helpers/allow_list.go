@@ -11,44 +11,87 @@
 
 package helpers
 
+import "github.com/RoaringBitmap/roaring/roaring64"
+
 // AllowList groups a list of possible indexIDs to be passed to a secondary
 // index. The secondary index must make sure that it only returns result
 // present on the AllowList
-type AllowList map[uint64]struct{}
+//
+// A nil AllowList means that there is no restriction at all. An empty list
+// on the other hand does not allow any id.
+type AllowList interface {
+	Insert(ids ...uint64)
+	Contains(id uint64) bool
+	DeepCopy() AllowList
+	Slice() []uint64
+
+	IsEmpty() bool
+	Len() int
+	Iterator() AllowListIterator
+
+	// Size is the estimated memory footprint of the list in bytes
+	Size() uint64
+}
+
+// AllowListIterator iterates over the ids of an AllowList in ascending
+// order
+type AllowListIterator interface {
+	HasNext() bool
+	Next() uint64
+}
+
+// NewAllowList creates an AllowList containing the specified ids. The list
+// is backed by a compressed bitmap, so that even lists of millions of ids
+// only take up a fraction of the memory a map would require
+func NewAllowList(ids ...uint64) AllowList {
+	return NewAllowListFromBitmap(roaring64.BitmapOf(ids...))
+}
+
+// NewAllowListFromBitmap wraps an existing bitmap without copying it. The
+// bitmap must not be modified by anyone else after it has been wrapped.
+func NewAllowListFromBitmap(bm *roaring64.Bitmap) AllowList {
+	return &bitmapAllowList{bm: bm}
+}
+
+type bitmapAllowList struct {
+	bm *roaring64.Bitmap
+}
 
 // Inserting and reading is not thread-safe. However, if inserting has
 // completed, and the list can be considered read-only, it is safe to read from
 // it concurrently
-func (al AllowList) Insert(id uint64) {
-	// no need to check if it's already present, simply overwrite
-	al[id] = struct{}{}
+func (al *bitmapAllowList) Insert(ids ...uint64) {
+	al.bm.AddMany(ids)
 }
 
 // Contains is not thread-safe if the list is still being filled. However, if
 // you can guarantee that the list is no longer being inserted into and it
 // effectively becomes read-only, you can safely read concurrently
-func (al AllowList) Contains(id uint64) bool {
-	_, ok := al[id]
-	return ok
+func (al *bitmapAllowList) Contains(id uint64) bool {
+	return al.bm.Contains(id)
 }
 
-func (al AllowList) DeepCopy() AllowList {
-	out := AllowList{}
+func (al *bitmapAllowList) DeepCopy() AllowList {
+	return NewAllowListFromBitmap(al.bm.Clone())
+}
 
-	for id := range al {
-		out[id] = struct{}{}
-	}
+// Slice returns all ids in ascending order
+func (al *bitmapAllowList) Slice() []uint64 {
+	return al.bm.ToArray()
+}
+
+func (al *bitmapAllowList) IsEmpty() bool {
+	return al.bm.IsEmpty()
+}
 
-	return out
+func (al *bitmapAllowList) Len() int {
+	return int(al.bm.GetCardinality())
 }
 
-func (al AllowList) Slice() []uint64 {
-	out := make([]uint64, len(al))
-	i := 0
-	for id := range al {
-		out[i] = id
-		i++
-	}
+func (al *bitmapAllowList) Iterator() AllowListIterator {
+	return al.bm.Iterator()
+}
 
-	return out
+func (al *bitmapAllowList) Size() uint64 {
+	return al.bm.GetSizeInBytes()
 }
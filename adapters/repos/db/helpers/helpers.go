@@ -17,6 +17,14 @@ var (
 	ObjectsBucket    = []byte("objects")
 	ObjectsBucketLSM = "objects"
 	DocIDBucket      = []byte("doc_ids")
+
+	// VectorsBucketLSM holds the vectors of a flat vector index
+	VectorsBucketLSM = "vectors"
+
+	// PositionsStateBucketLSM tracks whether the positions of all objects of
+	// a shard have been indexed, which is marked by the PositionsIndexedKey
+	PositionsStateBucketLSM = "positions_state"
+	PositionsIndexedKey     = []byte("indexed")
 )
 
 // BucketFromPropName creates the byte-representation used as the bucket name
@@ -32,6 +40,13 @@ func MetaCountProp(propName string) string {
 	return fmt.Sprintf("%s__meta_count", propName)
 }
 
+// ReindexProp creates the internally used propName under which a prop is
+// indexed while it is being reindexed, e.g. after its tokenization was
+// changed. Once complete, its buckets replace the ones of the prop.
+func ReindexProp(propName string) string {
+	return fmt.Sprintf("%s__reindex", propName)
+}
+
 // BucketFromPropName creates the byte-representation used as the bucket name
 // for a partiular prop in the inverted index
 func BucketFromPropNameLSM(propName string) string {
@@ -43,3 +58,10 @@ func BucketFromPropNameLSM(propName string) string {
 func HashBucketFromPropNameLSM(propName string) string {
 	return fmt.Sprintf("hash_property_%s", propName)
 }
+
+// PositionsBucketFromPropNameLSM creates the name of the bucket holding the
+// positions of the terms of a particular text prop, which are required for
+// phrase queries
+func PositionsBucketFromPropNameLSM(propName string) string {
+	return fmt.Sprintf("positions_property_%s", propName)
+}
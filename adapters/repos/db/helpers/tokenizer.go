@@ -47,6 +47,76 @@ func TokenizeTextKeepWildcards(in string) []string {
 	return parts
 }
 
+// TokenizeTrigram splits on any non-alphanumerical, lowercases the words and
+// splits them into overlapping trigrams
+func TokenizeTrigram(in string) []string {
+	return Trigrams(TokenizeText(in))
+}
+
+// Trigrams splits every word into its overlapping trigrams, e.g. "hello"
+// becomes "hel", "ell" and "llo". Words with less than three letters are kept
+// as they are.
+func Trigrams(words []string) []string {
+	return ngrams(words, 3, func(rune) bool { return true })
+}
+
+// TokenizeCJK splits on any non-alphanumerical, lowercases the words and
+// splits runs of Chinese, Japanese and Korean characters into overlapping
+// bigrams. It does not need a dictionary, as the bigrams of a query are
+// matched against the bigrams of the indexed text.
+func TokenizeCJK(in string) []string {
+	return CJKBigrams(TokenizeText(in))
+}
+
+// CJKBigrams splits the runs of Chinese, Japanese and Korean characters within
+// the words into overlapping bigrams, e.g. "東京タワー" becomes "東京", "京タ",
+// "タワ" and "ワー". All other parts of the words are kept as they are.
+func CJKBigrams(words []string) []string {
+	return ngrams(words, 2, IsCJK)
+}
+
+// IsCJK returns whether the rune is a Chinese, Japanese or Korean character
+func IsCJK(r rune) bool {
+	switch r {
+	case 'ー', 'ｰ', '々':
+		// the prolonged sound and iteration marks belong to no script, but are
+		// part of Japanese words
+		return true
+	}
+
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana,
+		unicode.Hangul)
+}
+
+// ngrams splits the runs of runes in a word for which split is true into
+// overlapping n-grams. Runs with less than n runes, as well as the parts of
+// the word for which split is false, are kept as they are.
+func ngrams(words []string, n int, split func(rune) bool) []string {
+	var out []string
+	for _, word := range words {
+		runes := []rune(word)
+		for start := 0; start < len(runes); {
+			end := start + 1
+			for end < len(runes) && split(runes[end]) == split(runes[start]) {
+				end++
+			}
+
+			run := runes[start:end]
+			if !split(runes[start]) || len(run) <= n {
+				out = append(out, string(run))
+			} else {
+				for i := 0; i+n <= len(run); i++ {
+					out = append(out, string(run[i:i+n]))
+				}
+			}
+
+			start = end
+		}
+	}
+
+	return out
+}
+
 // TrimString trims on white spaces
 func TrimString(in string) string {
 	return strings.TrimFunc(in, unicode.IsSpace)
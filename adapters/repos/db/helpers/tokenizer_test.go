@@ -0,0 +1,57 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizeTrigram(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected []string
+	}{
+		{in: "Hello", expected: []string{"hel", "ell", "llo"}},
+		{in: "the fox", expected: []string{"the", "fox"}},
+		{in: "a Hi, WORLD", expected: []string{"a", "hi", "wor", "orl", "rld"}},
+		{in: "Straße", expected: []string{"str", "tra", "raß", "aße"}},
+		{in: "", expected: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			assert.Equal(t, test.expected, TokenizeTrigram(test.in))
+		})
+	}
+}
+
+func TestTokenizeCJK(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected []string
+	}{
+		{in: "東京タワー", expected: []string{"東京", "京タ", "タワ", "ワー"}},
+		{in: "我爱北京", expected: []string{"我爱", "爱北", "北京"}},
+		{in: "한국어 사전", expected: []string{"한국", "국어", "사전"}},
+		{in: "東", expected: []string{"東"}},
+		{in: "Tokyo東京Tower", expected: []string{"tokyo", "東京", "tower"}},
+		{in: "hello, 世界!", expected: []string{"hello", "世界"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			assert.Equal(t, test.expected, TokenizeCJK(test.in))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/entities/searchparams"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+// hybridRankConstant is the k in the reciprocal rank fusion 1/(k+rank). It
+// dampens the advantage of the very first ranks, 60 is the value suggested in
+// the original RRF paper
+const hybridRankConstant = 60
+
+// hybridResult is a single object of a hybrid search with its fused score
+// and a human-readable explanation of how that score came to be
+type hybridResult struct {
+	object      *storobj.Object
+	score       float64
+	explanation []string
+}
+
+// hybridFuser fuses the results of a keyword-based (BM25) and a vector search
+// into a single ranking. The keyword results are ordered by descending score,
+// the vector results by ascending distance. Alpha weighs the vector search
+// against the keyword search, with 1 being a pure vector search and 0 being a
+// pure keyword search.
+type hybridFuser struct {
+	fusionType string
+	alpha      float64
+}
+
+func newHybridFuser(fusionType string, alpha float64) *hybridFuser {
+	return &hybridFuser{fusionType: fusionType, alpha: alpha}
+}
+
+func (f *hybridFuser) fuse(keywordObjs []*storobj.Object, keywordScores []float32,
+	vectorObjs []*storobj.Object, vectorDists []float32,
+) []hybridResult {
+	var keywordContrib, vectorContrib []float64
+	switch f.fusionType {
+	case searchparams.HybridFusionRelativeScore:
+		keywordContrib = normalizeScores(keywordScores, false)
+		vectorContrib = normalizeScores(vectorDists, true)
+	default:
+		keywordContrib = reciprocalRanks(len(keywordObjs))
+		vectorContrib = reciprocalRanks(len(vectorObjs))
+	}
+
+	results := make([]hybridResult, 0, len(keywordObjs)+len(vectorObjs))
+	// objects are identified by their uuid rather than their doc id, as doc
+	// ids are only unique within a single shard
+	pos := make(map[strfmt.UUID]int, len(keywordObjs)+len(vectorObjs))
+
+	add := func(obj *storobj.Object, contrib float64, explanation string) {
+		i, ok := pos[obj.ID()]
+		if !ok {
+			i = len(results)
+			pos[obj.ID()] = i
+			results = append(results, hybridResult{object: obj})
+		}
+		results[i].score += contrib
+		results[i].explanation = append(results[i].explanation, explanation)
+	}
+
+	for i, obj := range keywordObjs {
+		contrib := (1 - f.alpha) * keywordContrib[i]
+		add(obj, contrib, fmt.Sprintf("(bm25) rank %d, score %v contributes %v",
+			i+1, keywordScores[i], contrib))
+	}
+
+	for i, obj := range vectorObjs {
+		contrib := f.alpha * vectorContrib[i]
+		add(obj, contrib, fmt.Sprintf("(vector) rank %d, distance %v contributes %v",
+			i+1, vectorDists[i], contrib))
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].score > results[b].score
+	})
+
+	return results
+}
+
+// explain returns the explanation of the fused score of a single result
+func (f *hybridFuser) explain(res hybridResult) string {
+	return fmt.Sprintf("hybrid (%s, alpha %v) score %v: %s", f.fusionType,
+		f.alpha, res.score, strings.Join(res.explanation, "; "))
+}
+
+// reciprocalRanks returns 1/(k+rank) for each of the n ranks, starting at
+// rank 1
+func reciprocalRanks(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = 1 / float64(hybridRankConstant+i+1)
+	}
+	return out
+}
+
+// normalizeScores scales the scores to the range 0..1, with 1 being the best
+// result. If lowerIsBetter is set, as it is for distances, the scale is
+// inverted. If all scores are identical, they are all considered best.
+func normalizeScores(scores []float32, lowerIsBetter bool) []float64 {
+	out := make([]float64, len(scores))
+	if len(scores) == 0 {
+		return out
+	}
+
+	min, max := scores[0], scores[0]
+	for _, score := range scores {
+		if score < min {
+			min = score
+		}
+		if score > max {
+			max = score
+		}
+	}
+
+	for i, score := range scores {
+		if max == min {
+			out[i] = 1
+			continue
+		}
+
+		if lowerIsBetter {
+			out[i] = float64(max-score) / float64(max-min)
+		} else {
+			out[i] = float64(score-min) / float64(max-min)
+		}
+	}
+
+	return out
+}
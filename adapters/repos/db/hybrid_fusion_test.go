@@ -0,0 +1,127 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/searchparams"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HybridFusion(t *testing.T) {
+	obj := func(id string) *storobj.Object {
+		return &storobj.Object{Object: models.Object{ID: strfmt.UUID(id)}}
+	}
+
+	// the keyword search ranks a over b over c, the vector search ranks c
+	// over d over a
+	keywordObjs := []*storobj.Object{
+		obj("0b6e8ad2-3a57-4a0c-bd7b-5f3e9c0e9f5a"),
+		obj("1c8d6b2e-9f40-4c66-8b07-2a86a1d2f0b3"),
+		obj("2f4e1c3d-7a6b-4b0e-9c3a-6d5e8f7a9b1c"),
+	}
+	keywordScores := []float32{3, 2, 1}
+	vectorObjs := []*storobj.Object{
+		obj("2f4e1c3d-7a6b-4b0e-9c3a-6d5e8f7a9b1c"),
+		obj("3a7b9c1d-2e4f-4a6b-8c0d-1e3f5a7b9c2d"),
+		obj("0b6e8ad2-3a57-4a0c-bd7b-5f3e9c0e9f5a"),
+	}
+	vectorDists := []float32{0.1, 0.2, 0.5}
+
+	ids := func(res []hybridResult) []string {
+		out := make([]string, len(res))
+		for i := range res {
+			out[i] = res[i].object.ID().String()
+		}
+		return out
+	}
+
+	t.Run("ranked fusion with equal weights", func(t *testing.T) {
+		res := newHybridFuser(searchparams.HybridFusionRanked, 0.5).
+			fuse(keywordObjs, keywordScores, vectorObjs, vectorDists)
+
+		// a and c are both contained in both lists at rank 1 and 3, but a is
+		// added first, d is only contained in a single list
+		assert.Equal(t, []string{
+			"0b6e8ad2-3a57-4a0c-bd7b-5f3e9c0e9f5a",
+			"2f4e1c3d-7a6b-4b0e-9c3a-6d5e8f7a9b1c",
+			"1c8d6b2e-9f40-4c66-8b07-2a86a1d2f0b3",
+			"3a7b9c1d-2e4f-4a6b-8c0d-1e3f5a7b9c2d",
+		}, ids(res))
+		assert.InDelta(t, 0.5/61+0.5/63, res[0].score, 1e-9)
+		assert.InDelta(t, 0.5/62, res[2].score, 1e-9)
+		assert.Len(t, res[0].explanation, 2)
+		assert.Len(t, res[2].explanation, 1)
+	})
+
+	t.Run("ranked fusion favoring the vector search", func(t *testing.T) {
+		res := newHybridFuser(searchparams.HybridFusionRanked, 0.75).
+			fuse(keywordObjs, keywordScores, vectorObjs, vectorDists)
+
+		assert.Equal(t, []string{
+			"2f4e1c3d-7a6b-4b0e-9c3a-6d5e8f7a9b1c",
+			"0b6e8ad2-3a57-4a0c-bd7b-5f3e9c0e9f5a",
+			"3a7b9c1d-2e4f-4a6b-8c0d-1e3f5a7b9c2d",
+			"1c8d6b2e-9f40-4c66-8b07-2a86a1d2f0b3",
+		}, ids(res))
+	})
+
+	t.Run("relative score fusion", func(t *testing.T) {
+		res := newHybridFuser(searchparams.HybridFusionRelativeScore, 0.5).
+			fuse(keywordObjs, keywordScores, vectorObjs, vectorDists)
+
+		// normalized keyword scores are 1, 0.5, 0 and normalized vector
+		// scores are 1, 0.75, 0
+		require.Len(t, res, 4)
+		assert.Equal(t, "0b6e8ad2-3a57-4a0c-bd7b-5f3e9c0e9f5a", res[0].object.ID().String())
+		assert.InDelta(t, 0.5, res[0].score, 1e-6)
+		assert.Equal(t, "2f4e1c3d-7a6b-4b0e-9c3a-6d5e8f7a9b1c", res[1].object.ID().String())
+		assert.InDelta(t, 0.5, res[1].score, 1e-6)
+		assert.Equal(t, "3a7b9c1d-2e4f-4a6b-8c0d-1e3f5a7b9c2d", res[2].object.ID().String())
+		assert.InDelta(t, 0.375, res[2].score, 1e-6)
+		assert.Equal(t, "1c8d6b2e-9f40-4c66-8b07-2a86a1d2f0b3", res[3].object.ID().String())
+		assert.InDelta(t, 0.25, res[3].score, 1e-6)
+	})
+
+	t.Run("with only keyword results", func(t *testing.T) {
+		res := newHybridFuser(searchparams.HybridFusionRelativeScore, 0).
+			fuse(keywordObjs, keywordScores, nil, nil)
+
+		assert.Equal(t, []string{
+			"0b6e8ad2-3a57-4a0c-bd7b-5f3e9c0e9f5a",
+			"1c8d6b2e-9f40-4c66-8b07-2a86a1d2f0b3",
+			"2f4e1c3d-7a6b-4b0e-9c3a-6d5e8f7a9b1c",
+		}, ids(res))
+	})
+
+	t.Run("explanation", func(t *testing.T) {
+		fuser := newHybridFuser(searchparams.HybridFusionRanked, 0.5)
+		res := fuser.fuse(keywordObjs, keywordScores, vectorObjs, vectorDists)
+
+		explanation := fuser.explain(res[0])
+		assert.Contains(t, explanation, "hybrid (rankedFusion, alpha 0.5)")
+		assert.Contains(t, explanation, "(bm25) rank 1, score 3")
+		assert.Contains(t, explanation, "(vector) rank 3, distance 0.5")
+	})
+}
+
+func Test_HybridFusion_NormalizeScores(t *testing.T) {
+	assert.Equal(t, []float64{1, 0.5, 0}, normalizeScores([]float32{4, 3, 2}, false))
+	assert.Equal(t, []float64{1, 0.5, 0}, normalizeScores([]float32{2, 3, 4}, true))
+	assert.Equal(t, []float64{1, 1}, normalizeScores([]float32{2, 2}, false))
+	assert.Equal(t, []float64{}, normalizeScores(nil, false))
+}
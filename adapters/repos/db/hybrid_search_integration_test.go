@@ -0,0 +1,266 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/additional"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/searchparams"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_Hybrid_Search(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "HybridRacecarPosts"
+
+	// the keyword search for "driver" ranks idB over idA, the vector search
+	// ranks idA over idC over idB over idD
+	const (
+		idA = "a7b4b4ad-0c6a-4b68-8a0b-3d8d7c3f1f01"
+		idB = "b1e0c6a8-4a3b-4d0e-9a8c-6f5e4d3c2b02"
+		idC = "c3d2e1f0-5b4a-4c9d-8e7f-1a2b3c4d5e03"
+		idD = "d9c8b7a6-6e5d-4f4c-9b3a-2e1d0c9b8a04"
+	)
+
+	t.Run("prepare", func(t *testing.T) {
+		class := &models.Class{
+			Class:             className,
+			VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+			InvertedIndexConfig: &models.InvertedIndexConfig{
+				CleanupIntervalSeconds: 60,
+			},
+			Properties: []*models.Property{
+				{
+					Name:         "contents",
+					DataType:     []string{string(schema.DataTypeText)},
+					Tokenization: "word",
+				},
+			},
+		}
+
+		t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+	})
+
+	t.Run("insert search data", func(t *testing.T) {
+		data := []struct {
+			id       string
+			contents string
+			vector   []float32
+		}{
+			{idA, "a driver drives a car", []float32{1, 0, 0}},
+			{idB, "driver driver driver", []float32{0.2, 1, 0}},
+			{idC, "the race was won", []float32{0.9, 0.1, 0}},
+			{idD, "nothing to see here", []float32{0, 0.3, 1}},
+		}
+
+		objs := make(objects.BatchObjects, len(data))
+		for i, d := range data {
+			objs[i] = objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          strfmt.UUID(d.id),
+				Vector:        normalize(d.vector),
+				Object: &models.Object{
+					ID:    strfmt.UUID(d.id),
+					Class: className,
+					Properties: map[string]interface{}{
+						"contents": d.contents,
+					},
+					Vector: normalize(d.vector),
+				},
+			}
+		}
+
+		_, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+	})
+
+	hybridSearch := func(t *testing.T, alpha float64, fusionType string,
+		pagination *filters.Pagination,
+	) []string {
+		res, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+			ClassName:  className,
+			Pagination: pagination,
+			HybridSearch: &searchparams.HybridSearch{
+				Query:      "driver",
+				Vector:     normalize([]float32{1, 0, 0}),
+				Alpha:      alpha,
+				FusionType: fusionType,
+				Properties: []string{"contents"},
+			},
+			AdditionalProperties: additional.Properties{ExplainScore: true},
+		})
+		require.Nil(t, err)
+
+		ids := make([]string, len(res))
+		for i := range res {
+			ids[i] = res[i].ID.String()
+			if i > 0 {
+				assert.GreaterOrEqual(t, res[i-1].Score, res[i].Score)
+			}
+			assert.Contains(t, res[i].AdditionalProperties["explainScore"], "hybrid")
+		}
+		return ids
+	}
+
+	t.Run("pure keyword search", func(t *testing.T) {
+		ids := hybridSearch(t, 0, searchparams.HybridFusionRanked,
+			&filters.Pagination{Limit: 10})
+		assert.Equal(t, []string{idB, idA}, ids)
+	})
+
+	t.Run("pure vector search", func(t *testing.T) {
+		ids := hybridSearch(t, 1, searchparams.HybridFusionRanked,
+			&filters.Pagination{Limit: 10})
+		assert.Equal(t, []string{idA, idC, idB, idD}, ids)
+	})
+
+	t.Run("ranked fusion", func(t *testing.T) {
+		ids := hybridSearch(t, 0.5, searchparams.HybridFusionRanked,
+			&filters.Pagination{Limit: 10})
+		assert.Equal(t, []string{idA, idB, idC, idD}, ids)
+	})
+
+	t.Run("relative score fusion", func(t *testing.T) {
+		ids := hybridSearch(t, 0.5, searchparams.HybridFusionRelativeScore,
+			&filters.Pagination{Limit: 10})
+		// idB has the best keyword score and a mediocre distance, whereas the
+		// keyword score of idA is the worst of the keyword results
+		assert.Equal(t, []string{idB, idA, idC, idD}, ids)
+	})
+
+	t.Run("paginating through the fused results", func(t *testing.T) {
+		ids := hybridSearch(t, 0.5, searchparams.HybridFusionRanked,
+			&filters.Pagination{Offset: 1, Limit: 2})
+		assert.Equal(t, []string{idB, idC}, ids)
+	})
+}
+
+func Test_MultiShardJourneys_Hybrid_Search_Pagination(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	// the window is smaller than the number of objects, but spans all pages
+	// which are compared below
+	repo.config.HybridCandidates = 20
+	className := "HybridPaginatedPosts"
+
+	t.Run("prepare", func(t *testing.T) {
+		class := &models.Class{
+			Class:             className,
+			VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+			InvertedIndexConfig: &models.InvertedIndexConfig{
+				CleanupIntervalSeconds: 60,
+			},
+			Properties: []*models.Property{
+				{
+					Name:         "contents",
+					DataType:     []string{string(schema.DataTypeText)},
+					Tokenization: "word",
+				},
+			},
+		}
+
+		t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+	})
+
+	t.Run("insert search data", func(t *testing.T) {
+		// the keyword and the vector ranking of the objects differ, so that
+		// objects far down in one ranking are near the top of the other one
+		objs := make(objects.BatchObjects, 40)
+		for i := range objs {
+			id := strfmt.UUID(fmt.Sprintf("8a1b2c3d-4e5f-4a6b-8c7d-%012d", i))
+			contents := strings.Repeat("driver ", i%7+1) + strings.Repeat("filler ", i%5)
+			angle := float64((i*13)%40) / 40 * math.Pi / 2
+			vector := normalize([]float32{float32(math.Cos(angle)), float32(math.Sin(angle)), 0.1})
+
+			objs[i] = objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          id,
+				Vector:        vector,
+				Object: &models.Object{
+					ID:         id,
+					Class:      className,
+					Properties: map[string]interface{}{"contents": contents},
+					Vector:     vector,
+				},
+			}
+		}
+
+		_, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+	})
+
+	hybridSearch := func(t *testing.T, fusionType string, offset, limit int) []string {
+		res, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+			ClassName:  className,
+			Pagination: &filters.Pagination{Offset: offset, Limit: limit},
+			HybridSearch: &searchparams.HybridSearch{
+				Query:      "driver",
+				Vector:     normalize([]float32{1, 0, 0}),
+				Alpha:      0.5,
+				FusionType: fusionType,
+				Properties: []string{"contents"},
+			},
+		})
+		require.Nil(t, err)
+
+		ids := make([]string, len(res))
+		for i := range res {
+			ids[i] = res[i].ID.String()
+		}
+		return ids
+	}
+
+	for _, fusionType := range []string{
+		searchparams.HybridFusionRanked,
+		searchparams.HybridFusionRelativeScore,
+	} {
+		t.Run(fusionType, func(t *testing.T) {
+			all := hybridSearch(t, fusionType, 0, 20)
+			require.Len(t, all, 20)
+
+			var paged []string
+			for offset := 0; offset < 20; offset += 5 {
+				page := hybridSearch(t, fusionType, offset, 5)
+				require.Len(t, page, 5)
+				for _, id := range page {
+					assert.NotContains(t, paged, id)
+				}
+				paged = append(paged, page...)
+			}
+
+			assert.Equal(t, all, paged)
+		})
+	}
+}
@@ -18,7 +18,6 @@ import (
 	"time"
 
 	"github.com/go-openapi/strfmt"
-	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/aggregator"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted"
@@ -49,12 +48,15 @@ import (
 type Index struct {
 	classSearcher         inverted.ClassSearcher // to allow for nested by-references searches
 	Shards                map[string]*Shard
+	shardsLock            sync.RWMutex
 	Config                IndexConfig
 	vectorIndexUserConfig schema.VectorIndexConfig
-	getSchema             schemaUC.SchemaGetter
-	logger                logrus.FieldLogger
-	remote                *sharding.RemoteIndex
-	stopwords             *stopwords.Detector
+	// vectorIndexUserConfigs holds the configs of the named vectors, by name
+	vectorIndexUserConfigs map[string]schema.VectorIndexConfig
+	getSchema              schemaUC.SchemaGetter
+	logger                 logrus.FieldLogger
+	remote                 *sharding.RemoteIndex
+	stopwords              *stopwords.Detector
 
 	snapshotState     backup.State
 	snapshotStateLock sync.RWMutex
@@ -69,6 +71,29 @@ func (i *Index) ID() string {
 	return indexID(i.Config.ClassName)
 }
 
+// shard returns the shard with the specified name if it is loaded on this
+// node, nil otherwise
+func (i *Index) shard(name string) *Shard {
+	i.shardsLock.RLock()
+	defer i.shardsLock.RUnlock()
+
+	return i.Shards[name]
+}
+
+// localShards returns all shards loaded on this node. As shards can be
+// loaded and dropped while the index is in use, the returned map is a copy.
+func (i *Index) localShards() map[string]*Shard {
+	i.shardsLock.RLock()
+	defer i.shardsLock.RUnlock()
+
+	shards := make(map[string]*Shard, len(i.Shards))
+	for name, shard := range i.Shards {
+		shards[name] = shard
+	}
+
+	return shards
+}
+
 type nodeResolver interface {
 	NodeHostname(nodeName string) (string, bool)
 }
@@ -77,7 +102,8 @@ type nodeResolver interface {
 // the shards that are local to a node
 func NewIndex(ctx context.Context, config IndexConfig,
 	shardState *sharding.State, invertedIndexConfig schema.InvertedIndexConfig,
-	vectorIndexUserConfig schema.VectorIndexConfig, sg schemaUC.SchemaGetter,
+	vectorIndexUserConfig schema.VectorIndexConfig,
+	vectorIndexUserConfigs map[string]schema.VectorIndexConfig, sg schemaUC.SchemaGetter,
 	cs inverted.ClassSearcher, logger logrus.FieldLogger,
 	nodeResolver nodeResolver, remoteClient sharding.RemoteIndexClient,
 	promMetrics *monitoring.PrometheusMetrics,
@@ -88,14 +114,15 @@ func NewIndex(ctx context.Context, config IndexConfig,
 	}
 
 	index := &Index{
-		Config:                config,
-		Shards:                map[string]*Shard{},
-		getSchema:             sg,
-		logger:                logger,
-		classSearcher:         cs,
-		vectorIndexUserConfig: vectorIndexUserConfig,
-		invertedIndexConfig:   invertedIndexConfig,
-		stopwords:             sd,
+		Config:                 config,
+		Shards:                 map[string]*Shard{},
+		getSchema:              sg,
+		logger:                 logger,
+		classSearcher:          cs,
+		vectorIndexUserConfig:  vectorIndexUserConfig,
+		vectorIndexUserConfigs: vectorIndexUserConfigs,
+		invertedIndexConfig:    invertedIndexConfig,
+		stopwords:              sd,
 		remote: sharding.NewRemoteIndex(config.ClassName.String(), sg,
 			nodeResolver, remoteClient),
 		metrics: NewMetrics(logger, promMetrics, config.ClassName.String(), "n/a"),
@@ -124,7 +151,7 @@ func NewIndex(ctx context.Context, config IndexConfig,
 }
 
 func (i *Index) addProperty(ctx context.Context, prop *models.Property) error {
-	for name, shard := range i.Shards {
+	for name, shard := range i.localShards() {
 		if err := shard.addProperty(ctx, prop); err != nil {
 			return errors.Wrapf(err, "add property to shard %q", name)
 		}
@@ -133,8 +160,32 @@ func (i *Index) addProperty(ctx context.Context, prop *models.Property) error {
 	return nil
 }
 
+func (i *Index) dropProperty(ctx context.Context, propName string) error {
+	for name, shard := range i.localShards() {
+		if err := shard.dropProperty(ctx, propName); err != nil {
+			return errors.Wrapf(err, "drop property from shard %q", name)
+		}
+	}
+
+	return nil
+}
+
+// reindexProperty rebuilds the inverted index of the property in the
+// background on all local shards
+func (i *Index) reindexProperty(ctx context.Context,
+	previous, next *models.Property,
+) error {
+	for name, shard := range i.localShards() {
+		if err := shard.reindexProperty(ctx, previous, next); err != nil {
+			return errors.Wrapf(err, "reindex property of shard %q", name)
+		}
+	}
+
+	return nil
+}
+
 func (i *Index) addUUIDProperty(ctx context.Context) error {
-	for name, shard := range i.Shards {
+	for name, shard := range i.localShards() {
 		if err := shard.addIDProperty(ctx); err != nil {
 			return errors.Wrapf(err, "add id property to shard %q", name)
 		}
@@ -144,7 +195,7 @@ func (i *Index) addUUIDProperty(ctx context.Context) error {
 }
 
 func (i *Index) addTimestampProperties(ctx context.Context) error {
-	for name, shard := range i.Shards {
+	for name, shard := range i.localShards() {
 		if err := shard.addTimestampProperties(ctx); err != nil {
 			return errors.Wrapf(err, "add timestamp properties to shard %q", name)
 		}
@@ -157,7 +208,7 @@ func (i *Index) updateVectorIndexConfig(ctx context.Context,
 	updated schema.VectorIndexConfig,
 ) error {
 	// an updated is not specific to one shard, but rather all
-	for name, shard := range i.Shards {
+	for name, shard := range i.localShards() {
 		// At the moment, we don't do anything in an update that could fail, but
 		// technically this should be part of some sort of a two-phase commit  or
 		// have another way to rollback if we have updates that could potentially
@@ -170,6 +221,18 @@ func (i *Index) updateVectorIndexConfig(ctx context.Context,
 	return nil
 }
 
+func (i *Index) updateVectorIndexConfigs(ctx context.Context,
+	updated map[string]schema.VectorIndexConfig,
+) error {
+	for name, shard := range i.localShards() {
+		if err := shard.updateVectorIndexConfigs(ctx, updated); err != nil {
+			return errors.Wrapf(err, "shard %s", name)
+		}
+	}
+
+	return nil
+}
+
 func (i *Index) getInvertedIndexConfig() schema.InvertedIndexConfig {
 	i.invertedIndexConfigLock.Lock()
 	defer i.invertedIndexConfigLock.Unlock()
@@ -181,9 +244,21 @@ func (i *Index) updateInvertedIndexConfig(ctx context.Context,
 	updated schema.InvertedIndexConfig,
 ) error {
 	i.invertedIndexConfigLock.Lock()
-	defer i.invertedIndexConfigLock.Unlock()
-
+	positionsChanged := i.invertedIndexConfig.IndexPositions != updated.IndexPositions
 	i.invertedIndexConfig = updated
+	i.invertedIndexConfigLock.Unlock()
+
+	if !positionsChanged {
+		return nil
+	}
+
+	// enabling positions indexes the positions of all existing objects in
+	// the background, phrase queries are possible once that completes
+	for name, shard := range i.localShards() {
+		if err := shard.initPositions(ctx); err != nil {
+			return errors.Wrapf(err, "init positions of shard %s", name)
+		}
+	}
 
 	return nil
 }
@@ -192,6 +267,7 @@ type IndexConfig struct {
 	RootPath                  string
 	ClassName                 schema.ClassName
 	QueryMaximumResults       int64
+	HybridCandidates          int64
 	DiskUseWarningPercentage  uint64
 	DiskUseReadOnlyPercentage uint64
 	MaxImportGoroutinesFactor float64
@@ -202,18 +278,6 @@ func indexID(class schema.ClassName) string {
 	return strings.ToLower(string(class))
 }
 
-func (i *Index) shardFromUUID(in strfmt.UUID) (string, error) {
-	uuid, err := uuid.Parse(in.String())
-	if err != nil {
-		return "", errors.Wrap(err, "parse id as uuid")
-	}
-
-	uuidBytes, _ := uuid.MarshalBinary() // cannot error
-
-	return i.getSchema.ShardingState(i.Config.ClassName.String()).
-		PhysicalShard(uuidBytes), nil
-}
-
 func (i *Index) putObject(ctx context.Context, object *storobj.Object) error {
 	if i.Config.ClassName != object.Class() {
 		return errors.Errorf("cannot import object of class %s into index of class %s",
@@ -221,23 +285,21 @@ func (i *Index) putObject(ctx context.Context, object *storobj.Object) error {
 	}
 	i.snapshotStateLock.RLock()
 	defer i.snapshotStateLock.RUnlock()
-	shardName, err := i.shardFromUUID(object.ID())
+	shardName, err := i.shardFromObject(object)
 	if err != nil {
 		return err
 	}
 
-	localShard, ok := i.Shards[shardName]
-	if !ok {
-		// this must be a remote shard, try sending it remotely
-		if err := i.remote.PutObject(ctx, shardName, object); err != nil {
-			return errors.Wrap(err, "send to remote shard")
+	if localShard := i.shard(shardName); localShard != nil {
+		if err := localShard.putObject(ctx, object); err != nil {
+			return errors.Wrapf(err, "shard %s", localShard.ID())
 		}
-
-		return nil
 	}
 
-	if err := localShard.putObject(ctx, object); err != nil {
-		return errors.Wrapf(err, "shard %s", localShard.ID())
+	// the remaining replicas of the shard live on other nodes, this is a no-op
+	// for local shards which are not replicated
+	if err := i.remote.PutObject(ctx, shardName, object); err != nil {
+		return errors.Wrap(err, "send to remote shard")
 	}
 
 	return nil
@@ -248,8 +310,8 @@ func (i *Index) IncomingPutObject(ctx context.Context, shardName string,
 ) error {
 	i.snapshotStateLock.RLock()
 	defer i.snapshotStateLock.RUnlock()
-	localShard, ok := i.Shards[shardName]
-	if !ok {
+	localShard := i.shard(shardName)
+	if localShard == nil {
 		return errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
@@ -369,7 +431,7 @@ func (i *Index) putObjectBatch(ctx context.Context,
 	out := make([]error, len(objects))
 
 	for pos, obj := range objects {
-		shardName, err := i.shardFromUUID(obj.ID())
+		shardName, err := i.shardFromObject(obj)
 		if err != nil {
 			out[pos] = err
 			continue
@@ -392,13 +454,24 @@ func (i *Index) putObjectBatch(ctx context.Context,
 				ShardingState(i.Config.ClassName.String()).
 				IsShardLocal(shardName)
 
-			var errs []error
-			if !local {
-				errs = i.remote.BatchPutObjects(ctx, shardName, group.objects)
-			} else {
-				shard := i.Shards[shardName]
-				errs = shard.putObjectBatch(ctx, group.objects)
+			if local {
+				shard := i.shard(shardName)
+				errs := shard.putObjectBatch(ctx, group.objects)
+
+				// only objects written to the local replica are replicated
+				var remaining objsAndPos
+				for j, err := range errs {
+					if err != nil {
+						out[group.pos[j]] = err
+						continue
+					}
+					remaining.objects = append(remaining.objects, group.objects[j])
+					remaining.pos = append(remaining.pos, group.pos[j])
+				}
+				group = remaining
 			}
+
+			errs := i.remote.BatchPutObjects(ctx, shardName, group.objects)
 			for i, err := range errs {
 				desiredPos := group.pos[i]
 				out[desiredPos] = err
@@ -425,8 +498,8 @@ func (i *Index) IncomingBatchPutObjects(ctx context.Context, shardName string,
 ) []error {
 	i.snapshotStateLock.RLock()
 	defer i.snapshotStateLock.RUnlock()
-	localShard, ok := i.Shards[shardName]
-	if !ok {
+	localShard := i.shard(shardName)
+	if localShard == nil {
 		return duplicateErr(errors.Errorf("shard %q does not exist locally",
 			shardName), len(objects))
 	}
@@ -463,11 +536,15 @@ func (i *Index) addReferencesBatch(ctx context.Context,
 	out := make([]error, len(refs))
 
 	for pos, ref := range refs {
-		shardName, err := i.shardFromUUID(ref.From.TargetID)
+		shardName, err := i.shardFromID(ctx, ref.From.TargetID)
 		if err != nil {
 			out[pos] = err
 			continue
 		}
+		if shardName == "" {
+			out[pos] = errors.Errorf("source object %s not found", ref.From.TargetID)
+			continue
+		}
 
 		group := byShard[shardName]
 		group.refs = append(group.refs, ref)
@@ -480,13 +557,24 @@ func (i *Index) addReferencesBatch(ctx context.Context,
 			ShardingState(i.Config.ClassName.String()).
 			IsShardLocal(shardName)
 
-		var errs []error
-		if !local {
-			errs = i.remote.BatchAddReferences(ctx, shardName, group.refs)
-		} else {
-			shard := i.Shards[shardName]
-			errs = shard.addReferencesBatch(ctx, group.refs)
+		if local {
+			shard := i.shard(shardName)
+			errs := shard.addReferencesBatch(ctx, group.refs)
+
+			// only references added to the local replica are replicated
+			var remaining refsAndPos
+			for j, err := range errs {
+				if err != nil {
+					out[group.pos[j]] = err
+					continue
+				}
+				remaining.refs = append(remaining.refs, group.refs[j])
+				remaining.pos = append(remaining.pos, group.pos[j])
+			}
+			group = remaining
 		}
+
+		errs := i.remote.BatchAddReferences(ctx, shardName, group.refs)
 		for i, err := range errs {
 			desiredPos := group.pos[i]
 			out[desiredPos] = err
@@ -501,8 +589,8 @@ func (i *Index) IncomingBatchAddReferences(ctx context.Context, shardName string
 ) []error {
 	i.snapshotStateLock.RLock()
 	defer i.snapshotStateLock.RUnlock()
-	localShard, ok := i.Shards[shardName]
-	if !ok {
+	localShard := i.shard(shardName)
+	if localShard == nil {
 		return duplicateErr(errors.Errorf("shard %q does not exist locally",
 			shardName), len(refs))
 	}
@@ -513,10 +601,13 @@ func (i *Index) IncomingBatchAddReferences(ctx context.Context, shardName string
 func (i *Index) objectByID(ctx context.Context, id strfmt.UUID,
 	props search.SelectProperties, additional additional.Properties,
 ) (*storobj.Object, error) {
-	shardName, err := i.shardFromUUID(id)
+	shardName, err := i.shardFromID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if shardName == "" {
+		return nil, nil
+	}
 
 	local := i.getSchema.
 		ShardingState(i.Config.ClassName.String()).
@@ -527,7 +618,7 @@ func (i *Index) objectByID(ctx context.Context, id strfmt.UUID,
 		return remote, err
 	}
 
-	shard := i.Shards[shardName]
+	shard := i.shard(shardName)
 	obj, err := shard.objectByID(ctx, id, props, additional)
 	if err != nil {
 		return nil, errors.Wrapf(err, "shard %s", shard.ID())
@@ -540,8 +631,8 @@ func (i *Index) IncomingGetObject(ctx context.Context, shardName string,
 	id strfmt.UUID, props search.SelectProperties,
 	additional additional.Properties,
 ) (*storobj.Object, error) {
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return nil, errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
@@ -556,8 +647,8 @@ func (i *Index) IncomingGetObject(ctx context.Context, shardName string,
 func (i *Index) IncomingMultiGetObjects(ctx context.Context, shardName string,
 	ids []strfmt.UUID,
 ) ([]*storobj.Object, error) {
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return nil, errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
@@ -579,16 +670,27 @@ func (i *Index) multiObjectByID(ctx context.Context,
 
 	byShard := map[string]idsAndPos{}
 
+	// if the class is sharded by a property, any shard could hold the objects,
+	// each one only returns those it holds
+	state := i.getSchema.ShardingState(i.Config.ClassName.String())
+	allShards := state.AllServingPhysicalShards()
+
 	for pos, id := range query {
-		shardName, err := i.shardFromUUID(strfmt.UUID(id.ID))
-		if err != nil {
-			return nil, err
+		shardNames := allShards
+		if state.Config.KeyProperty() == "" {
+			shardName, err := i.shardFromUUID(strfmt.UUID(id.ID))
+			if err != nil {
+				return nil, err
+			}
+			shardNames = []string{shardName}
 		}
 
-		group := byShard[shardName]
-		group.ids = append(group.ids, id)
-		group.pos = append(group.pos, pos)
-		byShard[shardName] = group
+		for _, shardName := range shardNames {
+			group := byShard[shardName]
+			group.ids = append(group.ids, id)
+			group.pos = append(group.pos, pos)
+			byShard[shardName] = group
+		}
 	}
 
 	out := make([]*storobj.Object, len(query))
@@ -602,7 +704,7 @@ func (i *Index) multiObjectByID(ctx context.Context,
 		var err error
 
 		if local {
-			shard := i.Shards[shardName]
+			shard := i.shard(shardName)
 			objects, err = shard.multiObjectByID(ctx, group.ids)
 			if err != nil {
 				return nil, errors.Wrapf(err, "shard %s", shard.ID())
@@ -615,6 +717,9 @@ func (i *Index) multiObjectByID(ctx context.Context,
 		}
 
 		for i, obj := range objects {
+			if obj == nil {
+				continue
+			}
 			desiredPos := group.pos[i]
 			out[desiredPos] = obj
 		}
@@ -644,34 +749,45 @@ func wrapIDsInMulti(in []strfmt.UUID) []multi.Identifier {
 }
 
 func (i *Index) exists(ctx context.Context, id strfmt.UUID) (bool, error) {
+	state := i.getSchema.ShardingState(i.Config.ClassName.String())
+	if state.Config.KeyProperty() != "" {
+		shardName, err := i.shardFromID(ctx, id)
+		return shardName != "", err
+	}
+
 	shardName, err := i.shardFromUUID(id)
 	if err != nil {
 		return false, err
 	}
 
+	ok, err := i.existsInShard(ctx, shardName, id)
+	if err != nil {
+		return false, errors.Wrapf(err, "shard %s", shardName)
+	}
+
+	return ok, nil
+}
+
+func (i *Index) existsInShard(ctx context.Context, shardName string,
+	id strfmt.UUID,
+) (bool, error) {
 	local := i.getSchema.
 		ShardingState(i.Config.ClassName.String()).
 		IsShardLocal(shardName)
 
-	var ok bool
 	if local {
-		shard := i.Shards[shardName]
-		ok, err = shard.exists(ctx, id)
-	} else {
-		ok, err = i.remote.Exists(ctx, shardName, id)
-	}
-	if err != nil {
-		return false, errors.Wrapf(err, "shard %s", shardName)
+		shard := i.shard(shardName)
+		return shard.exists(ctx, id)
 	}
 
-	return ok, nil
+	return i.remote.Exists(ctx, shardName, id)
 }
 
 func (i *Index) IncomingExists(ctx context.Context, shardName string,
 	id strfmt.UUID,
 ) (bool, error) {
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return false, errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
@@ -686,9 +802,8 @@ func (i *Index) IncomingExists(ctx context.Context, shardName string,
 func (i *Index) objectSearch(ctx context.Context, limit int, filters *filters.LocalFilter,
 	keywordRanking *searchparams.KeywordRanking, sort []filters.Sort,
 	additional additional.Properties,
-) ([]*storobj.Object, error) {
-	shardNames := i.getSchema.ShardingState(i.Config.ClassName.String()).
-		AllPhysicalShards()
+) ([]*storobj.Object, []float32, error) {
+	shardNames := i.shardsFromFilters(filters)
 
 	outObjects := make([]*storobj.Object, 0, len(shardNames)*limit)
 	outScores := make([]float32, 0, len(shardNames)*limit)
@@ -702,17 +817,17 @@ func (i *Index) objectSearch(ctx context.Context, limit int, filters *filters.Lo
 		var err error
 
 		if local {
-			shard := i.Shards[shardName]
+			shard := i.shard(shardName)
 			objs, scores, err = shard.objectSearch(ctx, limit, filters, keywordRanking, sort, additional)
 			if err != nil {
-				return nil, errors.Wrapf(err, "shard %s", shard.ID())
+				return nil, nil, errors.Wrapf(err, "shard %s", shard.ID())
 			}
 
 		} else {
 			objs, scores, err = i.remote.SearchShard(
-				ctx, shardName, nil, limit, filters, keywordRanking, sort, additional)
+				ctx, shardName, nil, "", limit, filters, keywordRanking, sort, additional)
 			if err != nil {
-				return nil, errors.Wrapf(err, "remote shard %s", shardName)
+				return nil, nil, errors.Wrapf(err, "remote shard %s", shardName)
 			}
 		}
 		outObjects = append(outObjects, objs...)
@@ -721,17 +836,17 @@ func (i *Index) objectSearch(ctx context.Context, limit int, filters *filters.Lo
 
 	if len(sort) > 0 {
 		if len(shardNames) > 1 {
-			sortedObjs, _, err := i.sort(outObjects, outScores, sort, limit)
+			sortedObjs, sortedScores, err := i.sort(outObjects, outScores, sort, limit)
 			if err != nil {
-				return nil, errors.Wrap(err, "sort")
+				return nil, nil, errors.Wrap(err, "sort")
 			}
-			return sortedObjs, nil
+			return sortedObjs, sortedScores, nil
 		}
-		return outObjects, nil
+		return outObjects, outScores, nil
 	}
 
 	if keywordRanking != nil {
-		outObjects, _ = i.sortKeywordRanking(outObjects, outScores)
+		outObjects, outScores = i.sortKeywordRanking(outObjects, outScores)
 	}
 
 	// if this search was caused by a reference property
@@ -745,9 +860,12 @@ func (i *Index) objectSearch(ctx context.Context, limit int, filters *filters.Lo
 	// and return all referenced object properties.
 	if !additional.ReferenceQuery && len(outObjects) > limit {
 		outObjects = outObjects[:limit]
+		if len(outScores) > limit {
+			outScores = outScores[:limit]
+		}
 	}
 
-	return outObjects, nil
+	return outObjects, outScores, nil
 }
 
 func (i *Index) sortKeywordRanking(objects []*storobj.Object,
@@ -764,11 +882,10 @@ func (i *Index) sort(objects []*storobj.Object, scores []float32,
 }
 
 func (i *Index) objectVectorSearch(ctx context.Context, searchVector []float32,
-	dist float32, limit int, filters *filters.LocalFilter,
+	targetVector string, dist float32, limit int, filters *filters.LocalFilter,
 	sort []filters.Sort, additional additional.Properties,
 ) ([]*storobj.Object, []float32, error) {
-	shardNames := i.getSchema.ShardingState(i.Config.ClassName.String()).
-		AllPhysicalShards()
+	shardNames := i.shardsFromFilters(filters)
 
 	errgrp := &errgroup.Group{}
 	m := &sync.Mutex{}
@@ -796,15 +913,15 @@ func (i *Index) objectVectorSearch(ctx context.Context, searchVector []float32,
 			var err error
 
 			if local {
-				shard := i.Shards[shardName]
+				shard := i.shard(shardName)
 				res, resDists, err = shard.objectVectorSearch(
-					ctx, searchVector, dist, limit, filters, sort, additional)
+					ctx, searchVector, targetVector, dist, limit, filters, sort, additional)
 				if err != nil {
 					return errors.Wrapf(err, "shard %s", shard.ID())
 				}
 			} else {
 				res, resDists, err = i.remote.SearchShard(
-					ctx, shardName, searchVector, limit, filters, nil, sort, additional)
+					ctx, shardName, searchVector, targetVector, limit, filters, nil, sort, additional)
 				if err != nil {
 					return errors.Wrapf(err, "remote shard %s", shardName)
 				}
@@ -840,13 +957,86 @@ func (i *Index) objectVectorSearch(ctx context.Context, searchVector []float32,
 	return out, dists, nil
 }
 
+// objectHybridSearch runs both a keyword-based (BM25) and a vector search on
+// all shards and fuses the two rankings into one. Fusing on the server,
+// rather than the client, keeps pagination consistent: the same fused list
+// is cut for every page.
+//
+// Both searches fetch the same number of candidates no matter which page is
+// requested. Otherwise the fused score of an object would depend on whether
+// it made it into the shorter or longer candidate list, and objects could
+// move across page boundaries.
+func (i *Index) objectHybridSearch(ctx context.Context,
+	hybrid *searchparams.HybridSearch, limit int, additional additional.Properties,
+) ([]*storobj.Object, []float32, error) {
+	var (
+		keywordObjs   []*storobj.Object
+		keywordScores []float32
+		vectorObjs    []*storobj.Object
+		vectorDists   []float32
+	)
+
+	// both rankings are fused over the same window for every page, so that
+	// the pages line up, unless a page reaches beyond the window
+	candidates := int(i.Config.HybridCandidates)
+	if limit > candidates {
+		candidates = limit
+	}
+
+	// an alpha of 1 is a pure vector search and an alpha of 0 a pure keyword
+	// search, there is no need to run the part that has no weight
+	if hybrid.Alpha < 1 {
+		keywordRanking := &searchparams.KeywordRanking{
+			Type:       "bm25",
+			Query:      hybrid.Query,
+			Properties: hybrid.Properties,
+		}
+
+		objs, scores, err := i.objectSearch(ctx, candidates, nil, keywordRanking, nil, additional)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "hybrid: keyword search")
+		}
+		keywordObjs, keywordScores = objs, scores
+	}
+
+	if hybrid.Alpha > 0 {
+		objs, dists, err := i.objectVectorSearch(ctx, hybrid.Vector, hybrid.TargetVector,
+			0, candidates, nil, nil, additional)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "hybrid: vector search")
+		}
+		vectorObjs, vectorDists = objs, dists
+	}
+
+	fuser := newHybridFuser(hybrid.FusionType, hybrid.Alpha)
+	fused := fuser.fuse(keywordObjs, keywordScores, vectorObjs, vectorDists)
+	if limit > 0 && len(fused) > limit {
+		fused = fused[:limit]
+	}
+
+	out := make([]*storobj.Object, len(fused))
+	scores := make([]float32, len(fused))
+	for j, res := range fused {
+		if additional.ExplainScore {
+			if res.object.Object.Additional == nil {
+				res.object.Object.Additional = models.AdditionalProperties{}
+			}
+			res.object.Object.Additional["explainScore"] = fuser.explain(res)
+		}
+		out[j] = res.object
+		scores[j] = float32(res.score)
+	}
+
+	return out, scores, nil
+}
+
 func (i *Index) IncomingSearch(ctx context.Context, shardName string,
-	searchVector []float32, distance float32, limit int, filters *filters.LocalFilter,
+	searchVector []float32, targetVector string, distance float32, limit int, filters *filters.LocalFilter,
 	keywordRanking *searchparams.KeywordRanking, sort []filters.Sort,
 	additional additional.Properties,
 ) ([]*storobj.Object, []float32, error) {
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return nil, nil, errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
@@ -860,7 +1050,7 @@ func (i *Index) IncomingSearch(ctx context.Context, shardName string,
 	}
 
 	res, resDists, err := shard.objectVectorSearch(
-		ctx, searchVector, distance, limit, filters, sort, additional)
+		ctx, searchVector, targetVector, distance, limit, filters, sort, additional)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "shard %s", shard.ID())
 	}
@@ -871,19 +1061,24 @@ func (i *Index) IncomingSearch(ctx context.Context, shardName string,
 func (i *Index) deleteObject(ctx context.Context, id strfmt.UUID) error {
 	i.snapshotStateLock.RLock()
 	defer i.snapshotStateLock.RUnlock()
-	shardName, err := i.shardFromUUID(id)
+	shardName, err := i.shardFromID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if shardName == "" {
+		// there is nothing to delete
+		return nil
+	}
 
 	local := i.getSchema.
 		ShardingState(i.Config.ClassName.String()).
 		IsShardLocal(shardName)
 
 	if local {
-		shard := i.Shards[shardName]
+		shard := i.shard(shardName)
 		err = shard.deleteObject(ctx, id)
-	} else {
+	}
+	if err == nil {
 		err = i.remote.DeleteObject(ctx, shardName, id)
 	}
 	if err != nil {
@@ -898,8 +1093,8 @@ func (i *Index) IncomingDeleteObject(ctx context.Context, shardName string,
 ) error {
 	i.snapshotStateLock.RLock()
 	defer i.snapshotStateLock.RUnlock()
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
@@ -914,19 +1109,23 @@ func (i *Index) IncomingDeleteObject(ctx context.Context, shardName string,
 func (i *Index) mergeObject(ctx context.Context, merge objects.MergeDocument) error {
 	i.snapshotStateLock.RLock()
 	defer i.snapshotStateLock.RUnlock()
-	shardName, err := i.shardFromUUID(merge.ID)
+	shardName, err := i.shardFromID(ctx, merge.ID)
 	if err != nil {
 		return err
 	}
+	if shardName == "" {
+		return errors.Errorf("no object with id %s", merge.ID)
+	}
 
 	local := i.getSchema.
 		ShardingState(i.Config.ClassName.String()).
 		IsShardLocal(shardName)
 
 	if local {
-		shard := i.Shards[shardName]
+		shard := i.shard(shardName)
 		err = shard.mergeObject(ctx, merge)
-	} else {
+	}
+	if err == nil {
 		err = i.remote.MergeObject(ctx, shardName, merge)
 	}
 	if err != nil {
@@ -941,8 +1140,8 @@ func (i *Index) IncomingMergeObject(ctx context.Context, shardName string,
 ) error {
 	i.snapshotStateLock.RLock()
 	defer i.snapshotStateLock.RUnlock()
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
@@ -958,7 +1157,7 @@ func (i *Index) aggregate(ctx context.Context,
 	params aggregation.Params,
 ) (*aggregation.Result, error) {
 	shardState := i.getSchema.ShardingState(i.Config.ClassName.String())
-	shardNames := shardState.AllPhysicalShards()
+	shardNames := i.shardsFromFilters(params.Filters)
 
 	results := make([]*aggregation.Result, len(shardNames))
 	for j, shardName := range shardNames {
@@ -969,7 +1168,7 @@ func (i *Index) aggregate(ctx context.Context,
 		if !local {
 			res, err = i.remote.Aggregate(ctx, shardName, params)
 		} else {
-			shard := i.Shards[shardName]
+			shard := i.shard(shardName)
 			res, err = shard.aggregate(ctx, params)
 		}
 		if err != nil {
@@ -989,8 +1188,8 @@ func (i *Index) aggregate(ctx context.Context,
 func (i *Index) IncomingAggregate(ctx context.Context, shardName string,
 	params aggregation.Params,
 ) (*aggregation.Result, error) {
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return nil, errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
@@ -1007,8 +1206,8 @@ func (i *Index) drop() error {
 	defer i.snapshotStateLock.RUnlock()
 	for _, name := range i.getSchema.ShardingState(i.Config.ClassName.String()).
 		AllPhysicalShards() {
-		shard, ok := i.Shards[name]
-		if !ok {
+		shard := i.shard(name)
+		if shard == nil {
 			// skip non-local, but do delete everything that exists - even if it
 			// shouldn't
 			continue
@@ -1025,7 +1224,7 @@ func (i *Index) drop() error {
 func (i *Index) Shutdown(ctx context.Context) error {
 	i.snapshotStateLock.RLock()
 	defer i.snapshotStateLock.RUnlock()
-	for id, shard := range i.Shards {
+	for id, shard := range i.localShards() {
 		if err := shard.shutdown(ctx); err != nil {
 			return errors.Wrapf(err, "shutdown shard %q", id)
 		}
@@ -1048,8 +1247,8 @@ func (i *Index) getShardsStatus(ctx context.Context) (map[string]string, error)
 		if !local {
 			status, err = i.remote.GetShardStatus(ctx, shardName)
 		} else {
-			shard, ok := i.Shards[shardName]
-			if !ok {
+			shard := i.shard(shardName)
+			if shard == nil {
 				err = errors.Errorf("shard %s does not exist", shardName)
 			} else {
 				status = shard.getStatus().String()
@@ -1066,28 +1265,75 @@ func (i *Index) getShardsStatus(ctx context.Context) (map[string]string, error)
 }
 
 func (i *Index) IncomingGetShardStatus(ctx context.Context, shardName string) (string, error) {
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return "", errors.Errorf("shard %q does not exist", shardName)
 	}
 	return shard.getStatus().String(), nil
 }
 
+// getShardsReindexStatus returns the progress of the properties which are
+// being reindexed, by shard. Shards without a reindex are left out.
+func (i *Index) getShardsReindexStatus(ctx context.Context,
+) (map[string][]*models.PropertyReindexStatus, error) {
+	out := make(map[string][]*models.PropertyReindexStatus)
+
+	shardState := i.getSchema.ShardingState(i.Config.ClassName.String())
+	shardNames := shardState.AllPhysicalShards()
+
+	for _, shardName := range shardNames {
+		local := shardState.IsShardLocal(shardName)
+
+		var err error
+		var status []*models.PropertyReindexStatus
+		if !local {
+			status, err = i.remote.GetShardReindexStatus(ctx, shardName)
+		} else {
+			shard := i.shard(shardName)
+			if shard == nil {
+				err = errors.Errorf("shard %s does not exist", shardName)
+			} else {
+				status = shard.reindexStatus()
+			}
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "shard %s", shardName)
+		}
+
+		if len(status) > 0 {
+			out[shardName] = status
+		}
+	}
+
+	return out, nil
+}
+
+func (i *Index) IncomingGetShardReindexStatus(ctx context.Context,
+	shardName string,
+) ([]*models.PropertyReindexStatus, error) {
+	shard := i.shard(shardName)
+	if shard == nil {
+		return nil, errors.Errorf("shard %q does not exist", shardName)
+	}
+	return shard.reindexStatus(), nil
+}
+
 func (i *Index) updateShardStatus(ctx context.Context, shardName, targetStatus string) error {
 	shardState := i.getSchema.ShardingState(i.Config.ClassName.String())
 
 	var err error
 	local := shardState.IsShardLocal(shardName)
-	if !local {
-		err = i.remote.UpdateShardStatus(ctx, shardName, targetStatus)
-	} else {
-		shard, ok := i.Shards[shardName]
-		if !ok {
+	if local {
+		shard := i.shard(shardName)
+		if shard == nil {
 			err = errors.Errorf("shard %s does not exist", shardName)
 		} else {
 			err = shard.updateStatus(targetStatus)
 		}
 	}
+	if err == nil {
+		err = i.remote.UpdateShardStatus(ctx, shardName, targetStatus)
+	}
 	if err != nil {
 		return errors.Wrapf(err, "shard %s", shardName)
 	}
@@ -1096,15 +1342,15 @@ func (i *Index) updateShardStatus(ctx context.Context, shardName, targetStatus s
 }
 
 func (i *Index) IncomingUpdateShardStatus(ctx context.Context, shardName, targetStatus string) error {
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return errors.Errorf("shard %s does not exist", shardName)
 	}
 	return shard.updateStatus(targetStatus)
 }
 
 func (i *Index) notifyReady() {
-	for _, shd := range i.Shards {
+	for _, shd := range i.localShards() {
 		shd.notifyReady()
 	}
 }
@@ -1116,7 +1362,7 @@ func (i *Index) findDocIDs(ctx context.Context,
 	defer i.metrics.BatchDelete(before, "filter_total")
 
 	shardState := i.getSchema.ShardingState(i.Config.ClassName.String())
-	shardNames := shardState.AllPhysicalShards()
+	shardNames := i.shardsFromFilters(filters)
 
 	results := make(map[string][]uint64)
 	for _, shardName := range shardNames {
@@ -1127,7 +1373,7 @@ func (i *Index) findDocIDs(ctx context.Context,
 		if !local {
 			res, err = i.remote.FindDocIDs(ctx, shardName, filters)
 		} else {
-			shard := i.Shards[shardName]
+			shard := i.shard(shardName)
 			res, err = shard.findDocIDs(ctx, filters)
 		}
 		if err != nil {
@@ -1143,8 +1389,8 @@ func (i *Index) findDocIDs(ctx context.Context,
 func (i *Index) IncomingFindDocIDs(ctx context.Context, shardName string,
 	filters *filters.LocalFilter,
 ) ([]uint64, error) {
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return nil, errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
@@ -1183,9 +1429,12 @@ func (i *Index) batchDeleteObjects(ctx context.Context,
 			if !local {
 				objs = i.remote.DeleteObjectBatch(ctx, shardName, docIDs, dryRun)
 			} else {
-				shard := i.Shards[shardName]
+				shard := i.shard(shardName)
 				objs = shard.deleteObjectBatch(ctx, docIDs, dryRun)
 			}
+			if !dryRun {
+				i.replicateBatchDelete(ctx, shardName, objs)
+			}
 			ch <- result{objs}
 		}(shardName, docIDs)
 	}
@@ -1201,13 +1450,35 @@ func (i *Index) batchDeleteObjects(ctx context.Context,
 	return out, nil
 }
 
+// replicateBatchDelete deletes the objects, which were deleted from a single
+// replica of the shard by their doc ids, from all other replicas. Doc ids
+// differ between the replicas, so the objects are deleted by their id.
+func (i *Index) replicateBatchDelete(ctx context.Context, shardName string,
+	objs objects.BatchSimpleObjects,
+) {
+	shard := i.getSchema.ShardingState(i.Config.ClassName.String()).
+		Physical[shardName]
+	if len(shard.ReplicaNodes) == 0 {
+		return
+	}
+
+	for j := range objs {
+		if objs[j].Err != nil {
+			continue
+		}
+		if err := i.remote.DeleteObject(ctx, shardName, objs[j].UUID); err != nil {
+			objs[j].Err = errors.Wrapf(err, "shard %s", shardName)
+		}
+	}
+}
+
 func (i *Index) IncomingDeleteObjectBatch(ctx context.Context, shardName string,
 	docIDs []uint64, dryRun bool,
 ) objects.BatchSimpleObjects {
 	i.snapshotStateLock.RLock()
 	defer i.snapshotStateLock.RUnlock()
-	shard, ok := i.Shards[shardName]
-	if !ok {
+	shard := i.shard(shardName)
+	if shard == nil {
 		return objects.BatchSimpleObjects{
 			objects.BatchSimpleObject{Err: errors.Errorf("shard %q does not exist locally", shardName)},
 		}
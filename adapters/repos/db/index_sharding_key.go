@@ -0,0 +1,186 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
+)
+
+// shardingKey returns the key the shard of the object is determined by. This
+// is its id, unless the class is sharded by a property, then it is the value
+// of that property.
+func shardingKey(state *sharding.State, object *storobj.Object) ([]byte, error) {
+	key := state.Config.KeyProperty()
+	if key == "" {
+		return uuidKey(object.ID())
+	}
+
+	props, _ := object.Properties().(map[string]interface{})
+	value, ok := props[key]
+	if !ok || value == nil {
+		return nil, errors.Errorf("object %s has no value for sharding key %q",
+			object.ID(), key)
+	}
+
+	return state.Config.PropertyKey(value)
+}
+
+func uuidKey(in strfmt.UUID) ([]byte, error) {
+	uuid, err := uuid.Parse(in.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "parse id as uuid")
+	}
+
+	return uuid.MarshalBinary()
+}
+
+func (i *Index) shardFromUUID(in strfmt.UUID) (string, error) {
+	key, err := uuidKey(in)
+	if err != nil {
+		return "", err
+	}
+
+	return i.getSchema.ShardingState(i.Config.ClassName.String()).
+		PhysicalShard(key), nil
+}
+
+func (i *Index) shardFromObject(object *storobj.Object) (string, error) {
+	state := i.getSchema.ShardingState(i.Config.ClassName.String())
+	key, err := shardingKey(state, object)
+	if err != nil {
+		return "", err
+	}
+
+	return state.PhysicalShard(key), nil
+}
+
+// shardFromID returns the shard holding the object with the specified id.
+// If the class is sharded by a property, any shard could hold it, so every
+// shard is asked until one does. The name is empty if no shard holds it.
+func (i *Index) shardFromID(ctx context.Context, id strfmt.UUID) (string, error) {
+	state := i.getSchema.ShardingState(i.Config.ClassName.String())
+	if state.Config.KeyProperty() == "" {
+		return i.shardFromUUID(id)
+	}
+
+	for _, shardName := range state.AllServingPhysicalShards() {
+		ok, err := i.existsInShard(ctx, shardName, id)
+		if err != nil {
+			return "", errors.Wrapf(err, "shard %s", shardName)
+		}
+		if ok {
+			return shardName, nil
+		}
+	}
+
+	return "", nil
+}
+
+// shardsFromFilters returns the shards which can hold objects matching the
+// filters. If the class is sharded by a property and the filters only match
+// specific values of it, these are the shards of those values, otherwise it
+// is every shard.
+func (i *Index) shardsFromFilters(filters *filters.LocalFilter) []string {
+	state := i.getSchema.ShardingState(i.Config.ClassName.String())
+	if filters == nil || filters.Root == nil || state.Config.KeyProperty() == "" {
+		return state.AllServingPhysicalShards()
+	}
+
+	pinned := pinnedShards(state, filters.Root)
+	if len(pinned) == 0 {
+		return state.AllServingPhysicalShards()
+	}
+
+	shardNames := make([]string, 0, len(pinned))
+	for shardName := range pinned {
+		shardNames = append(shardNames, shardName)
+	}
+	sort.Strings(shardNames)
+
+	return shardNames
+}
+
+// pinnedShards returns the shards holding the objects the clause can match,
+// if it pins the property the class is sharded by to specific values. It
+// returns nil if the clause could match objects in any shard.
+func pinnedShards(state *sharding.State, clause *filters.Clause) map[string]struct{} {
+	switch clause.Operator {
+	case filters.OperatorEqual:
+		if clause.On == nil || clause.On.Child != nil || clause.Value == nil ||
+			string(clause.On.Property) != state.Config.KeyProperty() {
+			return nil
+		}
+
+		switch clause.Value.Type {
+		case schema.DataTypeString, schema.DataTypeInt:
+		default:
+			return nil
+		}
+
+		key, err := state.Config.PropertyKey(clause.Value.Value)
+		if err != nil {
+			return nil
+		}
+
+		return map[string]struct{}{state.PhysicalShard(key): {}}
+
+	case filters.OperatorAnd:
+		// every operand has to match, so each one pinning the shards narrows
+		// them down further
+		var out map[string]struct{}
+		for j := range clause.Operands {
+			pinned := pinnedShards(state, &clause.Operands[j])
+			if pinned == nil {
+				continue
+			}
+
+			if out == nil {
+				out = pinned
+				continue
+			}
+
+			for shardName := range out {
+				if _, ok := pinned[shardName]; !ok {
+					delete(out, shardName)
+				}
+			}
+		}
+		return out
+
+	case filters.OperatorOr:
+		// any operand can match, so all of them have to pin the shards
+		out := map[string]struct{}{}
+		for j := range clause.Operands {
+			pinned := pinnedShards(state, &clause.Operands[j])
+			if pinned == nil {
+				return nil
+			}
+
+			for shardName := range pinned {
+				out[shardName] = struct{}{}
+			}
+		}
+		return out
+
+	default:
+		return nil
+	}
+}
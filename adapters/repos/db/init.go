@@ -55,11 +55,13 @@ func (d *DB) init(ctx context.Context) error {
 				DiskUseWarningPercentage:  d.config.DiskUseWarningPercentage,
 				DiskUseReadOnlyPercentage: d.config.DiskUseReadOnlyPercentage,
 				QueryMaximumResults:       d.config.QueryMaximumResults,
+				HybridCandidates:          d.config.HybridCandidates,
 				MaxImportGoroutinesFactor: d.config.MaxImportGoroutinesFactor,
 				NodeName:                  d.config.NodeName,
 			}, d.schemaGetter.ShardingState(class.Class),
 				inverted.ConfigFromModel(invertedConfig),
 				class.VectorIndexConfig.(schema.VectorIndexConfig),
+				namedVectorIndexConfigs(class),
 				d.schemaGetter, d, d.logger, d.nodeResolver, d.remoteClient, d.promMetrics)
 			if err != nil {
 				return errors.Wrap(err, "create index")
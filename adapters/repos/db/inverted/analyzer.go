@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"encoding/binary"
 
+	"github.com/google/uuid"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/stopwords"
 	"github.com/semi-technologies/weaviate/entities/models"
@@ -23,6 +24,10 @@ import (
 type Countable struct {
 	Data          []byte
 	TermFrequency float32
+
+	// Positions of the term within the property, only set for text and text[]
+	// properties
+	Positions []uint32
 }
 
 type Property struct {
@@ -49,6 +54,17 @@ func (a *Analyzer) TextArray(tokenization string, in []string) []Countable {
 	return a.countParts(parts)
 }
 
+// TextProp tokenizes the values of a text or text[] property and applies the
+// analyzer of the property, then aggregates duplicates
+func (a *Analyzer) TextProp(prop *models.Property, in []string) ([]Countable, error) {
+	analysis, err := newTextAnalysis(prop, a.stopwords)
+	if err != nil {
+		return nil, err
+	}
+
+	return countPositionedTerms(analysis.positionedTerms(in...)), nil
+}
+
 func textArrayTokenize(tokenization string, in []string) []string {
 	var parts []string
 
@@ -57,6 +73,14 @@ func textArrayTokenize(tokenization string, in []string) []string {
 		for _, value := range in {
 			parts = append(parts, helpers.TokenizeText(value)...)
 		}
+	case models.PropertyTokenizationTrigram:
+		for _, value := range in {
+			parts = append(parts, helpers.TokenizeTrigram(value)...)
+		}
+	case models.PropertyTokenizationCjk:
+		for _, value := range in {
+			parts = append(parts, helpers.TokenizeCJK(value)...)
+		}
 	}
 
 	return parts
@@ -96,12 +120,39 @@ func stringArrayTokenize(tokenization string, in []string) []string {
 }
 
 func (a *Analyzer) countParts(parts []string) []Countable {
-	terms := map[string]uint64{}
+	words := make([]string, 0, len(parts))
 	for _, word := range parts {
 		if a.stopwords.IsStopword(word) {
 			continue
 		}
 
+		words = append(words, word)
+	}
+
+	return countTerms(words)
+}
+
+func countPositionedTerms(terms []positionedTerm) []Countable {
+	var out []Countable
+	indices := map[string]int{}
+	for _, term := range terms {
+		i, ok := indices[term.term]
+		if !ok {
+			i = len(out)
+			indices[term.term] = i
+			out = append(out, Countable{Data: []byte(term.term)})
+		}
+
+		out[i].TermFrequency++
+		out[i].Positions = append(out[i].Positions, term.position)
+	}
+
+	return out
+}
+
+func countTerms(words []string) []Countable {
+	terms := map[string]uint64{}
+	for _, word := range words {
 		count, ok := terms[word]
 		if !ok {
 			terms[word] = 0
@@ -214,6 +265,23 @@ func (a *Analyzer) Bool(in bool) ([]Countable, error) {
 	}, nil
 }
 
+// UUID requires no analysis, the 16 bytes of the uuid are indexed as they
+// are, which makes them both exact matches and lexicographically sortable
+func (a *Analyzer) UUID(in uuid.UUID) []Countable {
+	return a.UUIDArray([]uuid.UUID{in})
+}
+
+func (a *Analyzer) UUIDArray(in []uuid.UUID) []Countable {
+	out := make([]Countable, len(in))
+	for i := range in {
+		data := make([]byte, len(in[i]))
+		copy(data, in[i][:])
+		out[i] = Countable{Data: data}
+	}
+
+	return out
+}
+
 // RefCount does not index the content of the refs, but only the count with 0
 // being an explicitly allowed value as well.
 func (a *Analyzer) RefCount(in models.MultipleRef) ([]Countable, error) {
@@ -17,6 +17,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/stopwords"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
@@ -380,6 +381,20 @@ func TestAnalyzer(t *testing.T) {
 		sort.Slice(afterSort, func(a, b int) bool { return bytes.Compare(afterSort[a], afterSort[b]) == -1 })
 		assert.Equal(t, results, afterSort)
 	})
+
+	t.Run("with uuids", func(t *testing.T) {
+		id1 := uuid.MustParse("28f3f61b-b524-45e0-9bbe-2c1550bf73d2")
+		id2 := uuid.MustParse("0ca2a9d9-6d3f-4b0b-8c4e-3f0f3c4b7a5e")
+
+		countable := a.UUID(id1)
+		require.Len(t, countable, 1)
+		assert.Equal(t, id1[:], countable[0].Data)
+
+		countable = a.UUIDArray([]uuid.UUID{id1, id2})
+		require.Len(t, countable, 2)
+		assert.Equal(t, id1[:], countable[0].Data)
+		assert.Equal(t, id2[:], countable[1].Data)
+	})
 }
 
 func TestAnalyzer_ConfigurableStopwords(t *testing.T) {
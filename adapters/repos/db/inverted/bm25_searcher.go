@@ -19,15 +19,16 @@ import (
 	"math"
 	"runtime/debug"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/stopwords"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/propertyspecific"
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/searchparams"
 	"github.com/semi-technologies/weaviate/entities/storobj"
@@ -43,6 +44,7 @@ type BM25Searcher struct {
 	propIndices   propertyspecific.Indices
 	deletedDocIDs DeletedDocIDChecker
 	propLengths   propLengthRetriever
+	stopwords     stopwords.StopwordDetector
 	logger        logrus.FieldLogger
 	shardVersion  uint16
 }
@@ -54,8 +56,8 @@ type propLengthRetriever interface {
 func NewBM25Searcher(config schema.BM25Config, store *lsmkv.Store, schema schema.Schema,
 	rowCache cacher, propIndices propertyspecific.Indices,
 	classSearcher ClassSearcher, deletedDocIDs DeletedDocIDChecker,
-	propLengths propLengthRetriever, logger logrus.FieldLogger,
-	shardVersion uint16,
+	propLengths propLengthRetriever, stopwords stopwords.StopwordDetector,
+	logger logrus.FieldLogger, shardVersion uint16,
 ) *BM25Searcher {
 	return &BM25Searcher{
 		config:        config,
@@ -66,6 +68,7 @@ func NewBM25Searcher(config schema.BM25Config, store *lsmkv.Store, schema schema
 		classSearcher: classSearcher,
 		deletedDocIDs: deletedDocIDs,
 		propLengths:   propLengths,
+		stopwords:     stopwords,
 		logger:        logger.WithField("action", "bm25_search"),
 		shardVersion:  shardVersion,
 	}
@@ -85,19 +88,53 @@ func (b *BM25Searcher) Object(ctx context.Context, limit int,
 		}
 	}()
 
-	// TODO: more complex pre-processing with proper split function
-	terms := strings.Split(keywordRanking.Query, " ")
+	properties, err := searchparams.ParsePropertyBoosts(keywordRanking.Properties)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(properties) == 0 {
+		return nil, nil, errors.Errorf("keyword search (bm25) requires at least one property")
+	}
+
+	analyses := make([]*textAnalysis, len(properties))
+	for i, prop := range properties {
+		analyses[i], err = b.propertyAnalysis(className, prop.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	terms, phrases, err := parseKeywordQuery(keywordRanking.Query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idLists := make([]docPointersWithScore, 0, len(terms)+len(phrases))
+
+	for _, term := range terms {
+		// a term with an edit distance suffix, e.g. iphnoe~1, is fuzzy
+		term, distance, fuzzy, err := parseFuzzyTerm(term)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	idLists := make([]docPointersWithScore, len(terms))
+		ids, err := b.retrieveScoreAndSortForSingleTerm(ctx, properties, analyses,
+			term, fuzzy, distance)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		idLists = append(idLists, ids)
+	}
 
-	for i, term := range terms {
-		ids, err := b.retrieveScoreAndSortForSingleTerm(ctx,
-			keywordRanking.Properties[0], term)
+	for _, phrase := range phrases {
+		ids, err := b.retrieveScoreAndSortForPhrase(ctx, properties, analyses, phrase)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		idLists[i] = ids
+		idLists = append(idLists, ids)
 	}
 
 	before := time.Now()
@@ -130,28 +167,114 @@ func (b *BM25Searcher) sort(ids docPointersWithScore) docPointersWithScore {
 	return ids
 }
 
+// propertyAnalysis returns the analysis of the property if it is of type text
+// or text[]. Values of other types are searched as they are.
+func (b *BM25Searcher) propertyAnalysis(className schema.ClassName,
+	propName string,
+) (*textAnalysis, error) {
+	prop, err := b.schema.GetProperty(className, schema.PropertyName(propName))
+	if err != nil {
+		return nil, err
+	}
+
+	if !isTextProp(prop) {
+		return nil, nil
+	}
+
+	return newTextAnalysis(prop, b.stopwords)
+}
+
+// propertyTerms turns a term of the query into the terms it was indexed as
+// in a property, a single query term can result in several or no terms at
+// all, e.g. if it is a stopword or the property is tokenized into n-grams
+func propertyTerms(analysis *textAnalysis, term string) []string {
+	if analysis == nil {
+		return []string{term}
+	}
+
+	return analysis.grams(analysis.terms(helpers.TokenizeText(term)))
+}
+
+// fuzzyPropertyTerms expands the terms of a property into all terms of its
+// inverted index within the edit distance. Fuzzy matching is not applied to
+// n-grams, as they already match similar words.
+func (b *BM25Searcher) fuzzyPropertyTerms(ctx context.Context, propName string,
+	analysis *textAnalysis, terms []string, distance int,
+) ([]string, error) {
+	if analysis != nil && analysis.tokenization != models.PropertyTokenizationWord &&
+		analysis.tokenization != models.PropertyTokenizationField {
+		return terms, nil
+	}
+
+	bucket := b.store.Bucket(helpers.BucketFromPropNameLSM(propName))
+	if bucket == nil {
+		return nil, errors.Errorf("property %q has no searchable inverted index", propName)
+	}
+
+	var out []string
+	for _, term := range terms {
+		matches, err := fuzzyTerms(ctx, bucket, term, distance, b.shardVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fuzzy term %q of property %q", term, propName)
+		}
+
+		for _, match := range matches {
+			out = append(out, string(match))
+		}
+	}
+
+	return out, nil
+}
+
+// retrieveScoreAndSortForSingleTerm scores all docs containing the term in
+// any of the properties. A fuzzy term matches all terms within the edit
+// distance. The returned list is sorted by doc id, as this is what the score
+// merger expects.
 func (b *BM25Searcher) retrieveScoreAndSortForSingleTerm(ctx context.Context,
-	property, term string,
+	properties []searchparams.PropertyBoost, analyses []*textAnalysis, term string,
+	fuzzy bool, distance int,
 ) (docPointersWithScore, error) {
-	before := time.Now()
-	ids, err := b.getIdsWithFrequenciesForTerm(ctx, property, term)
-	if err != nil {
-		return docPointersWithScore{}, errors.Wrap(err,
-			"read doc ids and their frequencies from inverted index")
+	propIDs := make([]docPointersWithScore, len(properties))
+	for i, prop := range properties {
+		before := time.Now()
+		propTerms := propertyTerms(analyses[i], term)
+		if fuzzy {
+			var err error
+			propTerms, err = b.fuzzyPropertyTerms(ctx, prop.Name, analyses[i], propTerms, distance)
+			if err != nil {
+				return docPointersWithScore{}, err
+			}
+		}
+
+		var ids docPointersWithScore
+		for _, propTerm := range propTerms {
+			termIDs, err := b.getIdsWithFrequenciesForTerm(ctx, prop.Name, propTerm)
+			if err != nil {
+				return docPointersWithScore{}, errors.Wrapf(err,
+					"read doc ids and their frequencies from inverted index of %q", prop.Name)
+			}
+			ids.count += termIDs.count
+			ids.docIDs = append(ids.docIDs, termIDs.docIDs...)
+		}
+		took := time.Since(before)
+		b.logger.WithField("took", took).
+			WithField("event", "retrieve_doc_ids").
+			WithField("count", len(ids.docIDs)).
+			WithField("term", term).
+			WithField("property", prop.Name).
+			Debugf("retrieve %d doc ids for term %q took %s", len(ids.docIDs),
+				term, took)
+
+		propIDs[i] = ids
 	}
-	took := time.Since(before)
-	b.logger.WithField("took", took).
-		WithField("event", "retrieve_doc_ids").
-		WithField("count", len(ids.docIDs)).
-		WithField("term", term).
-		Debugf("retrieve %d doc ids for term %q took %s", len(ids.docIDs),
-			term, took)
 
-	before = time.Now()
-	if err := b.score(ids, property); err != nil {
+	before := time.Now()
+	objectCount := float64(b.store.Bucket(helpers.ObjectsBucketLSM).Count())
+	ids, err := b.score(properties, propIDs, objectCount)
+	if err != nil {
 		return docPointersWithScore{}, err
 	}
-	took = time.Since(before)
+	took := time.Since(before)
 	b.logger.WithField("took", took).
 		WithField("event", "score_doc_ids").
 		WithField("count", len(ids.docIDs)).
@@ -162,26 +285,108 @@ func (b *BM25Searcher) retrieveScoreAndSortForSingleTerm(ctx context.Context,
 	return ids, nil
 }
 
-func (bm *BM25Searcher) score(ids docPointersWithScore, propName string) error {
-	m, err := bm.propLengths.PropertyMean(propName)
-	if err != nil {
-		return err
+// retrieveScoreAndSortForPhrase scores all docs containing the phrase in any
+// of the text properties. A phrase is scored like a single term whose
+// frequency is the number of occurrences of the phrase. Properties which are
+// not of type text cannot contain a phrase and are skipped.
+func (b *BM25Searcher) retrieveScoreAndSortForPhrase(ctx context.Context,
+	properties []searchparams.PropertyBoost, analyses []*textAnalysis,
+	phrase keywordPhrase,
+) (docPointersWithScore, error) {
+	propIDs := make([]docPointersWithScore, len(properties))
+	for i, prop := range properties {
+		if analyses[i] == nil {
+			continue
+		}
+
+		pq, err := newPhraseQuery(analyses[i], phrase.text, phrase.slop)
+		if err != nil {
+			return docPointersWithScore{}, errors.Wrapf(err, "property %q", prop.Name)
+		}
+
+		bucket := b.store.Bucket(helpers.PositionsBucketFromPropNameLSM(prop.Name))
+		if bucket == nil {
+			return docPointersWithScore{}, errors.Errorf("property %q has no positional "+
+				"index, add `indexPositions: true` to the invertedIndexConfig to search "+
+				"for phrases", prop.Name)
+		}
+
+		if err := requirePositionsIndexed(b.store); err != nil {
+			return docPointersWithScore{}, err
+		}
+
+		before := time.Now()
+		ids, err := pq.matches(bucket)
+		if err != nil {
+			return docPointersWithScore{}, errors.Wrapf(err,
+				"read doc ids and their positions from positional index of %q", prop.Name)
+		}
+		took := time.Since(before)
+		b.logger.WithField("took", took).
+			WithField("event", "retrieve_doc_ids").
+			WithField("count", len(ids.docIDs)).
+			WithField("phrase", phrase.text).
+			WithField("property", prop.Name).
+			Debugf("retrieve %d doc ids for phrase %q took %s", len(ids.docIDs),
+				phrase.text, took)
+
+		propIDs[i] = ids
 	}
 
-	averageDocLen := float64(m)
+	objectCount := float64(b.store.Bucket(helpers.ObjectsBucketLSM).Count())
+	return b.score(properties, propIDs, objectCount)
+}
+
+// score implements BM25F: the term frequencies of a doc are normalized by
+// the length of the respective property, weighted by the boost of the
+// property and summed up across properties before the saturation with k1 is
+// applied. This way a term that occurs in several properties of the same doc
+// is not counted as if it were several independent matches. With a single
+// property and a boost of 1 this is identical to regular BM25.
+func (bm *BM25Searcher) score(properties []searchparams.PropertyBoost,
+	propIDs []docPointersWithScore, objectCount float64,
+) (docPointersWithScore, error) {
 	k1 := bm.config.K1
 	b := bm.config.B
 
-	N := float64(bm.store.Bucket(helpers.ObjectsBucketLSM).Count())
-	n := float64(len(ids.docIDs))
+	frequencies := map[uint64]float64{}
+	for i, prop := range properties {
+		m, err := bm.propLengths.PropertyMean(prop.Name)
+		if err != nil {
+			return docPointersWithScore{}, err
+		}
+		averagePropLen := float64(m)
+
+		for _, id := range propIDs[i].docIDs {
+			norm := 1 - b
+			if averagePropLen > 0 {
+				norm += b * id.propLength / averagePropLen
+			}
+			frequencies[id.id] += prop.Boost * id.frequency / norm
+		}
+	}
+
+	out := docPointersWithScore{
+		count:  uint64(len(frequencies)),
+		docIDs: make([]docPointerWithScore, 0, len(frequencies)),
+	}
+
+	N := objectCount
+	n := float64(len(frequencies))
 	idf := math.Log(float64(1) + (N-n+0.5)/(n+0.5))
-	for i, id := range ids.docIDs {
-		docLen := id.propLength
-		tf := id.frequency / (id.frequency + k1*(1-b+b*docLen/averageDocLen))
-		ids.docIDs[i].score = tf * idf
+	for id, frequency := range frequencies {
+		out.docIDs = append(out.docIDs, docPointerWithScore{
+			id:        id,
+			frequency: frequency,
+			score:     idf * frequency / (k1 + frequency),
+		})
 	}
 
-	return nil
+	sort.Slice(out.docIDs, func(a, b int) bool {
+		return out.docIDs[a].id < out.docIDs[b].id
+	})
+
+	return out, nil
 }
 
 func (b *BM25Searcher) getIdsWithFrequenciesForTerm(ctx context.Context,
@@ -189,6 +394,10 @@ func (b *BM25Searcher) getIdsWithFrequenciesForTerm(ctx context.Context,
 ) (docPointersWithScore, error) {
 	bucketName := helpers.BucketFromPropNameLSM(prop)
 	bucket := b.store.Bucket(bucketName)
+	if bucket == nil {
+		return docPointersWithScore{}, errors.Errorf("property %q has no searchable "+
+			"inverted index", prop)
+	}
 
 	return b.docPointersInvertedFrequency(prop, bucket, 0, &propValuePair{
 		operator: filters.OperatorEqual,
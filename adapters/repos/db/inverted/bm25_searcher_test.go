@@ -0,0 +1,102 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/searchparams"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePropLengths map[string]float32
+
+func (f fakePropLengths) PropertyMean(prop string) (float32, error) {
+	mean, ok := f[prop]
+	if !ok {
+		return 0, errors.Errorf("prop %q not tracked", prop)
+	}
+	return mean, nil
+}
+
+func Test_BM25F_Score(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	config := schema.BM25Config{K1: 1.2, B: 0.75}
+	searcher := NewBM25Searcher(config, nil, schema.Schema{}, nil, nil, nil, nil,
+		fakePropLengths{"title": 4, "body": 20}, nil, logger, 2)
+
+	titleIDs := docPointersWithScore{docIDs: []docPointerWithScore{
+		{id: 3, frequency: 1, propLength: 4},
+		{id: 7, frequency: 1, propLength: 4},
+	}}
+	bodyIDs := docPointersWithScore{docIDs: []docPointerWithScore{
+		{id: 1, frequency: 2, propLength: 20},
+		{id: 7, frequency: 1, propLength: 20},
+	}}
+
+	t.Run("with a single property it is regular bm25", func(t *testing.T) {
+		res, err := searcher.score([]searchparams.PropertyBoost{{Name: "body", Boost: 1}},
+			[]docPointersWithScore{bodyIDs}, 10)
+		require.Nil(t, err)
+		require.Len(t, res.docIDs, 2)
+
+		idf := math.Log(1 + (10-2+0.5)/(2+0.5))
+		assert.Equal(t, uint64(1), res.docIDs[0].id)
+		assert.InDelta(t, idf*2/(2+1.2), res.docIDs[0].score, 1e-9)
+		assert.Equal(t, uint64(7), res.docIDs[1].id)
+		assert.InDelta(t, idf*1/(1+1.2), res.docIDs[1].score, 1e-9)
+	})
+
+	t.Run("across properties the frequencies of a doc are merged", func(t *testing.T) {
+		res, err := searcher.score([]searchparams.PropertyBoost{
+			{Name: "title", Boost: 1},
+			{Name: "body", Boost: 1},
+		}, []docPointersWithScore{titleIDs, bodyIDs}, 10)
+		require.Nil(t, err)
+
+		// doc 7 is contained in both properties, but only counted once for the
+		// idf and the output is sorted by doc id for the score merger
+		ids := make([]uint64, len(res.docIDs))
+		for i := range res.docIDs {
+			ids[i] = res.docIDs[i].id
+		}
+		assert.Equal(t, []uint64{1, 3, 7}, ids)
+
+		idf := math.Log(1 + (10-3+0.5)/(3+0.5))
+		tf := 1.0 + 1.0
+		assert.InDelta(t, idf*tf/(tf+1.2), res.docIDs[2].score, 1e-9)
+	})
+
+	t.Run("a boost weighs the term frequencies of a property", func(t *testing.T) {
+		res, err := searcher.score([]searchparams.PropertyBoost{
+			{Name: "title", Boost: 3},
+			{Name: "body", Boost: 1},
+		}, []docPointersWithScore{titleIDs, bodyIDs}, 10)
+		require.Nil(t, err)
+		require.Len(t, res.docIDs, 3)
+
+		// a single occurrence in the boosted title outweighs two occurrences
+		// in the body
+		assert.Greater(t, res.docIDs[1].score, res.docIDs[0].score)
+	})
+
+	t.Run("with an untracked property", func(t *testing.T) {
+		_, err := searcher.score([]searchparams.PropertyBoost{{Name: "summary", Boost: 1}},
+			[]docPointersWithScore{{}}, 10)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,218 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+)
+
+const (
+	// maxFuzzyDistance limits the edit distance of fuzzy terms, as the number
+	// of matching terms grows quickly with the distance
+	maxFuzzyDistance = 2
+
+	// maxFuzzyExpansions limits the number of terms a fuzzy term is expanded
+	// to, the closest ones are kept
+	maxFuzzyExpansions = 50
+)
+
+var fuzzySuffixRegexp = regexp.MustCompile(`^(.+)~(\d*)$`)
+
+// parseFuzzyTerm splits the optional edit distance suffix off a fuzzy term,
+// e.g. iphnoe~1. Without a number after the ~ the maximum distance is used.
+// The returned bool is false if the term has no suffix.
+func parseFuzzyTerm(value string) (string, int, bool, error) {
+	match := fuzzySuffixRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return value, 0, false, nil
+	}
+
+	if match[2] == "" {
+		return match[1], maxFuzzyDistance, true, nil
+	}
+
+	distance, err := strconv.Atoi(match[2])
+	if err != nil {
+		return "", 0, false, errors.Wrapf(err, "invalid edit distance of fuzzy term %q", value)
+	}
+	if distance > maxFuzzyDistance {
+		return "", 0, false, errors.Errorf("invalid edit distance of fuzzy term %q, "+
+			"the maximum is %d", value, maxFuzzyDistance)
+	}
+
+	return match[1], distance, true, nil
+}
+
+// autoFuzzyDistance is the edit distance of a fuzzy filter without an
+// explicit distance. Short terms would match too many others otherwise.
+func autoFuzzyDistance(term string) int {
+	switch length := utf8.RuneCountInString(term); {
+	case length <= 2:
+		return 0
+	case length <= 5:
+		return 1
+	default:
+		return maxFuzzyDistance
+	}
+}
+
+// levenshteinAutomaton accepts all terms within the max edit distance of the
+// query. Its state is the current row of the edit distance matrix, so it can
+// be fed a term rune by rune and tell as soon as no continuation of the
+// prefix can match anymore.
+type levenshteinAutomaton struct {
+	query []rune
+	max   int
+}
+
+func newLevenshteinAutomaton(query string, max int) *levenshteinAutomaton {
+	return &levenshteinAutomaton{query: []rune(query), max: max}
+}
+
+func (la *levenshteinAutomaton) start() []int {
+	row := make([]int, len(la.query)+1)
+	for i := range row {
+		row[i] = i
+	}
+	return row
+}
+
+func (la *levenshteinAutomaton) step(row []int, r rune) []int {
+	next := make([]int, len(row))
+	next[0] = row[0] + 1
+	for i := 1; i < len(row); i++ {
+		cost := 1
+		if la.query[i-1] == r {
+			cost = 0
+		}
+		next[i] = min(min(row[i-1]+cost, row[i]+1), next[i-1]+1)
+	}
+	return next
+}
+
+// canMatch returns whether any continuation of the input so far can still
+// be within the max distance
+func (la *levenshteinAutomaton) canMatch(row []int) bool {
+	for _, dist := range row {
+		if dist <= la.max {
+			return true
+		}
+	}
+	return false
+}
+
+// distance returns the edit distance of the term to the query and whether
+// it is within the max distance. If it isn't, the second return value is the
+// length of the shortest prefix of the term that already can't match. It is
+// 0 if the term is too far from the query, but longer terms starting with it
+// can still match.
+func (la *levenshteinAutomaton) distance(term []byte) (int, int, bool) {
+	row := la.start()
+	for pos := 0; pos < len(term); {
+		r, size := utf8.DecodeRune(term[pos:])
+		pos += size
+
+		row = la.step(row, r)
+		if !la.canMatch(row) {
+			return 0, pos, false
+		}
+	}
+
+	dist := row[len(row)-1]
+	return dist, 0, dist <= la.max
+}
+
+// prefixSuccessor returns the smallest key that is greater than all keys
+// starting with the prefix, or nil if there is none
+func prefixSuccessor(prefix []byte) []byte {
+	out := make([]byte, len(prefix))
+	copy(out, prefix)
+	for i := len(out) - 1; i >= 0; i-- {
+		if out[i] < 0xff {
+			out[i]++
+			return out[:i+1]
+		}
+	}
+	return nil
+}
+
+type fuzzyMatch struct {
+	term     []byte
+	distance int
+}
+
+// fuzzyTerms enumerates the terms of the bucket within the edit distance of
+// the term. Instead of testing every key, the cursor seeks past all keys
+// sharing a prefix that already can't match. At most maxFuzzyExpansions
+// terms are returned, the closest first.
+func fuzzyTerms(ctx context.Context, bucket *lsmkv.Bucket, term string,
+	distance int, shardVersion uint16,
+) ([][]byte, error) {
+	var opts []lsmkv.MapListOption
+	if shardVersion < 2 {
+		opts = append(opts, lsmkv.MapListLegacySortingRequired())
+	}
+
+	c := bucket.MapCursorKeyOnly(opts...)
+	defer c.Close()
+
+	la := newLevenshteinAutomaton(term, distance)
+
+	var matches []fuzzyMatch
+	for k, _ := c.First(); k != nil; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		dist, prefixLen, ok := la.distance(k)
+		if ok {
+			// the cursor may reuse the key's memory on the next iteration
+			key := make([]byte, len(k))
+			copy(key, k)
+			matches = append(matches, fuzzyMatch{term: key, distance: dist})
+			k, _ = c.Next()
+			continue
+		}
+
+		if prefixLen == 0 {
+			// the continuations of the key can still match
+			k, _ = c.Next()
+			continue
+		}
+
+		next := prefixSuccessor(k[:prefixLen])
+		if next == nil {
+			break
+		}
+		k, _ = c.Seek(next)
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].distance < matches[b].distance
+	})
+	if len(matches) > maxFuzzyExpansions {
+		matches = matches[:maxFuzzyExpansions]
+	}
+
+	out := make([][]byte, len(matches))
+	for i := range matches {
+		out[i] = matches[i].term
+	}
+	return out, nil
+}
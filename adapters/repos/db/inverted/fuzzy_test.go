@@ -0,0 +1,143 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFuzzyTerm(t *testing.T) {
+	tests := []struct {
+		value            string
+		expectedTerm     string
+		expectedDistance int
+		expectedFuzzy    bool
+		expectedErr      bool
+	}{
+		{value: "iphone", expectedTerm: "iphone"},
+		{value: "iphnoe~1", expectedTerm: "iphnoe", expectedDistance: 1, expectedFuzzy: true},
+		{value: "iphnoe~", expectedTerm: "iphnoe", expectedDistance: 2, expectedFuzzy: true},
+		{value: "iphnoe~3", expectedErr: true},
+		{value: "~", expectedTerm: "~"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			term, distance, fuzzy, err := parseFuzzyTerm(test.value)
+			if test.expectedErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedTerm, term)
+			assert.Equal(t, test.expectedDistance, distance)
+			assert.Equal(t, test.expectedFuzzy, fuzzy)
+		})
+	}
+}
+
+func TestAutoFuzzyDistance(t *testing.T) {
+	assert.Equal(t, 0, autoFuzzyDistance("tv"))
+	assert.Equal(t, 1, autoFuzzyDistance("phone"))
+	assert.Equal(t, 2, autoFuzzyDistance("iphnoe"))
+}
+
+func TestLevenshteinAutomaton(t *testing.T) {
+	la := newLevenshteinAutomaton("iphone", 1)
+
+	tests := []struct {
+		term             string
+		expectedDistance int
+		expectedMatch    bool
+	}{
+		{term: "iphone", expectedDistance: 0, expectedMatch: true},
+		{term: "iphones", expectedDistance: 1, expectedMatch: true},
+		{term: "ipone", expectedDistance: 1, expectedMatch: true},
+		{term: "iphoen", expectedMatch: false},
+		{term: "android", expectedMatch: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.term, func(t *testing.T) {
+			dist, _, ok := la.distance([]byte(test.term))
+			assert.Equal(t, test.expectedMatch, ok)
+			if ok {
+				assert.Equal(t, test.expectedDistance, dist)
+			}
+		})
+	}
+
+	t.Run("multi-byte runes count as a single edit", func(t *testing.T) {
+		dist, _, ok := newLevenshteinAutomaton("käse", 1).distance([]byte("kase"))
+		assert.True(t, ok)
+		assert.Equal(t, 1, dist)
+	})
+
+	t.Run("the prefix that can't match anymore", func(t *testing.T) {
+		_, prefixLen, ok := la.distance([]byte("abcdef"))
+		assert.False(t, ok)
+		assert.Equal(t, 2, prefixLen)
+	})
+
+	t.Run("a term too far off whose continuations can match", func(t *testing.T) {
+		_, prefixLen, ok := la.distance([]byte("ip"))
+		assert.False(t, ok)
+		assert.Equal(t, 0, prefixLen)
+	})
+}
+
+func TestPrefixSuccessor(t *testing.T) {
+	assert.Equal(t, []byte("ac"), prefixSuccessor([]byte("ab")))
+	assert.Equal(t, []byte("b"), prefixSuccessor([]byte{'a', 0xff}))
+	assert.Nil(t, prefixSuccessor([]byte{0xff, 0xff}))
+}
+
+func TestFuzzyTerms(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store, err := lsmkv.New(t.TempDir(), "", logger, nil)
+	require.Nil(t, err)
+	defer store.Shutdown(context.Background())
+
+	require.Nil(t, store.CreateOrLoadBucket(context.Background(), "terms",
+		lsmkv.WithStrategy(lsmkv.StrategyMapCollection)))
+	bucket := store.Bucket("terms")
+
+	for i, term := range []string{"android", "ip", "iphone", "iphones", "ipod", "phone", "zphone"} {
+		docID := make([]byte, 8)
+		binary.BigEndian.PutUint64(docID, uint64(i))
+		require.Nil(t, bucket.MapSet([]byte(term), lsmkv.MapPair{Key: docID, Value: make([]byte, 8)}))
+	}
+
+	terms, err := fuzzyTerms(context.Background(), bucket, "iphnoe", 2, 2)
+	require.Nil(t, err)
+	// a transposition counts as two edits, so iphones is too far off
+	assert.Equal(t, [][]byte{[]byte("iphone")}, terms)
+
+	terms, err = fuzzyTerms(context.Background(), bucket, "phone", 1, 2)
+	require.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("phone"), []byte("iphone"), []byte("zphone")}, terms)
+
+	// ip is too far from iphone, but the keys starting with it are not
+	terms, err = fuzzyTerms(context.Background(), bucket, "iphone", 1, 2)
+	require.Nil(t, err)
+	assert.Equal(t, [][]byte{
+		[]byte("iphone"), []byte("iphones"), []byte("phone"), []byte("zphone"),
+	}, terms)
+}
@@ -0,0 +1,83 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package languages
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// letters which are not composed of a base letter and a diacritic and can
+// therefore not be folded by decomposition
+var foldings = map[rune]string{
+	'ß': "ss",
+	'æ': "ae",
+	'Æ': "AE",
+	'œ': "oe",
+	'Œ': "OE",
+	'ø': "o",
+	'Ø': "O",
+	'đ': "d",
+	'Đ': "D",
+	'ð': "d",
+	'Ð': "D",
+	'ł': "l",
+	'Ł': "L",
+	'þ': "th",
+	'Þ': "TH",
+	'ı': "i",
+}
+
+// FoldASCII removes the diacritics of latin letters and replaces latin
+// letters without an ASCII counterpart by their common transliteration, e.g.
+// "Straße" becomes "Strasse" and "élève" becomes "eleve". Letters of
+// non-latin scripts are kept as they are.
+func FoldASCII(in string) string {
+	isASCII := true
+	for i := 0; i < len(in); i++ {
+		if in[i] >= unicode.MaxASCII {
+			isASCII = false
+			break
+		}
+	}
+	if isASCII {
+		return in
+	}
+
+	var out strings.Builder
+	out.Grow(len(in))
+	baseIsLatin := false
+	for _, r := range norm.NFD.String(in) {
+		if unicode.Is(unicode.Mn, r) {
+			// a combining mark, such as the diaeresis of an umlaut
+			if !baseIsLatin {
+				out.WriteRune(r)
+			}
+			continue
+		}
+
+		baseIsLatin = unicode.Is(unicode.Latin, r)
+
+		if folded, ok := foldings[r]; ok {
+			out.WriteString(folded)
+			continue
+		}
+
+		out.WriteRune(r)
+	}
+
+	// compose again, so that non-latin letters which have been decomposed
+	// are not left in their decomposed form
+	return norm.NFC.String(out.String())
+}
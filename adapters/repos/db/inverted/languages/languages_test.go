@@ -0,0 +1,65 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package languages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		language string
+		words    []string
+		expected string
+	}{
+		{language: "de", words: []string{"häuser", "haus", "hause"}, expected: "haus"},
+		{language: "fr", words: []string{"maisons", "maison"}, expected: "maison"},
+		{language: "en", words: []string{"running", "runs", "run"}, expected: "run"},
+		{language: "es", words: []string{"casas", "casa"}, expected: "cas"},
+	}
+
+	for _, test := range tests {
+		for _, word := range test.words {
+			t.Run(test.language+" "+word, func(t *testing.T) {
+				assert.Equal(t, test.expected, Stem(test.language, word))
+			})
+		}
+	}
+
+	t.Run("with an unsupported language", func(t *testing.T) {
+		assert.Equal(t, "häuser", Stem("xx", "häuser"))
+	})
+}
+
+func TestFoldASCII(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{in: "plain", expected: "plain"},
+		{in: "häuser", expected: "hauser"},
+		{in: "élève", expected: "eleve"},
+		{in: "straße", expected: "strasse"},
+		{in: "œuvre", expected: "oeuvre"},
+		{in: "łódź", expected: "lodz"},
+		{in: "smørrebrød", expected: "smorrebrod"},
+		{in: "йогурт", expected: "йогурт"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			assert.Equal(t, test.expected, FoldASCII(test.in))
+		})
+	}
+}
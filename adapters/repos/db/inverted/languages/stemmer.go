@@ -0,0 +1,83 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+// Package languages contains the language-specific parts of the text
+// analysis, such as stemming and folding to ASCII
+package languages
+
+import (
+	"sort"
+
+	"github.com/blevesearch/snowballstem"
+	"github.com/blevesearch/snowballstem/danish"
+	"github.com/blevesearch/snowballstem/dutch"
+	"github.com/blevesearch/snowballstem/english"
+	"github.com/blevesearch/snowballstem/finnish"
+	"github.com/blevesearch/snowballstem/french"
+	"github.com/blevesearch/snowballstem/german"
+	"github.com/blevesearch/snowballstem/hungarian"
+	"github.com/blevesearch/snowballstem/italian"
+	"github.com/blevesearch/snowballstem/norwegian"
+	"github.com/blevesearch/snowballstem/portuguese"
+	"github.com/blevesearch/snowballstem/romanian"
+	"github.com/blevesearch/snowballstem/russian"
+	"github.com/blevesearch/snowballstem/spanish"
+	"github.com/blevesearch/snowballstem/swedish"
+	"github.com/blevesearch/snowballstem/turkish"
+)
+
+// stemmers by the ISO 639-1 code of their language
+var stemmers = map[string]func(env *snowballstem.Env) bool{
+	"da": danish.Stem,
+	"de": german.Stem,
+	"en": english.Stem,
+	"es": spanish.Stem,
+	"fi": finnish.Stem,
+	"fr": french.Stem,
+	"hu": hungarian.Stem,
+	"it": italian.Stem,
+	"nl": dutch.Stem,
+	"no": norwegian.Stem,
+	"pt": portuguese.Stem,
+	"ro": romanian.Stem,
+	"ru": russian.Stem,
+	"sv": swedish.Stem,
+	"tr": turkish.Stem,
+}
+
+// Supported returns whether there is a stemmer for the language
+func Supported(language string) bool {
+	_, ok := stemmers[language]
+	return ok
+}
+
+// All returns the codes of all supported languages in alphabetical order
+func All() []string {
+	out := make([]string, 0, len(stemmers))
+	for language := range stemmers {
+		out = append(out, language)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Stem reduces the lowercased word to its stem using the Snowball stemmer of
+// the language. Words of unsupported languages are returned unchanged.
+func Stem(language, word string) string {
+	stem, ok := stemmers[language]
+	if !ok {
+		return word
+	}
+
+	env := snowballstem.NewEnv(word)
+	stem(env)
+	return env.Current()
+}
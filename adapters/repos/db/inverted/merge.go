@@ -14,29 +14,19 @@ package inverted
 import (
 	"sort"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/entities/filters"
 )
 
-func mergeAndOptimized(children []*propValuePair,
-	acceptDuplicates bool,
-) (*docPointers, error) {
-	sets := make([]*docPointers, len(children))
-
+func mergeAnd(children []*propValuePair) (*docPointers, error) {
 	// Since the nested filter could have further children which are AND/OR
-	// filters, we need to merge the innermost of them first
-
-	// Part 1: Merge Children if any
-	// -----------------------------
-	// If the given operands are Value filters, merge will simply return the
-	// respective values
-	for i, child := range children {
-		docIDs, err := child.mergeDocIDs(acceptDuplicates)
-		if err != nil {
-			return nil, errors.Wrapf(err, "retrieve doc ids of child %d", i)
-		}
-
-		sets[i] = docIDs
+	// filters, we need to merge the innermost of them first. If the given
+	// operands are Value filters, merge will simply return the respective
+	// values
+	sets, err := mergeChildren(children)
+	if err != nil {
+		return nil, err
 	}
 
 	// Potential early exit condition
@@ -47,90 +37,64 @@ func mergeAndOptimized(children []*propValuePair,
 		return sets[0], nil
 	}
 
+	// the checksum must be calculated prior to sorting, so that the same filter
+	// always leads to the same checksum
 	checksum := combineSetChecksums(sets, filters.OperatorAnd)
 
-	// Part 2: Recursively intersect sets
-	// ----------------------------------
-	// The idea is that we pick the smallest list first and check against it, as
-	// building a map is considerably more expensive than a map lookup. So we
-	// build a small map first. Since the overall strategy is AND, we know that
-	// we will never have more items than on the smallest list (since an id would
-	// have to be present on all lists to make it through the merge)
-
-	// Thus we must start by sorting the respective sets by their length in ASC
-	// order
-
+	// Since the overall strategy is AND, the result can never contain more ids
+	// than the smallest set. Intersecting the sets in ASC order of their
+	// cardinality therefore keeps the intermediary results as small as
+	// possible.
 	sort.Slice(sets, func(a, b int) bool {
-		return len(sets[a].docIDs) < len(sets[b].docIDs)
+		return sets[a].count() < sets[b].count()
 	})
 
-	// Now we start a recursive merge where merge element 0 and element 1, then
-	// remove both from the list. If there are elements left we merge the result
-	// of the first round with the next smallest set (originally element 2, now
-	// element 0) and so on until we are left with only a single element
-
-	for len(sets) > 1 {
-		merged := intersectAnd(sets[0], sets[1])
-		sets[0] = nil // set to nil to avoid mem leak, as we are cutting from * slice
-		sets[1] = nil // set to nil to avoid mem leak, as we are cutting from * slice
-		sets = append([]*docPointers{merged}, sets[2:]...)
-	}
-
-	sets[0].checksum = checksum
-
-	return sets[0], nil
+	return &docPointers{
+		docIDs:   roaring64.FastAnd(bitmaps(sets)...),
+		checksum: checksum,
+	}, nil
 }
 
-func intersectAnd(smaller, larger *docPointers) *docPointers {
-	lookup := make(map[uint64]struct{}, len(smaller.docIDs))
-	eligibile := docPointers{
-		docIDs: make([]uint64, len(smaller.docIDs)),
-	}
-	for i := range smaller.docIDs {
-		lookup[smaller.docIDs[i]] = struct{}{}
+func mergeOr(children []*propValuePair) (*docPointers, error) {
+	sets, err := mergeChildren(children)
+	if err != nil {
+		return nil, err
 	}
 
-	matches := 0
-	for i := range larger.docIDs {
-		if _, ok := lookup[larger.docIDs[i]]; ok {
-			eligibile.docIDs[matches] = larger.docIDs[i]
-
-			// remove the current match from the lookup list. Otherwise, if the
-			// larger of the two lists contains duplicates for a doc id the total
-			// length is no longer that of the smaller list. E.g. compare list_a=[1]
-			// and list_b=[1,1]. Without eliminating duplicates we would suddenly
-			// find two matches. After deleting [1] from list_a after it was first
-			// found on list_b, we will no longer create a match for the second
-			// entry.
-			delete(lookup, larger.docIDs[i])
-			matches++
-		}
+	if len(sets) == 1 || checksumsIdentical(sets) {
+		// all children are identical, no need to merge, simply return the first
+		// set
+		return sets[0], nil
 	}
 
-	eligibile.docIDs = eligibile.docIDs[:matches]
-	eligibile.count = uint64(matches)
-
-	return &eligibile
+	return &docPointers{
+		docIDs:   roaring64.FastOr(bitmaps(sets)...),
+		checksum: combineSetChecksums(sets, filters.OperatorOr),
+	}, nil
 }
 
-func mergeOrAcceptDuplicates(in []*docPointers) (*docPointers, error) {
-	size := 0
-	for i := range in {
-		size += len(in[i].docIDs)
-	}
+func mergeChildren(children []*propValuePair) ([]*docPointers, error) {
+	sets := make([]*docPointers, len(children))
+	for i, child := range children {
+		docIDs, err := child.mergeDocIDs()
+		if err != nil {
+			return nil, errors.Wrapf(err, "retrieve doc ids of child %d", i)
+		}
 
-	out := docPointers{
-		docIDs:   make([]uint64, size),
-		checksum: combineSetChecksums(in, filters.OperatorOr),
+		sets[i] = docIDs
 	}
 
-	index := 0
-	for i := range in {
-		for j := range in[i].docIDs {
-			out.docIDs[index] = in[i].docIDs[j]
-			index++
-		}
+	return sets, nil
+}
+
+// bitmaps returns the underlying bitmaps of the sets. The merge operations
+// on them create new bitmaps, the sets themselves are never modified, as
+// they may still be referenced by the filter cache.
+func bitmaps(sets []*docPointers) []*roaring64.Bitmap {
+	out := make([]*roaring64.Bitmap, len(sets))
+	for i := range sets {
+		out[i] = sets[i].docIDs
 	}
 
-	return &out, nil
+	return out
 }
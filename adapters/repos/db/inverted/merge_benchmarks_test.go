@@ -17,15 +17,16 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/semi-technologies/weaviate/entities/filters"
 )
 
-func BenchmarkAnd10k1m_Old(b *testing.B) {
+func BenchmarkAnd10k1m(b *testing.B) {
 	b.StopTimer()
 
 	list1 := propValuePair{
 		docIDs: docPointers{
-			docIDs:   randomIDs(1e4),
+			docIDs:   roaring64.BitmapOf(randomIDs(1e4)...),
 			checksum: []byte{0x01},
 		},
 		operator: filters.OperatorEqual,
@@ -33,7 +34,7 @@ func BenchmarkAnd10k1m_Old(b *testing.B) {
 
 	list2 := propValuePair{
 		docIDs: docPointers{
-			docIDs:   randomIDs(1e6),
+			docIDs:   roaring64.BitmapOf(randomIDs(1e6)...),
 			checksum: []byte{0x02},
 		},
 		operator: filters.OperatorEqual,
@@ -41,63 +42,18 @@ func BenchmarkAnd10k1m_Old(b *testing.B) {
 
 	b.StartTimer()
 	for i := 0; i < b.N; i++ {
-		mergeAnd([]*propValuePair{&list1, &list2}, false)
+		mergeAnd([]*propValuePair{&list1, &list2})
 	}
 }
 
-func BenchmarkAnd10k1m_Optimized(b *testing.B) {
-	b.StopTimer()
-
-	list1 := propValuePair{
-		docIDs: docPointers{
-			docIDs:   randomIDs(1e4),
-			checksum: []byte{0x01},
-		},
-		operator: filters.OperatorEqual,
-	}
-
-	list2 := propValuePair{
-		docIDs: docPointers{
-			docIDs:   randomIDs(1e6),
-			checksum: []byte{0x02},
-		},
-		operator: filters.OperatorEqual,
-	}
-
-	b.StartTimer()
-	for i := 0; i < b.N; i++ {
-		mergeAndOptimized([]*propValuePair{&list1, &list2}, false)
-	}
-}
-
-func BenchmarkMultipleListsOf20k_Old(b *testing.B) {
-	b.StopTimer()
-
-	lists := make([]*propValuePair, 10)
-	for i := range lists {
-		lists[i] = &propValuePair{
-			docIDs: docPointers{
-				docIDs:   randomIDs(2e4),
-				checksum: []byte{uint8(i)},
-			},
-			operator: filters.OperatorEqual,
-		}
-	}
-
-	b.StartTimer()
-	for i := 0; i < b.N; i++ {
-		mergeAnd(lists, false)
-	}
-}
-
-func BenchmarkMultipleListsOf20k_Optimized(b *testing.B) {
+func BenchmarkMultipleListsOf20k(b *testing.B) {
 	b.StopTimer()
 
 	lists := make([]*propValuePair, 10)
 	for i := range lists {
 		lists[i] = &propValuePair{
 			docIDs: docPointers{
-				docIDs:   randomIDs(2e4),
+				docIDs:   roaring64.BitmapOf(randomIDs(2e4)...),
 				checksum: []byte{uint8(i)},
 			},
 			operator: filters.OperatorEqual,
@@ -106,7 +62,7 @@ func BenchmarkMultipleListsOf20k_Optimized(b *testing.B) {
 
 	b.StartTimer()
 	for i := 0; i < b.N; i++ {
-		mergeAndOptimized(lists, false)
+		mergeAnd(lists)
 	}
 }
 
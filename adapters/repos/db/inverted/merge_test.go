@@ -14,107 +14,97 @@ package inverted
 import (
 	"testing"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/semi-technologies/weaviate/entities/filters"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestMergeAnd_Old(t *testing.T) {
-	list1 := propValuePair{
-		docIDs: docPointers{
-			docIDs: []uint64{
-				7, 8, 9, 10, 11,
-			},
-			checksum: []byte{0x01},
-		},
-		operator: filters.OperatorEqual,
-	}
+func TestMergeAnd(t *testing.T) {
+	list1 := valuePair([]byte{0x01}, 7, 8, 9, 10, 11)
+	list2 := valuePair([]byte{0x02}, 1, 3, 5, 7, 9, 11)
+	list3 := valuePair([]byte{0x03}, 1, 3, 5, 7, 9)
+	list4 := valuePair([]byte{0x04}, 1, 3, 5, 7)
 
-	list2 := propValuePair{
-		docIDs: docPointers{
-			docIDs: []uint64{
-				1, 3, 5, 7, 9, 11,
-			},
-			checksum: []byte{0x02},
-		},
-		operator: filters.OperatorEqual,
-	}
+	res, err := mergeAnd([]*propValuePair{list1, list2, list3, list4})
+	require.Nil(t, err)
 
-	list3 := propValuePair{
-		docIDs: docPointers{
-			docIDs: []uint64{
-				1, 3, 5, 7, 9,
-			},
-			checksum: []byte{0x03},
-		},
-		operator: filters.OperatorEqual,
-	}
+	assert.Equal(t, []uint64{7}, res.IDs())
 
-	list4 := propValuePair{
-		docIDs: docPointers{
-			docIDs: []uint64{
-				1, 3, 5, 7,
-			},
-			checksum: []byte{0x04},
-		},
-		operator: filters.OperatorEqual,
-	}
+	t.Run("the merged sets are left untouched", func(t *testing.T) {
+		assert.Equal(t, []uint64{7, 8, 9, 10, 11}, list1.docIDs.IDs())
+		assert.Equal(t, []uint64{1, 3, 5, 7}, list4.docIDs.IDs())
+	})
 
-	res, err := mergeAnd([]*propValuePair{&list1, &list2, &list3, &list4}, false)
-	require.Nil(t, err)
+	t.Run("the result does not depend on the order of the sets", func(t *testing.T) {
+		reordered, err := mergeAnd([]*propValuePair{list4, list3, list2, list1})
+		require.Nil(t, err)
 
-	expectedPointers := []uint64{
-		7,
-	}
+		assert.Equal(t, res.IDs(), reordered.IDs())
+	})
+
+	t.Run("the checksum is stable", func(t *testing.T) {
+		again, err := mergeAnd([]*propValuePair{list1, list2, list3, list4})
+		require.Nil(t, err)
 
-	assert.ElementsMatch(t, expectedPointers, res.docIDs)
+		assert.Equal(t, res.checksum, again.checksum)
+		assert.NotEqual(t, list1.docIDs.checksum, res.checksum)
+	})
 }
 
-func TestMergeAnd_Optimized(t *testing.T) {
-	list1 := propValuePair{
-		docIDs: docPointers{
-			docIDs: []uint64{
-				7, 8, 9, 10, 11,
-			},
-			checksum: []byte{0x01},
-		},
-		operator: filters.OperatorEqual,
-	}
+func TestMergeOr(t *testing.T) {
+	list1 := valuePair([]byte{0x01}, 7, 8, 9)
+	list2 := valuePair([]byte{0x02}, 1, 3, 7, 9)
+	list3 := valuePair([]byte{0x03}, 1<<40)
 
-	list2 := propValuePair{
-		docIDs: docPointers{
-			docIDs: []uint64{
-				1, 3, 5, 7, 9, 11,
-			},
-			checksum: []byte{0x02},
-		},
-		operator: filters.OperatorEqual,
-	}
+	res, err := mergeOr([]*propValuePair{list1, list2, list3})
+	require.Nil(t, err)
 
-	list3 := propValuePair{
-		docIDs: docPointers{
-			docIDs: []uint64{
-				1, 3, 5, 7, 9,
-			},
-			checksum: []byte{0x03},
+	assert.Equal(t, []uint64{1, 3, 7, 8, 9, 1 << 40}, res.IDs())
+	assert.Equal(t, uint64(6), res.count())
+	assert.Equal(t, []uint64{7, 8, 9}, list1.docIDs.IDs())
+}
+
+func TestMergeNested(t *testing.T) {
+	// (list1 OR list2) AND list3
+	or := &propValuePair{
+		operator: filters.OperatorOr,
+		children: []*propValuePair{
+			valuePair([]byte{0x01}, 1, 2, 3),
+			valuePair([]byte{0x02}, 4, 5, 6),
 		},
-		operator: filters.OperatorEqual,
 	}
-
-	list4 := propValuePair{
-		docIDs: docPointers{
-			docIDs: []uint64{
-				1, 3, 5, 7,
-			},
-			checksum: []byte{0x04},
+	and := &propValuePair{
+		operator: filters.OperatorAnd,
+		children: []*propValuePair{
+			or,
+			valuePair([]byte{0x03}, 2, 4, 6, 8),
 		},
-		operator: filters.OperatorEqual,
 	}
 
-	res, err := mergeAndOptimized([]*propValuePair{&list1, &list2, &list3, &list4}, false)
+	res, err := and.mergeDocIDs()
 	require.Nil(t, err)
 
-	expectedPointers := []uint64{7}
+	assert.Equal(t, []uint64{2, 4, 6}, res.IDs())
+}
+
+func TestMergeIdenticalSets(t *testing.T) {
+	list1 := valuePair([]byte{0x01}, 1, 2, 3)
+	list2 := valuePair([]byte{0x01}, 1, 2, 3)
 
-	assert.ElementsMatch(t, expectedPointers, res.docIDs)
+	res, err := mergeAnd([]*propValuePair{list1, list2})
+	require.Nil(t, err)
+
+	// identical checksums mean identical sets, there is nothing to merge
+	assert.True(t, res == &list1.docIDs)
+}
+
+func valuePair(checksum []byte, ids ...uint64) *propValuePair {
+	return &propValuePair{
+		docIDs: docPointers{
+			docIDs:   roaring64.BitmapOf(ids...),
+			checksum: checksum,
+		},
+		operator: filters.OperatorEqual,
+	}
 }
@@ -0,0 +1,358 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+// ngramFilter is a filter on an n-gram tokenized property. The objects which
+// have all grams of a term are only candidates, as the grams do not have to
+// be adjacent and the term does not have to be a whole word, e.g. "othello"
+// has all trigrams of "hello". Each candidate is checked against the words
+// of its stored value, so the filter matches like on a word tokenized
+// property.
+type ngramFilter struct {
+	analysis   *textAnalysis
+	candidates *propValuePair
+
+	// terms are the analyzed words of an Equal, ContainsAny or ContainsAll
+	// filter, patterns the analyzed parts of a Like filter
+	terms    []string
+	patterns []*likeRegexp
+	any      bool
+}
+
+func isNgramTokenization(tokenization string) bool {
+	return tokenization == models.PropertyTokenizationTrigram ||
+		tokenization == models.PropertyTokenizationCjk
+}
+
+// extractNgramProp resolves a filter on an n-gram tokenized property. Other
+// operators than Equal and Like would have to read every object of the
+// property, so they are not supported.
+func (fs *Searcher) extractNgramProp(propName, value string,
+	operator filters.Operator, analysis *textAnalysis,
+) (*propValuePair, error) {
+	filter := &ngramFilter{analysis: analysis}
+
+	switch operator {
+	case filters.OperatorEqual:
+		filter.terms = analysis.terms(helpers.TokenizeText(value))
+		if len(filter.terms) == 0 {
+			return nil, errors.Errorf("invalid search term, only stopwords provided. Stopwords can be configured in class.invertedIndexConfig.stopwords")
+		}
+		filter.candidates = ngramTermCandidates(propName, filter.terms,
+			analysis, filters.OperatorAnd)
+	case filters.OperatorLike:
+		for _, part := range analysis.terms(helpers.TokenizeTextKeepWildcards(value)) {
+			pattern, err := parseLikeRegexp([]byte(part))
+			if err != nil {
+				return nil, errors.Wrapf(err, "like pattern %q", part)
+			}
+			filter.patterns = append(filter.patterns, pattern)
+		}
+
+		candidates, err := ngramLikeCandidates(propName, value, analysis)
+		if err != nil {
+			return nil, err
+		}
+		filter.candidates = candidates
+	default:
+		return nil, fmt.Errorf("operator %s is not supported for tokenization '%v'",
+			operator.Name(), analysis.tokenization)
+	}
+
+	return &propValuePair{
+		prop:         propName,
+		operator:     operator,
+		hasFrequency: true,
+		ngram:        filter,
+	}, nil
+}
+
+// extractNgramValueList resolves a ContainsAny or ContainsAll filter on an
+// n-gram tokenized property, the operator applies to the words of the values
+func (fs *Searcher) extractNgramValueList(propName string, values []string,
+	operator filters.Operator, analysis *textAnalysis,
+) (*propValuePair, error) {
+	var terms []string
+	for _, value := range values {
+		terms = append(terms, analysis.terms(helpers.TokenizeText(value))...)
+	}
+	if len(terms) == 0 {
+		return nil, errors.Errorf("invalid search term, only stopwords provided. Stopwords can be configured in class.invertedIndexConfig.stopwords")
+	}
+
+	merge := filters.OperatorAnd
+	if operator == filters.OperatorContainsAny {
+		merge = filters.OperatorOr
+	}
+
+	return &propValuePair{
+		prop:         propName,
+		operator:     operator,
+		hasFrequency: true,
+		ngram: &ngramFilter{
+			analysis:   analysis,
+			candidates: ngramTermCandidates(propName, terms, analysis, merge),
+			terms:      terms,
+			any:        operator == filters.OperatorContainsAny,
+		},
+	}, nil
+}
+
+// ngramTermCandidates matches the objects which have all grams of a term,
+// merged with the specified operator across the terms. A cjk term which is
+// too short to form a gram can also occur within a longer run of cjk
+// characters, so it is matched as a substring of the indexed grams.
+func ngramTermCandidates(propName string, terms []string,
+	analysis *textAnalysis, merge filters.Operator,
+) *propValuePair {
+	termPairs := make([]*propValuePair, len(terms))
+	for i, term := range terms {
+		var gramPairs []*propValuePair
+		for _, gram := range analysis.grams([]string{term}) {
+			pair := &propValuePair{
+				value:        []byte(gram),
+				hasFrequency: true,
+				prop:         propName,
+				operator:     filters.OperatorEqual,
+			}
+			if analysis.tokenization == models.PropertyTokenizationCjk &&
+				!analysis.isFullGram(gram) && helpers.IsCJK([]rune(gram)[0]) {
+				pair.value = []byte("*" + gram + "*")
+				pair.operator = filters.OperatorLike
+			}
+			gramPairs = append(gramPairs, pair)
+		}
+
+		termPairs[i] = mergedPropValuePairs(gramPairs, filters.OperatorAnd)
+	}
+
+	return mergedPropValuePairs(termPairs, merge)
+}
+
+// ngramLikeCandidates matches the objects which have all grams of the
+// literal parts of the pattern. Parts too short to form a full gram are
+// matched as a substring of the indexed grams.
+func ngramLikeCandidates(propName, pattern string,
+	analysis *textAnalysis,
+) (*propValuePair, error) {
+	literals := strings.FieldsFunc(pattern, func(r rune) bool {
+		return r == '*' || r == '?'
+	})
+
+	var propValuePairs []*propValuePair
+	for _, literal := range literals {
+		for _, term := range analysis.grams(analysis.terms(helpers.TokenizeText(literal))) {
+			pair := &propValuePair{
+				value:        []byte(term),
+				hasFrequency: true,
+				prop:         propName,
+				operator:     filters.OperatorEqual,
+			}
+			if !analysis.isFullGram(term) {
+				pair.value = []byte("*" + term + "*")
+				pair.operator = filters.OperatorLike
+			}
+			propValuePairs = append(propValuePairs, pair)
+		}
+	}
+
+	if len(propValuePairs) == 0 {
+		return nil, errors.Errorf("invalid search term, the like pattern has no " +
+			"searchable characters apart from stopwords")
+	}
+	return mergedPropValuePairs(propValuePairs, filters.OperatorAnd), nil
+}
+
+func mergedPropValuePairs(pairs []*propValuePair, operator filters.Operator) *propValuePair {
+	if len(pairs) == 1 {
+		return pairs[0]
+	}
+	return &propValuePair{operator: operator, children: pairs}
+}
+
+// matches returns whether the words of the stored values of a candidate
+// match the filter
+func (f *ngramFilter) matches(values []string) bool {
+	words := f.analysis.terms(helpers.TokenizeText(strings.Join(values, " ")))
+
+	if f.patterns != nil {
+		for _, pattern := range f.patterns {
+			if !anyWord(words, func(word string) bool {
+				return pattern.regexp.MatchString(word)
+			}) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, term := range f.terms {
+		found := anyWord(words, func(word string) bool {
+			return f.wordMatches(word, term)
+		})
+		if found && f.any {
+			return true
+		}
+		if !found && !f.any {
+			return false
+		}
+	}
+	return !f.any
+}
+
+func anyWord(words []string, match func(string) bool) bool {
+	for _, word := range words {
+		if match(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// wordMatches returns whether the stored word matches the term. Cjk text is
+// not split into words, so a term can also match within a run of cjk
+// characters of the word. Any other part of the term has to match a whole
+// run, just like it was indexed.
+func (f *ngramFilter) wordMatches(word, term string) bool {
+	if word == term {
+		return true
+	}
+	if f.analysis.tokenization != models.PropertyTokenizationCjk {
+		return false
+	}
+
+	w, t := []rune(word), []rune(term)
+	if len(t) == 0 {
+		return false
+	}
+
+	for start := 0; start+len(t) <= len(w); start++ {
+		end := start + len(t)
+		if string(w[start:end]) != term {
+			continue
+		}
+
+		startsRun := start == 0 || helpers.IsCJK(t[0]) || helpers.IsCJK(w[start-1])
+		endsRun := end == len(w) || helpers.IsCJK(t[len(t)-1]) || helpers.IsCJK(w[end])
+		if startsRun && endsRun {
+			return true
+		}
+	}
+	return false
+}
+
+// docPointersNgram reads the candidates of an n-gram filter and keeps the
+// ones whose stored values match the filter
+func (fs *Searcher) docPointersNgram(limit int, pv *propValuePair,
+	keepOrder bool,
+) (docPointers, error) {
+	pointers := newDocPointers()
+
+	if err := pv.ngram.candidates.fetchDocIDs(fs, 0, false); err != nil {
+		return pointers, errors.Wrap(err, "fetch candidates")
+	}
+	candidates, err := pv.ngram.candidates.mergeDocIDs()
+	if err != nil {
+		return pointers, errors.Wrap(err, "merge candidates")
+	}
+
+	objects := fs.store.Bucket(helpers.ObjectsBucketLSM)
+	if objects == nil {
+		return pointers, errors.Errorf("objects bucket not found")
+	}
+
+	it := candidates.docIDs.Iterator()
+	for it.HasNext() {
+		id := it.Next()
+
+		keyBuf := bytes.NewBuffer(nil)
+		binary.Write(keyBuf, binary.LittleEndian, &id)
+		res, err := objects.GetBySecondary(0, keyBuf.Bytes())
+		if err != nil {
+			return pointers, errors.Wrapf(err, "get object with doc id %d", id)
+		}
+		if res == nil {
+			continue
+		}
+
+		obj, err := storobj.FromBinary(res)
+		if err != nil {
+			return pointers, errors.Wrapf(err, "unmarshal object with doc id %d", id)
+		}
+
+		if !pv.ngram.matches(textPropValues(obj, pv.prop)) {
+			continue
+		}
+
+		pointers.add(id, keepOrder)
+		if limit > 0 && pointers.count() >= uint64(limit) {
+			break
+		}
+	}
+
+	// n-gram filters are not cacheable, the checksum is only used for merging
+	chksum, err := docPointerChecksum(pointers.IDs())
+	if err != nil {
+		return pointers, errors.Wrap(err, "calculate checksum")
+	}
+	pointers.checksum = chksum
+
+	return pointers, nil
+}
+
+// textPropValues returns the values of a text or text[] property. The
+// values of a nested prop are collected along its path, e.g.
+// "address.city", from every object of the object[] props on the way.
+func textPropValues(obj *storobj.Object, propName string) []string {
+	props, ok := obj.Properties().(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var out []string
+	collectTextValues(&out, props, strings.Split(propName, schema.NestedPropertySeparator))
+	return out
+}
+
+func collectTextValues(out *[]string, value interface{}, path []string) {
+	if asMap, ok := value.(map[string]interface{}); ok && len(path) > 0 {
+		collectTextValues(out, asMap[path[0]], path[1:])
+		return
+	}
+
+	if asString, ok := value.(string); ok {
+		if len(path) == 0 {
+			*out = append(*out, asString)
+		}
+		return
+	}
+
+	if values, ok := arrayValues(value); ok {
+		for _, elem := range values {
+			collectTextValues(out, elem, path)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"testing"
+
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNgramFilterMatches(t *testing.T) {
+	trigram := &textAnalysis{tokenization: models.PropertyTokenizationTrigram}
+	cjk := &textAnalysis{tokenization: models.PropertyTokenizationCjk}
+
+	tests := []struct {
+		name     string
+		analysis *textAnalysis
+		operator filters.Operator
+		value    string
+		values   []string
+		expected bool
+	}{
+		{
+			name:     "equal on a whole word",
+			analysis: trigram,
+			operator: filters.OperatorEqual,
+			value:    "Hello",
+			values:   []string{"hello world"},
+			expected: true,
+		},
+		{
+			name:     "equal on a word within a longer word",
+			analysis: trigram,
+			operator: filters.OperatorEqual,
+			value:    "hello",
+			values:   []string{"othello"},
+			expected: false,
+		},
+		{
+			name:     "equal on all words across values",
+			analysis: trigram,
+			operator: filters.OperatorEqual,
+			value:    "hello world",
+			values:   []string{"hello", "wide world"},
+			expected: true,
+		},
+		{
+			name:     "like anchored to the start",
+			analysis: trigram,
+			operator: filters.OperatorLike,
+			value:    "ello*",
+			values:   []string{"hello yellow"},
+			expected: false,
+		},
+		{
+			name:     "like with a substring",
+			analysis: trigram,
+			operator: filters.OperatorLike,
+			value:    "*ello*",
+			values:   []string{"yellow"},
+			expected: true,
+		},
+		{
+			name:     "cjk term within a run",
+			analysis: cjk,
+			operator: filters.OperatorEqual,
+			value:    "京タ",
+			values:   []string{"東京タワー"},
+			expected: true,
+		},
+		{
+			name:     "cjk term with non adjacent bigrams",
+			analysis: cjk,
+			operator: filters.OperatorEqual,
+			value:    "東京都",
+			values:   []string{"京都と東京"},
+			expected: false,
+		},
+		{
+			name:     "cjk term with a partial latin run",
+			analysis: cjk,
+			operator: filters.OperatorEqual,
+			value:    "bc東京",
+			values:   []string{"abc東京"},
+			expected: false,
+		},
+		{
+			name:     "cjk term with a whole latin run",
+			analysis: cjk,
+			operator: filters.OperatorEqual,
+			value:    "abc東",
+			values:   []string{"xyz abc東京"},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := &Searcher{}
+			pv, err := fs.extractNgramProp("prop", test.value, test.operator, test.analysis)
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, pv.ngram.matches(test.values))
+		})
+	}
+}
+
+func TestNgramFilterValueList(t *testing.T) {
+	analysis := &textAnalysis{tokenization: models.PropertyTokenizationTrigram}
+	fs := &Searcher{}
+
+	any, err := fs.extractNgramValueList("prop", []string{"hello", "submarine"},
+		filters.OperatorContainsAny, analysis)
+	require.Nil(t, err)
+	assert.True(t, any.ngram.matches([]string{"yellow submarine"}))
+	assert.False(t, any.ngram.matches([]string{"othello"}))
+
+	all, err := fs.extractNgramValueList("prop", []string{"hello", "world"},
+		filters.OperatorContainsAll, analysis)
+	require.Nil(t, err)
+	assert.True(t, all.ngram.matches([]string{"hello", "world"}))
+	assert.False(t, all.ngram.matches([]string{"hello othello"}))
+}
+
+func TestNgramFilterUnsupportedOperator(t *testing.T) {
+	analysis := &textAnalysis{tokenization: models.PropertyTokenizationTrigram}
+	_, err := (&Searcher{}).extractNgramProp("prop", "hello",
+		filters.OperatorNotEqual, analysis)
+	assert.EqualError(t, err, "operator NotEqual is not supported for tokenization 'trigram'")
+}
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/entities/filters"
@@ -81,6 +82,10 @@ func (a *Analyzer) analyzeProps(propsMap map[string]*models.Property,
 			if err := a.extendPropertiesWithArrayType(&out, prop, input, key); err != nil {
 				return nil, err
 			}
+		} else if schema.IsNestedDataType(prop.DataType) {
+			if err := a.extendPropertiesWithNested(&out, prop, input, key); err != nil {
+				return nil, err
+			}
 		} else {
 			if err := a.extendPropertiesWithPrimitive(&out, prop, input, key); err != nil {
 				return nil, err
@@ -146,7 +151,7 @@ func (a *Analyzer) extendPropertiesWithArrayType(properties *[]Property,
 		return nil
 	}
 
-	values, ok := value.([]interface{})
+	values, ok := arrayValues(value)
 	if !ok {
 		// skip any primitive prop that's not set
 		errors.New("analyze array prop: expected array prop")
@@ -164,6 +169,99 @@ func (a *Analyzer) extendPropertiesWithArrayType(properties *[]Property,
 	return nil
 }
 
+// extendPropertiesWithNested flattens the value of an object or object[] prop
+// into the values of its leaf props, which are indexed under their paths, e.g.
+// "address.city". Like the values of an array prop, the values of all objects
+// of an object[] prop are indexed together.
+func (a *Analyzer) extendPropertiesWithNested(properties *[]Property,
+	prop *models.Property, input map[string]interface{}, propName string,
+) error {
+	value, ok := input[propName]
+	if !ok {
+		// skip any nested prop that's not set
+		return nil
+	}
+
+	values := map[string][]interface{}{}
+	if err := collectNestedValues(values, prop, prop.Name, value); err != nil {
+		return errors.Wrap(err, "analyze nested prop")
+	}
+
+	for _, leaf := range schema.FlattenNestedProperties(prop) {
+		if leaf.IndexInverted != nil && !*leaf.IndexInverted {
+			continue
+		}
+
+		leafValues, ok := values[leaf.Name]
+		if !ok {
+			continue
+		}
+
+		arrayLeaf := *leaf
+		if _, ok := schema.IsArrayType(schema.DataType(leaf.DataType[0])); !ok {
+			arrayLeaf.DataType = []string{leaf.DataType[0] + "[]"}
+		}
+
+		property, err := a.analyzeArrayProp(&arrayLeaf, leafValues)
+		if err != nil {
+			return errors.Wrap(err, "analyze nested prop")
+		}
+		if property == nil {
+			continue
+		}
+
+		*properties = append(*properties, *property)
+	}
+
+	return nil
+}
+
+// collectNestedValues adds the values of the leaf props of a nested prop to
+// the values by path
+func collectNestedValues(values map[string][]interface{}, prop *models.Property,
+	path string, value interface{},
+) error {
+	objects := []interface{}{value}
+	if schema.DataType(prop.DataType[0]) == schema.DataTypeObjectArray {
+		var ok bool
+		objects, ok = arrayValues(value)
+		if !ok {
+			return fmt.Errorf("expected property %s to be an array of objects, but got %T", path, value)
+		}
+	}
+
+	for _, object := range objects {
+		asMap, ok := object.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected property %s to be an object, but got %T", path, object)
+		}
+
+		for _, nested := range prop.NestedProperties {
+			nestedValue, ok := asMap[nested.Name]
+			if !ok {
+				continue
+			}
+
+			nestedPath := schema.NestedPropertyPath(path, nested.Name)
+			if schema.IsNestedDataType(nested.DataType) {
+				if err := collectNestedValues(values, nested, nestedPath, nestedValue); err != nil {
+					return err
+				}
+			} else if schema.IsArrayDataType(nested.DataType) {
+				nestedValues, ok := arrayValues(nestedValue)
+				if !ok {
+					return fmt.Errorf("expected property %s to be an array, but got %T", nestedPath, nestedValue)
+				}
+				values[nestedPath] = append(values[nestedPath], nestedValues...)
+			} else {
+				values[nestedPath] = append(values[nestedPath], nestedValue)
+			}
+		}
+	}
+
+	return nil
+}
+
 // extendPropertiesWithPrimitive mutates the passed in properties, by extending
 // it with an additional property - if applicable
 func (a *Analyzer) extendPropertiesWithPrimitive(properties *[]Property,
@@ -189,6 +287,41 @@ func (a *Analyzer) extendPropertiesWithPrimitive(properties *[]Property,
 	return nil
 }
 
+// arrayValues returns the values of an array prop. Objects read from disk
+// contain typed arrays instead of the generic ones of a json payload.
+func arrayValues(value interface{}) ([]interface{}, bool) {
+	switch typed := value.(type) {
+	case []interface{}:
+		return typed, true
+	case []string:
+		out := make([]interface{}, len(typed))
+		for i := range typed {
+			out[i] = typed[i]
+		}
+		return out, true
+	case []float64:
+		out := make([]interface{}, len(typed))
+		for i := range typed {
+			out[i] = typed[i]
+		}
+		return out, true
+	case []bool:
+		out := make([]interface{}, len(typed))
+		for i := range typed {
+			out[i] = typed[i]
+		}
+		return out, true
+	case []uuid.UUID:
+		out := make([]interface{}, len(typed))
+		for i := range typed {
+			out[i] = typed[i]
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
 func HasFrequency(dt schema.DataType) bool {
 	if dt == schema.DataTypeText || dt == schema.DataTypeString ||
 		dt == schema.DataTypeStringArray || dt == schema.DataTypeTextArray {
@@ -198,6 +331,12 @@ func HasFrequency(dt schema.DataType) bool {
 	return false
 }
 
+// HasPositions returns whether the positions of the terms of a prop of the
+// data type can be indexed, which is required for phrase queries
+func HasPositions(dt schema.DataType) bool {
+	return dt == schema.DataTypeText || dt == schema.DataTypeTextArray
+}
+
 func (a *Analyzer) analyzeArrayProp(prop *models.Property, values []interface{}) (*Property, error) {
 	var hasFrequency bool
 	var items []Countable
@@ -209,7 +348,10 @@ func (a *Analyzer) analyzeArrayProp(prop *models.Property, values []interface{})
 		if err != nil {
 			return nil, err
 		}
-		items = a.TextArray(prop.Tokenization, in)
+		items, err = a.TextProp(prop, in)
+		if err != nil {
+			return nil, errors.Wrapf(err, "analyze property %s", prop.Name)
+		}
 	case schema.DataTypeStringArray:
 		hasFrequency = HasFrequency(dt)
 		in, err := stringsFromValues(prop, values)
@@ -294,6 +436,18 @@ func (a *Analyzer) analyzeArrayProp(prop *models.Property, values []interface{})
 		if err != nil {
 			return nil, errors.Wrapf(err, "analyze property %s", prop.Name)
 		}
+	case schema.DataTypeUUIDArray:
+		hasFrequency = HasFrequency(dt)
+		in := make([]uuid.UUID, len(values))
+		for i, value := range values {
+			parsed, err := uuidFromValue(prop, value)
+			if err != nil {
+				return nil, err
+			}
+			in[i] = parsed
+		}
+
+		items = a.UUIDArray(in)
 
 	default:
 		// ignore unsupported prop type
@@ -319,6 +473,23 @@ func stringsFromValues(prop *models.Property, values []interface{}) ([]string, e
 	return in, nil
 }
 
+// uuidFromValue accepts uuids as well as their string form, which is what
+// the uuids nested in object props are stored as
+func uuidFromValue(prop *models.Property, value interface{}) (uuid.UUID, error) {
+	switch typed := value.(type) {
+	case uuid.UUID:
+		return typed, nil
+	case string:
+		parsed, err := uuid.Parse(typed)
+		if err != nil {
+			return uuid.UUID{}, errors.Wrapf(err, "parse uuid of property %s", prop.Name)
+		}
+		return parsed, nil
+	default:
+		return uuid.UUID{}, fmt.Errorf("expected property %s to be of type uuid, but got %T", prop.Name, value)
+	}
+}
+
 func (a *Analyzer) analyzePrimitiveProp(prop *models.Property, value interface{}) (*Property, error) {
 	var hasFrequency bool
 	var items []Countable
@@ -330,7 +501,12 @@ func (a *Analyzer) analyzePrimitiveProp(prop *models.Property, value interface{}
 		if !ok {
 			return nil, fmt.Errorf("expected property %s to be of type string, but got %T", prop.Name, value)
 		}
-		items = a.Text(prop.Tokenization, asString)
+
+		var err error
+		items, err = a.TextProp(prop, []string{asString})
+		if err != nil {
+			return nil, errors.Wrapf(err, "analyze property %s", prop.Name)
+		}
 	case schema.DataTypeString:
 		hasFrequency = HasFrequency(dt)
 		asString, ok := value.(string)
@@ -392,6 +568,14 @@ func (a *Analyzer) analyzePrimitiveProp(prop *models.Property, value interface{}
 		if err != nil {
 			return nil, errors.Wrapf(err, "analyze property %s", prop.Name)
 		}
+	case schema.DataTypeUUID:
+		hasFrequency = HasFrequency(dt)
+		parsed, err := uuidFromValue(prop, value)
+		if err != nil {
+			return nil, err
+		}
+
+		items = a.UUID(parsed)
 
 	default:
 		// ignore unsupported prop type
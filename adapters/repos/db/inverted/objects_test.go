@@ -62,14 +62,17 @@ func TestAnalyzeObject(t *testing.T) {
 			{
 				Data:          []byte("i"),
 				TermFrequency: float32(1),
+				Positions:     []uint32{0},
 			},
 			{
 				Data:          []byte("am"),
 				TermFrequency: float32(1),
+				Positions:     []uint32{1},
 			},
 			{
 				Data:          []byte("great"),
 				TermFrequency: float32(1),
+				Positions:     []uint32{2},
 			},
 		}
 
@@ -356,22 +359,28 @@ func TestAnalyzeObject(t *testing.T) {
 			res, err := a.Object(schema, props, strfmt.UUID(uuid))
 			require.Nil(t, err)
 
+			// the positions of the second value start after a gap, so that
+			// phrases don't match across values
 			expectedDescriptions := []Countable{
 				{
 					Data:          []byte("i"),
 					TermFrequency: float32(2),
+					Positions:     []uint32{0, 103},
 				},
 				{
 					Data:          []byte("am"),
 					TermFrequency: float32(2),
+					Positions:     []uint32{1, 104},
 				},
 				{
 					Data:          []byte("great"),
 					TermFrequency: float32(2),
+					Positions:     []uint32{2, 106},
 				},
 				{
 					Data:          []byte("also"),
 					TermFrequency: float32(1),
+					Positions:     []uint32{105},
 				},
 			}
 
@@ -514,6 +523,78 @@ func TestAnalyzeObject(t *testing.T) {
 		})
 	})
 
+	t.Run("with nested properties", func(t *testing.T) {
+		vFalse := false
+		schema := map[string]interface{}{
+			"address": map[string]interface{}{
+				"city": "Amsterdam",
+				"zip":  int64(1011),
+			},
+			"pets": []interface{}{
+				map[string]interface{}{
+					"name": "Bello",
+					"tags": []interface{}{"good boy", "fluffy"},
+					"vet":  map[string]interface{}{"name": "Dr. Doolittle"},
+				},
+				map[string]interface{}{
+					"name": "Garfield",
+				},
+			},
+		}
+
+		uuid := "2609f1bc-7693-48f3-b531-6ddc52cd2501"
+		props := []*models.Property{
+			{
+				Name:     "address",
+				DataType: []string{"object"},
+				NestedProperties: []*models.Property{
+					{Name: "city", DataType: []string{"string"}, Tokenization: "field"},
+					{Name: "zip", DataType: []string{"int"}},
+				},
+			},
+			{
+				Name:     "pets",
+				DataType: []string{"object[]"},
+				NestedProperties: []*models.Property{
+					{Name: "name", DataType: []string{"string"}, Tokenization: "field"},
+					{Name: "tags", DataType: []string{"string[]"}, Tokenization: "field"},
+					{
+						Name:          "vet",
+						DataType:      []string{"object"},
+						IndexInverted: &vFalse,
+						NestedProperties: []*models.Property{
+							{Name: "name", DataType: []string{"string"}, Tokenization: "field"},
+						},
+					},
+				},
+			},
+		}
+		res, err := a.Object(schema, props, strfmt.UUID(uuid))
+		require.Nil(t, err)
+
+		actual := map[string][]string{}
+		for _, elem := range res {
+			if elem.Name == "_id" {
+				continue
+			}
+			for _, item := range elem.Items {
+				actual[elem.Name] = append(actual[elem.Name], string(item.Data))
+			}
+		}
+
+		expected := map[string][]string{
+			"address.city": {"Amsterdam"},
+			"address.zip":  {string(mustGetByteIntNumber(1011))},
+			"pets.name":    {"Bello", "Garfield"},
+			"pets.tags":    {"good boy", "fluffy"},
+		}
+
+		require.Len(t, actual, len(expected))
+		for name, values := range expected {
+			assert.ElementsMatch(t, values, actual[name], name)
+		}
+	})
+
 	t.Run("when objects are indexed by timestamps", func(t *testing.T) {
 		schema := map[string]interface{}{
 			"description":         "pretty ok if you ask me",
@@ -538,12 +619,12 @@ func TestAnalyzeObject(t *testing.T) {
 			{
 				Name: "description",
 				Items: []Countable{
-					{Data: []byte("pretty"), TermFrequency: 1},
-					{Data: []byte("ok"), TermFrequency: 1},
-					{Data: []byte("if"), TermFrequency: 1},
-					{Data: []byte("you"), TermFrequency: 1},
-					{Data: []byte("ask"), TermFrequency: 1},
-					{Data: []byte("me"), TermFrequency: 1},
+					{Data: []byte("pretty"), TermFrequency: 1, Positions: []uint32{0}},
+					{Data: []byte("ok"), TermFrequency: 1, Positions: []uint32{1}},
+					{Data: []byte("if"), TermFrequency: 1, Positions: []uint32{2}},
+					{Data: []byte("you"), TermFrequency: 1, Positions: []uint32{3}},
+					{Data: []byte("ask"), TermFrequency: 1, Positions: []uint32{4}},
+					{Data: []byte("me"), TermFrequency: 1, Positions: []uint32{5}},
 				},
 				HasFrequency: true,
 			},
@@ -0,0 +1,288 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"encoding/binary"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+)
+
+// positionIncrementGap separates the values of a text[] property, so that a
+// phrase with a reasonable slop cannot match across two values
+const positionIncrementGap = 100
+
+type positionedTerm struct {
+	term     string
+	position uint32
+}
+
+// phraseQuery is a phrase of a filter or keyword search. Its terms have to
+// occur in the order of the phrase with at most slop additional positions in
+// between them in total.
+type phraseQuery struct {
+	terms []positionedTerm
+	slop  int
+}
+
+// newPhraseQuery analyzes the phrase like the values of the property it is
+// run against. The positions of the terms are relative to the first term.
+func newPhraseQuery(analysis *textAnalysis, phrase string, slop int) (*phraseQuery, error) {
+	terms := analysis.positionedTerms(phrase)
+	if len(terms) == 0 {
+		return nil, errors.Errorf("invalid phrase %q, only stopwords provided. "+
+			"Stopwords can be configured in class.invertedIndexConfig.stopwords", phrase)
+	}
+
+	first := terms[0].position
+	for i := range terms {
+		terms[i].position -= first
+	}
+
+	return &phraseQuery{terms: terms, slop: slop}, nil
+}
+
+var phraseSlopRegexp = regexp.MustCompile(`^"(.*)"~(\d+)$`)
+
+// parsePhrase parses the value of a phrase filter. Apart from the plain
+// phrase, e.g. machine learning, it accepts the quoted phrase with a slop as
+// used in keyword search queries, e.g. "machine learning"~2.
+func parsePhrase(value string) (string, int, error) {
+	value = strings.TrimSpace(value)
+	if match := phraseSlopRegexp.FindStringSubmatch(value); match != nil {
+		slop, err := strconv.Atoi(match[2])
+		if err != nil {
+			return "", 0, errors.Wrapf(err, "invalid slop of phrase %q", value)
+		}
+		return match[1], slop, nil
+	}
+
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1], 0, nil
+	}
+
+	return value, 0, nil
+}
+
+type keywordPhrase struct {
+	text string
+	slop int
+}
+
+// parseKeywordQuery splits a keyword search query into its single terms and
+// quoted phrases. A phrase can be followed by a slop, e.g.
+// `"machine learning"~2 python` contains the phrase "machine learning" with a
+// slop of 2 and the term python. An unterminated quote extends to the end of
+// the query.
+func parseKeywordQuery(query string) ([]string, []keywordPhrase, error) {
+	var terms []string
+	var phrases []keywordPhrase
+
+	for {
+		start := strings.IndexByte(query, '"')
+		if start < 0 {
+			terms = append(terms, strings.Fields(query)...)
+			return terms, phrases, nil
+		}
+
+		terms = append(terms, strings.Fields(query[:start])...)
+		query = query[start+1:]
+
+		end := strings.IndexByte(query, '"')
+		if end < 0 {
+			end = len(query)
+		}
+		phrase := keywordPhrase{text: query[:end]}
+		query = query[min(end+1, len(query)):]
+
+		if strings.HasPrefix(query, "~") {
+			digits := len(query[1:]) - len(strings.TrimLeft(query[1:], "0123456789"))
+			slop, err := strconv.Atoi(query[1 : 1+digits])
+			if err != nil {
+				return nil, nil, errors.Errorf("invalid slop of phrase %q, "+
+					"expected a number after ~", phrase.text)
+			}
+			phrase.slop = slop
+			query = query[1+digits:]
+		}
+
+		phrases = append(phrases, phrase)
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// phrasePosting is the entry of a doc in the positions bucket of a term
+type phrasePosting struct {
+	propLength float32
+	positions  []uint32
+}
+
+// EncodePhrasePosting encodes the value of a doc in the positions bucket, the
+// length of the property followed by the positions of the term in it
+func EncodePhrasePosting(propLength float32, positions []uint32) []byte {
+	out := make([]byte, 4+4*len(positions))
+	binary.LittleEndian.PutUint32(out[0:4], math.Float32bits(propLength))
+	for i, pos := range positions {
+		binary.LittleEndian.PutUint32(out[4+4*i:8+4*i], pos)
+	}
+	return out
+}
+
+func decodePhrasePosting(in []byte) (phrasePosting, error) {
+	if len(in) < 4 || len(in)%4 != 0 {
+		return phrasePosting{}, errors.Errorf("invalid positions entry of length %d", len(in))
+	}
+
+	out := phrasePosting{
+		propLength: math.Float32frombits(binary.LittleEndian.Uint32(in[0:4])),
+		positions:  make([]uint32, (len(in)-4)/4),
+	}
+	for i := range out.positions {
+		out.positions[i] = binary.LittleEndian.Uint32(in[4+4*i : 8+4*i])
+	}
+	return out, nil
+}
+
+func readPhrasePostings(bucket *lsmkv.Bucket, term string) (map[uint64]phrasePosting, error) {
+	pairs, err := bucket.MapList([]byte(term))
+	if err != nil {
+		return nil, errors.Wrapf(err, "read positions of term %q", term)
+	}
+
+	out := make(map[uint64]phrasePosting, len(pairs))
+	for _, pair := range pairs {
+		posting, err := decodePhrasePosting(pair.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read positions of term %q", term)
+		}
+		out[binary.BigEndian.Uint64(pair.Key)] = posting
+	}
+
+	return out, nil
+}
+
+// matches returns the docs containing the phrase sorted by doc id. The
+// frequency of each doc is the number of occurrences of the phrase.
+func (pq *phraseQuery) matches(bucket *lsmkv.Bucket) (docPointersWithScore, error) {
+	postings := map[string]map[uint64]phrasePosting{}
+	for _, term := range pq.terms {
+		if _, ok := postings[term.term]; ok {
+			continue
+		}
+
+		termPostings, err := readPhrasePostings(bucket, term.term)
+		if err != nil {
+			return docPointersWithScore{}, err
+		}
+		postings[term.term] = termPostings
+	}
+
+	var out docPointersWithScore
+	positions := make([][]uint32, len(pq.terms))
+	for docID, first := range postings[pq.terms[0].term] {
+		found := true
+		for i, term := range pq.terms {
+			posting, ok := postings[term.term][docID]
+			if !ok {
+				found = false
+				break
+			}
+			positions[i] = posting.positions
+		}
+		if !found {
+			continue
+		}
+
+		if frequency := pq.frequency(positions); frequency > 0 {
+			out.docIDs = append(out.docIDs, docPointerWithScore{
+				id:         docID,
+				frequency:  float64(frequency),
+				propLength: float64(first.propLength),
+			})
+		}
+	}
+
+	sort.Slice(out.docIDs, func(a, b int) bool {
+		return out.docIDs[a].id < out.docIDs[b].id
+	})
+	out.count = uint64(len(out.docIDs))
+
+	return out, nil
+}
+
+// frequency counts the occurrences of the phrase in a doc given the sorted
+// positions of each term of the phrase in it. Starting at every position of
+// the first term, each following term is matched at the closest position
+// after the one expected by the phrase, until the additional positions
+// exceed the slop.
+func (pq *phraseQuery) frequency(positions [][]uint32) int {
+	count := 0
+	for _, start := range positions[0] {
+		prev, extra := start, 0
+		matched := true
+		for i := 1; i < len(pq.terms); i++ {
+			expected := prev + pq.terms[i].position - pq.terms[i-1].position
+			candidates := positions[i]
+			j := sort.Search(len(candidates), func(k int) bool {
+				return candidates[k] >= expected
+			})
+			if j == len(candidates) {
+				matched = false
+				break
+			}
+
+			extra += int(candidates[j] - expected)
+			if extra > pq.slop {
+				matched = false
+				break
+			}
+			prev = candidates[j]
+		}
+
+		if matched {
+			count++
+		}
+	}
+
+	return count
+}
+
+// requirePositionsIndexed returns an error until the positions of the
+// objects imported before positions were enabled have been indexed, a phrase
+// would miss those objects otherwise
+func requirePositionsIndexed(store *lsmkv.Store) error {
+	if state := store.Bucket(helpers.PositionsStateBucketLSM); state != nil {
+		indexed, err := state.Get(helpers.PositionsIndexedKey)
+		if err != nil {
+			return errors.Wrap(err, "read positions state")
+		}
+		if indexed != nil {
+			return nil
+		}
+	}
+
+	return errors.Errorf("the positions of the existing objects are still " +
+		"being indexed, phrases can be searched for once indexing completes")
+}
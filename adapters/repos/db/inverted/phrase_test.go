@@ -0,0 +1,167 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/stopwords"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePhrase(t *testing.T) {
+	tests := []struct {
+		value          string
+		expectedPhrase string
+		expectedSlop   int
+	}{
+		{value: "machine learning", expectedPhrase: "machine learning"},
+		{value: ` "machine learning" `, expectedPhrase: "machine learning"},
+		{value: `"machine learning"~3`, expectedPhrase: "machine learning", expectedSlop: 3},
+		{value: `machine learning~3`, expectedPhrase: "machine learning~3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			phrase, slop, err := parsePhrase(test.value)
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedPhrase, phrase)
+			assert.Equal(t, test.expectedSlop, slop)
+		})
+	}
+}
+
+func TestParseKeywordQuery(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		expectedTerms   []string
+		expectedPhrases []keywordPhrase
+		expectedErr     string
+	}{
+		{
+			name:          "only terms",
+			query:         "machine  learning",
+			expectedTerms: []string{"machine", "learning"},
+		},
+		{
+			name:            "terms and phrases",
+			query:           `python "machine learning"~2 tutorial "deep learning"`,
+			expectedTerms:   []string{"python", "tutorial"},
+			expectedPhrases: []keywordPhrase{{"machine learning", 2}, {"deep learning", 0}},
+		},
+		{
+			name:            "unterminated phrase",
+			query:           `python "machine learning`,
+			expectedTerms:   []string{"python"},
+			expectedPhrases: []keywordPhrase{{"machine learning", 0}},
+		},
+		{
+			name:        "slop without a number",
+			query:       `"machine learning"~ python`,
+			expectedErr: "invalid slop",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			terms, phrases, err := parseKeywordQuery(test.query)
+			if test.expectedErr != "" {
+				require.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedTerms, terms)
+			assert.Equal(t, test.expectedPhrases, phrases)
+		})
+	}
+}
+
+func TestPhraseQuery(t *testing.T) {
+	sd, err := stopwords.NewDetectorFromPreset(stopwords.EnglishPreset)
+	require.Nil(t, err)
+
+	analysis, err := newTextAnalysis(&models.Property{
+		Name:         "description",
+		DataType:     []string{"text"},
+		Tokenization: models.PropertyTokenizationWord,
+	}, sd)
+	require.Nil(t, err)
+
+	t.Run("positions are relative and keep removed stopwords", func(t *testing.T) {
+		pq, err := newPhraseQuery(analysis, "The state of the art", 0)
+		require.Nil(t, err)
+		assert.Equal(t, []positionedTerm{{"state", 0}, {"art", 3}}, pq.terms)
+	})
+
+	t.Run("a phrase of stopwords only", func(t *testing.T) {
+		_, err := newPhraseQuery(analysis, "of the", 0)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("frequency", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			slop      int
+			positions [][]uint32
+			expected  int
+		}{
+			{
+				name:      "exact occurrences",
+				positions: [][]uint32{{0, 7, 20}, {1, 8, 25}},
+				expected:  2,
+			},
+			{
+				name:      "terms in the wrong order",
+				positions: [][]uint32{{5}, {4}},
+				expected:  0,
+			},
+			{
+				name:      "terms too far apart",
+				slop:      1,
+				positions: [][]uint32{{0}, {3}},
+				expected:  0,
+			},
+			{
+				name:      "terms within the slop",
+				slop:      2,
+				positions: [][]uint32{{0}, {3}},
+				expected:  1,
+			},
+		}
+
+		pq, err := newPhraseQuery(analysis, "machine learning", 0)
+		require.Nil(t, err)
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				pq.slop = test.slop
+				assert.Equal(t, test.expected, pq.frequency(test.positions))
+			})
+		}
+	})
+}
+
+func TestPhrasePostingEncoding(t *testing.T) {
+	encoded := EncodePhrasePosting(7, []uint32{1, 103})
+
+	decoded, err := decodePhrasePosting(encoded)
+	require.Nil(t, err)
+	assert.Equal(t, phrasePosting{propLength: 7, positions: []uint32{1, 103}}, decoded)
+
+	_, err = decodePhrasePosting(encoded[:5])
+	assert.NotNil(t, err)
+}
@@ -115,6 +115,57 @@ func (t *PropertyLengthTracker) TrackProperty(propName string,
 	binary.LittleEndian.PutUint32(t.pages[bucketOffset:bucketOffset+4], v)
 }
 
+// ResetProperty zeroes the buckets of a property, e.g. because the property
+// was deleted. The index entry of the property is kept, so the buckets are
+// reused if a property with the same name is added again.
+func (t *PropertyLengthTracker) ResetProperty(propName string) {
+	t.Lock()
+	defer t.Unlock()
+
+	page, relBucketOffset, ok := t.propExists(propName)
+	if !ok {
+		return
+	}
+
+	bucketOffset := page*4096 + relBucketOffset
+	for i := bucketOffset; i < bucketOffset+256; i++ {
+		t.pages[i] = 0
+	}
+}
+
+// ReplaceProperty moves the buckets of the replacement property to the
+// property, whose previous buckets are discarded. The buckets of the
+// replacement are zeroed. This is used once a property has been reindexed
+// under a different name.
+func (t *PropertyLengthTracker) ReplaceProperty(propName, replacementName string) {
+	t.Lock()
+	defer t.Unlock()
+
+	var replacement []byte
+	if page, relBucketOffset, ok := t.propExists(replacementName); ok {
+		bucketOffset := page*4096 + relBucketOffset
+		replacement = make([]byte, 256)
+		copy(replacement, t.pages[bucketOffset:bucketOffset+256])
+		for i := bucketOffset; i < bucketOffset+256; i++ {
+			t.pages[i] = 0
+		}
+	}
+
+	page, relBucketOffset, ok := t.propExists(propName)
+	if !ok {
+		if replacement == nil {
+			return
+		}
+		page, relBucketOffset = t.addProperty(propName)
+	}
+
+	bucketOffset := page*4096 + relBucketOffset
+	for i := bucketOffset; i < bucketOffset+256; i++ {
+		t.pages[i] = 0
+	}
+	copy(t.pages[bucketOffset:bucketOffset+256], replacement)
+}
+
 // propExists returns page number, relative offset on page, and a bool whether
 // the prop existed at all. The first to values have no meaning if the latter
 // is false
@@ -244,6 +295,11 @@ func (t *PropertyLengthTracker) PropertyMean(propName string) (float32, error) {
 		bucket++
 	}
 
+	if totalCount == 0 {
+		// the property was reset
+		return 0, nil
+	}
+
 	return sum / totalCount, nil
 }
 
@@ -233,3 +233,90 @@ func Test_PropertyLengthTracker_Persistence(t *testing.T) {
 		assert.InEpsilon(t, actualMeanForProp20, res, 0.1)
 	})
 }
+
+func Test_PropertyLengthTracker_ResetProperty(t *testing.T) {
+	dirName := t.TempDir()
+	trackerPath := path.Join(dirName, "my_test_shard")
+
+	tracker, err := NewPropertyLengthTracker(trackerPath)
+	require.Nil(t, err)
+
+	for _, v := range []float32{2, 4, 6} {
+		tracker.TrackProperty("dropped", v)
+		tracker.TrackProperty("kept", v)
+	}
+
+	t.Run("reset a single property", func(t *testing.T) {
+		tracker.ResetProperty("dropped")
+
+		res, err := tracker.PropertyMean("dropped")
+		require.Nil(t, err)
+		assert.Equal(t, float32(0), res)
+
+		res, err = tracker.PropertyMean("kept")
+		require.Nil(t, err)
+		assert.InEpsilon(t, float32(4), res, 0.1)
+	})
+
+	t.Run("reset a property which was never tracked", func(t *testing.T) {
+		tracker.ResetProperty("unknown")
+
+		res, err := tracker.PropertyMean("kept")
+		require.Nil(t, err)
+		assert.InEpsilon(t, float32(4), res, 0.1)
+	})
+
+	t.Run("track the property again", func(t *testing.T) {
+		tracker.TrackProperty("dropped", 10)
+
+		res, err := tracker.PropertyMean("dropped")
+		require.Nil(t, err)
+		assert.InEpsilon(t, float32(10), res, 0.1)
+	})
+
+	require.Nil(t, tracker.Close())
+}
+
+func Test_PropertyLengthTracker_ReplaceProperty(t *testing.T) {
+	dirName := t.TempDir()
+	trackerPath := path.Join(dirName, "my_test_shard")
+
+	tracker, err := NewPropertyLengthTracker(trackerPath)
+	require.Nil(t, err)
+
+	for _, v := range []float32{2, 4, 6} {
+		tracker.TrackProperty("prop", v)
+		tracker.TrackProperty("prop__reindex", v*2)
+	}
+
+	t.Run("replace the property", func(t *testing.T) {
+		tracker.ReplaceProperty("prop", "prop__reindex")
+
+		res, err := tracker.PropertyMean("prop")
+		require.Nil(t, err)
+		assert.InEpsilon(t, float32(8), res, 0.1)
+
+		res, err = tracker.PropertyMean("prop__reindex")
+		require.Nil(t, err)
+		assert.Equal(t, float32(0), res)
+	})
+
+	t.Run("replace a property which was never tracked", func(t *testing.T) {
+		tracker.TrackProperty("other__reindex", 3)
+		tracker.ReplaceProperty("other", "other__reindex")
+
+		res, err := tracker.PropertyMean("other")
+		require.Nil(t, err)
+		assert.InEpsilon(t, float32(3), res, 0.1)
+	})
+
+	t.Run("replace with a property which was never tracked", func(t *testing.T) {
+		tracker.ReplaceProperty("prop", "unknown")
+
+		res, err := tracker.PropertyMean("prop")
+		require.Nil(t, err)
+		assert.Equal(t, float32(0), res)
+	})
+
+	require.Nil(t, tracker.Close())
+}
@@ -34,11 +34,24 @@ type propValuePair struct {
 	hasFrequency  bool
 	docIDs        docPointers
 	children      []*propValuePair
+
+	// only set if operator=OperatorPhrase, which is served by the positions
+	// bucket instead of the regular inverted index
+	phrase *phraseQuery
+
+	// only set if operator=OperatorContainsAny or OperatorContainsAll, each
+	// value is a row of the inverted index
+	values [][]byte
+
+	// only set on an n-gram tokenized property, whose candidates need to be
+	// checked against the stored values
+	ngram *ngramFilter
 }
 
-func (pv *propValuePair) fetchDocIDs(s *Searcher, limit int,
-	tolerateDuplicates bool,
-) error {
+// fetchDocIDs reads the docIDs matching the filter. If keepOrder is set, the
+// order in which they were read is kept in addition to the set of ids. This
+// only applies to a value filter, nested filters are merged into a set.
+func (pv *propValuePair) fetchDocIDs(s *Searcher, limit int, keepOrder bool) error {
 	if pv.operator.OnValue() {
 		id := helpers.BucketFromPropNameLSM(pv.prop)
 		if pv.prop == filters.InternalPropBackwardsCompatID {
@@ -48,8 +61,22 @@ func (pv *propValuePair) fetchDocIDs(s *Searcher, limit int,
 			pv.prop = filters.InternalPropID
 			pv.hasFrequency = false
 		}
+		if pv.operator == filters.OperatorPhrase {
+			id = helpers.PositionsBucketFromPropNameLSM(pv.prop)
+		}
 		b := s.store.Bucket(id)
 
+		if b == nil && pv.operator == filters.OperatorPhrase {
+			return errors.Errorf("positions of prop %s are not indexed! "+
+				"add `indexPositions: true` to the invertedIndexConfig", pv.prop)
+		}
+
+		if pv.operator == filters.OperatorPhrase {
+			if err := requirePositionsIndexed(s.store); err != nil {
+				return err
+			}
+		}
+
 		if b == nil && (pv.prop == filters.InternalPropCreationTimeUnix ||
 			pv.prop == filters.InternalPropLastUpdateTimeUnix) {
 			return errors.Errorf("timestamps must be indexed to be filterable! " +
@@ -63,7 +90,7 @@ func (pv *propValuePair) fetchDocIDs(s *Searcher, limit int,
 			return errors.Errorf("bucket for prop %s not found - is it indexed?", pv.prop)
 		}
 
-		pointers, err := s.docPointers(id, b, limit, pv, tolerateDuplicates)
+		pointers, err := s.docPointers(id, b, limit, pv, keepOrder)
 		if err != nil {
 			return err
 		}
@@ -75,7 +102,7 @@ func (pv *propValuePair) fetchDocIDs(s *Searcher, limit int,
 			// otherwise we run into situations where each subfilter on their own
 			// runs into the limit, possibly yielding in "less than limit" results
 			// after merging.
-			err := child.fetchDocIDs(s, 0, tolerateDuplicates)
+			err := child.fetchDocIDs(s, 0, false)
 			if err != nil {
 				return errors.Wrapf(err, "nested child %d", i)
 			}
@@ -85,122 +112,21 @@ func (pv *propValuePair) fetchDocIDs(s *Searcher, limit int,
 	return nil
 }
 
-// if duplicates are acceptable, simpler (and faster) algorithms can be used
-// for merging
-func (pv *propValuePair) mergeDocIDs(acceptDuplicates bool) (*docPointers, error) {
+func (pv *propValuePair) mergeDocIDs() (*docPointers, error) {
 	if pv.operator.OnValue() {
 		return &pv.docIDs, nil
 	}
 
 	switch pv.operator {
 	case filters.OperatorAnd:
-		return mergeAndOptimized(pv.children, acceptDuplicates)
+		return mergeAnd(pv.children)
 	case filters.OperatorOr:
-		return mergeOr(pv.children, acceptDuplicates)
+		return mergeOr(pv.children)
 	default:
 		return nil, fmt.Errorf("unsupported operator: %s", pv.operator.Name())
 	}
 }
 
-// TODO: Delete?
-// This is only left so we can use it as a control or baselines in tests and
-// benchmkarks against the newer optimized version.
-func mergeAnd(children []*propValuePair, acceptDuplicates bool) (*docPointers, error) {
-	sets := make([]*docPointers, len(children))
-
-	// retrieve child IDs
-	for i, child := range children {
-		docIDs, err := child.mergeDocIDs(acceptDuplicates)
-		if err != nil {
-			return nil, errors.Wrapf(err, "retrieve doc ids of child %d", i)
-		}
-
-		sets[i] = docIDs
-	}
-
-	if checksumsIdentical(sets) {
-		// all children are identical, no need to merge, simply return the first
-		// set
-		return sets[0], nil
-	}
-
-	// merge AND
-	found := map[uint64]uint64{} // map[id]count
-	for _, set := range sets {
-		for _, pointer := range set.docIDs {
-			count := found[pointer]
-			count++
-			found[pointer] = count
-		}
-	}
-
-	var out docPointers
-	var idsForChecksum []uint64
-	for id, count := range found {
-		if count != uint64(len(sets)) {
-			continue
-		}
-
-		// TODO: optimize to use fixed length slice and cut off (should be
-		// considerably cheaper on very long lists, such as we encounter during
-		// large classification cases
-		out.docIDs = append(out.docIDs, id)
-		idsForChecksum = append(idsForChecksum, id)
-	}
-
-	checksum, err := docPointerChecksum(idsForChecksum)
-	if err != nil {
-		return nil, errors.Wrapf(err, "calculate checksum")
-	}
-
-	out.checksum = checksum
-	return &out, nil
-}
-
-func mergeOr(children []*propValuePair, acceptDuplicates bool) (*docPointers, error) {
-	sets := make([]*docPointers, len(children))
-
-	// retrieve child IDs
-	for i, child := range children {
-		docIDs, err := child.mergeDocIDs(acceptDuplicates)
-		if err != nil {
-			return nil, errors.Wrapf(err, "retrieve doc ids of child %d", i)
-		}
-
-		sets[i] = docIDs
-	}
-
-	if checksumsIdentical(sets) {
-		// all children are identical, no need to merge, simply return the first
-		// set
-		return sets[0], nil
-	}
-
-	if acceptDuplicates {
-		return mergeOrAcceptDuplicates(sets)
-	}
-
-	// merge OR
-	var checksums [][]byte
-	found := map[uint64]uint64{} // map[id]count
-	for _, set := range sets {
-		for _, pointer := range set.docIDs {
-			count := found[pointer]
-			count++
-			found[pointer] = count
-		}
-		checksums = append(checksums, set.checksum)
-	}
-
-	var out docPointers
-	for id := range found {
-		out.docIDs = append(out.docIDs, id)
-	}
-
-	out.checksum = combineChecksums(checksums, filters.OperatorOr)
-	return &out, nil
-}
-
 func checksumsIdentical(sets []*docPointers) bool {
 	if len(sets) == 0 {
 		return false
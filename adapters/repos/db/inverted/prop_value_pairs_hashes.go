@@ -21,6 +21,10 @@ import (
 )
 
 func (pv *propValuePair) cacheable() bool {
+	if pv.ngram != nil {
+		return false
+	}
+
 	for _, child := range pv.children {
 		if !child.cacheable() {
 			return false
@@ -31,7 +35,8 @@ func (pv *propValuePair) cacheable() bool {
 	case filters.OperatorEqual, filters.OperatorAnd, filters.OperatorOr,
 		filters.OperatorGreaterThan, filters.OperatorGreaterThanEqual,
 		filters.OperatorLessThan, filters.OperatorLessThanEqual,
-		filters.OperatorNotEqual, filters.OperatorLike:
+		filters.OperatorNotEqual, filters.OperatorLike,
+		filters.OperatorContainsAny, filters.OperatorContainsAll:
 		return true
 	default:
 		return false
@@ -60,6 +65,11 @@ func (pv *propValuePair) fetchHashes(s *Searcher) error {
 			if err != nil {
 				return err
 			}
+		} else if pv.operator.OnValueList() {
+			hash, err = pv.hashForValueList(b)
+			if err != nil {
+				return err
+			}
 		} else {
 			hash, err = pv.hashForNonEqualOp(s.store, b, s.shardVersion)
 			if err != nil {
@@ -149,3 +159,18 @@ func (pv *propValuePair) hashForNonEqualOpWithFrequency(propBucket,
 
 	return combineChecksums(hashes, pv.operator), nil
 }
+
+// hashForValueList combines the hashes of the rows of all values, as
+// ContainsAny and ContainsAll read exactly those rows
+func (pv *propValuePair) hashForValueList(hashBucket *lsmkv.Bucket) ([]byte, error) {
+	hashes := make([][]byte, len(pv.values))
+	for i, value := range pv.values {
+		h, err := hashBucket.Get(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get hash for key %v", value)
+		}
+		hashes[i] = h
+	}
+
+	return combineChecksums(hashes, pv.operator), nil
+}
@@ -40,13 +40,18 @@ type CacheEntry struct {
 	AllowList helpers.AllowList
 }
 
-// Size cannot be determined accurately since a golang map does not have fixed
-// size per elements. However, through experimentation we have found that a
-// map[uint64]struct{} rarely exceeds 25 bytes per entry, so we are using this
-// as an estimate. In addition, we know that the partial content uses an array
-// where we can assume full efficiency, i.e. 8 bytes per entry.
+// Size is an estimate based on the in-memory size of the bitmaps. If the
+// allow list and the partial share the same bitmap, it is counted twice,
+// which errs on the side of evicting too early.
 func (ce *CacheEntry) Size() uint64 {
-	return uint64(25*len(ce.AllowList) + 8*len(ce.Partial.docIDs))
+	var size uint64
+	if ce.AllowList != nil {
+		size += ce.AllowList.Size()
+	}
+	if ce.Partial != nil && ce.Partial.docIDs != nil {
+		size += ce.Partial.docIDs.GetSizeInBytes()
+	}
+	return size
 }
 
 type CacheEntryType uint8
@@ -17,8 +17,12 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/stopwords"
@@ -80,19 +84,19 @@ func (f *Searcher) Object(ctx context.Context, limit int,
 		return nil, err
 	}
 
-	// we assume that when retrieving objects, we can not tolerate duplicates as
-	// they would have a direct impact on the user
-	if err := pv.fetchDocIDs(f, limit, false); err != nil {
+	// the order of a single value filter, e.g. by value on a range or by
+	// distance on a geo filter, is visible to the user and must be kept
+	if err := pv.fetchDocIDs(f, limit, true); err != nil {
 		return nil, errors.Wrap(err, "fetch doc ids for prop/value pair")
 	}
 
-	pointers, err := pv.mergeDocIDs(false)
+	pointers, err := pv.mergeDocIDs()
 	if err != nil {
 		return nil, errors.Wrap(err, "merge doc ids by operator")
 	}
 
 	if len(sort) > 0 {
-		return f.sortedObjectsByDocID(ctx, limit, sort, pointers.docIDs, additional, className)
+		return f.sortedObjectsByDocID(ctx, limit, sort, pointers.IDs(), additional, className)
 	}
 
 	return f.allObjectsByDocID(pointers.IDs(), limit, additional)
@@ -216,21 +220,18 @@ func (f *Searcher) docIDs(ctx context.Context, filter *filters.LocalFilter,
 		}
 	}
 
-	// when building an allow list (which is a set anyway) we can skip the costly
-	// deduplication, as it doesn't matter
-	if err := pv.fetchDocIDs(f, -1, true); err != nil {
+	if err := pv.fetchDocIDs(f, -1, false); err != nil {
 		return nil, errors.Wrap(err, "fetch doc ids for prop/value pair")
 	}
 
-	pointers, err := pv.mergeDocIDs(true)
+	pointers, err := pv.mergeDocIDs()
 	if err != nil {
 		return nil, errors.Wrap(err, "merge doc ids by operator")
 	}
 
-	out := make(helpers.AllowList, len(pointers.docIDs))
-	for _, p := range pointers.docIDs {
-		out.Insert(p)
-	}
+	// the merged bitmap can be passed on as it is, the allow list is never
+	// modified once it has been built
+	out := helpers.NewAllowListFromBitmap(pointers.docIDs)
 
 	if cacheable && allowCaching {
 		f.rowCache.Store(pv.docIDs.checksum, &CacheEntry{
@@ -280,6 +281,16 @@ func (fs *Searcher) extractPropValuePair(filter *filters.Clause,
 		return fs.extractReferenceCount(props[0], filter.Value.Value, filter.Operator)
 	}
 
+	if filter.Operator.OnValueList() {
+		property, err := fs.schema.GetProperty(className, schema.PropertyName(props[0]))
+		if err != nil {
+			return nil, err
+		}
+
+		return fs.extractValueListProp(property, filter.Value.Type, filter.Value.Value,
+			filter.Operator)
+	}
+
 	if fs.onGeoProp(className, props[0]) {
 		return fs.extractGeoFilter(props[0], filter.Value.Value, filter.Value.Type,
 			filter.Operator)
@@ -291,8 +302,8 @@ func (fs *Searcher) extractPropValuePair(filter *filters.Clause,
 			return nil, err
 		}
 
-		return fs.extractTokenizableProp(props[0], filter.Value.Type, filter.Value.Value,
-			filter.Operator, property.Tokenization)
+		return fs.extractTokenizableProp(property, filter.Value.Type, filter.Value.Value,
+			filter.Operator)
 	}
 
 	return fs.extractPrimitiveProp(props[0], filter.Value.Type, filter.Value.Value,
@@ -309,26 +320,9 @@ func (fs *Searcher) extractReferenceFilter(filter *filters.Clause,
 func (fs *Searcher) extractPrimitiveProp(propName string, dt schema.DataType,
 	value interface{}, operator filters.Operator,
 ) (*propValuePair, error) {
-	var extractValueFn func(in interface{}) ([]byte, error)
-	var hasFrequency bool
-	switch dt {
-	case schema.DataTypeBoolean:
-		extractValueFn = fs.extractBoolValue
-		hasFrequency = false
-	case schema.DataTypeInt:
-		extractValueFn = fs.extractIntValue
-		hasFrequency = false
-	case schema.DataTypeNumber:
-		extractValueFn = fs.extractNumberValue
-		hasFrequency = false
-	case schema.DataTypeDate:
-		extractValueFn = fs.extractDateValue
-		hasFrequency = false
-	case "":
-		return nil, fmt.Errorf("data type cannot be empty")
-	default:
-		return nil, fmt.Errorf("data type %q not supported yet in standalone mode, "+
-			"see %s for details", dt, notimplemented.Link)
+	extractValueFn, err := fs.primitiveValueExtractor(dt)
+	if err != nil {
+		return nil, err
 	}
 
 	byteValue, err := extractValueFn(value)
@@ -338,12 +332,35 @@ func (fs *Searcher) extractPrimitiveProp(propName string, dt schema.DataType,
 
 	return &propValuePair{
 		value:        byteValue,
-		hasFrequency: hasFrequency,
+		hasFrequency: false,
 		prop:         propName,
 		operator:     operator,
 	}, nil
 }
 
+// primitiveValueExtractor returns the function which turns a value of the
+// data type into its representation in the inverted index. Primitive values
+// are indexed without a frequency.
+func (fs *Searcher) primitiveValueExtractor(dt schema.DataType) (func(in interface{}) ([]byte, error), error) {
+	switch dt {
+	case schema.DataTypeBoolean:
+		return fs.extractBoolValue, nil
+	case schema.DataTypeInt:
+		return fs.extractIntValue, nil
+	case schema.DataTypeNumber:
+		return fs.extractNumberValue, nil
+	case schema.DataTypeDate:
+		return fs.extractDateValue, nil
+	case schema.DataTypeUUID:
+		return fs.extractUUIDValue, nil
+	case "":
+		return nil, fmt.Errorf("data type cannot be empty")
+	default:
+		return nil, fmt.Errorf("data type %q not supported yet in standalone mode, "+
+			"see %s for details", dt, notimplemented.Link)
+	}
+}
+
 func (fs *Searcher) extractReferenceCount(propName string, value interface{},
 	operator filters.Operator,
 ) (*propValuePair, error) {
@@ -448,58 +465,275 @@ func extractTimestampProp(propName string, propType schema.DataType, value inter
 	}, nil
 }
 
-func (fs *Searcher) extractTokenizableProp(propName string, dt schema.DataType, value interface{},
-	operator filters.Operator, tokenization string,
+func (fs *Searcher) extractTokenizableProp(prop *models.Property, dt schema.DataType, value interface{},
+	operator filters.Operator,
 ) (*propValuePair, error) {
 	var parts []string
+	propName := prop.Name
+	tokenization := prop.Tokenization
 
+	analysis, err := newTextAnalysis(prop, fs.stopwords)
+	if err != nil {
+		return nil, err
+	}
+	if operator == filters.OperatorLike {
+		analysis = analysis.withoutStemming()
+	}
+
+	if operator == filters.OperatorPhrase {
+		return fs.extractPhraseProp(prop, dt, value.(string), analysis)
+	}
+
+	fuzzyDistance, fuzzyExplicit := 0, false
+	if operator == filters.OperatorFuzzy {
+		if isNgramTokenization(tokenization) {
+			return nil, fmt.Errorf("operator %s is not supported for tokenization '%v'",
+				operator.Name(), tokenization)
+		}
+
+		value, fuzzyDistance, fuzzyExplicit, err = parseFuzzyTerm(strings.TrimSpace(value.(string)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if isNgramTokenization(tokenization) {
+		return fs.extractNgramProp(propName, value.(string), operator, analysis)
+	}
+
+	// if the operator is like, we cannot apply the regular text-splitting
+	// logic as it would remove all wildcard symbols
+	parts, err = tokenizeFilterValue(dt, tokenization, value.(string),
+		operator == filters.OperatorLike)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := analysis.grams(analysis.terms(parts))
+	propValuePairs := make([]*propValuePair, 0, len(terms))
+	for _, term := range terms {
+		if operator == filters.OperatorFuzzy {
+			distance := fuzzyDistance
+			if !fuzzyExplicit {
+				distance = autoFuzzyDistance(term)
+			}
+
+			pair, err := fs.extractFuzzyTerm(propName, term, distance)
+			if err != nil {
+				return nil, err
+			}
+			propValuePairs = append(propValuePairs, pair)
+			continue
+		}
+
+		propValuePairs = append(propValuePairs, &propValuePair{
+			value:        []byte(term),
+			hasFrequency: true,
+			prop:         propName,
+			operator:     operator,
+		})
+	}
+
+	if len(propValuePairs) > 1 {
+		return &propValuePair{operator: filters.OperatorAnd, children: propValuePairs}, nil
+	}
+	if len(propValuePairs) == 1 {
+		return propValuePairs[0], nil
+	}
+	return nil, errors.Errorf("invalid search term, only stopwords provided. Stopwords can be configured in class.invertedIndexConfig.stopwords")
+}
+
+// tokenizeFilterValue splits the value of a filter on a string or text prop
+// the same way the values of the prop were split when they were indexed
+func tokenizeFilterValue(dt schema.DataType, tokenization, value string,
+	keepWildcards bool,
+) ([]string, error) {
 	switch dt {
 	case schema.DataTypeString:
 		switch tokenization {
 		case models.PropertyTokenizationWord:
-			parts = helpers.TokenizeString(value.(string))
+			return helpers.TokenizeString(value), nil
 		case models.PropertyTokenizationField:
-			parts = []string{helpers.TrimString(value.(string))}
+			return []string{helpers.TrimString(value)}, nil
 		default:
 			return nil, fmt.Errorf("unsupported tokenization '%v' configured for data type '%v'", tokenization, dt)
 		}
 	case schema.DataTypeText:
 		switch tokenization {
 		case models.PropertyTokenizationWord:
-			if operator == filters.OperatorLike {
-				// if the operator is like, we cannot apply the regular text-splitting
-				// logic as it would remove all wildcard symbols
-				parts = helpers.TokenizeTextKeepWildcards(value.(string))
-			} else {
-				parts = helpers.TokenizeText(value.(string))
+			if keepWildcards {
+				return helpers.TokenizeTextKeepWildcards(value), nil
 			}
+			return helpers.TokenizeText(value), nil
+		case models.PropertyTokenizationTrigram, models.PropertyTokenizationCjk:
+			return helpers.TokenizeText(value), nil
 		default:
 			return nil, fmt.Errorf("unsupported tokenization '%v' configured for data type '%v'", tokenization, dt)
 		}
 	default:
 		return nil, fmt.Errorf("expected value type to be string or text, got %v", dt)
 	}
+}
 
-	propValuePairs := make([]*propValuePair, 0, len(parts))
-	for _, part := range parts {
-		if fs.stopwords.IsStopword(part) {
+// extractValueListProp resolves a ContainsAny or ContainsAll filter into a
+// single pair which holds the rows of all values. They are read and merged
+// in one pass, instead of as a nested filter with a clause per value. The
+// values of string and text props are split into their terms, so the
+// operators apply to the terms.
+func (fs *Searcher) extractValueListProp(prop *models.Property, dt schema.DataType,
+	value interface{}, operator filters.Operator,
+) (*propValuePair, error) {
+	baseType, ok := schema.IsArrayType(dt)
+	if !ok {
+		return nil, fmt.Errorf("operator %s requires a list of values, got %v",
+			operator.Name(), dt)
+	}
+
+	values := reflect.ValueOf(value)
+	if values.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("operator %s requires a list of values, got %T",
+			operator.Name(), value)
+	}
+
+	var byteValues [][]byte
+	hasFrequency := false
+	switch baseType {
+	case schema.DataTypeString, schema.DataTypeText:
+		analysis, err := newTextAnalysis(prop, fs.stopwords)
+		if err != nil {
+			return nil, err
+		}
+
+		asStrings := make([]string, values.Len())
+		for i := range asStrings {
+			asString, ok := values.Index(i).Interface().(string)
+			if !ok {
+				return nil, fmt.Errorf("expected value to be string, got %T",
+					values.Index(i).Interface())
+			}
+			asStrings[i] = asString
+		}
+
+		if isNgramTokenization(prop.Tokenization) {
+			return fs.extractNgramValueList(prop.Name, asStrings, operator, analysis)
+		}
+
+		hasFrequency = true
+		for _, asString := range asStrings {
+			parts, err := tokenizeFilterValue(baseType, prop.Tokenization, asString, false)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, term := range analysis.grams(analysis.terms(parts)) {
+				byteValues = append(byteValues, []byte(term))
+			}
+		}
+
+		if len(byteValues) == 0 {
+			return nil, errors.Errorf("invalid search term, only stopwords provided. Stopwords can be configured in class.invertedIndexConfig.stopwords")
+		}
+	default:
+		extractValueFn, err := fs.primitiveValueExtractor(baseType)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < values.Len(); i++ {
+			byteValue, err := extractValueFn(values.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			byteValues = append(byteValues, byteValue)
+		}
+	}
+
+	return &propValuePair{
+		values:       uniqueSortedValues(byteValues),
+		hasFrequency: hasFrequency,
+		prop:         prop.Name,
+		operator:     operator,
+	}, nil
+}
+
+// uniqueSortedValues removes duplicates, the order makes the checksum of the
+// rows independent of the order of the values
+func uniqueSortedValues(in [][]byte) [][]byte {
+	sort.Slice(in, func(a, b int) bool { return bytes.Compare(in[a], in[b]) < 0 })
+
+	out := in[:0]
+	for i := range in {
+		if i > 0 && bytes.Equal(in[i], in[i-1]) {
 			continue
 		}
-		propValuePairs = append(propValuePairs, &propValuePair{
-			value:        []byte(part),
+		out = append(out, in[i])
+	}
+
+	return out
+}
+
+// extractPhraseProp resolves a phrase filter, which matches the terms of the
+// phrase in order using the positions of the terms of a text property
+func (fs *Searcher) extractPhraseProp(prop *models.Property, dt schema.DataType,
+	value string, analysis *textAnalysis,
+) (*propValuePair, error) {
+	if dt != schema.DataTypeText {
+		return nil, fmt.Errorf("operator %s is only supported on properties of "+
+			"data type text or text[], got %v", filters.OperatorPhrase.Name(), dt)
+	}
+
+	phrase, slop, err := parsePhrase(value)
+	if err != nil {
+		return nil, err
+	}
+
+	pq, err := newPhraseQuery(analysis, phrase, slop)
+	if err != nil {
+		return nil, err
+	}
+
+	return &propValuePair{
+		prop:         prop.Name,
+		operator:     filters.OperatorPhrase,
+		hasFrequency: true,
+		phrase:       pq,
+	}, nil
+}
+
+// extractFuzzyTerm expands a fuzzy term into the terms of the property within
+// the edit distance, which are matched like regular terms
+func (fs *Searcher) extractFuzzyTerm(propName, term string,
+	distance int,
+) (*propValuePair, error) {
+	b := fs.store.Bucket(helpers.BucketFromPropNameLSM(propName))
+	if b == nil {
+		return nil, errors.Errorf("bucket for prop %s not found - is it indexed?", propName)
+	}
+
+	matches, err := fuzzyTerms(context.TODO(), b, term, distance, fs.shardVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fuzzy term %q", term)
+	}
+
+	if len(matches) == 0 {
+		// nothing is close enough, the term itself won't match either
+		matches = [][]byte{[]byte(term)}
+	}
+
+	children := make([]*propValuePair, len(matches))
+	for i, match := range matches {
+		children[i] = &propValuePair{
+			value:        match,
 			hasFrequency: true,
 			prop:         propName,
-			operator:     operator,
-		})
+			operator:     filters.OperatorEqual,
+		}
 	}
 
-	if len(propValuePairs) > 1 {
-		return &propValuePair{operator: filters.OperatorAnd, children: propValuePairs}, nil
+	if len(children) == 1 {
+		return children[0], nil
 	}
-	if len(propValuePairs) == 1 {
-		return propValuePairs[0], nil
-	}
-	return nil, errors.Errorf("invalid search term, only stopwords provided. Stopwords can be configured in class.invertedIndexConfig.stopwords")
+	return &propValuePair{operator: filters.OperatorOr, children: children}, nil
 }
 
 // TODO: repeated calls to on... aren't too efficient because we iterate over
@@ -541,12 +775,23 @@ func (fs *Searcher) onTokenizablePropValue(valueType schema.DataType) bool {
 	}
 }
 
+// docPointers holds the docIDs matching (part of) a filter. As the ids form a
+// set, they are kept in a compressed bitmap, which is both considerably
+// smaller than a list of ids and can be intersected or merged without
+// building any intermediary lookups.
 type docPointers struct {
-	count    uint64
-	docIDs   []uint64
+	docIDs *roaring64.Bitmap
+
+	// ordered holds the same ids in the order they were read, if this was
+	// requested. Sets resulting from a merge are always ordered by docID.
+	ordered  []uint64
 	checksum []byte // helps us judge if a cached read is still fresh
 }
 
+func newDocPointers() docPointers {
+	return docPointers{docIDs: roaring64.New()}
+}
+
 type docPointersWithScore struct {
 	count    uint64
 	docIDs   []docPointerWithScore
@@ -560,8 +805,24 @@ type docPointerWithScore struct {
 	score      float64
 }
 
+// IDs returns the docIDs in the order they were read if it was kept,
+// otherwise in ascending order
 func (d docPointers) IDs() []uint64 {
-	return d.docIDs
+	if d.ordered != nil {
+		return d.ordered
+	}
+
+	return d.docIDs.ToArray()
+}
+
+func (d *docPointers) add(id uint64, keepOrder bool) {
+	if d.docIDs.CheckedAdd(id) && keepOrder {
+		d.ordered = append(d.ordered, id)
+	}
+}
+
+func (d docPointers) count() uint64 {
+	return d.docIDs.GetCardinality()
 }
 
 func (d docPointersWithScore) IDs() []uint64 {
@@ -571,23 +832,3 @@ func (d docPointersWithScore) IDs() []uint64 {
 	}
 	return out
 }
-
-func (d *docPointers) removeDuplicates() {
-	counts := make(map[uint64]uint16, len(d.docIDs))
-	for _, id := range d.docIDs {
-		counts[id]++
-	}
-
-	updated := make([]uint64, len(d.docIDs))
-	i := 0
-	for _, id := range d.docIDs {
-		if counts[id] == 1 {
-			updated[i] = id
-			i++
-		}
-
-		counts[id]--
-	}
-
-	d.docIDs = updated[:i]
-}
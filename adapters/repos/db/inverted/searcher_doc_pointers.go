@@ -17,6 +17,7 @@ import (
 	"encoding/binary"
 	"hash/crc64"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
@@ -24,47 +25,55 @@ import (
 )
 
 func (fs *Searcher) docPointers(prop string, b *lsmkv.Bucket, limit int,
-	pv *propValuePair, tolerateDuplicates bool,
+	pv *propValuePair, keepOrder bool,
 ) (docPointers, error) {
 	if pv.operator == filters.OperatorWithinGeoRange {
 		// geo props cannot be served by the inverted index and they require an
 		// external index. So, instead of trying to serve this chunk of the filter
 		// request internally, we can pass it to an external geo index
-		return fs.docPointersGeo(pv)
+		return fs.docPointersGeo(pv, keepOrder)
+	} else if pv.ngram != nil {
+		// the grams only narrow down the candidates, which are checked against
+		// the stored values
+		return fs.docPointersNgram(limit, pv, keepOrder)
+	} else if pv.operator == filters.OperatorPhrase {
+		// phrases require the positions of the terms, which are stored in a
+		// separate bucket
+		return fs.docPointersPhrase(b, limit, pv, keepOrder)
+	} else if pv.operator.OnValueList() {
+		// the rows of all values are merged while reading them, the result is
+		// a set, so there is no order to keep
+		return fs.docPointersValueList(b, pv)
 	} else {
 		// all other operators perform operations on the inverted index which we
 		// can serve directly
-		return fs.docPointersInverted(prop, b, limit, pv, tolerateDuplicates)
+		return fs.docPointersInverted(prop, b, limit, pv, keepOrder)
 	}
 }
 
 func (fs *Searcher) docPointersInverted(prop string, b *lsmkv.Bucket, limit int,
-	pv *propValuePair, tolerateDuplicates bool,
+	pv *propValuePair, keepOrder bool,
 ) (docPointers, error) {
 	if pv.hasFrequency {
-		return fs.docPointersInvertedFrequency(prop, b, limit, pv, tolerateDuplicates)
+		return fs.docPointersInvertedFrequency(prop, b, limit, pv, keepOrder)
 	}
 
-	return fs.docPointersInvertedNoFrequency(prop, b, limit, pv, tolerateDuplicates)
+	return fs.docPointersInvertedNoFrequency(prop, b, limit, pv, keepOrder)
 }
 
 func (fs *Searcher) docPointersInvertedNoFrequency(prop string, b *lsmkv.Bucket, limit int,
-	pv *propValuePair, tolerateDuplicates bool,
+	pv *propValuePair, keepOrder bool,
 ) (docPointers, error) {
 	rr := NewRowReader(b, pv.value, pv.operator, false)
 
-	var pointers docPointers
+	pointers := newDocPointers()
 	var hashes [][]byte
 
 	if err := rr.Read(context.TODO(), func(k []byte, ids [][]byte) (bool, error) {
-		currentDocIDs := make([]uint64, len(ids))
-		for i, asBytes := range ids {
-			currentDocIDs[i] = binary.LittleEndian.Uint64(asBytes)
+		for _, asBytes := range ids {
+			pointers.add(binary.LittleEndian.Uint64(asBytes), keepOrder)
 		}
 
-		pointers.count += uint64(len(ids))
-		pointers.docIDs = append(pointers.docIDs, currentDocIDs...)
-
 		hashBucket := fs.store.Bucket(helpers.HashBucketFromPropNameLSM(pv.prop))
 		if hashBucket == nil {
 			return false, errors.Errorf("no hash bucket for prop '%s' found", pv.prop)
@@ -76,7 +85,7 @@ func (fs *Searcher) docPointersInvertedNoFrequency(prop string, b *lsmkv.Bucket,
 		}
 
 		hashes = append(hashes, currHash)
-		if limit > 0 && pointers.count >= uint64(limit) {
+		if limit > 0 && pointers.count() >= uint64(limit) {
 			return false, nil
 		}
 
@@ -86,41 +95,28 @@ func (fs *Searcher) docPointersInvertedNoFrequency(prop string, b *lsmkv.Bucket,
 	}
 
 	pointers.checksum = combineChecksums(hashes, pv.operator)
-	if !tolerateDuplicates {
-		pointers.removeDuplicates()
-	}
 
 	return pointers, nil
 }
 
 func (fs *Searcher) docPointersInvertedFrequency(prop string, b *lsmkv.Bucket, limit int,
-	pv *propValuePair, tolerateDuplicates bool,
+	pv *propValuePair, keepOrder bool,
 ) (docPointers, error) {
 	rr := NewRowReaderFrequency(b, pv.value, pv.operator, false, fs.shardVersion)
 
-	var pointers docPointers
+	pointers := newDocPointers()
 	var hashes [][]byte
 
 	if err := rr.Read(context.TODO(), func(k []byte, pairs []lsmkv.MapPair) (bool, error) {
-		currentDocIDs := make([]uint64, len(pairs))
-		// beforePairs := time.Now()
-		for i, pair := range pairs {
+		for _, pair := range pairs {
 			// this entry has a frequency, but that's only used for bm25, not for
 			// pure filtering, so we can ignore it here
 			if fs.shardVersion < 2 {
-				currentDocIDs[i] = binary.LittleEndian.Uint64(pair.Key)
+				pointers.add(binary.LittleEndian.Uint64(pair.Key), keepOrder)
 			} else {
-				currentDocIDs[i] = binary.BigEndian.Uint64(pair.Key)
+				pointers.add(binary.BigEndian.Uint64(pair.Key), keepOrder)
 			}
 		}
-		// fmt.Printf("loop through pairs took %s\n", time.Since(beforePairs))
-
-		pointers.count += uint64(len(pairs))
-		if len(pointers.docIDs) > 0 {
-			pointers.docIDs = append(pointers.docIDs, currentDocIDs...)
-		} else {
-			pointers.docIDs = currentDocIDs
-		}
 
 		hashBucket := fs.store.Bucket(helpers.HashBucketFromPropNameLSM(pv.prop))
 		if b == nil {
@@ -135,7 +131,7 @@ func (fs *Searcher) docPointersInvertedFrequency(prop string, b *lsmkv.Bucket, l
 		}
 
 		hashes = append(hashes, currHash)
-		if limit > 0 && pointers.count >= uint64(limit) {
+		if limit > 0 && pointers.count() >= uint64(limit) {
 			return false, nil
 		}
 
@@ -146,15 +142,112 @@ func (fs *Searcher) docPointersInvertedFrequency(prop string, b *lsmkv.Bucket, l
 
 	pointers.checksum = combineChecksums(hashes, pv.operator)
 
-	if !tolerateDuplicates {
-		pointers.removeDuplicates()
+	return pointers, nil
+}
+
+// docPointersValueList reads the row of each value of a ContainsAny or
+// ContainsAll filter and merges it into the result right away. For
+// ContainsAll the remaining rows are skipped once no docID is left.
+func (fs *Searcher) docPointersValueList(b *lsmkv.Bucket,
+	pv *propValuePair,
+) (docPointers, error) {
+	pointers := newDocPointers()
+
+	hashBucket := fs.store.Bucket(helpers.HashBucketFromPropNameLSM(pv.prop))
+	if hashBucket == nil {
+		return pointers, errors.Errorf("no hash bucket for prop '%s' found", pv.prop)
+	}
+
+	for i, value := range pv.values {
+		row, err := fs.readRowDocIDs(b, value, pv.hasFrequency)
+		if err != nil {
+			return pointers, errors.Wrapf(err, "read row %d", i)
+		}
+
+		switch {
+		case i == 0:
+			pointers.docIDs = row
+		case pv.operator == filters.OperatorContainsAll:
+			pointers.docIDs.And(row)
+		default:
+			pointers.docIDs.Or(row)
+		}
+
+		if pv.operator == filters.OperatorContainsAll && pointers.docIDs.IsEmpty() {
+			break
+		}
+	}
+
+	checksum, err := pv.hashForValueList(hashBucket)
+	if err != nil {
+		return pointers, err
+	}
+	pointers.checksum = checksum
+
+	return pointers, nil
+}
+
+// readRowDocIDs reads the docIDs of a single row of the inverted index
+func (fs *Searcher) readRowDocIDs(b *lsmkv.Bucket, value []byte,
+	hasFrequency bool,
+) (*roaring64.Bitmap, error) {
+	out := roaring64.New()
+
+	if !hasFrequency {
+		rr := NewRowReader(b, value, filters.OperatorEqual, false)
+		err := rr.Read(context.TODO(), func(k []byte, ids [][]byte) (bool, error) {
+			for _, asBytes := range ids {
+				out.Add(binary.LittleEndian.Uint64(asBytes))
+			}
+			return true, nil
+		})
+		return out, err
 	}
+
+	rr := NewRowReaderFrequency(b, value, filters.OperatorEqual, false, fs.shardVersion)
+	err := rr.Read(context.TODO(), func(k []byte, pairs []lsmkv.MapPair) (bool, error) {
+		for _, pair := range pairs {
+			if fs.shardVersion < 2 {
+				out.Add(binary.LittleEndian.Uint64(pair.Key))
+			} else {
+				out.Add(binary.BigEndian.Uint64(pair.Key))
+			}
+		}
+		return true, nil
+	})
+	return out, err
+}
+
+func (fs *Searcher) docPointersPhrase(b *lsmkv.Bucket, limit int,
+	pv *propValuePair, keepOrder bool,
+) (docPointers, error) {
+	pointers := newDocPointers()
+
+	matches, err := pv.phrase.matches(b)
+	if err != nil {
+		return pointers, errors.Wrapf(err, "phrase search on prop %q", pv.prop)
+	}
+
+	for _, match := range matches.docIDs {
+		pointers.add(match.id, keepOrder)
+		if limit > 0 && pointers.count() >= uint64(limit) {
+			break
+		}
+	}
+
+	// phrase filters are not cacheable, the checksum is only used for merging
+	chksum, err := docPointerChecksum(pointers.IDs())
+	if err != nil {
+		return pointers, errors.Wrap(err, "calculate checksum")
+	}
+	pointers.checksum = chksum
+
 	return pointers, nil
 }
 
-func (fs *Searcher) docPointersGeo(pv *propValuePair) (docPointers, error) {
+func (fs *Searcher) docPointersGeo(pv *propValuePair, keepOrder bool) (docPointers, error) {
 	propIndex, ok := fs.propIndices.ByProp(pv.prop)
-	out := docPointers{}
+	out := newDocPointers()
 	if !ok {
 		return out, nil
 	}
@@ -165,8 +258,9 @@ func (fs *Searcher) docPointersGeo(pv *propValuePair) (docPointers, error) {
 		return out, errors.Wrapf(err, "geo index range search on prop %q", pv.prop)
 	}
 
-	out.docIDs = res
-	out.count = uint64(len(res))
+	for _, id := range res {
+		out.add(id, keepOrder)
+	}
 
 	// we can not use the checksum in the same fashion as with the inverted
 	// index, i.e. it can not prevent a search as the underlying index does not
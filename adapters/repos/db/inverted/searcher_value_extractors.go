@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
@@ -88,3 +89,28 @@ func (fs Searcher) extractDateValue(in interface{}) ([]byte, error) {
 
 	return LexicographicallySortableInt64(asInt64)
 }
+
+// accepts a uuid or its string form and returns its 16 bytes, which is how
+// uuids are indexed
+func (fs Searcher) extractUUIDValue(in interface{}) ([]byte, error) {
+	var value uuid.UUID
+
+	switch t := in.(type) {
+	case string:
+		parsed, err := uuid.Parse(t)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse uuid")
+		}
+
+		value = parsed
+
+	case uuid.UUID:
+		value = t
+
+	default:
+		return nil, fmt.Errorf("expected value to be uuid (or parseable string)"+
+			", got %T", in)
+	}
+
+	return value[:], nil
+}
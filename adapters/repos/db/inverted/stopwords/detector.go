@@ -61,6 +61,24 @@ func NewDetectorFromPreset(preset string) (*Detector, error) {
 	return d, nil
 }
 
+var presetDetectors sync.Map
+
+// PresetDetector returns a detector for the unmodified preset. Detectors are
+// shared, so they must not be changed with SetAdditions or SetRemovals.
+func PresetDetector(preset string) (StopwordDetector, error) {
+	if d, ok := presetDetectors.Load(preset); ok {
+		return d.(*Detector), nil
+	}
+
+	d, err := NewDetectorFromPreset(preset)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := presetDetectors.LoadOrStore(preset, d)
+	return actual.(*Detector), nil
+}
+
 func (d *Detector) SetAdditions(additions []string) {
 	d.Lock()
 	defer d.Unlock()
@@ -12,8 +12,14 @@
 package stopwords
 
 const (
-	EnglishPreset = "en"
-	NoPreset      = "none"
+	EnglishPreset    = "en"
+	GermanPreset     = "de"
+	FrenchPreset     = "fr"
+	SpanishPreset    = "es"
+	ItalianPreset    = "it"
+	DutchPreset      = "nl"
+	PortuguesePreset = "pt"
+	NoPreset         = "none"
 )
 
 var Presets = map[string][]string{
@@ -23,5 +29,53 @@ var Presets = map[string][]string{
 		"the", "their", "then", "there", "these", "they", "this", "to", "was", "will",
 		"with",
 	},
+	GermanPreset: {
+		"aber", "als", "am", "an", "auch", "auf", "aus", "bei", "bin", "bis",
+		"bist", "da", "damit", "dann", "das", "dass", "dem", "den", "der", "des",
+		"die", "dies", "diese", "dieser", "doch", "du", "durch", "ein", "eine",
+		"einem", "einen", "einer", "eines", "er", "es", "für", "hat", "hatte",
+		"ich", "ihr", "im", "in", "ist", "ja", "kein", "keine", "man", "mit",
+		"nach", "nicht", "noch", "nur", "oder", "sich", "sie", "sind", "so",
+		"um", "und", "uns", "von", "vor", "war", "was", "weil", "wenn", "wie",
+		"wir", "wird", "zu", "zum", "zur",
+	},
+	FrenchPreset: {
+		"au", "aux", "avec", "ce", "ces", "dans", "de", "des", "du", "elle",
+		"en", "et", "eux", "il", "je", "la", "le", "les", "leur", "lui", "ma",
+		"mais", "me", "même", "mes", "moi", "mon", "ne", "nos", "notre", "nous",
+		"on", "ou", "par", "pas", "pour", "qu", "que", "qui", "sa", "se", "ses",
+		"son", "sur", "ta", "te", "tes", "toi", "ton", "tu", "un", "une", "vos",
+		"votre", "vous", "est", "sont", "été",
+	},
+	SpanishPreset: {
+		"a", "al", "algo", "como", "con", "de", "del", "el", "ella", "ellos",
+		"en", "entre", "era", "es", "esta", "este", "esto", "fue", "ha", "la",
+		"las", "le", "les", "lo", "los", "más", "me", "mi", "muy", "no", "nos",
+		"o", "para", "pero", "por", "porque", "que", "se", "si", "sin", "sobre",
+		"su", "sus", "también", "te", "tu", "un", "una", "uno", "y", "ya", "yo",
+	},
+	ItalianPreset: {
+		"a", "ad", "al", "alla", "alle", "anche", "che", "chi", "ci", "come",
+		"con", "da", "dal", "dalla", "dei", "del", "della", "delle", "di", "e",
+		"è", "gli", "ha", "i", "il", "in", "io", "la", "le", "lei", "lo", "lui",
+		"ma", "mi", "ne", "nel", "nella", "non", "noi", "o", "per", "più", "se",
+		"si", "sono", "su", "sul", "sulla", "tu", "un", "una", "uno",
+	},
+	DutchPreset: {
+		"aan", "al", "als", "bij", "dan", "dat", "de", "der", "die", "dit",
+		"door", "een", "en", "er", "had", "heb", "heeft", "het", "hij", "hoe",
+		"hun", "ik", "in", "is", "je", "kan", "maar", "me", "met", "mij", "na",
+		"naar", "niet", "nog", "nu", "of", "om", "omdat", "ons", "ook", "op",
+		"over", "te", "tot", "u", "uit", "van", "voor", "want", "was", "wat",
+		"we", "wel", "wie", "wij", "zal", "ze", "zich", "zij", "zo", "zou",
+	},
+	PortuguesePreset: {
+		"a", "ao", "aos", "as", "com", "como", "da", "das", "de", "do", "dos",
+		"e", "é", "ela", "ele", "eles", "em", "entre", "era", "essa", "esse",
+		"esta", "este", "eu", "foi", "há", "isso", "isto", "já", "lhe", "mais",
+		"mas", "me", "mesmo", "meu", "minha", "muito", "na", "nas", "não", "no",
+		"nos", "nós", "o", "os", "ou", "para", "pela", "pelo", "por", "que", "se",
+		"sem", "seu", "sua", "também", "te", "um", "uma", "você",
+	},
 	NoPreset: {},
 }
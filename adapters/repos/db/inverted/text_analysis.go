@@ -0,0 +1,183 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/languages"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/stopwords"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+)
+
+// textAnalysis turns the tokens of a property value into the terms of the
+// inverted index. The same analysis has to be applied when the property is
+// indexed, filtered and searched with bm25, otherwise the terms won't match.
+type textAnalysis struct {
+	tokenization string
+	stopwords    stopwords.StopwordDetector
+	language     string
+	stemming     bool
+	asciiFolding bool
+}
+
+// newTextAnalysis builds the analysis of the property. Only the class
+// stopwords are applied unless the property is of type text or text[] and has
+// an analyzer.
+func newTextAnalysis(prop *models.Property,
+	classStopwords stopwords.StopwordDetector,
+) (*textAnalysis, error) {
+	ta := &textAnalysis{stopwords: classStopwords}
+	if prop != nil {
+		ta.tokenization = prop.Tokenization
+	}
+	if prop == nil || prop.Analyzer == nil || !isTextProp(prop) {
+		return ta, nil
+	}
+
+	if preset := prop.Analyzer.StopwordPreset; preset != "" {
+		sd, err := stopwords.PresetDetector(preset)
+		if err != nil {
+			return nil, errors.Wrapf(err, "stopwords of property %q", prop.Name)
+		}
+		ta.stopwords = sd
+	}
+
+	ta.language = prop.Analyzer.Language
+	ta.stemming = prop.Analyzer.Stemming
+	ta.asciiFolding = prop.Analyzer.ASCIIFolding
+	return ta, nil
+}
+
+func isTextProp(prop *models.Property) bool {
+	if len(prop.DataType) != 1 {
+		return false
+	}
+
+	switch schema.DataType(prop.DataType[0]) {
+	case schema.DataTypeText, schema.DataTypeTextArray:
+		return true
+	default:
+		return false
+	}
+}
+
+// withoutStemming is used for wildcard (like) values, as stemming a partial
+// word would change its prefix unpredictably
+func (ta *textAnalysis) withoutStemming() *textAnalysis {
+	out := *ta
+	out.stemming = false
+	return &out
+}
+
+// terms removes the stopwords from the tokens, then stems and folds the
+// remaining ones. Stopwords are matched before stemming and folding, so
+// the stopword lists can be used as they are.
+func (ta *textAnalysis) terms(tokens []string) []string {
+	out := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if ta.stopwords != nil && ta.stopwords.IsStopword(token) {
+			continue
+		}
+
+		if ta.stemming {
+			token = languages.Stem(ta.language, token)
+		}
+
+		if ta.asciiFolding {
+			token = languages.FoldASCII(token)
+		}
+
+		out = append(out, token)
+	}
+
+	return out
+}
+
+// valueTerms tokenizes the values of a text property into words and
+// analyzes them. For n-gram tokenizations the grams are built from the
+// analyzed words, as neither stopwords nor stemming can be applied to grams.
+func (ta *textAnalysis) valueTerms(values ...string) []string {
+	positioned := ta.positionedTerms(values...)
+	if positioned == nil {
+		return nil
+	}
+
+	out := make([]string, len(positioned))
+	for i, term := range positioned {
+		out[i] = term.term
+	}
+	return out
+}
+
+// positionedTerms is like valueTerms, but also returns the position of each
+// term. A removed stopword keeps its position, so that "state of the art"
+// does not match "state art" as a phrase.
+func (ta *textAnalysis) positionedTerms(values ...string) []positionedTerm {
+	switch ta.tokenization {
+	case models.PropertyTokenizationWord, models.PropertyTokenizationTrigram,
+		models.PropertyTokenizationCjk:
+	default:
+		return nil
+	}
+
+	out := []positionedTerm{}
+	var position uint32
+	for i, value := range values {
+		if i > 0 {
+			position += positionIncrementGap
+		}
+
+		for _, word := range helpers.TokenizeText(value) {
+			terms := ta.grams(ta.terms([]string{word}))
+			if len(terms) == 0 {
+				position++
+				continue
+			}
+
+			for _, term := range terms {
+				out = append(out, positionedTerm{term: term, position: position})
+				position++
+			}
+		}
+	}
+
+	return out
+}
+
+// grams splits the analyzed words into the n-grams of the tokenization
+func (ta *textAnalysis) grams(words []string) []string {
+	switch ta.tokenization {
+	case models.PropertyTokenizationTrigram:
+		return helpers.Trigrams(words)
+	case models.PropertyTokenizationCjk:
+		return helpers.CJKBigrams(words)
+	default:
+		return words
+	}
+}
+
+// isFullGram returns whether the term is a complete n-gram of the
+// tokenization, as opposed to a word that was too short to be split or, for
+// cjk, a word of a script that is not split at all
+func (ta *textAnalysis) isFullGram(term string) bool {
+	runes := []rune(term)
+	switch ta.tokenization {
+	case models.PropertyTokenizationTrigram:
+		return len(runes) == 3
+	case models.PropertyTokenizationCjk:
+		return len(runes) == 2 && helpers.IsCJK(runes[0])
+	default:
+		return false
+	}
+}
@@ -0,0 +1,208 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/stopwords"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextAnalysis(t *testing.T) {
+	classStopwords, err := stopwords.NewDetectorFromPreset(stopwords.EnglishPreset)
+	require.Nil(t, err)
+
+	textProp := func(dataType string, analyzer *models.PropertyAnalyzerConfig) *models.Property {
+		return &models.Property{
+			Name:         "description",
+			DataType:     []string{dataType},
+			Tokenization: models.PropertyTokenizationWord,
+			Analyzer:     analyzer,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		prop     *models.Property
+		tokens   []string
+		expected []string
+	}{
+		{
+			name:     "without an analyzer only the class stopwords are removed",
+			prop:     textProp("text", nil),
+			tokens:   []string{"the", "häuser", "and", "die", "gärten"},
+			expected: []string{"häuser", "die", "gärten"},
+		},
+		{
+			name: "with a stopword preset it replaces the class stopwords",
+			prop: textProp("text", &models.PropertyAnalyzerConfig{
+				StopwordPreset: stopwords.GermanPreset,
+			}),
+			tokens:   []string{"the", "häuser", "und", "die", "gärten"},
+			expected: []string{"the", "häuser", "gärten"},
+		},
+		{
+			name: "with stemming",
+			prop: textProp("text[]", &models.PropertyAnalyzerConfig{
+				Language: "de", Stemming: true, StopwordPreset: stopwords.GermanPreset,
+			}),
+			tokens:   []string{"die", "häuser", "und", "das", "haus"},
+			expected: []string{"haus", "haus"},
+		},
+		{
+			name: "with folding",
+			prop: textProp("text", &models.PropertyAnalyzerConfig{
+				ASCIIFolding: true,
+			}),
+			tokens:   []string{"élève", "straße", "the"},
+			expected: []string{"eleve", "strasse"},
+		},
+		{
+			name: "with stemming and folding",
+			prop: textProp("text", &models.PropertyAnalyzerConfig{
+				Language: "fr", Stemming: true, ASCIIFolding: true,
+			}),
+			tokens:   []string{"élèves", "élève"},
+			expected: []string{"elev", "elev"},
+		},
+		{
+			name: "the analyzer of a non-text property is ignored",
+			prop: &models.Property{
+				Name:     "name",
+				DataType: []string{"string"},
+				Analyzer: &models.PropertyAnalyzerConfig{ASCIIFolding: true},
+			},
+			tokens:   []string{"the", "Élève"},
+			expected: []string{"Élève"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			analysis, err := newTextAnalysis(test.prop, classStopwords)
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, analysis.terms(test.tokens))
+		})
+	}
+
+	t.Run("without stemming for wildcard values", func(t *testing.T) {
+		analysis, err := newTextAnalysis(textProp("text", &models.PropertyAnalyzerConfig{
+			Language: "de", Stemming: true, ASCIIFolding: true,
+		}), classStopwords)
+		require.Nil(t, err)
+
+		assert.Equal(t, []string{"hauser*"}, analysis.withoutStemming().terms([]string{"häuser*"}))
+		assert.Equal(t, []string{"haus"}, analysis.terms([]string{"häuser"}))
+	})
+
+	t.Run("with an unknown stopword preset", func(t *testing.T) {
+		_, err := newTextAnalysis(textProp("text", &models.PropertyAnalyzerConfig{
+			StopwordPreset: "xx",
+		}), classStopwords)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAnalyzerTextProp(t *testing.T) {
+	a := NewAnalyzer(fakeStopwordDetector{})
+
+	prop := &models.Property{
+		Name:         "description",
+		DataType:     []string{"text"},
+		Tokenization: models.PropertyTokenizationWord,
+		Analyzer: &models.PropertyAnalyzerConfig{
+			Language: "de", Stemming: true, ASCIIFolding: true,
+			StopwordPreset: stopwords.GermanPreset,
+		},
+	}
+
+	res, err := a.TextProp(prop, []string{"Die Häuser und das Haus am Fluß"})
+	require.Nil(t, err)
+	// removed stopwords keep their positions
+	assert.ElementsMatch(t, []Countable{
+		{Data: []byte("haus"), TermFrequency: 2, Positions: []uint32{1, 4}},
+		{Data: []byte("fluss"), TermFrequency: 1, Positions: []uint32{6}},
+	}, res)
+}
+
+func TestTextAnalysisValueTerms(t *testing.T) {
+	classStopwords, err := stopwords.NewDetectorFromPreset(stopwords.EnglishPreset)
+	require.Nil(t, err)
+
+	tests := []struct {
+		name         string
+		tokenization string
+		analyzer     *models.PropertyAnalyzerConfig
+		values       []string
+		expected     []string
+	}{
+		{
+			name:         "with word tokenization",
+			tokenization: models.PropertyTokenizationWord,
+			values:       []string{"The Quick fox", "jumps"},
+			expected:     []string{"quick", "fox", "jumps"},
+		},
+		{
+			name:         "with trigram tokenization",
+			tokenization: models.PropertyTokenizationTrigram,
+			values:       []string{"The Quick fox"},
+			expected:     []string{"qui", "uic", "ick", "fox"},
+		},
+		{
+			name:         "with trigram tokenization grams are built after the analysis",
+			tokenization: models.PropertyTokenizationTrigram,
+			analyzer: &models.PropertyAnalyzerConfig{
+				Language: "de", Stemming: true, ASCIIFolding: true,
+			},
+			values:   []string{"Häuser"},
+			expected: []string{"hau", "aus"},
+		},
+		{
+			name:         "with cjk tokenization",
+			tokenization: models.PropertyTokenizationCjk,
+			values:       []string{"the 東京タワー", "and Tokyo"},
+			expected:     []string{"東京", "京タ", "タワ", "ワー", "tokyo"},
+		},
+		{
+			name:     "without tokenization",
+			values:   []string{"The Quick fox"},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			analysis, err := newTextAnalysis(&models.Property{
+				Name:         "description",
+				DataType:     []string{"text"},
+				Tokenization: test.tokenization,
+				Analyzer:     test.analyzer,
+			}, classStopwords)
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, analysis.valueTerms(test.values...))
+		})
+	}
+
+	t.Run("full grams", func(t *testing.T) {
+		trigram := &textAnalysis{tokenization: models.PropertyTokenizationTrigram}
+		assert.True(t, trigram.isFullGram("ell"))
+		assert.False(t, trigram.isFullGram("el"))
+
+		cjk := &textAnalysis{tokenization: models.PropertyTokenizationCjk}
+		assert.True(t, cjk.isFullGram("東京"))
+		assert.False(t, cjk.isFullGram("東"))
+		assert.False(t, cjk.isFullGram("to"))
+	})
+}
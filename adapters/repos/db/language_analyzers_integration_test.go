@@ -0,0 +1,180 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/stopwords"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/searchparams"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_LanguageAnalyzers(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "GermanRealEstate"
+
+	const (
+		idA = "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c01"
+		idB = "1b2c3d4e-5f6a-4b7c-9d8e-0f1a2b3c4d02"
+		idC = "2c3d4e5f-6a7b-4c8d-8e9f-1a2b3c4d5e03"
+	)
+
+	t.Run("prepare", func(t *testing.T) {
+		class := &models.Class{
+			Class:             className,
+			VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+			InvertedIndexConfig: &models.InvertedIndexConfig{
+				CleanupIntervalSeconds: 60,
+			},
+			Properties: []*models.Property{
+				{
+					Name:         "description",
+					DataType:     []string{string(schema.DataTypeText)},
+					Tokenization: "word",
+					Analyzer: &models.PropertyAnalyzerConfig{
+						Language:       "de",
+						Stemming:       true,
+						ASCIIFolding:   true,
+						StopwordPreset: stopwords.GermanPreset,
+					},
+				},
+			},
+		}
+
+		t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+	})
+
+	t.Run("insert data", func(t *testing.T) {
+		data := []struct {
+			id          string
+			description string
+		}{
+			{idA, "Zwei Häuser mit großem Garten"},
+			{idB, "Ein Haus am See"},
+			{idC, "Eine Wohnung in der Stadt"},
+		}
+
+		objs := make(objects.BatchObjects, len(data))
+		for i, d := range data {
+			objs[i] = objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          strfmt.UUID(d.id),
+				Object: &models.Object{
+					ID:    strfmt.UUID(d.id),
+					Class: className,
+					Properties: map[string]interface{}{
+						"description": d.description,
+					},
+				},
+			}
+		}
+
+		_, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+	})
+
+	filterSearch := func(t *testing.T, operator filters.Operator, value string) []string {
+		res, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+			ClassName:  className,
+			Pagination: &filters.Pagination{Limit: 10},
+			Filters: &filters.LocalFilter{
+				Root: &filters.Clause{
+					Operator: operator,
+					On: &filters.Path{
+						Class:    schema.ClassName(className),
+						Property: "description",
+					},
+					Value: &filters.Value{
+						Value: value,
+						Type:  schema.DataTypeText,
+					},
+				},
+			},
+		})
+		require.Nil(t, err)
+
+		ids := make([]string, len(res))
+		for i := range res {
+			ids[i] = res[i].ID.String()
+		}
+		return ids
+	}
+
+	t.Run("filter matches all inflections of a word", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{idA, idB}, filterSearch(t, filters.OperatorEqual, "Haus"))
+		assert.ElementsMatch(t, []string{idA, idB}, filterSearch(t, filters.OperatorEqual, "Häuser"))
+	})
+
+	t.Run("filter matches folded words", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{idA}, filterSearch(t, filters.OperatorEqual, "grossem"))
+	})
+
+	t.Run("filter with wildcards is folded but not stemmed", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{idC}, filterSearch(t, filters.OperatorLike, "Wohn*"))
+	})
+
+	t.Run("filter with only stopwords", func(t *testing.T) {
+		_, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+			ClassName:  className,
+			Pagination: &filters.Pagination{Limit: 10},
+			Filters: &filters.LocalFilter{
+				Root: &filters.Clause{
+					Operator: filters.OperatorEqual,
+					On: &filters.Path{
+						Class:    schema.ClassName(className),
+						Property: "description",
+					},
+					Value: &filters.Value{
+						Value: "der und die",
+						Type:  schema.DataTypeText,
+					},
+				},
+			},
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("keyword search matches all inflections of a word", func(t *testing.T) {
+		res, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+			ClassName:  className,
+			Pagination: &filters.Pagination{Limit: 10},
+			KeywordRanking: &searchparams.KeywordRanking{
+				Query:      "Häuser",
+				Properties: []string{"description"},
+			},
+		})
+		require.Nil(t, err)
+
+		ids := make([]string, len(res))
+		for i := range res {
+			ids[i] = res[i].ID.String()
+		}
+		assert.ElementsMatch(t, []string{idA, idB}, ids)
+	})
+}
@@ -128,6 +128,63 @@ func (s *Store) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// DropBucket shuts the bucket down and removes it from disk. A bucket which is
+// present on disk, but was never loaded, is removed as well.
+func (s *Store) DropBucket(ctx context.Context, bucketName string) error {
+	s.bucketAccessLock.Lock()
+	b := s.bucketsByName[bucketName]
+	delete(s.bucketsByName, bucketName)
+	s.bucketAccessLock.Unlock()
+
+	if b != nil {
+		if err := b.Shutdown(ctx); err != nil {
+			return errors.Wrapf(err, "shutdown bucket %q", bucketName)
+		}
+	}
+
+	if err := os.RemoveAll(s.bucketDir(bucketName)); err != nil {
+		return errors.Wrapf(err, "remove bucket %q", bucketName)
+	}
+
+	return nil
+}
+
+// ReplaceBucket drops the bucket and moves the replacement bucket in its
+// place, so that its contents can be accessed under the name of the bucket
+// afterwards. The replacement is loaded again with the given options. If the
+// replacement does not exist, the bucket is only dropped.
+func (s *Store) ReplaceBucket(ctx context.Context, bucketName, replacementName string,
+	opts ...BucketOption,
+) error {
+	s.bucketAccessLock.Lock()
+	replacement := s.bucketsByName[replacementName]
+	delete(s.bucketsByName, replacementName)
+	s.bucketAccessLock.Unlock()
+
+	if replacement != nil {
+		if err := replacement.Shutdown(ctx); err != nil {
+			return errors.Wrapf(err, "shutdown bucket %q", replacementName)
+		}
+	}
+
+	if err := s.DropBucket(ctx, bucketName); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(s.bucketDir(replacementName)); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "check bucket %q", replacementName)
+	}
+
+	if err := os.Rename(s.bucketDir(replacementName), s.bucketDir(bucketName)); err != nil {
+		return errors.Wrapf(err, "move bucket %q to %q", replacementName, bucketName)
+	}
+
+	return s.CreateOrLoadBucket(ctx, bucketName, opts...)
+}
+
 func (s *Store) WriteWALs() error {
 	s.bucketAccessLock.RLock()
 	defer s.bucketAccessLock.RUnlock()
@@ -17,6 +17,7 @@ package lsmkv
 import (
 	"context"
 	"math/rand"
+	"path"
 	"testing"
 	"time"
 
@@ -82,3 +83,85 @@ func TestStoreLifecycle(t *testing.T) {
 		require.Nil(t, err)
 	})
 }
+
+func TestStoreDropBucket(t *testing.T) {
+	dirName := t.TempDir()
+
+	store, err := New(dirName, "", nullLogger(), nil)
+	require.Nil(t, err)
+
+	err = store.CreateOrLoadBucket(testCtx(), "bucket1", WithStrategy(StrategyReplace))
+	require.Nil(t, err)
+
+	err = store.Bucket("bucket1").Put([]byte("name"), []byte("Jane Doe"))
+	require.Nil(t, err)
+
+	t.Run("drop the bucket", func(t *testing.T) {
+		err := store.DropBucket(context.Background(), "bucket1")
+		require.Nil(t, err)
+
+		assert.Nil(t, store.Bucket("bucket1"))
+		assert.NoDirExists(t, path.Join(dirName, "bucket1"))
+	})
+
+	t.Run("recreate the bucket", func(t *testing.T) {
+		err := store.CreateOrLoadBucket(testCtx(), "bucket1", WithStrategy(StrategyReplace))
+		require.Nil(t, err)
+
+		res, err := store.Bucket("bucket1").Get([]byte("name"))
+		require.Nil(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("drop a bucket which does not exist", func(t *testing.T) {
+		err := store.DropBucket(context.Background(), "bucket2")
+		require.Nil(t, err)
+	})
+
+	err = store.Shutdown(context.Background())
+	require.Nil(t, err)
+}
+
+func TestStoreReplaceBucket(t *testing.T) {
+	dirName := t.TempDir()
+
+	store, err := New(dirName, "", nullLogger(), nil)
+	require.Nil(t, err)
+
+	err = store.CreateOrLoadBucket(testCtx(), "bucket1", WithStrategy(StrategyReplace))
+	require.Nil(t, err)
+
+	err = store.Bucket("bucket1").Put([]byte("name"), []byte("Jane Doe"))
+	require.Nil(t, err)
+
+	err = store.CreateOrLoadBucket(testCtx(), "bucket1_new", WithStrategy(StrategyReplace))
+	require.Nil(t, err)
+
+	err = store.Bucket("bucket1_new").Put([]byte("name"), []byte("John Doe"))
+	require.Nil(t, err)
+
+	t.Run("replace the bucket", func(t *testing.T) {
+		err := store.ReplaceBucket(context.Background(), "bucket1", "bucket1_new",
+			WithStrategy(StrategyReplace))
+		require.Nil(t, err)
+
+		assert.Nil(t, store.Bucket("bucket1_new"))
+		assert.NoDirExists(t, path.Join(dirName, "bucket1_new"))
+
+		res, err := store.Bucket("bucket1").Get([]byte("name"))
+		require.Nil(t, err)
+		assert.Equal(t, []byte("John Doe"), res)
+	})
+
+	t.Run("replace with a bucket which does not exist", func(t *testing.T) {
+		err := store.ReplaceBucket(context.Background(), "bucket1", "bucket2",
+			WithStrategy(StrategyReplace))
+		require.Nil(t, err)
+
+		assert.Nil(t, store.Bucket("bucket1"))
+		assert.NoDirExists(t, path.Join(dirName, "bucket1"))
+	})
+
+	err = store.Shutdown(context.Background())
+	require.Nil(t, err)
+}
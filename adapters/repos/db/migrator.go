@@ -16,6 +16,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
@@ -38,6 +39,7 @@ func (m *Migrator) AddClass(ctx context.Context, class *models.Class,
 			DiskUseWarningPercentage:  m.db.config.DiskUseWarningPercentage,
 			DiskUseReadOnlyPercentage: m.db.config.DiskUseReadOnlyPercentage,
 			QueryMaximumResults:       m.db.config.QueryMaximumResults,
+			HybridCandidates:          m.db.config.HybridCandidates,
 			MaxImportGoroutinesFactor: m.db.config.MaxImportGoroutinesFactor,
 			NodeName:                  m.db.config.NodeName,
 		},
@@ -46,6 +48,7 @@ func (m *Migrator) AddClass(ctx context.Context, class *models.Class,
 		// always have the field set
 		inverted.ConfigFromModel(class.InvertedIndexConfig),
 		class.VectorIndexConfig.(schema.VectorIndexConfig),
+		namedVectorIndexConfigs(class),
 		m.db.schemaGetter, m.db, m.logger, m.db.nodeResolver, m.db.remoteClient,
 		m.db.promMetrics)
 	if err != nil {
@@ -107,10 +110,28 @@ func (m *Migrator) AddProperty(ctx context.Context, className string, prop *mode
 	return idx.addProperty(ctx, prop)
 }
 
-// DropProperty is ignored, API compliant change
+// DropProperty removes the indexes of the property, its values are stripped
+// from the stored objects in the background
 func (m *Migrator) DropProperty(ctx context.Context, className string, propertyName string) error {
-	// ignore but don't error
-	return nil
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return errors.Errorf("cannot drop property from a non-existing index for %s", className)
+	}
+
+	return idx.dropProperty(ctx, propertyName)
+}
+
+// ReindexProperty rebuilds the inverted index of the property in the
+// background, after its tokenization or indexInverted setting was changed
+func (m *Migrator) ReindexProperty(ctx context.Context, className string,
+	previous, updated *models.Property,
+) error {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return errors.Errorf("cannot reindex property of a non-existing index for %s", className)
+	}
+
+	return idx.reindexProperty(ctx, previous, updated)
 }
 
 func (m *Migrator) UpdateProperty(ctx context.Context, className string, propName string, newName *string) error {
@@ -130,6 +151,17 @@ func (m *Migrator) GetShardsStatus(ctx context.Context, className string) (map[s
 	return idx.getShardsStatus(ctx)
 }
 
+func (m *Migrator) GetShardsReindexStatus(ctx context.Context,
+	className string,
+) (map[string][]*models.PropertyReindexStatus, error) {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return nil, errors.Errorf("cannot get shards reindex status for a non-existing index for %s", className)
+	}
+
+	return idx.getShardsReindexStatus(ctx)
+}
+
 func (m *Migrator) UpdateShardStatus(ctx context.Context, className, shardName, targetStatus string) error {
 	idx := m.db.GetIndex(schema.ClassName(className))
 	if idx == nil {
@@ -139,6 +171,45 @@ func (m *Migrator) UpdateShardStatus(ctx context.Context, className, shardName,
 	return idx.updateShardStatus(ctx, shardName, targetStatus)
 }
 
+// TransferShards copies the source shards of the transfers to the nodes and
+// under the names specified by them
+func (m *Migrator) TransferShards(ctx context.Context, className string,
+	transfers []sharding.Transfer,
+) error {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return errors.Errorf("cannot transfer shards of non-existing index for %s", className)
+	}
+
+	return idx.transferShards(ctx, transfers)
+}
+
+// LoadShards loads the local shards of the sharding state which are not
+// loaded yet. It is called before the state is applied.
+func (m *Migrator) LoadShards(ctx context.Context, className string,
+	state *sharding.State,
+) error {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return errors.Errorf("cannot load shards of non-existing index for %s", className)
+	}
+
+	return idx.loadShards(ctx, state, m.db.promMetrics)
+}
+
+// UnloadShards removes the shards which are no longer local and prunes the
+// ones which have been split. It is called after the state is applied.
+func (m *Migrator) UnloadShards(ctx context.Context, className string,
+	previous *sharding.State,
+) error {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return errors.Errorf("cannot unload shards of non-existing index for %s", className)
+	}
+
+	return idx.unloadShards(ctx, previous)
+}
+
 func NewMigrator(db *DB, logger logrus.FieldLogger) *Migrator {
 	return &Migrator{db: db, logger: logger}
 }
@@ -154,13 +225,26 @@ func (m *Migrator) UpdateVectorIndexConfig(ctx context.Context,
 	return idx.updateVectorIndexConfig(ctx, updated)
 }
 
+func (m *Migrator) UpdateVectorIndexConfigs(ctx context.Context,
+	className string, updated map[string]schema.VectorIndexConfig,
+) error {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return errors.Errorf("cannot update vector index configs of non-existing index for %s", className)
+	}
+
+	return idx.updateVectorIndexConfigs(ctx, updated)
+}
+
 func (m *Migrator) ValidateVectorIndexConfigUpdate(ctx context.Context,
 	old, updated schema.VectorIndexConfig,
 ) error {
-	// hnsw is the only supported vector index type at the moment, so no need
-	// to check, we can always use that an hnsw-specific validation should be
-	// used for now.
-	return hnsw.ValidateUserConfigUpdate(old, updated)
+	switch old.IndexType() {
+	case flat.IndexType:
+		return flat.ValidateUserConfigUpdate(old, updated)
+	default:
+		return hnsw.ValidateUserConfigUpdate(old, updated)
+	}
 }
 
 func (m *Migrator) ValidateInvertedIndexConfigUpdate(ctx context.Context,
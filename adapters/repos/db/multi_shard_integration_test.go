@@ -268,6 +268,132 @@ func Test_MultiShardJourneys_BM25_Search(t *testing.T) {
 	})
 }
 
+func Test_MultiShardJourneys_BM25F_Search(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "RacecarArticles"
+
+	t.Run("prepare", func(t *testing.T) {
+		class := &models.Class{
+			Class:             className,
+			VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+			InvertedIndexConfig: &models.InvertedIndexConfig{
+				CleanupIntervalSeconds: 60,
+			},
+			Properties: []*models.Property{
+				{
+					Name:         "title",
+					DataType:     []string{string(schema.DataTypeText)},
+					Tokenization: "word",
+				},
+				{
+					Name:         "body",
+					DataType:     []string{string(schema.DataTypeText)},
+					Tokenization: "word",
+				},
+			},
+		}
+
+		t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+	})
+
+	t.Run("insert search data", func(t *testing.T) {
+		data := []struct {
+			id, title, body string
+		}{
+			{
+				"6e6d2a47-9a0c-4f5b-8f64-2d3f0c1a5b01",
+				"Pit stop strategies",
+				"the engine of a car and the engine of another car",
+			},
+			{
+				"7f1a3b58-ab1d-4a6c-9075-3e4a1d2b6c02",
+				"Engine development",
+				"a look at how teams work on their power units",
+			},
+			{
+				"8a2b4c69-bc2e-4b7d-8186-4f5b2e3c7d03",
+				"Season review",
+				"nothing to see here",
+			},
+		}
+
+		objs := make(objects.BatchObjects, len(data))
+		for i, d := range data {
+			objs[i] = objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          strfmt.UUID(d.id),
+				Object: &models.Object{
+					ID:    strfmt.UUID(d.id),
+					Class: className,
+					Properties: map[string]interface{}{
+						"title": d.title,
+						"body":  d.body,
+					},
+				},
+			}
+		}
+
+		_, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+	})
+
+	t.Run("ranked keyword search across properties", func(t *testing.T) {
+		type testcase struct {
+			name            string
+			properties      []string
+			expectedResults []string
+		}
+
+		tests := []testcase{
+			{
+				name:       "only the body",
+				properties: []string{"body"},
+				expectedResults: []string{
+					"6e6d2a47-9a0c-4f5b-8f64-2d3f0c1a5b01",
+				},
+			},
+			{
+				name:       "title and body without a boost",
+				properties: []string{"title", "body"},
+				expectedResults: []string{
+					"6e6d2a47-9a0c-4f5b-8f64-2d3f0c1a5b01",
+					"7f1a3b58-ab1d-4a6c-9075-3e4a1d2b6c02",
+				},
+			},
+			{
+				name:       "title boosted over body",
+				properties: []string{"title^5", "body"},
+				expectedResults: []string{
+					"7f1a3b58-ab1d-4a6c-9075-3e4a1d2b6c02",
+					"6e6d2a47-9a0c-4f5b-8f64-2d3f0c1a5b01",
+				},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				res, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+					ClassName:  className,
+					Pagination: &filters.Pagination{Limit: 10},
+					KeywordRanking: &searchparams.KeywordRanking{
+						Query:      "engine",
+						Properties: test.properties,
+					},
+				})
+				require.Nil(t, err)
+				require.Equal(t, len(test.expectedResults), len(res))
+				for i := range res {
+					assert.Equal(t, test.expectedResults[i], res[i].ID.String())
+				}
+			})
+		}
+	})
+}
+
 func setupMultiShardTest(t *testing.T) (*DB, *logrus.Logger) {
 	rand.Seed(time.Now().UnixNano())
 	dirName := t.TempDir()
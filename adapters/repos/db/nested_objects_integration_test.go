@@ -0,0 +1,258 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/additional"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_NestedObjects(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "NestedPeople"
+
+	const (
+		idA = "4c5d6e7f-8a9b-4c0d-9e1f-3a4b5c6d7e01"
+		idB = "5d6e7f8a-9b0c-4d1e-8f2a-4b5c6d7e8f02"
+		idC = "6e7f8a9b-0c1d-4e2f-9a3b-5c6d7e8f9a03"
+	)
+
+	vFalse := false
+
+	t.Run("prepare", func(t *testing.T) {
+		class := &models.Class{
+			Class:             className,
+			VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+			InvertedIndexConfig: &models.InvertedIndexConfig{
+				CleanupIntervalSeconds: 60,
+			},
+			Properties: []*models.Property{
+				{
+					Name:     "address",
+					DataType: []string{string(schema.DataTypeObject)},
+					NestedProperties: []*models.Property{
+						{
+							Name:         "city",
+							DataType:     []string{string(schema.DataTypeString)},
+							Tokenization: models.PropertyTokenizationField,
+						},
+						{
+							Name:     "zip",
+							DataType: []string{string(schema.DataTypeInt)},
+						},
+					},
+				},
+				{
+					Name:     "pets",
+					DataType: []string{string(schema.DataTypeObjectArray)},
+					NestedProperties: []*models.Property{
+						{
+							Name:         "name",
+							DataType:     []string{string(schema.DataTypeText)},
+							Tokenization: models.PropertyTokenizationWord,
+						},
+						{
+							Name:          "notes",
+							DataType:      []string{string(schema.DataTypeText)},
+							Tokenization:  models.PropertyTokenizationWord,
+							IndexInverted: &vFalse,
+						},
+					},
+				},
+			},
+		}
+
+		t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+	})
+
+	t.Run("insert data", func(t *testing.T) {
+		data := []struct {
+			id      string
+			address map[string]interface{}
+			pets    []interface{}
+		}{
+			{
+				idA,
+				map[string]interface{}{"city": "Amsterdam", "zip": int64(1011)},
+				[]interface{}{
+					map[string]interface{}{"name": "Bello", "notes": "loves the park"},
+					map[string]interface{}{"name": "Garfield"},
+				},
+			},
+			{
+				idB,
+				map[string]interface{}{"city": "Berlin", "zip": int64(10115)},
+				[]interface{}{
+					map[string]interface{}{"name": "Nemo"},
+				},
+			},
+			{
+				idC,
+				map[string]interface{}{"city": "New Amsterdam"},
+				nil,
+			},
+		}
+
+		objs := make(objects.BatchObjects, len(data))
+		for i, d := range data {
+			props := map[string]interface{}{
+				"address": d.address,
+			}
+			if d.pets != nil {
+				props["pets"] = d.pets
+			}
+
+			objs[i] = objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          strfmt.UUID(d.id),
+				Object: &models.Object{
+					ID:         strfmt.UUID(d.id),
+					Class:      className,
+					Properties: props,
+				},
+			}
+		}
+
+		_, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+	})
+
+	search := func(t *testing.T, clause *filters.Clause) []string {
+		res, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+			ClassName:  className,
+			Pagination: &filters.Pagination{Limit: 10},
+			Filters:    &filters.LocalFilter{Root: clause},
+		})
+		require.Nil(t, err)
+
+		ids := make([]string, len(res))
+		for i := range res {
+			ids[i] = res[i].ID.String()
+		}
+		return ids
+	}
+
+	t.Run("filter by nested props", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			operator filters.Operator
+			prop     string
+			value    interface{}
+			dataType schema.DataType
+			expected []string
+		}{
+			{
+				name:     "by the city of an object",
+				operator: filters.OperatorEqual,
+				prop:     "address.city",
+				value:    "Amsterdam",
+				dataType: schema.DataTypeString,
+				expected: []string{idA},
+			},
+			{
+				name:     "by the zip of an object",
+				operator: filters.OperatorGreaterThan,
+				prop:     "address.zip",
+				value:    2000,
+				dataType: schema.DataTypeInt,
+				expected: []string{idB},
+			},
+			{
+				name:     "by the name of any element of an object array",
+				operator: filters.OperatorEqual,
+				prop:     "pets.name",
+				value:    "garfield",
+				dataType: schema.DataTypeText,
+				expected: []string{idA},
+			},
+			{
+				name:     "by a like filter on an object array",
+				operator: filters.OperatorLike,
+				prop:     "pets.name",
+				value:    "ne*",
+				dataType: schema.DataTypeText,
+				expected: []string{idB},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				ids := search(t, &filters.Clause{
+					Operator: test.operator,
+					On: &filters.Path{
+						Class:    schema.ClassName(className),
+						Property: schema.PropertyName(test.prop),
+					},
+					Value: &filters.Value{
+						Value: test.value,
+						Type:  test.dataType,
+					},
+				})
+				assert.ElementsMatch(t, test.expected, ids)
+			})
+		}
+	})
+
+	t.Run("nested props which are not indexed can't be filtered", func(t *testing.T) {
+		_, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+			ClassName:  className,
+			Pagination: &filters.Pagination{Limit: 10},
+			Filters: &filters.LocalFilter{Root: &filters.Clause{
+				Operator: filters.OperatorEqual,
+				On: &filters.Path{
+					Class:    schema.ClassName(className),
+					Property: "pets.notes",
+				},
+				Value: &filters.Value{
+					Value: "park",
+					Type:  schema.DataTypeText,
+				},
+			}},
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("nested values are returned as they were stored", func(t *testing.T) {
+		res, err := repo.ObjectByID(context.Background(), strfmt.UUID(idA),
+			nil, additional.Properties{})
+		require.Nil(t, err)
+		require.NotNil(t, res)
+
+		props := res.Schema.(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{
+			"city": "Amsterdam",
+			"zip":  float64(1011),
+		}, props["address"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "Bello", "notes": "loves the park"},
+			map[string]interface{}{"name": "Garfield"},
+		}, props["pets"])
+	})
+}
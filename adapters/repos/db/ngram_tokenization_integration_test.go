@@ -0,0 +1,292 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/searchparams"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_NgramTokenization(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "NgramArticles"
+
+	const (
+		idA = "3d4e5f6a-7b8c-4d9e-8f0a-2b3c4d5e6f01"
+		idB = "4e5f6a7b-8c9d-4e0f-9a1b-3c4d5e6f7a02"
+		idC = "5f6a7b8c-9d0e-4f1a-8b2c-4d5e6f7a8b03"
+		idD = "6a7b8c9d-0e1f-4a2b-9c3d-5e6f7a8b9c04"
+	)
+
+	t.Run("prepare", func(t *testing.T) {
+		class := &models.Class{
+			Class:             className,
+			VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+			InvertedIndexConfig: &models.InvertedIndexConfig{
+				CleanupIntervalSeconds: 60,
+			},
+			Properties: []*models.Property{
+				{
+					Name:         "title",
+					DataType:     []string{string(schema.DataTypeText)},
+					Tokenization: models.PropertyTokenizationTrigram,
+				},
+				{
+					Name:         "body",
+					DataType:     []string{string(schema.DataTypeText)},
+					Tokenization: models.PropertyTokenizationCjk,
+				},
+				{
+					Name:     "author",
+					DataType: []string{string(schema.DataTypeObject)},
+					NestedProperties: []*models.Property{
+						{
+							Name:         "name",
+							DataType:     []string{string(schema.DataTypeText)},
+							Tokenization: models.PropertyTokenizationTrigram,
+						},
+					},
+				},
+				{
+					Name:     "comments",
+					DataType: []string{string(schema.DataTypeObjectArray)},
+					NestedProperties: []*models.Property{
+						{
+							Name:         "text",
+							DataType:     []string{string(schema.DataTypeText)},
+							Tokenization: models.PropertyTokenizationTrigram,
+						},
+					},
+				},
+			},
+		}
+
+		t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+	})
+
+	t.Run("insert data", func(t *testing.T) {
+		data := []struct {
+			id       string
+			title    string
+			body     string
+			author   string
+			comments []string
+		}{
+			{idA, "Hello world", "東京タワーに行きました", "Ada Lovelace", []string{"great read", "loved it"}},
+			{idB, "Yellow submarine", "北京は中国の首都です", "John Lennon", []string{"classic"}},
+			{idC, "Wonderwall", "東京都の人口", "Noel Gallagher", nil},
+			{idD, "Othello", "京都と東京", "William Shakespeare", []string{"unloved"}},
+		}
+
+		objs := make(objects.BatchObjects, len(data))
+		for i, d := range data {
+			comments := make([]interface{}, len(d.comments))
+			for j, text := range d.comments {
+				comments[j] = map[string]interface{}{"text": text}
+			}
+
+			objs[i] = objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          strfmt.UUID(d.id),
+				Object: &models.Object{
+					ID:    strfmt.UUID(d.id),
+					Class: className,
+					Properties: map[string]interface{}{
+						"title":    d.title,
+						"body":     d.body,
+						"author":   map[string]interface{}{"name": d.author},
+						"comments": comments,
+					},
+				},
+			}
+		}
+
+		_, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+	})
+
+	resultIDs := func(t *testing.T, params traverser.GetParams) []string {
+		params.ClassName = className
+		params.Pagination = &filters.Pagination{Limit: 10}
+		res, err := repo.ClassSearch(context.Background(), params)
+		require.Nil(t, err)
+
+		ids := make([]string, len(res))
+		for i := range res {
+			ids[i] = res[i].ID.String()
+		}
+		return ids
+	}
+
+	filterSearch := func(t *testing.T, prop string, operator filters.Operator,
+		value string,
+	) []string {
+		return resultIDs(t, traverser.GetParams{
+			Filters: &filters.LocalFilter{
+				Root: &filters.Clause{
+					Operator: operator,
+					On: &filters.Path{
+						Class:    schema.ClassName(className),
+						Property: schema.PropertyName(prop),
+					},
+					Value: &filters.Value{
+						Value: value,
+						Type:  schema.DataTypeText,
+					},
+				},
+			},
+		})
+	}
+
+	valueListSearch := func(t *testing.T, prop string, operator filters.Operator,
+		values ...string,
+	) []string {
+		return resultIDs(t, traverser.GetParams{
+			Filters: &filters.LocalFilter{
+				Root: &filters.Clause{
+					Operator: operator,
+					On: &filters.Path{
+						Class:    schema.ClassName(className),
+						Property: schema.PropertyName(prop),
+					},
+					Value: &filters.Value{
+						Value: values,
+						Type:  schema.DataTypeTextArray,
+					},
+				},
+			},
+		})
+	}
+
+	t.Run("trigram tokenization", func(t *testing.T) {
+		t.Run("like with a substring", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idA, idB, idD}, filterSearch(t, "title", filters.OperatorLike, "*ello*"))
+		})
+
+		t.Run("like is anchored to the start of a word", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idA}, filterSearch(t, "title", filters.OperatorLike, "hel*"))
+			assert.Empty(t, filterSearch(t, "title", filters.OperatorLike, "ello*"))
+		})
+
+		t.Run("like is anchored to the end of a word", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idA, idD}, filterSearch(t, "title", filters.OperatorLike, "*ello"))
+		})
+
+		t.Run("like with a prefix", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idC}, filterSearch(t, "title", filters.OperatorLike, "Wonder*"))
+		})
+
+		t.Run("like with a part shorter than a trigram", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idA, idC}, filterSearch(t, "title", filters.OperatorLike, "*wo*"))
+		})
+
+		t.Run("equal", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idA}, filterSearch(t, "title", filters.OperatorEqual, "world"))
+		})
+
+		t.Run("equal on a word within a longer word", func(t *testing.T) {
+			// othello has all trigrams of hello
+			assert.ElementsMatch(t, []string{idA}, filterSearch(t, "title", filters.OperatorEqual, "hello"))
+			assert.ElementsMatch(t, []string{idD}, filterSearch(t, "title", filters.OperatorEqual, "othello"))
+		})
+
+		t.Run("contains any and all", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idA, idB},
+				valueListSearch(t, "title", filters.OperatorContainsAny, "hello", "submarine"))
+			assert.ElementsMatch(t, []string{idA},
+				valueListSearch(t, "title", filters.OperatorContainsAll, "hello", "world"))
+		})
+
+		t.Run("bm25", func(t *testing.T) {
+			ids := resultIDs(t, traverser.GetParams{
+				KeywordRanking: &searchparams.KeywordRanking{
+					Query:      "yellow",
+					Properties: []string{"title"},
+				},
+			})
+			// yellow shares the trigrams of "ello" with hello and othello
+			require.Len(t, ids, 3)
+			assert.Equal(t, idB, ids[0])
+			assert.ElementsMatch(t, []string{idA, idD}, ids[1:])
+		})
+	})
+
+	t.Run("trigram tokenization of nested props", func(t *testing.T) {
+		t.Run("equal on an object prop", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idB}, filterSearch(t, "author.name", filters.OperatorEqual, "lennon"))
+		})
+
+		t.Run("like on an object prop", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idA, idC}, filterSearch(t, "author.name", filters.OperatorLike, "*la*"))
+		})
+
+		t.Run("equal on an object[] prop", func(t *testing.T) {
+			// unloved has all trigrams of loved
+			assert.ElementsMatch(t, []string{idA}, filterSearch(t, "comments.text", filters.OperatorEqual, "loved"))
+		})
+
+		t.Run("contains any on an object[] prop", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idA, idB},
+				valueListSearch(t, "comments.text", filters.OperatorContainsAny, "classic", "great"))
+		})
+	})
+
+	t.Run("cjk tokenization", func(t *testing.T) {
+		t.Run("equal", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idA, idC, idD}, filterSearch(t, "body", filters.OperatorEqual, "東京"))
+			assert.ElementsMatch(t, []string{idA}, filterSearch(t, "body", filters.OperatorEqual, "東京タワー"))
+		})
+
+		t.Run("equal on a run shorter than a bigram", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idA, idC, idD}, filterSearch(t, "body", filters.OperatorEqual, "東"))
+		})
+
+		t.Run("equal requires adjacent bigrams", func(t *testing.T) {
+			// the body of D has both bigrams of 東京都, but not next to each other
+			assert.ElementsMatch(t, []string{idC}, filterSearch(t, "body", filters.OperatorEqual, "東京都"))
+		})
+
+		t.Run("like", func(t *testing.T) {
+			assert.ElementsMatch(t, []string{idB}, filterSearch(t, "body", filters.OperatorLike, "*中国*"))
+			assert.ElementsMatch(t, []string{idA, idC}, filterSearch(t, "body", filters.OperatorLike, "東*"))
+			assert.ElementsMatch(t, []string{idD}, filterSearch(t, "body", filters.OperatorLike, "京都*"))
+		})
+
+		t.Run("bm25", func(t *testing.T) {
+			ids := resultIDs(t, traverser.GetParams{
+				KeywordRanking: &searchparams.KeywordRanking{
+					Query:      "首都",
+					Properties: []string{"body"},
+				},
+			})
+			assert.Equal(t, []string{idB}, ids)
+		})
+	})
+}
@@ -0,0 +1,54 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"sort"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+// LocalNodeShards returns all shards loaded on this node together with the
+// number of objects they hold, sorted by class and shard name
+func (d *DB) LocalNodeShards() []*models.NodeShardStatus {
+	d.indexLock.Lock()
+	indices := make([]*Index, 0, len(d.indices))
+	for _, index := range d.indices {
+		indices = append(indices, index)
+	}
+	d.indexLock.Unlock()
+
+	var out []*models.NodeShardStatus
+	for _, index := range indices {
+		for name, shard := range index.localShards() {
+			out = append(out, &models.NodeShardStatus{
+				Name:        name,
+				Class:       index.Config.ClassName.String(),
+				ObjectCount: int64(shard.objectCount()),
+			})
+		}
+	}
+
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].Class != out[b].Class {
+			return out[a].Class < out[b].Class
+		}
+		return out[a].Name < out[b].Name
+	})
+
+	return out
+}
+
+func (s *Shard) objectCount() int {
+	return s.store.Bucket(helpers.ObjectsBucketLSM).Count()
+}
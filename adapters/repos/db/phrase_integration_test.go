@@ -0,0 +1,268 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/searchparams"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_Phrases(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "PhraseArticles"
+
+	const (
+		idA = "6a7b8c9d-0e1f-4a2b-9c3d-5e6f7a8b9c01"
+		idB = "7b8c9d0e-1f2a-4b3c-8d4e-6f7a8b9c0d02"
+		idC = "8c9d0e1f-2a3b-4c4d-9e5f-7a8b9c0d1e03"
+		idD = "9d0e1f2a-3b4c-4d5e-8f6a-8b9c0d1e2f04"
+	)
+
+	invertedConfig := func(indexPositions bool) *models.InvertedIndexConfig {
+		return &models.InvertedIndexConfig{
+			CleanupIntervalSeconds: 60,
+			Stopwords: &models.StopwordConfig{
+				Preset: "en",
+			},
+			IndexPositions: indexPositions,
+		}
+	}
+
+	t.Run("prepare", func(t *testing.T) {
+		class := &models.Class{
+			Class:               className,
+			VectorIndexConfig:   hnsw.NewDefaultUserConfig(),
+			InvertedIndexConfig: invertedConfig(false),
+			Properties: []*models.Property{
+				{
+					Name:         "body",
+					DataType:     []string{string(schema.DataTypeText)},
+					Tokenization: models.PropertyTokenizationWord,
+				},
+				{
+					Name:         "notes",
+					DataType:     []string{string(schema.DataTypeTextArray)},
+					Tokenization: models.PropertyTokenizationWord,
+				},
+			},
+		}
+
+		t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+	})
+
+	insert := func(t *testing.T, id, body string, notes ...interface{}) {
+		objs := objects.BatchObjects{{
+			UUID: strfmt.UUID(id),
+			Object: &models.Object{
+				ID:    strfmt.UUID(id),
+				Class: className,
+				Properties: map[string]interface{}{
+					"body":  body,
+					"notes": notes,
+				},
+			},
+		}}
+
+		res, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+		require.Nil(t, res[0].Err)
+	}
+
+	t.Run("insert data", func(t *testing.T) {
+		insert(t, idA, "Machine learning is fun", "deep machine", "learning rocks")
+		insert(t, idB, "Learning about the machine")
+		insert(t, idC, "Machine and deep learning")
+	})
+
+	search := func(params traverser.GetParams) ([]string, error) {
+		params.ClassName = className
+		params.Pagination = &filters.Pagination{Limit: 10}
+		res, err := repo.ClassSearch(context.Background(), params)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]string, len(res))
+		for i := range res {
+			ids[i] = res[i].ID.String()
+		}
+		return ids, nil
+	}
+
+	phraseFilter := func(prop, value string) traverser.GetParams {
+		return traverser.GetParams{
+			Filters: &filters.LocalFilter{
+				Root: &filters.Clause{
+					Operator: filters.OperatorPhrase,
+					On: &filters.Path{
+						Class:    schema.ClassName(className),
+						Property: schema.PropertyName(prop),
+					},
+					Value: &filters.Value{
+						Value: value,
+						Type:  schema.DataTypeText,
+					},
+				},
+			},
+		}
+	}
+
+	keywordSearch := func(query string) traverser.GetParams {
+		return traverser.GetParams{
+			KeywordRanking: &searchparams.KeywordRanking{
+				Query:      query,
+				Properties: []string{"body", "notes"},
+			},
+		}
+	}
+
+	t.Run("phrases require positions", func(t *testing.T) {
+		_, err := search(phraseFilter("body", "machine learning"))
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "indexPositions")
+
+		_, err = search(keywordSearch(`"machine learning"`))
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "indexPositions")
+	})
+
+	t.Run("enable positions on the existing class", func(t *testing.T) {
+		migrator := NewMigrator(repo, logger)
+		err := migrator.UpdateInvertedIndexConfig(context.Background(), className,
+			invertedConfig(true))
+		require.Nil(t, err)
+	})
+
+	t.Run("insert data after enabling positions", func(t *testing.T) {
+		insert(t, idD, "I love machine learning, machine learning rocks")
+	})
+
+	t.Run("wait for the positions of the existing objects", func(t *testing.T) {
+		assert.Eventually(t, func() bool {
+			_, err := search(phraseFilter("body", "machine learning"))
+			return err == nil
+		}, 10*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("phrase filter", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			prop     string
+			value    string
+			expected []string
+		}{
+			{
+				name:     "exact phrase",
+				prop:     "body",
+				value:    "machine learning",
+				expected: []string{idA, idD},
+			},
+			{
+				name:     "quoted phrase",
+				prop:     "body",
+				value:    `"Machine Learning"`,
+				expected: []string{idA, idD},
+			},
+			{
+				name:     "phrase with a slop",
+				prop:     "body",
+				value:    `"machine learning"~2`,
+				expected: []string{idA, idC, idD},
+			},
+			{
+				name:     "phrase with a slop too small for the removed stopword",
+				prop:     "body",
+				value:    `"machine learning"~1`,
+				expected: []string{idA, idD},
+			},
+			{
+				name:     "phrase does not match across array values",
+				prop:     "notes",
+				value:    "machine learning",
+				expected: []string{},
+			},
+			{
+				name:     "phrase within an array value",
+				prop:     "notes",
+				value:    "learning rocks",
+				expected: []string{idA},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				ids, err := search(phraseFilter(test.prop, test.value))
+				require.Nil(t, err)
+				assert.ElementsMatch(t, test.expected, ids)
+			})
+		}
+	})
+
+	t.Run("phrase in a keyword search", func(t *testing.T) {
+		ids, err := search(keywordSearch(`"machine learning"`))
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{idA, idD}, ids)
+
+		ids, err = search(keywordSearch(`"machine learning"~2`))
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{idA, idC, idD}, ids)
+	})
+
+	t.Run("phrase combined with a term in a keyword search", func(t *testing.T) {
+		ids, err := search(keywordSearch(`"machine learning" about`))
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{idA, idB, idD}, ids)
+	})
+
+	t.Run("phrases are rejected until the positions are indexed", func(t *testing.T) {
+		shards := repo.GetIndex(schema.ClassName(className)).localShards()
+		for _, shard := range shards {
+			require.Nil(t, shard.resetPositionsState(context.Background()))
+		}
+
+		_, err := search(phraseFilter("body", "machine learning"))
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "still being indexed")
+
+		_, err = search(keywordSearch(`"machine learning"`))
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "still being indexed")
+
+		for _, shard := range shards {
+			require.Nil(t, shard.initPositions(context.Background()))
+		}
+
+		assert.Eventually(t, func() bool {
+			ids, err := search(phraseFilter("body", "machine learning"))
+			return err == nil && len(ids) == 2
+		}, 10*time.Second, 50*time.Millisecond)
+	})
+}
@@ -0,0 +1,230 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/backup"
+	"github.com/semi-technologies/weaviate/entities/errorcompounder"
+	"github.com/semi-technologies/weaviate/entities/storagestate"
+	"github.com/semi-technologies/weaviate/usecases/monitoring"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
+)
+
+// transferShards copies the files of the source shards of the transfers to
+// the target nodes, where they are stored under the name of the target
+// shard. Each source shard is snapshotted once, no matter how many copies
+// are made of it. The copies are not loaded by the target nodes, this only
+// happens once a state containing them is applied, see loadShards.
+//
+// The source shards should be read-only, so that the copies do not miss any
+// writes which happen in the meantime.
+func (i *Index) transferShards(ctx context.Context,
+	transfers []sharding.Transfer,
+) error {
+	bySource := map[string][]sharding.Transfer{}
+	for _, transfer := range transfers {
+		bySource[transfer.Shard] = append(bySource[transfer.Shard], transfer)
+	}
+
+	sources := make([]string, 0, len(bySource))
+	for source := range bySource {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	snapshotID := "rebalance-" + uuid.New().String()
+	if err := i.initSnapshot(snapshotID); err != nil {
+		return err
+	}
+	defer i.resetSnapshotState()
+
+	for _, source := range sources {
+		if err := i.transferShard(ctx, snapshotID, source, bySource[source]); err != nil {
+			return errors.Wrapf(err, "transfer shard %q", source)
+		}
+	}
+
+	return nil
+}
+
+func (i *Index) transferShard(ctx context.Context, snapshotID, source string,
+	transfers []sharding.Transfer,
+) (err error) {
+	var (
+		snap *backup.ShardSnapshot
+		open func(relPath string) (io.ReadCloser, error)
+	)
+
+	if shard := i.shard(source); shard != nil {
+		defer func() {
+			ec := errorcompounder.ErrorCompounder{}
+			ec.Add(err)
+			ec.Add(shard.resumeMaintenanceCycles(ctx))
+			err = ec.ToError()
+		}()
+
+		if snap, err = shard.createBackup(ctx); err != nil {
+			return err
+		}
+		open = func(relPath string) (io.ReadCloser, error) {
+			return i.openBackupFile(source, relPath)
+		}
+	} else {
+		if snap, err = i.remote.CreateShardBackup(ctx, source, snapshotID); err != nil {
+			return errors.Wrap(err, "create backup of remote shard")
+		}
+		defer func() {
+			ec := errorcompounder.ErrorCompounder{}
+			ec.Add(err)
+			ec.Add(i.remote.ReleaseShardBackup(ctx, source, snapshotID))
+			err = ec.ToError()
+		}()
+
+		open = func(relPath string) (io.ReadCloser, error) {
+			return i.remote.GetShardBackupFile(ctx, source, relPath)
+		}
+	}
+
+	for _, transfer := range transfers {
+		from := i.ID() + "_" + source
+		to := i.ID() + "_" + transfer.TargetShard
+
+		for _, file := range snap.Files {
+			if !strings.HasPrefix(file.Path, from) {
+				return errors.Errorf("file %q does not belong to shard %q",
+					file.Path, source)
+			}
+
+			r, err := open(file.Path)
+			if err != nil {
+				return err
+			}
+
+			relPath := to + strings.TrimPrefix(file.Path, from)
+			err = i.writeTransferredFile(ctx, transfer, relPath, r)
+			r.Close()
+			if err != nil {
+				return errors.Wrapf(err, "copy to node %q", transfer.Node)
+			}
+		}
+
+		if snap.Metadata == nil {
+			continue
+		}
+
+		files := shardMetadataFiles(i.ID(), transfer.TargetShard, snap.Metadata)
+		for relPath, contents := range files {
+			err := i.writeTransferredFile(ctx, transfer, relPath, bytes.NewReader(contents))
+			if err != nil {
+				return errors.Wrapf(err, "copy metadata to node %q", transfer.Node)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (i *Index) writeTransferredFile(ctx context.Context, transfer sharding.Transfer,
+	relPath string, r io.Reader,
+) error {
+	if transfer.Node == i.Config.NodeName {
+		return writeShardFile(i.Config.RootPath, i.ID(), transfer.TargetShard, relPath, r)
+	}
+
+	return i.remote.PutShardBackupFile(ctx, transfer.Node, transfer.TargetShard,
+		relPath, r)
+}
+
+// loadShards loads the shards which are local according to the specified
+// state, but have not been loaded yet, such as the copies made by
+// transferShards. Objects which the state places in other shards are
+// removed from them. They stay read-only until they are explicitly marked
+// as ready, as they do not serve any requests yet.
+func (i *Index) loadShards(ctx context.Context, state *sharding.State,
+	promMetrics *monitoring.PrometheusMetrics,
+) error {
+	for _, name := range state.AllLocalPhysicalShards() {
+		if i.shard(name) != nil {
+			continue
+		}
+
+		shard, err := NewShard(ctx, promMetrics, name, i)
+		if err != nil {
+			return errors.Wrapf(err, "init shard %s of index %s", name, i.ID())
+		}
+
+		pruned, err := shard.pruneObjects(ctx, state)
+		if err != nil {
+			return errors.Wrapf(err, "init shard %s of index %s", name, i.ID())
+		}
+
+		shard.notifyReady()
+		if err := shard.updateStatus(storagestate.StatusReadOnly.String()); err != nil {
+			return errors.Wrapf(err, "init shard %s of index %s", name, i.ID())
+		}
+
+		i.shardsLock.Lock()
+		i.Shards[name] = shard
+		i.shardsLock.Unlock()
+
+		i.logger.
+			WithField("action", "load_shard").
+			WithField("shard", name).
+			WithField("pruned_objects", pruned).
+			Debugf("loaded shard %s of index %s", name, i.ID())
+	}
+
+	return nil
+}
+
+// unloadShards removes the loaded shards which are no longer local according
+// to the current state from the disk. Local shards which own fewer virtual
+// shards than in the previous state, because they have been split, are
+// pruned down to the objects of the virtual shards they still own.
+func (i *Index) unloadShards(ctx context.Context, previous *sharding.State) error {
+	state := i.getSchema.ShardingState(i.Config.ClassName.String())
+
+	for name, shard := range i.localShards() {
+		if state.IsShardLocal(name) {
+			continue
+		}
+
+		i.shardsLock.Lock()
+		delete(i.Shards, name)
+		i.shardsLock.Unlock()
+
+		if err := shard.drop(true); err != nil {
+			return errors.Wrapf(err, "drop shard %s", shard.ID())
+		}
+	}
+
+	for name, shard := range i.localShards() {
+		physical, ok := previous.Physical[name]
+		if !ok || len(physical.OwnsVirtual) <= len(state.Physical[name].OwnsVirtual) {
+			continue
+		}
+
+		if _, err := shard.pruneObjects(ctx, state); err != nil {
+			return errors.Wrapf(err, "prune shard %s", shard.ID())
+		}
+	}
+
+	return nil
+}
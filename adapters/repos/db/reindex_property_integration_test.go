@@ -0,0 +1,176 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_ReindexProperty(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "ReindexPropertyArticles"
+	objectCount := 250
+	addedID := strfmt.UUID("5f6a7b8c-9d0e-4f1a-8b2c-3d4e5f6a7b05")
+
+	class := &models.Class{
+		Class:               className,
+		VectorIndexConfig:   hnsw.NewDefaultUserConfig(),
+		InvertedIndexConfig: invertedConfig(),
+		Properties: []*models.Property{
+			{
+				Name:         "title",
+				DataType:     []string{string(schema.DataTypeString)},
+				Tokenization: models.PropertyTokenizationField,
+			},
+		},
+	}
+	migrator := NewMigrator(repo, logger)
+
+	t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+
+	var ids []strfmt.UUID
+	t.Run("insert data", func(t *testing.T) {
+		var objs objects.BatchObjects
+		for i := 0; i < objectCount; i++ {
+			id := strfmt.UUID(uuid.New().String())
+			ids = append(ids, id)
+			objs = append(objs, objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          id,
+				Object: &models.Object{
+					ID:    id,
+					Class: className,
+					Properties: map[string]interface{}{
+						"title": fmt.Sprintf("quick brown fox %d", i),
+					},
+				},
+			})
+		}
+
+		res, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+		for _, r := range res {
+			require.Nil(t, r.Err)
+		}
+	})
+
+	titleFilter := func(value string) traverser.GetParams {
+		return traverser.GetParams{
+			ClassName:  className,
+			Pagination: &filters.Pagination{Limit: 1000},
+			Filters:    buildFilter("title", value, eq, schema.DataTypeString),
+		}
+	}
+
+	search := func(t *testing.T, value string) int {
+		res, err := repo.ClassSearch(context.Background(), titleFilter(value))
+		require.Nil(t, err)
+		return len(res)
+	}
+
+	reindexStatus := func(t *testing.T) map[string][]*models.PropertyReindexStatus {
+		status, err := migrator.GetShardsReindexStatus(context.Background(), className)
+		require.Nil(t, err)
+		return status
+	}
+
+	t.Run("the field tokenization matches whole values only", func(t *testing.T) {
+		assert.Equal(t, 0, search(t, "quick"))
+		assert.Equal(t, 1, search(t, "quick brown fox 7"))
+	})
+
+	previous := class.Properties[0]
+	next := &models.Property{
+		Name:         "title",
+		DataType:     []string{string(schema.DataTypeString)},
+		Tokenization: models.PropertyTokenizationWord,
+	}
+
+	t.Run("change the tokenization", func(t *testing.T) {
+		// the migrator runs before the schema manager stores the change
+		err := migrator.ReindexProperty(context.Background(), className, previous, next)
+		require.Nil(t, err)
+	})
+
+	t.Run("the reindex is reported until the schema is updated", func(t *testing.T) {
+		status := reindexStatus(t)
+		require.NotEmpty(t, status)
+		for shard, props := range status {
+			require.Len(t, props, 1, "shard %s", shard)
+			assert.Equal(t, "title", props[0].Property, "shard %s", shard)
+		}
+	})
+
+	t.Run("searches keep using the previous tokenization", func(t *testing.T) {
+		assert.Equal(t, 0, search(t, "quick"))
+		assert.Equal(t, 1, search(t, "quick brown fox 7"))
+	})
+
+	t.Run("write and delete objects during the reindex", func(t *testing.T) {
+		err := repo.PutObject(context.Background(), &models.Object{
+			ID:    addedID,
+			Class: className,
+			Properties: map[string]interface{}{
+				"title": "quick red fox",
+			},
+		}, []float32{1, 2, 3})
+		require.Nil(t, err)
+
+		require.Nil(t, repo.DeleteObject(context.Background(), className, ids[0]))
+
+		assert.Equal(t, 1, search(t, "quick red fox"))
+	})
+
+	t.Run("update the schema", func(t *testing.T) {
+		// the class is replaced rather than changed, as the shards read the
+		// schema concurrently
+		updated := *class
+		updated.Properties = []*models.Property{next}
+		repo.schemaGetter.(*fakeSchemaGetter).setSchema(schema.Schema{
+			Objects: &models.Schema{Classes: []*models.Class{&updated}},
+		})
+
+		assert.Eventually(t, func() bool {
+			return len(reindexStatus(t)) == 0
+		}, 10*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("searches use the new tokenization", func(t *testing.T) {
+		// all objects except for the deleted one plus the added one
+		assert.Equal(t, objectCount, search(t, "quick"))
+		assert.Equal(t, 1, search(t, "red"))
+		assert.Equal(t, objectCount-1, search(t, "brown"))
+		assert.Equal(t, 0, search(t, "quick brown fox 0"))
+		assert.Equal(t, 1, search(t, "quick brown fox 7"))
+	})
+}
@@ -70,6 +70,7 @@ type Config struct {
 	RootPath                  string
 	QueryLimit                int64
 	QueryMaximumResults       int64
+	HybridCandidates          int64
 	DiskUseWarningPercentage  uint64
 	DiskUseReadOnlyPercentage uint64
 	MaxImportGoroutinesFactor float64
@@ -62,14 +62,25 @@ func (db *DB) ClassSearch(ctx context.Context,
 		return nil, errors.Wrapf(err, "invalid pagination params")
 	}
 
-	res, err := idx.objectSearch(ctx, totalLimit, params.Filters,
-		params.KeywordRanking, params.Sort, params.AdditionalProperties)
-	if err != nil {
-		return nil, errors.Wrapf(err, "object search at index %s", idx.ID())
+	var res []*storobj.Object
+	var scores []float32
+	if params.HybridSearch != nil {
+		res, scores, err = idx.objectHybridSearch(ctx, params.HybridSearch,
+			totalLimit, params.AdditionalProperties)
+		if err != nil {
+			return nil, errors.Wrapf(err, "hybrid search at index %s", idx.ID())
+		}
+	} else {
+		res, scores, err = idx.objectSearch(ctx, totalLimit, params.Filters,
+			params.KeywordRanking, params.Sort, params.AdditionalProperties)
+		if err != nil {
+			return nil, errors.Wrapf(err, "object search at index %s", idx.ID())
+		}
 	}
 
 	return db.enrichRefsForList(ctx,
-		storobj.SearchResults(db.getStoreObjects(res, params.Pagination), params.AdditionalProperties),
+		storobj.SearchResultsWithScore(db.getStoreObjects(res, params.Pagination),
+			params.AdditionalProperties, db.getDists(scores, params.Pagination)),
 		params.Properties, params.AdditionalProperties)
 }
 
@@ -91,7 +102,7 @@ func (db *DB) VectorClassSearch(ctx context.Context,
 	}
 
 	targetDist := extractDistanceFromParams(params)
-	res, dists, err := idx.objectVectorSearch(ctx, params.SearchVector, targetDist,
+	res, dists, err := idx.objectVectorSearch(ctx, params.SearchVector, params.TargetVector, targetDist,
 		totalLimit, params.Filters, params.Sort, params.AdditionalProperties)
 	if err != nil {
 		return nil, errors.Wrapf(err, "object vector search at index %s", idx.ID())
@@ -132,7 +143,7 @@ func (db *DB) VectorSearch(ctx context.Context, vector []float32, offset, limit
 			defer wg.Done()
 
 			objs, dist, err := index.objectVectorSearch(
-				ctx, vector, 0, totalLimit, filters, nil, additional.Properties{})
+				ctx, vector, "", 0, totalLimit, filters, nil, additional.Properties{})
 			if err != nil {
 				mutex.Lock()
 				searchErrors = append(searchErrors, errors.Wrapf(err, "search index %s", index.ID()))
@@ -189,7 +200,7 @@ func (d *DB) Query(ctx context.Context, q *objects.QueryInput) (search.Results,
 	if idx == nil {
 		return nil, &objects.Error{Msg: "class not found " + q.Class, Code: objects.StatusNotFound}
 	}
-	res, err := idx.objectSearch(ctx, totalLimit, q.Filters, nil, q.Sort, q.Additional)
+	res, _, err := idx.objectSearch(ctx, totalLimit, q.Filters, nil, q.Sort, q.Additional)
 	if err != nil {
 		return nil, &objects.Error{Msg: "search index " + idx.ID(), Code: objects.StatusInternalServerError, Err: err}
 	}
@@ -219,7 +230,7 @@ func (d *DB) objectSearch(ctx context.Context, offset, limit int,
 	// painfully slow on large schemas
 	for _, index := range d.indices {
 		// TODO support all additional props
-		res, err := index.objectSearch(ctx, totalLimit, filters, nil, sort, additional)
+		res, _, err := index.objectSearch(ctx, totalLimit, filters, nil, sort, additional)
 		if err != nil {
 			return nil, errors.Wrapf(err, "search index %s", index.ID())
 		}
@@ -28,6 +28,7 @@ import (
 	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/propertyspecific"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/noop"
@@ -49,6 +50,7 @@ type Shard struct {
 	store            *lsmkv.Store
 	counter          *indexcounter.Counter
 	vectorIndex      VectorIndex
+	vectorIndexes    map[string]VectorIndex // of the named vectors, by name
 	invertedRowCache *inverted.RowCacher
 	metrics          *Metrics
 	promMetrics      *monitoring.PrometheusMetrics
@@ -61,6 +63,30 @@ type Shard struct {
 	versioner        *shardVersioner
 	diskScanState    *diskScanState
 
+	// writers of the objects bucket hold the read lock, so that the background
+	// rewrite of the objects after a property was dropped, which holds the
+	// write lock, never overwrites a concurrent change
+	objectRewriteLock sync.RWMutex
+	// held for reading while the property-specific indices are updated, so a
+	// property-specific index is never dropped while it is in use
+	propertyIndicesLock   sync.RWMutex
+	propertyCleanupLock   sync.Mutex
+	propertyCleanupCtx    context.Context
+	propertyCleanupCancel context.CancelFunc
+	propertyCleanupWg     sync.WaitGroup
+
+	// the props whose inverted index is being rebuilt, by name
+	reindexing  map[string]*propertyReindex
+	reindexLock sync.Mutex
+	reindexWg   sync.WaitGroup
+	// searches hold the read lock, so that the buckets of a reindexed prop
+	// are never replaced while they are in use
+	invertedSwapLock sync.RWMutex
+
+	// the background indexing of the positions of existing objects
+	positionsBackfill     *positionsBackfill
+	positionsBackfillLock sync.Mutex
+
 	numActiveBatches    int
 	activeBatchesLock   sync.Mutex
 	jobQueueCh          chan job
@@ -104,80 +130,35 @@ func NewShard(ctx context.Context, promMetrics *monitoring.PrometheusMetrics,
 		cancel:              make(chan struct{}, 1),
 		randomSource:        rand,
 		diskScanState:       newDiskScanState(),
+		reindexing:          map[string]*propertyReindex{},
 		jobQueueCh:          make(chan job, 100000),
 		maxNumberGoroutines: int(math.Round(index.Config.MaxImportGoroutinesFactor * float64(runtime.GOMAXPROCS(0)))),
 	}
 	if s.maxNumberGoroutines == 0 {
 		return s, errors.New("no workers to add batch-jobs configured.")
 	}
+	s.propertyCleanupCtx, s.propertyCleanupCancel = context.WithCancel(context.Background())
 
 	defer s.metrics.ShardStartup(before)
 
-	hnswUserConfig, ok := index.vectorIndexUserConfig.(hnsw.UserConfig)
-	if !ok {
-		return nil, errors.Errorf("hnsw vector index: config is not hnsw.UserConfig: %T",
-			index.vectorIndexUserConfig)
+	err = s.initDBFile(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "init shard %q: shard db", s.ID())
 	}
 
-	if hnswUserConfig.Skip {
-		s.vectorIndex = noop.NewIndex()
-	} else {
-		var distProv distancer.Provider
-
-		switch hnswUserConfig.Distance {
-		case "", hnsw.DistanceCosine:
-			distProv = distancer.NewCosineDistanceProvider()
-		case hnsw.DistanceDot:
-			distProv = distancer.NewDotProductProvider()
-		case hnsw.DistanceL2Squared:
-			distProv = distancer.NewL2SquaredProvider()
-		case hnsw.DistanceManhattan:
-			distProv = distancer.NewManhattanProvider()
-		case hnsw.DistanceHamming:
-			distProv = distancer.NewHammingProvider()
-		default:
-			return nil, errors.Errorf("unrecognized distance metric %q,"+
-				"choose one of [\"cosine\", \"dot\", \"l2-squared\", \"manhattan\",\"hamming\"]", hnswUserConfig.Distance)
-		}
-
-		vi, err := hnsw.New(hnsw.Config{
-			Logger:            index.logger,
-			RootPath:          s.index.Config.RootPath,
-			ID:                s.ID(),
-			ShardName:         s.name,
-			ClassName:         s.index.Config.ClassName.String(),
-			PrometheusMetrics: s.promMetrics,
-			MakeCommitLoggerThunk: func() (hnsw.CommitLogger, error) {
-				// Previously we had an interval of 10s in here, which was changed to
-				// 0.5s as part of gh-1867. There's really no way to wait so long in
-				// between checks: If you are running on a low-powered machine, the
-				// interval will simply find that there is no work and do nothing in
-				// each iteration. However, if you are running on a very powerful
-				// machine within 10s you could have potentially created two units of
-				// work, but we'll only be handling one every 10s. This means
-				// uncombined/uncondensed hnsw commit logs will keep piling up can only
-				// be processes long after the initial insert is complete. This also
-				// means that if there is a crash during importing a lot of work needs
-				// to be done at startup, since the commit logs still contain too many
-				// redundancies. So as of now it seems there are only advantages to
-				// running the cleanup checks and work much more often.
-				return hnsw.NewCommitLogger(s.index.Config.RootPath, s.ID(), 500*time.Millisecond,
-					index.logger)
-			},
-			VectorForIDThunk: s.vectorByIndexID,
-			DistanceProvider: distProv,
-		}, hnswUserConfig)
-		if err != nil {
-			return nil, errors.Wrapf(err, "init shard %q: hnsw index", s.ID())
-		}
-		s.vectorIndex = vi
-
-		defer vi.PostStartup()
+	vi, postStartup, err := s.initVectorIndex(ctx, "", index.vectorIndexUserConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "init shard %q", s.ID())
 	}
+	s.vectorIndex = vi
+	defer postStartup()
 
-	err = s.initDBFile(ctx)
+	namedPostStartup, err := s.initNamedVectorIndexes(ctx)
 	if err != nil {
-		return nil, errors.Wrapf(err, "init shard %q: shard db", s.ID())
+		return nil, errors.Wrapf(err, "init shard %q", s.ID())
+	}
+	for _, post := range namedPostStartup {
+		defer post()
 	}
 
 	counter, err := indexcounter.New(s.ID(), index.Config.RootPath)
@@ -205,9 +186,95 @@ func NewShard(ctx context.Context, promMetrics *monitoring.PrometheusMetrics,
 		return nil, errors.Wrapf(err, "init shard %q: init per property indices", s.ID())
 	}
 
+	if err := s.rewriteRenamedClass(ctx); err != nil {
+		return nil, errors.Wrapf(err, "init shard %q: rewrite class of restored objects", s.ID())
+	}
+
 	return s, nil
 }
 
+// initVectorIndex creates the vector index of the named vector, or the one of
+// the vector of the object if no target vector is set. The returned func has
+// to be called once the shard is ready.
+func (s *Shard) initVectorIndex(ctx context.Context, targetVector string,
+	vectorIndexUserConfig schema.VectorIndexConfig,
+) (VectorIndex, func(), error) {
+	id, bucket := s.vectorIndexID(targetVector), vectorsBucket(targetVector)
+	vectorForID := s.vectorByIndexID
+	if targetVector != "" {
+		vectorForID = s.namedVectorByIndexID(targetVector)
+	}
+
+	switch userConfig := vectorIndexUserConfig.(type) {
+	case hnsw.UserConfig:
+		if userConfig.Skip {
+			return noop.NewIndex(), noopPostStartup, nil
+		} else {
+			distProv, err := distancerProvider(userConfig.Distance)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "hnsw index")
+			}
+
+			vi, err := hnsw.New(hnsw.Config{
+				Logger:            s.index.logger,
+				RootPath:          s.index.Config.RootPath,
+				ID:                id,
+				ShardName:         s.name,
+				ClassName:         s.index.Config.ClassName.String(),
+				PrometheusMetrics: s.promMetrics,
+				MakeCommitLoggerThunk: func() (hnsw.CommitLogger, error) {
+					// Previously we had an interval of 10s in here, which was changed to
+					// 0.5s as part of gh-1867. There's really no way to wait so long in
+					// between checks: If you are running on a low-powered machine, the
+					// interval will simply find that there is no work and do nothing in
+					// each iteration. However, if you are running on a very powerful
+					// machine within 10s you could have potentially created two units of
+					// work, but we'll only be handling one every 10s. This means
+					// uncombined/uncondensed hnsw commit logs will keep piling up can only
+					// be processes long after the initial insert is complete. This also
+					// means that if there is a crash during importing a lot of work needs
+					// to be done at startup, since the commit logs still contain too many
+					// redundancies. So as of now it seems there are only advantages to
+					// running the cleanup checks and work much more often.
+					return hnsw.NewCommitLogger(s.index.Config.RootPath, id, 500*time.Millisecond,
+						s.index.logger)
+				},
+				VectorForIDThunk: vectorForID,
+				DistanceProvider: distProv,
+			}, userConfig)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "hnsw index")
+			}
+			return vi, vi.PostStartup, nil
+		}
+	case flat.UserConfig:
+		distProv, err := distancerProvider(userConfig.Distance)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "flat index")
+		}
+
+		if err := s.store.CreateOrLoadBucket(ctx, bucket,
+			lsmkv.WithStrategy(lsmkv.StrategyReplace)); err != nil {
+			return nil, nil, errors.Wrap(err, "flat index: create vectors bucket")
+		}
+
+		vi, err := flat.New(flat.Config{
+			ID:               id,
+			DistanceProvider: distProv,
+			Bucket:           s.store.Bucket(bucket),
+		})
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "flat index")
+		}
+		return vi, noopPostStartup, nil
+	default:
+		return nil, nil, errors.Errorf("vector index: unsupported config type %T",
+			vectorIndexUserConfig)
+	}
+}
+
+func noopPostStartup() {}
+
 func (s *Shard) ID() string {
 	return fmt.Sprintf("%s_%s", s.index.ID(), s.name)
 }
@@ -252,6 +319,9 @@ func (s *Shard) drop(force bool) error {
 	}
 
 	s.cancel <- struct{}{}
+	s.stopPropertyCleanup()
+	s.stopPropertyReindexes()
+	s.stopPositionsBackfill()
 
 	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
 	defer cancel()
@@ -277,8 +347,10 @@ func (s *Shard) drop(force bool) error {
 	if err != nil {
 		return errors.Wrapf(err, "remove indexcount at %s", s.DBPathLSM())
 	}
-	// remove vector index
-	err = s.vectorIndex.Drop(ctx)
+	// remove vector indexes
+	err = s.forEachVectorIndex(func(_ string, index VectorIndex) error {
+		return index.Drop(ctx)
+	})
 	if err != nil {
 		return errors.Wrapf(err, "remove vector index at %s", s.DBPathLSM())
 	}
@@ -376,6 +448,27 @@ func (s *Shard) addProperty(ctx context.Context, prop *models.Property) error {
 		return storagestate.ErrStatusReadOnly
 	}
 
+	// a prop with the same name might have been dropped before, its values
+	// need to be gone before new ones are written
+	if err := s.finishPropertyCleanup(ctx, prop.Name); err != nil {
+		return err
+	}
+
+	if schema.IsNestedDataType(prop.DataType) {
+		// served by the buckets of the leaf props, named after their paths
+		for _, leaf := range schema.FlattenNestedProperties(prop) {
+			if leaf.IndexInverted != nil && !*leaf.IndexInverted {
+				continue
+			}
+
+			if err := s.addProperty(ctx, leaf); err != nil {
+				return errors.Wrapf(err, "init nested property %s", leaf.Name)
+			}
+		}
+
+		return nil
+	}
+
 	if schema.IsRefDataType(prop.DataType) {
 		err := s.store.CreateOrLoadBucket(ctx,
 			helpers.BucketFromPropNameLSM(helpers.MetaCountProp(prop.Name)),
@@ -396,18 +489,8 @@ func (s *Shard) addProperty(ctx context.Context, prop *models.Property) error {
 		return s.initGeoProp(prop)
 	}
 
-	var mapOpts []lsmkv.BucketOption
-	if inverted.HasFrequency(schema.DataType(prop.DataType[0])) {
-		mapOpts = append(mapOpts, lsmkv.WithStrategy(lsmkv.StrategyMapCollection))
-		if s.versioner.Version() < 2 {
-			mapOpts = append(mapOpts, lsmkv.WithLegacyMapSorting())
-		}
-	} else {
-		mapOpts = append(mapOpts, lsmkv.WithStrategy(lsmkv.StrategySetCollection))
-	}
-
 	err := s.store.CreateOrLoadBucket(ctx, helpers.BucketFromPropNameLSM(prop.Name),
-		mapOpts...)
+		s.propertyBucketOptions(prop)...)
 	if err != nil {
 		return err
 	}
@@ -418,9 +501,34 @@ func (s *Shard) addProperty(ctx context.Context, prop *models.Property) error {
 		return err
 	}
 
+	if s.index.getInvertedIndexConfig().IndexPositions &&
+		inverted.HasPositions(schema.DataType(prop.DataType[0])) {
+		err = s.store.CreateOrLoadBucket(ctx, helpers.PositionsBucketFromPropNameLSM(prop.Name),
+			lsmkv.WithStrategy(lsmkv.StrategyMapCollection))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// propertyBucketOptions are the options of the inverted index bucket of a
+// prop, which is a map if the prop has frequencies and a set otherwise
+func (s *Shard) propertyBucketOptions(prop *models.Property) []lsmkv.BucketOption {
+	var mapOpts []lsmkv.BucketOption
+	if inverted.HasFrequency(schema.DataType(prop.DataType[0])) {
+		mapOpts = append(mapOpts, lsmkv.WithStrategy(lsmkv.StrategyMapCollection))
+		if s.versioner.Version() < 2 {
+			mapOpts = append(mapOpts, lsmkv.WithLegacyMapSorting())
+		}
+	} else {
+		mapOpts = append(mapOpts, lsmkv.WithStrategy(lsmkv.StrategySetCollection))
+	}
+
+	return mapOpts
+}
+
 func (s *Shard) updateVectorIndexConfig(ctx context.Context,
 	updated schema.VectorIndexConfig,
 ) error {
@@ -431,8 +539,32 @@ func (s *Shard) updateVectorIndexConfig(ctx context.Context,
 	return s.vectorIndex.UpdateUserConfig(updated)
 }
 
+func (s *Shard) updateVectorIndexConfigs(ctx context.Context,
+	updated map[string]schema.VectorIndexConfig,
+) error {
+	if s.isReadOnly() {
+		return storagestate.ErrStatusReadOnly
+	}
+
+	for targetVector, cfg := range updated {
+		index, err := s.getVectorIndex(targetVector)
+		if err != nil {
+			return err
+		}
+
+		if err := index.UpdateUserConfig(cfg); err != nil {
+			return errors.Wrapf(err, "named vector %q", targetVector)
+		}
+	}
+
+	return nil
+}
+
 func (s *Shard) shutdown(ctx context.Context) error {
 	s.cancel <- struct{}{}
+	s.stopPropertyCleanup()
+	s.stopPropertyReindexes()
+	s.stopPositionsBackfill()
 
 	if err := s.propLengths.Close(); err != nil {
 		return errors.Wrap(err, "close prop length tracker")
@@ -443,11 +575,14 @@ func (s *Shard) shutdown(ctx context.Context) error {
 	// 'RemoveTombstone' entry is not picked up on restarts
 	// resulting in perpetually attempting to remove a tombstone
 	// which doesn't actually exist anymore
-	if err := s.vectorIndex.Flush(); err != nil {
+	if err := s.flushVectorIndexes(); err != nil {
 		return errors.Wrap(err, "flush vector index commitlog")
 	}
 
-	if err := s.vectorIndex.Shutdown(ctx); err != nil {
+	err := s.forEachVectorIndex(func(_ string, index VectorIndex) error {
+		return index.Shutdown(ctx)
+	})
+	if err != nil {
 		return errors.Wrap(err, "shut down vector index")
 	}
 
@@ -460,3 +595,21 @@ func (s *Shard) notifyReady() {
 		WithField("action", "startup").
 		Debugf("shard=%s is ready", s.name)
 }
+
+func distancerProvider(distance string) (distancer.Provider, error) {
+	switch distance {
+	case "", hnsw.DistanceCosine:
+		return distancer.NewCosineDistanceProvider(), nil
+	case hnsw.DistanceDot:
+		return distancer.NewDotProductProvider(), nil
+	case hnsw.DistanceL2Squared:
+		return distancer.NewL2SquaredProvider(), nil
+	case hnsw.DistanceManhattan:
+		return distancer.NewManhattanProvider(), nil
+	case hnsw.DistanceHamming:
+		return distancer.NewHammingProvider(), nil
+	default:
+		return nil, errors.Errorf("unrecognized distance metric %q,"+
+			"choose one of [\"cosine\", \"dot\", \"l2-squared\", \"manhattan\",\"hamming\"]", distance)
+	}
+}
@@ -21,8 +21,16 @@ import (
 func (s *Shard) aggregate(ctx context.Context,
 	params aggregation.Params,
 ) (*aggregation.Result, error) {
-	return aggregator.New(s.store, params, s.index.getSchema, s.invertedRowCache,
+	vectorIndex, err := s.getVectorIndex(params.TargetVector)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invertedSwapLock.RLock()
+	defer s.invertedSwapLock.RUnlock()
+
+	return aggregator.New(s.store, params, searchSchemaGetter{s.index.getSchema, s}, s.invertedRowCache,
 		s.index.classSearcher, s.deletedDocIDs, s.index.stopwords, s.versioner.Version(),
-		s.vectorIndex).
+		vectorIndex).
 		Do(ctx)
 }
@@ -0,0 +1,396 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/propertyspecific"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/storagestate"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+// the property cleanup bucket holds the names of the dropped props whose
+// values still need to be stripped from the stored objects
+const propertyCleanupBucket = "property_cleanup"
+
+// the objects are rewritten in batches, so concurrent writes are only
+// blocked for a short time
+const propertyCleanupBatchSize = 100
+
+// dropProperty removes all indexes of a property, i.e. the inverted index
+// buckets including the positions and the meta count of ref props, the
+// tracked property lengths and the property-specific index. The values of the
+// property are stripped from the stored objects in the background.
+func (s *Shard) dropProperty(ctx context.Context, propName string) error {
+	if s.isReadOnly() {
+		return storagestate.ErrStatusReadOnly
+	}
+
+	if err := s.abortPropertyReindex(ctx, propName); err != nil {
+		return err
+	}
+
+	nestedPropNames, err := s.nestedPropertyNames(propName)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range append([]string{propName}, nestedPropNames...) {
+		if err := s.dropPropertyBuckets(ctx, name); err != nil {
+			return err
+		}
+
+		s.propLengths.ResetProperty(name)
+	}
+	if err := s.propLengths.Flush(); err != nil {
+		return errors.Wrap(err, "flush prop length tracker to disk")
+	}
+
+	if err := s.dropPropertySpecificIndex(ctx, propName); err != nil {
+		return err
+	}
+
+	err = s.store.CreateOrLoadBucket(ctx, propertyCleanupBucket,
+		lsmkv.WithStrategy(lsmkv.StrategyReplace))
+	if err != nil {
+		return errors.Wrap(err, "init property cleanup")
+	}
+
+	err = s.store.Bucket(propertyCleanupBucket).Put([]byte(propName), []byte{1})
+	if err != nil {
+		return errors.Wrapf(err, "schedule cleanup of property %s", propName)
+	}
+
+	s.startPropertyCleanup()
+	return nil
+}
+
+// nestedPropertyNames are the paths of the leaf props of an object or
+// object[] prop which have an inverted index. The prop might not be part of
+// the schema anymore, so they are taken from the buckets on disk.
+func (s *Shard) nestedPropertyNames(propName string) ([]string, error) {
+	entries, err := os.ReadDir(s.DBPathLSM())
+	if err != nil {
+		return nil, errors.Wrap(err, "list buckets")
+	}
+
+	prefix := helpers.BucketFromPropNameLSM(propName + schema.NestedPropertySeparator)
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), prefix) {
+			names = append(names, strings.TrimPrefix(entry.Name(),
+				helpers.BucketFromPropNameLSM("")))
+		}
+	}
+
+	return names, nil
+}
+
+// dropPropertyBuckets drops the inverted index buckets of the prop once no
+// search is using them anymore
+func (s *Shard) dropPropertyBuckets(ctx context.Context, propName string) error {
+	if err := s.lockInvertedSwap(ctx); err != nil {
+		return err
+	}
+	defer s.invertedSwapLock.Unlock()
+
+	bucketNames := []string{
+		helpers.BucketFromPropNameLSM(propName),
+		helpers.HashBucketFromPropNameLSM(propName),
+		helpers.PositionsBucketFromPropNameLSM(propName),
+		helpers.BucketFromPropNameLSM(helpers.MetaCountProp(propName)),
+		helpers.HashBucketFromPropNameLSM(helpers.MetaCountProp(propName)),
+	}
+	for _, name := range bucketNames {
+		if err := s.store.DropBucket(ctx, name); err != nil {
+			return errors.Wrapf(err, "drop bucket %s", name)
+		}
+	}
+
+	return nil
+}
+
+// dropPropertySpecificIndex replaces the property-specific indices with a
+// copy without the prop, so concurrent readers of the previous map are not
+// affected. Writers hold the read lock while updating the indices, so the
+// dropped index is no longer in use once the lock is acquired.
+func (s *Shard) dropPropertySpecificIndex(ctx context.Context, propName string) error {
+	s.propertyIndicesLock.Lock()
+	defer s.propertyIndicesLock.Unlock()
+
+	if _, ok := s.propertyIndices.ByProp(propName); !ok {
+		return nil
+	}
+
+	indices := make(propertyspecific.Indices, len(s.propertyIndices))
+	for name, index := range s.propertyIndices {
+		indices[name] = index
+	}
+
+	if err := indices.Drop(ctx, propName); err != nil {
+		return errors.Wrap(err, "drop property-specific index")
+	}
+
+	s.propertyIndices = indices
+	return nil
+}
+
+// initPropertyCleanup resumes the cleanups which did not finish before the
+// shard was shut down. If a prop was added again in the meantime, its
+// cleanup is finished before the new prop is initialized. The cleanup bucket
+// only exists if a prop was ever dropped, so it is not created otherwise.
+func (s *Shard) initPropertyCleanup(ctx context.Context) error {
+	if _, err := os.Stat(path.Join(s.DBPathLSM(), propertyCleanupBucket)); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "check property cleanup")
+	}
+
+	err := s.store.CreateOrLoadBucket(ctx, propertyCleanupBucket,
+		lsmkv.WithStrategy(lsmkv.StrategyReplace))
+	if err != nil {
+		return errors.Wrap(err, "init property cleanup")
+	}
+
+	pending, err := s.pendingPropertyCleanups()
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	sch := s.index.getSchema.GetSchemaSkipAuth()
+	if class := sch.FindClassByName(s.index.Config.ClassName); class != nil {
+		for _, prop := range class.Properties {
+			if err := s.finishPropertyCleanup(ctx, prop.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.startPropertyCleanup()
+	return nil
+}
+
+// startPropertyCleanup strips the values of all dropped props from the
+// stored objects in the background. It stops when the shard is shut down or
+// dropped, any remaining work is resumed on the next startup.
+func (s *Shard) startPropertyCleanup() {
+	s.propertyCleanupWg.Add(1)
+	go func() {
+		defer s.propertyCleanupWg.Done()
+
+		if err := s.runPropertyCleanups(s.propertyCleanupCtx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+
+			s.index.logger.WithField("action", "property_cleanup").
+				WithField("shard", s.name).
+				WithError(err).
+				Error("strip dropped properties from objects")
+		}
+	}()
+}
+
+func (s *Shard) stopPropertyCleanup() {
+	s.propertyCleanupCancel()
+	s.propertyCleanupWg.Wait()
+}
+
+func (s *Shard) runPropertyCleanups(ctx context.Context) error {
+	s.propertyCleanupLock.Lock()
+	defer s.propertyCleanupLock.Unlock()
+
+	pending, err := s.pendingPropertyCleanups()
+	if err != nil {
+		return err
+	}
+
+	for _, propName := range pending {
+		if err := s.cleanupProperty(ctx, propName); err != nil {
+			return errors.Wrapf(err, "property %s", propName)
+		}
+	}
+
+	return nil
+}
+
+// finishPropertyCleanup strips the values of a dropped prop synchronously,
+// it is a no-op if there is no pending cleanup for the prop.
+func (s *Shard) finishPropertyCleanup(ctx context.Context, propName string) error {
+	bucket := s.store.Bucket(propertyCleanupBucket)
+	if bucket == nil {
+		return nil
+	}
+
+	s.propertyCleanupLock.Lock()
+	defer s.propertyCleanupLock.Unlock()
+
+	pending, err := bucket.Get([]byte(propName))
+	if err != nil {
+		return errors.Wrapf(err, "read cleanup state of property %s", propName)
+	}
+	if pending == nil {
+		return nil
+	}
+
+	if err := s.cleanupProperty(ctx, propName); err != nil {
+		return errors.Wrapf(err, "finish cleanup of dropped property %s", propName)
+	}
+
+	return nil
+}
+
+func (s *Shard) pendingPropertyCleanups() ([]string, error) {
+	bucket := s.store.Bucket(propertyCleanupBucket)
+	if bucket == nil {
+		return nil, nil
+	}
+
+	var pending []string
+	cursor := bucket.Cursor()
+	defer cursor.Close()
+
+	for k, _ := cursor.First(); k != nil; k, _ = cursor.Next() {
+		pending = append(pending, string(k))
+	}
+
+	return pending, nil
+}
+
+// cleanupProperty strips the values of the prop from all stored objects and
+// removes the pending cleanup. The objects keep their doc ids and are not
+// re-indexed, as the indexes of the prop are already gone.
+func (s *Shard) cleanupProperty(ctx context.Context, propName string) error {
+	keys, err := s.objectKeysWithProperty(ctx, propName)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(keys); start += propertyCleanupBatchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		end := start + propertyCleanupBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		if err := s.stripPropertyFromObjects(keys[start:end], propName); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.WriteWALs(); err != nil {
+		return errors.Wrap(err, "flush all buffered WALs")
+	}
+
+	if len(keys) > 0 {
+		s.index.logger.WithField("action", "property_cleanup").
+			WithField("shard", s.name).
+			WithField("property", propName).
+			WithField("count", len(keys)).
+			Infof("stripped dropped property %s from %d objects", propName, len(keys))
+	}
+
+	return s.store.Bucket(propertyCleanupBucket).Delete([]byte(propName))
+}
+
+func (s *Shard) objectKeysWithProperty(ctx context.Context,
+	propName string,
+) ([][]byte, error) {
+	var keys [][]byte
+	cursor := s.store.Bucket(helpers.ObjectsBucketLSM).Cursor()
+	defer cursor.Close()
+
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		obj, err := storobj.FromBinary(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unmarshal object %x", k)
+		}
+
+		if _, ok := objectProperty(obj, propName); ok {
+			key := make([]byte, len(k))
+			copy(key, k)
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+// stripPropertyFromObjects holds the write lock of the objects bucket, so
+// the objects cannot be changed between reading and rewriting them
+func (s *Shard) stripPropertyFromObjects(keys [][]byte, propName string) error {
+	s.objectRewriteLock.Lock()
+	defer s.objectRewriteLock.Unlock()
+
+	bucket := s.store.Bucket(helpers.ObjectsBucketLSM)
+	for _, key := range keys {
+		data, err := bucket.Get(key)
+		if err != nil {
+			return errors.Wrapf(err, "get object %x", key)
+		}
+		if data == nil {
+			// deleted in the meantime
+			continue
+		}
+
+		obj, err := storobj.FromBinary(data)
+		if err != nil {
+			return errors.Wrapf(err, "unmarshal object %x", key)
+		}
+
+		if _, ok := objectProperty(obj, propName); !ok {
+			continue
+		}
+		delete(obj.Properties().(map[string]interface{}), propName)
+
+		data, err = obj.MarshalBinary()
+		if err != nil {
+			return errors.Wrapf(err, "marshal object %s to binary", obj.ID())
+		}
+
+		if err := s.upsertObjectDataLSM(bucket, key, data, obj.DocID()); err != nil {
+			return errors.Wrapf(err, "upsert object %s", obj.ID())
+		}
+	}
+
+	return nil
+}
+
+func objectProperty(obj *storobj.Object, propName string) (interface{}, bool) {
+	props, ok := obj.Properties().(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := props[propName]
+	return value, ok
+}
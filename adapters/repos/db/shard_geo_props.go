@@ -86,6 +86,9 @@ func (s *Shard) updatePropertySpecificIndices(object *storobj.Object,
 		return storagestate.ErrStatusReadOnly
 	}
 
+	s.propertyIndicesLock.RLock()
+	defer s.propertyIndicesLock.RUnlock()
+
 	for propName, propIndex := range s.propertyIndices {
 		if err := s.updatePropertySpecificIndex(propName, propIndex,
 			object, status); err != nil {
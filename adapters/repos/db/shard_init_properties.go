@@ -23,6 +23,13 @@ import (
 
 func (s *Shard) initProperties() error {
 	s.propertyIndices = propertyspecific.Indices{}
+	if err := s.initPropertyCleanup(context.TODO()); err != nil {
+		return errors.Wrap(err, "init property cleanup")
+	}
+	if err := s.initPropertyReindex(context.TODO()); err != nil {
+		return errors.Wrap(err, "init property reindex")
+	}
+
 	sch := s.index.getSchema.GetSchemaSkipAuth()
 	c := sch.FindClassByName(s.index.Config.ClassName)
 	if c == nil {
@@ -30,7 +37,9 @@ func (s *Shard) initProperties() error {
 	}
 
 	eg := &errgroup.Group{}
-	for _, prop := range c.Properties {
+	// props which are being reindexed are loaded in their previous version,
+	// until the reindex is resumed below
+	for _, prop := range s.withPreviousProperties(c.Properties) {
 		if prop.IndexInverted != nil && !*prop.IndexInverted {
 			continue
 		}
@@ -76,5 +85,17 @@ func (s *Shard) initProperties() error {
 		})
 	}
 
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if err := s.initPositions(context.TODO()); err != nil {
+		return errors.Wrap(err, "init positions")
+	}
+
+	if err := s.resumePropertyReindexes(context.TODO()); err != nil {
+		return errors.Wrap(err, "resume property reindexes")
+	}
+
+	return nil
 }
@@ -0,0 +1,274 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/storagestate"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+// positionsBackfill indexes the positions of the objects which were imported
+// before positions were enabled. Writes index the positions of the objects
+// themselves from the start of the backfill on.
+type positionsBackfill struct {
+	// objects with a doc id from here on are indexed by the writes themselves
+	startDocID uint64
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// initPositions indexes the positions of the terms of all text props if
+// enabled in the inverted index config. Objects imported before positions
+// were enabled are indexed once in the background, the marker in the state
+// bucket is set when complete. Phrase queries are rejected until then, see
+// inverted.requirePositionsIndexed. If positions are disabled the marker is
+// removed, as the positions of objects imported in the meantime will be
+// missing once positions are enabled again.
+func (s *Shard) initPositions(ctx context.Context) error {
+	if !s.index.getInvertedIndexConfig().IndexPositions {
+		s.stopPositionsBackfill()
+		return s.resetPositionsState(ctx)
+	}
+
+	if s.isReadOnly() {
+		return storagestate.ErrStatusReadOnly
+	}
+
+	err := s.store.CreateOrLoadBucket(ctx, helpers.PositionsStateBucketLSM,
+		lsmkv.WithStrategy(lsmkv.StrategyReplace))
+	if err != nil {
+		return errors.Wrap(err, "init positions state")
+	}
+	state := s.store.Bucket(helpers.PositionsStateBucketLSM)
+
+	indexed, err := state.Get(helpers.PositionsIndexedKey)
+	if err != nil {
+		return errors.Wrap(err, "read positions state")
+	}
+	if indexed != nil {
+		return nil
+	}
+
+	sch := s.index.getSchema.GetSchemaSkipAuth()
+	class := sch.FindClassByName(s.index.Config.ClassName)
+	if class == nil {
+		return nil
+	}
+
+	for _, prop := range class.Properties {
+		if prop.IndexInverted != nil && !*prop.IndexInverted {
+			continue
+		}
+
+		if !inverted.HasPositions(schema.DataType(prop.DataType[0])) {
+			continue
+		}
+
+		if err := s.addProperty(ctx, prop); err != nil {
+			return errors.Wrapf(err, "init positions of property %s", prop.Name)
+		}
+	}
+
+	return s.startPositionsBackfill(state)
+}
+
+// startPositionsBackfill indexes the positions of the existing objects in
+// the background. A shard without objects has nothing to index, so phrase
+// queries can be used right away.
+func (s *Shard) startPositionsBackfill(state *lsmkv.Bucket) error {
+	s.positionsBackfillLock.Lock()
+	running := s.positionsBackfill != nil
+	s.positionsBackfillLock.Unlock()
+	if running {
+		return nil
+	}
+
+	cursor := s.store.Bucket(helpers.ObjectsBucketLSM).Cursor()
+	first, _ := cursor.First()
+	cursor.Close()
+	if first == nil {
+		return state.Put(helpers.PositionsIndexedKey, []byte{1})
+	}
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job := &positionsBackfill{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	// no object can be written while the job is registered, every object has
+	// either been written before or is written with the positions bucket
+	// in place
+	s.objectRewriteLock.Lock()
+	job.startDocID = s.counter.PreviewNext()
+	s.positionsBackfillLock.Lock()
+	s.positionsBackfill = job
+	s.positionsBackfillLock.Unlock()
+	s.objectRewriteLock.Unlock()
+
+	go func() {
+		defer close(job.done)
+		defer func() {
+			s.positionsBackfillLock.Lock()
+			if s.positionsBackfill == job {
+				s.positionsBackfill = nil
+			}
+			s.positionsBackfillLock.Unlock()
+		}()
+
+		if err := s.runPositionsBackfill(jobCtx, job, state); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+
+			s.index.logger.WithField("action", "index_positions").
+				WithField("shard", s.name).
+				WithError(err).
+				Error("index positions of existing objects")
+		}
+	}()
+
+	return nil
+}
+
+// stopPositionsBackfill stops the running backfill, it starts over on the
+// next startup or once positions are enabled again
+func (s *Shard) stopPositionsBackfill() {
+	s.positionsBackfillLock.Lock()
+	job := s.positionsBackfill
+	s.positionsBackfillLock.Unlock()
+	if job == nil {
+		return
+	}
+
+	job.cancel()
+	<-job.done
+}
+
+func (s *Shard) runPositionsBackfill(ctx context.Context, job *positionsBackfill,
+	state *lsmkv.Bucket,
+) error {
+	keys, err := s.objectKeysBefore(ctx, job.startDocID)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(keys); start += propertyReindexBatchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		end := start + propertyReindexBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		if err := s.indexExistingPositions(keys[start:end], job.startDocID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.WriteWALs(); err != nil {
+		return errors.Wrap(err, "flush all buffered WALs")
+	}
+
+	if err := state.Put(helpers.PositionsIndexedKey, []byte{1}); err != nil {
+		return errors.Wrap(err, "mark positions as indexed")
+	}
+
+	s.index.logger.WithField("action", "index_positions").
+		WithField("shard", s.name).
+		WithField("count", len(keys)).
+		Infof("indexed the positions of %d existing objects", len(keys))
+
+	return nil
+}
+
+// indexExistingPositions adds the positions of the objects which were
+// present before the backfill started. It holds the write lock of the
+// objects bucket, so that the objects cannot be changed while they are
+// indexed.
+func (s *Shard) indexExistingPositions(keys [][]byte, startDocID uint64) error {
+	s.objectRewriteLock.Lock()
+	defer s.objectRewriteLock.Unlock()
+
+	bucket := s.store.Bucket(helpers.ObjectsBucketLSM)
+	for _, key := range keys {
+		data, err := bucket.Get(key)
+		if err != nil {
+			return errors.Wrapf(err, "get object %x", key)
+		}
+		if data == nil {
+			// deleted in the meantime
+			continue
+		}
+
+		obj, err := storobj.FromBinary(data)
+		if err != nil {
+			return errors.Wrapf(err, "unmarshal object %x", key)
+		}
+
+		if obj.DocID() >= startDocID {
+			// updated in the meantime, which indexed the positions already
+			continue
+		}
+
+		props, err := s.analyzeObject(obj)
+		if err != nil {
+			return errors.Wrapf(err, "analyze object %s", obj.ID())
+		}
+
+		for _, prop := range props {
+			if !prop.HasFrequency {
+				continue
+			}
+
+			if err := s.extendPositionsLSM(prop, obj.DocID()); err != nil {
+				return errors.Wrapf(err, "object %s: extend positions of prop '%s'",
+					obj.ID(), prop.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resetPositionsState removes the marker that the positions of all objects
+// have been indexed. The state bucket only exists if positions were enabled
+// at some point, so it is not created otherwise.
+func (s *Shard) resetPositionsState(ctx context.Context) error {
+	if s.store.Bucket(helpers.PositionsStateBucketLSM) == nil {
+		if _, err := os.Stat(path.Join(s.DBPathLSM(), helpers.PositionsStateBucketLSM)); err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return errors.Wrap(err, "check positions state")
+		}
+
+		err := s.store.CreateOrLoadBucket(ctx, helpers.PositionsStateBucketLSM,
+			lsmkv.WithStrategy(lsmkv.StrategyReplace))
+		if err != nil {
+			return errors.Wrap(err, "init positions state")
+		}
+	}
+
+	return s.store.Bucket(helpers.PositionsStateBucketLSM).Delete(helpers.PositionsIndexedKey)
+}
@@ -173,9 +173,12 @@ func (s *Shard) objectSearch(ctx context.Context, limit int,
 
 		bm25Config := s.index.getInvertedIndexConfig().BM25
 
+		s.invertedSwapLock.RLock()
+		defer s.invertedSwapLock.RUnlock()
+
 		return inverted.NewBM25Searcher(bm25Config, s.store,
-			s.index.getSchema.GetSchemaSkipAuth(), s.invertedRowCache,
-			s.propertyIndices, s.index.classSearcher, s.deletedDocIDs, s.propLengths,
+			s.searchSchema(), s.invertedRowCache,
+			s.propertyIndices, s.index.classSearcher, s.deletedDocIDs, s.propLengths, s.index.stopwords,
 			s.index.logger, s.versioner.Version()).
 			Object(ctx, limit, keywordRanking, filters, sort, additional, s.index.Config.ClassName)
 	}
@@ -184,7 +187,11 @@ func (s *Shard) objectSearch(ctx context.Context, limit int,
 		objs, err := s.objectList(ctx, limit, sort, additional, s.index.Config.ClassName)
 		return objs, nil, err
 	}
-	objs, err := inverted.NewSearcher(s.store, s.index.getSchema.GetSchemaSkipAuth(),
+
+	s.invertedSwapLock.RLock()
+	defer s.invertedSwapLock.RUnlock()
+
+	objs, err := inverted.NewSearcher(s.store, s.searchSchema(),
 		s.invertedRowCache, s.propertyIndices, s.index.classSearcher,
 		s.deletedDocIDs, s.index.stopwords, s.versioner.Version()).
 		Object(ctx, limit, filters, sort, additional, s.index.Config.ClassName)
@@ -192,13 +199,17 @@ func (s *Shard) objectSearch(ctx context.Context, limit int,
 }
 
 func (s *Shard) objectVectorSearch(ctx context.Context,
-	searchVector []float32, targetDist float32, limit int, filters *filters.LocalFilter,
-	sort []filters.Sort, additional additional.Properties,
+	searchVector []float32, targetVector string, targetDist float32, limit int,
+	filters *filters.LocalFilter, sort []filters.Sort, additional additional.Properties,
 ) ([]*storobj.Object, []float32, error) {
+	vectorIndex, err := s.getVectorIndex(targetVector)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var (
 		ids       []uint64
 		dists     []float32
-		err       error
 		allowList helpers.AllowList
 	)
 
@@ -213,13 +224,13 @@ func (s *Shard) objectVectorSearch(ctx context.Context,
 	}
 
 	if limit < 0 {
-		ids, dists, err = s.vectorIndex.SearchByVectorDistance(
+		ids, dists, err = vectorIndex.SearchByVectorDistance(
 			searchVector, targetDist, s.index.Config.QueryMaximumResults, allowList)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "vector search by distance")
 		}
 	} else {
-		ids, dists, err = s.vectorIndex.SearchByVector(searchVector, limit, allowList)
+		ids, dists, err = vectorIndex.SearchByVector(searchVector, limit, allowList)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "vector search")
 		}
@@ -365,7 +376,10 @@ func (s *Shard) sortDocIDsAndDists(ctx context.Context, limit int, sort []filter
 func (s *Shard) buildAllowList(ctx context.Context, filters *filters.LocalFilter,
 	addl additional.Properties,
 ) (helpers.AllowList, error) {
-	list, err := inverted.NewSearcher(s.store, s.index.getSchema.GetSchemaSkipAuth(),
+	s.invertedSwapLock.RLock()
+	defer s.invertedSwapLock.RUnlock()
+
+	list, err := inverted.NewSearcher(s.store, s.searchSchema(),
 		s.invertedRowCache, s.propertyIndices, s.index.classSearcher,
 		s.deletedDocIDs, s.index.stopwords, s.versioner.Version()).
 		DocIDs(ctx, filters, addl, s.index.Config.ClassName)
@@ -405,6 +419,9 @@ func (s *Shard) batchDeleteObject(ctx context.Context, id strfmt.UUID) error {
 	}
 
 	var docID uint64
+	s.objectRewriteLock.RLock()
+	defer s.objectRewriteLock.RUnlock()
+
 	bucket := s.store.Bucket(helpers.ObjectsBucketLSM)
 	existing, err := bucket.Get([]byte(idBytes))
 	if err != nil {
@@ -437,7 +454,7 @@ func (s *Shard) batchDeleteObject(ctx context.Context, id strfmt.UUID) error {
 	// TODO: do we still need this?
 	s.deletedDocIDs.Add(docID)
 
-	if err := s.vectorIndex.Delete(docID); err != nil {
+	if err := s.deleteFromVectorIndexes(docID); err != nil {
 		return errors.Wrap(err, "delete from vector index")
 	}
 
@@ -0,0 +1,119 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/entities/errorcompounder"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
+)
+
+// pruneObjects deletes all objects which belong to virtual shards no longer
+// owned by this shard according to the specified state. This is the case
+// for the copy of a split shard, as well as for the shard it was split from.
+// It returns the number of deleted objects.
+func (s *Shard) pruneObjects(ctx context.Context, state *sharding.State) (int, error) {
+	physical, ok := state.Physical[s.name]
+	if !ok {
+		return 0, errors.Errorf("shard %q is not part of the sharding state", s.name)
+	}
+
+	owned := make(map[string]struct{}, len(physical.OwnsVirtual))
+	for _, name := range physical.OwnsVirtual {
+		owned[name] = struct{}{}
+	}
+
+	var keys [][]byte
+	cursor := s.store.Bucket(helpers.ObjectsBucketLSM).Cursor()
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		// the objects are stored by their id, which is the sharding key unless
+		// the class is sharded by a property
+		shardingKey := k
+		if state.Config.KeyProperty() != "" {
+			var err error
+			if shardingKey, err = objectShardingKey(state, v); err != nil {
+				cursor.Close()
+				return 0, errors.Wrapf(err, "object %x", k)
+			}
+		}
+
+		if _, ok := owned[state.VirtualShard(shardingKey)]; ok {
+			continue
+		}
+
+		// the cursor may reuse the key's memory on the next iteration
+		key := make([]byte, len(k))
+		copy(key, k)
+		keys = append(keys, key)
+	}
+	cursor.Close()
+
+	for i, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return i, errors.Wrap(err, "prune objects")
+		}
+
+		if _, err := s.deleteObjectByKey(key); err != nil {
+			return i, errors.Wrapf(err, "prune object %x", key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	if err := s.store.WriteWALs(); err != nil {
+		return len(keys), errors.Wrap(err, "flush all buffered WALs")
+	}
+
+	if err := s.flushVectorIndexes(); err != nil {
+		return len(keys), errors.Wrap(err, "flush all vector index buffered WALs")
+	}
+
+	// the vectors of the pruned objects are gone, searches cannot pass through
+	// them until their tombstones have been cleaned up. This would otherwise
+	// happen in the next cleanup cycle, which may leave a large part of the
+	// vector index unreachable until then.
+	if err := s.cleanUpTombstonedNodes(ctx); err != nil {
+		return len(keys), errors.Wrap(err, "clean up pruned objects in vector index")
+	}
+
+	return len(keys), nil
+}
+
+func objectShardingKey(state *sharding.State, data []byte) ([]byte, error) {
+	obj, err := storobj.FromBinary(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal object")
+	}
+
+	return shardingKey(state, obj)
+}
+
+func (s *Shard) cleanUpTombstonedNodes(ctx context.Context) error {
+	return s.forEachVectorIndex(func(_ string, index VectorIndex) error {
+		if err := index.PauseMaintenance(ctx); err != nil {
+			return err
+		}
+
+		ec := errorcompounder.ErrorCompounder{}
+		ec.Add(index.CleanUpTombstonedNodes(func() bool {
+			return ctx.Err() != nil
+		}))
+		ec.Add(index.ResumeMaintenance(ctx))
+		return ec.ToError()
+	})
+}
@@ -0,0 +1,732 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"context"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/storagestate"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+	schemaUC "github.com/semi-technologies/weaviate/usecases/schema"
+)
+
+// the property reindex bucket holds the previous version of the props whose
+// inverted index is being rebuilt, keyed by the name of the prop
+const propertyReindexBucket = "property_reindex"
+
+// the objects are indexed in batches, so concurrent writes are only blocked
+// for a short time
+const propertyReindexBatchSize = 100
+
+// propertyReindex is the rebuild of the inverted index of a prop after its
+// tokenization or indexInverted setting was changed. The new index is built
+// in separate buckets, while the previous one keeps being used. Once
+// complete, the new buckets replace the previous ones.
+type propertyReindex struct {
+	previous *models.Property
+	// next is nil while the reindex is not running, e.g. on startup until it
+	// is resumed, only the previous index is kept up to date then
+	next *models.Property
+	// objects with a doc id from here on are indexed by the writes themselves
+	startDocID uint64
+	total      int64
+	processed  int64
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// reindexProperty rebuilds the inverted index of the prop in the background.
+// It must be called before the updated prop becomes visible in the schema,
+// searches and writes keep using the previous version of the prop until the
+// reindexed buckets replace the previous ones. A reindex of the same prop
+// which is still running is replaced by the new one.
+func (s *Shard) reindexProperty(ctx context.Context, previous, next *models.Property) error {
+	if s.isReadOnly() {
+		return storagestate.ErrStatusReadOnly
+	}
+
+	// the index which is in use still matches the version of the prop from
+	// before the running reindex was started
+	if job := s.stopPropertyReindex(next.Name); job != nil {
+		previous = job.previous
+	}
+
+	err := s.store.CreateOrLoadBucket(ctx, propertyReindexBucket,
+		lsmkv.WithStrategy(lsmkv.StrategyReplace))
+	if err != nil {
+		return errors.Wrap(err, "init property reindex")
+	}
+
+	previousBytes, err := previous.MarshalBinary()
+	if err != nil {
+		return errors.Wrapf(err, "marshal previous version of property %s", next.Name)
+	}
+
+	err = s.store.Bucket(propertyReindexBucket).Put([]byte(next.Name), previousBytes)
+	if err != nil {
+		return errors.Wrapf(err, "schedule reindex of property %s", next.Name)
+	}
+
+	return s.startPropertyReindex(ctx, previous, next)
+}
+
+// initPropertyReindex restores the reindexes which did not finish before the
+// shard was shut down. The props keep their previous version until the
+// reindexes are resumed, so that the previous buckets are loaded. The reindex
+// bucket only exists if a prop was ever reindexed, so it is not created
+// otherwise.
+func (s *Shard) initPropertyReindex(ctx context.Context) error {
+	if _, err := os.Stat(path.Join(s.DBPathLSM(), propertyReindexBucket)); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "check property reindex")
+	}
+
+	err := s.store.CreateOrLoadBucket(ctx, propertyReindexBucket,
+		lsmkv.WithStrategy(lsmkv.StrategyReplace))
+	if err != nil {
+		return errors.Wrap(err, "init property reindex")
+	}
+
+	cursor := s.store.Bucket(propertyReindexBucket).Cursor()
+	defer cursor.Close()
+
+	s.reindexLock.Lock()
+	defer s.reindexLock.Unlock()
+
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		previous := &models.Property{}
+		if err := previous.UnmarshalBinary(v); err != nil {
+			return errors.Wrapf(err, "unmarshal previous version of property %s", string(k))
+		}
+
+		s.reindexing[string(k)] = &propertyReindex{previous: previous}
+	}
+
+	return nil
+}
+
+// resumePropertyReindexes restarts the reindexes restored on startup. Their
+// progress is not persisted, so they start over.
+func (s *Shard) resumePropertyReindexes(ctx context.Context) error {
+	s.reindexLock.Lock()
+	paused := make(map[string]*models.Property, len(s.reindexing))
+	for name, job := range s.reindexing {
+		paused[name] = job.previous
+	}
+	s.reindexLock.Unlock()
+
+	if len(paused) == 0 {
+		return nil
+	}
+
+	sch := s.index.getSchema.GetSchemaSkipAuth()
+	class := sch.FindClassByName(s.index.Config.ClassName)
+	for name, previous := range paused {
+		var next *models.Property
+		if class != nil {
+			next, _ = schema.GetPropertyByName(class, name)
+		}
+
+		if next == nil {
+			if err := s.abortPropertyReindex(ctx, name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.startPropertyReindex(ctx, previous, next); err != nil {
+			return errors.Wrapf(err, "resume reindex of property %s", name)
+		}
+	}
+
+	return nil
+}
+
+// startPropertyReindex creates the buckets of the reindexed prop and indexes
+// the objects in the background. Writes keep the reindexed buckets up to date
+// from here on, the background job only indexes the objects which were
+// present before.
+func (s *Shard) startPropertyReindex(ctx context.Context, previous, next *models.Property) error {
+	reindexProp := *next
+	reindexProp.Name = helpers.ReindexProp(next.Name)
+
+	// a reindex which was interrupted or replaced might have left its buckets
+	if err := s.dropReindexBuckets(ctx, next.Name); err != nil {
+		return err
+	}
+	s.propLengths.ResetProperty(reindexProp.Name)
+
+	if next.IndexInverted == nil || *next.IndexInverted {
+		if err := s.addProperty(ctx, &reindexProp); err != nil {
+			return errors.Wrapf(err, "init buckets of reindexed property %s", next.Name)
+		}
+	}
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job := &propertyReindex{
+		previous: previous,
+		next:     next,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	// no object can be written while the job is registered, every object has
+	// either been written before or is written with the job in place
+	s.objectRewriteLock.Lock()
+	job.startDocID = s.counter.PreviewNext()
+	s.reindexLock.Lock()
+	s.reindexing[next.Name] = job
+	s.reindexLock.Unlock()
+	s.objectRewriteLock.Unlock()
+
+	s.reindexWg.Add(1)
+	go func() {
+		defer s.reindexWg.Done()
+		defer close(job.done)
+
+		if err := s.runPropertyReindex(jobCtx, job); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+
+			s.index.logger.WithField("action", "property_reindex").
+				WithField("shard", s.name).
+				WithField("property", next.Name).
+				WithError(err).
+				Error("reindex property")
+		}
+	}()
+
+	return nil
+}
+
+// stopPropertyReindex stops the running reindex of the prop and returns it,
+// it is nil if there is none or if it completed in the meantime. The previous
+// version of the prop stays in use.
+func (s *Shard) stopPropertyReindex(propName string) *propertyReindex {
+	s.reindexLock.Lock()
+	job, ok := s.reindexing[propName]
+	s.reindexLock.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if job.cancel != nil {
+		job.cancel()
+		<-job.done
+	}
+
+	s.objectRewriteLock.Lock()
+	defer s.objectRewriteLock.Unlock()
+
+	s.reindexLock.Lock()
+	defer s.reindexLock.Unlock()
+
+	if s.reindexing[propName] != job {
+		return nil
+	}
+
+	s.reindexing[propName] = &propertyReindex{previous: job.previous}
+	return job
+}
+
+// stopPropertyReindexes stops all running reindexes, they are resumed on the
+// next startup
+func (s *Shard) stopPropertyReindexes() {
+	s.reindexLock.Lock()
+	for _, job := range s.reindexing {
+		if job.cancel != nil {
+			job.cancel()
+		}
+	}
+	s.reindexLock.Unlock()
+
+	s.reindexWg.Wait()
+}
+
+// abortPropertyReindex stops the reindex of the prop and removes all of its
+// traces, e.g. because the prop was dropped
+func (s *Shard) abortPropertyReindex(ctx context.Context, propName string) error {
+	s.stopPropertyReindex(propName)
+
+	s.objectRewriteLock.Lock()
+	s.reindexLock.Lock()
+	delete(s.reindexing, propName)
+	s.reindexLock.Unlock()
+	s.objectRewriteLock.Unlock()
+
+	if err := s.dropReindexBuckets(ctx, propName); err != nil {
+		return err
+	}
+	s.propLengths.ResetProperty(helpers.ReindexProp(propName))
+
+	if bucket := s.store.Bucket(propertyReindexBucket); bucket != nil {
+		if err := bucket.Delete([]byte(propName)); err != nil {
+			return errors.Wrapf(err, "remove reindex of property %s", propName)
+		}
+	}
+
+	return nil
+}
+
+func (s *Shard) dropReindexBuckets(ctx context.Context, propName string) error {
+	reindexPropName := helpers.ReindexProp(propName)
+	bucketNames := []string{
+		helpers.BucketFromPropNameLSM(reindexPropName),
+		helpers.HashBucketFromPropNameLSM(reindexPropName),
+		helpers.PositionsBucketFromPropNameLSM(reindexPropName),
+	}
+	for _, name := range bucketNames {
+		if err := s.store.DropBucket(ctx, name); err != nil {
+			return errors.Wrapf(err, "drop bucket %s", name)
+		}
+	}
+
+	return nil
+}
+
+func (s *Shard) runPropertyReindex(ctx context.Context, job *propertyReindex) error {
+	// if the change was reverted while a reindex was running, the index in
+	// use is the right one already
+	replace := schemaUC.PropertyNeedsReindex(job.previous, job.next)
+
+	if replace && (job.next.IndexInverted == nil || *job.next.IndexInverted) {
+		keys, err := s.objectKeysBefore(ctx, job.startDocID)
+		if err != nil {
+			return err
+		}
+
+		s.reindexLock.Lock()
+		job.total = int64(len(keys))
+		s.reindexLock.Unlock()
+
+		for start := 0; start < len(keys); start += propertyReindexBatchSize {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			end := start + propertyReindexBatchSize
+			if end > len(keys) {
+				end = len(keys)
+			}
+
+			if err := s.reindexObjects(keys[start:end], job); err != nil {
+				return err
+			}
+
+			s.reindexLock.Lock()
+			job.processed = int64(end)
+			s.reindexLock.Unlock()
+		}
+
+		if err := s.store.WriteWALs(); err != nil {
+			return errors.Wrap(err, "flush all buffered WALs")
+		}
+	}
+
+	if err := s.completePropertyReindex(ctx, job, replace); err != nil {
+		return err
+	}
+
+	s.index.logger.WithField("action", "property_reindex").
+		WithField("shard", s.name).
+		WithField("property", job.next.Name).
+		WithField("count", job.total).
+		Infof("reindexed property %s of %d objects", job.next.Name, job.total)
+
+	return nil
+}
+
+func (s *Shard) objectKeysBefore(ctx context.Context, docID uint64) ([][]byte, error) {
+	var keys [][]byte
+	cursor := s.store.Bucket(helpers.ObjectsBucketLSM).Cursor()
+	defer cursor.Close()
+
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		objDocID, err := storobj.DocIDFromBinary(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get doc id of object %x", k)
+		}
+
+		if objDocID < docID {
+			key := make([]byte, len(k))
+			copy(key, k)
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+// reindexObjects holds the write lock of the objects bucket, so that the
+// objects cannot be changed while they are indexed
+func (s *Shard) reindexObjects(keys [][]byte, job *propertyReindex) error {
+	s.objectRewriteLock.Lock()
+	defer s.objectRewriteLock.Unlock()
+
+	bucket := s.store.Bucket(helpers.ObjectsBucketLSM)
+	for _, key := range keys {
+		data, err := bucket.Get(key)
+		if err != nil {
+			return errors.Wrapf(err, "get object %x", key)
+		}
+		if data == nil {
+			// deleted in the meantime
+			continue
+		}
+
+		obj, err := storobj.FromBinary(data)
+		if err != nil {
+			return errors.Wrapf(err, "unmarshal object %x", key)
+		}
+
+		if obj.DocID() >= job.startDocID {
+			// updated in the meantime, which indexed the object already
+			continue
+		}
+
+		props, err := s.analyzeReindexedProperty(obj, job.next)
+		if err != nil {
+			return errors.Wrapf(err, "analyze object %s", obj.ID())
+		}
+
+		if err := s.extendInvertedIndicesLSM(props, obj.DocID()); err != nil {
+			return errors.Wrapf(err, "index object %s", obj.ID())
+		}
+
+		if err := s.addPropLengths(props); err != nil {
+			return errors.Wrapf(err, "store field length values of object %s", obj.ID())
+		}
+	}
+
+	return nil
+}
+
+// completePropertyReindex replaces the buckets of the prop with the
+// reindexed ones, unless the previous buckets are kept. Writes and searches
+// are blocked meanwhile, so that the swap is atomic to them.
+func (s *Shard) completePropertyReindex(ctx context.Context, job *propertyReindex,
+	replace bool,
+) error {
+	// the previous version of the prop must stay in use until the updated
+	// prop is visible in the schema
+	if err := s.waitForSchemaProperty(ctx, job.next); err != nil {
+		return err
+	}
+
+	s.objectRewriteLock.Lock()
+	defer s.objectRewriteLock.Unlock()
+
+	if err := s.lockInvertedSwap(ctx); err != nil {
+		return err
+	}
+	defer s.invertedSwapLock.Unlock()
+
+	propName := job.next.Name
+	reindexPropName := helpers.ReindexProp(propName)
+
+	if replace {
+		buckets := []struct {
+			name, replacement string
+			opts              []lsmkv.BucketOption
+		}{
+			{
+				name:        helpers.BucketFromPropNameLSM(propName),
+				replacement: helpers.BucketFromPropNameLSM(reindexPropName),
+				opts:        s.propertyBucketOptions(job.next),
+			},
+			{
+				name:        helpers.HashBucketFromPropNameLSM(propName),
+				replacement: helpers.HashBucketFromPropNameLSM(reindexPropName),
+				opts:        []lsmkv.BucketOption{lsmkv.WithStrategy(lsmkv.StrategyReplace)},
+			},
+			{
+				name:        helpers.PositionsBucketFromPropNameLSM(propName),
+				replacement: helpers.PositionsBucketFromPropNameLSM(reindexPropName),
+				opts:        []lsmkv.BucketOption{lsmkv.WithStrategy(lsmkv.StrategyMapCollection)},
+			},
+		}
+		for _, b := range buckets {
+			if err := s.store.ReplaceBucket(ctx, b.name, b.replacement, b.opts...); err != nil {
+				return errors.Wrapf(err, "replace bucket %s", b.name)
+			}
+		}
+
+		s.propLengths.ReplaceProperty(propName, reindexPropName)
+	} else {
+		if err := s.dropReindexBuckets(ctx, propName); err != nil {
+			return err
+		}
+		s.propLengths.ResetProperty(reindexPropName)
+	}
+
+	if err := s.propLengths.Flush(); err != nil {
+		return errors.Wrap(err, "flush prop length tracker to disk")
+	}
+
+	err := s.store.Bucket(propertyReindexBucket).Delete([]byte(propName))
+	if err != nil {
+		return errors.Wrapf(err, "remove reindex of property %s", propName)
+	}
+
+	s.reindexLock.Lock()
+	delete(s.reindexing, propName)
+	s.reindexLock.Unlock()
+
+	return nil
+}
+
+func (s *Shard) waitForSchemaProperty(ctx context.Context, next *models.Property) error {
+	for {
+		sch := s.index.getSchema.GetSchemaSkipAuth()
+		if class := sch.FindClassByName(s.index.Config.ClassName); class != nil {
+			prop, err := schema.GetPropertyByName(class, next.Name)
+			if err == nil && !schemaUC.PropertyNeedsReindex(prop, next) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// lockInvertedSwap acquires the write lock which keeps searches from using
+// the buckets while they are replaced. The lock is polled for, as a search
+// might run nested searches on the same shard while holding the read lock,
+// e.g. for filters on references to the own class, which must not be blocked
+// by the waiting writer.
+func (s *Shard) lockInvertedSwap(ctx context.Context) error {
+	for !s.invertedSwapLock.TryLock() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	return nil
+}
+
+// reindexedProperties are the updated versions of the props which are being
+// reindexed and have an inverted index
+func (s *Shard) reindexedProperties() []*models.Property {
+	s.reindexLock.Lock()
+	defer s.reindexLock.Unlock()
+
+	var props []*models.Property
+	for _, job := range s.reindexing {
+		if job.next == nil || (job.next.IndexInverted != nil && !*job.next.IndexInverted) {
+			continue
+		}
+
+		props = append(props, job.next)
+	}
+
+	return props
+}
+
+// analyzeReindexedProperty analyzes the value of the prop in its updated
+// version. The results are named after the buckets of the reindexed prop.
+func (s *Shard) analyzeReindexedProperty(obj *storobj.Object,
+	prop *models.Property,
+) ([]inverted.Property, error) {
+	input, ok := obj.Properties().(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	analyzed, err := inverted.NewAnalyzer(s.index.stopwords).
+		Object(input, []*models.Property{prop}, obj.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	var props []inverted.Property
+	for _, analyzedProp := range analyzed {
+		if analyzedProp.Name != prop.Name {
+			continue
+		}
+
+		analyzedProp.Name = helpers.ReindexProp(prop.Name)
+		props = append(props, analyzedProp)
+	}
+
+	return props, nil
+}
+
+// updateReindexedProperties keeps the buckets of the reindexed props up to
+// date with a written object
+func (s *Shard) updateReindexedProperties(object *storobj.Object,
+	status objectInsertStatus, previous []byte,
+) error {
+	for _, prop := range s.reindexedProperties() {
+		if status.docIDChanged {
+			previousObject, err := storobj.FromBinary(previous)
+			if err != nil {
+				return errors.Wrap(err, "unmarshal previous object")
+			}
+
+			if err := s.deleteReindexedProperty(previousObject, prop, status.oldDocID); err != nil {
+				return err
+			}
+		}
+
+		props, err := s.analyzeReindexedProperty(object, prop)
+		if err != nil {
+			return errors.Wrapf(err, "analyze reindexed property %s", prop.Name)
+		}
+
+		if err := s.extendInvertedIndicesLSM(props, status.docID); err != nil {
+			return errors.Wrapf(err, "extend reindexed property %s", prop.Name)
+		}
+
+		if err := s.addPropLengths(props); err != nil {
+			return errors.Wrapf(err, "store field length values of reindexed property %s", prop.Name)
+		}
+	}
+
+	return nil
+}
+
+// cleanupReindexedProperties removes a deleted object from the buckets of
+// the reindexed props
+func (s *Shard) cleanupReindexedProperties(previous *storobj.Object, docID uint64) error {
+	for _, prop := range s.reindexedProperties() {
+		if err := s.deleteReindexedProperty(previous, prop, docID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Shard) deleteReindexedProperty(obj *storobj.Object, prop *models.Property,
+	docID uint64,
+) error {
+	props, err := s.analyzeReindexedProperty(obj, prop)
+	if err != nil {
+		return errors.Wrapf(err, "analyze reindexed property %s", prop.Name)
+	}
+
+	if err := s.deleteFromInvertedIndicesLSM(props, docID); err != nil {
+		return errors.Wrapf(err, "delete from reindexed property %s", prop.Name)
+	}
+
+	return nil
+}
+
+// withPreviousProperties replaces the props which are being reindexed with
+// their previous version, whose inverted index is in use until the
+// reindexed one replaces it
+func (s *Shard) withPreviousProperties(props []*models.Property) []*models.Property {
+	s.reindexLock.Lock()
+	defer s.reindexLock.Unlock()
+
+	if len(s.reindexing) == 0 {
+		return props
+	}
+
+	out := make([]*models.Property, len(props))
+	for i, prop := range props {
+		if job, ok := s.reindexing[prop.Name]; ok {
+			out[i] = job.previous
+		} else {
+			out[i] = prop
+		}
+	}
+
+	return out
+}
+
+// searchSchema is the schema searches on the shard are run against. The
+// props which are being reindexed appear in their previous version.
+func (s *Shard) searchSchema() schema.Schema {
+	sch := s.index.getSchema.GetSchemaSkipAuth()
+	if sch.Objects == nil {
+		return sch
+	}
+
+	s.reindexLock.Lock()
+	reindexing := len(s.reindexing) > 0
+	s.reindexLock.Unlock()
+	if !reindexing {
+		return sch
+	}
+
+	classes := make([]*models.Class, len(sch.Objects.Classes))
+	for i, class := range sch.Objects.Classes {
+		if class.Class != s.index.Config.ClassName.String() {
+			classes[i] = class
+			continue
+		}
+
+		searchClass := *class
+		searchClass.Properties = s.withPreviousProperties(class.Properties)
+		classes[i] = &searchClass
+	}
+
+	objects := *sch.Objects
+	objects.Classes = classes
+	return schema.Schema{Objects: &objects}
+}
+
+// searchSchemaGetter provides the search schema of the shard to the
+// aggregator
+type searchSchemaGetter struct {
+	schemaUC.SchemaGetter
+	shard *Shard
+}
+
+func (g searchSchemaGetter) GetSchemaSkipAuth() schema.Schema {
+	return g.shard.searchSchema()
+}
+
+// reindexStatus is the progress of the props which are being reindexed
+func (s *Shard) reindexStatus() []*models.PropertyReindexStatus {
+	s.reindexLock.Lock()
+	defer s.reindexLock.Unlock()
+
+	out := make([]*models.PropertyReindexStatus, 0, len(s.reindexing))
+	for name, job := range s.reindexing {
+		out = append(out, &models.PropertyReindexStatus{
+			Property:         name,
+			ProcessedObjects: job.processed,
+			TotalObjects:     job.total,
+		})
+	}
+
+	sort.Slice(out, func(a, b int) bool {
+		return out[a].Property < out[b].Property
+	})
+
+	return out
+}
@@ -13,52 +13,70 @@ package db
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"os"
+	"path/filepath"
+	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/entities/backup"
+	"github.com/semi-technologies/weaviate/entities/storagestate"
+	"github.com/semi-technologies/weaviate/entities/storobj"
 	"golang.org/x/sync/errgroup"
 )
 
-func (s *Shard) createBackup(ctx context.Context, snap *backup.Snapshot) error {
-	var g errgroup.Group
+func (s *Shard) createBackup(ctx context.Context) (*backup.ShardSnapshot, error) {
+	var (
+		g     errgroup.Group
+		lock  sync.Mutex
+		files []backup.SnapshotFile
+	)
+
+	if s.isReadOnly() {
+		// the shard rejects writes while it is read-only, but its store still
+		// needs to flush the memtables for the snapshot to be complete
+		s.updateStoreStatus(storagestate.StatusReady)
+		defer s.updateStoreStatus(storagestate.StatusReadOnly)
+	}
 
 	g.Go(func() error {
-		files, err := s.createStoreLevelSnapshot(ctx)
+		storeFiles, err := s.createStoreLevelSnapshot(ctx)
 		if err != nil {
 			return err
 		}
-		snap.Lock()
-		defer snap.Unlock()
-		snap.Files = append(snap.Files, files...)
+		lock.Lock()
+		defer lock.Unlock()
+		files = append(files, storeFiles...)
 		return nil
 	})
 
 	g.Go(func() error {
-		files, err := s.createVectorIndexLevelSnapshot(ctx)
+		vectorFiles, err := s.createVectorIndexLevelSnapshot(ctx)
 		if err != nil {
 			return err
 		}
-		snap.Lock()
-		defer snap.Unlock()
-		snap.Files = append(snap.Files, files...)
+		lock.Lock()
+		defer lock.Unlock()
+		files = append(files, vectorFiles...)
 		return nil
 	})
 
 	if err := g.Wait(); err != nil {
-		return err
+		return nil, err
 	}
 
 	shardMeta, err := s.readSnapshotMetadata()
 	if err != nil {
-		return errors.Wrap(err, "create snapshot")
+		return nil, errors.Wrap(err, "create snapshot")
 	}
 
-	snap.Lock()
-	snap.ShardMetadata[s.name] = shardMeta
-	snap.Unlock()
-
-	return nil
+	return &backup.ShardSnapshot{
+		Files:    files,
+		Metadata: shardMeta,
+	}, nil
 }
 
 func (s *Shard) resumeMaintenanceCycles(ctx context.Context) error {
@@ -68,8 +86,11 @@ func (s *Shard) resumeMaintenanceCycles(ctx context.Context) error {
 		return s.store.ResumeCompaction(ctx)
 	})
 
-	g.Go(func() error {
-		return s.vectorIndex.ResumeMaintenance(ctx)
+	s.forEachVectorIndex(func(_ string, index VectorIndex) error {
+		g.Go(func() error {
+			return index.ResumeMaintenance(ctx)
+		})
+		return nil
 	})
 
 	if err := g.Wait(); err != nil {
@@ -106,31 +127,35 @@ func (s *Shard) createStoreLevelSnapshot(ctx context.Context) ([]backup.Snapshot
 		return nil, errors.Wrap(err, "create snapshot")
 	}
 
-	files := make([]backup.SnapshotFile, len(paths))
-	for i, pth := range paths {
-		files[i] = backup.SnapshotFile{
-			Path:  pth,
-			Class: s.index.Config.ClassName.String(),
-			Node:  s.index.Config.NodeName,
-			Shard: s.name,
-		}
+	return s.snapshotFiles(paths)
+}
+
+func (s *Shard) createVectorIndexLevelSnapshot(ctx context.Context) ([]backup.SnapshotFile, error) {
+	var paths []string
+	err := s.forEachVectorIndex(func(_ string, index VectorIndex) error {
+		indexPaths, err := snapshotVectorIndex(ctx, index)
+		paths = append(paths, indexPaths...)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return files, nil
+	return s.snapshotFiles(paths)
 }
 
-func (s *Shard) createVectorIndexLevelSnapshot(ctx context.Context) ([]backup.SnapshotFile, error) {
+func snapshotVectorIndex(ctx context.Context, index VectorIndex) ([]string, error) {
 	var g errgroup.Group
 
 	g.Go(func() error {
-		if err := s.vectorIndex.PauseMaintenance(ctx); err != nil {
+		if err := index.PauseMaintenance(ctx); err != nil {
 			return errors.Wrap(err, "create snapshot")
 		}
 		return nil
 	})
 
 	g.Go(func() error {
-		if err := s.vectorIndex.SwitchCommitLogs(ctx); err != nil {
+		if err := index.SwitchCommitLogs(ctx); err != nil {
 			return errors.Wrap(err, "create snapshot")
 		}
 		return nil
@@ -140,24 +165,55 @@ func (s *Shard) createVectorIndexLevelSnapshot(ctx context.Context) ([]backup.Sn
 		return nil, err
 	}
 
-	paths, err := s.vectorIndex.ListFiles(ctx)
+	paths, err := index.ListFiles(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "create snapshot")
 	}
 
+	return paths, nil
+}
+
+// snapshotFiles turns the paths of a snapshot into snapshot files. The
+// checksum of each file is computed here, on the node which owns the shard,
+// so incremental backups can tell unchanged files apart without having to
+// transfer them first, and restores can verify the transferred contents.
+func (s *Shard) snapshotFiles(paths []string) ([]backup.SnapshotFile, error) {
 	files := make([]backup.SnapshotFile, len(paths))
 	for i, pth := range paths {
+		checksum, size, err := fileChecksum(filepath.Join(s.index.Config.RootPath, pth))
+		if err != nil {
+			return nil, errors.Wrapf(err, "checksum of file %s", pth)
+		}
+
 		files[i] = backup.SnapshotFile{
-			Path:  pth,
-			Class: s.index.Config.ClassName.String(),
-			Node:  s.index.Config.NodeName,
-			Shard: s.name,
+			Path:     pth,
+			Class:    s.index.Config.ClassName.String(),
+			Node:     s.index.Config.NodeName,
+			Shard:    s.name,
+			Checksum: checksum,
+			Size:     size,
 		}
 	}
 
 	return files, nil
 }
 
+// fileChecksum returns the hex encoded sha256 and the size of the file
+func fileChecksum(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
 func (s *Shard) readSnapshotMetadata() (*backup.ShardMetadata, error) {
 	counterContents, err := s.readIndexCounter()
 	if err != nil {
@@ -195,3 +251,81 @@ func (s *Shard) readPropLengthTracker() ([]byte, error) {
 func (s *Shard) readShardVersion() ([]byte, error) {
 	return os.ReadFile(s.versioner.path)
 }
+
+// the renamed class marker is restored next to the objects bucket of a shard
+// which was backed up from a class of a different name. It contains the
+// name of the class the objects were backed up from.
+const renamedClassMarker = ".renamed"
+
+// rewriteRenamedClass sets the class of every object restored under a new
+// class name, as the class name is part of each stored object. The marker is
+// only removed once all objects are rewritten, so an interrupted rewrite is
+// resumed on the next startup.
+func (s *Shard) rewriteRenamedClass(ctx context.Context) error {
+	markerPath := filepath.Join(s.index.Config.RootPath, s.ID()+renamedClassMarker)
+	previous, err := os.ReadFile(markerPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "read renamed class marker")
+	}
+
+	className := s.index.Config.ClassName.String()
+	bucket := s.store.Bucket(helpers.ObjectsBucketLSM)
+
+	var keys [][]byte
+	cursor := bucket.Cursor()
+	for k, _ := cursor.First(); k != nil; k, _ = cursor.Next() {
+		key := make([]byte, len(k))
+		copy(key, k)
+		keys = append(keys, key)
+	}
+	cursor.Close()
+
+	rewritten := 0
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		data, err := bucket.Get(key)
+		if err != nil {
+			return errors.Wrapf(err, "get object %x", key)
+		}
+		if data == nil {
+			continue
+		}
+
+		obj, err := storobj.FromBinary(data)
+		if err != nil {
+			return errors.Wrapf(err, "unmarshal object %x", key)
+		}
+		if obj.Class().String() == className {
+			continue
+		}
+		obj.SetClass(className)
+
+		data, err = obj.MarshalBinary()
+		if err != nil {
+			return errors.Wrapf(err, "marshal object %s to binary", obj.ID())
+		}
+
+		if err := s.upsertObjectDataLSM(bucket, key, data, obj.DocID()); err != nil {
+			return errors.Wrapf(err, "upsert object %s", obj.ID())
+		}
+		rewritten++
+	}
+
+	if err := s.store.WriteWALs(); err != nil {
+		return errors.Wrap(err, "flush all buffered WALs")
+	}
+
+	s.index.logger.WithField("action", "restore_renamed_class").
+		WithField("shard", s.name).
+		WithField("count", rewritten).
+		Infof("rewrote %d objects restored from class %s as %s",
+			rewritten, string(previous), className)
+
+	return os.Remove(markerPath)
+}
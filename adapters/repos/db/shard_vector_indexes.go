@@ -0,0 +1,163 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+// namedVectorIndexConfigs returns the already parsed vector index configs of
+// the named vectors of the class, by the names of the vectors
+func namedVectorIndexConfigs(class *models.Class) map[string]schema.VectorIndexConfig {
+	if len(class.VectorConfig) == 0 {
+		return nil
+	}
+
+	out := make(map[string]schema.VectorIndexConfig, len(class.VectorConfig))
+	for name, cfg := range class.VectorConfig {
+		out[name] = cfg.VectorIndexConfig.(schema.VectorIndexConfig)
+	}
+
+	return out
+}
+
+// vectorIndexID is the ID of the vector index of the named vector, or of the
+// shard itself for the vector of the object
+func (s *Shard) vectorIndexID(targetVector string) string {
+	if targetVector == "" {
+		return s.ID()
+	}
+
+	return fmt.Sprintf("%s_vector_%s", s.ID(), targetVector)
+}
+
+// vectorsBucket is the bucket a flat index of the named vector keeps its
+// vectors in
+func vectorsBucket(targetVector string) string {
+	if targetVector == "" {
+		return helpers.VectorsBucketLSM
+	}
+
+	return fmt.Sprintf("%s_%s", helpers.VectorsBucketLSM, targetVector)
+}
+
+func (s *Shard) initNamedVectorIndexes(ctx context.Context) ([]func(), error) {
+	var postStartup []func()
+
+	s.vectorIndexes = make(map[string]VectorIndex, len(s.index.vectorIndexUserConfigs))
+	for targetVector, cfg := range s.index.vectorIndexUserConfigs {
+		vi, post, err := s.initVectorIndex(ctx, targetVector, cfg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "named vector %q", targetVector)
+		}
+
+		s.vectorIndexes[targetVector] = vi
+		postStartup = append(postStartup, post)
+	}
+
+	return postStartup, nil
+}
+
+// getVectorIndex returns the index of the named vector, or the index of the
+// vector of the object if no target vector is set
+func (s *Shard) getVectorIndex(targetVector string) (VectorIndex, error) {
+	if targetVector == "" {
+		return s.vectorIndex, nil
+	}
+
+	vi, ok := s.vectorIndexes[targetVector]
+	if !ok {
+		return nil, errors.Errorf("shard %q has no vector index for named vector %q",
+			s.name, targetVector)
+	}
+
+	return vi, nil
+}
+
+// forEachVectorIndex calls f for the index of the vector of the object
+// first, then for the indexes of the named vectors in order of their names.
+// It stops at the first error.
+func (s *Shard) forEachVectorIndex(f func(targetVector string, index VectorIndex) error) error {
+	if err := f("", s.vectorIndex); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(s.vectorIndexes))
+	for name := range s.vectorIndexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := f(name, s.vectorIndexes[name]); err != nil {
+			return errors.Wrapf(err, "named vector %q", name)
+		}
+	}
+
+	return nil
+}
+
+func (s *Shard) flushVectorIndexes() error {
+	return s.forEachVectorIndex(func(_ string, index VectorIndex) error {
+		return index.Flush()
+	})
+}
+
+func (s *Shard) deleteFromVectorIndexes(docID uint64) error {
+	return s.forEachVectorIndex(func(_ string, index VectorIndex) error {
+		return index.Delete(docID)
+	})
+}
+
+// updateVectorIndexes updates the index of the vector of the object as well
+// as the indexes of its named vectors
+func (s *Shard) updateVectorIndexes(object *storobj.Object,
+	status objectInsertStatus,
+) error {
+	return s.forEachVectorIndex(func(targetVector string, index VectorIndex) error {
+		vector := object.Vector
+		if targetVector != "" {
+			vector = object.Vectors[targetVector]
+		}
+
+		return s.updateVectorIndex(index, vector, status)
+	})
+}
+
+func (s *Shard) namedVectorByIndexID(targetVector string) func(context.Context, uint64) ([]float32, error) {
+	return func(ctx context.Context, indexID uint64) ([]float32, error) {
+		keyBuf := make([]byte, 8)
+		binary.LittleEndian.PutUint64(keyBuf, indexID)
+
+		bytes, err := s.store.Bucket(helpers.ObjectsBucketLSM).
+			GetBySecondary(0, keyBuf)
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes == nil {
+			return nil, storobj.NewErrNotFoundf(indexID,
+				"uuid found for docID, but object is nil")
+		}
+
+		return storobj.NamedVectorFromBinary(bytes, targetVector)
+	}
+}
@@ -123,7 +123,7 @@ func (b *deleteObjectsBatcher) flushWALs(ctx context.Context) {
 		}
 	}
 
-	if err := b.shard.vectorIndex.Flush(); err != nil {
+	if err := b.shard.flushVectorIndexes(); err != nil {
 		for i := range b.objects {
 			b.setErrorAtIndex(err, i)
 		}
@@ -148,14 +148,16 @@ func (s *Shard) findDocIDs(ctx context.Context,
 	// This method is used exclusively for batch delete, so we can always
 	// prevent filter caching, as a Batch-Delete filter will lead to a state
 	// mutation, making the filter not reusable anyway.
-	allowList, err := inverted.NewSearcher(s.store, s.index.getSchema.GetSchemaSkipAuth(),
+	s.invertedSwapLock.RLock()
+	defer s.invertedSwapLock.RUnlock()
+
+	allowList, err := inverted.NewSearcher(s.store, s.searchSchema(),
 		s.invertedRowCache, nil, s.index.classSearcher, s.deletedDocIDs,
 		s.index.stopwords, s.versioner.version).
 		DocIDsPreventCaching(ctx, filters, additional.Properties{}, s.index.Config.ClassName)
-
-	var docIDs []uint64
-	for id := range allowList {
-		docIDs = append(docIDs, id)
+	if err != nil {
+		return nil, err
 	}
-	return docIDs, err
+
+	return allowList.Slice(), nil
 }
@@ -271,10 +271,10 @@ func (b *objectsBatcher) storeSingleObjectInAdditionalStorage(ctx context.Contex
 		return
 	}
 
-	if object.Vector != nil {
+	if object.Vector != nil || len(object.Vectors) > 0 {
 		// vector is now optional as of
 		// https://github.com/semi-technologies/weaviate/issues/1800
-		if err := b.shard.updateVectorIndex(object.Vector, status); err != nil {
+		if err := b.shard.updateVectorIndexes(object, status); err != nil {
 			b.setErrorAtIndex(errors.Wrap(err, "insert to vector index"), index)
 			return
 		}
@@ -330,7 +330,7 @@ func (b *objectsBatcher) flushWALs(ctx context.Context) {
 		}
 	}
 
-	if err := b.shard.vectorIndex.Flush(); err != nil {
+	if err := b.shard.flushVectorIndexes(); err != nil {
 		for i := range b.objects {
 			b.setErrorAtIndex(err, i)
 		}
@@ -32,52 +32,68 @@ func (s *Shard) deleteObject(ctx context.Context, id strfmt.UUID) error {
 		return err
 	}
 
+	deleted, err := s.deleteObjectByKey(idBytes)
+	if err != nil || !deleted {
+		return err
+	}
+
+	if err := s.store.WriteWALs(); err != nil {
+		return errors.Wrap(err, "flush all buffered WALs")
+	}
+
+	if err := s.flushVectorIndexes(); err != nil {
+		return errors.Wrap(err, "flush all vector index buffered WALs")
+	}
+
+	return nil
+}
+
+// deleteObjectByKey removes the object with the specified binary uuid from
+// the objects bucket as well as from the inverted and the vector index. It
+// neither checks the status of the shard nor flushes the WALs, which is up
+// to the caller. The returned bool is false if the object did not exist.
+func (s *Shard) deleteObjectByKey(idBytes []byte) (bool, error) {
 	var docID uint64
+	s.objectRewriteLock.RLock()
+	defer s.objectRewriteLock.RUnlock()
+
 	bucket := s.store.Bucket(helpers.ObjectsBucketLSM)
 	existing, err := bucket.Get([]byte(idBytes))
 	if err != nil {
-		return errors.Wrap(err, "unexpected error on previous lookup")
+		return false, errors.Wrap(err, "unexpected error on previous lookup")
 	}
 
 	if existing == nil {
 		// nothing to do
-		return nil
+		return false, nil
 	}
 
 	// we need the doc ID so we can clean up inverted indices currently
 	// pointing to this object
 	docID, err = storobj.DocIDFromBinary(existing)
 	if err != nil {
-		return errors.Wrap(err, "get existing doc id from object binary")
+		return false, errors.Wrap(err, "get existing doc id from object binary")
 	}
 
 	err = bucket.Delete(idBytes)
 	if err != nil {
-		return errors.Wrap(err, "delete object from bucket")
+		return false, errors.Wrap(err, "delete object from bucket")
 	}
 
 	err = s.cleanupInvertedIndexOnDelete(existing, docID)
 	if err != nil {
-		return errors.Wrap(err, "delete object from bucket")
+		return false, errors.Wrap(err, "delete object from bucket")
 	}
 
 	// in-mem
 	// TODO: do we still need this?
 	s.deletedDocIDs.Add(docID)
 
-	if err := s.vectorIndex.Delete(docID); err != nil {
-		return errors.Wrap(err, "delete from vector index")
-	}
-
-	if err := s.store.WriteWALs(); err != nil {
-		return errors.Wrap(err, "flush all buffered WALs")
-	}
-
-	if err := s.vectorIndex.Flush(); err != nil {
-		return errors.Wrap(err, "flush all vector index buffered WALs")
+	if err := s.deleteFromVectorIndexes(docID); err != nil {
+		return false, errors.Wrap(err, "delete from vector index")
 	}
 
-	return nil
+	return true, nil
 }
 
 func (s *Shard) cleanupInvertedIndexOnDelete(previous []byte, docID uint64) error {
@@ -96,5 +112,9 @@ func (s *Shard) cleanupInvertedIndexOnDelete(previous []byte, docID uint64) erro
 		return errors.Wrap(err, "put inverted indices props")
 	}
 
+	if err := s.cleanupReindexedProperties(previousObject, docID); err != nil {
+		return errors.Wrap(err, "delete from reindexed props")
+	}
+
 	return nil
 }
@@ -47,5 +47,8 @@ func (s *Shard) analyzeObject(object *storobj.Object) ([]inverted.Property, erro
 		schemaMap[filters.InternalPropLastUpdateTimeUnix] = object.Object.LastUpdateTimeUnix
 	}
 
-	return inverted.NewAnalyzer(s.index.stopwords).Object(schemaMap, c.Properties, object.ID())
+	// props which are being reindexed are analyzed in their previous version,
+	// as their previous inverted index stays in use until it is replaced
+	return inverted.NewAnalyzer(s.index.stopwords).Object(schemaMap,
+		s.withPreviousProperties(c.Properties), object.ID())
 }
@@ -43,6 +43,10 @@ func (s *Shard) extendInvertedIndicesLSM(props []inverted.Property,
 						string(item.Data))
 				}
 			}
+
+			if err := s.extendPositionsLSM(prop, docID); err != nil {
+				return errors.Wrapf(err, "extend positions of prop '%s'", prop.Name)
+			}
 		} else {
 			for _, item := range prop.Items {
 				if err := s.extendInvertedIndexItemLSM(b, hashBucket, item, docID); err != nil {
@@ -93,6 +97,36 @@ func (s *Shard) extendInvertedIndexItemWithFrequencyLSM(b, hashBucket *lsmkv.Buc
 	return b.MapSet(item.Data, pair)
 }
 
+// extendPositionsLSM stores the positions of the terms of a text prop. The
+// bucket only exists if positions are enabled in the inverted index config.
+func (s *Shard) extendPositionsLSM(prop inverted.Property, docID uint64) error {
+	b := s.store.Bucket(helpers.PositionsBucketFromPropNameLSM(prop.Name))
+	if b == nil {
+		return nil
+	}
+
+	// the positions bucket has been introduced with shard version 2, so the
+	// doc ids are always stored as BigEndian
+	docIDBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(docIDBytes, docID)
+
+	for _, item := range prop.Items {
+		if item.Positions == nil {
+			continue
+		}
+
+		pair := lsmkv.MapPair{
+			Key:   docIDBytes,
+			Value: inverted.EncodePhrasePosting(float32(len(prop.Items)), item.Positions),
+		}
+		if err := b.MapSet(item.Data, pair); err != nil {
+			return errors.Wrapf(err, "item '%s'", string(item.Data))
+		}
+	}
+
+	return nil
+}
+
 func (s *Shard) extendInvertedIndexItemLSM(b, hashBucket *lsmkv.Bucket,
 	item inverted.Countable, docID uint64,
 ) error {
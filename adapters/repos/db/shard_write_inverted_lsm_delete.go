@@ -43,6 +43,10 @@ func (s *Shard) deleteFromInvertedIndicesLSM(props []inverted.Property,
 						string(item.Data))
 				}
 			}
+
+			if err := s.deletePositionsLSM(prop, docID); err != nil {
+				return errors.Wrapf(err, "delete positions of prop '%s'", prop.Name)
+			}
 		} else {
 			for _, item := range prop.Items {
 				if err := s.deleteInvertedIndexItemLSM(b, hashBucket, item, docID); err != nil {
@@ -84,6 +88,28 @@ func (s *Shard) deleteInvertedIndexItemWithFrequencyLSM(b, hashBucket *lsmkv.Buc
 	return b.MapDeleteKey(item.Data, docIDBytes)
 }
 
+func (s *Shard) deletePositionsLSM(prop inverted.Property, docID uint64) error {
+	b := s.store.Bucket(helpers.PositionsBucketFromPropNameLSM(prop.Name))
+	if b == nil {
+		return nil
+	}
+
+	docIDBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(docIDBytes, docID)
+
+	for _, item := range prop.Items {
+		if item.Positions == nil {
+			continue
+		}
+
+		if err := b.MapDeleteKey(item.Data, docIDBytes); err != nil {
+			return errors.Wrapf(err, "item '%s'", string(item.Data))
+		}
+	}
+
+	return nil
+}
+
 func (s *Shard) deleteInvertedIndexItemLSM(b, hashBucket *lsmkv.Bucket,
 	item inverted.Countable, docID uint64,
 ) error {
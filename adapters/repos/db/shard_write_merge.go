@@ -38,7 +38,7 @@ func (s *Shard) mergeObject(ctx context.Context, merge objects.MergeDocument) er
 		return err
 	}
 
-	if err := s.updateVectorIndex(next.Vector, status); err != nil {
+	if err := s.updateVectorIndexes(next, status); err != nil {
 		return errors.Wrap(err, "update vector index")
 	}
 
@@ -46,7 +46,7 @@ func (s *Shard) mergeObject(ctx context.Context, merge objects.MergeDocument) er
 		return errors.Wrap(err, "flush all buffered WALs")
 	}
 
-	if err := s.vectorIndex.Flush(); err != nil {
+	if err := s.flushVectorIndexes(); err != nil {
 		return errors.Wrap(err, "flush all vector index buffered WALs")
 	}
 
@@ -56,6 +56,9 @@ func (s *Shard) mergeObject(ctx context.Context, merge objects.MergeDocument) er
 func (s *Shard) mergeObjectInStorage(merge objects.MergeDocument,
 	idBytes []byte,
 ) (*storobj.Object, objectInsertStatus, error) {
+	s.objectRewriteLock.RLock()
+	defer s.objectRewriteLock.RUnlock()
+
 	bucket := s.store.Bucket(helpers.ObjectsBucketLSM)
 	previous, err := bucket.Get([]byte(idBytes))
 	if err != nil {
@@ -111,6 +114,9 @@ func (s *Shard) mergeObjectInStorage(merge objects.MergeDocument,
 func (s *Shard) mutableMergeObjectLSM(merge objects.MergeDocument,
 	idBytes []byte,
 ) (mutableMergeResult, error) {
+	s.objectRewriteLock.RLock()
+	defer s.objectRewriteLock.RUnlock()
+
 	bucket := s.store.Bucket(helpers.ObjectsBucketLSM)
 	out := mutableMergeResult{}
 
@@ -208,6 +214,14 @@ func mergeProps(previous *storobj.Object,
 		next.Vector = merge.Vector
 	}
 
+	// named vectors which are not part of the merge keep their previous value
+	for name, vector := range merge.Vectors {
+		if next.Vectors == nil {
+			next.Vectors = map[string][]float32{}
+		}
+		next.Vectors[name] = vector
+	}
+
 	next.Object.LastUpdateTimeUnix = merge.UpdateTime
 	next.SetProperties(properties)
 
@@ -45,7 +45,7 @@ func (s *Shard) putObject(ctx context.Context, object *storobj.Object) error {
 		return errors.Wrap(err, "store object in LSM store")
 	}
 
-	if err := s.updateVectorIndex(object.Vector, status); err != nil {
+	if err := s.updateVectorIndexes(object, status); err != nil {
 		return errors.Wrap(err, "update vector index")
 	}
 
@@ -61,14 +61,14 @@ func (s *Shard) putObject(ctx context.Context, object *storobj.Object) error {
 		return errors.Wrap(err, "flush prop length tracker to disk")
 	}
 
-	if err := s.vectorIndex.Flush(); err != nil {
+	if err := s.flushVectorIndexes(); err != nil {
 		return errors.Wrap(err, "flush all vector index buffered WALs")
 	}
 
 	return nil
 }
 
-func (s *Shard) updateVectorIndex(vector []float32,
+func (s *Shard) updateVectorIndex(index VectorIndex, vector []float32,
 	status objectInsertStatus,
 ) error {
 	// even if no vector is provided in an update, we still need
@@ -76,7 +76,7 @@ func (s *Shard) updateVectorIndex(vector []float32,
 	// exists. otherwise, the associated doc id is left dangling,
 	// resulting in failed attempts to merge an object on restarts.
 	if status.docIDChanged {
-		if err := s.vectorIndex.Delete(status.oldDocID); err != nil {
+		if err := index.Delete(status.oldDocID); err != nil {
 			return errors.Wrapf(err, "delete doc id %d from vector index", status.oldDocID)
 		}
 	}
@@ -87,7 +87,7 @@ func (s *Shard) updateVectorIndex(vector []float32,
 		return nil
 	}
 
-	if err := s.vectorIndex.Add(status.docID, vector); err != nil {
+	if err := index.Add(status.docID, vector); err != nil {
 		return errors.Wrapf(err, "insert doc id %d to vector index", status.docID)
 	}
 
@@ -100,6 +100,9 @@ func (s *Shard) putObjectLSM(object *storobj.Object,
 	before := time.Now()
 	defer s.metrics.PutObject(before)
 
+	s.objectRewriteLock.RLock()
+	defer s.objectRewriteLock.RUnlock()
+
 	bucket := s.store.Bucket(helpers.ObjectsBucketLSM)
 	previous, err := bucket.Get([]byte(idBytes))
 	if err != nil {
@@ -249,6 +252,10 @@ func (s *Shard) updateInvertedIndexLSM(object *storobj.Object,
 		return errors.Wrap(err, "store field length values for props")
 	}
 
+	if err := s.updateReindexedProperties(object, status, previous); err != nil {
+		return errors.Wrap(err, "update reindexed props")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,229 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/additional"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/search"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_PropertyShardingKey(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer repo.Shutdown(context.Background())
+
+	className := "TenantClass"
+	class := &models.Class{
+		VectorIndexConfig:   hnsw.NewDefaultUserConfig(),
+		InvertedIndexConfig: invertedConfig(),
+		Class:               className,
+		Properties: []*models.Property{
+			{
+				Name:         "tenantId",
+				DataType:     []string{string(schema.DataTypeString)},
+				Tokenization: models.PropertyTokenizationField,
+			},
+			{
+				Name:     "index",
+				DataType: []string{string(schema.DataTypeInt)},
+			},
+		},
+	}
+
+	shardState := multiShardState()
+	shardState.Config.Key = "tenantId"
+	schemaGetter := &fakeSchemaGetter{
+		shardState: shardState,
+		schema: schema.Schema{
+			Objects: &models.Schema{Classes: []*models.Class{class}},
+		},
+	}
+	repo.SetSchemaGetter(schemaGetter)
+	require.Nil(t, repo.WaitForStartup(testCtx()))
+	require.Nil(t, NewMigrator(repo, logger).AddClass(context.Background(),
+		class, shardState))
+
+	tenants := []string{"tenant-a", "tenant-b", "tenant-c", "tenant-d", "tenant-e"}
+	var data []*models.Object
+	for i := 0; i < 50; i++ {
+		data = append(data, &models.Object{
+			Class: className,
+			ID:    strfmt.UUID(uuid.New().String()),
+			Properties: map[string]interface{}{
+				"tenantId": tenants[i%len(tenants)],
+				"index":    int64(i),
+			},
+			Vector: []float32{float32(i), 1, 1},
+		})
+	}
+
+	t.Run("import individually and in a batch", func(t *testing.T) {
+		for _, obj := range data[:25] {
+			require.Nil(t, repo.PutObject(context.Background(), obj, obj.Vector))
+		}
+
+		batch := make(objects.BatchObjects, 0, 25)
+		for i, obj := range data[25:] {
+			batch = append(batch, objects.BatchObject{
+				OriginalIndex: i,
+				Object:        obj,
+				Vector:        obj.Vector,
+				UUID:          obj.ID,
+			})
+		}
+		res, err := repo.BatchPutObjects(context.Background(), batch)
+		require.Nil(t, err)
+		for _, obj := range res {
+			require.Nil(t, obj.Err)
+		}
+	})
+
+	tenantFilter := func(tenant string) *filters.LocalFilter {
+		return &filters.LocalFilter{
+			Root: &filters.Clause{
+				Operator: filters.OperatorEqual,
+				On: &filters.Path{
+					Class:    schema.ClassName(className),
+					Property: "tenantId",
+				},
+				Value: &filters.Value{
+					Value: tenant,
+					Type:  schema.DataTypeString,
+				},
+			},
+		}
+	}
+
+	t.Run("objects are placed by their sharding key", func(t *testing.T) {
+		index := repo.GetIndex(schema.ClassName(className))
+		for _, obj := range data {
+			tenant := obj.Properties.(map[string]interface{})["tenantId"].(string)
+			expected := shardState.PhysicalShard([]byte(tenant))
+
+			ok, err := index.shard(expected).exists(context.Background(), obj.ID)
+			require.Nil(t, err)
+			assert.True(t, ok, "object %s in shard %s", obj.ID, expected)
+		}
+	})
+
+	t.Run("objects are found by their id", func(t *testing.T) {
+		for _, obj := range data {
+			ok, err := repo.Exists(context.Background(), className, obj.ID)
+			require.Nil(t, err)
+			assert.True(t, ok)
+
+			res, err := repo.ObjectByID(context.Background(), obj.ID,
+				search.SelectProperties{}, additional.Properties{})
+			require.Nil(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, obj.ID, res.ID)
+		}
+
+		ok, err := repo.Exists(context.Background(), className,
+			strfmt.UUID(uuid.New().String()))
+		require.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("a filter on the sharding key only hits its shard", func(t *testing.T) {
+		index := repo.GetIndex(schema.ClassName(className))
+		for _, tenant := range tenants {
+			filter := tenantFilter(tenant)
+			assert.Equal(t, []string{shardState.PhysicalShard([]byte(tenant))},
+				index.shardsFromFilters(filter))
+
+			res, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+				ClassName:  className,
+				Filters:    filter,
+				Pagination: &filters.Pagination{Limit: 100},
+			})
+			require.Nil(t, err)
+			assert.Len(t, res, 10)
+			for _, obj := range res {
+				assert.Equal(t, tenant, obj.Schema.(map[string]interface{})["tenantId"])
+			}
+		}
+	})
+
+	t.Run("other filters hit every shard", func(t *testing.T) {
+		index := repo.GetIndex(schema.ClassName(className))
+		filter := &filters.LocalFilter{
+			Root: &filters.Clause{
+				Operator: filters.OperatorOr,
+				Operands: []filters.Clause{
+					*tenantFilter("tenant-a").Root,
+					{
+						Operator: filters.OperatorLessThan,
+						On: &filters.Path{
+							Class:    schema.ClassName(className),
+							Property: "index",
+						},
+						Value: &filters.Value{Value: 5, Type: schema.DataTypeInt},
+					},
+				},
+			},
+		}
+		assert.ElementsMatch(t, shardState.AllPhysicalShards(),
+			index.shardsFromFilters(filter))
+
+		res, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+			ClassName:  className,
+			Filters:    filter,
+			Pagination: &filters.Pagination{Limit: 100},
+		})
+		require.Nil(t, err)
+		assert.Len(t, res, 14)
+	})
+
+	t.Run("merge and delete objects by their id", func(t *testing.T) {
+		obj := data[0]
+		err := repo.Merge(context.Background(), objects.MergeDocument{
+			Class:           className,
+			ID:              obj.ID,
+			PrimitiveSchema: map[string]interface{}{"index": int64(1000)},
+			Vector:          obj.Vector,
+		})
+		require.Nil(t, err)
+
+		res, err := repo.ObjectByID(context.Background(), obj.ID,
+			search.SelectProperties{}, additional.Properties{})
+		require.Nil(t, err)
+		require.NotNil(t, res)
+		assert.Equal(t, float64(1000), res.Schema.(map[string]interface{})["index"])
+
+		for _, obj := range data[:10] {
+			require.Nil(t, repo.DeleteObject(context.Background(), className, obj.ID))
+		}
+
+		for i, obj := range data {
+			ok, err := repo.Exists(context.Background(), className, obj.ID)
+			require.Nil(t, err)
+			assert.Equal(t, i >= 10, ok, fmt.Sprintf("object %d", i))
+		}
+	})
+}
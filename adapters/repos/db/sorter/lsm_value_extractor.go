@@ -60,6 +60,10 @@ func (e *lsmPropertyExtractor) getProperty(v []byte) interface{} {
 			return prop[0]
 		case schema.DataTypeDateArray:
 			return prop
+		case schema.DataTypeUUID:
+			return prop[0]
+		case schema.DataTypeUUIDArray:
+			return prop
 		case schema.DataTypeBoolean:
 			return e.mustExtractBool(prop)[0]
 		case schema.DataTypeBooleanArray:
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
 )
@@ -49,6 +50,12 @@ func (s *sortBy) compare(i, j interface{}, dataType schema.DataType) bool {
 	case schema.DataTypeDateArray:
 		a, b := s.asDateArray(i), s.asDateArray(j)
 		return s.comparator.compareDate(a, b)
+	case schema.DataTypeUUID:
+		a, b := s.asUUID(i), s.asUUID(j)
+		return s.comparator.compareString(a, b)
+	case schema.DataTypeUUIDArray:
+		a, b := s.asUUIDArray(i), s.asUUIDArray(j)
+		return s.comparator.compareString(a, b)
 	case schema.DataTypeBoolean:
 		a, b := s.asBool(i), s.asBool(j)
 		return s.comparator.compareBool(a, b)
@@ -90,6 +97,32 @@ func (s *sortBy) asStringArray(prop interface{}) *string {
 	return nil
 }
 
+// asUUID compares uuids in their canonical form, which has the same order
+// as their bytes
+func (s *sortBy) asUUID(prop interface{}) *string {
+	if prop != nil {
+		if asUUID, ok := prop.(uuid.UUID); ok {
+			res := asUUID.String()
+			return &res
+		}
+	}
+	return s.asString(prop)
+}
+
+func (s *sortBy) asUUIDArray(prop interface{}) *string {
+	if prop != nil {
+		if arr, ok := prop.([]uuid.UUID); ok && len(arr) > 0 {
+			var sb strings.Builder
+			for i := range arr {
+				sb.WriteString(arr[i].String())
+			}
+			res := sb.String()
+			return &res
+		}
+	}
+	return s.asStringArray(prop)
+}
+
 func (s *sortBy) asNumber(prop interface{}) *float64 {
 	if prop != nil {
 		if asNumber, ok := prop.(float64); ok {
@@ -0,0 +1,220 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/additional"
+	"github.com/semi-technologies/weaviate/entities/aggregation"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiShardJourneys_UUIDProps(t *testing.T) {
+	repo, logger := setupMultiShardTest(t)
+	defer func() {
+		repo.Shutdown(context.Background())
+	}()
+
+	className := "UUIDProps"
+
+	const (
+		idA = "7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c01"
+		idB = "8b2c3d4e-5f6a-4b7c-9d8e-0f1a2b3c4d02"
+		idC = "9c3d4e5f-6a7b-4c8d-8e9f-1a2b3c4d5e03"
+	)
+
+	owner1 := uuid.MustParse("28f3f61b-b524-45e0-9bbe-2c1550bf73d2")
+	owner2 := uuid.MustParse("0ca2a9d9-6d3f-4b0b-8c4e-3f0f3c4b7a5e")
+
+	t.Run("prepare", func(t *testing.T) {
+		class := &models.Class{
+			Class:             className,
+			VectorIndexConfig: hnsw.NewDefaultUserConfig(),
+			InvertedIndexConfig: &models.InvertedIndexConfig{
+				CleanupIntervalSeconds: 60,
+			},
+			Properties: []*models.Property{
+				{
+					Name:     "ownerId",
+					DataType: []string{string(schema.DataTypeUUID)},
+				},
+				{
+					Name:     "friendIds",
+					DataType: []string{string(schema.DataTypeUUIDArray)},
+				},
+			},
+		}
+
+		t.Run("prepare", makeTestMultiShardSchema(repo, logger, true, class))
+	})
+
+	t.Run("insert data", func(t *testing.T) {
+		data := []struct {
+			id      string
+			owner   uuid.UUID
+			friends []uuid.UUID
+		}{
+			{idA, owner1, []uuid.UUID{owner2}},
+			{idB, owner1, []uuid.UUID{owner1, owner2}},
+			{idC, owner2, []uuid.UUID{}},
+		}
+
+		objs := make(objects.BatchObjects, len(data))
+		for i, d := range data {
+			objs[i] = objects.BatchObject{
+				OriginalIndex: i,
+				UUID:          strfmt.UUID(d.id),
+				Object: &models.Object{
+					ID:    strfmt.UUID(d.id),
+					Class: className,
+					Properties: map[string]interface{}{
+						"ownerId":   d.owner,
+						"friendIds": d.friends,
+					},
+				},
+			}
+		}
+
+		_, err := repo.BatchPutObjects(context.Background(), objs)
+		require.Nil(t, err)
+	})
+
+	t.Run("filter by uuid props", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			prop     string
+			value    string
+			expected []string
+		}{
+			{
+				name:     "by a uuid",
+				prop:     "ownerId",
+				value:    owner1.String(),
+				expected: []string{idA, idB},
+			},
+			{
+				name:     "by any element of a uuid array",
+				prop:     "friendIds",
+				value:    owner2.String(),
+				expected: []string{idA, idB},
+			},
+			{
+				name:     "by a uuid which is not present",
+				prop:     "ownerId",
+				value:    idA,
+				expected: []string{},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				res, err := repo.ClassSearch(context.Background(), traverser.GetParams{
+					ClassName:  className,
+					Pagination: &filters.Pagination{Limit: 10},
+					Filters: &filters.LocalFilter{Root: &filters.Clause{
+						Operator: filters.OperatorEqual,
+						On: &filters.Path{
+							Class:    schema.ClassName(className),
+							Property: schema.PropertyName(test.prop),
+						},
+						Value: &filters.Value{
+							Value: test.value,
+							Type:  schema.DataTypeUUID,
+						},
+					}},
+				})
+				require.Nil(t, err)
+
+				ids := make([]string, len(res))
+				for i := range res {
+					ids[i] = res[i].ID.String()
+				}
+				assert.ElementsMatch(t, test.expected, ids)
+			})
+		}
+	})
+
+	t.Run("uuid values are returned as uuids", func(t *testing.T) {
+		res, err := repo.ObjectByID(context.Background(), strfmt.UUID(idB),
+			nil, additional.Properties{})
+		require.Nil(t, err)
+		require.NotNil(t, res)
+
+		props := res.Schema.(map[string]interface{})
+		assert.Equal(t, owner1, props["ownerId"])
+		assert.Equal(t, []uuid.UUID{owner1, owner2}, props["friendIds"])
+	})
+
+	t.Run("aggregate uuid props", func(t *testing.T) {
+		aggregate := func(t *testing.T, filter *filters.LocalFilter) aggregation.Property {
+			res, err := repo.Aggregate(context.Background(), aggregation.Params{
+				ClassName: schema.ClassName(className),
+				Filters:   filter,
+				Properties: []aggregation.ParamProperty{
+					{
+						Name: "ownerId",
+						Aggregators: []aggregation.Aggregator{
+							aggregation.CountAggregator,
+							aggregation.NewTopOccurrencesAggregator(ptInt(5)),
+						},
+					},
+				},
+			})
+			require.Nil(t, err)
+			require.Len(t, res.Groups, 1)
+			return res.Groups[0].Properties["ownerId"]
+		}
+
+		expected := aggregation.Property{
+			Type: aggregation.PropertyTypeText,
+			TextAggregation: aggregation.Text{
+				Count: 3,
+				Items: []aggregation.TextOccurrence{
+					{Value: owner1.String(), Occurs: 2},
+					{Value: owner2.String(), Occurs: 1},
+				},
+			},
+		}
+
+		t.Run("unfiltered", func(t *testing.T) {
+			assert.Equal(t, expected, aggregate(t, nil))
+		})
+
+		t.Run("filtered", func(t *testing.T) {
+			assert.Equal(t, expected, aggregate(t, &filters.LocalFilter{Root: &filters.Clause{
+				Operator: filters.OperatorLike,
+				On: &filters.Path{
+					Class:    schema.ClassName(className),
+					Property: "id",
+				},
+				Value: &filters.Value{
+					Value: "*",
+					Type:  schema.DataTypeString,
+				},
+			}}))
+		})
+	})
+}
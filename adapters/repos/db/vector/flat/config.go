@@ -0,0 +1,89 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package flat
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/schema"
+)
+
+const (
+	IndexType             = "flat"
+	DefaultDistanceMetric = "cosine"
+)
+
+// UserConfig bundles all values settable by a user in the per-class settings
+type UserConfig struct {
+	Distance string `json:"distance"`
+}
+
+// IndexType returns the type of the underlying vector index, thus making sure
+// the schema.VectorIndexConfig interface is implemented
+func (u UserConfig) IndexType() string {
+	return IndexType
+}
+
+func (u UserConfig) DistanceName() string {
+	return u.Distance
+}
+
+// SetDefaults in the user-specifyable part of the config
+func (u *UserConfig) SetDefaults() {
+	u.Distance = DefaultDistanceMetric
+}
+
+// ParseUserConfig from an unknown input value, as this is not further
+// specified in the API to allow of exchanging the index type
+func ParseUserConfig(input interface{}) (schema.VectorIndexConfig, error) {
+	uc := UserConfig{}
+	uc.SetDefaults()
+
+	if input == nil {
+		return uc, nil
+	}
+
+	asMap, ok := input.(map[string]interface{})
+	if !ok || asMap == nil {
+		return uc, fmt.Errorf("input must be a non-nil map")
+	}
+
+	if value, ok := asMap["distance"]; ok {
+		asString, ok := value.(string)
+		if !ok {
+			return uc, errors.Errorf("distance must be a string, got %T", value)
+		}
+		uc.Distance = asString
+	}
+
+	return uc, nil
+}
+
+func ValidateUserConfigUpdate(initial, updated schema.VectorIndexConfig) error {
+	initialParsed, ok := initial.(UserConfig)
+	if !ok {
+		return errors.Errorf("initial is not UserConfig, but %T", initial)
+	}
+
+	updatedParsed, ok := updated.(UserConfig)
+	if !ok {
+		return errors.Errorf("updated is not UserConfig, but %T", updated)
+	}
+
+	if initialParsed.Distance != updatedParsed.Distance {
+		return errors.Errorf("distance is immutable: attempted change from %q to %q",
+			initialParsed.Distance, updatedParsed.Distance)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package flat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := ParseUserConfig(nil)
+		require.Nil(t, err)
+		assert.Equal(t, UserConfig{Distance: DefaultDistanceMetric}, cfg)
+	})
+
+	t.Run("with distance", func(t *testing.T) {
+		cfg, err := ParseUserConfig(map[string]interface{}{"distance": "l2-squared"})
+		require.Nil(t, err)
+		assert.Equal(t, UserConfig{Distance: "l2-squared"}, cfg)
+		assert.Equal(t, "flat", cfg.IndexType())
+	})
+
+	t.Run("updating the distance", func(t *testing.T) {
+		err := ValidateUserConfigUpdate(UserConfig{Distance: "cosine"},
+			UserConfig{Distance: "dot"})
+		require.NotNil(t, err)
+		assert.Equal(t, "distance is immutable: attempted change from \"cosine\" to \"dot\"",
+			err.Error())
+
+		assert.Nil(t, ValidateUserConfigUpdate(UserConfig{Distance: "dot"},
+			UserConfig{Distance: "dot"}))
+	})
+}
@@ -0,0 +1,293 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+// Package flat contains a vector index without any index structure. Every
+// search compares the query against all vectors, which makes it exact and
+// cheap to maintain, but only fast enough for a limited number of objects.
+package flat
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/priorityqueue"
+	"github.com/semi-technologies/weaviate/entities/cyclemanager"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/floatcomp"
+)
+
+// Config for a new flat index, this contains information that is derived
+// internally, e.g. by the shard. All User-settable config is specified in
+// UserConfig
+type Config struct {
+	ID               string
+	DistanceProvider distancer.Provider
+
+	// Bucket holds the vectors by their doc id. It is owned by the shard's
+	// store, so it is flushed, shut down and dropped together with the store.
+	Bucket *lsmkv.Bucket
+}
+
+func (c Config) Validate() error {
+	if c.ID == "" {
+		return errors.Errorf("id cannot be empty")
+	}
+
+	if c.DistanceProvider == nil {
+		return errors.Errorf("distancerProvider cannot be nil")
+	}
+
+	if c.Bucket == nil {
+		return errors.Errorf("bucket cannot be nil")
+	}
+
+	return nil
+}
+
+type Index struct {
+	id                string
+	distancerProvider distancer.Provider
+	bucket            *lsmkv.Bucket
+}
+
+func New(cfg Config) (*Index, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid config")
+	}
+
+	return &Index{
+		id:                cfg.ID,
+		distancerProvider: cfg.DistanceProvider,
+		bucket:            cfg.Bucket,
+	}, nil
+}
+
+func (i *Index) Add(id uint64, vector []float32) error {
+	if len(vector) == 0 {
+		return errors.Errorf("insert called with nil-vector")
+	}
+
+	if i.normalized() {
+		vector = distancer.Normalize(vector)
+	}
+
+	return i.bucket.Put(docIDKey(id), vectorToBytes(vector))
+}
+
+func (i *Index) Delete(id uint64) error {
+	return i.bucket.Delete(docIDKey(id))
+}
+
+func (i *Index) SearchByVector(vector []float32, k int,
+	allow helpers.AllowList,
+) ([]uint64, []float32, error) {
+	if k <= 0 {
+		return nil, nil, nil
+	}
+
+	results := priorityqueue.NewMax(k)
+	err := i.compareAll(vector, allow, func(id uint64, dist float32) {
+		if results.Len() < k {
+			results.Insert(id, dist)
+		} else if results.Top().Dist > dist {
+			results.Pop()
+			results.Insert(id, dist)
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]uint64, results.Len())
+	dists := make([]float32, results.Len())
+
+	// results is ordered in reverse, we need to flip the order before presenting
+	// to the user!
+	pos := len(ids) - 1
+	for results.Len() > 0 {
+		res := results.Pop()
+		ids[pos] = res.ID
+		dists[pos] = res.Dist
+		pos--
+	}
+
+	return ids, dists, nil
+}
+
+// SearchByVectorDistance returns all vectors within the target distance,
+// closest first. A maxLimit of -1 returns all of them, otherwise the results
+// are cut off after maxLimit.
+func (i *Index) SearchByVectorDistance(vector []float32, targetDistance float32,
+	maxLimit int64, allow helpers.AllowList,
+) ([]uint64, []float32, error) {
+	var results []priorityqueue.Item
+	err := i.compareAll(vector, allow, func(id uint64, dist float32) {
+		if dist <= targetDistance ||
+			floatcomp.InDelta(float64(dist), float64(targetDistance), 1e-6) {
+			results = append(results, priorityqueue.Item{ID: id, Dist: dist})
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(results, func(a, b int) bool {
+		return results[a].Dist < results[b].Dist
+	})
+
+	if maxLimit >= 0 && int64(len(results)) > maxLimit {
+		results = results[:maxLimit]
+	}
+
+	ids := make([]uint64, len(results))
+	dists := make([]float32, len(results))
+	for pos, res := range results {
+		ids[pos] = res.ID
+		dists[pos] = res.Dist
+	}
+
+	return ids, dists, nil
+}
+
+// compareAll calculates the distance between the query and all vectors, or
+// only the ones on the allow list if one is set
+func (i *Index) compareAll(vector []float32, allow helpers.AllowList,
+	fn func(id uint64, dist float32),
+) error {
+	if i.normalized() {
+		vector = distancer.Normalize(vector)
+	}
+	dist := i.distancerProvider.New(vector)
+
+	compare := func(key, value []byte) error {
+		d, _, err := dist.Distance(vectorFromBytes(value))
+		if err != nil {
+			return errors.Wrapf(err, "calculate distance to docID %d",
+				binary.LittleEndian.Uint64(key))
+		}
+
+		fn(binary.LittleEndian.Uint64(key), d)
+		return nil
+	}
+
+	if allow != nil {
+		for it := allow.Iterator(); it.HasNext(); {
+			id := it.Next()
+			key := docIDKey(id)
+			value, err := i.bucket.Get(key)
+			if err != nil {
+				return errors.Wrapf(err, "get vector of docID %d", id)
+			}
+
+			if value == nil {
+				// deleted or never vector-indexed
+				continue
+			}
+
+			if err := compare(key, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	cursor := i.bucket.Cursor()
+	defer cursor.Close()
+
+	for key, value := cursor.First(); key != nil; key, value = cursor.Next() {
+		if err := compare(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *Index) normalized() bool {
+	// cosine-dot requires normalized vectors, as the dot product and cosine
+	// similarity are only identical if the vector is normalized
+	return i.distancerProvider.Type() == "cosine-dot"
+}
+
+func (i *Index) UpdateUserConfig(updated schema.VectorIndexConfig) error {
+	if _, ok := updated.(UserConfig); !ok {
+		return errors.Errorf("config is not UserConfig, but %T", updated)
+	}
+
+	// the distance is the only setting and it is immutable
+	return nil
+}
+
+func (i *Index) Drop(ctx context.Context) error {
+	// the bucket is dropped with the shard's store
+	return nil
+}
+
+func (i *Index) Shutdown(ctx context.Context) error {
+	// the bucket is shut down with the shard's store
+	return nil
+}
+
+func (i *Index) Flush() error {
+	return i.bucket.WriteWAL()
+}
+
+func (i *Index) PauseMaintenance(ctx context.Context) error {
+	return nil
+}
+
+func (i *Index) SwitchCommitLogs(ctx context.Context) error {
+	return nil
+}
+
+// ListFiles returns no files, the bucket's files are listed with the
+// shard's store
+func (i *Index) ListFiles(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (i *Index) ResumeMaintenance(ctx context.Context) error {
+	return nil
+}
+
+func (i *Index) CleanUpTombstonedNodes(stopFunc cyclemanager.StopFunc) error {
+	// deletes are immediate, there are no tombstones
+	return nil
+}
+
+func docIDKey(id uint64) []byte {
+	key := make([]byte, 8)
+	binary.LittleEndian.PutUint64(key, id)
+	return key
+}
+
+func vectorToBytes(vector []float32) []byte {
+	out := make([]byte, 4*len(vector))
+	for pos, value := range vector {
+		binary.LittleEndian.PutUint32(out[4*pos:], math.Float32bits(value))
+	}
+	return out
+}
+
+func vectorFromBytes(in []byte) []float32 {
+	out := make([]float32, len(in)/4)
+	for pos := range out {
+		out[pos] = math.Float32frombits(binary.LittleEndian.Uint32(in[4*pos:]))
+	}
+	return out
+}
@@ -0,0 +1,109 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package flat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlatIndex(t *testing.T) {
+	vectors := [][]float32{
+		{1, 0, 0},
+		{0.9, 0.1, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+		{0.5, 0.5, 0},
+	}
+
+	index := testIndex(t, distancer.NewL2SquaredProvider())
+	for i, vec := range vectors {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	t.Run("search by vector", func(t *testing.T) {
+		ids, dists, err := index.SearchByVector([]float32{1, 0, 0}, 3, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{0, 1, 4}, ids)
+		assert.InDeltaSlice(t, []float32{0, 0.02, 0.5}, dists, 1e-6)
+	})
+
+	t.Run("search by vector with an allow list", func(t *testing.T) {
+		allow := helpers.NewAllowList(1, 3, 4, 7)
+		ids, _, err := index.SearchByVector([]float32{1, 0, 0}, 2, allow)
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{1, 4}, ids)
+	})
+
+	t.Run("search by distance", func(t *testing.T) {
+		ids, _, err := index.SearchByVectorDistance([]float32{1, 0, 0}, 0.5, -1, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{0, 1, 4}, ids)
+
+		ids, _, err = index.SearchByVectorDistance([]float32{1, 0, 0}, 0.5, 2, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{0, 1}, ids)
+	})
+
+	t.Run("deleted vectors are no longer found", func(t *testing.T) {
+		require.Nil(t, index.Delete(0))
+
+		ids, _, err := index.SearchByVector([]float32{1, 0, 0}, 1, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{1}, ids)
+	})
+
+	t.Run("vectors of a different length", func(t *testing.T) {
+		_, _, err := index.SearchByVector([]float32{1, 0}, 1, nil)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestFlatIndexCosine(t *testing.T) {
+	index := testIndex(t, distancer.NewCosineDistanceProvider())
+	require.Nil(t, index.Add(0, []float32{3, 0}))
+	require.Nil(t, index.Add(1, []float32{1, 1}))
+
+	ids, dists, err := index.SearchByVector([]float32{0, 5}, 2, nil)
+	require.Nil(t, err)
+	assert.Equal(t, []uint64{1, 0}, ids)
+	assert.InDeltaSlice(t, []float32{0.29289, 1}, dists, 1e-5)
+}
+
+func testIndex(t *testing.T, provider distancer.Provider) *Index {
+	logger, _ := test.NewNullLogger()
+	dir := t.TempDir()
+
+	store, err := lsmkv.New(dir, dir, logger, nil)
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		store.Shutdown(context.Background())
+	})
+
+	require.Nil(t, store.CreateOrLoadBucket(context.Background(), "vectors",
+		lsmkv.WithStrategy(lsmkv.StrategyReplace)))
+
+	index, err := New(Config{
+		ID:               "test",
+		DistanceProvider: provider,
+		Bucket:           store.Bucket("vectors"),
+	})
+	require.Nil(t, err)
+	return index
+}
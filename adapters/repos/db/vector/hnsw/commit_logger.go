@@ -474,8 +474,14 @@ func (l *hnswCommitLogger) SwitchCommitLogs(force bool) error {
 		return err
 	}
 
-	// this is a new commit log, initialize with the current time stamp
-	fileName := fmt.Sprintf("%d", time.Now().Unix())
+	// this is a new commit log, initialize with the current time stamp. If the
+	// old one was created within the same second, the new one still has to
+	// come after it, otherwise the old one would simply be reopened
+	ts := time.Now().Unix()
+	if oldTs, err := asTimeStamp(oldFileName); err == nil && ts <= oldTs {
+		ts = oldTs + 1
+	}
+	fileName := fmt.Sprintf("%d", ts)
 
 	if force {
 		l.logger.WithField("action", "commit_log_file_switched").
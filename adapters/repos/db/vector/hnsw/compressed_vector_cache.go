@@ -0,0 +1,148 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+	"sync"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/quantization"
+)
+
+// compressedVectorCache holds the product quantization codes of all vectors
+// once the index is compressed. Opposed to the shardedLockCache it never
+// evicts anything, the codes are small enough to keep all of them in memory.
+// On a miss the full vector is read through vectorForID and encoded.
+type compressedVectorCache struct {
+	shardedLocks    []sync.RWMutex
+	cache           [][]byte
+	vectorForID     VectorForID
+	normalizeOnRead bool
+	quantizer       *quantization.ProductQuantizer
+
+	// The maintenanceLock makes sure that only one maintenance operation, such
+	// as growing the cache or clearing the cache happens at the same time.
+	maintenanceLock sync.Mutex
+}
+
+func newCompressedVectorCache(vecForID VectorForID,
+	normalizeOnRead bool,
+) *compressedVectorCache {
+	return &compressedVectorCache{
+		shardedLocks:    make([]sync.RWMutex, shardFactor),
+		cache:           make([][]byte, initialSize),
+		vectorForID:     vecForID,
+		normalizeOnRead: normalizeOnRead,
+	}
+}
+
+// setQuantizer must be called before the cache is used for the first time
+func (c *compressedVectorCache) setQuantizer(quantizer *quantization.ProductQuantizer) {
+	c.quantizer = quantizer
+}
+
+func (c *compressedVectorCache) get(ctx context.Context, id uint64) ([]byte, error) {
+	c.shardedLocks[id%shardFactor].RLock()
+	code := c.cache[id]
+	c.shardedLocks[id%shardFactor].RUnlock()
+
+	if code != nil {
+		return code, nil
+	}
+
+	return c.handleCacheMiss(ctx, id)
+}
+
+func (c *compressedVectorCache) handleCacheMiss(ctx context.Context, id uint64) ([]byte, error) {
+	vec, err := c.vectorForID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.normalizeOnRead {
+		vec = distancer.Normalize(vec)
+	}
+
+	code, err := c.quantizer.Encode(vec)
+	if err != nil {
+		return nil, err
+	}
+
+	c.shardedLocks[id%shardFactor].Lock()
+	c.cache[id] = code
+	c.shardedLocks[id%shardFactor].Unlock()
+
+	return code, nil
+}
+
+// preload encodes a vector which is already normalized if required
+func (c *compressedVectorCache) preload(id uint64, vec []float32) error {
+	code, err := c.quantizer.Encode(vec)
+	if err != nil {
+		return err
+	}
+
+	c.shardedLocks[id%shardFactor].Lock()
+	defer c.shardedLocks[id%shardFactor].Unlock()
+
+	c.cache[id] = code
+	return nil
+}
+
+func (c *compressedVectorCache) delete(id uint64) {
+	c.shardedLocks[id%shardFactor].Lock()
+	defer c.shardedLocks[id%shardFactor].Unlock()
+
+	if int(id) >= len(c.cache) {
+		return
+	}
+
+	c.cache[id] = nil
+}
+
+func (c *compressedVectorCache) grow(node uint64) {
+	c.maintenanceLock.Lock()
+	defer c.maintenanceLock.Unlock()
+
+	c.obtainAllLocks()
+	defer c.releaseAllLocks()
+
+	newSize := node + minimumIndexGrowthDelta
+	newCache := make([][]byte, newSize)
+	copy(newCache, c.cache)
+	c.cache = newCache
+}
+
+func (c *compressedVectorCache) drop() {
+	c.maintenanceLock.Lock()
+	defer c.maintenanceLock.Unlock()
+
+	c.obtainAllLocks()
+	defer c.releaseAllLocks()
+
+	for i := range c.cache {
+		c.cache[i] = nil
+	}
+}
+
+func (c *compressedVectorCache) obtainAllLocks() {
+	for i := range c.shardedLocks {
+		c.shardedLocks[i].Lock()
+	}
+}
+
+func (c *compressedVectorCache) releaseAllLocks() {
+	for i := range c.shardedLocks {
+		c.shardedLocks[i].Unlock()
+	}
+}
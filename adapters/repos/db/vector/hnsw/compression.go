@@ -0,0 +1,345 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/priorityqueue"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/quantization"
+	"github.com/semi-technologies/weaviate/entities/cyclemanager"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+// compressionCheckInterval is the interval in which the index checks whether
+// it is ready to be compressed, i.e. product quantization is enabled and
+// enough vectors have been imported to train the quantizer
+const compressionCheckInterval = 10 * time.Second
+
+func (h *hnsw) compressionPath() string {
+	return filepath.Join(h.rootPath, fmt.Sprintf("%s.hnsw.pq", h.id))
+}
+
+func (h *hnsw) isCompressed() bool {
+	return atomic.LoadInt32(&h.compressed) == 1
+}
+
+func (h *hnsw) updatePQConfig(cfg PQConfig) {
+	h.compressionLock.Lock()
+	defer h.compressionLock.Unlock()
+
+	h.pqConfig = cfg
+}
+
+func (h *hnsw) copyPQConfig() PQConfig {
+	h.compressionLock.Lock()
+	defer h.compressionLock.Unlock()
+
+	return h.pqConfig
+}
+
+func (h *hnsw) compressionCheck(stopFunc cyclemanager.StopFunc) {
+	if err := h.compressIfReady(); err != nil {
+		h.logger.WithField("action", "hnsw_compression").
+			WithError(err).Error("compressing the vectors failed")
+	}
+}
+
+// compressIfReady trains the quantizer and switches the index to compressed
+// vectors once product quantization is enabled and the index holds at least
+// as many vectors as the training limit. It is a no-op otherwise.
+func (h *hnsw) compressIfReady() error {
+	cfg := h.copyPQConfig()
+	if !cfg.Enabled || h.isCompressed() {
+		return nil
+	}
+
+	ids := h.liveNodeIDs()
+	if len(ids) < cfg.TrainingLimit {
+		return nil
+	}
+
+	return h.compress(cfg, ids)
+}
+
+func (h *hnsw) liveNodeIDs() []uint64 {
+	h.RLock()
+	defer h.RUnlock()
+
+	var ids []uint64
+	for i, node := range h.nodes {
+		if node == nil || h.hasTombstone(uint64(i)) {
+			continue
+		}
+		ids = append(ids, uint64(i))
+	}
+
+	return ids
+}
+
+func (h *hnsw) compress(cfg PQConfig, ids []uint64) error {
+	before := time.Now()
+
+	rand.Shuffle(len(ids), func(a, b int) {
+		ids[a], ids[b] = ids[b], ids[a]
+	})
+	if len(ids) > cfg.TrainingLimit {
+		ids = ids[:cfg.TrainingLimit]
+	}
+
+	sample := make([][]float32, 0, len(ids))
+	for _, id := range ids {
+		vec, err := h.vectorForID(context.Background(), id)
+		if err != nil {
+			var e storobj.ErrNotFound
+			if errors.As(err, &e) {
+				// deleted in the meantime, simply leave it out of the sample
+				continue
+			}
+			return errors.Wrapf(err, "get vector of docID %d", id)
+		}
+		sample = append(sample, vec)
+	}
+
+	if len(sample) == 0 {
+		return errors.Errorf("no vectors to train the quantizer on")
+	}
+
+	dims := len(sample[0])
+	segments := cfg.Segments
+	if segments == 0 {
+		segments = defaultPQSegments(dims)
+	}
+
+	quantizer, err := quantization.NewProductQuantizer(dims, segments, cfg.Centroids)
+	if err != nil {
+		return errors.Wrap(err, "create quantizer")
+	}
+
+	if err := quantizer.Fit(sample); err != nil {
+		return errors.Wrap(err, "train quantizer")
+	}
+
+	if err := h.persistQuantizer(quantizer); err != nil {
+		return err
+	}
+
+	h.compressedVectors.setQuantizer(quantizer)
+	atomic.StoreInt32(&h.compressed, 1)
+
+	h.logger.WithField("action", "hnsw_compression").
+		WithField("id", h.id).
+		WithField("segments", segments).
+		WithField("centroids", cfg.Centroids).
+		WithField("training_vectors", len(sample)).
+		WithField("took", time.Since(before)).
+		Info("trained quantizer, vectors are compressed from now on")
+
+	return nil
+}
+
+// defaultPQSegments aims for segments of four dimensions, falling back to
+// shorter segments if the dimensions are not divisible by four
+func defaultPQSegments(dims int) int {
+	for length := 4; length > 1; length-- {
+		if dims%length == 0 {
+			return dims / length
+		}
+	}
+
+	return dims
+}
+
+// persistQuantizer writes to a temporary file first, so that a crash can
+// never leave a partially written codebook behind
+func (h *hnsw) persistQuantizer(quantizer *quantization.ProductQuantizer) error {
+	data, err := quantizer.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "serialize quantizer")
+	}
+
+	tmpPath := h.compressionPath() + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o666); err != nil {
+		return errors.Wrap(err, "write quantizer")
+	}
+
+	if err := os.Rename(tmpPath, h.compressionPath()); err != nil {
+		return errors.Wrap(err, "write quantizer")
+	}
+
+	return nil
+}
+
+// restoreQuantizer loads the quantizer of an index which has been compressed
+// before it was shut down
+func (h *hnsw) restoreQuantizer() error {
+	data, err := os.ReadFile(h.compressionPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "read quantizer")
+	}
+
+	quantizer := &quantization.ProductQuantizer{}
+	if err := quantizer.UnmarshalBinary(data); err != nil {
+		return errors.Wrap(err, "deserialize quantizer")
+	}
+
+	h.compressedVectors.setQuantizer(quantizer)
+	atomic.StoreInt32(&h.compressed, 1)
+
+	return nil
+}
+
+func (h *hnsw) removeQuantizer() error {
+	if err := os.Remove(h.compressionPath()); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "remove quantizer")
+	}
+
+	return nil
+}
+
+// graphVectorForID returns the vector used to build and traverse the graph.
+// Once the index is compressed this is the approximation reconstructed from
+// the vector's code rather than the full vector.
+func (h *hnsw) graphVectorForID(ctx context.Context, id uint64) ([]float32, error) {
+	if !h.isCompressed() {
+		return h.vectorForID(ctx, id)
+	}
+
+	code, err := h.compressedVectors.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.compressedVectors.quantizer.Decode(code), nil
+}
+
+func (h *hnsw) graphMultiVectorForID(ctx context.Context, ids []uint64) ([][]float32, error) {
+	if !h.isCompressed() {
+		return h.multiVectorForID(ctx, ids)
+	}
+
+	out := make([][]float32, len(ids))
+	for i, id := range ids {
+		vec, err := h.graphVectorForID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = vec
+	}
+
+	return out, nil
+}
+
+// nodeDistancer calculates the distance between a fixed query vector and the
+// nodes of the graph
+type nodeDistancer interface {
+	distanceToNode(nodeID uint64) (float32, bool, error)
+}
+
+// graphDistancer returns a distancer on the compressed vectors once the index
+// is compressed, and on the full vectors otherwise
+func (h *hnsw) graphDistancer(queryVector []float32) (nodeDistancer, error) {
+	if !h.isCompressed() {
+		return &vectorDistancer{
+			graph:     h,
+			distancer: h.distancerProvider.New(queryVector),
+		}, nil
+	}
+
+	quantizer := h.compressedVectors.quantizer
+	if len(queryVector) != quantizer.Dimensions() {
+		return nil, errors.Errorf("vector lengths don't match: %d vs %d",
+			len(queryVector), quantizer.Dimensions())
+	}
+
+	lookup := quantizer.NewDistanceLookup(queryVector, func(a, b []float32) float32 {
+		dist, _, _ := h.distancerProvider.SingleDist(a, b)
+		return dist
+	})
+
+	var offset float32
+	if h.distancerProvider.Type() == "cosine-dot" {
+		// the cosine-dot distance of each segment is 1-dot, summing them up
+		// yields segments-dot instead of 1-dot
+		offset = float32(1 - quantizer.Segments())
+	}
+
+	return &compressedDistancer{graph: h, lookup: lookup, offset: offset}, nil
+}
+
+type vectorDistancer struct {
+	graph     *hnsw
+	distancer distancer.Distancer
+}
+
+func (d *vectorDistancer) distanceToNode(nodeID uint64) (float32, bool, error) {
+	return d.graph.distanceToNode(d.distancer, nodeID)
+}
+
+type compressedDistancer struct {
+	graph  *hnsw
+	lookup *quantization.DistanceLookup
+	offset float32
+}
+
+func (d *compressedDistancer) distanceToNode(nodeID uint64) (float32, bool, error) {
+	code, err := d.graph.compressedVectors.get(context.Background(), nodeID)
+	if err != nil {
+		var e storobj.ErrNotFound
+		if errors.As(err, &e) {
+			d.graph.handleDeletedNode(e.DocID)
+			return 0, false, nil
+		} else {
+			// not a typed error, we can recover from, return with err
+			return 0, false, errors.Wrapf(err, "get compressed vector of docID %d", nodeID)
+		}
+	}
+
+	return d.lookup.Distance(code) + d.offset, true, nil
+}
+
+// rescore replaces the approximate distances of the results with the
+// distances to the full vectors, so the final cut-off and order are exact
+func (h *hnsw) rescore(queryVector []float32,
+	results *priorityqueue.Queue,
+) (*priorityqueue.Queue, error) {
+	distancer := h.distancerProvider.New(queryVector)
+	rescored := h.pools.pqResults.GetMax(results.Len())
+
+	for results.Len() > 0 {
+		item := results.Pop()
+		dist, ok, err := h.distanceToNode(distancer, item.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "rescore")
+		}
+
+		if !ok {
+			continue
+		}
+
+		rescored.Insert(item.ID, dist)
+	}
+
+	h.pools.pqResults.Put(results)
+	return rescored, nil
+}
@@ -0,0 +1,199 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompression(t *testing.T) {
+	dimensions := 32
+	vectors := randomClusteredVectors(3000, dimensions, 30)
+	queries := randomClusteredVectors(100, dimensions, 30)
+	rootPath := t.TempDir()
+	deleted := map[uint64]struct{}{}
+
+	vectorForID := func(ctx context.Context, id uint64) ([]float32, error) {
+		if _, ok := deleted[id]; ok {
+			return nil, storobj.NewErrNotFoundf(id, "deleted")
+		}
+		return vectors[int(id)], nil
+	}
+
+	pq := PQConfig{
+		Enabled:       true,
+		Segments:      8,
+		Centroids:     64,
+		TrainingLimit: 1000,
+	}
+
+	newIndex := func() *hnsw {
+		index, err := New(Config{
+			RootPath:              rootPath,
+			ID:                    "compression",
+			MakeCommitLoggerThunk: MakeNoopCommitLogger,
+			DistanceProvider:      distancer.NewCosineDistanceProvider(),
+			VectorForIDThunk:      vectorForID,
+		}, UserConfig{
+			MaxConnections: 30,
+			EFConstruction: 64,
+			// only the ef closest candidates on the compressed vectors are rescored,
+			// so the recall depends on it far more than without compression
+			EF:                    256,
+			VectorCacheMaxObjects: 100000,
+			PQ:                    pq,
+		})
+		require.Nil(t, err)
+		return index
+	}
+
+	index := newIndex()
+
+	t.Run("is not compressed below the training limit", func(t *testing.T) {
+		for i := 0; i < pq.TrainingLimit-1; i++ {
+			require.Nil(t, index.Add(uint64(i), vectors[i]))
+		}
+
+		require.Nil(t, index.compressIfReady())
+		assert.False(t, index.isCompressed())
+	})
+
+	t.Run("is compressed once the training limit is reached", func(t *testing.T) {
+		id := uint64(pq.TrainingLimit - 1)
+		require.Nil(t, index.Add(id, vectors[id]))
+
+		require.Nil(t, index.compressIfReady())
+		assert.True(t, index.isCompressed())
+		assert.Equal(t, 8, index.compressedVectors.quantizer.Segments())
+		assert.FileExists(t, index.compressionPath())
+	})
+
+	t.Run("import the remaining vectors into the compressed index", func(t *testing.T) {
+		for i := pq.TrainingLimit; i < len(vectors); i++ {
+			require.Nil(t, index.Add(uint64(i), vectors[i]))
+		}
+	})
+
+	assertRecall := func(t *testing.T, index *hnsw) {
+		k := 10
+		relevant := 0
+		for _, query := range queries {
+			results, dists, err := index.SearchByVector(query, k, nil)
+			require.Nil(t, err)
+			require.Len(t, results, k)
+
+			// the results are rescored, so the distances must be exact
+			for i, id := range results {
+				expected, _, err := distancer.NewCosineDistanceProvider().
+					SingleDist(distancer.Normalize(query), distancer.Normalize(vectors[id]))
+				require.Nil(t, err)
+				assert.InDelta(t, expected, dists[i], 1e-5)
+			}
+
+			for _, id := range bruteForceCosine(vectors, query, k) {
+				for _, result := range results {
+					if result == id {
+						relevant++
+					}
+				}
+			}
+		}
+
+		recall := float32(relevant) / float32(k*len(queries))
+		assert.GreaterOrEqual(t, recall, float32(0.9))
+	}
+
+	t.Run("search with a high recall on the compressed vectors", func(t *testing.T) {
+		assertRecall(t, index)
+	})
+
+	t.Run("flat search returns the exact results", func(t *testing.T) {
+		allowList := helpers.NewAllowList()
+		for i := uint64(0); i < 100; i++ {
+			allowList.Insert(i)
+		}
+
+		query := queries[0]
+		results, _, err := index.SearchByVector(query, 5, allowList)
+		require.Nil(t, err)
+		assert.Equal(t, bruteForceCosine(vectors[:100], query, 5), results)
+	})
+
+	t.Run("deleted vectors are no longer found", func(t *testing.T) {
+		query := queries[0]
+		results, _, err := index.SearchByVector(query, 1, nil)
+		require.Nil(t, err)
+		require.Len(t, results, 1)
+
+		deleted[results[0]] = struct{}{}
+		require.Nil(t, index.Delete(results[0]))
+		require.Nil(t, index.CleanUpTombstonedNodes(neverStop))
+
+		after, _, err := index.SearchByVector(query, 10, nil)
+		require.Nil(t, err)
+		assert.NotContains(t, after, results[0])
+	})
+
+	t.Run("the quantizer is restored on startup", func(t *testing.T) {
+		restored := newIndex()
+		assert.True(t, restored.isCompressed())
+		assert.Equal(t, index.compressedVectors.quantizer, restored.compressedVectors.quantizer)
+	})
+
+	t.Run("the quantizer is removed on drop", func(t *testing.T) {
+		require.Nil(t, index.Drop(context.Background()))
+		_, err := os.Stat(index.compressionPath())
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestDefaultPQSegments(t *testing.T) {
+	assert.Equal(t, 32, defaultPQSegments(128))
+	assert.Equal(t, 75, defaultPQSegments(300))
+	assert.Equal(t, 11, defaultPQSegments(33))
+	assert.Equal(t, 5, defaultPQSegments(10))
+	assert.Equal(t, 7, defaultPQSegments(7))
+}
+
+// randomClusteredVectors generates vectors around a fixed set of centers, as
+// real-world embeddings are typically clustered too
+func randomClusteredVectors(count, dims, clusters int) [][]float32 {
+	r := rand.New(rand.NewSource(int64(count)))
+	centers := make([][]float32, clusters)
+	centerSource := rand.New(rand.NewSource(42))
+	for i := range centers {
+		centers[i] = make([]float32, dims)
+		for j := range centers[i] {
+			centers[i][j] = centerSource.Float32()*2 - 1
+		}
+	}
+
+	out := make([][]float32, count)
+	for i := range out {
+		center := centers[r.Intn(clusters)]
+		out[i] = make([]float32, dims)
+		for j := range out[i] {
+			out[i][j] = center[j] + float32(r.NormFloat64()*0.1)
+		}
+	}
+
+	return out
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/quantization"
 	"github.com/semi-technologies/weaviate/entities/errorcompounder"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/usecases/monitoring"
@@ -87,21 +88,40 @@ const (
 	DefaultSkip                   = false
 	DefaultFlatSearchCutoff       = 40000
 	DefaultDistanceMetric         = DistanceCosine
+	DefaultPQEnabled              = false
+	DefaultPQSegments             = 0 // indicates "let Weaviate pick"
+	DefaultPQCentroids            = 256
+	DefaultPQTrainingLimit        = 100000
 )
 
 // UserConfig bundles all values settable by a user in the per-class settings
 type UserConfig struct {
-	Skip                   bool   `json:"skip"`
-	CleanupIntervalSeconds int    `json:"cleanupIntervalSeconds"`
-	MaxConnections         int    `json:"maxConnections"`
-	EFConstruction         int    `json:"efConstruction"`
-	EF                     int    `json:"ef"`
-	DynamicEFMin           int    `json:"dynamicEfMin"`
-	DynamicEFMax           int    `json:"dynamicEfMax"`
-	DynamicEFFactor        int    `json:"dynamicEfFactor"`
-	VectorCacheMaxObjects  int    `json:"vectorCacheMaxObjects"`
-	FlatSearchCutoff       int    `json:"flatSearchCutoff"`
-	Distance               string `json:"distance"`
+	Skip                   bool     `json:"skip"`
+	CleanupIntervalSeconds int      `json:"cleanupIntervalSeconds"`
+	MaxConnections         int      `json:"maxConnections"`
+	EFConstruction         int      `json:"efConstruction"`
+	EF                     int      `json:"ef"`
+	DynamicEFMin           int      `json:"dynamicEfMin"`
+	DynamicEFMax           int      `json:"dynamicEfMax"`
+	DynamicEFFactor        int      `json:"dynamicEfFactor"`
+	VectorCacheMaxObjects  int      `json:"vectorCacheMaxObjects"`
+	FlatSearchCutoff       int      `json:"flatSearchCutoff"`
+	Distance               string   `json:"distance"`
+	PQ                     PQConfig `json:"pq"`
+}
+
+// PQConfig controls the product quantization of the vectors held by the
+// index. Once enabled, the index trains a quantizer on a sample of its vectors
+// as soon as it holds at least TrainingLimit of them. From then on the graph
+// is traversed on the compressed vectors, only the top candidates of a search
+// are rescored with the full vectors. To actually save memory, the
+// vectorCacheMaxObjects should be lowered as well, as the full vectors are
+// still cached up to this limit.
+type PQConfig struct {
+	Enabled       bool `json:"enabled"`
+	Segments      int  `json:"segments"`
+	Centroids     int  `json:"centroids"`
+	TrainingLimit int  `json:"trainingLimit"`
 }
 
 // IndexType returns the type of the underlying vector index, thus making sure
@@ -110,6 +130,10 @@ func (u UserConfig) IndexType() string {
 	return "hnsw"
 }
 
+func (u UserConfig) DistanceName() string {
+	return u.Distance
+}
+
 // SetDefaults in the user-specifyable part of the config
 func (c *UserConfig) SetDefaults() {
 	c.MaxConnections = DefaultMaxConnections
@@ -123,6 +147,12 @@ func (c *UserConfig) SetDefaults() {
 	c.Skip = DefaultSkip
 	c.FlatSearchCutoff = DefaultFlatSearchCutoff
 	c.Distance = DefaultDistanceMetric
+	c.PQ = PQConfig{
+		Enabled:       DefaultPQEnabled,
+		Segments:      DefaultPQSegments,
+		Centroids:     DefaultPQCentroids,
+		TrainingLimit: DefaultPQTrainingLimit,
+	}
 }
 
 // ParseUserConfig from an unknown input value, as this is not further
@@ -206,9 +236,65 @@ func ParseUserConfig(input interface{}) (schema.VectorIndexConfig, error) {
 		return uc, err
 	}
 
+	if err := parsePQConfig(asMap, &uc.PQ); err != nil {
+		return uc, err
+	}
+
 	return uc, nil
 }
 
+func parsePQConfig(in map[string]interface{}, pq *PQConfig) error {
+	value, ok := in["pq"]
+	if !ok {
+		return nil
+	}
+
+	asMap, ok := value.(map[string]interface{})
+	if !ok || asMap == nil {
+		return fmt.Errorf("pq must be a non-nil map")
+	}
+
+	if err := optionalBoolFromMap(asMap, "enabled", func(v bool) {
+		pq.Enabled = v
+	}); err != nil {
+		return err
+	}
+
+	if err := optionalIntFromMap(asMap, "segments", func(v int) {
+		pq.Segments = v
+	}); err != nil {
+		return errors.Wrap(err, "pq")
+	}
+
+	if err := optionalIntFromMap(asMap, "centroids", func(v int) {
+		pq.Centroids = v
+	}); err != nil {
+		return errors.Wrap(err, "pq")
+	}
+
+	if err := optionalIntFromMap(asMap, "trainingLimit", func(v int) {
+		pq.TrainingLimit = v
+	}); err != nil {
+		return errors.Wrap(err, "pq")
+	}
+
+	if pq.Segments < 0 {
+		return errors.Errorf("pq.segments must not be negative, got %d", pq.Segments)
+	}
+
+	if pq.Centroids < 1 || pq.Centroids > quantization.MaxCentroids {
+		return errors.Errorf("pq.centroids must be between 1 and %d, got %d",
+			quantization.MaxCentroids, pq.Centroids)
+	}
+
+	if pq.TrainingLimit < pq.Centroids {
+		return errors.Errorf("pq.trainingLimit must be at least pq.centroids (%d), got %d",
+			pq.Centroids, pq.TrainingLimit)
+	}
+
+	return nil
+}
+
 func optionalIntFromMap(in map[string]interface{}, name string,
 	setFn func(v int),
 ) error {
@@ -19,6 +19,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_ValidConfig(t *testing.T) {
@@ -92,6 +93,13 @@ func Test_UserConfig(t *testing.T) {
 		expected UserConfig
 	}
 
+	defaultPQ := PQConfig{
+		Enabled:       DefaultPQEnabled,
+		Segments:      DefaultPQSegments,
+		Centroids:     DefaultPQCentroids,
+		TrainingLimit: DefaultPQTrainingLimit,
+	}
+
 	tests := []test{
 		{
 			name:  "nothing specified, all defaults",
@@ -108,6 +116,7 @@ func Test_UserConfig(t *testing.T) {
 				DynamicEFMax:           DefaultDynamicEFMax,
 				DynamicEFFactor:        DefaultDynamicEFFactor,
 				Distance:               DefaultDistanceMetric,
+				PQ:                     defaultPQ,
 			},
 		},
 
@@ -127,6 +136,7 @@ func Test_UserConfig(t *testing.T) {
 				DynamicEFMax:           DefaultDynamicEFMax,
 				DynamicEFFactor:        DefaultDynamicEFFactor,
 				Distance:               DefaultDistanceMetric,
+				PQ:                     defaultPQ,
 			},
 		},
 
@@ -157,6 +167,7 @@ func Test_UserConfig(t *testing.T) {
 				DynamicEFFactor:        19,
 				Skip:                   true,
 				Distance:               "l2-squared",
+				PQ:                     defaultPQ,
 			},
 		},
 
@@ -187,6 +198,7 @@ func Test_UserConfig(t *testing.T) {
 				DynamicEFFactor:        19,
 				Skip:                   true,
 				Distance:               "manhattan",
+				PQ:                     defaultPQ,
 			},
 		},
 
@@ -217,6 +229,7 @@ func Test_UserConfig(t *testing.T) {
 				DynamicEFFactor:        19,
 				Skip:                   true,
 				Distance:               "hamming",
+				PQ:                     defaultPQ,
 			},
 		},
 
@@ -245,6 +258,37 @@ func Test_UserConfig(t *testing.T) {
 				DynamicEFMax:           18,
 				DynamicEFFactor:        19,
 				Distance:               DefaultDistanceMetric,
+				PQ:                     defaultPQ,
+			},
+		},
+
+		{
+			name: "with product quantization",
+			input: map[string]interface{}{
+				"pq": map[string]interface{}{
+					"enabled":       true,
+					"segments":      json.Number("32"),
+					"centroids":     json.Number("128"),
+					"trainingLimit": float64(5000),
+				},
+			},
+			expected: UserConfig{
+				CleanupIntervalSeconds: DefaultCleanupIntervalSeconds,
+				MaxConnections:         DefaultMaxConnections,
+				EFConstruction:         DefaultEFConstruction,
+				VectorCacheMaxObjects:  DefaultVectorCacheMaxObjects,
+				EF:                     DefaultEF,
+				FlatSearchCutoff:       DefaultFlatSearchCutoff,
+				DynamicEFMin:           DefaultDynamicEFMin,
+				DynamicEFMax:           DefaultDynamicEFMax,
+				DynamicEFFactor:        DefaultDynamicEFFactor,
+				Distance:               DefaultDistanceMetric,
+				PQ: PQConfig{
+					Enabled:       true,
+					Segments:      32,
+					Centroids:     128,
+					TrainingLimit: 5000,
+				},
 			},
 		},
 	}
@@ -258,3 +302,40 @@ func Test_UserConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_InvalidPQConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		pq            interface{}
+		expectedError string
+	}{
+		{
+			name:          "not a map",
+			pq:            true,
+			expectedError: "pq must be a non-nil map",
+		},
+		{
+			name:          "too many centroids",
+			pq:            map[string]interface{}{"centroids": float64(257)},
+			expectedError: "pq.centroids must be between 1 and 256, got 257",
+		},
+		{
+			name:          "negative segments",
+			pq:            map[string]interface{}{"segments": float64(-1)},
+			expectedError: "pq.segments must not be negative, got -1",
+		},
+		{
+			name:          "training limit below centroids",
+			pq:            map[string]interface{}{"trainingLimit": float64(100)},
+			expectedError: "pq.trainingLimit must be at least pq.centroids (256), got 100",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseUserConfig(map[string]interface{}{"pq": test.pq})
+			require.NotNil(t, err)
+			assert.Equal(t, test.expectedError, err.Error())
+		})
+	}
+}
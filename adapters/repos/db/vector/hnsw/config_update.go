@@ -48,6 +48,26 @@ func ValidateUserConfigUpdate(initial, updated schema.VectorIndexConfig) error {
 		},
 	}
 
+	if initialParsed.PQ.Enabled {
+		// the vectors may already be compressed with a quantizer trained on the
+		// initial settings, there is no way back to the uncompressed state or
+		// to a different codebook without re-training and re-encoding everything
+		if !updatedParsed.PQ.Enabled {
+			return errors.Errorf("pq cannot be disabled once it has been enabled")
+		}
+
+		immutableFields = append(immutableFields,
+			immutableInt{
+				name:     "pq.segments",
+				accessor: func(c UserConfig) int { return c.PQ.Segments },
+			},
+			immutableInt{
+				name:     "pq.centroids",
+				accessor: func(c UserConfig) int { return c.PQ.Centroids },
+			},
+		)
+	}
+
 	for _, u := range immutableFields {
 		if err := validateImmutableIntField(u, initialParsed, updatedParsed); err != nil {
 			return err
@@ -91,5 +111,8 @@ func (h *hnsw) UpdateUserConfig(updated schema.VectorIndexConfig) error {
 
 	h.cache.updateMaxSize(int64(parsed.VectorCacheMaxObjects))
 
+	// a newly enabled quantization is picked up by the next compression cycle
+	h.updatePQConfig(parsed.PQ)
+
 	return nil
 }
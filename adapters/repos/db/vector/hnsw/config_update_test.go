@@ -86,6 +86,41 @@ func TestUserConfigUpdates(t *testing.T) {
 				},
 				expectedError: nil,
 			},
+			{
+				name:          "enabling pq",
+				initial:       UserConfig{},
+				update:        UserConfig{PQ: PQConfig{Enabled: true, Segments: 8, Centroids: 256}},
+				expectedError: nil,
+			},
+			{
+				name:    "attempting to disable pq",
+				initial: UserConfig{PQ: PQConfig{Enabled: true}},
+				update:  UserConfig{PQ: PQConfig{Enabled: false}},
+				expectedError: errors.Errorf(
+					"pq cannot be disabled once it has been enabled"),
+			},
+			{
+				name:    "attempting to change pq segments",
+				initial: UserConfig{PQ: PQConfig{Enabled: true, Segments: 8}},
+				update:  UserConfig{PQ: PQConfig{Enabled: true, Segments: 16}},
+				expectedError: errors.Errorf(
+					"pq.segments is immutable: " +
+						"attempted change from \"8\" to \"16\""),
+			},
+			{
+				name:    "attempting to change pq centroids",
+				initial: UserConfig{PQ: PQConfig{Enabled: true, Centroids: 256}},
+				update:  UserConfig{PQ: PQConfig{Enabled: true, Centroids: 128}},
+				expectedError: errors.Errorf(
+					"pq.centroids is immutable: " +
+						"attempted change from \"256\" to \"128\""),
+			},
+			{
+				name:          "changing pq training limit",
+				initial:       UserConfig{PQ: PQConfig{Enabled: true, TrainingLimit: 1000}},
+				update:        UserConfig{PQ: PQConfig{Enabled: true, TrainingLimit: 2000}},
+				expectedError: nil,
+			},
 		}
 
 		for _, test := range tests {
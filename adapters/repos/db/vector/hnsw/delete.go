@@ -40,6 +40,7 @@ func (h *hnsw) Delete(id uint64) error {
 	}
 
 	h.cache.delete(context.TODO(), id)
+	h.compressedVectors.delete(id)
 
 	// Adding a tombstone might not be enough in some cases, if the tombstoned
 	// entry was the entrypoint this might lead to issues for following inserts:
@@ -114,7 +115,7 @@ func (h *hnsw) resetUnsecured() error {
 }
 
 func (h *hnsw) tombstonesAsDenyList() helpers.AllowList {
-	deleteList := helpers.AllowList{}
+	deleteList := helpers.NewAllowList()
 	h.tombstoneLock.Lock()
 	defer h.tombstoneLock.Unlock()
 
@@ -148,7 +149,7 @@ func (h *hnsw) copyTombstonesToAllowList(breakCleanUpTombstonedNodes breakCleanU
 	h.tombstoneLock.Lock()
 	defer h.tombstoneLock.Unlock()
 
-	deleteList = helpers.AllowList{}
+	deleteList = helpers.NewAllowList()
 	for id := range h.tombstones {
 		if lenOfNodes <= id {
 			// we're trying to delete an id outside the possible range, nothing to do
@@ -158,7 +159,7 @@ func (h *hnsw) copyTombstonesToAllowList(breakCleanUpTombstonedNodes breakCleanU
 		deleteList.Insert(id)
 	}
 
-	if len(deleteList) == 0 {
+	if deleteList.IsEmpty() {
 		return false, nil
 	}
 
@@ -217,7 +218,8 @@ func (h *hnsw) replaceDeletedEntrypoint(deleteList helpers.AllowList, breakClean
 		return false, nil
 	}
 
-	for id := range deleteList {
+	for it := deleteList.Iterator(); it.HasNext(); {
+		id := it.Next()
 		if h.getEntrypoint() == id {
 			// this a special case because:
 			//
@@ -271,7 +273,7 @@ func (h *hnsw) reassignNeighbor(neighbor uint64, deleteList helpers.AllowList, b
 		return true, nil
 	}
 
-	neighborVec, err := h.vectorForID(context.Background(), neighbor)
+	neighborVec, err := h.graphVectorForID(context.Background(), neighbor)
 	if err != nil {
 		var e storobj.ErrNotFound
 		if errors.As(err, &e) {
@@ -528,7 +530,8 @@ func (h *hnsw) addTombstone(id uint64) error {
 }
 
 func (h *hnsw) removeTombstonesAndNodes(deleteList helpers.AllowList, breakCleanUpTombstonedNodes breakCleanUpTombstonedNodesFunc) (ok bool, err error) {
-	for id := range deleteList {
+	for it := deleteList.Iterator(); it.HasNext(); {
+		id := it.Next()
 		h.metrics.RemoveTombstone()
 		h.tombstoneLock.Lock()
 		delete(h.tombstones, id)
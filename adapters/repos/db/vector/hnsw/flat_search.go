@@ -21,7 +21,12 @@ func (h *hnsw) flatSearch(queryVector []float32, limit int,
 ) ([]uint64, []float32, error) {
 	results := priorityqueue.NewMax(limit)
 
-	for candidate := range allowList {
+	// the allow list is small enough to compare the full vectors, even if the
+	// graph is compressed
+	distancer := h.distancerProvider.New(queryVector)
+
+	for it := allowList.Iterator(); it.HasNext(); {
+		candidate := it.Next()
 		h.RLock()
 		// Hot fix for https://github.com/semi-technologies/weaviate/issues/1937
 		// this if statement mitigates the problem but it doesn't resolve the issue
@@ -38,7 +43,7 @@ func (h *hnsw) flatSearch(queryVector []float32, limit int,
 			continue
 		}
 		h.RUnlock()
-		dist, ok, err := h.distBetweenNodeAndVec(candidate, queryVector)
+		dist, ok, err := h.distanceToNode(distancer, candidate)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -38,7 +38,7 @@ func (h *hnsw) selectNeighborsHeuristic(input *priorityqueue.Queue,
 		i++
 	}
 
-	vecs, err := h.multiVectorForID(context.TODO(), ids)
+	vecs, err := h.graphMultiVectorForID(context.TODO(), ids)
 	if err != nil {
 		return err
 	}
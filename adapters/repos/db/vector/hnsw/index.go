@@ -91,6 +91,15 @@ type hnsw struct {
 
 	cache cache
 
+	// once product quantization is enabled and the quantizer has been trained,
+	// the graph is built and traversed on the compressed vectors, see
+	// compression.go
+	pqConfig          PQConfig
+	compressionLock   *sync.Mutex
+	compressed        int32 // accessed atomically
+	compressedVectors *compressedVectorCache
+	compressionCycle  *cyclemanager.CycleManager
+
 	commitLog CommitLogger
 
 	// a lookup of current tombstones (i.e. nodes that have received a tombstone,
@@ -194,6 +203,9 @@ func New(cfg Config, uc UserConfig) (*hnsw, error) {
 		flatSearchCutoff:  int64(uc.FlatSearchCutoff),
 		nodes:             make([]*vertex, initialSize),
 		cache:             vectorCache,
+		pqConfig:          uc.PQ,
+		compressionLock:   &sync.Mutex{},
+		compressedVectors: newCompressedVectorCache(cfg.VectorForIDThunk, normalizeOnRead),
 		vectorForID:       vectorCache.get,
 		multiVectorForID:  vectorCache.multiGet,
 		id:                cfg.ID,
@@ -220,6 +232,7 @@ func New(cfg Config, uc UserConfig) (*hnsw, error) {
 	}
 
 	index.tombstoneCleanupCycle = cyclemanager.New(index.cleanupInterval, index.tombstoneCleanup)
+	index.compressionCycle = cyclemanager.New(compressionCheckInterval, index.compressionCheck)
 	index.insertMetrics = newInsertMetrics(index.metrics)
 
 	if err := index.init(cfg); err != nil {
@@ -377,7 +390,7 @@ func min(a, b int) int {
 func (h *hnsw) distBetweenNodes(a, b uint64) (float32, bool, error) {
 	// TODO: introduce single search/transaction context instead of spawning new
 	// ones
-	vecA, err := h.vectorForID(context.Background(), a)
+	vecA, err := h.graphVectorForID(context.Background(), a)
 	if err != nil {
 		var e storobj.ErrNotFound
 		if errors.As(err, &e) {
@@ -394,7 +407,7 @@ func (h *hnsw) distBetweenNodes(a, b uint64) (float32, bool, error) {
 		return 0, false, fmt.Errorf("got a nil or zero-length vector at docID %d", a)
 	}
 
-	vecB, err := h.vectorForID(context.Background(), b)
+	vecB, err := h.graphVectorForID(context.Background(), b)
 	if err != nil {
 		var e storobj.ErrNotFound
 		if errors.As(err, &e) {
@@ -417,7 +430,7 @@ func (h *hnsw) distBetweenNodes(a, b uint64) (float32, bool, error) {
 func (h *hnsw) distBetweenNodeAndVec(node uint64, vecB []float32) (float32, bool, error) {
 	// TODO: introduce single search/transaction context instead of spawning new
 	// ones
-	vecA, err := h.vectorForID(context.Background(), node)
+	vecA, err := h.graphVectorForID(context.Background(), node)
 	if err != nil {
 		var e storobj.ErrNotFound
 		if errors.As(err, &e) {
@@ -515,8 +528,17 @@ func (h *hnsw) Drop(ctx context.Context) error {
 		}
 	}
 
+	if err := h.compressionCycle.StopAndWait(ctx); err != nil {
+		return errors.Wrap(err, "hnsw drop")
+	}
+
 	// cancel vector cache goroutine
 	h.cache.drop()
+	h.compressedVectors.drop()
+
+	if err := h.removeQuantizer(); err != nil {
+		return errors.Wrap(err, "hnsw drop")
+	}
 
 	// cancel commit logger last, as the tombstone cleanup cycle might still
 	// write while it's still running
@@ -533,11 +555,16 @@ func (h *hnsw) Shutdown(ctx context.Context) error {
 		return errors.Wrap(err, "hnsw shutdown")
 	}
 
+	if err := h.compressionCycle.StopAndWait(ctx); err != nil {
+		return errors.Wrap(err, "hnsw shutdown")
+	}
+
 	if err := h.commitLog.Shutdown(ctx); err != nil {
 		return errors.Wrap(err, "hnsw shutdown")
 	}
 
 	h.cache.drop()
+	h.compressedVectors.drop()
 
 	return nil
 }
@@ -132,6 +132,11 @@ func (h *hnsw) insert(node *vertex, nodeVec []float32) error {
 	// // make sure this new vec is immediately present in the cache, so we don't
 	// // have to read it from disk again
 	h.cache.preload(node.id, nodeVec)
+	if h.isCompressed() {
+		if err := h.compressedVectors.preload(node.id, nodeVec); err != nil {
+			return errors.Wrapf(err, "compress vector of node %d", node.id)
+		}
+	}
 
 	h.Lock()
 	h.nodes[nodeId] = node
@@ -44,6 +44,7 @@ func (h *hnsw) growIndexToAccomodateNode(id uint64, logger logrus.FieldLogger) e
 	defer h.metrics.GrowDuration(before)
 
 	h.cache.grow(uint64(len(newIndex)))
+	h.compressedVectors.grow(uint64(len(newIndex)))
 
 	h.pools.visitedListsLock.Lock()
 	h.pools.visitedLists.Destroy()
@@ -0,0 +1,135 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package quantization
+
+import (
+	"math/rand"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+)
+
+// maxKMeansIterations limits the training time, k-means typically converges
+// long before that
+const maxKMeansIterations = 25
+
+type kMeans struct {
+	k int
+}
+
+func newKMeans(k int) *kMeans {
+	return &kMeans{k: k}
+}
+
+// fit returns k centroids of the data. The centroids start out as data
+// points picked with k-means++ and are then moved to the mean of the points
+// closest to them until no point changes its closest centroid anymore.
+func (m *kMeans) fit(data [][]float32) [][]float32 {
+	dims := len(data[0])
+	centroids := m.initialCentroids(data)
+
+	assignment := make([]int, len(data))
+	for i := range assignment {
+		assignment[i] = -1
+	}
+
+	for iteration := 0; iteration < maxKMeansIterations; iteration++ {
+		changed := false
+		for i, point := range data {
+			if closest := nearest(centroids, point); closest != assignment[i] {
+				assignment[i] = closest
+				changed = true
+			}
+		}
+
+		if !changed {
+			break
+		}
+
+		sums := make([][]float32, m.k)
+		counts := make([]int, m.k)
+		for i := range sums {
+			sums[i] = make([]float32, dims)
+		}
+		for i, point := range data {
+			c := assignment[i]
+			counts[c]++
+			for d, v := range point {
+				sums[c][d] += v
+			}
+		}
+
+		for c := range centroids {
+			if counts[c] == 0 {
+				// an empty cluster does not help anyone, try again with another
+				// point instead
+				copy(centroids[c], data[rand.Intn(len(data))])
+				continue
+			}
+
+			for d := range centroids[c] {
+				centroids[c][d] = sums[c][d] / float32(counts[c])
+			}
+		}
+	}
+
+	return centroids
+}
+
+// initialCentroids picks the first centroid at random and each following
+// one with a probability proportional to its squared distance to the closest
+// centroid picked so far. Spreading out the centroids like this makes it far
+// less likely that two of them end up sharing a cluster.
+func (m *kMeans) initialCentroids(data [][]float32) [][]float32 {
+	centroids := make([][]float32, 0, m.k)
+	centroids = append(centroids, append([]float32(nil), data[rand.Intn(len(data))]...))
+
+	distances := make([]float32, len(data))
+	for i, point := range data {
+		distances[i] = l2Squared(centroids[0], point)
+	}
+
+	for len(centroids) < m.k {
+		var total float64
+		for _, dist := range distances {
+			total += float64(dist)
+		}
+
+		pick := rand.Intn(len(data))
+		if total > 0 {
+			target := rand.Float64() * total
+			for i, dist := range distances {
+				target -= float64(dist)
+				if target <= 0 {
+					pick = i
+					break
+				}
+			}
+		}
+
+		centroid := append([]float32(nil), data[pick]...)
+		centroids = append(centroids, centroid)
+		for i, point := range data {
+			if dist := l2Squared(centroid, point); dist < distances[i] {
+				distances[i] = dist
+			}
+		}
+	}
+
+	return centroids
+}
+
+var l2 = distancer.NewL2SquaredProvider()
+
+func l2Squared(a, b []float32) float32 {
+	dist, _, _ := l2.SingleDist(a, b)
+	return dist
+}
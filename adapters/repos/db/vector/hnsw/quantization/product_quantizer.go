@@ -0,0 +1,260 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+// Package quantization compresses vectors, so that far more of them fit into
+// memory than their full float32 representation would allow.
+package quantization
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// MaxCentroids is the maximum number of centroids per segment, as the index of
+// the closest centroid is stored in a single byte
+const MaxCentroids = 256
+
+// ProductQuantizer splits vectors into equally sized segments and replaces
+// each segment with the closest of the centroids learned for that segment.
+// Only the index of the centroid is stored, so a vector is compressed into a
+// code of one byte per segment.
+type ProductQuantizer struct {
+	dimensions int
+	segments   int
+	centroids  int
+
+	// codebook holds the centroids of each segment, a centroid has
+	// dimensions/segments values
+	codebook [][][]float32
+}
+
+func NewProductQuantizer(dimensions, segments, centroids int) (*ProductQuantizer, error) {
+	if dimensions <= 0 {
+		return nil, errors.Errorf("dimensions must be positive, got %d", dimensions)
+	}
+
+	if segments <= 0 || dimensions%segments != 0 {
+		return nil, errors.Errorf("segments must be a positive divisor of the "+
+			"vector dimensions %d, got %d", dimensions, segments)
+	}
+
+	if centroids <= 0 || centroids > MaxCentroids {
+		return nil, errors.Errorf("centroids must be between 1 and %d, got %d",
+			MaxCentroids, centroids)
+	}
+
+	return &ProductQuantizer{
+		dimensions: dimensions,
+		segments:   segments,
+		centroids:  centroids,
+	}, nil
+}
+
+func (p *ProductQuantizer) Dimensions() int {
+	return p.dimensions
+}
+
+func (p *ProductQuantizer) Segments() int {
+	return p.segments
+}
+
+func (p *ProductQuantizer) Centroids() int {
+	return p.centroids
+}
+
+func (p *ProductQuantizer) segmentLength() int {
+	return p.dimensions / p.segments
+}
+
+func (p *ProductQuantizer) segment(vector []float32, segment int) []float32 {
+	length := p.segmentLength()
+	return vector[segment*length : (segment+1)*length]
+}
+
+// Fit learns the centroids of every segment from the training vectors using
+// k-means. There must be at least as many training vectors as centroids.
+func (p *ProductQuantizer) Fit(vectors [][]float32) error {
+	if len(vectors) < p.centroids {
+		return errors.Errorf("need at least %d training vectors, got %d",
+			p.centroids, len(vectors))
+	}
+
+	for i, vector := range vectors {
+		if len(vector) != p.dimensions {
+			return errors.Errorf("training vector %d has %d dimensions, expected %d",
+				i, len(vector), p.dimensions)
+		}
+	}
+
+	codebook := make([][][]float32, p.segments)
+
+	// the segments are independent of each other, so they can be fit in
+	// parallel
+	segments := make(chan int, p.segments)
+	for s := 0; s < p.segments; s++ {
+		segments <- s
+	}
+	close(segments)
+
+	wg := &sync.WaitGroup{}
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for s := range segments {
+				data := make([][]float32, len(vectors))
+				for i, vector := range vectors {
+					data[i] = p.segment(vector, s)
+				}
+				codebook[s] = newKMeans(p.centroids).fit(data)
+			}
+		}()
+	}
+	wg.Wait()
+
+	p.codebook = codebook
+	return nil
+}
+
+// Encode returns the code of the vector, i.e. the index of the closest
+// centroid for each segment
+func (p *ProductQuantizer) Encode(vector []float32) ([]byte, error) {
+	if len(vector) != p.dimensions {
+		return nil, errors.Errorf("vector has %d dimensions, expected %d",
+			len(vector), p.dimensions)
+	}
+
+	code := make([]byte, p.segments)
+	for s := range code {
+		code[s] = byte(nearest(p.codebook[s], p.segment(vector, s)))
+	}
+
+	return code, nil
+}
+
+// Decode reconstructs an approximation of the encoded vector from its
+// centroids
+func (p *ProductQuantizer) Decode(code []byte) []float32 {
+	vector := make([]float32, 0, p.dimensions)
+	for s, c := range code {
+		vector = append(vector, p.codebook[s][c]...)
+	}
+
+	return vector
+}
+
+// DistanceLookup calculates the distance between a query and encoded vectors
+// without decoding them. It only works for distances which are the sum of
+// the distances of the individual segments.
+type DistanceLookup struct {
+	distances [][]float32 // per segment and centroid
+}
+
+// NewDistanceLookup precalculates the distance between each segment of the
+// query and each of the segment's centroids
+func (p *ProductQuantizer) NewDistanceLookup(query []float32,
+	segmentDistance func(a, b []float32) float32,
+) *DistanceLookup {
+	distances := make([][]float32, p.segments)
+	for s := range distances {
+		querySegment := p.segment(query, s)
+		distances[s] = make([]float32, len(p.codebook[s]))
+		for c, centroid := range p.codebook[s] {
+			distances[s][c] = segmentDistance(querySegment, centroid)
+		}
+	}
+
+	return &DistanceLookup{distances: distances}
+}
+
+func (l *DistanceLookup) Distance(code []byte) float32 {
+	var dist float32
+	for s, c := range code {
+		dist += l.distances[s][c]
+	}
+
+	return dist
+}
+
+// MarshalBinary serializes the quantizer including the learned codebook
+func (p *ProductQuantizer) MarshalBinary() ([]byte, error) {
+	if p.codebook == nil {
+		return nil, errors.New("product quantizer has not been fit")
+	}
+
+	buf := bytes.NewBuffer(nil)
+	header := []uint32{uint32(p.dimensions), uint32(p.segments), uint32(p.centroids)}
+	if err := binary.Write(buf, binary.LittleEndian, header); err != nil {
+		return nil, err
+	}
+
+	for _, centroids := range p.codebook {
+		for _, centroid := range centroids {
+			if err := binary.Write(buf, binary.LittleEndian, centroid); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores a quantizer serialized with MarshalBinary
+func (p *ProductQuantizer) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+
+	header := make([]uint32, 3)
+	if err := binary.Read(r, binary.LittleEndian, header); err != nil {
+		return errors.Wrap(err, "read header")
+	}
+
+	parsed, err := NewProductQuantizer(int(header[0]), int(header[1]), int(header[2]))
+	if err != nil {
+		return errors.Wrap(err, "invalid header")
+	}
+
+	parsed.codebook = make([][][]float32, parsed.segments)
+	for s := range parsed.codebook {
+		parsed.codebook[s] = make([][]float32, parsed.centroids)
+		for c := range parsed.codebook[s] {
+			centroid := make([]float32, parsed.segmentLength())
+			if err := binary.Read(r, binary.LittleEndian, centroid); err != nil {
+				return errors.Wrapf(err, "read centroid %d of segment %d", c, s)
+			}
+			parsed.codebook[s][c] = centroid
+		}
+	}
+
+	if r.Len() != 0 {
+		return errors.Errorf("%d unexpected trailing bytes", r.Len())
+	}
+
+	*p = *parsed
+	return nil
+}
+
+func nearest(centroids [][]float32, vector []float32) int {
+	best := 0
+	bestDist := float32(math.MaxFloat32)
+	for i, centroid := range centroids {
+		if dist := l2Squared(centroid, vector); dist < bestDist {
+			best = i
+			bestDist = dist
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,117 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package quantization
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProductQuantizer(t *testing.T) {
+	_, err := NewProductQuantizer(12, 5, 16)
+	assert.EqualError(t, err, "segments must be a positive divisor of the "+
+		"vector dimensions 12, got 5")
+
+	_, err = NewProductQuantizer(12, 4, 257)
+	assert.EqualError(t, err, "centroids must be between 1 and 256, got 257")
+
+	_, err = NewProductQuantizer(12, 4, 256)
+	assert.Nil(t, err)
+}
+
+func TestProductQuantizer(t *testing.T) {
+	// the vectors are scattered closely around 8 points, so 8 centroids per
+	// segment are enough to reconstruct them almost exactly
+	points := randomVectors(8, 12)
+	vectors := make([][]float32, 1000)
+	for i := range vectors {
+		vectors[i] = jitter(points[i%len(points)], 0.001)
+	}
+
+	pq, err := NewProductQuantizer(12, 4, 8)
+	require.Nil(t, err)
+	require.Nil(t, pq.Fit(vectors))
+
+	t.Run("compresses to one byte per segment", func(t *testing.T) {
+		code, err := pq.Encode(vectors[0])
+		require.Nil(t, err)
+		assert.Len(t, code, 4)
+	})
+
+	t.Run("decodes an approximation of the vector", func(t *testing.T) {
+		for _, vector := range vectors[:100] {
+			code, err := pq.Encode(vector)
+			require.Nil(t, err)
+			assert.InDeltaSlice(t, vector, pq.Decode(code), 0.01)
+		}
+	})
+
+	t.Run("looks up the distance to the decoded vector", func(t *testing.T) {
+		query := randomVectors(1, 12)[0]
+		lookup := pq.NewDistanceLookup(query, l2Squared)
+
+		for _, vector := range vectors[:100] {
+			code, err := pq.Encode(vector)
+			require.Nil(t, err)
+
+			expected, _, err := distancer.NewL2SquaredProvider().
+				SingleDist(query, pq.Decode(code))
+			require.Nil(t, err)
+			assert.InDelta(t, expected, lookup.Distance(code), 0.0001)
+		}
+	})
+
+	t.Run("survives serialization", func(t *testing.T) {
+		data, err := pq.MarshalBinary()
+		require.Nil(t, err)
+
+		restored := &ProductQuantizer{}
+		require.Nil(t, restored.UnmarshalBinary(data))
+		assert.Equal(t, pq, restored)
+	})
+
+	t.Run("rejects vectors of different dimensions", func(t *testing.T) {
+		_, err := pq.Encode(make([]float32, 8))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestProductQuantizer_TooFewTrainingVectors(t *testing.T) {
+	pq, err := NewProductQuantizer(12, 4, 8)
+	require.Nil(t, err)
+
+	err = pq.Fit(randomVectors(7, 12))
+	assert.EqualError(t, err, "need at least 8 training vectors, got 7")
+}
+
+func randomVectors(count, dims int) [][]float32 {
+	out := make([][]float32, count)
+	for i := range out {
+		out[i] = make([]float32, dims)
+		for d := range out[i] {
+			out[i][d] = rand.Float32()
+		}
+	}
+	return out
+}
+
+func jitter(vector []float32, amount float32) []float32 {
+	out := make([]float32, len(vector))
+	for i, v := range vector {
+		out[i] = v + (rand.Float32()*2-1)*amount
+	}
+	return out
+}
@@ -68,7 +68,7 @@ func (h *hnsw) SearchByVector(vector []float32, k int, allowList helpers.AllowLi
 	}
 
 	flatSearchCutoff := int(atomic.LoadInt64(&h.flatSearchCutoff))
-	if allowList != nil && !h.forbidFlat && len(allowList) < flatSearchCutoff {
+	if allowList != nil && !h.forbidFlat && allowList.Len() < flatSearchCutoff {
 		return h.flatSearch(vector, k, allowList)
 	}
 	return h.knnSearchByVector(vector, k, h.searchTimeEF(k), allowList)
@@ -163,18 +163,21 @@ func (h *hnsw) searchLayerByVector(queryVector []float32,
 
 	candidates := h.pools.pqCandidates.GetMin(ef)
 	results := h.pools.pqResults.GetMax(ef)
-	distancer := h.distancerProvider.New(queryVector)
+	queryDistancer, err := h.graphDistancer(queryVector)
+	if err != nil {
+		return nil, errors.Wrap(err, "create distancer for query")
+	}
 
 	h.insertViableEntrypointsAsCandidatesAndResults(entrypoints, candidates,
 		results, level, visited, allowList)
 
-	worstResultDistance, err := h.currentWorstResultDistance(results, distancer)
+	worstResultDistance, err := h.currentWorstResultDistance(results, queryDistancer)
 	if err != nil {
 		return nil, errors.Wrapf(err, "calculate distance of current last result")
 	}
 
 	for candidates.Len() > 0 {
-		dist, ok, err := h.distanceToNode(distancer, candidates.Top().ID)
+		dist, ok, err := queryDistancer.distanceToNode(candidates.Top().ID)
 		if err != nil {
 			return nil, errors.Wrap(err, "calculate distance between candidate and query")
 		}
@@ -233,7 +236,7 @@ func (h *hnsw) searchLayerByVector(queryVector []float32,
 			// make sure we never visit this neighbor again
 			visited.Visit(neighborID)
 
-			distance, ok, err := h.distanceToNode(distancer, neighborID)
+			distance, ok, err := queryDistancer.distanceToNode(neighborID)
 			if err != nil {
 				return nil, errors.Wrap(err, "calculate distance between candidate and query")
 			}
@@ -313,11 +316,11 @@ func (h *hnsw) insertViableEntrypointsAsCandidatesAndResults(
 }
 
 func (h *hnsw) currentWorstResultDistance(results *priorityqueue.Queue,
-	distancer distancer.Distancer,
+	queryDistancer nodeDistancer,
 ) (float32, error) {
 	if results.Len() > 0 {
 		id := results.Top().ID
-		d, ok, err := h.distanceToNode(distancer, id)
+		d, ok, err := queryDistancer.distanceToNode(id)
 		if err != nil {
 			return 0, errors.Wrap(err,
 				"calculated distance between worst result and query")
@@ -446,6 +449,15 @@ func (h *hnsw) knnSearchByVector(searchVec []float32, k int,
 		return nil, nil, errors.Wrapf(err, "knn search: search layer at level %d", 0)
 	}
 
+	if h.isCompressed() {
+		// the results were found on the compressed vectors, make sure the best
+		// ones according to the full vectors are kept
+		res, err = h.rescore(searchVec, res)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "knn search")
+		}
+	}
+
 	for res.Len() > k {
 		res.Pop()
 	}
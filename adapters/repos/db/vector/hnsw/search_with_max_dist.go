@@ -58,6 +58,13 @@ func (h *hnsw) KnnSearchByVectorMaxDist(searchVec []float32, dist float32,
 		return nil, errors.Wrapf(err, "knn search: search layer at level %d", 0)
 	}
 
+	if h.isCompressed() {
+		res, err = h.rescore(searchVec, res)
+		if err != nil {
+			return nil, errors.Wrap(err, "knn search")
+		}
+	}
+
 	all := make([]priorityqueue.Item, res.Len())
 	i := res.Len() - 1
 	for res.Len() > 0 {
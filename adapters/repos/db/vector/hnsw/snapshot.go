@@ -15,6 +15,7 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
@@ -121,6 +122,15 @@ func (h *hnsw) ListFiles(ctx context.Context) ([]string, error) {
 	}
 	delete(found, path)
 
+	// the quantizer only exists once the index has been compressed
+	if _, err := os.Stat(h.compressionPath()); err == nil {
+		path, err := filepath.Rel(h.commitLog.RootPath(), h.compressionPath())
+		if err != nil {
+			return nil, errors.Wrap(err, "quantizer file")
+		}
+		found[path] = struct{}{}
+	}
+
 	files, i := make([]string, len(found)), 0
 	for file := range found {
 		files[i] = file
@@ -31,6 +31,10 @@ func (h *hnsw) init(cfg Config) error {
 		return errors.Wrapf(err, "restore hnsw index %q", cfg.ID)
 	}
 
+	if err := h.restoreQuantizer(); err != nil {
+		return errors.Wrapf(err, "restore hnsw index %q", cfg.ID)
+	}
+
 	// init commit logger for future writes
 	cl, err := cfg.MakeCommitLoggerThunk()
 	if err != nil {
@@ -114,6 +118,7 @@ func (h *hnsw) restoreFromDisk() error {
 
 	// make sure the cache fits the current size
 	h.cache.grow(uint64(len(h.nodes)))
+	h.compressedVectors.grow(uint64(len(h.nodes)))
 
 	// make sure the visited list pool fits the current size
 	h.pools.visitedLists.Destroy()
@@ -137,6 +142,7 @@ func (h *hnsw) tombstoneCleanup(stopFunc cyclemanager.StopFunc) {
 // getVectorForID.
 func (h *hnsw) PostStartup() {
 	h.tombstoneCleanupCycle.Start()
+	h.compressionCycle.Start()
 	h.prefillCache()
 }
 
@@ -16,6 +16,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/entities/cyclemanager"
 	"github.com/semi-technologies/weaviate/entities/schema"
 )
 
@@ -75,3 +76,7 @@ func (i *Index) ListFiles(context.Context) ([]string, error) {
 func (i *Index) ResumeMaintenance(context.Context) error {
 	return nil
 }
+
+func (i *Index) CleanUpTombstonedNodes(stopFunc cyclemanager.StopFunc) error {
+	return nil
+}
@@ -14,7 +14,11 @@ package db
 import (
 	"context"
 
+	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/cyclemanager"
 	"github.com/semi-technologies/weaviate/entities/schema"
 )
 
@@ -34,4 +38,21 @@ type VectorIndex interface {
 	SwitchCommitLogs(ctx context.Context) error
 	ListFiles(ctx context.Context) ([]string, error)
 	ResumeMaintenance(ctx context.Context) error
+	CleanUpTombstonedNodes(stopFunc cyclemanager.StopFunc) error
+}
+
+// ParseVectorIndexConfig parses the user-specified config of a vector index
+// of the given type
+func ParseVectorIndexConfig(in interface{},
+	vectorIndexType string,
+) (schema.VectorIndexConfig, error) {
+	switch vectorIndexType {
+	case "hnsw":
+		return hnsw.ParseUserConfig(in)
+	case flat.IndexType:
+		return flat.ParseUserConfig(in)
+	default:
+		return nil, errors.Errorf("unsupported vector index type: %q",
+			vectorIndexType)
+	}
 }
@@ -38,10 +38,16 @@ type Client struct {
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	BackupsCancel(params *BackupsCancelParams, authInfo runtime.ClientAuthInfoWriter) (*BackupsCancelNoContent, error)
+
 	BackupsCreate(params *BackupsCreateParams, authInfo runtime.ClientAuthInfoWriter) (*BackupsCreateOK, error)
 
 	BackupsCreateStatus(params *BackupsCreateStatusParams, authInfo runtime.ClientAuthInfoWriter) (*BackupsCreateStatusOK, error)
 
+	BackupsDelete(params *BackupsDeleteParams, authInfo runtime.ClientAuthInfoWriter) (*BackupsDeleteNoContent, error)
+
+	BackupsList(params *BackupsListParams, authInfo runtime.ClientAuthInfoWriter) (*BackupsListOK, error)
+
 	BackupsRestore(params *BackupsRestoreParams, authInfo runtime.ClientAuthInfoWriter) (*BackupsRestoreOK, error)
 
 	BackupsRestoreStatus(params *BackupsRestoreStatusParams, authInfo runtime.ClientAuthInfoWriter) (*BackupsRestoreStatusOK, error)
@@ -49,6 +55,41 @@ type ClientService interface {
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+BackupsCancel Cancels an in-flight backup creation or restoration
+*/
+func (a *Client) BackupsCancel(params *BackupsCancelParams, authInfo runtime.ClientAuthInfoWriter) (*BackupsCancelNoContent, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewBackupsCancelParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "backups.cancel",
+		Method:             "POST",
+		PathPattern:        "/backups/{storageName}/{id}/cancel",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &BackupsCancelReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*BackupsCancelNoContent)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for backups.cancel: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 BackupsCreate Starts a process of creating a backup for a set of classes
 */
@@ -119,6 +160,76 @@ func (a *Client) BackupsCreateStatus(params *BackupsCreateStatusParams, authInfo
 	panic(msg)
 }
 
+/*
+BackupsDelete Deletes a backup from the storage
+*/
+func (a *Client) BackupsDelete(params *BackupsDeleteParams, authInfo runtime.ClientAuthInfoWriter) (*BackupsDeleteNoContent, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewBackupsDeleteParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "backups.delete",
+		Method:             "DELETE",
+		PathPattern:        "/backups/{storageName}/{id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &BackupsDeleteReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*BackupsDeleteNoContent)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for backups.delete: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+BackupsList Lists all backups on the storage
+*/
+func (a *Client) BackupsList(params *BackupsListParams, authInfo runtime.ClientAuthInfoWriter) (*BackupsListOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewBackupsListParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "backups.list",
+		Method:             "GET",
+		PathPattern:        "/backups/{storageName}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &BackupsListReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*BackupsListOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for backups.list: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 BackupsRestore Starts a process of restoring a backup for a set of classes
 */
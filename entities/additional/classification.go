@@ -25,12 +25,15 @@ type Properties struct {
 	Classification     bool                   `json:"classification"`
 	RefMeta            bool                   `json:"refMeta"`
 	Vector             bool                   `json:"vector"`
+	Vectors            bool                   `json:"vectors"`
 	Certainty          bool                   `json:"certainty"`
 	ID                 bool                   `json:"id"`
 	CreationTimeUnix   bool                   `json:"creationTimeUnix"`
 	LastUpdateTimeUnix bool                   `json:"lastUpdateTimeUnix"`
 	ModuleParams       map[string]interface{} `json:"moduleParams"`
 	Distance           bool                   `json:"distance"`
+	Score              bool                   `json:"score"`
+	ExplainScore       bool                   `json:"explainScore"`
 
 	// ReferenceQuery is used to indicate that a search
 	// is being conducted on behalf of a referenced
@@ -28,6 +28,7 @@ type Params struct {
 	Limit            *int                 `json:"limit"`
 	ObjectLimit      *int                 `json:"objectLimit"`
 	SearchVector     []float32
+	TargetVector     string
 	Certainty        float64
 	NearVector       *searchparams.NearVector
 	NearObject       *searchparams.NearObject
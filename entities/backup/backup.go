@@ -12,7 +12,6 @@
 package backup
 
 import (
-	"path"
 	"sync"
 	"time"
 )
@@ -31,39 +30,121 @@ type ShardMetadata struct {
 }
 
 type SnapshotFile struct {
-	Class string `json:"class"` // Name of class to which the file belongs
-	Node  string `json:"node"`  // Name of node to which the file belongs
-	Shard string `json:"shard"` // Name of shard to which the file belongs
-	Path  string `json:"path"`  // Relative paths to files in the snapshot
+	Class    string `json:"class"`    // Name of class to which the file belongs
+	Node     string `json:"node"`     // Name of node to which the file belongs
+	Shard    string `json:"shard"`    // Name of shard to which the file belongs
+	Path     string `json:"path"`     // Relative paths to files in the snapshot
+	Checksum string `json:"checksum"` // Hex encoded sha256 of the file contents
+	Size     int64  `json:"size"`     // Size of the file in bytes, uncompressed
+
+	// Snapshot is the ID of an earlier snapshot which holds the contents of
+	// the file. It is only set on files of incremental snapshots which did
+	// not change since the parent snapshot.
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// Chunk is the key of the compressed archive the file is stored in. It
+	// is empty if the file is stored uncompressed as an object of its own.
+	Chunk       string      `json:"chunk,omitempty"`
+	Compression Compression `json:"compression,omitempty"` // Compression of the chunk
 }
 
-type Snapshot struct {
-	StartedAt   time.Time `json:"startedAt"`
-	CompletedAt time.Time `json:"completedAt"`
+// StoredIn returns the ID of the snapshot under which the contents of the
+// file are stored, snapshotID being the ID of the snapshot the file is
+// listed in.
+func (f SnapshotFile) StoredIn(snapshotID string) string {
+	if f.Snapshot != "" {
+		return f.Snapshot
+	}
+	return snapshotID
+}
 
-	ID            string                    `json:"id"`        // User created snapshot id
-	ClassName     string                    `json:"className"` // DB class name, also selected by user
-	Status        string                    `json:"status"`    // "STARTED|TRANSFERRING|TRANSFERRED|SUCCESS|FAILED"
+// ShardSnapshot is the contribution of a single shard to a class snapshot.
+// It is created on the node which owns the shard, which is not necessarily
+// the node coordinating the backup.
+type ShardSnapshot struct {
+	Files    []SnapshotFile `json:"files"`
+	Metadata *ShardMetadata `json:"metadata"`
+}
+
+// ClassSnapshot contains everything required to restore a single class: the
+// files of all of its shards across all nodes, the per-shard metadata, as
+// well as the schema and sharding state at the time of the snapshot.
+type ClassSnapshot struct {
+	Name          string                    `json:"name"`
 	Files         []SnapshotFile            `json:"files"`
-	ShardMetadata map[string]*ShardMetadata `json:"shardMetadata"`
+	ShardMetadata map[string]*ShardMetadata `json:"shardMetadata"` // keyed by shard name
 	ShardingState []byte                    `json:"shardingState"`
 	Schema        []byte                    `json:"schema"`
-	ServerVersion string                    `json:"serverVersion"`
-	Error         string                    `json:"error"`
 
 	// so shard-level snapshotting can be safely parallelized
 	sync.Mutex `json:"-"`
 }
 
-func NewSnapshot(className, id string, startedAt time.Time) *Snapshot {
-	return &Snapshot{
-		ClassName:     className,
-		ID:            id,
-		StartedAt:     startedAt,
+func NewClassSnapshot(className string) *ClassSnapshot {
+	return &ClassSnapshot{
+		Name:          className,
 		ShardMetadata: make(map[string]*ShardMetadata),
 	}
 }
 
-func BuildSnapshotPath(basePath, className, id string) string {
-	return path.Join(basePath, "snapshots", className, id) + ".json"
+// AddShard merges the files and metadata of a single shard into the class
+// snapshot. It is safe to be called concurrently.
+func (c *ClassSnapshot) AddShard(shardName string, shard *ShardSnapshot) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.Files = append(c.Files, shard.Files...)
+	c.ShardMetadata[shardName] = shard.Metadata
+}
+
+// Snapshot is the single manifest of a backup. A backup can contain any
+// number of classes, each of which can span multiple shards on multiple
+// nodes.
+type Snapshot struct {
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+
+	ID            string           `json:"id"`       // User created snapshot id
+	ParentID      string           `json:"parentId"` // Parent of an incremental snapshot, empty otherwise
+	Classes       []*ClassSnapshot `json:"classes"`  // DB classes, selected by the user
+	Status        string           `json:"status"`   // "STARTED|TRANSFERRING|TRANSFERRED|SUCCESS|FAILED|CANCELED"
+	Size          int64            `json:"size"`     // Bytes uploaded to the storage, excluding referenced files
+	Compression   Compression      `json:"compression"`
+	ServerVersion string           `json:"serverVersion"`
+	Error         string           `json:"error"`
+}
+
+func NewSnapshot(id string, classes []string, startedAt time.Time) *Snapshot {
+	snap := &Snapshot{
+		ID:        id,
+		StartedAt: startedAt,
+		Classes:   make([]*ClassSnapshot, len(classes)),
+	}
+
+	for i, className := range classes {
+		snap.Classes[i] = NewClassSnapshot(className)
+	}
+
+	return snap
+}
+
+// ClassNames returns the names of all classes contained in the snapshot in
+// the order in which they were added
+func (s *Snapshot) ClassNames() []string {
+	names := make([]string, len(s.Classes))
+	for i, class := range s.Classes {
+		names[i] = class.Name
+	}
+	return names
+}
+
+// GetClass returns the snapshot of the specified class or nil if the class
+// is not part of the snapshot
+func (s *Snapshot) GetClass(className string) *ClassSnapshot {
+	for _, class := range s.Classes {
+		if class.Name == className {
+			return class
+		}
+	}
+	return nil
 }
@@ -0,0 +1,50 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package backup
+
+import "fmt"
+
+// Compression determines how the files of a snapshot are stored. Without
+// compression every file is stored as an object of its own, otherwise the
+// files are streamed into compressed tar archives, the chunks.
+type Compression string
+
+const (
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+// ParseCompression turns the compression of a user request into a
+// Compression, an empty value means no compression
+func ParseCompression(s string) (Compression, error) {
+	switch c := Compression(s); c {
+	case "":
+		return CompressionNone, nil
+	case CompressionNone, CompressionGzip, CompressionZstd:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unsupported compression %q", s)
+	}
+}
+
+// Extension returns the file extension of the chunks
+func (c Compression) Extension() string {
+	switch c {
+	case CompressionGzip:
+		return ".tar.gz"
+	case CompressionZstd:
+		return ".tar.zst"
+	default:
+		return ".tar"
+	}
+}
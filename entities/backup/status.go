@@ -17,6 +17,7 @@ const (
 	CreateTransferred  CreateStatus = "TRANSFERRED"
 	CreateSuccess      CreateStatus = "SUCCESS"
 	CreateFailed       CreateStatus = "FAILED"
+	CreateCanceled     CreateStatus = "CANCELED"
 )
 
 const (
@@ -25,6 +26,7 @@ const (
 	RestoreTransferred  RestoreStatus = "TRANSFERRED"
 	RestoreSuccess      RestoreStatus = "SUCCESS"
 	RestoreFailed       RestoreStatus = "FAILED"
+	RestoreCanceled     RestoreStatus = "CANCELED"
 )
 
 type (
@@ -32,6 +32,10 @@ const (
 	OperatorNot              Operator = 9
 	OperatorWithinGeoRange   Operator = 10
 	OperatorLike             Operator = 11
+	OperatorPhrase           Operator = 12
+	OperatorFuzzy            Operator = 13
+	OperatorContainsAny      Operator = 14
+	OperatorContainsAll      Operator = 15
 )
 
 func (o Operator) OnValue() bool {
@@ -43,13 +47,23 @@ func (o Operator) OnValue() bool {
 		OperatorLessThan,
 		OperatorLessThanEqual,
 		OperatorWithinGeoRange,
-		OperatorLike:
+		OperatorLike,
+		OperatorPhrase,
+		OperatorFuzzy,
+		OperatorContainsAny,
+		OperatorContainsAll:
 		return true
 	default:
 		return false
 	}
 }
 
+// OnValueList is true for the operators which compare a property to a list
+// of values instead of a single value
+func (o Operator) OnValueList() bool {
+	return o == OperatorContainsAny || o == OperatorContainsAll
+}
+
 func (o Operator) Name() string {
 	switch o {
 	case OperatorEqual:
@@ -74,6 +88,14 @@ func (o Operator) Name() string {
 		return "WithinGeoRange"
 	case OperatorLike:
 		return "Like"
+	case OperatorPhrase:
+		return "Phrase"
+	case OperatorFuzzy:
+		return "Fuzzy"
+	case OperatorContainsAny:
+		return "ContainsAny"
+	case OperatorContainsAll:
+		return "ContainsAll"
 	default:
 		panic("Unknown operator")
 	}
@@ -106,9 +128,48 @@ func (v *Value) UnmarshalJSON(data []byte) error {
 		v.Value = int(asFloat)
 	}
 
+	if asList, ok := v.Value.([]interface{}); ok {
+		v.Value = typedValueList(asList, v.Type)
+	}
+
 	return nil
 }
 
+// typedValueList restores the value list of a ContainsAny or ContainsAll
+// filter to the same types the filter was created with
+func typedValueList(in []interface{}, dt schema.DataType) interface{} {
+	switch dt {
+	case schema.DataTypeIntArray:
+		out := make([]int, len(in))
+		for i := range in {
+			asFloat, _ := in[i].(float64)
+			out[i] = int(asFloat)
+		}
+		return out
+	case schema.DataTypeNumberArray:
+		out := make([]float64, len(in))
+		for i := range in {
+			out[i], _ = in[i].(float64)
+		}
+		return out
+	case schema.DataTypeBooleanArray:
+		out := make([]bool, len(in))
+		for i := range in {
+			out[i], _ = in[i].(bool)
+		}
+		return out
+	case schema.DataTypeStringArray, schema.DataTypeTextArray,
+		schema.DataTypeDateArray, schema.DataTypeUUIDArray:
+		out := make([]string, len(in))
+		for i := range in {
+			out[i], _ = in[i].(string)
+		}
+		return out
+	default:
+		return in
+	}
+}
+
 type Clause struct {
 	Operator Operator `json:"operator"`
 	On       *Path    `json:"on"`
@@ -52,4 +52,24 @@ func TestSerializeValue(t *testing.T) {
 
 		assert.Equal(t, before, after)
 	})
+	t.Run("with value lists", func(t *testing.T) {
+		tests := []Value{
+			{Value: []int{3, 4}, Type: schema.DataTypeIntArray},
+			{Value: []float64{3.5, 4}, Type: schema.DataTypeNumberArray},
+			{Value: []bool{true, false}, Type: schema.DataTypeBooleanArray},
+			{Value: []string{"a", "b"}, Type: schema.DataTypeTextArray},
+			{Value: []string{"28f3f61b-b524-45e0-9bbe-2c1550bf73d2"}, Type: schema.DataTypeUUIDArray},
+		}
+
+		for _, before := range tests {
+			bytes, err := json.Marshal(before)
+			require.Nil(t, err)
+
+			var after Value
+			err = json.Unmarshal(bytes, &after)
+			require.Nil(t, err)
+
+			assert.Equal(t, before, after)
+		}
+	})
 }
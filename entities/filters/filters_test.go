@@ -33,6 +33,10 @@ func TestOperators(t *testing.T) {
 		{op: OperatorLessThan, expectedName: "LessThan", expectedOnValue: true},
 		{op: OperatorWithinGeoRange, expectedName: "WithinGeoRange", expectedOnValue: true},
 		{op: OperatorLike, expectedName: "Like", expectedOnValue: true},
+		{op: OperatorPhrase, expectedName: "Phrase", expectedOnValue: true},
+		{op: OperatorFuzzy, expectedName: "Fuzzy", expectedOnValue: true},
+		{op: OperatorContainsAny, expectedName: "ContainsAny", expectedOnValue: true},
+		{op: OperatorContainsAll, expectedName: "ContainsAll", expectedOnValue: true},
 		{op: OperatorAnd, expectedName: "And", expectedOnValue: false},
 		{op: OperatorOr, expectedName: "Or", expectedOnValue: false},
 		{op: OperatorNot, expectedName: "Not", expectedOnValue: false},
@@ -76,6 +76,16 @@ func validateClause(sch schema.Schema, clause *Clause) error {
 			"In this case make sure your path contains 3 elements in the form of "+
 			"[<propName>, <ClassNameOfReferencedClass>, <primitvePropOnClass>]",
 			propName, prop.DataType[0])
+	} else if schema.IsNestedDataType(prop.DataType) {
+		return errors.Errorf("Property %q is an object prop. Filter on one of its "+
+			"nested props instead, with a path in the form of [<propName>, <nestedPropName>]",
+			propName)
+	} else if clause.Operator.OnValueList() {
+		return validateValueListClause(prop.DataType, clause)
+	} else if _, ok := schema.IsArrayType(clause.Value.Type); ok {
+		return errors.Errorf("%q can only be used with the operators %s and %s",
+			valueNameFromDataType(clause.Value.Type), OperatorContainsAny.Name(),
+			OperatorContainsAll.Name())
 	} else if baseType, ok := schema.IsArrayType(schema.DataType(prop.DataType[0])); ok {
 		if baseType != clause.Value.Type {
 			return errors.Errorf("data type filter cannot use %q on type %q, use %q instead",
@@ -93,7 +103,28 @@ func validateClause(sch schema.Schema, clause *Clause) error {
 	return nil
 }
 
+// validateValueListClause makes sure the values of a ContainsAny or
+// ContainsAll filter are of the type of the property. Both array props and
+// single value props can be compared to a list of values.
+func validateValueListClause(propDataType []string, clause *Clause) error {
+	propType := schema.DataType(propDataType[0])
+	if baseType, ok := schema.IsArrayType(propType); ok {
+		propType = baseType
+	}
+
+	if valueType, ok := schema.IsArrayType(clause.Value.Type); !ok || valueType != propType {
+		return errors.Errorf("operator %s cannot use %q on type %q, use %q instead",
+			clause.Operator.Name(), valueNameFromDataType(clause.Value.Type),
+			schema.DataType(propDataType[0]), valueNameFromDataType(propType)+"Array")
+	}
+
+	return nil
+}
+
 func valueNameFromDataType(dt schema.DataType) string {
+	if baseType, ok := schema.IsArrayType(dt); ok {
+		return valueNameFromDataType(baseType) + "Array"
+	}
 	return "value" + strings.ToUpper(string(dt[0])) + string(dt[1:])
 }
 
@@ -80,6 +80,8 @@ func appendNestedPath(p *Path, omitClass bool) []string {
 // It parses an array of strings in this format
 // [0] ClassName -> The root class name we're drilling down from
 // [1] propertyName -> The property name we're interested in.
+// Further property names continue the path of a nested property, they are
+// joined into a single property, e.g. "address.city".
 func ParsePath(pathElements []interface{}, rootClass string) (*Path, error) {
 	// we need to manually insert the root class, as that is omitted from the user
 	pathElements = append([]interface{}{rootClass}, pathElements...)
@@ -94,7 +96,18 @@ func ParsePath(pathElements []interface{}, rootClass string) (*Path, error) {
 	// Now go through the path elements, step over it in increments of two.
 	// Simple case:      ClassName -> property
 	// Nested path case: ClassName -> HasRef -> ClassOfRef -> Property
+	// Nested prop case: ClassName -> property -> nestedProperty
 	for i := 0; i < len(pathElements); i += 2 {
+		if i > 0 {
+			if segment, ok := nestedPathSegment(pathElements[i]); ok {
+				current.Property = schema.PropertyName(schema.NestedPropertyPath(
+					string(current.Property), segment))
+				// only a single element is consumed
+				i--
+				continue
+			}
+		}
+
 		lengthRemaining := len(pathElements) - i
 		if lengthRemaining < 2 {
 			return nil, fmt.Errorf("missing an argument after '%s'", pathElements[i])
@@ -137,3 +150,23 @@ func ParsePath(pathElements []interface{}, rootClass string) (*Path, error) {
 
 	return sentinel.Child, nil
 }
+
+// nestedPathSegment is a property name in the position of a class name, which
+// continues the path of a nested property. Class names are capitalized,
+// property names are not.
+func nestedPathSegment(element interface{}) (string, bool) {
+	raw, ok := element.(string)
+	if !ok {
+		return "", false
+	}
+
+	if _, err := schema.ValidateClassName(raw); err == nil {
+		return "", false
+	}
+
+	if _, err := schema.ValidatePropertyName(raw); err != nil {
+		return "", false
+	}
+
+	return raw, true
+}
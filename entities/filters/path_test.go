@@ -64,6 +64,44 @@ func Test_ParsePath(t *testing.T) {
 
 		// Print Slice
 	})
+
+	t.Run("with a nested prop", func(t *testing.T) {
+		rootClass := "Article"
+		segments := []interface{}{"authors", "affiliation", "name"}
+		expectedPath := &Path{
+			Class:    "Article",
+			Property: "authors.affiliation.name",
+		}
+
+		path, err := ParsePath(segments, rootClass)
+
+		require.Nil(t, err, "should not error")
+		assert.Equal(t, expectedPath, path, "should parse the path correctly")
+	})
+
+	t.Run("with a nested prop of a referenced class", func(t *testing.T) {
+		rootClass := "Article"
+		segments := []interface{}{"publishedBy", "Publisher", "address", "city"}
+		expectedPath := &Path{
+			Class:    "Article",
+			Property: "publishedBy",
+			Child: &Path{
+				Class:    "Publisher",
+				Property: "address.city",
+			},
+		}
+
+		path, err := ParsePath(segments, rootClass)
+
+		require.Nil(t, err, "should not error")
+		assert.Equal(t, expectedPath, path, "should parse the path correctly")
+	})
+
+	t.Run("with a missing prop after a class", func(t *testing.T) {
+		_, err := ParsePath([]interface{}{"publishedBy", "Publisher"}, "Article")
+
+		assert.NotNil(t, err, "should error")
+	})
 }
 
 func Test_SlicePath(t *testing.T) {
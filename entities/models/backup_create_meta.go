@@ -39,11 +39,14 @@ type BackupCreateMeta struct {
 	// The ID of the backup. Must be URL-safe and work as a filesystem path, only lowercase, numbers, underscore, minus characters allowed.
 	ID string `json:"id,omitempty"`
 
+	// The ID of the parent backup, if this is an incremental backup
+	Parent string `json:"parent,omitempty"`
+
 	// destination path of backup files proper to selected storage
 	Path string `json:"path,omitempty"`
 
 	// phase of backup creation process
-	// Enum: [STARTED TRANSFERRING TRANSFERRED SUCCESS FAILED]
+	// Enum: [STARTED TRANSFERRING TRANSFERRED SUCCESS FAILED CANCELED]
 	Status *string `json:"status,omitempty"`
 
 	// Storage name e.g. filesystem, gcs, s3.
@@ -68,7 +71,7 @@ var backupCreateMetaTypeStatusPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["STARTED","TRANSFERRING","TRANSFERRED","SUCCESS","FAILED"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["STARTED","TRANSFERRING","TRANSFERRED","SUCCESS","FAILED","CANCELED"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -92,6 +95,9 @@ const (
 
 	// BackupCreateMetaStatusFAILED captures enum value "FAILED"
 	BackupCreateMetaStatusFAILED string = "FAILED"
+
+	// BackupCreateMetaStatusCANCELED captures enum value "CANCELED"
+	BackupCreateMetaStatusCANCELED string = "CANCELED"
 )
 
 // prop value enum
@@ -17,8 +17,12 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // BackupCreateRequest Request body for creating a backup of a set of classes
@@ -26,6 +30,10 @@ import (
 // swagger:model BackupCreateRequest
 type BackupCreateRequest struct {
 
+	// Compression of the backup files. Unless set to none, the files are streamed into compressed chunks on the storage.
+	// Enum: [none gzip zstd]
+	Compression string `json:"compression,omitempty"`
+
 	// Custom configuration for the backup creation process
 	Config interface{} `json:"config,omitempty"`
 
@@ -37,10 +45,68 @@ type BackupCreateRequest struct {
 
 	// List of classes to include in the backup creation process
 	Include []string `json:"include"`
+
+	// The ID of an existing backup to use as the parent of an incremental backup. Files which did not change since the parent backup are not uploaded again, but referenced.
+	Parent string `json:"parent,omitempty"`
 }
 
 // Validate validates this backup create request
 func (m *BackupCreateRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateCompression(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var backupCreateRequestTypeCompressionPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["none","gzip","zstd"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		backupCreateRequestTypeCompressionPropEnum = append(backupCreateRequestTypeCompressionPropEnum, v)
+	}
+}
+
+const (
+
+	// BackupCreateRequestCompressionNone captures enum value "none"
+	BackupCreateRequestCompressionNone string = "none"
+
+	// BackupCreateRequestCompressionGzip captures enum value "gzip"
+	BackupCreateRequestCompressionGzip string = "gzip"
+
+	// BackupCreateRequestCompressionZstd captures enum value "zstd"
+	BackupCreateRequestCompressionZstd string = "zstd"
+)
+
+// prop value enum
+func (m *BackupCreateRequest) validateCompressionEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, backupCreateRequestTypeCompressionPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *BackupCreateRequest) validateCompression(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Compression) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateCompressionEnum("compression", "body", m.Compression); err != nil {
+		return err
+	}
+
 	return nil
 }
 
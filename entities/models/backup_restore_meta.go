@@ -43,7 +43,7 @@ type BackupRestoreMeta struct {
 	Path string `json:"path,omitempty"`
 
 	// phase of backup restoration process
-	// Enum: [STARTED TRANSFERRING TRANSFERRED SUCCESS FAILED]
+	// Enum: [STARTED TRANSFERRING TRANSFERRED SUCCESS FAILED CANCELED]
 	Status *string `json:"status,omitempty"`
 
 	// Storage name e.g. filesystem, gcs, s3.
@@ -68,7 +68,7 @@ var backupRestoreMetaTypeStatusPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["STARTED","TRANSFERRING","TRANSFERRED","SUCCESS","FAILED"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["STARTED","TRANSFERRING","TRANSFERRED","SUCCESS","FAILED","CANCELED"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -92,6 +92,9 @@ const (
 
 	// BackupRestoreMetaStatusFAILED captures enum value "FAILED"
 	BackupRestoreMetaStatusFAILED string = "FAILED"
+
+	// BackupRestoreMetaStatusCANCELED captures enum value "CANCELED"
+	BackupRestoreMetaStatusCANCELED string = "CANCELED"
 )
 
 // prop value enum
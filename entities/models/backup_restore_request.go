@@ -37,6 +37,9 @@ type BackupRestoreRequest struct {
 
 	// List of classes to include in the backup restoration process
 	Include []string `json:"include"`
+
+	// Map of class names in the backup to the names under which they are restored. Classes which are not listed keep their original name. Cross-references to renamed classes are renamed as well.
+	Rename map[string]string `json:"rename,omitempty"`
 }
 
 // Validate validates this backup restore request
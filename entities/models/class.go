@@ -47,6 +47,9 @@ type Class struct {
 	// Manage how the index should be sharded and distributed in the cluster
 	ShardingConfig interface{} `json:"shardingConfig,omitempty"`
 
+	// Named vectors of the class in addition to its regular vector, each with its own vectorizer and vector index
+	VectorConfig map[string]VectorConfig `json:"vectorConfig,omitempty"`
+
 	// Vector-index config, that is specific to the type of index selected in vectorIndexType
 	VectorIndexConfig interface{} `json:"vectorIndexConfig,omitempty"`
 
@@ -69,6 +72,10 @@ func (m *Class) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateVectorConfig(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -118,6 +125,25 @@ func (m *Class) validateProperties(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Class) validateVectorConfig(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.VectorConfig) { // not required
+		return nil
+	}
+
+	for k := range m.VectorConfig {
+
+		if val, ok := m.VectorConfig[k]; ok {
+			if err := val.Validate(formats); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *Class) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -33,6 +33,9 @@ type InvertedIndexConfig struct {
 	// Asynchronous index clean up happens every n seconds
 	CleanupIntervalSeconds int64 `json:"cleanupIntervalSeconds,omitempty"`
 
+	// Index the positions of the terms of text and text[] properties, which is required for phrase queries. Enabling it on an existing class indexes the positions of all existing objects
+	IndexPositions bool `json:"indexPositions,omitempty"`
+
 	// Index each object by its internal timestamps
 	IndexTimestamps bool `json:"indexTimestamps,omitempty"`
 
@@ -52,6 +52,9 @@ type Object struct {
 
 	// vector weights
 	VectorWeights VectorWeights `json:"vectorWeights,omitempty"`
+
+	// This object's named vectors. Read-only for vectors using a vectorizer other than 'none'.
+	Vectors Vectors `json:"vectors,omitempty"`
 }
 
 // Validate validates this object
@@ -70,6 +73,10 @@ func (m *Object) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateVectors(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -121,6 +128,22 @@ func (m *Object) validateVector(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Object) validateVectors(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Vectors) { // not required
+		return nil
+	}
+
+	if err := m.Vectors.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("vectors")
+		}
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *Object) MarshalBinary() ([]byte, error) {
 	if m == nil {
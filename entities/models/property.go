@@ -18,6 +18,7 @@ package models
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -30,6 +31,9 @@ import (
 // swagger:model Property
 type Property struct {
 
+	// analyzer
+	Analyzer *PropertyAnalyzerConfig `json:"analyzer,omitempty"`
+
 	// Can be a reference to another type when it starts with a capital (for example Person), otherwise "string" or "int".
 	DataType []string `json:"dataType"`
 
@@ -45,8 +49,11 @@ type Property struct {
 	// Name of the property as URI relative to the schema URL.
 	Name string `json:"name,omitempty"`
 
-	// Determines tokenization of the property as separate words or whole field. Optional. Applies to string, string[], text and text[] data types. Allowed values are `word` (default) and `field` for string and string[], `word` (default) for text and text[]. Not supported for remaining data types
-	// Enum: [word field]
+	// The properties of the nested object(s). Required for the object and object[] data types and not supported for the remaining data types. Nested properties can be of the primitive data types except geoCoordinates, phoneNumber and blob, or be nested objects themselves
+	NestedProperties []*Property `json:"nestedProperties,omitempty"`
+
+	// Determines tokenization of the property as separate words or whole field. Optional. Applies to string, string[], text and text[] data types. Allowed values are `word` (default) and `field` for string and string[], `word` (default), `trigram` and `cjk` for text and text[]. `trigram` splits words into overlapping trigrams, which speeds up substring (like) filters. `cjk` splits runs of Chinese, Japanese and Korean characters into overlapping bigrams. Not supported for remaining data types
+	// Enum: [word field trigram cjk]
 	Tokenization string `json:"tokenization,omitempty"`
 }
 
@@ -54,6 +61,14 @@ type Property struct {
 func (m *Property) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAnalyzer(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNestedProperties(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTokenization(formats); err != nil {
 		res = append(res, err)
 	}
@@ -64,11 +79,54 @@ func (m *Property) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Property) validateAnalyzer(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Analyzer) { // not required
+		return nil
+	}
+
+	if m.Analyzer != nil {
+		if err := m.Analyzer.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("analyzer")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Property) validateNestedProperties(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NestedProperties) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.NestedProperties); i++ {
+		if swag.IsZero(m.NestedProperties[i]) { // not required
+			continue
+		}
+
+		if m.NestedProperties[i] != nil {
+			if err := m.NestedProperties[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("nestedProperties" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 var propertyTypeTokenizationPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["word","field"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["word","field","trigram","cjk"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -83,6 +141,12 @@ const (
 
 	// PropertyTokenizationField captures enum value "field"
 	PropertyTokenizationField string = "field"
+
+	// PropertyTokenizationTrigram captures enum value "trigram"
+	PropertyTokenizationTrigram string = "trigram"
+
+	// PropertyTokenizationCjk captures enum value "cjk"
+	PropertyTokenizationCjk string = "cjk"
 )
 
 // prop value enum
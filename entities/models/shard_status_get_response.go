@@ -17,6 +17,9 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -29,12 +32,49 @@ type ShardStatusGetResponse struct {
 	// Name of the shard
 	Name string `json:"name,omitempty"`
 
+	// The properties of the shard which are reindexed after their tokenization or indexInverted setting was changed
+	Reindexing []*PropertyReindexStatus `json:"reindexing"`
+
 	// Status of the shard
 	Status string `json:"status,omitempty"`
 }
 
 // Validate validates this shard status get response
 func (m *ShardStatusGetResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateReindexing(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ShardStatusGetResponse) validateReindexing(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Reindexing) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Reindexing); i++ {
+		if swag.IsZero(m.Reindexing[i]) { // not required
+			continue
+		}
+
+		if m.Reindexing[i] != nil {
+			if err := m.Reindexing[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("reindexing" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
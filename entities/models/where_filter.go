@@ -35,7 +35,7 @@ type WhereFilter struct {
 	Operands []*WhereFilter `json:"operands"`
 
 	// operator to use
-	// Enum: [And Or Equal Like Not NotEqual GreaterThan GreaterThanEqual LessThan LessThanEqual WithinGeoRange]
+	// Enum: [And Or Equal Like Phrase Fuzzy Not NotEqual GreaterThan GreaterThanEqual LessThan LessThanEqual WithinGeoRange ContainsAny ContainsAll]
 	Operator string `json:"operator,omitempty"`
 
 	// path to the property currently being filtered
@@ -44,23 +44,47 @@ type WhereFilter struct {
 	// value as boolean
 	ValueBoolean *bool `json:"valueBoolean,omitempty"`
 
+	// value as list of booleans (for ContainsAny and ContainsAll)
+	ValueBooleanArray []bool `json:"valueBooleanArray,omitempty"`
+
 	// value as date (as string)
 	ValueDate *string `json:"valueDate,omitempty"`
 
+	// value as list of dates (as strings, for ContainsAny and ContainsAll)
+	ValueDateArray []string `json:"valueDateArray,omitempty"`
+
 	// value as geo coordinates and distance
 	ValueGeoRange *WhereFilterGeoRange `json:"valueGeoRange,omitempty"`
 
 	// value as integer
 	ValueInt *int64 `json:"valueInt,omitempty"`
 
+	// value as list of integers (for ContainsAny and ContainsAll)
+	ValueIntArray []int64 `json:"valueIntArray,omitempty"`
+
 	// value as number/float
 	ValueNumber *float64 `json:"valueNumber,omitempty"`
 
+	// value as list of numbers/floats (for ContainsAny and ContainsAll)
+	ValueNumberArray []float64 `json:"valueNumberArray,omitempty"`
+
 	// value as string
 	ValueString *string `json:"valueString,omitempty"`
 
+	// value as list of strings (for ContainsAny and ContainsAll)
+	ValueStringArray []string `json:"valueStringArray,omitempty"`
+
 	// value as text (on text props)
 	ValueText *string `json:"valueText,omitempty"`
+
+	// value as list of texts (on text props, for ContainsAny and ContainsAll)
+	ValueTextArray []string `json:"valueTextArray,omitempty"`
+
+	// value as uuid (on uuid props)
+	ValueUUID *string `json:"valueUuid,omitempty"`
+
+	// value as list of uuids (on uuid props, for ContainsAny and ContainsAll)
+	ValueUUIDArray []string `json:"valueUuidArray,omitempty"`
 }
 
 // Validate validates this where filter
@@ -114,7 +138,7 @@ var whereFilterTypeOperatorPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["And","Or","Equal","Like","Not","NotEqual","GreaterThan","GreaterThanEqual","LessThan","LessThanEqual","WithinGeoRange"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["And","Or","Equal","Like","Phrase","Fuzzy","Not","NotEqual","GreaterThan","GreaterThanEqual","LessThan","LessThanEqual","WithinGeoRange","ContainsAny","ContainsAll"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -136,6 +160,12 @@ const (
 	// WhereFilterOperatorLike captures enum value "Like"
 	WhereFilterOperatorLike string = "Like"
 
+	// WhereFilterOperatorPhrase captures enum value "Phrase"
+	WhereFilterOperatorPhrase string = "Phrase"
+
+	// WhereFilterOperatorFuzzy captures enum value "Fuzzy"
+	WhereFilterOperatorFuzzy string = "Fuzzy"
+
 	// WhereFilterOperatorNot captures enum value "Not"
 	WhereFilterOperatorNot string = "Not"
 
@@ -156,6 +186,12 @@ const (
 
 	// WhereFilterOperatorWithinGeoRange captures enum value "WithinGeoRange"
 	WhereFilterOperatorWithinGeoRange string = "WithinGeoRange"
+
+	// WhereFilterOperatorContainsAny captures enum value "ContainsAny"
+	WhereFilterOperatorContainsAny string = "ContainsAny"
+
+	// WhereFilterOperatorContainsAll captures enum value "ContainsAll"
+	WhereFilterOperatorContainsAll string = "ContainsAll"
 )
 
 // prop value enum
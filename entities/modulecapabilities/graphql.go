@@ -32,6 +32,9 @@ type NearParam interface {
 	GetCertainty() float64
 	GetDistance() float64
 	SimilarityMetricProvided() bool
+	// GetTargetVector returns the name of the named vector to search, it is
+	// empty if the regular vector of the class is searched
+	GetTargetVector() string
 }
 
 // ValidateFn validates a given module param
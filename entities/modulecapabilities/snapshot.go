@@ -13,17 +13,32 @@ package modulecapabilities
 
 import (
 	"context"
+	"io"
 
 	"github.com/semi-technologies/weaviate/entities/backup"
 )
 
 type SnapshotStorage interface {
-	StoreSnapshot(ctx context.Context, snapshot *backup.Snapshot) error
-	RestoreSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error)
+	// PutObject stores the contents of r under the given key in the location
+	// of the specified snapshot. Keys are the relative paths of the files as
+	// listed in the snapshot.
+	PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error
+	// GetObject returns a reader for the contents stored under the given key in
+	// the location of the specified snapshot. It is the caller's
+	// responsibility to close the reader.
+	GetObject(ctx context.Context, snapshotID, key string) (io.ReadCloser, error)
 
-	InitSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error)
-	GetMeta(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error)
-	SetMetaStatus(ctx context.Context, className, snapshotID, status string) error
-	SetMetaError(ctx context.Context, className, snapshotID string, err error) error
-	DestinationPath(className, snapshotID string) string
+	InitSnapshot(ctx context.Context, snapshotID string, classes []string) (*backup.Snapshot, error)
+	GetMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error)
+	PutMeta(ctx context.Context, snapshot *backup.Snapshot) error
+	SetMetaStatus(ctx context.Context, snapshotID, status string) error
+	SetMetaError(ctx context.Context, snapshotID string, err error) error
+	DestinationPath(snapshotID string) string
+
+	// ListSnapshots returns the manifests of all snapshots which exist on the
+	// storage. Locations without a manifest are skipped.
+	ListSnapshots(ctx context.Context) ([]*backup.Snapshot, error)
+	// DeleteSnapshot removes the manifest as well as all files stored in the
+	// location of the specified snapshot.
+	DeleteSnapshot(ctx context.Context, snapshotID string) error
 }
@@ -49,7 +49,7 @@ func (s *Schema) GetProperty(className ClassName, propName PropertyName) (*model
 		return nil, err
 	}
 
-	semProp, err := GetPropertyByName(semSchemaClass, string(propName))
+	semProp, err := GetPropertyByPath(semSchemaClass, string(propName))
 	if err != nil {
 		return nil, err
 	}
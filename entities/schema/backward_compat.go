@@ -124,6 +124,8 @@ func GetValueDataTypeFromString(dt string) (*DataType, error) {
 			returnDataType = DataTypePhoneNumber
 		} else if dt == string(DataTypeBlob) {
 			returnDataType = DataTypeBlob
+		} else if dt == string(DataTypeUUID) {
+			returnDataType = DataTypeUUID
 		} else if dt == string(DataTypeStringArray) {
 			returnDataType = DataTypeStringArray
 		} else if dt == string(DataTypeTextArray) {
@@ -136,6 +138,12 @@ func GetValueDataTypeFromString(dt string) (*DataType, error) {
 			returnDataType = DataTypeBooleanArray
 		} else if dt == string(DataTypeDateArray) {
 			returnDataType = DataTypeDateArray
+		} else if dt == string(DataTypeUUIDArray) {
+			returnDataType = DataTypeUUIDArray
+		} else if dt == string(DataTypeObject) {
+			returnDataType = DataTypeObject
+		} else if dt == string(DataTypeObjectArray) {
+			returnDataType = DataTypeObjectArray
 		}
 	} else {
 		return nil, errors_.New(ErrorNoSuchDatatype)
@@ -157,12 +165,16 @@ func IsValidValueDataType(dt string) bool {
 		string(DataTypeGeoCoordinates),
 		string(DataTypePhoneNumber),
 		string(DataTypeBlob),
+		string(DataTypeUUID),
 		string(DataTypeStringArray),
 		string(DataTypeTextArray),
 		string(DataTypeIntArray),
 		string(DataTypeNumberArray),
 		string(DataTypeBooleanArray),
-		string(DataTypeDateArray):
+		string(DataTypeDateArray),
+		string(DataTypeUUIDArray),
+		string(DataTypeObject),
+		string(DataTypeObjectArray):
 		return true
 	}
 	return false
@@ -185,7 +197,7 @@ func IsBlobDataType(dt []string) bool {
 func IsArrayDataType(dt []string) bool {
 	for i := range dt {
 		switch DataType(dt[i]) {
-		case DataTypeStringArray, DataTypeTextArray, DataTypeIntArray, DataTypeNumberArray, DataTypeBooleanArray, DataTypeDateArray:
+		case DataTypeStringArray, DataTypeTextArray, DataTypeIntArray, DataTypeNumberArray, DataTypeBooleanArray, DataTypeDateArray, DataTypeUUIDArray:
 			return true
 		}
 	}
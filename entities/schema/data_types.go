@@ -41,6 +41,8 @@ const (
 	DataTypePhoneNumber DataType = "phoneNumber"
 	// DataTypeBlob represents a base64 encoded data
 	DataTypeBlob DataType = "blob"
+	// DataTypeUUID The data type is a value of type uuid
+	DataTypeUUID DataType = "uuid"
 	// DataTypeArrayString The data type is a value of type string array
 	DataTypeStringArray DataType = "string[]"
 	// DataTypeTextArray The data type is a value of type string array
@@ -53,21 +55,33 @@ const (
 	DataTypeBooleanArray DataType = "boolean[]"
 	// DataTypeDateArray The data type is a value of type date array
 	DataTypeDateArray DataType = "date[]"
+	// DataTypeUUIDArray The data type is a value of type uuid array
+	DataTypeUUIDArray DataType = "uuid[]"
+	// DataTypeObject The data type is a nested object, whose fields are
+	// described by the nested properties of the property
+	DataTypeObject DataType = "object"
+	// DataTypeObjectArray The data type is an array of nested objects
+	DataTypeObjectArray DataType = "object[]"
 )
 
-var PrimitiveDataTypes []DataType = []DataType{DataTypeString, DataTypeText, DataTypeInt, DataTypeNumber, DataTypeBoolean, DataTypeDate, DataTypeGeoCoordinates, DataTypePhoneNumber, DataTypeBlob, DataTypeStringArray, DataTypeTextArray, DataTypeIntArray, DataTypeNumberArray, DataTypeBooleanArray, DataTypeDateArray}
+var PrimitiveDataTypes []DataType = []DataType{DataTypeString, DataTypeText, DataTypeInt, DataTypeNumber, DataTypeBoolean, DataTypeDate, DataTypeGeoCoordinates, DataTypePhoneNumber, DataTypeBlob, DataTypeUUID, DataTypeStringArray, DataTypeTextArray, DataTypeIntArray, DataTypeNumberArray, DataTypeBooleanArray, DataTypeDateArray, DataTypeUUIDArray}
+
+var NestedDataTypes []DataType = []DataType{DataTypeObject, DataTypeObjectArray}
 
 type PropertyKind int
 
 const (
 	PropertyKindPrimitive PropertyKind = 1
 	PropertyKindRef       PropertyKind = 2
+	PropertyKindNested    PropertyKind = 3
 )
 
 type PropertyDataType interface {
 	Kind() PropertyKind
 	IsPrimitive() bool
 	AsPrimitive() DataType
+	IsNested() bool
+	AsNested() DataType
 	IsReference() bool
 	Classes() []ClassName
 	ContainsClass(name ClassName) bool
@@ -76,6 +90,7 @@ type PropertyDataType interface {
 type propertyDataType struct {
 	kind          PropertyKind
 	primitiveType DataType
+	nestedType    DataType
 	classes       []ClassName
 }
 
@@ -93,6 +108,8 @@ func IsArrayType(dt DataType) (DataType, bool) {
 		return DataTypeBoolean, true
 	case DataTypeDateArray:
 		return DataTypeDate, true
+	case DataTypeUUIDArray:
+		return DataTypeUUID, true
 
 	default:
 		return "", false
@@ -115,6 +132,18 @@ func (p *propertyDataType) AsPrimitive() DataType {
 	return p.primitiveType
 }
 
+func (p *propertyDataType) IsNested() bool {
+	return p.kind == PropertyKindNested
+}
+
+func (p *propertyDataType) AsNested() DataType {
+	if p.kind != PropertyKindNested {
+		panic("not nested type")
+	}
+
+	return p.nestedType
+}
+
 func (p *propertyDataType) IsReference() bool {
 	return p.kind == PropertyKindRef
 }
@@ -156,14 +185,20 @@ func (s *Schema) FindPropertyDataType(dataType []string) (PropertyDataType, erro
 			case string(DataTypeString), string(DataTypeText),
 				string(DataTypeInt), string(DataTypeNumber),
 				string(DataTypeBoolean), string(DataTypeDate), string(DataTypeGeoCoordinates),
-				string(DataTypePhoneNumber), string(DataTypeBlob),
+				string(DataTypePhoneNumber), string(DataTypeBlob), string(DataTypeUUID),
 				string(DataTypeStringArray), string(DataTypeTextArray),
 				string(DataTypeIntArray), string(DataTypeNumberArray),
-				string(DataTypeBooleanArray), string(DataTypeDateArray):
+				string(DataTypeBooleanArray), string(DataTypeDateArray),
+				string(DataTypeUUIDArray):
 				return &propertyDataType{
 					kind:          PropertyKindPrimitive,
 					primitiveType: DataType(someDataType),
 				}, nil
+			case string(DataTypeObject), string(DataTypeObjectArray):
+				return &propertyDataType{
+					kind:       PropertyKindNested,
+					nestedType: DataType(someDataType),
+				}, nil
 			default:
 				return nil, fmt.Errorf("Unknown primitive data type '%s'", someDataType)
 			}
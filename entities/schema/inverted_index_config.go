@@ -16,6 +16,7 @@ type InvertedIndexConfig struct {
 	BM25                   BM25Config
 	Stopwords              StopwordConfig
 	IndexTimestamps        bool
+	IndexPositions         bool
 }
 
 type BM25Config struct {
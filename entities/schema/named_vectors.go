@@ -0,0 +1,44 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package schema
+
+import (
+	"fmt"
+
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+// NamedVectorizer returns the name of the module which vectorizes a named
+// vector, as well as the module's config for that vector. The vectorizer
+// of a named vector is an object with the module name as its only key.
+func NamedVectorizer(cfg models.VectorConfig) (string, map[string]interface{}, error) {
+	asMap, ok := cfg.Vectorizer.(map[string]interface{})
+	if !ok || len(asMap) != 1 {
+		return "", nil, fmt.Errorf("vectorizer must be an object with exactly one " +
+			"key, the name of the vectorizer module, e.g. {\"none\": {}}")
+	}
+
+	for moduleName, moduleConfig := range asMap {
+		if moduleConfig == nil {
+			return moduleName, nil, nil
+		}
+
+		asConfig, ok := moduleConfig.(map[string]interface{})
+		if !ok {
+			return "", nil, fmt.Errorf("config of vectorizer %q must be an object, got %T",
+				moduleName, moduleConfig)
+		}
+		return moduleName, asConfig, nil
+	}
+
+	return "", nil, nil
+}
@@ -0,0 +1,113 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+// NestedPropertySeparator joins the names of a nested prop and its parents
+// into the path under which the prop is indexed and filtered, e.g.
+// "address.city"
+const NestedPropertySeparator = "."
+
+func IsNestedDataType(dt []string) bool {
+	for i := range dt {
+		switch DataType(dt[i]) {
+		case DataTypeObject, DataTypeObjectArray:
+			return true
+		}
+	}
+	return false
+}
+
+// NestedPropertyPath joins the names of a nested prop and its parents
+func NestedPropertyPath(names ...string) string {
+	return strings.Join(names, NestedPropertySeparator)
+}
+
+// FlattenNestedProperties returns the leaf props below a prop of the object
+// or object[] data type, i.e. all nested props which are not nested objects
+// themselves. They are copies named after their path, e.g. "address.city".
+// Leaves below a nested object which is not indexed are not indexed either.
+func FlattenNestedProperties(prop *models.Property) []*models.Property {
+	return flattenNestedProperties(prop, prop.Name, isIndexed(prop))
+}
+
+func flattenNestedProperties(prop *models.Property, path string,
+	indexed bool,
+) []*models.Property {
+	var out []*models.Property
+	for _, nested := range prop.NestedProperties {
+		nestedPath := NestedPropertyPath(path, nested.Name)
+		nestedIndexed := indexed && isIndexed(nested)
+
+		if IsNestedDataType(nested.DataType) {
+			out = append(out, flattenNestedProperties(nested, nestedPath, nestedIndexed)...)
+			continue
+		}
+
+		leaf := *nested
+		leaf.Name = nestedPath
+		if !nestedIndexed {
+			leaf.IndexInverted = &nestedIndexed
+		}
+		out = append(out, &leaf)
+	}
+
+	return out
+}
+
+func isIndexed(prop *models.Property) bool {
+	return prop.IndexInverted == nil || *prop.IndexInverted
+}
+
+// GetPropertyByPath returns the prop by its name. If the name is the path of
+// a nested prop, e.g. "address.city", the nested prop is returned as a copy
+// named after its path.
+func GetPropertyByPath(c *models.Class, path string) (*models.Property, error) {
+	prop, err := GetPropertyByName(c, path)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := strings.Split(path, NestedPropertySeparator)
+	if len(segments) == 1 || !IsNestedDataType(prop.DataType) {
+		return prop, nil
+	}
+
+	for _, segment := range segments[1:] {
+		if !IsNestedDataType(prop.DataType) {
+			return nil, fmt.Errorf(ErrorNoSuchProperty, path, c.Class)
+		}
+
+		var nested *models.Property
+		for _, candidate := range prop.NestedProperties {
+			if candidate.Name == segment {
+				nested = candidate
+				break
+			}
+		}
+		if nested == nil {
+			return nil, fmt.Errorf(ErrorNoSuchProperty, path, c.Class)
+		}
+
+		prop = nested
+	}
+
+	named := *prop
+	named.Name = path
+	return &named, nil
+}
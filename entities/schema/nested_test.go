@@ -0,0 +1,126 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nestedTestClass() *models.Class {
+	vFalse := false
+	return &models.Class{
+		Class: "Article",
+		Properties: []*models.Property{
+			{
+				Name:     "title",
+				DataType: []string{"text"},
+			},
+			{
+				Name:     "address",
+				DataType: []string{"object"},
+				NestedProperties: []*models.Property{
+					{Name: "street", DataType: []string{"text"}},
+					{Name: "city", DataType: []string{"string"}, Tokenization: "field"},
+				},
+			},
+			{
+				Name:     "authors",
+				DataType: []string{"object[]"},
+				NestedProperties: []*models.Property{
+					{Name: "name", DataType: []string{"text"}},
+					{
+						Name:          "affiliation",
+						DataType:      []string{"object"},
+						IndexInverted: &vFalse,
+						NestedProperties: []*models.Property{
+							{Name: "name", DataType: []string{"text"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDetectNestedTypes(t *testing.T) {
+	s := &Schema{}
+
+	for _, type_ := range NestedDataTypes {
+		pdt, err := s.FindPropertyDataType([]string{string(type_)})
+		require.Nil(t, err)
+
+		assert.True(t, pdt.IsNested())
+		assert.False(t, pdt.IsPrimitive())
+		assert.False(t, pdt.IsReference())
+		assert.Equal(t, type_, pdt.AsNested())
+	}
+}
+
+func TestFlattenNestedProperties(t *testing.T) {
+	class := nestedTestClass()
+
+	t.Run("with an object prop", func(t *testing.T) {
+		leaves := FlattenNestedProperties(class.Properties[1])
+
+		require.Len(t, leaves, 2)
+		assert.Equal(t, "address.street", leaves[0].Name)
+		assert.Equal(t, []string{"text"}, leaves[0].DataType)
+		assert.Nil(t, leaves[0].IndexInverted)
+		assert.Equal(t, "address.city", leaves[1].Name)
+		assert.Equal(t, "field", leaves[1].Tokenization)
+
+		// the schema itself is not changed
+		assert.Equal(t, "street", class.Properties[1].NestedProperties[0].Name)
+	})
+
+	t.Run("with a nested object which is not indexed", func(t *testing.T) {
+		leaves := FlattenNestedProperties(class.Properties[2])
+
+		require.Len(t, leaves, 2)
+		assert.Equal(t, "authors.name", leaves[0].Name)
+		assert.Nil(t, leaves[0].IndexInverted)
+		assert.Equal(t, "authors.affiliation.name", leaves[1].Name)
+		require.NotNil(t, leaves[1].IndexInverted)
+		assert.False(t, *leaves[1].IndexInverted)
+	})
+}
+
+func TestGetPropertyByPath(t *testing.T) {
+	class := nestedTestClass()
+
+	t.Run("with a top-level prop", func(t *testing.T) {
+		prop, err := GetPropertyByPath(class, "address")
+		require.Nil(t, err)
+		assert.Equal(t, class.Properties[1], prop)
+	})
+
+	t.Run("with a nested prop", func(t *testing.T) {
+		prop, err := GetPropertyByPath(class, "authors.affiliation.name")
+		require.Nil(t, err)
+		assert.Equal(t, "authors.affiliation.name", prop.Name)
+		assert.Equal(t, []string{"text"}, prop.DataType)
+	})
+
+	t.Run("with a non-existing nested prop", func(t *testing.T) {
+		_, err := GetPropertyByPath(class, "address.zip")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("with a path below a leaf", func(t *testing.T) {
+		_, err := GetPropertyByPath(class, "address.city.name")
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,23 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package schema
+
+// ShardingConfig is the parsed sharding config of a class
+type ShardingConfig interface {
+	// KeyProperty returns the name of the property the objects are sharded
+	// by, it is empty if they are sharded by their id
+	KeyProperty() string
+
+	// PropertyKey turns a value of that property into the key the shard of an
+	// object is determined by
+	PropertyKey(value interface{}) ([]byte, error)
+}
@@ -25,6 +25,7 @@ type Result struct {
 	Score                float32
 	Dist                 float32
 	Vector               []float32
+	Vectors              map[string][]float32
 	Beacon               string
 	Certainty            float32
 	Schema               models.PropertySchema
@@ -64,11 +65,24 @@ func (r Result) ObjectWithVector(includeVector bool) *models.Object {
 
 	if includeVector {
 		t.Vector = r.Vector
+		t.Vectors = vectorsModel(r.Vectors)
 	}
 
 	return t
 }
 
+func vectorsModel(in map[string][]float32) models.Vectors {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make(models.Vectors, len(in))
+	for name, vector := range in {
+		out[name] = vector
+	}
+	return out
+}
+
 func (rs Results) Objects() []*models.Object {
 	return rs.ObjectsWithVector(true)
 }
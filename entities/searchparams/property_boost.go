@@ -0,0 +1,67 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package searchparams
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PropertyBoost is a property of a keyword-based (bm25) search together with
+// the weight its term frequencies are multiplied with
+type PropertyBoost struct {
+	Name  string
+	Boost float64
+}
+
+// ParsePropertyBoost parses a property of a keyword-based search such as
+// "title^3" into the property name and its boost. A property without an
+// explicit boost has a boost of 1.
+func ParsePropertyBoost(prop string) (PropertyBoost, error) {
+	name, boostStr, hasBoost := strings.Cut(prop, "^")
+	if name == "" {
+		return PropertyBoost{}, errors.Errorf("invalid property %q: empty name", prop)
+	}
+
+	if !hasBoost {
+		return PropertyBoost{Name: name, Boost: 1}, nil
+	}
+
+	boost, err := strconv.ParseFloat(boostStr, 64)
+	if err != nil {
+		return PropertyBoost{}, errors.Errorf("invalid property %q: boost must be "+
+			"a number, got %q", prop, boostStr)
+	}
+
+	if boost <= 0 {
+		return PropertyBoost{}, errors.Errorf("invalid property %q: boost must be "+
+			"greater than 0", prop)
+	}
+
+	return PropertyBoost{Name: name, Boost: boost}, nil
+}
+
+// ParsePropertyBoosts parses all properties, see ParsePropertyBoost
+func ParsePropertyBoosts(props []string) ([]PropertyBoost, error) {
+	out := make([]PropertyBoost, len(props))
+	for i, prop := range props {
+		parsed, err := ParsePropertyBoost(prop)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = parsed
+	}
+
+	return out, nil
+}
@@ -0,0 +1,50 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package searchparams
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePropertyBoost(t *testing.T) {
+	tests := []struct {
+		in            string
+		expected      PropertyBoost
+		expectedError string
+	}{
+		{in: "title", expected: PropertyBoost{Name: "title", Boost: 1}},
+		{in: "title^3", expected: PropertyBoost{Name: "title", Boost: 3}},
+		{in: "title^0.5", expected: PropertyBoost{Name: "title", Boost: 0.5}},
+		{in: "^3", expectedError: "empty name"},
+		{in: "title^", expectedError: "boost must be a number"},
+		{in: "title^high", expectedError: "boost must be a number"},
+		{in: "title^0", expectedError: "boost must be greater than 0"},
+		{in: "title^-2", expectedError: "boost must be greater than 0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			res, err := ParsePropertyBoost(test.in)
+			if test.expectedError != "" {
+				require.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
@@ -16,6 +16,7 @@ type NearVector struct {
 	Certainty    float64   `json:"certainty"`
 	Distance     float64   `json:"distance"`
 	WithDistance bool      `json:"-"`
+	TargetVector string    `json:"targetVector"`
 }
 
 type KeywordRanking struct {
@@ -30,4 +31,27 @@ type NearObject struct {
 	Certainty    float64 `json:"certainty"`
 	Distance     float64 `json:"distance"`
 	WithDistance bool    `json:"-"`
+	TargetVector string  `json:"targetVector"`
+}
+
+const (
+	// HybridFusionRanked fuses the BM25 and vector result lists based on the
+	// rank of each object within the respective list (reciprocal rank fusion)
+	HybridFusionRanked = "rankedFusion"
+	// HybridFusionRelativeScore fuses the BM25 and vector result lists based
+	// on the scores (resp. distances) normalized to the range 0..1
+	HybridFusionRelativeScore = "relativeScoreFusion"
+
+	// HybridDefaultAlpha is used when the user does not specify how to weigh
+	// the vector search against the keyword search
+	HybridDefaultAlpha = 0.75
+)
+
+type HybridSearch struct {
+	Query        string    `json:"query"`
+	Vector       []float32 `json:"vector"`
+	Alpha        float64   `json:"alpha"`
+	FusionType   string    `json:"fusionType"`
+	Properties   []string  `json:"properties"`
+	TargetVector string    `json:"targetVector"`
 }
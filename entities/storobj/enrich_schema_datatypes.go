@@ -51,6 +51,9 @@ func (ko *Object) enrichSchemaTypes(schema map[string]interface{}) error {
 
 					schema[propName] = parsed
 				}
+			} else if isNestedObjectArrayValue(typed) {
+				// object[] props are kept as they are
+				continue
 			} else {
 				parsed, err := parseCrossRef(typed)
 				if err != nil {
@@ -79,17 +82,34 @@ func parseMapProp(input map[string]interface{}) (interface{}, error) {
 	lon, lonOK := input["longitude"]
 	_, phoneInputOK := input["input"]
 
-	if latOK && lonOK {
+	if latOK && lonOK && len(input) == 2 {
 		// this is a geoCoordinates prop
 		return parseGeoProp(lat, lon)
 	}
 
-	if phoneInputOK {
+	if phoneInputOK && hasOnlyKeys(input, phoneNumberKeys) {
 		// this is a phone number
 		return parsePhoneNumber(input)
 	}
 
-	return nil, fmt.Errorf("unknown map prop which is not a geo prop or phone: %v", input)
+	// this is a nested object, which is kept as it is
+	return input, nil
+}
+
+var phoneNumberKeys = map[string]struct{}{
+	"input": {}, "internationalFormatted": {}, "nationalFormatted": {},
+	"national": {}, "countryCode": {}, "defaultCountry": {}, "valid": {},
+}
+
+// hasOnlyKeys tells a map prop of a known structure apart from a nested
+// object which happens to share some of its keys
+func hasOnlyKeys(input map[string]interface{}, keys map[string]struct{}) bool {
+	for key := range input {
+		if _, ok := keys[key]; !ok {
+			return false
+		}
+	}
+	return true
 }
 
 func parseGeoProp(lat interface{}, lon interface{}) (*models.GeoCoordinates, error) {
@@ -208,6 +228,21 @@ func isArrayValue(value []interface{}) bool {
 	return false
 }
 
+// isNestedObjectArrayValue tells the elements of an object[] prop apart from
+// cross-refs, which are maps as well, but always have a beacon
+func isNestedObjectArrayValue(value []interface{}) bool {
+	if len(value) > 0 {
+		asMap, ok := value[0].(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		_, isRef := asMap["beacon"]
+		return !isRef
+	}
+	return false
+}
+
 func parseStringArrayValue(value []interface{}) ([]string, error) {
 	parsed := make([]string, len(value))
 	for i := range value {
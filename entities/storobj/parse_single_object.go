@@ -19,6 +19,7 @@ import (
 	"github.com/buger/jsonparser"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/usecases/byte_operations"
 )
 
 func ParseAndExtractProperty(data []byte, propName string) ([]string, bool, error) {
@@ -42,6 +43,14 @@ func ParseAndExtractTextProp(data []byte, propName string) ([]string, bool, erro
 	if err != nil {
 		return nil, false, err
 	}
+	if len(vals) == 0 {
+		// uuid props are not part of the json, but are stored in binary form
+		// after it
+		vals, err = extractUUIDProp(data, propName)
+		if err != nil {
+			return nil, false, err
+		}
+	}
 	return vals, true, nil
 }
 
@@ -131,9 +140,18 @@ func extractTimeUnix(data []byte, propertyName string) ([]string, bool, error) {
 }
 
 func extractPropsBytes(data []byte) ([]byte, error) {
+	start, end, err := propsBytesRange(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return data[start:end], nil
+}
+
+func propsBytesRange(data []byte) (int64, int64, error) {
 	version := uint8(data[0])
 	if version != 1 {
-		return nil, errors.Errorf("unsupported binary marshaller version %d", version)
+		return 0, 0, errors.Errorf("unsupported binary marshaller version %d", version)
 	}
 
 	vecLen := binary.LittleEndian.Uint16(data[discardBytesPreVector : discardBytesPreVector+2])
@@ -148,7 +166,43 @@ func extractPropsBytes(data []byte) ([]byte, error) {
 	start := int64(propsLenStart + 4)
 	end := start + int64(propsLen)
 
-	return data[start:end], nil
+	return start, end, nil
+}
+
+func extractUUIDProp(data []byte, propName string) ([]string, error) {
+	_, propsEnd, err := propsBytesRange(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the props are followed by the meta and the vector weights, each of them
+	// prefixed with its length
+	byteOps := byte_operations.ByteOperations{Position: uint64(propsEnd), Buffer: data}
+	byteOps.MoveBufferPositionForward(uint64(byteOps.ReadUint32()))
+	byteOps.MoveBufferPositionForward(uint64(byteOps.ReadUint32()))
+
+	_, uuidPropsB, err := splitOptionalSections(data[byteOps.Position:])
+	if err != nil {
+		return nil, err
+	}
+
+	var props map[string]interface{}
+	if err := unmarshalUUIDProperties(uuidPropsB, &props); err != nil {
+		return nil, errors.Wrap(err, "uuid properties")
+	}
+
+	switch val := props[propName].(type) {
+	case uuid.UUID:
+		return []string{val.String()}, nil
+	case []uuid.UUID:
+		out := make([]string, len(val))
+		for i := range val {
+			out[i] = val[i].String()
+		}
+		return out, nil
+	default:
+		return []string{}, nil
+	}
 }
 
 const discardBytesPreVector = 1 + 8 + 1 + 16 + 8 + 8
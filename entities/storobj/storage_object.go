@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"io"
 	"math"
+	"sort"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
@@ -35,6 +36,10 @@ type Object struct {
 	Vector            []float32     `json:"vector"`
 	VectorLen         int           `json:"-"`
 	docID             uint64
+
+	// Vectors holds the named vectors of the object, keyed by the name of the
+	// vector in the class' vectorConfig
+	Vectors map[string][]float32 `json:"vectors"`
 }
 
 func New(docID uint64) *Object {
@@ -45,12 +50,24 @@ func New(docID uint64) *Object {
 }
 
 func FromObject(object *models.Object, vector []float32) *Object {
-	return &Object{
+	ko := &Object{
 		Object:            *object,
 		Vector:            vector,
 		MarshallerVersion: 1,
 		VectorLen:         len(vector),
 	}
+
+	// the named vectors are stored in their own section, not as part of the
+	// object
+	if len(object.Vectors) > 0 {
+		ko.Vectors = make(map[string][]float32, len(object.Vectors))
+		for name, vector := range object.Vectors {
+			ko.Vectors[name] = vector
+		}
+	}
+	ko.Object.Vectors = nil
+
+	return ko
 }
 
 func FromBinary(data []byte) (*Object, error) {
@@ -134,6 +151,17 @@ func FromBinaryOptional(data []byte,
 		return nil, errors.Wrap(err, "compound err")
 	}
 
+	namedVectors, uuidProps, err := splitOptionalSections(data[len(data)-r.Len():])
+	if err != nil {
+		return nil, err
+	}
+
+	if addProp.Vector || addProp.Vectors {
+		if ko.Vectors, err = unmarshalNamedVectors(namedVectors); err != nil {
+			return nil, errors.Wrap(err, "named vectors")
+		}
+	}
+
 	uuidParsed, err := uuid.FromBytes(uuidBytes)
 	if err != nil {
 		return nil, err
@@ -147,6 +175,7 @@ func FromBinaryOptional(data []byte,
 		schema,
 		meta,
 		vectorWeights,
+		uuidProps,
 	); err != nil {
 		return nil, errors.Wrap(err, "parse")
 	}
@@ -254,6 +283,9 @@ func (ko *Object) SearchResult(additional additional.Properties) *search.Result
 		if additional.Classification {
 			additionalProperties["classification"] = ko.AdditionalProperties()["classification"]
 		}
+		if additional.ExplainScore {
+			additionalProperties["explainScore"] = ko.AdditionalProperties()["explainScore"]
+		}
 	}
 
 	return &search.Result{
@@ -261,6 +293,7 @@ func (ko *Object) SearchResult(additional additional.Properties) *search.Result
 		ClassName: ko.Class().String(),
 		Schema:    ko.Properties(),
 		Vector:    ko.Vector,
+		Vectors:   ko.Vectors,
 		Dims:      ko.VectorLen,
 		// VectorWeights: ko.VectorWeights(), // TODO: add vector weights
 		Created:              ko.CreationTimeUnix(),
@@ -299,6 +332,23 @@ func SearchResultsWithDists(in []*Object, additional additional.Properties,
 	return out
 }
 
+// SearchResultsWithScore sets the score of each result to the respective
+// entry of scores, e.g. from a keyword-based or hybrid search
+func SearchResultsWithScore(in []*Object, additional additional.Properties,
+	scores []float32,
+) search.Results {
+	out := make(search.Results, len(in))
+
+	for i, elem := range in {
+		out[i] = *(elem.SearchResult(additional))
+		if i < len(scores) {
+			out[i].Score = scores[i]
+		}
+	}
+
+	return out
+}
+
 func DocIDFromBinary(in []byte) (uint64, error) {
 	var version uint8
 	r := bytes.NewReader(in)
@@ -339,6 +389,16 @@ func DocIDFromBinary(in []byte) (uint64, error) {
 // n          | []byte    | meta as json
 // 2          | uint32    | length of vectorweights json
 // n          | []byte    | vectorweights as json
+//
+// Objects with named vectors are followed by an additional section, see
+// marshalNamedVectors. Objects without named vectors end after the vector
+// weights, which keeps them identical to objects written before named
+// vectors existed.
+//
+// The values of uuid and uuid[] props are not part of the schema json, they
+// are stored in their 16 byte form in a section after the named vectors, see
+// marshalUUIDProperties. Objects with uuid props therefore always have a
+// named vectors section, which is empty if they have no named vectors.
 func (ko *Object) MarshalBinary() ([]byte, error) {
 	if ko.MarshallerVersion != 1 {
 		return nil, errors.Errorf("unsupported marshaller version %d", ko.MarshallerVersion)
@@ -359,7 +419,8 @@ func (ko *Object) MarshalBinary() ([]byte, error) {
 	vectorLength := uint32(len(ko.Vector))
 	className := []byte(ko.Class())
 	classNameLength := uint32(len(className))
-	schema, err := json.Marshal(ko.Properties())
+	properties, uuidProps := splitUUIDProperties(ko.Properties())
+	schema, err := json.Marshal(properties)
 	if err != nil {
 		return nil, err
 	}
@@ -374,8 +435,13 @@ func (ko *Object) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 	vectorWeightsLength := uint32(len(vectorWeights))
+	namedVectorsLength := uint32(0)
+	if len(ko.Vectors) > 0 || len(uuidProps) > 0 {
+		namedVectorsLength = namedVectorsBinaryLength(ko.Vectors)
+	}
+	uuidPropsLength := uuidPropertiesBinaryLength(uuidProps)
 
-	totalBufferLength := 1 + 8 + 1 + 16 + 8 + 8 + 2 + vectorLength*4 + 2 + classNameLength + 4 + schemaLength + 4 + metaLength + 4 + vectorWeightsLength
+	totalBufferLength := 1 + 8 + 1 + 16 + 8 + 8 + 2 + vectorLength*4 + 2 + classNameLength + 4 + schemaLength + 4 + metaLength + 4 + vectorWeightsLength + namedVectorsLength + uuidPropsLength
 	byteBuffer := make([]byte, totalBufferLength)
 	byteOps := byte_operations.ByteOperations{Buffer: byteBuffer}
 	byteOps.WriteByte(ko.MarshallerVersion)
@@ -415,6 +481,16 @@ func (ko *Object) MarshalBinary() ([]byte, error) {
 		return byteBuffer, errors.Wrap(err, "Could not copy vectorWeights")
 	}
 
+	if namedVectorsLength > 0 {
+		if err := marshalNamedVectors(&byteOps, ko.Vectors); err != nil {
+			return byteBuffer, errors.Wrap(err, "Could not copy named vectors")
+		}
+	}
+
+	if err := marshalUUIDProperties(&byteOps, uuidProps); err != nil {
+		return byteBuffer, errors.Wrap(err, "Could not copy uuid properties")
+	}
+
 	return byteBuffer, nil
 }
 
@@ -438,10 +514,28 @@ func UnmarshalPropertiesFromObject(data []byte, properties *models.PropertySchem
 
 	// property schema length
 	propertyLength := uint64(byteOps.ReadUint32())
-	if err := json.Unmarshal(data[byteOps.Position:byteOps.Position+propertyLength], properties); err != nil {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data[byteOps.Position:byteOps.Position+propertyLength], &schema); err != nil {
+		return err
+	}
+	byteOps.MoveBufferPositionForward(propertyLength)
+
+	// skip meta and vector weights
+	byteOps.MoveBufferPositionForward(uint64(byteOps.ReadUint32()))
+	byteOps.MoveBufferPositionForward(uint64(byteOps.ReadUint32()))
+
+	_, uuidProps, err := splitOptionalSections(data[byteOps.Position:])
+	if err != nil {
 		return err
 	}
 
+	if err := unmarshalUUIDProperties(uuidProps, &schema); err != nil {
+		return errors.Wrap(err, "uuid properties")
+	}
+
+	if schema != nil {
+		*properties = schema
+	}
 	return nil
 }
 
@@ -498,6 +592,16 @@ func (ko *Object) UnmarshalBinary(data []byte) error {
 		return errors.Wrap(err, "Could not copy vectorWeights")
 	}
 
+	namedVectors, uuidProps, err := splitOptionalSections(data[byteOps.Position:])
+	if err != nil {
+		return err
+	}
+
+	ko.Vectors, err = unmarshalNamedVectors(namedVectors)
+	if err != nil {
+		return errors.Wrap(err, "Could not copy named vectors")
+	}
+
 	return ko.parseObject(
 		strfmt.UUID(uuidParsed.String()),
 		createTime,
@@ -506,6 +610,7 @@ func (ko *Object) UnmarshalBinary(data []byte) error {
 		schema,
 		meta,
 		vectorWeights,
+		uuidProps,
 	)
 }
 
@@ -539,8 +644,315 @@ func VectorFromBinary(in []byte) ([]float32, error) {
 	return out, nil
 }
 
+// NamedVectorFromBinary extracts the named vector with the given name, it
+// returns nil if the object has no such vector
+func NamedVectorFromBinary(in []byte, name string) ([]float32, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	version := in[0]
+	if version != 1 {
+		return nil, errors.Errorf("unsupported marshaller version %d", version)
+	}
+
+	// skip all sections up to the named vectors, see MarshalBinary
+	pos := uint64(discardBytesPreVector)
+	pos += 2 + uint64(binary.LittleEndian.Uint16(in[pos:pos+2]))*4 // vector
+	pos += 2 + uint64(binary.LittleEndian.Uint16(in[pos:pos+2]))   // class name
+	pos += 4 + uint64(binary.LittleEndian.Uint32(in[pos:pos+4]))   // schema
+	pos += 4 + uint64(binary.LittleEndian.Uint32(in[pos:pos+4]))   // meta
+	pos += 4 + uint64(binary.LittleEndian.Uint32(in[pos:pos+4]))   // vector weights
+
+	if pos >= uint64(len(in)) {
+		return nil, nil
+	}
+
+	byteOps := byte_operations.ByteOperations{Position: pos + 4, Buffer: in}
+	count := int(byteOps.ReadUint16())
+	for i := 0; i < count; i++ {
+		nameLength := uint64(byteOps.ReadUint16())
+		vectorName := in[byteOps.Position : byteOps.Position+nameLength]
+		byteOps.MoveBufferPositionForward(nameLength)
+
+		vectorLength := uint64(byteOps.ReadUint16())
+		if string(vectorName) != name {
+			byteOps.MoveBufferPositionForward(vectorLength * 4)
+			continue
+		}
+
+		out := make([]float32, vectorLength)
+		for j := range out {
+			out[j] = math.Float32frombits(byteOps.ReadUint32())
+		}
+		return out, nil
+	}
+
+	return nil, nil
+}
+
+// Named vectors section, only present if the object has any named vectors
+//
+// No. of B   | Type      | Content
+// ------------------------------------------------
+// 4          | uint32    | length of the section without this field
+// 2          | uint16    | number of named vectors
+//
+// followed by the number of named vectors times
+//
+// 2          | uint16    | length of name
+// n          | []byte    | name
+// 2          | uint16    | VectorLength
+// n*4        | []float32 | vector of length n
+func namedVectorsBinaryLength(vectors map[string][]float32) uint32 {
+	length := uint32(4 + 2)
+	for name, vector := range vectors {
+		length += 2 + uint32(len(name)) + 2 + uint32(len(vector))*4
+	}
+
+	return length
+}
+
+func marshalNamedVectors(byteOps *byte_operations.ByteOperations,
+	vectors map[string][]float32,
+) error {
+	names := make([]string, 0, len(vectors))
+	for name := range vectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	byteOps.WriteUint32(namedVectorsBinaryLength(vectors) - 4)
+	byteOps.WriteUint16(uint16(len(names)))
+	for _, name := range names {
+		byteOps.WriteUint16(uint16(len(name)))
+		if err := byteOps.CopyBytesToBuffer([]byte(name)); err != nil {
+			return errors.Wrapf(err, "vector name %q", name)
+		}
+
+		vector := vectors[name]
+		byteOps.WriteUint16(uint16(len(vector)))
+		for _, value := range vector {
+			byteOps.WriteUint32(math.Float32bits(value))
+		}
+	}
+
+	return nil
+}
+
+// splitOptionalSections splits the remainder of the object after the vector
+// weights into the named vectors and the uuid props sections. Either of them
+// is empty if the object has no such section.
+func splitOptionalSections(in []byte) ([]byte, []byte, error) {
+	if len(in) == 0 {
+		return nil, nil, nil
+	}
+
+	if len(in) < 6 {
+		return nil, nil, errors.Errorf("named vectors section too short: %d bytes", len(in))
+	}
+
+	length := uint64(binary.LittleEndian.Uint32(in[:4]))
+	if length+4 > uint64(len(in)) {
+		return nil, nil, errors.Errorf("named vectors section has length %d, but only %d bytes remain",
+			length, len(in)-4)
+	}
+
+	return in[:length+4], in[length+4:], nil
+}
+
+// unmarshalNamedVectors parses the named vectors section. Objects without
+// named vectors have no or an empty section, for those it returns nil.
+func unmarshalNamedVectors(in []byte) (map[string][]float32, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	byteOps := byte_operations.ByteOperations{Position: 4, Buffer: in}
+	count := int(byteOps.ReadUint16())
+	if count == 0 {
+		return nil, nil
+	}
+
+	out := make(map[string][]float32, count)
+	for i := 0; i < count; i++ {
+		nameLength := uint64(byteOps.ReadUint16())
+		name, err := byteOps.CopyBytesFromBuffer(nameLength, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "vector name")
+		}
+
+		vector := make([]float32, byteOps.ReadUint16())
+		for j := range vector {
+			vector[j] = math.Float32frombits(byteOps.ReadUint32())
+		}
+		out[string(name)] = vector
+	}
+
+	return out, nil
+}
+
+// UUID props section, only present if the object has any uuid or uuid[] props
+//
+// No. of B   | Type      | Content
+// ------------------------------------------------
+// 4          | uint32    | length of the section without this field
+// 2          | uint16    | number of props
+//
+// followed by the number of props times
+//
+// 2          | uint16    | length of name
+// n          | []byte    | name
+// 1          | uint8     | 0=uuid, 1=uuid[]
+// 4          | uint32    | number of uuids
+// n*16       | []byte    | uuids of length n
+func uuidPropertiesBinaryLength(props map[string]interface{}) uint32 {
+	if len(props) == 0 {
+		return 0
+	}
+
+	length := uint32(4 + 2)
+	for name, value := range props {
+		length += 2 + uint32(len(name)) + 1 + 4 + uint32(len(uuidValues(value)))*16
+	}
+
+	return length
+}
+
+// splitUUIDProperties separates the values of uuid and uuid[] props from the
+// remaining props, which are stored as json. The input is not modified.
+func splitUUIDProperties(in models.PropertySchema) (models.PropertySchema, map[string]interface{}) {
+	asMap, ok := in.(map[string]interface{})
+	if !ok {
+		return in, nil
+	}
+
+	var uuidProps map[string]interface{}
+	for name, value := range asMap {
+		switch value.(type) {
+		case uuid.UUID, []uuid.UUID:
+			if uuidProps == nil {
+				uuidProps = map[string]interface{}{}
+			}
+			uuidProps[name] = value
+		}
+	}
+
+	if len(uuidProps) == 0 {
+		return in, nil
+	}
+
+	rest := make(map[string]interface{}, len(asMap)-len(uuidProps))
+	for name, value := range asMap {
+		if _, ok := uuidProps[name]; !ok {
+			rest[name] = value
+		}
+	}
+
+	return rest, uuidProps
+}
+
+func uuidValues(value interface{}) []uuid.UUID {
+	switch typed := value.(type) {
+	case uuid.UUID:
+		return []uuid.UUID{typed}
+	case []uuid.UUID:
+		return typed
+	default:
+		return nil
+	}
+}
+
+func marshalUUIDProperties(byteOps *byte_operations.ByteOperations,
+	props map[string]interface{},
+) error {
+	if len(props) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	byteOps.WriteUint32(uuidPropertiesBinaryLength(props) - 4)
+	byteOps.WriteUint16(uint16(len(names)))
+	for _, name := range names {
+		byteOps.WriteUint16(uint16(len(name)))
+		if err := byteOps.CopyBytesToBuffer([]byte(name)); err != nil {
+			return errors.Wrapf(err, "property name %q", name)
+		}
+
+		isArray := uint8(0)
+		if _, ok := props[name].([]uuid.UUID); ok {
+			isArray = 1
+		}
+		byteOps.WriteByte(isArray)
+
+		values := uuidValues(props[name])
+		byteOps.WriteUint32(uint32(len(values)))
+		for _, value := range values {
+			if err := byteOps.CopyBytesToBuffer(value[:]); err != nil {
+				return errors.Wrapf(err, "property %q", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// unmarshalUUIDProperties adds the props of the uuid props section to the
+// props parsed from the schema json
+func unmarshalUUIDProperties(in []byte, props *map[string]interface{}) error {
+	if len(in) == 0 {
+		return nil
+	}
+
+	if len(in) < 6 {
+		return errors.Errorf("uuid properties section too short: %d bytes", len(in))
+	}
+
+	byteOps := byte_operations.ByteOperations{Buffer: in}
+	if length := byteOps.ReadUint32(); uint64(length)+4 > uint64(len(in)) {
+		return errors.Errorf("uuid properties section has length %d, but only %d bytes remain",
+			length, len(in)-4)
+	}
+
+	if *props == nil {
+		*props = map[string]interface{}{}
+	}
+
+	count := int(byteOps.ReadUint16())
+	for i := 0; i < count; i++ {
+		nameLength := uint64(byteOps.ReadUint16())
+		name, err := byteOps.CopyBytesFromBuffer(nameLength, nil)
+		if err != nil {
+			return errors.Wrap(err, "property name")
+		}
+
+		isArray := in[byteOps.Position] == 1
+		byteOps.MoveBufferPositionForward(1)
+
+		values := make([]uuid.UUID, byteOps.ReadUint32())
+		for j := range values {
+			if _, err := byteOps.CopyBytesFromBuffer(16, values[j][:]); err != nil {
+				return errors.Wrapf(err, "property %q", name)
+			}
+		}
+
+		if isArray {
+			(*props)[string(name)] = values
+		} else if len(values) == 1 {
+			(*props)[string(name)] = values[0]
+		}
+	}
+
+	return nil
+}
+
 func (ko *Object) parseObject(uuid strfmt.UUID, create, update int64, className string,
-	schemaB []byte, additionalB []byte, vectorWeightsB []byte,
+	schemaB []byte, additionalB []byte, vectorWeightsB []byte, uuidPropsB []byte,
 ) error {
 	var schema map[string]interface{}
 	if err := json.Unmarshal(schemaB, &schema); err != nil {
@@ -551,6 +963,10 @@ func (ko *Object) parseObject(uuid strfmt.UUID, create, update int64, className
 		return errors.Wrap(err, "enrich schema datatypes")
 	}
 
+	if err := unmarshalUUIDProperties(uuidPropsB, &schema); err != nil {
+		return errors.Wrap(err, "uuid properties")
+	}
+
 	var additionalProperties models.AdditionalProperties
 	if len(additionalB) > 0 {
 		if err := json.Unmarshal(additionalB, &additionalProperties); err != nil {
@@ -603,7 +1019,20 @@ func (ko *Object) DeepCopyDangerous() *Object {
 		docID:             ko.docID,
 		Object:            deepCopyObject(ko.Object),
 		Vector:            deepCopyVector(ko.Vector),
+		Vectors:           deepCopyVectors(ko.Vectors),
+	}
+}
+
+func deepCopyVectors(orig map[string][]float32) map[string][]float32 {
+	if orig == nil {
+		return nil
 	}
+
+	out := make(map[string][]float32, len(orig))
+	for name, vector := range orig {
+		out[name] = deepCopyVector(vector)
+	}
+	return out
 }
 
 func deepCopyVector(orig []float32) []float32 {
@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
@@ -319,3 +320,160 @@ func TestStorageArrayObjectMarshalling(t *testing.T) {
 		assert.Equal(t, []float64{1.1, 2.1}, prop)
 	})
 }
+
+func TestStorageObjectMarshallingNamedVectors(t *testing.T) {
+	before := FromObject(
+		&models.Object{
+			Class:              "MyFavoriteClass",
+			CreationTimeUnix:   123456,
+			LastUpdateTimeUnix: 56789,
+			ID:                 strfmt.UUID("73f2eb5f-5abf-447a-81ca-74b1dd168247"),
+			Properties: map[string]interface{}{
+				"name": "MyName",
+			},
+			Vectors: models.Vectors{
+				"title":       {1, 2, 3},
+				"description": {0.5, 0.25},
+			},
+		},
+		[]float32{1, 2, 0.7},
+	)
+	before.SetDocID(7)
+
+	assert.Nil(t, before.Object.Vectors, "named vectors are not part of the object")
+
+	asBinary, err := before.MarshalBinary()
+	require.Nil(t, err)
+
+	t.Run("full round trip", func(t *testing.T) {
+		after, err := FromBinary(asBinary)
+		require.Nil(t, err)
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("optional round trip", func(t *testing.T) {
+		after, err := FromBinaryOptional(asBinary, additional.Properties{Vectors: true})
+		require.Nil(t, err)
+		assert.Equal(t, before.Vectors, after.Vectors)
+		assert.Nil(t, after.Vector)
+
+		after, err = FromBinaryOptional(asBinary, additional.Properties{})
+		require.Nil(t, err)
+		assert.Nil(t, after.Vectors)
+	})
+
+	t.Run("extract single named vector", func(t *testing.T) {
+		vector, err := NamedVectorFromBinary(asBinary, "description")
+		require.Nil(t, err)
+		assert.Equal(t, []float32{0.5, 0.25}, vector)
+
+		vector, err = NamedVectorFromBinary(asBinary, "unknown")
+		require.Nil(t, err)
+		assert.Nil(t, vector)
+	})
+
+	t.Run("other sections are unaffected", func(t *testing.T) {
+		vector, err := VectorFromBinary(asBinary)
+		require.Nil(t, err)
+		assert.Equal(t, []float32{1, 2, 0.7}, vector)
+
+		prop, ok, err := ParseAndExtractTextProp(asBinary, "name")
+		require.Nil(t, err)
+		require.True(t, ok)
+		assert.Equal(t, []string{"MyName"}, prop)
+	})
+
+	t.Run("objects without named vectors", func(t *testing.T) {
+		withoutVectors := before.DeepCopyDangerous()
+		withoutVectors.Vectors = nil
+		asBinary, err := withoutVectors.MarshalBinary()
+		require.Nil(t, err)
+
+		after, err := FromBinary(asBinary)
+		require.Nil(t, err)
+		assert.Nil(t, after.Vectors)
+
+		vector, err := NamedVectorFromBinary(asBinary, "title")
+		require.Nil(t, err)
+		assert.Nil(t, vector)
+	})
+}
+
+func TestStorageObjectMarshallingUUIDProps(t *testing.T) {
+	id1 := uuid.MustParse("28f3f61b-b524-45e0-9bbe-2c1550bf73d2")
+	id2 := uuid.MustParse("0ca2a9d9-6d3f-4b0b-8c4e-3f0f3c4b7a5e")
+
+	newObject := func(vectors models.Vectors) *Object {
+		obj := FromObject(
+			&models.Object{
+				Class:              "MyFavoriteClass",
+				CreationTimeUnix:   123456,
+				LastUpdateTimeUnix: 56789,
+				ID:                 strfmt.UUID("73f2eb5f-5abf-447a-81ca-74b1dd168247"),
+				Properties: map[string]interface{}{
+					"name":    "MyName",
+					"ownerId": id1,
+					"tagIds":  []uuid.UUID{id2, id1},
+				},
+				Vectors: vectors,
+			},
+			[]float32{1, 2, 0.7},
+		)
+		obj.SetDocID(7)
+		return obj
+	}
+
+	for _, vectors := range []models.Vectors{nil, {"title": {1, 2, 3}}} {
+		before := newObject(vectors)
+		asBinary, err := before.MarshalBinary()
+		require.Nil(t, err)
+
+		t.Run("full round trip", func(t *testing.T) {
+			after, err := FromBinary(asBinary)
+			require.Nil(t, err)
+			assert.Equal(t, before, after)
+		})
+
+		t.Run("optional round trip", func(t *testing.T) {
+			after, err := FromBinaryOptional(asBinary, additional.Properties{})
+			require.Nil(t, err)
+			assert.Equal(t, before.Properties(), after.Properties())
+		})
+
+		t.Run("unmarshal only the properties", func(t *testing.T) {
+			var props models.PropertySchema
+			require.Nil(t, UnmarshalPropertiesFromObject(asBinary, &props))
+			assert.Equal(t, before.Properties(), props)
+		})
+
+		t.Run("extract uuid props", func(t *testing.T) {
+			prop, ok, err := ParseAndExtractTextProp(asBinary, "ownerId")
+			require.Nil(t, err)
+			require.True(t, ok)
+			assert.Equal(t, []string{id1.String()}, prop)
+
+			prop, ok, err = ParseAndExtractTextProp(asBinary, "tagIds")
+			require.Nil(t, err)
+			require.True(t, ok)
+			assert.Equal(t, []string{id2.String(), id1.String()}, prop)
+
+			prop, ok, err = ParseAndExtractTextProp(asBinary, "name")
+			require.Nil(t, err)
+			require.True(t, ok)
+			assert.Equal(t, []string{"MyName"}, prop)
+		})
+
+		t.Run("named vectors are unaffected", func(t *testing.T) {
+			vector, err := NamedVectorFromBinary(asBinary, "title")
+			require.Nil(t, err)
+			assert.Equal(t, []float32(vectors["title"]), vector)
+		})
+	}
+
+	t.Run("the given properties are not changed", func(t *testing.T) {
+		before := newObject(nil)
+		_, err := before.MarshalBinary()
+		require.Nil(t, err)
+		assert.Equal(t, id1, before.Properties().(map[string]interface{})["ownerId"])
+	})
+}
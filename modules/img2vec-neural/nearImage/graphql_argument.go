@@ -57,5 +57,9 @@ func nearImageFields(prefix string) graphql.InputObjectConfigFieldMap {
 			Description: descriptions.Distance,
 			Type:        graphql.Float,
 		},
+		"targetVector": &graphql.InputObjectFieldConfig{
+			Description: descriptions.TargetVector,
+			Type:        graphql.String,
+		},
 	}
 }
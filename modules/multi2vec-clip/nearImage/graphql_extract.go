@@ -31,5 +31,10 @@ func extractNearImageFn(source map[string]interface{}) interface{} {
 		args.WithDistance = true
 	}
 
+	targetVector, ok := source["targetVector"]
+	if ok {
+		args.TargetVector = targetVector.(string)
+	}
+
 	return &args
 }
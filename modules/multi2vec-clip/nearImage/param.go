@@ -20,6 +20,7 @@ type NearImageParams struct {
 	Certainty    float64
 	Distance     float64
 	WithDistance bool
+	TargetVector string
 }
 
 func (n NearImageParams) GetCertainty() float64 {
@@ -34,6 +35,10 @@ func (n NearImageParams) SimilarityMetricProvided() bool {
 	return n.Certainty != 0 || n.WithDistance
 }
 
+func (n NearImageParams) GetTargetVector() string {
+	return n.TargetVector
+}
+
 func validateNearImageFn(param interface{}) error {
 	nearImage, ok := param.(*NearImageParams)
 	if !ok {
@@ -57,6 +57,10 @@ func (g *GraphQLArgumentsProvider) askFields(prefix string) graphql.InputObjectC
 			Description: descriptions.Distance,
 			Type:        graphql.Float,
 		},
+		"targetVector": &graphql.InputObjectFieldConfig{
+			Description: descriptions.TargetVector,
+			Type:        graphql.String,
+		},
 		"properties": &graphql.InputObjectFieldConfig{
 			Description: "Properties which contains text",
 			Type:        graphql.NewList(graphql.String),
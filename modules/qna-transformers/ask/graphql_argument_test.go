@@ -39,7 +39,7 @@ func TestAskGraphQLArgument(t *testing.T) {
 		askFields, ok := ask.Type.(*graphql.InputObject)
 		assert.True(t, ok)
 		assert.NotNil(t, askFields)
-		assert.Equal(t, 5, len(askFields.Fields()))
+		assert.Equal(t, 6, len(askFields.Fields()))
 		fields := askFields.Fields()
 		question := fields["question"]
 		questionNonNull, questionNonNullOK := question.Type.(*graphql.NonNull)
@@ -47,6 +47,7 @@ func TestAskGraphQLArgument(t *testing.T) {
 		assert.Equal(t, "String", questionNonNull.OfType.Name())
 		assert.NotNil(t, question)
 		assert.NotNil(t, fields["certainty"])
+		assert.NotNil(t, fields["targetVector"])
 		assert.NotNil(t, fields["distance"])
 		properties := fields["properties"]
 		propertiesList, propertiesListOK := properties.Type.(*graphql.List)
@@ -78,7 +79,7 @@ func TestAskGraphQLArgumentWithAutocorrect(t *testing.T) {
 		askFields, ok := ask.Type.(*graphql.InputObject)
 		assert.True(t, ok)
 		assert.NotNil(t, askFields)
-		assert.Equal(t, 6, len(askFields.Fields()))
+		assert.Equal(t, 7, len(askFields.Fields()))
 		fields := askFields.Fields()
 		question := fields["question"]
 		questionNonNull, questionNonNullOK := question.Type.(*graphql.NonNull)
@@ -86,6 +87,7 @@ func TestAskGraphQLArgumentWithAutocorrect(t *testing.T) {
 		assert.Equal(t, "String", questionNonNull.OfType.Name())
 		assert.NotNil(t, question)
 		assert.NotNil(t, fields["certainty"])
+		assert.NotNil(t, fields["targetVector"])
 		assert.NotNil(t, fields["distance"])
 		properties := fields["properties"]
 		propertiesList, propertiesListOK := properties.Type.(*graphql.List)
@@ -44,6 +44,11 @@ func (g *GraphQLArgumentsProvider) extractAskFn(source map[string]interface{}) i
 		args.WithDistance = true
 	}
 
+	targetVector, ok := source["targetVector"]
+	if ok {
+		args.TargetVector = targetVector.(string)
+	}
+
 	properties, ok := source["properties"].([]interface{})
 	if ok {
 		args.Properties = make([]string, len(properties))
@@ -20,6 +20,7 @@ type AskParams struct {
 	Certainty    float64
 	Distance     float64
 	WithDistance bool
+	TargetVector string
 	Properties   []string
 	Autocorrect  bool
 	Rerank       bool
@@ -37,6 +38,10 @@ func (n AskParams) SimilarityMetricProvided() bool {
 	return n.Certainty != 0 || n.WithDistance
 }
 
+func (n AskParams) GetTargetVector() string {
+	return n.TargetVector
+}
+
 func (g *GraphQLArgumentsProvider) validateAskFn(param interface{}) error {
 	ask, ok := param.(*AskParams)
 	if !ok {
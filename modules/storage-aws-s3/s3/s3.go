@@ -19,14 +19,13 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/pkg/errors"
-	"github.com/prometheus/client_golang/prometheus"
 	"github.com/semi-technologies/weaviate/entities/backup"
-	"github.com/semi-technologies/weaviate/usecases/monitoring"
 	"github.com/sirupsen/logrus"
 )
 
@@ -38,13 +37,12 @@ const (
 )
 
 type s3 struct {
-	client   *minio.Client
-	config   Config
-	logger   logrus.FieldLogger
-	dataPath string
+	client *minio.Client
+	config Config
+	logger logrus.FieldLogger
 }
 
-func New(config Config, logger logrus.FieldLogger, dataPath string) (*s3, error) {
+func New(config Config, logger logrus.FieldLogger) (*s3, error) {
 	region := os.Getenv(AWS_REGION)
 	if len(region) == 0 {
 		region = os.Getenv(AWS_DEFAULT_REGION)
@@ -61,7 +59,7 @@ func New(config Config, logger logrus.FieldLogger, dataPath string) (*s3, error)
 	if err != nil {
 		return nil, errors.Wrap(err, "create client")
 	}
-	return &s3{client, config, logger, dataPath}, nil
+	return &s3{client, config, logger}, nil
 }
 
 func (s *s3) makeObjectName(parts ...string) string {
@@ -69,44 +67,62 @@ func (s *s3) makeObjectName(parts ...string) string {
 	return path.Join(s.config.SnapshotRoot(), base)
 }
 
-func makeFilePath(parts ...string) string {
-	return path.Join(parts...)
-}
+func (s *s3) PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error {
+	if err := ctx.Err(); err != nil {
+		return backup.NewErrContextExpired(
+			errors.Wrap(err, "store snapshot aborted"))
+	}
 
-func (s *s3) StoreSnapshot(ctx context.Context, snapshot *backup.Snapshot) error {
-	timer := prometheus.NewTimer(monitoring.GetMetrics().SnapshotStoreDurations.WithLabelValues("s3", snapshot.ClassName))
-	defer timer.ObserveDuration()
 	bucketName, err := s.findBucket(ctx)
 	if err != nil {
-		return backup.NewErrInternal(errors.Wrap(err, "store snapshot"))
+		return backup.NewErrInternal(errors.Wrap(err, "put object"))
 	}
 
-	// save files
+	objectName := s.makeObjectName(snapshotID, key)
 	putOptions := minio.PutObjectOptions{ContentType: "application/octet-stream"}
-	for _, file := range snapshot.Files {
-		if err := ctx.Err(); err != nil {
-			return backup.NewErrContextExpired(
-				errors.Wrap(err, "store snapshot aborted"))
-		}
+	if _, err := s.client.PutObject(ctx, bucketName, objectName, r, -1, putOptions); err != nil {
+		return backup.NewErrInternal(
+			errors.Wrapf(err, "put file '%s'", objectName))
+	}
 
-		objectName := s.makeObjectName(snapshot.ClassName, snapshot.ID, file.Path)
-		filePath := makeFilePath(s.dataPath, file.Path)
+	return nil
+}
 
-		_, err := s.client.FPutObject(ctx, bucketName, objectName, filePath, putOptions)
-		if err != nil {
-			return backup.NewErrInternal(
-				errors.Wrapf(err, "put file '%s'", objectName))
-		}
+func (s *s3) GetObject(ctx context.Context, snapshotID, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, backup.NewErrContextExpired(
+			errors.Wrap(err, "restore snapshot aborted"))
+	}
 
-		// Get size of file
-		fileInfo, err := os.Stat(filePath)
-		if err != nil {
-			return errors.Errorf("Unable to get size of file %v", filePath)
-		}
-		monitoring.GetMetrics().SnapshotStoreDataTransferred.WithLabelValues("s3", snapshot.ClassName).Add(float64(fileInfo.Size()))
+	bucketName, err := s.findBucket(ctx)
+	if err != nil {
+		return nil, backup.NewErrInternal(errors.Wrap(err, "get object"))
+	}
+
+	objectName := s.makeObjectName(snapshotID, key)
+	obj, err := s.client.GetObject(ctx, bucketName, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, backup.NewErrInternal(
+			errors.Wrapf(err, "get file '%s'", objectName))
+	}
 
+	// minio only issues the request on first read, so a missing object
+	// surfaces through Stat rather than GetObject
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		s3Err, ok := err.(minio.ErrorResponse)
+		if ok && s3Err.StatusCode == http.StatusNotFound {
+			return nil, backup.NewErrNotFound(
+				errors.Wrapf(err, "get file '%s'", objectName))
+		}
+		return nil, backup.NewErrInternal(
+			errors.Wrapf(err, "get file '%s'", objectName))
 	}
 
+	return obj, nil
+}
+
+func (s *s3) PutMeta(ctx context.Context, snapshot *backup.Snapshot) error {
 	return s.putMeta(ctx, snapshot)
 }
 
@@ -115,7 +131,7 @@ func (s *s3) putMeta(ctx context.Context, snapshot *backup.Snapshot) error {
 	if err != nil {
 		return backup.NewErrInternal(errors.Wrap(err, "save meta"))
 	}
-	objectName := s.makeObjectName(snapshot.ClassName, snapshot.ID, "snapshot.json")
+	objectName := s.makeObjectName(snapshot.ID, "snapshot.json")
 	reader := bytes.NewReader(content)
 	_, err = s.client.PutObject(ctx, s.config.BucketName(), objectName, reader, reader.Size(),
 		minio.PutObjectOptions{ContentType: "application/octet-stream"})
@@ -126,48 +142,8 @@ func (s *s3) putMeta(ctx context.Context, snapshot *backup.Snapshot) error {
 	return nil
 }
 
-func (s *s3) RestoreSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	timer := prometheus.NewTimer(monitoring.GetMetrics().SnapshotRestoreFromStorageDurations.WithLabelValues("s3", className))
-	defer timer.ObserveDuration()
-	bucketName, err := s.findBucket(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "restore snapshot")
-	}
-
-	// Load the metadata from the backup into a snapshot struct
-	snapshot, err := s.getSnapshotFromBucket(ctx, className, snapshotID)
-	if err != nil {
-		return nil, errors.Wrap(err, "restore snapshot")
-	}
-
-	// Restore the files
-	for _, file := range snapshot.Files {
-		if err := ctx.Err(); err != nil {
-			return nil, errors.Wrapf(err, "restore snapshot aborted")
-		}
-
-		// Get the correct paths for the backup file and the active file
-		objectName := s.makeObjectName(className, snapshotID, file.Path)
-		filePath := makeFilePath(s.dataPath, file.Path)
-
-		// Download the backup file from the bucket
-		err := s.client.FGetObject(ctx, bucketName, objectName, filePath, minio.GetObjectOptions{})
-		if err != nil {
-			return nil, errors.Wrapf(err, "Unable to restore file %s, system might be in a corrupted state", filePath)
-		}
-		// Get size of file
-		fileInfo, err := os.Stat(filePath)
-		if err != nil {
-			return nil, errors.Errorf("Unable to get size of file %v", filePath)
-		}
-		monitoring.GetMetrics().SnapshotRestoreDataTransferred.WithLabelValues("s3", className).Add(float64(fileInfo.Size()))
-
-	}
-	return snapshot, nil
-}
-
-func (s *s3) GetMeta(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	snapshot, err := s.getSnapshotFromBucket(ctx, className, snapshotID)
+func (s *s3) GetMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
+	snapshot, err := s.getSnapshotFromBucket(ctx, snapshotID)
 	if err != nil {
 		return nil, err
 	}
@@ -175,8 +151,8 @@ func (s *s3) GetMeta(ctx context.Context, className, snapshotID string) (*backup
 	return snapshot, nil
 }
 
-func (s *s3) SetMetaError(ctx context.Context, className, snapshotID string, snapErr error) error {
-	snapshot, err := s.getSnapshotFromBucket(ctx, className, snapshotID)
+func (s *s3) SetMetaError(ctx context.Context, snapshotID string, snapErr error) error {
+	snapshot, err := s.getSnapshotFromBucket(ctx, snapshotID)
 	if err != nil {
 		return errors.Wrap(err, "set snapshot error")
 	}
@@ -187,8 +163,8 @@ func (s *s3) SetMetaError(ctx context.Context, className, snapshotID string, sna
 	return s.putMeta(ctx, snapshot)
 }
 
-func (s *s3) SetMetaStatus(ctx context.Context, className, snapshotID, status string) error {
-	snapshot, err := s.getSnapshotFromBucket(ctx, className, snapshotID)
+func (s *s3) SetMetaStatus(ctx context.Context, snapshotID, status string) error {
+	snapshot, err := s.getSnapshotFromBucket(ctx, snapshotID)
 	if err != nil {
 		return errors.Wrap(err, "set snapshot status")
 	}
@@ -216,12 +192,12 @@ func (s *s3) findBucket(ctx context.Context) (string, error) {
 	return bucketName, nil
 }
 
-func (s *s3) InitSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
+func (s *s3) InitSnapshot(ctx context.Context, snapshotID string, classes []string) (*backup.Snapshot, error) {
 	if _, err := s.findBucket(ctx); err != nil {
 		return nil, errors.Wrap(err, "init snapshot")
 	}
 
-	snapshot := backup.NewSnapshot(className, snapshotID, time.Now())
+	snapshot := backup.NewSnapshot(snapshotID, classes, time.Now())
 	snapshot.Status = string(backup.CreateStarted)
 
 	if err := s.putMeta(ctx, snapshot); err != nil {
@@ -231,18 +207,91 @@ func (s *s3) InitSnapshot(ctx context.Context, className, snapshotID string) (*b
 	return snapshot, nil
 }
 
-func (s *s3) DestinationPath(className, snapshotID string) string {
+func (s *s3) DestinationPath(snapshotID string) string {
 	return "s3://" + path.Join(s.config.BucketName(),
-		s.makeObjectName(className, snapshotID, "snapshot.json"))
+		s.makeObjectName(snapshotID, "snapshot.json"))
+}
+
+func (s *s3) ListSnapshots(ctx context.Context) ([]*backup.Snapshot, error) {
+	bucketName, err := s.findBucket(ctx)
+	if err != nil {
+		return nil, backup.NewErrInternal(errors.Wrap(err, "list snapshots"))
+	}
+
+	prefix := s.config.SnapshotRoot()
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var snapshots []*backup.Snapshot
+	listOptions := minio.ListObjectsOptions{Prefix: prefix}
+	for obj := range s.client.ListObjects(ctx, bucketName, listOptions) {
+		if obj.Err != nil {
+			return nil, backup.NewErrInternal(
+				errors.Wrap(obj.Err, "list snapshots"))
+		}
+
+		// without the recursive option every snapshot shows up as a single
+		// common prefix, i.e. "<root>/<snapshotID>/"
+		if !strings.HasSuffix(obj.Key, "/") {
+			continue
+		}
+
+		snapshotID := path.Base(obj.Key)
+		snapshot, err := s.getSnapshotFromBucket(ctx, snapshotID)
+		if err != nil {
+			if _, ok := err.(backup.ErrNotFound); ok {
+				continue
+			}
+			return nil, errors.Wrap(err, "list snapshots")
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+func (s *s3) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	bucketName, err := s.findBucket(ctx)
+	if err != nil {
+		return backup.NewErrInternal(errors.Wrap(err, "delete snapshot"))
+	}
+
+	deleted := 0
+	listOptions := minio.ListObjectsOptions{
+		Prefix:    s.makeObjectName(snapshotID) + "/",
+		Recursive: true,
+	}
+	for obj := range s.client.ListObjects(ctx, bucketName, listOptions) {
+		if obj.Err != nil {
+			return backup.NewErrInternal(
+				errors.Wrapf(obj.Err, "delete snapshot '%s'", snapshotID))
+		}
+
+		err := s.client.RemoveObject(ctx, bucketName, obj.Key, minio.RemoveObjectOptions{})
+		if err != nil {
+			return backup.NewErrInternal(
+				errors.Wrapf(err, "delete file '%s'", obj.Key))
+		}
+		deleted++
+	}
+
+	if deleted == 0 {
+		return backup.NewErrNotFound(
+			errors.Errorf("delete snapshot: snapshot '%s' does not exist", snapshotID))
+	}
+
+	return nil
 }
 
-func (s *s3) getSnapshotFromBucket(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	objectName := s.makeObjectName(className, snapshotID, "snapshot.json")
+func (s *s3) getSnapshotFromBucket(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
+	objectName := s.makeObjectName(snapshotID, "snapshot.json")
 	obj, err := s.client.GetObject(ctx, s.config.BucketName(), objectName, minio.GetObjectOptions{})
 	if err != nil {
 		return nil, backup.NewErrInternal(
 			errors.Wrapf(err, "get file '%s'", objectName))
 	}
+	defer obj.Close()
 
 	data, err := io.ReadAll(obj)
 	s3Err, ok := err.(minio.ErrorResponse)
@@ -13,6 +13,7 @@ package modstgs3
 
 import (
 	"context"
+	"io"
 	"os"
 	"strings"
 
@@ -21,32 +22,44 @@ import (
 	"github.com/semi-technologies/weaviate/modules/storage-aws-s3/s3"
 )
 
-func (m *StorageS3Module) StoreSnapshot(ctx context.Context, snapshot *backup.Snapshot) error {
-	return m.storageProvider.StoreSnapshot(ctx, snapshot)
+func (m *StorageS3Module) PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error {
+	return m.storageProvider.PutObject(ctx, snapshotID, key, r)
 }
 
-func (m *StorageS3Module) RestoreSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	return m.storageProvider.RestoreSnapshot(ctx, className, snapshotID)
+func (m *StorageS3Module) GetObject(ctx context.Context, snapshotID, key string) (io.ReadCloser, error) {
+	return m.storageProvider.GetObject(ctx, snapshotID, key)
 }
 
-func (m *StorageS3Module) SetMetaError(ctx context.Context, className, snapshotID string, err error) error {
-	return m.storageProvider.SetMetaError(ctx, className, snapshotID, err)
+func (m *StorageS3Module) SetMetaError(ctx context.Context, snapshotID string, err error) error {
+	return m.storageProvider.SetMetaError(ctx, snapshotID, err)
 }
 
-func (m *StorageS3Module) SetMetaStatus(ctx context.Context, className, snapshotID, status string) error {
-	return m.storageProvider.SetMetaStatus(ctx, className, snapshotID, status)
+func (m *StorageS3Module) SetMetaStatus(ctx context.Context, snapshotID, status string) error {
+	return m.storageProvider.SetMetaStatus(ctx, snapshotID, status)
 }
 
-func (m *StorageS3Module) GetMeta(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	return m.storageProvider.GetMeta(ctx, className, snapshotID)
+func (m *StorageS3Module) GetMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
+	return m.storageProvider.GetMeta(ctx, snapshotID)
 }
 
-func (m *StorageS3Module) DestinationPath(className, snapshotID string) string {
-	return m.storageProvider.DestinationPath(className, snapshotID)
+func (m *StorageS3Module) PutMeta(ctx context.Context, snapshot *backup.Snapshot) error {
+	return m.storageProvider.PutMeta(ctx, snapshot)
 }
 
-func (m *StorageS3Module) InitSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	return m.storageProvider.InitSnapshot(ctx, className, snapshotID)
+func (m *StorageS3Module) DestinationPath(snapshotID string) string {
+	return m.storageProvider.DestinationPath(snapshotID)
+}
+
+func (m *StorageS3Module) InitSnapshot(ctx context.Context, snapshotID string, classes []string) (*backup.Snapshot, error) {
+	return m.storageProvider.InitSnapshot(ctx, snapshotID, classes)
+}
+
+func (m *StorageS3Module) ListSnapshots(ctx context.Context) ([]*backup.Snapshot, error) {
+	return m.storageProvider.ListSnapshots(ctx)
+}
+
+func (m *StorageS3Module) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	return m.storageProvider.DeleteSnapshot(ctx, snapshotID)
 }
 
 func (m *StorageS3Module) initSnapshotStorage(ctx context.Context) error {
@@ -59,7 +72,7 @@ func (m *StorageS3Module) initSnapshotStorage(ctx context.Context) error {
 	rootName := os.Getenv(s3SnapshotRoot)
 	useSSL := strings.ToLower(os.Getenv(s3UseSSL)) == "true"
 	config := s3.NewConfig(endpoint, bucketName, rootName, useSSL)
-	storageProvider, err := s3.New(config, m.logger, m.dataPath)
+	storageProvider, err := s3.New(config, m.logger)
 	if err != nil {
 		return errors.Wrap(err, "initialize AWS S3 module")
 	}
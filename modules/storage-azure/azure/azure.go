@@ -0,0 +1,347 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/backup"
+)
+
+const (
+	AZURE_STORAGE_CONNECTION_STRING = "AZURE_STORAGE_CONNECTION_STRING"
+	AZURE_STORAGE_ACCOUNT           = "AZURE_STORAGE_ACCOUNT"
+	AZURE_STORAGE_KEY               = "AZURE_STORAGE_KEY"
+)
+
+type azure struct {
+	client *azblob.ContainerClient
+	config Config
+}
+
+// New creates a client for the configured container. A connection string
+// takes precedence over the account name and key, as it also allows to
+// point the client to an emulator like Azurite.
+func New(ctx context.Context, config Config) (*azure, error) {
+	service, err := newServiceClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "create client")
+	}
+	client, err := service.NewContainerClient(config.ContainerName())
+	if err != nil {
+		return nil, errors.Wrap(err, "create container client")
+	}
+	return &azure{client, config}, nil
+}
+
+func newServiceClient() (*azblob.ServiceClient, error) {
+	if connectionString := os.Getenv(AZURE_STORAGE_CONNECTION_STRING); connectionString != "" {
+		return azblob.NewServiceClientFromConnectionString(connectionString, nil)
+	}
+
+	accountName := os.Getenv(AZURE_STORAGE_ACCOUNT)
+	if accountName == "" {
+		return nil, errors.Errorf("either '%s' or '%s' must be set",
+			AZURE_STORAGE_CONNECTION_STRING, AZURE_STORAGE_ACCOUNT)
+	}
+	serviceURL := fmt.Sprintf("https://%s.blob.core.windows.net/", accountName)
+
+	accountKey := os.Getenv(AZURE_STORAGE_KEY)
+	if accountKey == "" {
+		// only works for containers with anonymous read/write access
+		return azblob.NewServiceClientWithNoCredential(serviceURL, nil)
+	}
+	credential, err := azblob.NewSharedKeyCredential(accountName, accountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "create shared key credential")
+	}
+	return azblob.NewServiceClientWithSharedKey(serviceURL, credential, nil)
+}
+
+func (a *azure) getObject(ctx context.Context, objectName string) ([]byte, error) {
+	reader, err := a.newReader(ctx, objectName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, backup.ErrNotFound{}
+		}
+		return nil, errors.Wrapf(err, "new reader: %v", objectName)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read object: %v", objectName)
+	}
+	return content, nil
+}
+
+func (a *azure) PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error {
+	if err := ctx.Err(); err != nil {
+		return backup.NewErrContextExpired(
+			errors.Wrap(err, "store snapshot aborted"))
+	}
+
+	objectName := a.makeObjectName(snapshotID, key)
+	if err := a.putReader(ctx, snapshotID, objectName, r); err != nil {
+		return backup.NewErrInternal(errors.Wrap(err, "put object"))
+	}
+
+	return nil
+}
+
+func (a *azure) GetObject(ctx context.Context, snapshotID, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, backup.NewErrContextExpired(
+			errors.Wrap(err, "restore snapshot aborted"))
+	}
+
+	objectName := a.makeObjectName(snapshotID, key)
+	reader, err := a.newReader(ctx, objectName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, backup.NewErrNotFound(
+				errors.Wrapf(err, "get object: %v", objectName))
+		}
+		return nil, backup.NewErrInternal(
+			errors.Wrapf(err, "new reader: %v", objectName))
+	}
+
+	return reader, nil
+}
+
+func (a *azure) PutMeta(ctx context.Context, snapshot *backup.Snapshot) error {
+	content, err := json.Marshal(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal meta")
+	}
+	objectName := a.makeObjectName(snapshot.ID, "snapshot.json")
+	if err := a.putFile(ctx, snapshot.ID, objectName, content); err != nil {
+		return errors.Wrap(err, "failed to store meta")
+	}
+	return nil
+}
+
+func (a *azure) GetMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
+	objectName := a.makeObjectName(snapshotID, "snapshot.json")
+	contents, err := a.getObject(ctx, objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot backup.Snapshot
+	err = json.Unmarshal(contents, &snapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, "get snapshot status")
+	}
+
+	return &snapshot, nil
+}
+
+func (a *azure) SetMetaError(ctx context.Context, snapshotID string, snapErr error) error {
+	snapshot, err := a.GetMeta(ctx, snapshotID)
+	if err != nil {
+		return errors.Wrap(err, "set meta error")
+	}
+
+	snapshot.Status = string(backup.CreateFailed)
+	snapshot.Error = snapErr.Error()
+
+	if err := a.PutMeta(ctx, snapshot); err != nil {
+		return errors.Wrap(err, "set meta error")
+	}
+
+	return nil
+}
+
+func (a *azure) SetMetaStatus(ctx context.Context, snapshotID, status string) error {
+	snapshot, err := a.GetMeta(ctx, snapshotID)
+	if err != nil {
+		return errors.Wrap(err, "set meta status")
+	}
+
+	if status == string(backup.CreateSuccess) {
+		snapshot.CompletedAt = time.Now()
+	}
+
+	snapshot.Status = status
+
+	if err := a.PutMeta(ctx, snapshot); err != nil {
+		return errors.Wrap(err, "set meta status")
+	}
+
+	return nil
+}
+
+func (a *azure) DestinationPath(snapshotID string) string {
+	return strings.TrimSuffix(a.client.URL(), "/") + "/" +
+		a.makeObjectName(snapshotID, "snapshot.json")
+}
+
+func (a *azure) InitSnapshot(ctx context.Context, snapshotID string, classes []string) (*backup.Snapshot, error) {
+	snapshot := backup.NewSnapshot(snapshotID, classes, time.Now())
+	snapshot.Status = string(backup.CreateStarted)
+	b, err := json.Marshal(&snapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, "init snapshot")
+	}
+
+	objectName := a.makeObjectName(snapshotID, "snapshot.json")
+
+	if err := a.putFile(ctx, snapshot.ID, objectName, b); err != nil {
+		return nil, errors.Wrap(err, "init snapshot")
+	}
+
+	return snapshot, nil
+}
+
+func (a *azure) ListSnapshots(ctx context.Context) ([]*backup.Snapshot, error) {
+	prefix := a.config.SnapshotRoot()
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var snapshots []*backup.Snapshot
+	// with a delimiter every snapshot shows up as a single prefix,
+	// i.e. "<root>/<snapshotID>/"
+	pager := a.client.ListBlobsHierarchy("/",
+		&azblob.ContainerListBlobsHierarchyOptions{Prefix: &prefix})
+	for pager.NextPage(ctx) {
+		segment := pager.PageResponse().Segment
+		if segment == nil {
+			continue
+		}
+		for _, blobPrefix := range segment.BlobPrefixes {
+			if blobPrefix.Name == nil {
+				continue
+			}
+
+			snapshotID := path.Base(*blobPrefix.Name)
+			snapshot, err := a.GetMeta(ctx, snapshotID)
+			if err != nil {
+				if _, ok := err.(backup.ErrNotFound); ok {
+					continue
+				}
+				return nil, errors.Wrap(err, "list snapshots")
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, errors.Wrap(err, "list snapshots")
+	}
+
+	return snapshots, nil
+}
+
+func (a *azure) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	prefix := a.makeObjectName(snapshotID) + "/"
+
+	deleted := 0
+	pager := a.client.ListBlobsFlat(&azblob.ContainerListBlobsFlatOptions{Prefix: &prefix})
+	for pager.NextPage(ctx) {
+		segment := pager.PageResponse().Segment
+		if segment == nil {
+			continue
+		}
+		for _, item := range segment.BlobItems {
+			if item.Name == nil {
+				continue
+			}
+
+			blob, err := a.client.NewBlobClient(*item.Name)
+			if err != nil {
+				return backup.NewErrInternal(
+					errors.Wrapf(err, "delete object: %v", *item.Name))
+			}
+			if _, err := blob.Delete(ctx, nil); err != nil {
+				return backup.NewErrInternal(
+					errors.Wrapf(err, "delete object: %v", *item.Name))
+			}
+			deleted++
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return backup.NewErrInternal(
+			errors.Wrapf(err, "delete snapshot '%s'", snapshotID))
+	}
+
+	if deleted == 0 {
+		return backup.NewErrNotFound(
+			errors.Errorf("delete snapshot: snapshot '%s' does not exist", snapshotID))
+	}
+
+	return nil
+}
+
+func (a *azure) putFile(ctx context.Context, snapshotID, objectName string, content []byte) error {
+	return a.putReader(ctx, snapshotID, objectName, bytes.NewReader(content))
+}
+
+func (a *azure) putReader(ctx context.Context, snapshotID, objectName string, r io.Reader) error {
+	blob, err := a.client.NewBlockBlobClient(objectName)
+	if err != nil {
+		return errors.Wrapf(err, "create blob client for file: %v", objectName)
+	}
+
+	contentType := "application/octet-stream"
+	_, err = blob.UploadStream(ctx, r, azblob.UploadStreamOptions{
+		HTTPHeaders: &azblob.BlobHTTPHeaders{BlobContentType: &contentType},
+		// metadata keys must be valid C# identifiers
+		Metadata: map[string]string{
+			"snapshot_id": snapshotID,
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "write file: %v", objectName)
+	}
+	return nil
+}
+
+func (a *azure) newReader(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	blob, err := a.client.NewBlobClient(objectName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "create blob client for file: %v", objectName)
+	}
+
+	resp, err := blob.Download(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(nil), nil
+}
+
+func (a *azure) makeObjectName(parts ...string) string {
+	base := path.Join(parts...)
+	return path.Join(a.config.SnapshotRoot(), base)
+}
+
+func isNotFound(err error) bool {
+	var storageErr *azblob.StorageError
+	if errors.As(err, &storageErr) {
+		return storageErr.ErrorCode == azblob.StorageErrorCodeBlobNotFound ||
+			storageErr.ErrorCode == azblob.StorageErrorCodeContainerNotFound ||
+			(storageErr.Response() != nil &&
+				storageErr.Response().StatusCode == http.StatusNotFound)
+	}
+	return false
+}
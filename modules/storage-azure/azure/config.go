@@ -0,0 +1,38 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package azure
+
+type Config interface {
+	ContainerName() string
+	SnapshotRoot() string
+}
+
+type config struct {
+	container string
+
+	// this is an optional value, allowing for
+	// the snapshot to be stored in a specific
+	// directory inside the provided container
+	snapshotRoot string
+}
+
+func NewConfig(container, root string) Config {
+	return &config{container, root}
+}
+
+func (c *config) ContainerName() string {
+	return c.container
+}
+
+func (c *config) SnapshotRoot() string {
+	return c.snapshotRoot
+}
@@ -0,0 +1,93 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package modstgazure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/modulecapabilities"
+	"github.com/semi-technologies/weaviate/entities/moduletools"
+	"github.com/semi-technologies/weaviate/modules/storage-azure/azure"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	Name           = "storage-azure"
+	AltName1       = "azure"
+	azureContainer = "STORAGE_AZURE_CONTAINER"
+
+	// this is an optional value, allowing for
+	// the snapshot to be stored in a specific
+	// directory inside the provided container.
+	//
+	// if left unset, the snapshot files will
+	// be stored directly in the root of the
+	// container.
+	azureSnapshotRoot = "STORAGE_AZURE_ROOT"
+)
+
+type StorageAzureModule struct {
+	logger          logrus.FieldLogger
+	storageProvider modulecapabilities.SnapshotStorage
+	config          azure.Config
+}
+
+func New() *StorageAzureModule {
+	return &StorageAzureModule{}
+}
+
+func (m *StorageAzureModule) Name() string {
+	return Name
+}
+
+func (m *StorageAzureModule) AltNames() []string {
+	return []string{AltName1}
+}
+
+func (m *StorageAzureModule) Type() modulecapabilities.ModuleType {
+	return modulecapabilities.Storage
+}
+
+func (m *StorageAzureModule) Init(ctx context.Context,
+	params moduletools.ModuleInitParams,
+) error {
+	m.logger = params.GetLogger()
+
+	if err := m.initSnapshotStorage(ctx); err != nil {
+		return errors.Wrap(err, "init snapshot storage")
+	}
+
+	return nil
+}
+
+func (m *StorageAzureModule) RootHandler() http.Handler {
+	// TODO: remove once this is a capability interface
+	return nil
+}
+
+func (m *StorageAzureModule) MetaInfo() (map[string]interface{}, error) {
+	metaInfo := make(map[string]interface{})
+	metaInfo["containerName"] = m.config.ContainerName()
+	if root := m.config.SnapshotRoot(); root != "" {
+		metaInfo["rootName"] = root
+	}
+	return metaInfo, nil
+}
+
+// verify we implement the modules.Module interface
+var (
+	_ = modulecapabilities.Module(New())
+	_ = modulecapabilities.SnapshotStorage(New())
+	_ = modulecapabilities.MetaProvider(New())
+)
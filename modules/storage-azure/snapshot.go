@@ -0,0 +1,78 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package modstgazure
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/backup"
+	"github.com/semi-technologies/weaviate/modules/storage-azure/azure"
+)
+
+func (m *StorageAzureModule) PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error {
+	return m.storageProvider.PutObject(ctx, snapshotID, key, r)
+}
+
+func (m *StorageAzureModule) GetObject(ctx context.Context, snapshotID, key string) (io.ReadCloser, error) {
+	return m.storageProvider.GetObject(ctx, snapshotID, key)
+}
+
+func (m *StorageAzureModule) SetMetaError(ctx context.Context, snapshotID string, err error) error {
+	return m.storageProvider.SetMetaError(ctx, snapshotID, err)
+}
+
+func (m *StorageAzureModule) SetMetaStatus(ctx context.Context, snapshotID, status string) error {
+	return m.storageProvider.SetMetaStatus(ctx, snapshotID, status)
+}
+
+func (m *StorageAzureModule) GetMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
+	return m.storageProvider.GetMeta(ctx, snapshotID)
+}
+
+func (m *StorageAzureModule) PutMeta(ctx context.Context, snapshot *backup.Snapshot) error {
+	return m.storageProvider.PutMeta(ctx, snapshot)
+}
+
+func (m *StorageAzureModule) DestinationPath(snapshotID string) string {
+	return m.storageProvider.DestinationPath(snapshotID)
+}
+
+func (m *StorageAzureModule) InitSnapshot(ctx context.Context, snapshotID string, classes []string) (*backup.Snapshot, error) {
+	return m.storageProvider.InitSnapshot(ctx, snapshotID, classes)
+}
+
+func (m *StorageAzureModule) ListSnapshots(ctx context.Context) ([]*backup.Snapshot, error) {
+	return m.storageProvider.ListSnapshots(ctx)
+}
+
+func (m *StorageAzureModule) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	return m.storageProvider.DeleteSnapshot(ctx, snapshotID)
+}
+
+func (m *StorageAzureModule) initSnapshotStorage(ctx context.Context) error {
+	containerName := os.Getenv(azureContainer)
+	if containerName == "" {
+		return errors.Errorf("snapshot init: '%s' must be set", azureContainer)
+	}
+
+	config := azure.NewConfig(containerName, os.Getenv(azureSnapshotRoot))
+	storageProvider, err := azure.New(ctx, config)
+	if err != nil {
+		return errors.Wrap(err, "init azure client")
+	}
+	m.storageProvider = storageProvider
+	m.config = config
+	return nil
+}
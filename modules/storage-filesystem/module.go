@@ -16,6 +16,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/entities/modulecapabilities"
@@ -31,7 +32,6 @@ const (
 
 type StorageFileSystemModule struct {
 	logger        logrus.FieldLogger
-	dataPath      string // path to the current (operational) data
 	snapshotsPath string // complete(?) path to the directory that holds all the snapshots
 }
 
@@ -55,7 +55,6 @@ func (m *StorageFileSystemModule) Init(ctx context.Context,
 	params moduletools.ModuleInitParams,
 ) error {
 	m.logger = params.GetLogger()
-	m.dataPath = params.GetStorageProvider().DataPath()
 	snapshotsPath := os.Getenv(snapshotsPathName)
 	if err := m.initSnapshotStorage(ctx, snapshotsPath); err != nil {
 		return errors.Wrap(err, "init snapshot storage")
@@ -64,8 +63,8 @@ func (m *StorageFileSystemModule) Init(ctx context.Context,
 	return nil
 }
 
-func (m *StorageFileSystemModule) DestinationPath(className, snapshotID string) string {
-	return m.makeSnapshotDirPath(className, snapshotID)
+func (m *StorageFileSystemModule) DestinationPath(snapshotID string) string {
+	return m.makeSnapshotDirPath(snapshotID)
 }
 
 func (m *StorageFileSystemModule) RootHandler() http.Handler {
@@ -79,16 +78,23 @@ func (m *StorageFileSystemModule) MetaInfo() (map[string]interface{}, error) {
 	return metaInfo, nil
 }
 
-func (m *StorageFileSystemModule) makeSnapshotDirPath(className, id string) string {
-	return filepath.Join(m.snapshotsPath, className, id)
+func (m *StorageFileSystemModule) makeSnapshotDirPath(id string) string {
+	return filepath.Join(m.snapshotsPath, id)
 }
 
-func (m *StorageFileSystemModule) makeSnapshotFilePath(className, id, relPath string) string {
-	return filepath.Join(m.makeSnapshotDirPath(className, id), relPath)
+// makeSnapshotFilePath errors if the relative path would point outside of
+// the snapshot's directory
+func (m *StorageFileSystemModule) makeSnapshotFilePath(id, relPath string) (string, error) {
+	dir := m.makeSnapshotDirPath(id)
+	filePath := filepath.Join(dir, relPath)
+	if !strings.HasPrefix(filePath, dir+string(filepath.Separator)) {
+		return "", errors.Errorf("path %q is outside of snapshot dir", relPath)
+	}
+	return filePath, nil
 }
 
-func (m *StorageFileSystemModule) makeMetaFilePath(className, id string) string {
-	dir := m.makeSnapshotDirPath(className, id)
+func (m *StorageFileSystemModule) makeMetaFilePath(id string) string {
+	dir := m.makeSnapshotDirPath(id)
 	return filepath.Join(dir, "snapshot.json")
 }
 
@@ -22,88 +22,59 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
-	"github.com/prometheus/client_golang/prometheus"
 	"github.com/semi-technologies/weaviate/entities/backup"
-	"github.com/semi-technologies/weaviate/usecases/monitoring"
 )
 
-func (m *StorageFileSystemModule) StoreSnapshot(ctx context.Context, snapshot *backup.Snapshot) error {
-	timer := prometheus.NewTimer(monitoring.GetMetrics().SnapshotStoreDurations.WithLabelValues("filesystem", snapshot.ClassName))
-	defer timer.ObserveDuration()
+func (m *StorageFileSystemModule) PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error {
 	if err := ctx.Err(); err != nil {
 		return backup.NewErrContextExpired(
 			errors.Wrap(err, "store snapshot aborted"))
 	}
 
-	dstSnapshotPath, err := m.createBackupDir(snapshot)
+	dstPath, err := m.makeSnapshotFilePath(snapshotID, key)
 	if err != nil {
-		return err
+		return backup.NewErrInternal(errors.Wrapf(err, "put object '%v'", key))
 	}
 
-	for _, file := range snapshot.Files {
-		if err := ctx.Err(); err != nil {
-			return backup.NewErrContextExpired(
-				errors.Wrap(err, "store snapshot aborted"))
-		}
-		if err := m.copyFile(dstSnapshotPath, m.dataPath, file.Path); err != nil {
-			return err
-		}
-
-		destPath := m.makeSnapshotFilePath(snapshot.ClassName, snapshot.ID, file.Path)
-		// Get size of file
-		fileInfo, err := os.Stat(destPath)
-		if err != nil {
-			return errors.Errorf("Unable to get size of file %v", destPath)
-		}
-		monitoring.GetMetrics().SnapshotStoreDataTransferred.WithLabelValues("filesystem", snapshot.ClassName).Add(float64(fileInfo.Size()))
-	}
-
-	if err := m.saveMeta(snapshot); err != nil {
-		return err
+	if err := m.writeFile(dstPath, r); err != nil {
+		m.logger.WithField("module", m.Name()).
+			WithField("action", "put_object").
+			WithField("snapshot_id", snapshotID).
+			WithError(err).
+			Errorf("failed writing snapshot file")
+		return backup.NewErrInternal(errors.Wrapf(err, "put object '%v'", key))
 	}
 
 	return nil
 }
 
-func (m *StorageFileSystemModule) RestoreSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	timer := prometheus.NewTimer(monitoring.GetMetrics().SnapshotRestoreFromStorageDurations.WithLabelValues("filesystem", className))
-	defer timer.ObserveDuration()
-	snapshot, err := m.loadSnapshotMeta(ctx, className, snapshotID)
-	if err != nil {
-		return nil, errors.Wrap(err, "restore snapshot")
+func (m *StorageFileSystemModule) GetObject(ctx context.Context, snapshotID, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, backup.NewErrContextExpired(
+			errors.Wrap(err, "restore snapshot aborted"))
 	}
 
-	for _, file := range snapshot.Files {
-		destPath := m.makeSnapshotFilePath(className, snapshotID, file.Path)
-
-		if err := ctx.Err(); err != nil {
-			return nil, errors.Wrap(err, "restore snapshot aborted, system might be in an invalid state")
-		}
-		if err := m.copyFile(m.dataPath, m.makeSnapshotDirPath(className, snapshotID), file.Path); err != nil {
-			return nil, errors.Wrapf(err, "restore snapshot aborted, system might be in an invalid state: file %v", file.Path)
-		}
-		if err := m.copyFile(m.dataPath, m.makeSnapshotDirPath(className, snapshotID), file.Path); err != nil {
-			return nil, errors.Wrapf(err, "restore snapshot aborted, system might be in an invalid state: file %v", file.Path)
-		}
-
-		// Get size of file
-		fileInfo, err := os.Stat(destPath)
-		if err != nil {
-			return nil, errors.Errorf("Unable to get size of file %v", destPath)
-		}
-		monitoring.GetMetrics().SnapshotRestoreDataTransferred.WithLabelValues("filesystem", className).Add(float64(fileInfo.Size()))
+	srcPath, err := m.makeSnapshotFilePath(snapshotID, key)
+	if err != nil {
+		return nil, backup.NewErrInternal(errors.Wrapf(err, "get object '%v'", key))
+	}
 
+	f, err := os.Open(srcPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, backup.NewErrNotFound(errors.Wrapf(err, "get object '%v'", key))
+	} else if err != nil {
+		return nil, backup.NewErrInternal(errors.Wrapf(err, "get object '%v'", key))
 	}
 
-	return snapshot, nil
+	return f, nil
 }
 
-func (m *StorageFileSystemModule) loadSnapshotMeta(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
+func (m *StorageFileSystemModule) loadSnapshotMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, errors.Wrap(err, "load snapshot meta")
 	}
 
-	metaPath := m.makeMetaFilePath(className, snapshotID)
+	metaPath := m.makeMetaFilePath(snapshotID)
 
 	if _, err := os.Stat(metaPath); errors.Is(err, os.ErrNotExist) {
 		return nil, backup.NewErrNotFound(err)
@@ -126,12 +97,20 @@ func (m *StorageFileSystemModule) loadSnapshotMeta(ctx context.Context, classNam
 	return &snapshot, nil
 }
 
-func (m *StorageFileSystemModule) GetMeta(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	return m.loadSnapshotMeta(ctx, className, snapshotID)
+func (m *StorageFileSystemModule) GetMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
+	return m.loadSnapshotMeta(ctx, snapshotID)
+}
+
+func (m *StorageFileSystemModule) PutMeta(ctx context.Context, snapshot *backup.Snapshot) error {
+	if err := ctx.Err(); err != nil {
+		return backup.NewErrContextExpired(errors.Wrap(err, "put meta"))
+	}
+
+	return m.saveMeta(snapshot)
 }
 
-func (m *StorageFileSystemModule) InitSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	snapshot := backup.NewSnapshot(className, snapshotID, time.Now())
+func (m *StorageFileSystemModule) InitSnapshot(ctx context.Context, snapshotID string, classes []string) (*backup.Snapshot, error) {
+	snapshot := backup.NewSnapshot(snapshotID, classes, time.Now())
 	snapshot.Status = string(backup.CreateStarted)
 
 	if err := m.saveMeta(snapshot); err != nil {
@@ -141,12 +120,16 @@ func (m *StorageFileSystemModule) InitSnapshot(ctx context.Context, className, s
 	return snapshot, nil
 }
 
-func (m *StorageFileSystemModule) SetMetaStatus(ctx context.Context, className, snapshotID, status string) error {
-	snapshot, err := m.loadSnapshotMeta(ctx, className, snapshotID)
+func (m *StorageFileSystemModule) SetMetaStatus(ctx context.Context, snapshotID, status string) error {
+	snapshot, err := m.loadSnapshotMeta(ctx, snapshotID)
 	if err != nil {
 		return backup.NewErrInternal(errors.Wrap(err, "set meta status"))
 	}
 
+	if status == string(backup.CreateSuccess) {
+		snapshot.CompletedAt = time.Now()
+	}
+
 	snapshot.Status = string(status)
 
 	if err := m.saveMeta(snapshot); err != nil {
@@ -156,8 +139,8 @@ func (m *StorageFileSystemModule) SetMetaStatus(ctx context.Context, className,
 	return nil
 }
 
-func (m *StorageFileSystemModule) SetMetaError(ctx context.Context, className, snapshotID string, snapErr error) error {
-	snapshot, err := m.loadSnapshotMeta(ctx, className, snapshotID)
+func (m *StorageFileSystemModule) SetMetaError(ctx context.Context, snapshotID string, snapErr error) error {
+	snapshot, err := m.loadSnapshotMeta(ctx, snapshotID)
 	if err != nil {
 		return backup.NewErrInternal(errors.Wrap(err, "set meta error"))
 	}
@@ -199,53 +182,19 @@ func (m *StorageFileSystemModule) createBackupsDir(snapshotsPath string) error {
 	return nil
 }
 
-func (m *StorageFileSystemModule) createBackupDir(snapshot *backup.Snapshot) (snapshotPath string, err error) {
-	snapshotPath = m.makeSnapshotDirPath(snapshot.ClassName, snapshot.ID)
-	return snapshotPath, m.createBackupsDir(snapshotPath)
-}
-
-func (m *StorageFileSystemModule) copyFile(dstSnapshotPath, srcBasePath, srcRelPath string) error {
-	srcAbsPath := filepath.Join(srcBasePath, srcRelPath)
-	dstAbsPath := filepath.Join(dstSnapshotPath, srcRelPath)
-
-	src, err := os.Open(srcAbsPath)
-	if err != nil {
-		m.logger.WithField("module", m.Name()).
-			WithField("action", "copy_file").
-			WithError(err).
-			Errorf("failed opening source file")
-		return backup.NewErrInternal(
-			errors.Wrapf(err, "open snapshot source file '%v'", srcRelPath))
+func (m *StorageFileSystemModule) writeFile(dstPath string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(dstPath), os.ModePerm); err != nil {
+		return errors.Wrap(err, "create snapshot destination dir")
 	}
-	defer src.Close()
 
-	if err := os.MkdirAll(filepath.Dir(dstAbsPath), os.ModePerm); err != nil {
-		m.logger.WithField("module", m.Name()).
-			WithField("action", "copy_file").
-			WithError(err).
-			Errorf("failed creating destication dir for file")
-		return backup.NewErrInternal(
-			errors.Wrapf(err, "create snapshot destination dir for file '%v'", srcRelPath))
-	}
-	dst, err := os.Create(dstAbsPath)
+	dst, err := os.Create(dstPath)
 	if err != nil {
-		m.logger.WithField("module", m.Name()).
-			WithField("action", "copy_file").
-			WithError(err).
-			Errorf("failed creating destication file")
-		return backup.NewErrInternal(
-			errors.Wrapf(err, "create snapshot destination file '%v'", srcRelPath))
+		return errors.Wrap(err, "create snapshot destination file")
 	}
 	defer dst.Close()
 
-	_, err = io.Copy(dst, src)
-	if err != nil {
-		m.logger.WithField("module", m.Name()).
-			WithField("action", "copy_file").
-			WithError(err).
-			Errorf("failed copying snapshot file")
-		return backup.NewErrInternal(
-			errors.Wrapf(err, "copy snapshot file '%v'", srcRelPath))
+	if _, err := io.Copy(dst, r); err != nil {
+		return errors.Wrap(err, "copy snapshot file")
 	}
 
 	return nil
@@ -256,7 +205,6 @@ func (m *StorageFileSystemModule) saveMeta(snapshot *backup.Snapshot) error {
 	if err != nil {
 		m.logger.WithField("module", m.Name()).
 			WithField("action", "save_meta").
-			WithField("snapshot_classname", snapshot.ClassName).
 			WithField("snapshot_id", snapshot.ID).
 			WithError(err).
 			Errorf("failed creating meta file")
@@ -264,13 +212,12 @@ func (m *StorageFileSystemModule) saveMeta(snapshot *backup.Snapshot) error {
 			errors.Wrapf(err, "create meta file for snapshot '%v'", snapshot.ID))
 	}
 
-	metaFile := m.makeMetaFilePath(snapshot.ClassName, snapshot.ID)
+	metaFile := m.makeMetaFilePath(snapshot.ID)
 	metaDir := path.Dir(metaFile)
 
 	if err := os.MkdirAll(metaDir, os.ModePerm); err != nil {
 		m.logger.WithField("module", m.Name()).
 			WithField("action", "save_meta").
-			WithField("snapshot_classname", snapshot.ClassName).
 			WithField("snapshot_id", snapshot.ID).
 			WithError(err).
 			Errorf("failed creating meta file")
@@ -288,7 +235,6 @@ func (m *StorageFileSystemModule) saveMeta(snapshot *backup.Snapshot) error {
 	if err := os.WriteFile(tmpMetaFile, content, os.ModePerm); err != nil {
 		m.logger.WithField("module", m.Name()).
 			WithField("action", "save_meta").
-			WithField("snapshot_classname", snapshot.ClassName).
 			WithField("snapshot_id", snapshot.ID).
 			WithError(err).
 			Errorf("failed creating meta file")
@@ -296,10 +242,9 @@ func (m *StorageFileSystemModule) saveMeta(snapshot *backup.Snapshot) error {
 			errors.Wrapf(err, "create temporary meta file for snapshot %v", snapshot.ID))
 	}
 
-	if os.Rename(tmpMetaFile, metaFile); err != nil {
+	if err := os.Rename(tmpMetaFile, metaFile); err != nil {
 		m.logger.WithField("module", m.Name()).
 			WithField("action", "rename_meta").
-			WithField("snapshot_classname", snapshot.ClassName).
 			WithField("snapshot_id", snapshot.ID).
 			WithError(err).
 			Errorf("failed to rename meta file")
@@ -309,3 +254,56 @@ func (m *StorageFileSystemModule) saveMeta(snapshot *backup.Snapshot) error {
 
 	return nil
 }
+
+func (m *StorageFileSystemModule) ListSnapshots(ctx context.Context) ([]*backup.Snapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, backup.NewErrContextExpired(errors.Wrap(err, "list snapshots"))
+	}
+
+	entries, err := os.ReadDir(m.snapshotsPath)
+	if err != nil {
+		return nil, backup.NewErrInternal(errors.Wrap(err, "list snapshots"))
+	}
+
+	snapshots := make([]*backup.Snapshot, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		snapshot, err := m.loadSnapshotMeta(ctx, entry.Name())
+		if err != nil {
+			if _, ok := err.(backup.ErrNotFound); ok {
+				continue
+			}
+			return nil, errors.Wrap(err, "list snapshots")
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+func (m *StorageFileSystemModule) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	if err := ctx.Err(); err != nil {
+		return backup.NewErrContextExpired(errors.Wrap(err, "delete snapshot"))
+	}
+
+	dir := m.makeSnapshotDirPath(snapshotID)
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return backup.NewErrNotFound(errors.Wrapf(err, "delete snapshot '%v'", snapshotID))
+	} else if err != nil {
+		return backup.NewErrInternal(errors.Wrapf(err, "delete snapshot '%v'", snapshotID))
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		m.logger.WithField("module", m.Name()).
+			WithField("action", "delete_snapshot").
+			WithField("snapshot_id", snapshotID).
+			WithError(err).
+			Errorf("failed deleting snapshot")
+		return backup.NewErrInternal(errors.Wrapf(err, "delete snapshot '%v'", snapshotID))
+	}
+
+	return nil
+}
@@ -14,10 +14,12 @@ package modstgfs
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -89,16 +91,17 @@ func TestSnapshotStorage_StoreSnapshot(t *testing.T) {
 		module := New()
 		module.initSnapshotStorage(ctx, snapshotsAbsolutePath)
 		module.logger, _ = test.NewNullLogger()
-		module.dataPath, _ = os.Getwd()
-		err := module.StoreSnapshot(ctxSnapshot, snapshot)
 
+		for _, file := range snapshot.Classes[0].Files {
+			putTestFile(t, module, snapshot.ID, file.Path)
+		}
+		err := module.PutMeta(ctxSnapshot, snapshot)
 		assert.Nil(t, err)
 
-		var expectedFilePath string
-		var info os.FileInfo
-		for _, file := range snapshot.Files {
-			expectedFilePath = module.makeSnapshotFilePath(snapshot.ClassName, snapshot.ID, file.Path)
-			info, err = os.Stat(expectedFilePath)
+		for _, file := range snapshot.Classes[0].Files {
+			expectedFilePath, err := module.makeSnapshotFilePath(snapshot.ID, file.Path)
+			assert.Nil(t, err)
+			info, err := os.Stat(expectedFilePath)
 			assert.Nil(t, err) // file exists
 			orgInfo, err := os.Stat(file.Path)
 			assert.Nil(t, err) // file exists
@@ -106,8 +109,8 @@ func TestSnapshotStorage_StoreSnapshot(t *testing.T) {
 			assert.Equal(t, orgInfo.Size(), info.Size())
 		}
 
-		expectedFilePath = module.makeMetaFilePath(snapshot.ClassName, snapshot.ID)
-		info, err = os.Stat(expectedFilePath)
+		expectedFilePath := module.makeMetaFilePath(snapshot.ID)
+		info, err := os.Stat(expectedFilePath)
 		assert.Nil(t, err) // file exists
 		assert.Greater(t, info.Size(), int64(0))
 	})
@@ -117,35 +120,50 @@ func TestSnapshotStorage_StoreSnapshot(t *testing.T) {
 		module := New()
 		module.initSnapshotStorage(ctx, snapshotsAbsolutePath)
 		module.logger, _ = test.NewNullLogger()
-		module.dataPath, _ = os.Getwd()
 
-		// List all files in testDir
-		files, _ := os.ReadDir(testDir)
+		// Use the previous test snapshot to test the restore function
+		snapshot, err := module.GetMeta(ctxSnapshot, "snapshot_id")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"classname"}, snapshot.ClassNames())
+
+		for _, file := range snapshot.Classes[0].Files {
+			orgContent, err := os.ReadFile(file.Path)
+			assert.Nil(t, err)
 
-		// Remove the files, ready for restore
-		for _, f := range files {
-			os.Remove(filepath.Join(testDir, f.Name()))
-			assert.NoFileExists(t, filepath.Join(testDir, f.Name()))
+			r, err := module.GetObject(ctxSnapshot, snapshot.ID, file.Path)
+			assert.Nil(t, err)
+			content, err := io.ReadAll(r)
+			r.Close()
+			assert.Nil(t, err)
+
+			assert.Equal(t, orgContent, content)
 		}
+	})
 
-		// Use the previous test snapshot to test the restore function
+	t.Run("fails getting non-existing object", func(t *testing.T) {
+		module := New()
+		module.initSnapshotStorage(ctx, snapshotsAbsolutePath)
+		module.logger, _ = test.NewNullLogger()
 
-		_, err := module.RestoreSnapshot(ctxSnapshot, "classname", "snapshot_id")
+		_, err := module.GetObject(context.Background(), "snapshot_id", "does/not/exist")
 
-		assert.Nil(t, err)
+		assert.NotNil(t, err)
+		assert.IsType(t, backup.ErrNotFound{}, err)
+	})
 
-		assert.DirExists(t, module.dataPath)
+	t.Run("rejects keys outside of the snapshot dir", func(t *testing.T) {
+		module := New()
+		module.initSnapshotStorage(ctx, snapshotsAbsolutePath)
+		module.logger, _ = test.NewNullLogger()
 
-		// Check that every file in the snapshot exists in testDir
-		for _, filePath := range files {
-			expectedFilePath := filepath.Join(testDir, filePath.Name())
-			assert.FileExists(t, expectedFilePath)
-		}
+		err := module.PutObject(context.Background(), "snapshot_id",
+			"../other_snapshot/file", strings.NewReader("content"))
+
+		assert.NotNil(t, err)
 	})
 }
 
 func TestSnapshotStorage_MetaStatus(t *testing.T) {
-	var testClass string
 	var testId string
 	testDir := makeTestDir(t, testdataMainDir)
 	snapshotsRelativePath := filepath.Join(snapshotsMainDir, "some", "nested", "dir") // ./snapshots/some/nested/dir
@@ -155,15 +173,13 @@ func TestSnapshotStorage_MetaStatus(t *testing.T) {
 
 	t.Run("store snapshot", func(t *testing.T) {
 		snapshot := createBackupInstance(t, testDir)
-		testClass = snapshot.ClassName
 		testId = snapshot.ID
 		ctxSnapshot := context.Background()
 
 		module := New()
 		module.initSnapshotStorage(context.Background(), snapshotsAbsolutePath)
 		module.logger, _ = test.NewNullLogger()
-		module.dataPath, _ = os.Getwd()
-		err := module.StoreSnapshot(ctxSnapshot, snapshot)
+		err := module.PutMeta(ctxSnapshot, snapshot)
 		assert.Nil(t, err)
 	})
 
@@ -171,7 +187,7 @@ func TestSnapshotStorage_MetaStatus(t *testing.T) {
 		module := New()
 		module.snapshotsPath = snapshotsAbsolutePath
 
-		err := module.SetMetaStatus(context.Background(), testClass, testId, string(backup.CreateStarted))
+		err := module.SetMetaStatus(context.Background(), testId, string(backup.CreateStarted))
 		assert.Nil(t, err)
 	})
 
@@ -179,12 +195,71 @@ func TestSnapshotStorage_MetaStatus(t *testing.T) {
 		module := New()
 		module.snapshotsPath = snapshotsAbsolutePath
 
-		meta, err := module.GetMeta(context.Background(), testClass, testId)
+		meta, err := module.GetMeta(context.Background(), testId)
 		assert.Nil(t, err)
 		assert.Equal(t, string(backup.CreateStarted), meta.Status)
 	})
 }
 
+func TestSnapshotStorage_ListAndDelete(t *testing.T) {
+	snapshotsRelativePath := filepath.Join(snapshotsMainDir, "some", "nested", "dir") // ./snapshots/some/nested/dir
+	snapshotsAbsolutePath, _ := filepath.Abs(snapshotsRelativePath)
+	defer removeDir(t, snapshotsMainDir)
+
+	ctx := context.Background()
+	module := New()
+	module.initSnapshotStorage(ctx, snapshotsAbsolutePath)
+	module.logger, _ = test.NewNullLogger()
+
+	t.Run("lists no snapshots on empty storage", func(t *testing.T) {
+		snapshots, err := module.ListSnapshots(ctx)
+
+		assert.Nil(t, err)
+		assert.Len(t, snapshots, 0)
+	})
+
+	t.Run("lists stored snapshots", func(t *testing.T) {
+		for _, id := range []string{"snapshot_1", "snapshot_2"} {
+			_, err := module.InitSnapshot(ctx, id, []string{"classname"})
+			assert.Nil(t, err)
+		}
+		err := module.PutObject(ctx, "snapshot_2", "file.db", strings.NewReader("content"))
+		assert.Nil(t, err)
+		// a directory without manifest is not a snapshot
+		makeDir(t, filepath.Join(snapshotsRelativePath, "not_a_snapshot"))
+
+		snapshots, err := module.ListSnapshots(ctx)
+
+		assert.Nil(t, err)
+		ids := make([]string, len(snapshots))
+		for i, snapshot := range snapshots {
+			ids[i] = snapshot.ID
+		}
+		assert.ElementsMatch(t, []string{"snapshot_1", "snapshot_2"}, ids)
+	})
+
+	t.Run("deletes snapshot with all of its files", func(t *testing.T) {
+		err := module.DeleteSnapshot(ctx, "snapshot_2")
+		assert.Nil(t, err)
+
+		_, err = os.Stat(module.makeSnapshotDirPath("snapshot_2"))
+		assert.True(t, os.IsNotExist(err))
+		_, err = module.GetMeta(ctx, "snapshot_2")
+		assert.IsType(t, backup.ErrNotFound{}, err)
+
+		snapshots, err := module.ListSnapshots(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, snapshots, 1)
+	})
+
+	t.Run("fails deleting non-existing snapshot", func(t *testing.T) {
+		err := module.DeleteSnapshot(ctx, "does_not_exist")
+
+		assert.NotNil(t, err)
+		assert.IsType(t, backup.ErrNotFound{}, err)
+	})
+}
+
 func makeTestDir(t *testing.T, basePath string) string {
 	rand.Seed(time.Now().UnixNano())
 	dirPath := filepath.Join(basePath, strconv.Itoa(rand.Intn(10000000)))
@@ -213,12 +288,24 @@ func createBackupInstance(t *testing.T, dirPath string) *backup.Snapshot {
 		files[i] = backup.SnapshotFile{Path: filePaths[i]}
 	}
 
-	snap := backup.NewSnapshot("classname", "snapshot_id", startedAt)
-	snap.Files = files
+	snap := backup.NewSnapshot("snapshot_id", []string{"classname"}, startedAt)
+	snap.Classes[0].Files = files
 	snap.CompletedAt = time.Now()
 	return snap
 }
 
+func putTestFile(t *testing.T, module *StorageFileSystemModule, snapshotID, filePath string) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		t.Fatalf("failed to open test file '%s': %s", filePath, err)
+	}
+	defer f.Close()
+
+	if err := module.PutObject(context.Background(), snapshotID, filePath, f); err != nil {
+		t.Fatalf("failed to put test file '%s': %s", filePath, err)
+	}
+}
+
 func createTestFiles(t *testing.T, dirPath string) []string {
 	count := 5
 	filePaths := make([]string, count)
@@ -12,6 +12,7 @@
 package gcs
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
@@ -21,10 +22,9 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/pkg/errors"
-	"github.com/prometheus/client_golang/prometheus"
 	"github.com/semi-technologies/weaviate/entities/backup"
-	"github.com/semi-technologies/weaviate/usecases/monitoring"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -39,10 +39,9 @@ type gcs struct {
 	client    *storage.Client
 	config    Config
 	projectID string
-	dataPath  string
 }
 
-func New(ctx context.Context, config Config, dataPath string) (*gcs, error) {
+func New(ctx context.Context, config Config) (*gcs, error) {
 	options := []option.ClientOption{}
 	if len(os.Getenv(GOOGLE_APPLICATION_CREDENTIALS)) > 0 {
 		scopes := []string{
@@ -67,7 +66,7 @@ func New(ctx context.Context, config Config, dataPath string) (*gcs, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "create client")
 	}
-	return &gcs{client, config, projectID, dataPath}, nil
+	return &gcs{client, config, projectID}, nil
 }
 
 func (g *gcs) getObject(ctx context.Context, bucket *storage.BucketHandle,
@@ -90,112 +89,57 @@ func (g *gcs) getObject(ctx context.Context, bucket *storage.BucketHandle,
 	return content, nil
 }
 
-func (g *gcs) saveFile(ctx context.Context, bucket *storage.BucketHandle,
-	snapshotID, objectName, targetPath string,
-) error {
-	// Get the file from the bucket
-	content, err := g.getObject(ctx, bucket, snapshotID, objectName)
-	if err != nil {
-		return errors.Wrapf(err, "get object: %v", objectName)
+func (g *gcs) PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error {
+	if err := ctx.Err(); err != nil {
+		return backup.NewErrContextExpired(
+			errors.Wrap(err, "store snapshot aborted"))
 	}
 
-	destDir := path.Dir(targetPath)
-	if err := os.MkdirAll(destDir, os.ModePerm); err != nil {
-		return errors.Wrapf(err,
-			"save file: failed to make destination dir for file '%s'", targetPath)
+	bucket, err := g.findBucket(ctx)
+	if err != nil {
+		return errors.Wrap(err, "put object")
 	}
 
-	// Write it to disk
-	if err := os.WriteFile(targetPath, content, 0o644); err != nil {
-		return errors.Wrapf(err, "write file: %v", targetPath)
+	objectName := g.makeObjectName(snapshotID, key)
+	if err := g.putReader(ctx, bucket, snapshotID, objectName, r); err != nil {
+		return backup.NewErrInternal(errors.Wrap(err, "put object"))
 	}
+
 	return nil
 }
 
-func (g *gcs) RestoreSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	timer := prometheus.NewTimer(monitoring.GetMetrics().SnapshotRestoreFromStorageDurations.WithLabelValues("gcs", className))
-	defer timer.ObserveDuration()
-	bucket, err := g.findBucket(ctx)
-	if err != nil || bucket == nil {
-		return nil, errors.Wrap(err, "snapshot bucket does not exist")
+func (g *gcs) GetObject(ctx context.Context, snapshotID, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, backup.NewErrContextExpired(
+			errors.Wrap(err, "restore snapshot aborted"))
 	}
 
-	// Download metadata for snapshot
-	objectName := g.makeObjectName(className, snapshotID, "snapshot.json")
-	reader, err := bucket.Object(objectName).NewReader(ctx)
+	bucket, err := g.findBucket(ctx)
 	if err != nil {
-		return nil, errors.Wrapf(err, "new reader: %v", objectName)
+		return nil, errors.Wrap(err, "get object")
 	}
 
-	// Fetch content
-	content, err := io.ReadAll(reader)
+	objectName := g.makeObjectName(snapshotID, key)
+	reader, err := bucket.Object(objectName).NewReader(ctx)
 	if err != nil {
-		return nil, errors.Wrapf(err, "read object: %v", objectName)
-	}
-
-	// Unmarshal content into snapshot struct
-	var snapshot backup.Snapshot
-	if err := json.Unmarshal(content, &snapshot); err != nil {
-		return nil, errors.Wrapf(err, "unmarshal snapshot: %v", objectName)
-	}
-
-	// download files listed in snapshot
-	for _, file := range snapshot.Files {
-		if err := ctx.Err(); err != nil {
-			return nil, errors.Wrapf(err, "store snapshot aborted")
-		}
-		objectName := g.makeObjectName(className, snapshotID, file.Path)
-		filePath := makeFilePath(g.dataPath, file.Path)
-		if err := g.saveFile(ctx, bucket, snapshotID, objectName, filePath); err != nil {
-			return nil, errors.Wrap(err, "put file")
-		}
-
-		// Get size of file
-		fileInfo, err := os.Stat(filePath)
-		if err != nil {
-			return nil, errors.Errorf("Unable to get size of file %v", filePath)
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, backup.NewErrNotFound(
+				errors.Wrapf(err, "get object: %v", objectName))
 		}
-		monitoring.GetMetrics().SnapshotRestoreDataTransferred.WithLabelValues("gcs", className).Add(float64(fileInfo.Size()))
+		return nil, backup.NewErrInternal(
+			errors.Wrapf(err, "new reader: %v", objectName))
 	}
-	return &snapshot, nil
+
+	return reader, nil
 }
 
-func (g *gcs) StoreSnapshot(ctx context.Context, snapshot *backup.Snapshot) error {
-	timer := prometheus.NewTimer(monitoring.GetMetrics().SnapshotStoreDurations.WithLabelValues("gcs", snapshot.ClassName))
-	defer timer.ObserveDuration()
+func (g *gcs) PutMeta(ctx context.Context, snapshot *backup.Snapshot) error {
 	bucket, err := g.findBucket(ctx)
 	if err != nil {
-		return err
-	}
-
-	if bucket == nil {
-		return backup.NewErrNotFound(storage.ErrBucketNotExist)
+		return errors.Wrap(err, "put meta")
 	}
 
-	// save files
-	for _, file := range snapshot.Files {
-		if err := ctx.Err(); err != nil {
-			return errors.Wrap(err, "store snapshot aborted")
-		}
-		objectName := g.makeObjectName(snapshot.ClassName, snapshot.ID, file.Path)
-		filePath := makeFilePath(g.dataPath, file.Path)
-		content, err := os.ReadFile(filePath)
-		if err != nil {
-			return errors.Wrapf(err, "read file: %v", filePath)
-		}
-
-		if err := g.putFile(ctx, bucket, snapshot.ID, objectName, content); err != nil {
-			return errors.Wrap(err, "put file")
-		}
-
-		monitoring.GetMetrics().SnapshotStoreDataTransferred.WithLabelValues("gcs", snapshot.ClassName).Add(float64(len(content)))
-	}
-
-	if err := g.putMeta(ctx, bucket, snapshot); err != nil {
-		return errors.Wrap(err, "store snapshot")
-	}
-
-	return nil
+	return g.putMeta(ctx, bucket, snapshot)
 }
 
 func (g *gcs) putMeta(ctx context.Context, bucket *storage.BucketHandle, snapshot *backup.Snapshot) error {
@@ -203,14 +147,14 @@ func (g *gcs) putMeta(ctx context.Context, bucket *storage.BucketHandle, snapsho
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal meta")
 	}
-	objectName := g.makeObjectName(snapshot.ClassName, snapshot.ID, "snapshot.json")
+	objectName := g.makeObjectName(snapshot.ID, "snapshot.json")
 	if err := g.putFile(ctx, bucket, snapshot.ID, objectName, content); err != nil {
 		return errors.Wrap(err, "failed to store meta")
 	}
 	return nil
 }
 
-func (g *gcs) GetMeta(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
+func (g *gcs) GetMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
 	bucket, err := g.findBucket(ctx)
 	if err != nil {
 		return nil, err
@@ -220,7 +164,7 @@ func (g *gcs) GetMeta(ctx context.Context, className, snapshotID string) (*backu
 		return nil, backup.ErrNotFound{}
 	}
 
-	objectName := g.makeObjectName(className, snapshotID, "snapshot.json")
+	objectName := g.makeObjectName(snapshotID, "snapshot.json")
 	contents, err := g.getObject(ctx, bucket, snapshotID, objectName)
 	if err != nil {
 		return nil, err
@@ -235,13 +179,13 @@ func (g *gcs) GetMeta(ctx context.Context, className, snapshotID string) (*backu
 	return &snapshot, nil
 }
 
-func (g *gcs) SetMetaError(ctx context.Context, className, snapshotID string, snapErr error) error {
+func (g *gcs) SetMetaError(ctx context.Context, snapshotID string, snapErr error) error {
 	bucket, err := g.findBucket(ctx)
 	if err != nil {
 		return errors.Wrap(err, "set snapshot error")
 	}
 
-	objectName := g.makeObjectName(className, snapshotID, "snapshot.json")
+	objectName := g.makeObjectName(snapshotID, "snapshot.json")
 	contents, err := g.getObject(ctx, bucket, snapshotID, objectName)
 	if err != nil {
 		return errors.Wrap(err, "set snapshot status")
@@ -263,13 +207,13 @@ func (g *gcs) SetMetaError(ctx context.Context, className, snapshotID string, sn
 	return nil
 }
 
-func (g *gcs) SetMetaStatus(ctx context.Context, className, snapshotID, status string) error {
+func (g *gcs) SetMetaStatus(ctx context.Context, snapshotID, status string) error {
 	bucket, err := g.findBucket(ctx)
 	if err != nil {
 		return errors.Wrap(err, "set meta status")
 	}
 
-	objectName := g.makeObjectName(className, snapshotID, "snapshot.json")
+	objectName := g.makeObjectName(snapshotID, "snapshot.json")
 	contents, err := g.getObject(ctx, bucket, snapshotID, objectName)
 	if err != nil {
 		return errors.Wrap(err, "set meta status")
@@ -294,25 +238,25 @@ func (g *gcs) SetMetaStatus(ctx context.Context, className, snapshotID, status s
 	return nil
 }
 
-func (g *gcs) DestinationPath(className, snapshotID string) string {
+func (g *gcs) DestinationPath(snapshotID string) string {
 	return "gs://" + path.Join(g.config.BucketName(),
-		g.makeObjectName(className, snapshotID, "snapshot.json"))
+		g.makeObjectName(snapshotID, "snapshot.json"))
 }
 
-func (g *gcs) InitSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
+func (g *gcs) InitSnapshot(ctx context.Context, snapshotID string, classes []string) (*backup.Snapshot, error) {
 	bucket, err := g.findBucket(ctx)
 	if err != nil && !errors.Is(err, backup.ErrNotFound{}) {
 		return nil, errors.Wrap(err, "init snapshot")
 	}
 
-	snapshot := backup.NewSnapshot(className, snapshotID, time.Now())
+	snapshot := backup.NewSnapshot(snapshotID, classes, time.Now())
 	snapshot.Status = string(backup.CreateStarted)
 	b, err := json.Marshal(&snapshot)
 	if err != nil {
 		return nil, errors.Wrap(err, "init snapshot")
 	}
 
-	objectName := g.makeObjectName(className, snapshotID, "snapshot.json")
+	objectName := g.makeObjectName(snapshotID, "snapshot.json")
 
 	if err := g.putFile(ctx, bucket, snapshot.ID, objectName, b); err != nil {
 		return nil, errors.Wrap(err, "init snapshot")
@@ -321,8 +265,94 @@ func (g *gcs) InitSnapshot(ctx context.Context, className, snapshotID string) (*
 	return snapshot, nil
 }
 
+func (g *gcs) ListSnapshots(ctx context.Context) ([]*backup.Snapshot, error) {
+	bucket, err := g.findBucket(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "list snapshots")
+	}
+
+	prefix := g.config.SnapshotRoot()
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var snapshots []*backup.Snapshot
+	// with a delimiter every snapshot shows up as a single prefix,
+	// i.e. "<root>/<snapshotID>/"
+	it := bucket.Objects(ctx, &storage.Query{Prefix: prefix, Delimiter: "/"})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "list snapshots")
+		}
+		if attrs.Prefix == "" {
+			continue
+		}
+
+		snapshotID := path.Base(attrs.Prefix)
+		objectName := g.makeObjectName(snapshotID, "snapshot.json")
+		contents, err := g.getObject(ctx, bucket, snapshotID, objectName)
+		if err != nil {
+			if _, ok := err.(backup.ErrNotFound); ok {
+				continue
+			}
+			return nil, errors.Wrap(err, "list snapshots")
+		}
+
+		var snapshot backup.Snapshot
+		if err := json.Unmarshal(contents, &snapshot); err != nil {
+			return nil, errors.Wrap(err, "list snapshots")
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+
+	return snapshots, nil
+}
+
+func (g *gcs) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	bucket, err := g.findBucket(ctx)
+	if err != nil {
+		return errors.Wrap(err, "delete snapshot")
+	}
+
+	deleted := 0
+	it := bucket.Objects(ctx, &storage.Query{Prefix: g.makeObjectName(snapshotID) + "/"})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return backup.NewErrInternal(
+				errors.Wrapf(err, "delete snapshot '%s'", snapshotID))
+		}
+
+		if err := bucket.Object(attrs.Name).Delete(ctx); err != nil {
+			return backup.NewErrInternal(
+				errors.Wrapf(err, "delete object: %v", attrs.Name))
+		}
+		deleted++
+	}
+
+	if deleted == 0 {
+		return backup.NewErrNotFound(
+			errors.Errorf("delete snapshot: snapshot '%s' does not exist", snapshotID))
+	}
+
+	return nil
+}
+
 func (g *gcs) putFile(ctx context.Context, bucket *storage.BucketHandle,
 	snapshotID, objectName string, content []byte,
+) error {
+	return g.putReader(ctx, bucket, snapshotID, objectName, bytes.NewReader(content))
+}
+
+func (g *gcs) putReader(ctx context.Context, bucket *storage.BucketHandle,
+	snapshotID, objectName string, r io.Reader,
 ) error {
 	obj := bucket.Object(objectName)
 	writer := obj.NewWriter(ctx)
@@ -330,7 +360,8 @@ func (g *gcs) putFile(ctx context.Context, bucket *storage.BucketHandle,
 	writer.Metadata = map[string]string{
 		"snapshot-id": snapshotID,
 	}
-	if _, err := writer.Write(content); err != nil {
+	if _, err := io.Copy(writer, r); err != nil {
+		writer.Close()
 		return errors.Wrapf(err, "write file: %v", objectName)
 	}
 	if err := writer.Close(); err != nil {
@@ -353,7 +384,3 @@ func (g *gcs) makeObjectName(parts ...string) string {
 	base := path.Join(parts...)
 	return path.Join(g.config.SnapshotRoot(), base)
 }
-
-func makeFilePath(parts ...string) string {
-	return path.Join(parts...)
-}
@@ -13,6 +13,7 @@ package modstggcs
 
 import (
 	"context"
+	"io"
 	"os"
 
 	"github.com/pkg/errors"
@@ -20,32 +21,44 @@ import (
 	"github.com/semi-technologies/weaviate/modules/storage-gcs/gcs"
 )
 
-func (m *StorageGCSModule) StoreSnapshot(ctx context.Context, snapshot *backup.Snapshot) error {
-	return m.storageProvider.StoreSnapshot(ctx, snapshot)
+func (m *StorageGCSModule) PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error {
+	return m.storageProvider.PutObject(ctx, snapshotID, key, r)
 }
 
-func (m *StorageGCSModule) RestoreSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	return m.storageProvider.RestoreSnapshot(ctx, className, snapshotID)
+func (m *StorageGCSModule) GetObject(ctx context.Context, snapshotID, key string) (io.ReadCloser, error) {
+	return m.storageProvider.GetObject(ctx, snapshotID, key)
 }
 
-func (m *StorageGCSModule) SetMetaStatus(ctx context.Context, className, snapshotID, status string) error {
-	return m.storageProvider.SetMetaStatus(ctx, className, snapshotID, status)
+func (m *StorageGCSModule) SetMetaError(ctx context.Context, snapshotID string, err error) error {
+	return m.storageProvider.SetMetaError(ctx, snapshotID, err)
 }
 
-func (m *StorageGCSModule) SetMetaError(ctx context.Context, className, snapshotID string, err error) error {
-	return m.storageProvider.SetMetaError(ctx, className, snapshotID, err)
+func (m *StorageGCSModule) SetMetaStatus(ctx context.Context, snapshotID, status string) error {
+	return m.storageProvider.SetMetaStatus(ctx, snapshotID, status)
 }
 
-func (m *StorageGCSModule) GetMeta(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	return m.storageProvider.GetMeta(ctx, className, snapshotID)
+func (m *StorageGCSModule) GetMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
+	return m.storageProvider.GetMeta(ctx, snapshotID)
 }
 
-func (m *StorageGCSModule) DestinationPath(className, snapshotID string) string {
-	return m.storageProvider.DestinationPath(className, snapshotID)
+func (m *StorageGCSModule) PutMeta(ctx context.Context, snapshot *backup.Snapshot) error {
+	return m.storageProvider.PutMeta(ctx, snapshot)
 }
 
-func (m *StorageGCSModule) InitSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	return m.storageProvider.InitSnapshot(ctx, className, snapshotID)
+func (m *StorageGCSModule) DestinationPath(snapshotID string) string {
+	return m.storageProvider.DestinationPath(snapshotID)
+}
+
+func (m *StorageGCSModule) InitSnapshot(ctx context.Context, snapshotID string, classes []string) (*backup.Snapshot, error) {
+	return m.storageProvider.InitSnapshot(ctx, snapshotID, classes)
+}
+
+func (m *StorageGCSModule) ListSnapshots(ctx context.Context) ([]*backup.Snapshot, error) {
+	return m.storageProvider.ListSnapshots(ctx)
+}
+
+func (m *StorageGCSModule) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	return m.storageProvider.DeleteSnapshot(ctx, snapshotID)
 }
 
 func (m *StorageGCSModule) initSnapshotStorage(ctx context.Context) error {
@@ -55,7 +68,7 @@ func (m *StorageGCSModule) initSnapshotStorage(ctx context.Context) error {
 	}
 
 	config := gcs.NewConfig(bucketName, os.Getenv(gcsSnapshotRoot))
-	storageProvider, err := gcs.New(ctx, config, m.dataPath)
+	storageProvider, err := gcs.New(ctx, config)
 	if err != nil {
 		return errors.Wrap(err, "init gcs client")
 	}
@@ -61,6 +61,10 @@ func (g *GraphQLArgumentsProvider) nearTextFields(prefix string) graphql.InputOb
 			Description: descriptions.Distance,
 			Type:        graphql.Float,
 		},
+		"targetVector": &graphql.InputObjectFieldConfig{
+			Description: descriptions.TargetVector,
+			Type:        graphql.String,
+		},
 		"moveAwayFrom": &graphql.InputObjectFieldConfig{
 			Description: descriptions.VectorMovement,
 			Type: graphql.NewInputObject(
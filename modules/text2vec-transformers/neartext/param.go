@@ -23,6 +23,7 @@ type NearTextParams struct {
 	Certainty    float64
 	Distance     float64
 	WithDistance bool
+	TargetVector string
 	Network      bool
 	Autocorrect  bool
 }
@@ -39,6 +40,10 @@ func (n NearTextParams) SimilarityMetricProvided() bool {
 	return n.Certainty != 0 || n.WithDistance
 }
 
+func (n NearTextParams) GetTargetVector() string {
+	return n.TargetVector
+}
+
 // ExploreMove moves an existing Search Vector closer (or further away from) a specific other search term
 type ExploreMove struct {
 	Values  []string
@@ -59,6 +59,8 @@ func testGetSchemaWithoutClient(t *testing.T) {
 					"strategy":            "hash",
 					"key":                 "_id",
 					"virtualPerPhysical":  float64(128),
+					"replicas":            float64(1),
+					"consistencyLevel":    "QUORUM",
 				},
 				"vectorizer": "text2vec-contextionary", // global default from env var, see docker-compose-test.yml
 				"invertedIndexConfig": map[string]interface{}{
@@ -0,0 +1,65 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const Azurite = "azurite"
+
+// AzuriteConnectionString returns the connection string for the well-known
+// development account of the emulator, reachable under the given endpoint
+func AzuriteConnectionString(endpoint string) string {
+	return "DefaultEndpointsProtocol=http;AccountName=devstoreaccount1;" +
+		"AccountKey=Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==;" +
+		fmt.Sprintf("BlobEndpoint=http://%s/devstoreaccount1;", endpoint)
+}
+
+func startAzurite(ctx context.Context, networkName string) (*DockerContainer, error) {
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "mcr.microsoft.com/azure-storage/azurite",
+			ExposedPorts: []string{"10000/tcp"},
+			Name:         Azurite,
+			Hostname:     Azurite,
+			AutoRemove:   true,
+			Networks:     []string{networkName},
+			NetworkAliases: map[string][]string{
+				networkName: {Azurite},
+			},
+			Entrypoint: []string{"azurite-blob"},
+			Cmd:        []string{"--blobHost", "0.0.0.0", "--blobPort", "10000"},
+			WaitingFor: wait.
+				ForListeningPort(nat.Port("10000/tcp")).
+				WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := container.Endpoint(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	envSettings := make(map[string]string)
+	envSettings["AZURE_STORAGE_CONNECTION_STRING"] = AzuriteConnectionString(
+		fmt.Sprintf("%s:%s", Azurite, "10000"))
+	return &DockerContainer{Azurite, endpoint, container, envSettings}, nil
+}
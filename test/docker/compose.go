@@ -17,6 +17,7 @@ import (
 
 	"github.com/pkg/errors"
 	modstgs3 "github.com/semi-technologies/weaviate/modules/storage-aws-s3"
+	modstgazure "github.com/semi-technologies/weaviate/modules/storage-azure"
 	modstggcs "github.com/semi-technologies/weaviate/modules/storage-gcs"
 	"github.com/testcontainers/testcontainers-go"
 )
@@ -38,23 +39,27 @@ const (
 	StorageFileSystem = "storage-filesystem"
 	StorageAWSS3      = "storage-aws-s3"
 	StorageGCS        = "storage-gcs"
+	StorageAzure      = "storage-azure"
 )
 
 type Compose struct {
-	enableModules           []string
-	defaultVectorizerModule string
-	withMinIO               bool
-	withGCS                 bool
-	withStorageFilesystem   bool
-	withStorageAWSS3        bool
-	withStorageAWSS3Bucket  string
-	withStorageGCS          bool
-	withStorageGCSBucket    string
-	withTransformers        bool
-	withContextionary       bool
-	withQnATransformers     bool
-	withWeaviate            bool
-	withSUMTransformers     bool
+	enableModules             []string
+	defaultVectorizerModule   string
+	withMinIO                 bool
+	withGCS                   bool
+	withAzurite               bool
+	withStorageFilesystem     bool
+	withStorageAWSS3          bool
+	withStorageAWSS3Bucket    string
+	withStorageGCS            bool
+	withStorageGCSBucket      string
+	withStorageAzure          bool
+	withStorageAzureContainer string
+	withTransformers          bool
+	withContextionary         bool
+	withQnATransformers       bool
+	withWeaviate              bool
+	withSUMTransformers       bool
 }
 
 func New() *Compose {
@@ -73,6 +78,12 @@ func (d *Compose) WithGCS() *Compose {
 	return d
 }
 
+func (d *Compose) WithAzurite() *Compose {
+	d.withAzurite = true
+	d.enableModules = append(d.enableModules, modstgazure.Name)
+	return d
+}
+
 func (d *Compose) WithText2VecTransformers() *Compose {
 	d.withTransformers = true
 	d.enableModules = append(d.enableModules, Text2VecTransformers)
@@ -115,6 +126,14 @@ func (d *Compose) WithStorageGCS(bucket string) *Compose {
 	return d
 }
 
+func (d *Compose) WithStorageAzure(container string) *Compose {
+	d.withStorageAzure = true
+	d.withStorageAzureContainer = container
+	d.withAzurite = true
+	d.enableModules = append(d.enableModules, StorageAzure)
+	return d
+}
+
 func (d *Compose) WithSUMTransformers() *Compose {
 	d.withSUMTransformers = true
 	d.enableModules = append(d.enableModules, SUMTransformers)
@@ -165,6 +184,19 @@ func (d *Compose) Start(ctx context.Context) (*DockerCompose, error) {
 			envSettings["STORAGE_GCS_BUCKET"] = d.withStorageGCSBucket
 		}
 	}
+	if d.withAzurite {
+		container, err := startAzurite(ctx, networkName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "start %s", Azurite)
+		}
+		containers = append(containers, container)
+		if d.withStorageAzure {
+			for k, v := range container.envSettings {
+				envSettings[k] = v
+			}
+			envSettings["STORAGE_AZURE_CONTAINER"] = d.withStorageAzureContainer
+		}
+	}
 	if d.withStorageFilesystem {
 		envSettings["STORAGE_FS_SNAPSHOTS_PATH"] = "/tmp/snapshots"
 	}
@@ -14,8 +14,8 @@ package test
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
 	"testing"
 	"time"
 
@@ -54,6 +54,8 @@ func moduleLevelStoreSnapshot(t *testing.T) {
 	testDir := moduleshelper.MakeTestDir(t, testdataMainDir)
 	defer moduleshelper.RemoveDir(t, testdataMainDir)
 
+	filePaths := moduleshelper.CreateTestFiles(t, testDir)
+
 	className := "SnapshotClass"
 	snapshotID := "snapshot_id"
 	bucketName := "bucket"
@@ -71,63 +73,53 @@ func moduleLevelStoreSnapshot(t *testing.T) {
 
 	t.Run("store snapshot in s3", func(t *testing.T) {
 		logger, _ := test.NewNullLogger()
-
 		s3Config := s3.NewConfig(endpoint, bucketName, "", false)
-		path, _ := os.Getwd()
-		s3, err := s3.New(s3Config, logger, path)
+		s3, err := s3.New(s3Config, logger)
 		require.Nil(t, err)
 
-		snapshot, err := s3.InitSnapshot(testCtx, className, snapshotID)
+		snapshot, err := s3.InitSnapshot(testCtx, snapshotID, []string{className})
 		require.Nil(t, err)
 
-		err = s3.StoreSnapshot(testCtx, snapshot)
-		assert.Nil(t, err)
+		for _, filePath := range filePaths {
+			putTestFile(testCtx, t, s3, snapshotID, filePath)
+		}
+
+		err = s3.PutMeta(testCtx, snapshot)
+		require.Nil(t, err)
 
-		dest := s3.DestinationPath(className, snapshotID)
-		expected := fmt.Sprintf("s3://%s/%s/%s/snapshot.json", bucketName, className, snapshotID)
+		dest := s3.DestinationPath(snapshotID)
+		expected := fmt.Sprintf("s3://%s/%s/snapshot.json", bucketName, snapshotID)
 		assert.Equal(t, expected, dest)
 
 		t.Run("assert snapshot meta contents", func(t *testing.T) {
-			meta, err := s3.GetMeta(testCtx, className, snapshotID)
+			meta, err := s3.GetMeta(testCtx, snapshotID)
 			require.Nil(t, err)
 			assert.NotEmpty(t, meta.StartedAt)
 			assert.Empty(t, meta.CompletedAt)
 			assert.Equal(t, meta.Status, string(backup.CreateStarted))
+			assert.Equal(t, []string{className}, meta.ClassNames())
 			assert.Empty(t, meta.Error)
 		})
 	})
 
 	t.Run("restores snapshot data from S3", func(t *testing.T) {
-		s3Config := s3.NewConfig(endpoint, "bucket", "", false)
 		logger, _ := test.NewNullLogger()
-		path, _ := os.Getwd()
-		s3, err := s3.New(s3Config, logger, path)
+		s3Config := s3.NewConfig(endpoint, bucketName, "", false)
+		s3, err := s3.New(s3Config, logger)
 		require.Nil(t, err)
 
-		// List all files in testDir
-		files, _ := os.ReadDir(testDir)
-
-		// Remove the files, ready for restore
-		for _, f := range files {
-			require.Nil(t, os.Remove(filepath.Join(testDir, f.Name())))
-			assert.NoFileExists(t, filepath.Join(testDir, f.Name()))
-		}
-
-		// Use the previous test snapshot to test the restore function
-		_, err = s3.RestoreSnapshot(testCtx, className, snapshotID)
-		require.Nil(t, err)
+		for _, filePath := range filePaths {
+			expected, err := os.ReadFile(filePath)
+			require.Nil(t, err)
 
-		assert.DirExists(t, path)
+			r, err := s3.GetObject(testCtx, snapshotID, filePath)
+			require.Nil(t, err)
+			content, err := io.ReadAll(r)
+			r.Close()
+			require.Nil(t, err)
 
-		// Check that every file in the snapshot exists in testDir
-		for _, filePath := range files {
-			expectedFilePath := filepath.Join(testDir, filePath.Name())
-			assert.FileExists(t, expectedFilePath)
+			assert.Equal(t, expected, content)
 		}
-
-		dest := s3.DestinationPath(className, snapshotID)
-		expected := fmt.Sprintf("s3://%s/%s/%s/snapshot.json", bucketName, className, snapshotID)
-		assert.Equal(t, expected, dest)
 	})
 }
 
@@ -160,30 +152,42 @@ func moduleLevelGetMetaStatus(t *testing.T) {
 	logger, _ := test.NewNullLogger()
 
 	t.Run("store snapshot in s3", func(t *testing.T) {
-		s3, err := s3.New(s3Config, logger, testDir)
+		s3, err := s3.New(s3Config, logger)
 		require.Nil(t, err)
 
-		snapshot, err := s3.InitSnapshot(testCtx, className, snapshotID)
+		snapshot, err := s3.InitSnapshot(testCtx, snapshotID, []string{className})
 		require.Nil(t, err)
 
-		err = s3.StoreSnapshot(testCtx, snapshot)
+		err = s3.PutMeta(testCtx, snapshot)
 		assert.Nil(t, err)
 	})
 
 	t.Run("set snapshot status", func(t *testing.T) {
-		s3, err := s3.New(s3Config, logger, testDir)
+		s3, err := s3.New(s3Config, logger)
 		require.Nil(t, err)
 
-		err = s3.SetMetaStatus(testCtx, className, snapshotID, "STARTED")
+		err = s3.SetMetaStatus(testCtx, snapshotID, "STARTED")
 		assert.Nil(t, err)
 	})
 
 	t.Run("get snapshot status", func(t *testing.T) {
-		s3, err := s3.New(s3Config, logger, testDir)
+		s3, err := s3.New(s3Config, logger)
 		require.Nil(t, err)
 
-		meta, err := s3.GetMeta(testCtx, className, snapshotID)
+		meta, err := s3.GetMeta(testCtx, snapshotID)
 		assert.Nil(t, err)
 		assert.Equal(t, "STARTED", meta.Status)
 	})
 }
+
+type objectPutter interface {
+	PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error
+}
+
+func putTestFile(ctx context.Context, t *testing.T, storage objectPutter, snapshotID, filePath string) {
+	f, err := os.Open(filePath)
+	require.Nil(t, err)
+	defer f.Close()
+
+	require.Nil(t, storage.PutObject(ctx, snapshotID, filePath, f))
+}
@@ -0,0 +1,70 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/semi-technologies/weaviate/test/docker"
+	"github.com/semi-technologies/weaviate/test/helper"
+	"github.com/semi-technologies/weaviate/test/helper/journey"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	envAzureConnectionString = "AZURE_STORAGE_CONNECTION_STRING"
+	envAzureContainer        = "STORAGE_AZURE_CONTAINER"
+
+	azureBackupJourneyClassName     = "AzureBackup"
+	azureBackupJourneySnapshotID    = "azure-snapshot"
+	azureBackupJourneyContainerName = "snapshots"
+)
+
+func Test_BackupJourney(t *testing.T) {
+	t.Skip("to be enabled after finishing WEAVIATE-278")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	t.Run("pre-instance env setup", func(t *testing.T) {
+		require.Nil(t, os.Setenv(envAzureContainer, azureBackupJourneyContainerName))
+	})
+
+	compose, err := docker.New().
+		WithStorageAzure(azureBackupJourneyContainerName).
+		WithText2VecContextionary().
+		WithWeaviate().
+		Start(ctx)
+	require.Nil(t, err)
+	defer func() {
+		if err := compose.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminte test containers: %s", err.Error())
+		}
+	}()
+
+	t.Run("post-instance env setup", func(t *testing.T) {
+		connectionString := docker.AzuriteConnectionString(compose.GetAzurite().URI())
+		require.Nil(t, os.Setenv(envAzureConnectionString, connectionString))
+
+		createContainer(ctx, t, connectionString, azureBackupJourneyContainerName)
+		helper.SetupClient(compose.GetWeaviate().URI())
+	})
+
+	// journey tests
+	t.Run("storage-azure", func(t *testing.T) {
+		journey.BackupJourneyTests(t, compose.GetWeaviate().URI(),
+			"azure", azureBackupJourneyClassName, azureBackupJourneySnapshotID)
+	})
+}
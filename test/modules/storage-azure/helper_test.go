@@ -0,0 +1,37 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func createContainer(ctx context.Context, t *testing.T, connectionString, containerName string) {
+	client, err := azblob.NewServiceClientFromConnectionString(connectionString, nil)
+	require.Nil(t, err)
+
+	_, err = client.CreateContainer(ctx, containerName, nil)
+	var storageErr *azblob.StorageError
+	if errors.As(err, &storageErr) {
+		// the container persists from the previous test.
+		// if the container already exists, we can proceed
+		if storageErr.ErrorCode == azblob.StorageErrorCodeContainerAlreadyExists {
+			return
+		}
+	}
+	require.Nil(t, err)
+}
@@ -0,0 +1,225 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/backup"
+	"github.com/semi-technologies/weaviate/modules/storage-azure/azure"
+	"github.com/semi-technologies/weaviate/test/docker"
+	moduleshelper "github.com/semi-technologies/weaviate/test/helper/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AzureStorage_SnapshotCreate(t *testing.T) {
+	ctx := context.Background()
+	compose, err := docker.New().WithAzurite().Start(ctx)
+	if err != nil {
+		panic(errors.Wrapf(err, "cannot start"))
+	}
+
+	require.Nil(t, os.Setenv(envAzureConnectionString,
+		docker.AzuriteConnectionString(compose.GetAzurite().URI())))
+
+	t.Run("store snapshot", moduleLevelStoreSnapshot)
+	t.Run("get meta status", moduleLevelGetMetaStatus)
+	t.Run("list and delete snapshots", moduleLevelListAndDeleteSnapshots)
+
+	if err := compose.Terminate(ctx); err != nil {
+		t.Fatalf("failed to terminte test containers: %s", err.Error())
+	}
+}
+
+func moduleLevelStoreSnapshot(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	testdataMainDir := "./testData"
+	testDir := moduleshelper.MakeTestDir(t, testdataMainDir)
+	defer moduleshelper.RemoveDir(t, testdataMainDir)
+
+	filePaths := moduleshelper.CreateTestFiles(t, testDir)
+
+	className := "SnapshotClass"
+	snapshotID := "snapshot_id"
+	containerName := "weaviate-snapshots"
+
+	t.Run("setup env", func(t *testing.T) {
+		require.Nil(t, os.Setenv(envAzureContainer, containerName))
+
+		createContainer(testCtx, t, os.Getenv(envAzureConnectionString), containerName)
+	})
+
+	t.Run("store snapshot in azure", func(t *testing.T) {
+		azureConfig := azure.NewConfig(containerName, "")
+		azure, err := azure.New(testCtx, azureConfig)
+		require.Nil(t, err)
+
+		snapshot, err := azure.InitSnapshot(testCtx, snapshotID, []string{className})
+		require.Nil(t, err)
+
+		for _, filePath := range filePaths {
+			putTestFile(testCtx, t, azure, snapshotID, filePath)
+		}
+
+		err = azure.PutMeta(testCtx, snapshot)
+		require.Nil(t, err)
+
+		dest := azure.DestinationPath(snapshotID)
+		expected := fmt.Sprintf("/devstoreaccount1/%s/%s/snapshot.json", containerName, snapshotID)
+		assert.Contains(t, dest, expected)
+
+		t.Run("assert snapshot meta contents", func(t *testing.T) {
+			meta, err := azure.GetMeta(testCtx, snapshotID)
+			require.Nil(t, err)
+			assert.NotEmpty(t, meta.StartedAt)
+			assert.Empty(t, meta.CompletedAt)
+			assert.Equal(t, meta.Status, string(backup.CreateStarted))
+			assert.Equal(t, []string{className}, meta.ClassNames())
+			assert.Empty(t, meta.Error)
+		})
+	})
+
+	t.Run("restore snapshot in azure", func(t *testing.T) {
+		azureConfig := azure.NewConfig(containerName, "")
+		azure, err := azure.New(testCtx, azureConfig)
+		require.Nil(t, err)
+
+		for _, filePath := range filePaths {
+			expected, err := os.ReadFile(filePath)
+			require.Nil(t, err)
+
+			r, err := azure.GetObject(testCtx, snapshotID, filePath)
+			require.Nil(t, err)
+			content, err := io.ReadAll(r)
+			r.Close()
+			require.Nil(t, err)
+
+			assert.Equal(t, expected, content)
+		}
+	})
+}
+
+func moduleLevelGetMetaStatus(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	className := "SnapshotClass"
+	snapshotID := "snapshot_id"
+	containerName := "weaviate-snapshots"
+
+	t.Run("setup env", func(t *testing.T) {
+		require.Nil(t, os.Setenv(envAzureContainer, containerName))
+
+		createContainer(testCtx, t, os.Getenv(envAzureConnectionString), containerName)
+	})
+
+	azureConfig := azure.NewConfig(containerName, "")
+
+	t.Run("store snapshot in azure", func(t *testing.T) {
+		azure, err := azure.New(testCtx, azureConfig)
+		require.Nil(t, err)
+
+		snapshot, err := azure.InitSnapshot(testCtx, snapshotID, []string{className})
+		require.Nil(t, err)
+
+		err = azure.PutMeta(testCtx, snapshot)
+		assert.Nil(t, err)
+	})
+
+	t.Run("set snapshot status", func(t *testing.T) {
+		azure, err := azure.New(testCtx, azureConfig)
+		require.Nil(t, err)
+
+		err = azure.SetMetaStatus(testCtx, snapshotID, "STARTED")
+		assert.Nil(t, err)
+	})
+
+	t.Run("get snapshot status", func(t *testing.T) {
+		azure, err := azure.New(testCtx, azureConfig)
+		require.Nil(t, err)
+
+		meta, err := azure.GetMeta(testCtx, snapshotID)
+		require.Nil(t, err)
+		assert.Equal(t, "STARTED", meta.Status)
+	})
+
+	t.Run("get status of missing snapshot", func(t *testing.T) {
+		azure, err := azure.New(testCtx, azureConfig)
+		require.Nil(t, err)
+
+		_, err = azure.GetMeta(testCtx, "missing")
+		assert.IsType(t, backup.ErrNotFound{}, err)
+	})
+}
+
+func moduleLevelListAndDeleteSnapshots(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	containerName := "weaviate-snapshots-list"
+	snapshotRoot := "root"
+
+	t.Run("setup env", func(t *testing.T) {
+		createContainer(testCtx, t, os.Getenv(envAzureConnectionString), containerName)
+	})
+
+	azure, err := azure.New(testCtx, azure.NewConfig(containerName, snapshotRoot))
+	require.Nil(t, err)
+
+	t.Run("list snapshots", func(t *testing.T) {
+		for _, snapshotID := range []string{"first", "second"} {
+			_, err := azure.InitSnapshot(testCtx, snapshotID, []string{"SnapshotClass"})
+			require.Nil(t, err)
+		}
+
+		snapshots, err := azure.ListSnapshots(testCtx)
+		require.Nil(t, err)
+		require.Len(t, snapshots, 2)
+		assert.ElementsMatch(t, []string{"first", "second"},
+			[]string{snapshots[0].ID, snapshots[1].ID})
+	})
+
+	t.Run("delete snapshot", func(t *testing.T) {
+		require.Nil(t, azure.DeleteSnapshot(testCtx, "first"))
+
+		snapshots, err := azure.ListSnapshots(testCtx)
+		require.Nil(t, err)
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, "second", snapshots[0].ID)
+	})
+
+	t.Run("delete missing snapshot", func(t *testing.T) {
+		err := azure.DeleteSnapshot(testCtx, "first")
+		assert.IsType(t, backup.ErrNotFound{}, err)
+	})
+}
+
+type objectPutter interface {
+	PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error
+}
+
+func putTestFile(ctx context.Context, t *testing.T, storage objectPutter, snapshotID, filePath string) {
+	f, err := os.Open(filePath)
+	require.Nil(t, err)
+	defer f.Close()
+
+	require.Nil(t, storage.PutObject(ctx, snapshotID, filePath, f))
+}
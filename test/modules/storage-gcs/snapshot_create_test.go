@@ -14,8 +14,8 @@ package test
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
 	"testing"
 	"time"
 
@@ -55,8 +55,7 @@ func moduleLevelStoreSnapshot(t *testing.T) {
 	testDir := moduleshelper.MakeTestDir(t, testdataMainDir)
 	defer moduleshelper.RemoveDir(t, testdataMainDir)
 
-	path, err := os.Getwd()
-	require.Nil(t, err)
+	filePaths := moduleshelper.CreateTestFiles(t, testDir)
 
 	className := "SnapshotClass"
 	snapshotID := "snapshot_id"
@@ -76,57 +75,51 @@ func moduleLevelStoreSnapshot(t *testing.T) {
 
 	t.Run("store snapshot in gcs", func(t *testing.T) {
 		gcsConfig := gcs.NewConfig(bucketName, "")
-		path, _ := os.Getwd()
-
-		gcs, err := gcs.New(testCtx, gcsConfig, path)
+		gcs, err := gcs.New(testCtx, gcsConfig)
 		require.Nil(t, err)
 
-		snapshot, err := gcs.InitSnapshot(testCtx, className, snapshotID)
+		snapshot, err := gcs.InitSnapshot(testCtx, snapshotID, []string{className})
 		require.Nil(t, err)
 
-		err = gcs.StoreSnapshot(testCtx, snapshot)
+		for _, filePath := range filePaths {
+			putTestFile(testCtx, t, gcs, snapshotID, filePath)
+		}
+
+		err = gcs.PutMeta(testCtx, snapshot)
 		require.Nil(t, err)
 
-		dest := gcs.DestinationPath(className, snapshotID)
-		expected := fmt.Sprintf("gs://%s/%s/%s/snapshot.json", bucketName, className, snapshotID)
+		dest := gcs.DestinationPath(snapshotID)
+		expected := fmt.Sprintf("gs://%s/%s/snapshot.json", bucketName, snapshotID)
 		assert.Equal(t, expected, dest)
 
 		t.Run("assert snapshot meta contents", func(t *testing.T) {
-			meta, err := gcs.GetMeta(testCtx, className, snapshotID)
+			meta, err := gcs.GetMeta(testCtx, snapshotID)
 			require.Nil(t, err)
 			assert.NotEmpty(t, meta.StartedAt)
 			assert.Empty(t, meta.CompletedAt)
 			assert.Equal(t, meta.Status, string(backup.CreateStarted))
+			assert.Equal(t, []string{className}, meta.ClassNames())
 			assert.Empty(t, meta.Error)
 		})
 	})
 
 	t.Run("restore snapshot in gcs", func(t *testing.T) {
 		gcsConfig := gcs.NewConfig(bucketName, "")
-		gcs, err := gcs.New(testCtx, gcsConfig, path)
+		gcs, err := gcs.New(testCtx, gcsConfig)
 		require.Nil(t, err)
 
-		// List all files in testDir
-		files, _ := os.ReadDir(testDir)
-
-		// Remove the files, ready for restore
-		for _, f := range files {
-			require.Nil(t, os.Remove(filepath.Join(testDir, f.Name())))
-			assert.NoFileExists(t, filepath.Join(testDir, f.Name()))
-		}
+		for _, filePath := range filePaths {
+			expected, err := os.ReadFile(filePath)
+			require.Nil(t, err)
 
-		_, err = gcs.RestoreSnapshot(testCtx, "SnapshotClass", "snapshot_id")
-		assert.Nil(t, err)
+			r, err := gcs.GetObject(testCtx, snapshotID, filePath)
+			require.Nil(t, err)
+			content, err := io.ReadAll(r)
+			r.Close()
+			require.Nil(t, err)
 
-		// Check that every file in the snapshot exists in testDir
-		for _, filePath := range files {
-			expectedFilePath := filepath.Join(testDir, filePath.Name())
-			assert.FileExists(t, expectedFilePath)
+			assert.Equal(t, expected, content)
 		}
-
-		dest := gcs.DestinationPath(className, snapshotID)
-		expected := fmt.Sprintf("gs://%s/%s/%s/snapshot.json", bucketName, className, snapshotID)
-		assert.Equal(t, expected, dest)
 	})
 }
 
@@ -159,13 +152,13 @@ func moduleLevelGetMetaStatus(t *testing.T) {
 	gcsConfig := gcs.NewConfig(bucketName, "")
 
 	t.Run("store snapshot in gcs", func(t *testing.T) {
-		gcs, err := gcs.New(testCtx, gcsConfig, testDir)
+		gcs, err := gcs.New(testCtx, gcsConfig)
 		require.Nil(t, err)
 
-		snapshot, err := gcs.InitSnapshot(testCtx, className, snapshotID)
+		snapshot, err := gcs.InitSnapshot(testCtx, snapshotID, []string{className})
 		require.Nil(t, err)
 
-		err = gcs.StoreSnapshot(testCtx, snapshot)
+		err = gcs.PutMeta(testCtx, snapshot)
 		assert.Nil(t, err)
 	})
 
@@ -177,19 +170,31 @@ func moduleLevelGetMetaStatus(t *testing.T) {
 			t.Fatal(err.Error())
 		}
 
-		gcs, err := gcs.New(testCtx, gcsConfig, testDir)
+		gcs, err := gcs.New(testCtx, gcsConfig)
 		require.Nil(t, err)
 
-		err = gcs.SetMetaStatus(testCtx, className, snapshotID, "STARTED")
+		err = gcs.SetMetaStatus(testCtx, snapshotID, "STARTED")
 		assert.Nil(t, err)
 	})
 
 	t.Run("get snapshot status", func(t *testing.T) {
-		gcs, err := gcs.New(testCtx, gcsConfig, testDir)
+		gcs, err := gcs.New(testCtx, gcsConfig)
 		require.Nil(t, err)
 
-		meta, err := gcs.GetMeta(testCtx, className, snapshotID)
+		meta, err := gcs.GetMeta(testCtx, snapshotID)
 		require.Nil(t, err)
 		assert.Equal(t, "STARTED", meta.Status)
 	})
 }
+
+type objectPutter interface {
+	PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error
+}
+
+func putTestFile(ctx context.Context, t *testing.T, storage objectPutter, snapshotID, filePath string) {
+	f, err := os.Open(filePath)
+	require.Nil(t, err)
+	defer f.Close()
+
+	require.Nil(t, storage.PutObject(ctx, snapshotID, filePath, f))
+}
@@ -49,6 +49,9 @@ fi
 if [[ "$*" == *--gcs* ]]; then
   ADDITIONAL_SERVICES+=('storage-gcs')
 fi
+if [[ "$*" == *--azure* ]]; then
+  ADDITIONAL_SERVICES+=('storage-azure')
+fi
 
 docker compose -f $DOCKER_COMPOSE_FILE down --remove-orphans
 
@@ -38,27 +38,45 @@ func Test_Authorization(t *testing.T) {
 	tests := []testCase{
 		{
 			methodName:       "CreateBackup",
-			additionalArgs:   []interface{}{"className", "storageName", "id"},
+			additionalArgs:   []interface{}{"storageName", "id", "", "", []string{}, []string{}},
 			expectedVerb:     "add",
-			expectedResource: "schema/className/snapshots/storageName/id",
+			expectedResource: "backups/storageName/id",
 		},
 		{
 			methodName:       "CreateBackupStatus",
-			additionalArgs:   []interface{}{"className", "storageName", "id"},
+			additionalArgs:   []interface{}{"storageName", "id"},
 			expectedVerb:     "get",
-			expectedResource: "schema/className/snapshots/storageName/id",
+			expectedResource: "backups/storageName/id",
 		},
 		{
 			methodName:       "RestoreBackup",
-			additionalArgs:   []interface{}{"className", "storageName", "id"},
+			additionalArgs:   []interface{}{"storageName", "id", []string{}, []string{}, map[string]string{}},
 			expectedVerb:     "restore",
-			expectedResource: "schema/className/snapshots/storageName/id/restore",
+			expectedResource: "backups/storageName/id/restore",
 		},
 		{
 			methodName:       "RestoreBackupStatus",
-			additionalArgs:   []interface{}{"className", "storageName", "id"},
+			additionalArgs:   []interface{}{"storageName", "id"},
 			expectedVerb:     "get",
-			expectedResource: "schema/className/snapshots/storageName/id/restore",
+			expectedResource: "backups/storageName/id/restore",
+		},
+		{
+			methodName:       "CancelBackup",
+			additionalArgs:   []interface{}{"storageName", "id"},
+			expectedVerb:     "update",
+			expectedResource: "backups/storageName/id",
+		},
+		{
+			methodName:       "ListBackups",
+			additionalArgs:   []interface{}{"storageName"},
+			expectedVerb:     "list",
+			expectedResource: "backups/storageName",
+		},
+		{
+			methodName:       "DeleteBackup",
+			additionalArgs:   []interface{}{"storageName", "id"},
+			expectedVerb:     "delete",
+			expectedResource: "backups/storageName/id",
 		},
 	}
 
@@ -12,61 +12,71 @@
 package backup
 
 import (
+	"archive/tar"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/modulecapabilities"
 	"github.com/semi-technologies/weaviate/usecases/monitoring"
 	"github.com/semi-technologies/weaviate/usecases/sharding"
 	"github.com/sirupsen/logrus"
 )
 
-type shardingStateFunc func(className string) *sharding.State
-
 type backupManager struct {
-	logger            logrus.FieldLogger
-	source            SourceFactory
-	storages          BackupStorageProvider
-	shardingStateFunc shardingStateFunc
+	logger   logrus.FieldLogger
+	source   SourceFactory
+	restorer Restorer
+	storages BackupStorageProvider
 
 	createInProgress  map[string]bool
+	createCancels     map[string]context.CancelFunc // keyed by snapshot UID
 	createLock        sync.Mutex
 	restoreInProgress map[string]bool
 	restoreLock       sync.Mutex
 }
 
-func NewBackupManager(logger logrus.FieldLogger, snapshotters SourceFactory, storages BackupStorageProvider,
-	shardingStateFunc shardingStateFunc,
+func NewBackupManager(logger logrus.FieldLogger, snapshotters SourceFactory,
+	restorer Restorer, storages BackupStorageProvider,
 ) *backupManager {
 	return &backupManager{
-		logger:            logger,
-		source:            snapshotters,
-		storages:          storages,
-		shardingStateFunc: shardingStateFunc,
+		logger:   logger,
+		source:   snapshotters,
+		restorer: restorer,
+		storages: storages,
 
 		createInProgress:  make(map[string]bool),
+		createCancels:     make(map[string]context.CancelFunc),
 		restoreInProgress: make(map[string]bool),
 	}
 }
 
 // CreateBackup is called by the User
-func (bm *backupManager) CreateBackup(ctx context.Context, className,
-	storageName, snapshotID string,
+func (bm *backupManager) CreateBackup(ctx context.Context, storageName,
+	snapshotID, parentID string, compression backup.Compression, classes []string,
 ) (*backup.CreateMeta, error) {
-	// snapshotter (index) exists
-	snapshotter := bm.source.SourceFactory(className)
-	if snapshotter == nil {
-		return nil, backup.NewErrUnprocessable(fmt.Errorf("can not create snapshot of non-existing index for %s", className))
+	if len(classes) == 0 {
+		return nil, backup.NewErrUnprocessable(fmt.Errorf("no classes to back up"))
 	}
 
-	// multi shards not supported yet
-	if bm.isMultiShard(className) {
-		return nil, backup.NewErrUnprocessable(fmt.Errorf("snapshots for multi shard index for %s not supported yet", className))
+	// snapshotters (indexes) exist
+	sources := make(map[string]Sourcer, len(classes))
+	for _, className := range classes {
+		snapshotter := bm.source.SourceFactory(className)
+		if snapshotter == nil {
+			return nil, backup.NewErrUnprocessable(fmt.Errorf("can not create snapshot of non-existing index for %s", className))
+		}
+		sources[className] = snapshotter
 	}
 
 	// requested storage is registered
@@ -76,56 +86,82 @@ func (bm *backupManager) CreateBackup(ctx context.Context, className,
 	}
 
 	// there is no snapshot with given id on the storage, regardless of its state (valid or corrupted)
-	_, err = storage.GetMeta(ctx, className, snapshotID)
+	_, err = storage.GetMeta(ctx, snapshotID)
 	if err == nil {
-		return nil, backup.NewErrUnprocessable(fmt.Errorf("snapshot %s of index for %s already exists on storage %s", snapshotID, className, storageName))
+		return nil, backup.NewErrUnprocessable(fmt.Errorf("snapshot %s already exists on storage %s", snapshotID, storageName))
 	}
 	if _, ok := err.(backup.ErrNotFound); !ok {
-		return nil, backup.NewErrUnprocessable(errors.Wrapf(err, "checking snapshot %s of index for %s exists on storage %s", snapshotID, className, storageName))
+		return nil, backup.NewErrUnprocessable(errors.Wrapf(err, "checking snapshot %s exists on storage %s", snapshotID, storageName))
+	}
+
+	// the parent of an incremental snapshot is complete
+	var parent *backup.Snapshot
+	if parentID != "" {
+		if parent, err = bm.parentSnapshot(ctx, storage, storageName, parentID); err != nil {
+			return nil, err
+		}
 	}
 
-	// no snapshot in progress for the class
-	if !bm.setCreateInProgress(className, true) {
-		return nil, backup.NewErrUnprocessable(fmt.Errorf("snapshot of index for %s already in progress", className))
+	// no snapshot in progress for any of the classes
+	if !bm.setCreateInProgress(classes, true) {
+		return nil, backup.NewErrUnprocessable(fmt.Errorf("snapshot of index for one of %v already in progress", classes))
 	}
 
-	provider := newBackupProvider(snapshotter, storage, className, snapshotID)
+	provider := newBackupProvider(sources, storage, storageName, snapshotID,
+		classes, parent, compression)
 	snapshot, err := provider.start(ctx)
 	if err != nil {
-		bm.setCreateInProgress(className, false)
+		bm.setCreateInProgress(classes, false)
 		return nil, backup.NewErrUnprocessable(errors.Wrapf(err, "snapshot start"))
 	}
 
+	// the backup outlives the request, it can only be stopped through
+	// CancelCreate
+	snapshotUID := storageName + "-" + snapshotID
+	ctxBackup, cancel := context.WithCancel(context.Background())
+	bm.setCreateCancel(snapshotUID, cancel)
+
 	go func(ctx context.Context, provider *backupProvider) {
+		defer cancel()
 		if err := provider.backup(ctx, snapshot); err != nil {
 			bm.logger.WithField("action", "create_backup").
 				Error(err)
 		}
-		bm.setCreateInProgress(className, false)
-	}(ctx, provider)
+		bm.setCreateCancel(snapshotUID, nil)
+		bm.setCreateInProgress(classes, false)
+	}(ctxBackup, provider)
 
 	return &backup.CreateMeta{
-		Path:   storage.DestinationPath(className, snapshotID),
+		Path:   storage.DestinationPath(snapshotID),
 		Status: backup.CreateStarted,
 	}, nil
 }
 
-func (bm *backupManager) CreateBackupStatus(ctx context.Context,
-	className, storageName, snapshotID string,
-) (*models.BackupCreateMeta, error) {
-	// snapshotter (index) exists
-	if snapshotter := bm.source.SourceFactory(className); snapshotter == nil {
-		return nil, backup.NewErrNotFound(
-			fmt.Errorf("can't fetch snapshot creation status of "+
-				"non-existing index for %s", className))
+func (bm *backupManager) parentSnapshot(ctx context.Context,
+	storage modulecapabilities.SnapshotStorage, storageName, parentID string,
+) (*backup.Snapshot, error) {
+	parent, err := storage.GetMeta(ctx, parentID)
+	if err != nil {
+		if _, ok := err.(backup.ErrNotFound); ok {
+			return nil, backup.NewErrUnprocessable(fmt.Errorf("parent snapshot %s does not exist on storage %s", parentID, storageName))
+		}
+		return nil, backup.NewErrUnprocessable(errors.Wrapf(err, "checking parent snapshot %s exists on storage %s", parentID, storageName))
+	}
+	if parent.Status != string(backup.CreateSuccess) {
+		return nil, backup.NewErrUnprocessable(fmt.Errorf("parent snapshot %s on storage %s is not complete", parentID, storageName))
 	}
+	return parent, nil
+}
 
+func (bm *backupManager) CreateBackupStatus(ctx context.Context,
+	storageName, snapshotID string,
+) (*models.BackupCreateMeta, error) {
 	storage, err := bm.storages.BackupStorage(storageName)
 	if err != nil {
 		return nil, backup.NewErrUnprocessable(errors.Wrapf(err, "find storage by name %s", storageName))
 	}
 
-	meta, err := storage.GetMeta(ctx, className, snapshotID)
+	meta, err := storage.GetMeta(ctx, snapshotID)
 	if err != nil && errors.As(err, &backup.ErrNotFound{}) {
 		return nil, backup.NewErrNotFound(
 			fmt.Errorf("can't fetch snapshot creation status of "+
@@ -136,34 +172,145 @@ func (bm *backupManager) CreateBackupStatus(ctx context.Context,
 
 	status := string(meta.Status)
 
-	// TODO: populate Error field if snapshot failed
 	return &models.BackupCreateMeta{
 		ID:          snapshotID,
-		Path:        storage.DestinationPath(className, snapshotID),
+		Parent:      meta.ParentID,
+		Classes:     meta.ClassNames(),
+		Path:        storage.DestinationPath(snapshotID),
 		Status:      &status,
 		StorageName: storageName,
+		Error:       meta.Error,
 	}, nil
 }
 
-func (bm *backupManager) DestinationPath(storageName, className, snapshotID string) (string, error) {
+// CancelCreate stops the creation of the specified snapshot. It returns
+// false if no such creation is in progress. The snapshot is marked as
+// canceled once all classes have been released again.
+func (bm *backupManager) CancelCreate(storageName, snapshotID string) bool {
+	bm.createLock.Lock()
+	defer bm.createLock.Unlock()
+
+	cancel, ok := bm.createCancels[storageName+"-"+snapshotID]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+func (bm *backupManager) isCreateInProgress(storageName, snapshotID string) bool {
+	bm.createLock.Lock()
+	defer bm.createLock.Unlock()
+
+	_, ok := bm.createCancels[storageName+"-"+snapshotID]
+	return ok
+}
+
+// ListBackups returns all snapshots on the storage, oldest first
+func (bm *backupManager) ListBackups(ctx context.Context,
+	storageName string,
+) ([]*models.BackupListItem, error) {
+	storage, err := bm.storages.BackupStorage(storageName)
+	if err != nil {
+		return nil, backup.NewErrUnprocessable(errors.Wrapf(err, "find storage by name %s", storageName))
+	}
+
+	snapshots, err := storage.ListSnapshots(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list snapshots on storage %s", storageName)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].StartedAt.Before(snapshots[j].StartedAt)
+	})
+
+	items := make([]*models.BackupListItem, len(snapshots))
+	for i, snapshot := range snapshots {
+		status := snapshot.Status
+		items[i] = &models.BackupListItem{
+			ID:      snapshot.ID,
+			Parent:  snapshot.ParentID,
+			Classes: snapshot.ClassNames(),
+			Size:    snapshot.Size,
+			Status:  &status,
+			Error:   snapshot.Error,
+		}
+	}
+	return items, nil
+}
+
+// DeleteBackup removes a snapshot from the storage. Snapshots which are
+// still being created or whose files are referenced by incremental
+// snapshots can not be deleted.
+func (bm *backupManager) DeleteBackup(ctx context.Context, storageName,
+	snapshotID string,
+) error {
+	storage, err := bm.storages.BackupStorage(storageName)
+	if err != nil {
+		return backup.NewErrUnprocessable(errors.Wrapf(err, "find storage by name %s", storageName))
+	}
+
+	if bm.isCreateInProgress(storageName, snapshotID) {
+		return backup.NewErrUnprocessable(fmt.Errorf("snapshot %s is still being created, cancel it first", snapshotID))
+	}
+
+	if _, err := storage.GetMeta(ctx, snapshotID); err != nil {
+		if _, ok := err.(backup.ErrNotFound); ok {
+			return backup.NewErrNotFound(fmt.Errorf("snapshot %s does not exist on storage %s", snapshotID, storageName))
+		}
+		return errors.Wrapf(err, "checking snapshot %s exists on storage %s", snapshotID, storageName)
+	}
+
+	snapshots, err := storage.ListSnapshots(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "list snapshots on storage %s", storageName)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.ID != snapshotID && referencesSnapshot(snapshot, snapshotID) {
+			return backup.NewErrUnprocessable(fmt.Errorf("snapshot %s is referenced by incremental snapshot %s", snapshotID, snapshot.ID))
+		}
+	}
+
+	if err := storage.DeleteSnapshot(ctx, snapshotID); err != nil {
+		return errors.Wrapf(err, "delete snapshot %s", snapshotID)
+	}
+	return nil
+}
+
+// referencesSnapshot checks whether the snapshot is an incremental snapshot
+// of the other one or relies on any of its files
+func referencesSnapshot(snapshot *backup.Snapshot, otherID string) bool {
+	if snapshot.ParentID == otherID {
+		return true
+	}
+	for _, classSnap := range snapshot.Classes {
+		for _, file := range classSnap.Files {
+			if file.Snapshot == otherID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (bm *backupManager) DestinationPath(storageName, snapshotID string) (string, error) {
 	// requested storage is registered
 	storage, err := bm.storages.BackupStorage(storageName)
 	if err != nil {
 		return "", err
 	}
 
-	return storage.DestinationPath(className, snapshotID), nil
+	return storage.DestinationPath(snapshotID), nil
 }
 
-func (bm *backupManager) RestoreBackup(ctx context.Context, className,
-	storageName, snapshotID string,
+// RestoreBackup validates that the selected classes of the snapshot can be
+// restored and marks them as in progress. The returned snapshot only
+// contains the selected classes, already renamed as specified by rename.
+// Transferring the files is left to RestoreClassFiles and the caller has to
+// call ReleaseRestore once done.
+func (bm *backupManager) RestoreBackup(ctx context.Context, storageName,
+	snapshotID string, include, exclude []string, rename map[string]string,
 ) (*backup.RestoreMeta, *backup.Snapshot, error) {
-	timer := monitoring.NewOnceTimer(prometheus.NewTimer(monitoring.GetMetrics().SnapshotRestoreBackupInitDurations.WithLabelValues(storageName, className)))
-	defer timer.ObserveDurationOnce()
-	// snapshotter (index) does not exist
-	if snapshotter := bm.source.SourceFactory(className); snapshotter != nil {
-		return nil, nil, backup.NewErrUnprocessable(fmt.Errorf("can not restore snapshot of existing index for %s", className))
-	}
+	started := time.Now()
 
 	// requested storage is registered
 	storage, err := bm.storages.BackupStorage(storageName)
@@ -172,67 +319,383 @@ func (bm *backupManager) RestoreBackup(ctx context.Context, className,
 	}
 
 	// snapshot with given id exists and is valid
-	if meta, err := storage.GetMeta(ctx, className, snapshotID); err != nil {
+	meta, err := storage.GetMeta(ctx, snapshotID)
+	if err != nil {
 		if _, ok := err.(backup.ErrNotFound); !ok {
-			return nil, nil, backup.NewErrUnprocessable(errors.Wrapf(err, "checking snapshot %s of index for %s exists on storage %s", snapshotID, className, storageName))
+			return nil, nil, backup.NewErrUnprocessable(errors.Wrapf(err, "checking snapshot %s exists on storage %s", snapshotID, storageName))
 		}
-		return nil, nil, backup.NewErrNotFound(errors.Wrapf(err, "snapshot %s of index for %s does not exist on storage %s", snapshotID, className, storageName))
+		return nil, nil, backup.NewErrNotFound(errors.Wrapf(err, "snapshot %s does not exist on storage %s", snapshotID, storageName))
 	} else if meta.Status != string(backup.CreateSuccess) {
-		return nil, nil, backup.NewErrNotFound(fmt.Errorf("snapshot %s of index for %s on storage %s is corrupted", snapshotID, className, storageName))
+		return nil, nil, backup.NewErrNotFound(fmt.Errorf("snapshot %s on storage %s is corrupted", snapshotID, storageName))
 	}
 
-	// no restore in progress for the class
-	if !bm.setRestoreInProgress(className, true) {
-		return nil, nil, backup.NewErrUnprocessable(fmt.Errorf("restoration of index for %s already in progress", className))
+	classes, err := selectClasses(meta.ClassNames(), include, exclude)
+	if err != nil {
+		return nil, nil, backup.NewErrUnprocessable(errors.Wrapf(err, "snapshot %s", snapshotID))
 	}
 
-	timer.ObserveDurationOnce()
-
-	snapshot, err := storage.RestoreSnapshot(ctx, className, snapshotID)
+	rename, err = validateRename(classes, rename)
 	if err != nil {
-		bm.setRestoreInProgress(className, false)
-		return nil, nil, backup.NewErrUnprocessable(errors.Wrapf(err, "restore snapshot %s of index for %s", snapshotID, className))
+		return nil, nil, backup.NewErrUnprocessable(errors.Wrapf(err, "snapshot %s", snapshotID))
+	}
+
+	selected := make([]*backup.ClassSnapshot, len(classes))
+	targets := make([]string, len(classes))
+	for i, className := range classes {
+		classSnap, err := renameClass(meta.GetClass(className), rename)
+		if err != nil {
+			return nil, nil, backup.NewErrUnprocessable(errors.Wrapf(err, "snapshot %s", snapshotID))
+		}
+		selected[i] = classSnap
+		targets[i] = classSnap.Name
 	}
 
-	bm.setRestoreInProgress(className, false)
+	// snapshotters (indexes) do not exist
+	for _, className := range targets {
+		if snapshotter := bm.source.SourceFactory(className); snapshotter != nil {
+			return nil, nil, backup.NewErrUnprocessable(fmt.Errorf("can not restore snapshot of existing index for %s", className))
+		}
+	}
+
+	// no restore in progress for any of the classes
+	if !bm.setRestoreInProgress(targets, true) {
+		return nil, nil, backup.NewErrUnprocessable(fmt.Errorf("restoration of index for one of %v already in progress", targets))
+	}
+
+	for _, className := range targets {
+		monitoring.GetMetrics().SnapshotRestoreBackupInitDurations.
+			WithLabelValues(storageName, className).
+			Observe(time.Since(started).Seconds())
+	}
+	meta.Classes = selected
 
 	return &backup.RestoreMeta{
-		Path:   storage.DestinationPath(className, snapshotID),
+		Path:   storage.DestinationPath(snapshotID),
 		Status: backup.RestoreStarted,
-	}, snapshot, nil
+	}, meta, nil
 }
 
-func (bm *backupManager) isMultiShard(className string) bool {
-	physicalShards := bm.shardingStateFunc(className).Physical
-	return len(physicalShards) > 1
+// RestoreClassFiles copies all files and the shard metadata of a single
+// class from the storage back to every node which holds a replica of them.
+// The checksum of every file is verified, so a corrupted snapshot fails the
+// restore before the class is brought online. The files are restored as
+// part of the class named in the snapshot, even if they were backed up from
+// a class of a different name.
+func (bm *backupManager) RestoreClassFiles(ctx context.Context, storageName,
+	snapshotID string, classSnap *backup.ClassSnapshot,
+) error {
+	timer := prometheus.NewTimer(monitoring.GetMetrics().SnapshotRestoreFromStorageDurations.
+		WithLabelValues(storageName, classSnap.Name))
+	defer timer.ObserveDuration()
+
+	storage, err := bm.storages.BackupStorage(storageName)
+	if err != nil {
+		return errors.Wrapf(err, "find storage by name %s", storageName)
+	}
+
+	var shardingState sharding.State
+	if err := json.Unmarshal(classSnap.ShardingState, &shardingState); err != nil {
+		return errors.Wrapf(err, "unmarshal sharding state of %s", classSnap.Name)
+	}
+
+	for _, files := range replicaFiles(classSnap.Files, &shardingState) {
+		if err := bm.restoreFiles(ctx, storage, storageName, snapshotID,
+			classSnap.Name, files); err != nil {
+			return err
+		}
+	}
+
+	for shardName, shardMeta := range classSnap.ShardMetadata {
+		shard, ok := shardingState.Physical[shardName]
+		if !ok {
+			return errors.Errorf("class %s has no physical shard %q", classSnap.Name, shardName)
+		}
+
+		for _, node := range shard.Nodes() {
+			if err := bm.restorer.RestoreShardMetadata(ctx, classSnap.Name, shardName,
+				node, shardMeta); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// replicaFiles distributes the files of a class among all replicas of their
+// shards. The first set contains the files as they were backed up, every
+// further set one more copy of the files of each replicated shard. As no
+// set contains the same file twice, each set can be restored at once.
+func replicaFiles(files []backup.SnapshotFile, state *sharding.State) [][]backup.SnapshotFile {
+	sets := [][]backup.SnapshotFile{files}
+	for _, file := range files {
+		if file.Node == "" {
+			// backed up before shards were distributed across nodes
+			continue
+		}
+
+		set := 1
+		for _, node := range state.Physical[file.Shard].Nodes() {
+			if node == file.Node {
+				continue
+			}
+			if set == len(sets) {
+				sets = append(sets, nil)
+			}
+			replica := file
+			replica.Node = node
+			sets[set] = append(sets[set], replica)
+			set++
+		}
+	}
+	return sets
+}
+
+// restoreFiles restores files which are stored on their own right away,
+// while files stored in chunks are restored chunk by chunk, in the order in
+// which the chunks are first referenced
+func (bm *backupManager) restoreFiles(ctx context.Context, storage modulecapabilities.SnapshotStorage,
+	storageName, snapshotID, className string, files []backup.SnapshotFile,
+) error {
+	var chunks []chunkRef
+	chunkFiles := make(map[chunkRef][]backup.SnapshotFile)
+	for _, file := range files {
+		if file.Chunk == "" {
+			if err := bm.restoreFile(ctx, storage, storageName, snapshotID, className, file); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ref := chunkRef{file.StoredIn(snapshotID), file.Chunk, file.Compression}
+		if _, ok := chunkFiles[ref]; !ok {
+			chunks = append(chunks, ref)
+		}
+		chunkFiles[ref] = append(chunkFiles[ref], file)
+	}
+
+	for _, ref := range chunks {
+		if err := bm.restoreChunk(ctx, storage, storageName, className, ref, chunkFiles[ref]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// chunkRef identifies a chunk across the snapshots of an incremental chain
+type chunkRef struct {
+	snapshotID  string
+	key         string
+	compression backup.Compression
 }
 
-func (bm *backupManager) setCreateInProgress(className string, inProgress bool) bool {
+func (bm *backupManager) restoreFile(ctx context.Context, storage modulecapabilities.SnapshotStorage,
+	storageName, snapshotID, className string, file backup.SnapshotFile,
+) error {
+	// files of incremental snapshots may be stored under one of its ancestors
+	r, err := storage.GetObject(ctx, file.StoredIn(snapshotID), file.Path)
+	if err != nil {
+		return errors.Wrapf(err, "get file %s", file.Path)
+	}
+	defer r.Close()
+
+	counter := &countingReader{r: r}
+	if err := bm.restoreVerified(ctx, className, file, counter); err != nil {
+		return err
+	}
+
+	monitoring.GetMetrics().SnapshotRestoreDataTransferred.
+		WithLabelValues(storageName, file.Class).Add(float64(counter.n))
+	return nil
+}
+
+// restoreChunk restores the specified files from a single chunk. The chunk
+// may contain further files, which changed since the chunk was created and
+// are therefore stored elsewhere.
+func (bm *backupManager) restoreChunk(ctx context.Context, storage modulecapabilities.SnapshotStorage,
+	storageName, className string, ref chunkRef, files []backup.SnapshotFile,
+) error {
+	r, err := storage.GetObject(ctx, ref.snapshotID, ref.key)
+	if err != nil {
+		return errors.Wrapf(err, "get chunk %s", ref.key)
+	}
+	defer r.Close()
+
+	counter := &countingReader{r: r}
+	dr, err := newDecompressor(counter, ref.compression)
+	if err != nil {
+		return errors.Wrapf(err, "decompress chunk %s", ref.key)
+	}
+	defer dr.Close()
+
+	pending := make(map[string]backup.SnapshotFile, len(files))
+	for _, file := range files {
+		pending[file.Path] = file
+	}
+
+	tr := tar.NewReader(dr)
+	for len(pending) > 0 {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "read chunk %s", ref.key)
+		}
+
+		file, ok := pending[header.Name]
+		if !ok {
+			continue
+		}
+		if err := bm.restoreVerified(ctx, className, file, tr); err != nil {
+			return err
+		}
+		delete(pending, header.Name)
+	}
+
+	if len(pending) > 0 {
+		missing := make([]string, 0, len(pending))
+		for path := range pending {
+			missing = append(missing, path)
+		}
+		sort.Strings(missing)
+		return errors.Errorf("chunk %s is missing files %v", ref.key, missing)
+	}
+
+	monitoring.GetMetrics().SnapshotRestoreDataTransferred.
+		WithLabelValues(storageName, files[0].Class).Add(float64(counter.n))
+	return nil
+}
+
+// restoreVerified restores a single file once its checksum has been
+// verified. The file is staged in a temporary file until then, so that a
+// corrupted file is never written to a shard. Files without a checksum can
+// not be verified and are restored right away.
+func (bm *backupManager) restoreVerified(ctx context.Context, className string,
+	file backup.SnapshotFile, r io.Reader,
+) error {
+	if file.Checksum == "" {
+		return bm.writeRestoredFile(ctx, className, file, r)
+	}
+
+	staged, err := os.CreateTemp("", "restore-*")
+	if err != nil {
+		return errors.Wrapf(err, "stage file %s", file.Path)
+	}
+	defer func() {
+		staged.Close()
+		os.Remove(staged.Name())
+	}()
+
+	checksum := newChecksumReader(r)
+	if _, err := io.Copy(staged, checksum); err != nil {
+		return errors.Wrapf(err, "stage file %s", file.Path)
+	}
+	if err := checksum.verify(file); err != nil {
+		return errors.Wrap(err, "snapshot is corrupted")
+	}
+
+	if _, err := staged.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrapf(err, "read staged file %s", file.Path)
+	}
+	return bm.writeRestoredFile(ctx, className, file, staged)
+}
+
+func (bm *backupManager) writeRestoredFile(ctx context.Context, className string,
+	file backup.SnapshotFile, r io.Reader,
+) error {
+	if err := bm.restorer.RestoreBackupFile(ctx, className, file, r); err != nil {
+		return errors.Wrapf(err, "restore file %s, system might be in a corrupted state", file.Path)
+	}
+	return nil
+}
+
+// ReleaseRestore marks the restoration of the specified classes as finished
+func (bm *backupManager) ReleaseRestore(classes []string) {
+	bm.setRestoreInProgress(classes, false)
+}
+
+func (bm *backupManager) setCreateInProgress(classes []string, inProgress bool) bool {
 	bm.createLock.Lock()
 	defer bm.createLock.Unlock()
 
-	key := strings.ToLower(className)
-	if inProgress && bm.createInProgress[key] {
-		return false
-	}
-	if !inProgress && !bm.createInProgress[key] {
-		return false
+	return setInProgress(bm.createInProgress, classes, inProgress)
+}
+
+// setCreateCancel registers the function to cancel the creation of a
+// snapshot, a nil cancel removes it again
+func (bm *backupManager) setCreateCancel(snapshotUID string, cancel context.CancelFunc) {
+	bm.createLock.Lock()
+	defer bm.createLock.Unlock()
+
+	if cancel == nil {
+		delete(bm.createCancels, snapshotUID)
+		return
 	}
-	bm.createInProgress[key] = inProgress
-	return true
+	bm.createCancels[snapshotUID] = cancel
 }
 
-func (bm *backupManager) setRestoreInProgress(className string, inProgress bool) bool {
+func (bm *backupManager) setRestoreInProgress(classes []string, inProgress bool) bool {
 	bm.restoreLock.Lock()
 	defer bm.restoreLock.Unlock()
 
-	key := strings.ToLower(className)
-	if inProgress && bm.restoreInProgress[key] {
-		return false
+	return setInProgress(bm.restoreInProgress, classes, inProgress)
+}
+
+// setInProgress changes the state of all classes at once. It does not change
+// anything and returns false if any of the classes already is in the
+// target state.
+func setInProgress(state map[string]bool, classes []string, inProgress bool) bool {
+	for _, className := range classes {
+		if state[strings.ToLower(className)] == inProgress {
+			return false
+		}
 	}
-	if !inProgress && !bm.restoreInProgress[key] {
-		return false
+	for _, className := range classes {
+		state[strings.ToLower(className)] = inProgress
 	}
-	bm.restoreInProgress[key] = inProgress
 	return true
 }
+
+// selectClasses narrows down the available classes to either the included
+// or all but the excluded ones. Specifying both is an error, as is including
+// a class which is not available.
+func selectClasses(available, include, exclude []string) ([]string, error) {
+	if len(include) > 0 && len(exclude) > 0 {
+		return nil, fmt.Errorf("include and exclude are mutually exclusive")
+	}
+
+	var selected []string
+	if len(include) > 0 {
+		for _, className := range include {
+			name, ok := findClass(available, className)
+			if !ok {
+				return nil, fmt.Errorf("class %s not found", className)
+			}
+			selected = append(selected, name)
+		}
+	} else {
+		for _, className := range available {
+			if _, ok := findClass(exclude, className); !ok {
+				selected = append(selected, className)
+			}
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no classes selected")
+	}
+	return selected, nil
+}
+
+// findClass looks up a class name case-insensitively and returns it as
+// spelled in classes
+func findClass(classes []string, className string) (string, bool) {
+	for _, c := range classes {
+		if strings.EqualFold(c, className) {
+			return c, true
+		}
+	}
+	return "", false
+}
@@ -12,10 +12,15 @@
 package backup
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"sync"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -40,48 +45,56 @@ func TestBackupManager_CreateBackup(t *testing.T) {
 	t.Run("fails when snapshot is not valid", func(t *testing.T) {
 		bm := createManager(nil, nil, nil, nil)
 
-		meta, err := bm.CreateBackup(ctx, nil, className, storageName, "A*:")
+		meta, err := bm.CreateBackup(ctx, nil, storageName, "A*:", "", "", []string{className}, nil)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 
-		meta, err = bm.CreateBackup(ctx, nil, className, storageName, "")
+		meta, err = bm.CreateBackup(ctx, nil, storageName, "", "", "", []string{className}, nil)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 	})
 
-	t.Run("fails when index does not exist", func(t *testing.T) {
+	t.Run("fails when include and exclude are both set", func(t *testing.T) {
 		bm := createManager(nil, nil, nil, nil)
 
-		meta, err := bm.CreateBackup(ctx, nil, className, storageName, snapshotID)
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", []string{className}, []string{className2})
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
-		assert.Contains(t, err.Error(), fmt.Sprintf("can not create snapshot of non-existing index for %s", className))
+		assert.Contains(t, err.Error(), "include and exclude are mutually exclusive")
 		assert.IsType(t, backup.ErrUnprocessable{}, err)
 	})
 
-	t.Run("fails when index has multiple shards", func(t *testing.T) {
-		snapshotter := &fakeSnapshotter{}
-		shardingState := &sharding.State{Physical: map[string]sharding.Physical{"a": {}, "b": {}}}
-		bm := createManager(snapshotter, nil, nil, shardingState)
+	t.Run("fails when included class is not in the schema", func(t *testing.T) {
+		bm := createManager(nil, nil, nil, nil)
 
-		meta, err := bm.CreateBackup(ctx, nil, className, storageName, snapshotID)
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", []string{"UnknownClass"}, nil)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
-		assert.Contains(t, err.Error(), fmt.Sprintf("snapshots for multi shard index for %s not supported yet", className))
+		assert.Contains(t, err.Error(), "class UnknownClass not found")
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+	})
+
+	t.Run("fails when index does not exist", func(t *testing.T) {
+		bm := createManager(nil, nil, nil, nil)
+
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", []string{className}, nil)
+
+		assert.Nil(t, meta)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("can not create snapshot of non-existing index for %s", className))
 		assert.IsType(t, backup.ErrUnprocessable{}, err)
 	})
 
 	t.Run("fails when storage not registered", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		shardingState := &sharding.State{Physical: map[string]sharding.Physical{"a": {}}}
 		storageError := errors.New("I do not exist")
-		bm := createManager(snapshotter, nil, storageError, shardingState)
+		bm := createManager(snapshotter, nil, nil, storageError)
 
-		meta, err := bm.CreateBackup(ctx, nil, className, storageName, snapshotID)
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", []string{className}, nil)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
@@ -92,106 +105,128 @@ func TestBackupManager_CreateBackup(t *testing.T) {
 	t.Run("fails when error reading meta from storage", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
 		storage := &fakeStorage{}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(nil, errors.New("can not be read"))
-		shardingState := &sharding.State{Physical: map[string]sharding.Physical{"a": {}}}
-		bm := createManager(snapshotter, storage, nil, shardingState)
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, errors.New("can not be read"))
+		bm := createManager(snapshotter, nil, storage, nil)
 
-		meta, err := bm.CreateBackup(ctx, nil, className, storageName, snapshotID)
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", []string{className}, nil)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
-		assert.Contains(t, err.Error(), fmt.Sprintf("checking snapshot %s of index for %s exists on storage %s", snapshotID, className, storageName))
+		assert.Contains(t, err.Error(), fmt.Sprintf("checking snapshot %s exists on storage %s", snapshotID, storageName))
 		assert.IsType(t, backup.ErrUnprocessable{}, err)
 	})
 
 	t.Run("fails when meta exists on storage", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
 		storage := &fakeStorage{}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(&backup.Snapshot{}, nil)
-		shardingState := &sharding.State{Physical: map[string]sharding.Physical{"a": {}}}
-		bm := createManager(snapshotter, storage, nil, shardingState)
+		storage.On("GetMeta", ctx, snapshotID).Return(&backup.Snapshot{}, nil)
+		bm := createManager(snapshotter, nil, storage, nil)
+
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", []string{className}, nil)
+
+		assert.Nil(t, meta)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("snapshot %s already exists on storage %s", snapshotID, storageName))
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+	})
+
+	t.Run("fails when parent does not exist on storage", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{}
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		storage.On("GetMeta", ctx, snapshotID2).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		bm := createManager(snapshotter, nil, storage, nil)
 
-		meta, err := bm.CreateBackup(ctx, nil, className, storageName, snapshotID)
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, snapshotID2, "", []string{className}, nil)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
-		assert.Contains(t, err.Error(), fmt.Sprintf("snapshot %s of index for %s already exists on storage %s", snapshotID, className, storageName))
+		assert.Contains(t, err.Error(), fmt.Sprintf("parent snapshot %s does not exist on storage %s", snapshotID2, storageName))
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+	})
+
+	t.Run("fails when parent is not complete", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{}
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		storage.On("GetMeta", ctx, snapshotID2).Return(&backup.Snapshot{Status: string(backup.CreateFailed)}, nil)
+		bm := createManager(snapshotter, nil, storage, nil)
+
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, snapshotID2, "", []string{className}, nil)
+
+		assert.Nil(t, meta)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("parent snapshot %s on storage %s is not complete", snapshotID2, storageName))
 		assert.IsType(t, backup.ErrUnprocessable{}, err)
 	})
 
 	t.Run("fails when snapshot creation already in progress", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, mock.Anything).Return(nil, nil)
+		// make sure create backup takes some time, so the second call starts before the first one finishes
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).
+			Return(backup.NewClassSnapshot(className), nil).After(50 * time.Millisecond)
 		snapshotter.On("ReleaseBackup", mock.Anything, mock.Anything).Return(nil)
-		// make sure create backup takes some time, so the parallel execution has enough time to start before first one finishes
-		storage := &fakeStorage{getMetaStatusSleep: 50 * time.Millisecond, storeSnapshotSleep: 50 * time.Millisecond}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
-		storage.On("GetMeta", ctx, className, snapshotID2).Return(nil, backup.NewErrNotFound(errors.New("not found")))
-		storage.On("InitSnapshot", mock.Anything, className, snapshotID).Return(&backup.Snapshot{}, nil)
-		storage.On("DestinationPath", className, snapshotID).Return(path)
-		storage.On("SetMetaStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-		storage.On("StoreSnapshot", mock.Anything, mock.Anything).Return(nil)
-		shardingState := &sharding.State{Physical: map[string]sharding.Physical{"a": {}}}
-		bm := createManager(snapshotter, storage, nil, shardingState)
-
-		wg := sync.WaitGroup{}
-		wg.Add(2)
-
-		go func() {
-			meta, err := bm.CreateBackup(ctx, nil, className, storageName, snapshotID)
-			time.Sleep(75 * time.Millisecond) // enough time to async create finish
-
-			assert.NotNil(t, meta)
-			assert.Equal(t, backup.CreateStarted, backup.CreateStatus(*meta.Status))
-			assert.Equal(t, path, meta.Path)
-			assert.Nil(t, err)
-			wg.Done()
-		}()
-		go func() {
-			time.Sleep(25 * time.Millisecond)
-			meta, err := bm.CreateBackup(ctx, nil, className, storageName, snapshotID2)
-			time.Sleep(75 * time.Millisecond) // enough time to async create finish
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		storage.On("GetMeta", ctx, snapshotID2).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		storage.On("InitSnapshot", mock.Anything, snapshotID, []string{className}).
+			Return(backup.NewSnapshot(snapshotID, []string{className}, time.Now()), nil)
+		storage.On("DestinationPath", snapshotID).Return(path)
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("SetMetaStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		bm := createManager(snapshotter, nil, storage, nil)
+
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", []string{className}, nil)
+
+		require.Nil(t, err)
+		assert.Equal(t, backup.CreateStarted, backup.CreateStatus(*meta.Status))
+		assert.Equal(t, path, meta.Path)
+		assert.Equal(t, []string{className}, meta.Classes)
 
-			assert.Nil(t, meta)
-			assert.NotNil(t, err)
-			assert.Contains(t, err.Error(), fmt.Sprintf("snapshot of index for %s already in progress", className))
-			assert.IsType(t, backup.ErrUnprocessable{}, err)
-			wg.Done()
-		}()
+		// the class is already part of a running backup
+		meta, err = bm.CreateBackup(ctx, nil, storageName, snapshotID2, "", "", []string{className, className2}, nil)
 
-		wg.Wait()
+		assert.Nil(t, meta)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "already in progress")
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+
+		time.Sleep(100 * time.Millisecond) // enough time to async create finish
+		snapshotter.AssertExpectations(t)
 	})
 
 	t.Run("fails when init meta fails", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
 		storage := &fakeStorage{}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
-		storage.On("InitSnapshot", mock.Anything, className, snapshotID).Return(nil, errors.New("init meta failed"))
-		shardingState := &sharding.State{Physical: map[string]sharding.Physical{"a": {}}}
-		bm := createManager(snapshotter, storage, nil, shardingState)
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		storage.On("InitSnapshot", mock.Anything, snapshotID, []string{className}).Return(nil, errors.New("init meta failed"))
+		bm := createManager(snapshotter, nil, storage, nil)
 
-		meta, err := bm.CreateBackup(ctx, nil, className, storageName, snapshotID)
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", []string{className}, nil)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "snapshot start")
 		assert.IsType(t, backup.ErrUnprocessable{}, err)
+
+		// the classes must not be blocked by the failed attempt
+		assert.True(t, bm.backups.setCreateInProgress([]string{className}, true))
 	})
 
 	t.Run("successfully starts", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, mock.Anything).Return(nil, nil).Once()
-		snapshotter.On("ReleaseBackup", mock.Anything, mock.Anything).Return(nil).Once()
-		storage := &fakeStorage{}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
-		storage.On("InitSnapshot", mock.Anything, className, snapshotID).Return(&backup.Snapshot{}, nil)
-		storage.On("DestinationPath", className, snapshotID).Return(path)
-		storage.On("SetMetaStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-		storage.On("StoreSnapshot", mock.Anything, mock.Anything).Return(nil)
-		shardingState := &sharding.State{Physical: map[string]sharding.Physical{"a": {}}}
-		bm := createManager(snapshotter, storage, nil, shardingState)
-
-		meta, err := bm.CreateBackup(ctx, nil, className, storageName, snapshotID)
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(backup.NewClassSnapshot(className), nil).Once()
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil).Once()
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		storage.On("InitSnapshot", mock.Anything, snapshotID, []string{className}).
+			Return(backup.NewSnapshot(snapshotID, []string{className}, time.Now()), nil)
+		storage.On("DestinationPath", snapshotID).Return(path)
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("SetMetaStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		bm := createManager(snapshotter, nil, storage, nil)
+
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", []string{className}, nil)
 		time.Sleep(10 * time.Millisecond) // enough time to async create finish
 
 		assert.NotNil(t, meta)
@@ -201,50 +236,31 @@ func TestBackupManager_CreateBackup(t *testing.T) {
 		snapshotter.AssertExpectations(t) // make sure async create called
 	})
 
-	t.Run("successfully starts for multiple classes", func(t *testing.T) {
+	t.Run("successfully starts for all but excluded classes", func(t *testing.T) {
+		classSnap := backup.NewClassSnapshot(className)
+		classSnap.Files = []backup.SnapshotFile{{Class: className, Shard: "shard", Path: "democlass_shard.file"}}
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, mock.Anything).Return(nil, nil).Twice()
-		snapshotter.On("ReleaseBackup", mock.Anything, mock.Anything).Return(nil).Twice()
-		// make sure create backup takes some time, so the parallel execution has enough time to start before first one finishes
-		storage := &fakeStorage{getMetaStatusSleep: 50 * time.Millisecond, storeSnapshotSleep: 50 * time.Millisecond}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
-		storage.On("GetMeta", ctx, className2, snapshotID2).Return(nil, backup.NewErrNotFound(errors.New("not found")))
-		storage.On("InitSnapshot", mock.Anything, className, snapshotID).Return(&backup.Snapshot{}, nil)
-		storage.On("InitSnapshot", mock.Anything, className2, snapshotID2).Return(&backup.Snapshot{}, nil)
-		storage.On("DestinationPath", className, snapshotID).Return(path)
-		storage.On("DestinationPath", className2, snapshotID2).Return(path)
-		storage.On("SetMetaStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-		storage.On("StoreSnapshot", mock.Anything, mock.Anything).Return(nil)
-		shardingState := &sharding.State{Physical: map[string]sharding.Physical{"a": {}}}
-		bm := createManager(snapshotter, storage, nil, shardingState)
-
-		wg := sync.WaitGroup{}
-		wg.Add(2)
-
-		go func() {
-			meta, err := bm.CreateBackup(ctx, nil, className, storageName, snapshotID)
-			time.Sleep(75 * time.Millisecond) // enough time to async create finish
-
-			assert.NotNil(t, meta)
-			assert.Equal(t, backup.CreateStarted, backup.CreateStatus(*meta.Status))
-			assert.Equal(t, path, meta.Path)
-			assert.Nil(t, err)
-			wg.Done()
-		}()
-		go func() {
-			time.Sleep(25 * time.Millisecond)
-			meta, err := bm.CreateBackup(ctx, nil, className2, storageName, snapshotID2)
-			time.Sleep(75 * time.Millisecond) // enough time to async create finish
-
-			assert.NotNil(t, meta)
-			assert.Equal(t, backup.CreateStarted, backup.CreateStatus(*meta.Status))
-			assert.Equal(t, path, meta.Path)
-			assert.Nil(t, err)
-			wg.Done()
-		}()
-
-		wg.Wait()
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(classSnap, nil).Once()
+		snapshotter.On("ReadBackupFile", mock.Anything, classSnap.Files[0]).
+			Return(io.NopCloser(strings.NewReader("file contents")), nil).Once()
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil).Once()
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		storage.On("InitSnapshot", mock.Anything, snapshotID, []string{className}).
+			Return(backup.NewSnapshot(snapshotID, []string{className}, time.Now()), nil)
+		storage.On("DestinationPath", snapshotID).Return(path)
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, classSnap.Files[0].Path, mock.Anything).Return(nil).Once()
+		storage.On("SetMetaStatus", mock.Anything, snapshotID, string(backup.CreateSuccess)).Return(nil).Once()
+		bm := createManager(snapshotter, nil, storage, nil)
+
+		meta, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", nil, []string{className2})
+		time.Sleep(10 * time.Millisecond) // enough time to async create finish
+
+		require.Nil(t, err)
+		assert.Equal(t, []string{className}, meta.Classes)
 		snapshotter.AssertExpectations(t)
+		storage.AssertExpectations(t)
 	})
 }
 
@@ -252,27 +268,21 @@ func TestBackupManager_RestoreBackup(t *testing.T) {
 	className := "DemoClass"
 	className2 := "DemoClass2"
 	storageName := "DemoStorage"
-	snapshotID := "SnapshotID"
-	snapshotID2 := "SnapshotID2"
+	snapshotID := "snapshot-id"
 	ctx := context.Background()
 	path := "dst/path"
 
-	t.Run("fails when index already exists", func(t *testing.T) {
-		snapshotter := &fakeSnapshotter{}
-		bm := createManager(snapshotter, nil, nil, nil)
-		meta, _, err := bm.backups.RestoreBackup(ctx, className, storageName, snapshotID)
-
-		assert.Nil(t, meta)
-		assert.NotNil(t, err)
-		assert.Contains(t, err.Error(), fmt.Sprintf("can not restore snapshot of existing index for %s", className))
-		assert.IsType(t, backup.ErrUnprocessable{}, err)
-	})
+	successfulSnapshot := func() *backup.Snapshot {
+		snap := backup.NewSnapshot(snapshotID, []string{className, className2}, time.Now())
+		snap.Status = string(backup.CreateSuccess)
+		return snap
+	}
 
 	t.Run("fails when storage not registered", func(t *testing.T) {
 		storageError := errors.New("I do not exist")
-		bm := createManager(nil, nil, storageError, nil)
+		bm := createManager(nil, nil, nil, storageError)
 
-		meta, _, err := bm.backups.RestoreBackup(ctx, className, storageName, snapshotID)
+		meta, _, err := bm.backups.RestoreBackup(ctx, storageName, snapshotID, nil, nil, nil)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
@@ -282,27 +292,27 @@ func TestBackupManager_RestoreBackup(t *testing.T) {
 
 	t.Run("fails when error reading meta from storage", func(t *testing.T) {
 		storage := &fakeStorage{}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(nil, errors.New("can not be read"))
-		bm := createManager(nil, storage, nil, nil)
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, errors.New("can not be read"))
+		bm := createManager(nil, nil, storage, nil)
 
-		meta, _, err := bm.backups.RestoreBackup(ctx, className, storageName, snapshotID)
+		meta, _, err := bm.backups.RestoreBackup(ctx, storageName, snapshotID, nil, nil, nil)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
-		assert.Contains(t, err.Error(), fmt.Sprintf("checking snapshot %s of index for %s exists on storage %s", snapshotID, className, storageName))
+		assert.Contains(t, err.Error(), fmt.Sprintf("checking snapshot %s exists on storage %s", snapshotID, storageName))
 		assert.IsType(t, backup.ErrUnprocessable{}, err)
 	})
 
 	t.Run("fails when meta does not exist on storage", func(t *testing.T) {
 		storage := &fakeStorage{}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
-		bm := createManager(nil, storage, nil, nil)
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		bm := createManager(nil, nil, storage, nil)
 
-		meta, _, err := bm.backups.RestoreBackup(ctx, className, storageName, snapshotID)
+		meta, _, err := bm.backups.RestoreBackup(ctx, storageName, snapshotID, nil, nil, nil)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
-		assert.Contains(t, err.Error(), fmt.Sprintf("snapshot %s of index for %s does not exist on storage %s", snapshotID, className, storageName))
+		assert.Contains(t, err.Error(), fmt.Sprintf("snapshot %s does not exist on storage %s", snapshotID, storageName))
 		assert.IsType(t, backup.ErrNotFound{}, err)
 	})
 
@@ -311,135 +321,410 @@ func TestBackupManager_RestoreBackup(t *testing.T) {
 
 		for _, status := range statuses {
 			storage := &fakeStorage{}
-			storage.On("GetMeta", ctx, className, snapshotID).Return(&backup.Snapshot{Status: status}, nil)
-			bm := createManager(nil, storage, nil, nil)
+			storage.On("GetMeta", ctx, snapshotID).Return(&backup.Snapshot{Status: status}, nil)
+			bm := createManager(nil, nil, storage, nil)
 
-			meta, _, err := bm.backups.RestoreBackup(ctx, className, storageName, snapshotID)
+			meta, _, err := bm.backups.RestoreBackup(ctx, storageName, snapshotID, nil, nil, nil)
 
 			assert.Nil(t, meta)
 			assert.NotNil(t, err)
-			assert.Contains(t, err.Error(), fmt.Sprintf("snapshot %s of index for %s on storage %s is corrupted", snapshotID, className, storageName))
+			assert.Contains(t, err.Error(), fmt.Sprintf("snapshot %s on storage %s is corrupted", snapshotID, storageName))
 			assert.IsType(t, backup.ErrNotFound{}, err)
 		}
 	})
 
+	t.Run("fails when included class is not part of the snapshot", func(t *testing.T) {
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(successfulSnapshot(), nil)
+		bm := createManager(nil, nil, storage, nil)
+
+		meta, _, err := bm.backups.RestoreBackup(ctx, storageName, snapshotID, []string{"UnknownClass"}, nil, nil)
+
+		assert.Nil(t, meta)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "class UnknownClass not found")
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+	})
+
+	t.Run("fails when index already exists", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{}
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(successfulSnapshot(), nil)
+		bm := createManager(snapshotter, nil, storage, nil)
+
+		meta, _, err := bm.backups.RestoreBackup(ctx, storageName, snapshotID, nil, nil, nil)
+
+		assert.Nil(t, meta)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("can not restore snapshot of existing index for %s", className))
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+	})
+
 	t.Run("fails when snapshot restoration already in progress", func(t *testing.T) {
-		// make sure restore backup takes some time, so the parallel execution has enough time to start before first one finishes
-		storage := &fakeStorage{getMetaStatusSleep: 50 * time.Millisecond, restoreSnapshotSleep: 50 * time.Millisecond}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(&backup.Snapshot{Status: string(backup.CreateSuccess)}, nil)
-		storage.On("GetMeta", ctx, className, snapshotID2).Return(&backup.Snapshot{Status: string(backup.CreateSuccess)}, nil)
-		storage.On("DestinationPath", className, snapshotID).Return(path)
-		storage.On("RestoreSnapshot", mock.Anything, className, snapshotID).Return(&backup.Snapshot{}, nil).Once()
-		bm := createManager(nil, storage, nil, nil)
-
-		wg := sync.WaitGroup{}
-		wg.Add(2)
-
-		go func() {
-			meta, _, err := bm.backups.RestoreBackup(ctx, className, storageName, snapshotID)
-			time.Sleep(75 * time.Millisecond) // enough time to async restore finish
-
-			assert.NotNil(t, meta)
-			assert.Equal(t, backup.RestoreStarted, meta.Status)
-			assert.Equal(t, path, meta.Path)
-			assert.Nil(t, err)
-			wg.Done()
-		}()
-		go func() {
-			time.Sleep(25 * time.Millisecond)
-			meta, _, err := bm.backups.RestoreBackup(ctx, className, storageName, snapshotID2)
-			time.Sleep(75 * time.Millisecond) // enough time to async restore finish
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(successfulSnapshot(), nil)
+		storage.On("DestinationPath", snapshotID).Return(path)
+		bm := createManager(nil, nil, storage, nil)
 
-			assert.Nil(t, meta)
-			assert.NotNil(t, err)
-			assert.Contains(t, err.Error(), fmt.Sprintf("restoration of index for %s already in progress", className))
-			assert.IsType(t, backup.ErrUnprocessable{}, err)
-			wg.Done()
-		}()
+		meta, _, err := bm.backups.RestoreBackup(ctx, storageName, snapshotID, []string{className}, nil, nil)
+		require.Nil(t, err)
+		assert.Equal(t, backup.RestoreStarted, meta.Status)
+
+		meta, _, err = bm.backups.RestoreBackup(ctx, storageName, snapshotID, nil, nil, nil)
+
+		assert.Nil(t, meta)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "already in progress")
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+
+		bm.backups.ReleaseRestore([]string{className})
 
-		wg.Wait()
+		meta, _, err = bm.backups.RestoreBackup(ctx, storageName, snapshotID, nil, nil, nil)
+		require.Nil(t, err)
+		assert.Equal(t, backup.RestoreStarted, meta.Status)
 	})
 
 	t.Run("successfully starts", func(t *testing.T) {
 		storage := &fakeStorage{}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(&backup.Snapshot{Status: string(backup.CreateSuccess)}, nil)
-		storage.On("DestinationPath", className, snapshotID).Return(path)
-		storage.On("RestoreSnapshot", mock.Anything, mock.Anything, mock.Anything).Return(&backup.Snapshot{}, nil).Once()
-		bm := createManager(nil, storage, nil, nil)
+		storage.On("GetMeta", ctx, snapshotID).Return(successfulSnapshot(), nil)
+		storage.On("DestinationPath", snapshotID).Return(path)
+		bm := createManager(nil, nil, storage, nil)
 
-		meta, _, err := bm.backups.RestoreBackup(ctx, className, storageName, snapshotID)
-		time.Sleep(10 * time.Millisecond) // enough time to async restore start
+		meta, snapshot, err := bm.backups.RestoreBackup(ctx, storageName, snapshotID, nil, []string{className}, nil)
 
-		assert.NotNil(t, meta)
+		require.Nil(t, err)
 		assert.Equal(t, backup.RestoreStarted, meta.Status)
 		assert.Equal(t, path, meta.Path)
-		assert.Nil(t, err)
-		storage.AssertExpectations(t) // make sure async restore called
-	})
-
-	t.Run("successfully starts for multiple classes", func(t *testing.T) {
-		// make sure restore backup takes some time, so the parallel execution has enough time to start before first one finishes
-		storage := &fakeStorage{getMetaStatusSleep: 50 * time.Millisecond, restoreSnapshotSleep: 50 * time.Millisecond}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(&backup.Snapshot{Status: string(backup.CreateSuccess)}, nil)
-		storage.On("GetMeta", ctx, className2, snapshotID2).Return(&backup.Snapshot{Status: string(backup.CreateSuccess)}, nil)
-		storage.On("DestinationPath", className, snapshotID).Return(path)
-		storage.On("DestinationPath", className2, snapshotID2).Return(path)
-		storage.On("RestoreSnapshot", mock.Anything, mock.Anything, mock.Anything).Return(&backup.Snapshot{}, nil).Twice()
-		bm := createManager(nil, storage, nil, nil)
-
-		wg := sync.WaitGroup{}
-		wg.Add(2)
-
-		go func() {
-			meta, _, err := bm.backups.RestoreBackup(ctx, className, storageName, snapshotID)
-			time.Sleep(75 * time.Millisecond) // enough time to async restore finish
-
-			assert.NotNil(t, meta)
-			assert.Equal(t, backup.RestoreStarted, meta.Status)
-			assert.Equal(t, path, meta.Path)
-			assert.Nil(t, err)
-			wg.Done()
-		}()
-		go func() {
-			time.Sleep(25 * time.Millisecond)
-			meta, _, err := bm.backups.RestoreBackup(ctx, className2, storageName, snapshotID2)
-			time.Sleep(75 * time.Millisecond) // enough time to async restore finish
-
-			assert.NotNil(t, meta)
-			assert.Equal(t, backup.RestoreStarted, meta.Status)
-			assert.Equal(t, path, meta.Path)
-			assert.Nil(t, err)
-			wg.Done()
-		}()
-
-		wg.Wait()
+		assert.Equal(t, []string{className2}, snapshot.ClassNames())
+	})
+
+	t.Run("successfully starts with renamed class", func(t *testing.T) {
+		snap := successfulSnapshot()
+		for _, classSnap := range snap.Classes {
+			classSnap.Schema = []byte(`{"class":"` + classSnap.Name + `"}`)
+		}
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(snap, nil)
+		storage.On("DestinationPath", snapshotID).Return(path)
+		bm := createManager(nil, nil, storage, nil)
+
+		meta, snapshot, err := bm.backups.RestoreBackup(ctx, storageName, snapshotID,
+			nil, nil, map[string]string{"democlass": "DemoClass_restored"})
+
+		require.Nil(t, err)
+		assert.Equal(t, backup.RestoreStarted, meta.Status)
+		assert.Equal(t, []string{"DemoClass_restored", className2}, snapshot.ClassNames())
+
+		// the restore is tracked under the new name
+		_, _, err = bm.backups.RestoreBackup(ctx, storageName, snapshotID,
+			[]string{className2}, nil, map[string]string{className2: "DemoClass_restored"})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "already in progress")
+	})
+
+	t.Run("fails when renamed class is not restored", func(t *testing.T) {
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(successfulSnapshot(), nil)
+		bm := createManager(nil, nil, storage, nil)
+
+		meta, _, err := bm.backups.RestoreBackup(ctx, storageName, snapshotID,
+			[]string{className2}, nil, map[string]string{className: "DemoClass_restored"})
+
+		assert.Nil(t, meta)
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("class %s to rename is not restored", className))
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
 	})
 }
 
-func TestBackupManager_CreateBackupStatus(t *testing.T) {
+func TestBackupManager_RestoreClassFiles(t *testing.T) {
 	className := "DemoClass"
 	storageName := "DemoStorage"
-	snapshotID := "SnapshotID"
+	snapshotID := "snapshot-id"
 	ctx := context.Background()
-	path := "dst/path"
 
-	t.Run("fails when index does not exist", func(t *testing.T) {
-		bm := createManager(nil, nil, nil, nil)
+	newClassSnapshot := func(t *testing.T) *backup.ClassSnapshot {
+		shardingState, err := json.Marshal(sharding.State{
+			Physical: map[string]sharding.Physical{
+				"shard1": {Name: "shard1", BelongsToNode: "node1"},
+				"shard2": {Name: "shard2", BelongsToNode: "node2"},
+			},
+		})
+		require.Nil(t, err)
+
+		classSnap := backup.NewClassSnapshot(className)
+		classSnap.ShardingState = shardingState
+		classSnap.AddShard("shard1", &backup.ShardSnapshot{
+			Files:    []backup.SnapshotFile{{Class: className, Node: "node1", Shard: "shard1", Path: "democlass_shard1.file"}},
+			Metadata: &backup.ShardMetadata{ShardVersion: []byte("1")},
+		})
+		classSnap.AddShard("shard2", &backup.ShardSnapshot{
+			Files:    []backup.SnapshotFile{{Class: className, Node: "node2", Shard: "shard2", Path: "democlass_shard2.file"}},
+			Metadata: &backup.ShardMetadata{ShardVersion: []byte("2")},
+		})
+		return classSnap
+	}
+
+	t.Run("reads unchanged files of incremental snapshots from the snapshot holding them", func(t *testing.T) {
+		classSnap := newClassSnapshot(t)
+		classSnap.Files[1].Snapshot = "parent-id"
+		storage := &fakeStorage{}
+		storage.On("GetObject", ctx, snapshotID, classSnap.Files[0].Path).
+			Return(io.NopCloser(strings.NewReader("file contents")), nil).Once()
+		storage.On("GetObject", ctx, "parent-id", classSnap.Files[1].Path).
+			Return(io.NopCloser(strings.NewReader("file contents")), nil).Once()
+		restorer := &fakeRestorer{}
+		restorer.On("RestoreBackupFile", ctx, className, mock.Anything, mock.Anything).Return(nil).Twice()
+		restorer.On("RestoreShardMetadata", ctx, className, mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+		bm := createManager(nil, restorer, storage, nil)
 
-		meta, err := bm.CreateBackupStatus(ctx, nil, className, storageName, snapshotID)
+		err := bm.backups.RestoreClassFiles(ctx, storageName, snapshotID, classSnap)
+
+		assert.Nil(t, err)
+		storage.AssertExpectations(t)
+		restorer.AssertExpectations(t)
+	})
+
+	t.Run("restores files of a renamed class as part of the new class", func(t *testing.T) {
+		classSnap := newClassSnapshot(t)
+		classSnap.Name = "DemoClass_restored"
+		storage := &fakeStorage{}
+		for _, file := range classSnap.Files {
+			storage.On("GetObject", ctx, snapshotID, file.Path).
+				Return(io.NopCloser(strings.NewReader("file contents")), nil).Once()
+		}
+		restorer := &fakeRestorer{}
+		for _, file := range classSnap.Files {
+			// files are read under the name they were backed up with
+			restorer.On("RestoreBackupFile", ctx, "DemoClass_restored", file, mock.Anything).Return(nil).Once()
+		}
+		restorer.On("RestoreShardMetadata", ctx, "DemoClass_restored", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+		bm := createManager(nil, restorer, storage, nil)
+
+		err := bm.backups.RestoreClassFiles(ctx, storageName, snapshotID, classSnap)
+
+		assert.Nil(t, err)
+		storage.AssertExpectations(t)
+		restorer.AssertExpectations(t)
+	})
+
+	t.Run("restores files and metadata to every replica", func(t *testing.T) {
+		classSnap := newClassSnapshot(t)
+		shardingState, err := json.Marshal(sharding.State{
+			Physical: map[string]sharding.Physical{
+				"shard1": {Name: "shard1", BelongsToNode: "node1", ReplicaNodes: []string{"node2"}},
+				"shard2": {Name: "shard2", BelongsToNode: "node2", ReplicaNodes: []string{"node1"}},
+			},
+		})
+		require.Nil(t, err)
+		classSnap.ShardingState = shardingState
+
+		storage := &fakeStorage{}
+		for _, file := range classSnap.Files {
+			storage.On("GetObject", ctx, snapshotID, file.Path).
+				Return(io.NopCloser(strings.NewReader("file contents")), nil).Twice()
+		}
+		restorer := &fakeRestorer{}
+		for _, file := range classSnap.Files {
+			for _, node := range []string{"node1", "node2"} {
+				replica := file
+				replica.Node = node
+				restorer.On("RestoreBackupFile", ctx, className, replica, mock.Anything).Return(nil).Once()
+			}
+		}
+		for _, shardName := range []string{"shard1", "shard2"} {
+			for _, node := range []string{"node1", "node2"} {
+				restorer.On("RestoreShardMetadata", ctx, className, shardName, node, mock.Anything).Return(nil).Once()
+			}
+		}
+		bm := createManager(nil, restorer, storage, nil)
+
+		err = bm.backups.RestoreClassFiles(ctx, storageName, snapshotID, classSnap)
+
+		assert.Nil(t, err)
+		storage.AssertExpectations(t)
+		restorer.AssertExpectations(t)
+	})
+
+	t.Run("fails when file can not be read from storage", func(t *testing.T) {
+		classSnap := newClassSnapshot(t)
+		storage := &fakeStorage{}
+		storage.On("GetObject", ctx, snapshotID, classSnap.Files[0].Path).Return(nil, errors.New("storage read error"))
+		bm := createManager(nil, &fakeRestorer{}, storage, nil)
+
+		err := bm.backups.RestoreClassFiles(ctx, storageName, snapshotID, classSnap)
 
-		assert.Nil(t, meta)
 		assert.NotNil(t, err)
-		assert.Contains(t, err.Error(), fmt.Sprintf("can't fetch snapshot creation status of non-existing index for %s", className))
-		assert.IsType(t, backup.ErrNotFound{}, err)
+		assert.Contains(t, err.Error(), "storage read error")
+	})
+
+	t.Run("fails when file can not be restored", func(t *testing.T) {
+		classSnap := newClassSnapshot(t)
+		storage := &fakeStorage{}
+		storage.On("GetObject", ctx, snapshotID, classSnap.Files[0].Path).
+			Return(io.NopCloser(strings.NewReader("file contents")), nil)
+		restorer := &fakeRestorer{}
+		restorer.On("RestoreBackupFile", ctx, className, classSnap.Files[0], mock.Anything).Return(errors.New("restorer write error"))
+		bm := createManager(nil, restorer, storage, nil)
+
+		err := bm.backups.RestoreClassFiles(ctx, storageName, snapshotID, classSnap)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "restorer write error")
 	})
 
+	t.Run("successfully restores files and shard metadata to their nodes", func(t *testing.T) {
+		classSnap := newClassSnapshot(t)
+		storage := &fakeStorage{}
+		restorer := &fakeRestorer{}
+		for _, file := range classSnap.Files {
+			storage.On("GetObject", ctx, snapshotID, file.Path).
+				Return(io.NopCloser(strings.NewReader("file contents")), nil).Once()
+			restorer.On("RestoreBackupFile", ctx, className, file, mock.Anything).Return(nil).Once()
+		}
+		restorer.On("RestoreShardMetadata", ctx, className, "shard1", "node1",
+			classSnap.ShardMetadata["shard1"]).Return(nil).Once()
+		restorer.On("RestoreShardMetadata", ctx, className, "shard2", "node2",
+			classSnap.ShardMetadata["shard2"]).Return(nil).Once()
+		bm := createManager(nil, restorer, storage, nil)
+
+		err := bm.backups.RestoreClassFiles(ctx, storageName, snapshotID, classSnap)
+
+		assert.Nil(t, err)
+		storage.AssertExpectations(t)
+		restorer.AssertExpectations(t)
+	})
+
+	sum := sha256.Sum256([]byte("file contents"))
+	checksum := hex.EncodeToString(sum[:])
+	consume := func(args mock.Arguments) {
+		io.ReadAll(args.Get(3).(io.Reader))
+	}
+
+	// newChunk compresses the files of the class snapshot into a single
+	// chunk, all of them having the same contents
+	newChunk := func(t *testing.T, files []backup.SnapshotFile, compression backup.Compression) []byte {
+		source := &fakeSnapshotter{}
+		for _, file := range files {
+			source.On("ReadBackupFile", ctx, file).
+				Return(io.NopCloser(strings.NewReader("file contents")), nil)
+		}
+		var buf bytes.Buffer
+		require.Nil(t, writeChunk(ctx, &buf, source, files, compression))
+		return buf.Bytes()
+	}
+
+	t.Run("verifies checksums of uncompressed files", func(t *testing.T) {
+		classSnap := newClassSnapshot(t)
+		classSnap.Files[0].Checksum = checksum
+		storage := &fakeStorage{}
+		storage.On("GetObject", ctx, snapshotID, classSnap.Files[0].Path).
+			Return(io.NopCloser(strings.NewReader("file c0ntents")), nil)
+		restorer := &fakeRestorer{}
+		restorer.On("RestoreBackupFile", ctx, className, classSnap.Files[0], mock.Anything).Return(nil).Run(consume)
+		bm := createManager(nil, restorer, storage, nil)
+
+		err := bm.backups.RestoreClassFiles(ctx, storageName, snapshotID, classSnap)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "checksum mismatch")
+		restorer.AssertNotCalled(t, "RestoreBackupFile", mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything)
+		restorer.AssertNotCalled(t, "RestoreShardMetadata", mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("successfully restores files from a compressed chunk", func(t *testing.T) {
+		classSnap := newClassSnapshot(t)
+		key := chunkKey(className, 0, backup.CompressionZstd)
+		for i := range classSnap.Files {
+			classSnap.Files[i].Checksum = checksum
+			classSnap.Files[i].Size = int64(len("file contents"))
+		}
+		chunk := newChunk(t, classSnap.Files, backup.CompressionZstd)
+		for i := range classSnap.Files {
+			classSnap.Files[i].Chunk = key
+			classSnap.Files[i].Compression = backup.CompressionZstd
+		}
+		storage := &fakeStorage{}
+		storage.On("GetObject", ctx, snapshotID, key).
+			Return(io.NopCloser(bytes.NewReader(chunk)), nil).Once()
+		restorer := &fakeRestorer{}
+		for _, file := range classSnap.Files {
+			restorer.On("RestoreBackupFile", ctx, className, file, mock.Anything).Return(nil).Run(consume).Once()
+		}
+		restorer.On("RestoreShardMetadata", ctx, className, mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+		bm := createManager(nil, restorer, storage, nil)
+
+		err := bm.backups.RestoreClassFiles(ctx, storageName, snapshotID, classSnap)
+
+		assert.Nil(t, err)
+		storage.AssertExpectations(t)
+		restorer.AssertExpectations(t)
+	})
+
+	t.Run("fails when a compressed file is corrupted", func(t *testing.T) {
+		classSnap := newClassSnapshot(t)
+		key := chunkKey(className, 0, backup.CompressionGzip)
+		classSnap.Files = classSnap.Files[:1]
+		classSnap.Files[0].Checksum = "corrupted"
+		classSnap.Files[0].Size = int64(len("file contents"))
+		chunk := newChunk(t, classSnap.Files, backup.CompressionGzip)
+		classSnap.Files[0].Chunk = key
+		classSnap.Files[0].Compression = backup.CompressionGzip
+		storage := &fakeStorage{}
+		storage.On("GetObject", ctx, snapshotID, key).
+			Return(io.NopCloser(bytes.NewReader(chunk)), nil)
+		restorer := &fakeRestorer{}
+		restorer.On("RestoreBackupFile", ctx, className, classSnap.Files[0], mock.Anything).Return(nil).Run(consume)
+		bm := createManager(nil, restorer, storage, nil)
+
+		err := bm.backups.RestoreClassFiles(ctx, storageName, snapshotID, classSnap)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "checksum mismatch")
+		restorer.AssertNotCalled(t, "RestoreBackupFile", mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails when a chunk misses files", func(t *testing.T) {
+		classSnap := newClassSnapshot(t)
+		key := chunkKey(className, 0, backup.CompressionGzip)
+		for i := range classSnap.Files {
+			classSnap.Files[i].Size = int64(len("file contents"))
+		}
+		chunk := newChunk(t, classSnap.Files[:1], backup.CompressionGzip)
+		for i := range classSnap.Files {
+			classSnap.Files[i].Chunk = key
+			classSnap.Files[i].Compression = backup.CompressionGzip
+		}
+		storage := &fakeStorage{}
+		storage.On("GetObject", ctx, snapshotID, key).
+			Return(io.NopCloser(bytes.NewReader(chunk)), nil)
+		restorer := &fakeRestorer{}
+		restorer.On("RestoreBackupFile", ctx, className, mock.Anything, mock.Anything).Return(nil).Run(consume)
+		bm := createManager(nil, restorer, storage, nil)
+
+		err := bm.backups.RestoreClassFiles(ctx, storageName, snapshotID, classSnap)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), classSnap.Files[1].Path)
+	})
+}
+
+func TestBackupManager_CreateBackupStatus(t *testing.T) {
+	className := "DemoClass"
+	storageName := "DemoStorage"
+	snapshotID := "snapshot-id"
+	ctx := context.Background()
+	path := "dst/path"
+
 	t.Run("fails when storage not registered", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
 		storageError := errors.New("I do not exist")
-		bm := createManager(snapshotter, nil, storageError, nil)
+		bm := createManager(snapshotter, nil, nil, storageError)
 
-		meta, err := bm.CreateBackupStatus(ctx, nil, className, storageName, snapshotID)
+		meta, err := bm.CreateBackupStatus(ctx, nil, storageName, snapshotID)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
@@ -450,10 +735,10 @@ func TestBackupManager_CreateBackupStatus(t *testing.T) {
 	t.Run("fails when error reading meta from storage", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
 		storage := &fakeStorage{}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(nil, errors.New("any type of error"))
-		bm := createManager(snapshotter, storage, nil, nil)
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, errors.New("any type of error"))
+		bm := createManager(snapshotter, nil, storage, nil)
 
-		meta, err := bm.CreateBackupStatus(ctx, nil, className, storageName, snapshotID)
+		meta, err := bm.CreateBackupStatus(ctx, nil, storageName, snapshotID)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
@@ -463,10 +748,10 @@ func TestBackupManager_CreateBackupStatus(t *testing.T) {
 	t.Run("fails when meta does not exist on storage", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
 		storage := &fakeStorage{}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
-		bm := createManager(snapshotter, storage, nil, nil)
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		bm := createManager(snapshotter, nil, storage, nil)
 
-		meta, err := bm.CreateBackupStatus(ctx, nil, className, storageName, snapshotID)
+		meta, err := bm.CreateBackupStatus(ctx, nil, storageName, snapshotID)
 
 		assert.Nil(t, meta)
 		assert.NotNil(t, err)
@@ -476,16 +761,19 @@ func TestBackupManager_CreateBackupStatus(t *testing.T) {
 
 	t.Run("successfully gets status", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
+		snap := backup.NewSnapshot(snapshotID, []string{className}, time.Now())
+		snap.Status = "SOME_STATUS"
 		storage := &fakeStorage{}
-		storage.On("GetMeta", ctx, className, snapshotID).Return(&backup.Snapshot{Status: "SOME_STATUS"}, nil)
-		storage.On("DestinationPath", className, snapshotID).Return(path)
-		bm := createManager(snapshotter, storage, nil, nil)
+		storage.On("GetMeta", ctx, snapshotID).Return(snap, nil)
+		storage.On("DestinationPath", snapshotID).Return(path)
+		bm := createManager(snapshotter, nil, storage, nil)
 
-		meta, err := bm.CreateBackupStatus(ctx, nil, className, storageName, snapshotID)
+		meta, err := bm.CreateBackupStatus(ctx, nil, storageName, snapshotID)
 
 		assert.NotNil(t, meta)
 		assert.Equal(t, "SOME_STATUS", *meta.Status)
 		assert.Equal(t, snapshotID, meta.ID)
+		assert.Equal(t, []string{className}, meta.Classes)
 		assert.Equal(t, storageName, meta.StorageName)
 		assert.Equal(t, path, meta.Path)
 		assert.Nil(t, err)
@@ -494,26 +782,333 @@ func TestBackupManager_CreateBackupStatus(t *testing.T) {
 
 func TestBackupManager_DestinationPath(t *testing.T) {
 	storageError := errors.New("I do not exist")
-	sm := createManager(nil, nil, storageError, nil)
-	path, err := sm.backups.DestinationPath("storageName", "className", "ID")
+	sm := createManager(nil, nil, nil, storageError)
+	path, err := sm.backups.DestinationPath("storageName", "ID")
 	require.NotNil(t, err)
 	assert.Equal(t, "", path)
 
 	storage := &fakeStorage{}
-	storage.On("DestinationPath", "className", "ID").Return(path)
-	sm = createManager(nil, storage, nil, nil)
-	path2, err := sm.backups.DestinationPath("storageName", "className", "ID")
+	storage.On("DestinationPath", "ID").Return(path)
+	sm = createManager(nil, nil, storage, nil)
+	path2, err := sm.backups.DestinationPath("storageName", "ID")
 	require.Nil(t, err)
 	assert.Equal(t, path, path2)
 }
 
-func createManager(snapshotter Sourcer, storage modulecapabilities.SnapshotStorage,
-	storageErr error, shardingState *sharding.State,
+func TestBackupManager_CancelBackup(t *testing.T) {
+	className := "DemoClass"
+	storageName := "DemoStorage"
+	snapshotID := "snapshot-id"
+	ctx := context.Background()
+
+	t.Run("fails when storage not registered", func(t *testing.T) {
+		storageError := errors.New("I do not exist")
+		bm := createManager(nil, nil, nil, storageError)
+
+		err := bm.CancelBackup(ctx, nil, storageName, snapshotID)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("find storage by name %s", storageName))
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+	})
+
+	t.Run("fails when nothing in progress", func(t *testing.T) {
+		storage := &fakeStorage{}
+		storage.On("DestinationPath", snapshotID).Return("dst/path")
+		bm := createManager(nil, nil, storage, nil)
+
+		err := bm.CancelBackup(ctx, nil, storageName, snapshotID)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "no creation or restoration of backup")
+		assert.IsType(t, backup.ErrNotFound{}, err)
+	})
+
+	t.Run("cancels snapshot creation in progress", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{}
+		// block until canceled
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(nil, context.Canceled).
+			Run(func(args mock.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+			})
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		storage.On("InitSnapshot", mock.Anything, snapshotID, []string{className}).
+			Return(backup.NewSnapshot(snapshotID, []string{className}, time.Now()), nil)
+		storage.On("DestinationPath", snapshotID).Return("dst/path")
+		storage.On("SetMetaStatus", mock.Anything, snapshotID, string(backup.CreateCanceled)).Return(nil).Once()
+		bm := createManager(snapshotter, nil, storage, nil)
+
+		_, err := bm.CreateBackup(ctx, nil, storageName, snapshotID, "", "", []string{className}, nil)
+		require.Nil(t, err)
+
+		err = bm.CancelBackup(ctx, nil, storageName, snapshotID)
+		require.Nil(t, err)
+
+		time.Sleep(10 * time.Millisecond) // enough time to async create finish
+		storage.AssertExpectations(t)
+		// the classes are released again
+		assert.True(t, bm.backups.setCreateInProgress([]string{className}, true))
+		assert.False(t, bm.backups.CancelCreate(storageName, snapshotID))
+	})
+
+	t.Run("cancels restoration in progress", func(t *testing.T) {
+		snapshot := backup.NewSnapshot(snapshotID, []string{className}, time.Now())
+		snapshot.Status = string(backup.CreateSuccess)
+		snapshot.Classes[0].Files = []backup.SnapshotFile{{Class: className, Shard: "shard", Path: "democlass_shard.file"}}
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(snapshot, nil)
+		storage.On("DestinationPath", snapshotID).Return("dst/path")
+		// block until canceled
+		storage.On("GetObject", mock.Anything, snapshotID, "democlass_shard.file").Return(nil, context.Canceled).
+			Run(func(args mock.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+			})
+		bm := createManager(nil, nil, storage, nil)
+
+		_, err := bm.RestoreBackup(ctx, nil, storageName, snapshotID, nil, nil, nil)
+		require.Nil(t, err)
+
+		err = bm.CancelBackup(ctx, nil, storageName, snapshotID)
+		require.Nil(t, err)
+
+		time.Sleep(10 * time.Millisecond) // enough time to async restore finish
+		meta, err := bm.RestoreBackupStatus(ctx, nil, storageName, snapshotID)
+		require.Nil(t, err)
+		assert.Equal(t, string(backup.RestoreCanceled), *meta.Status)
+		// the classes are released again
+		assert.True(t, bm.backups.setRestoreInProgress([]string{className}, true))
+	})
+}
+
+func TestBackupManager_ListBackups(t *testing.T) {
+	storageName := "DemoStorage"
+	ctx := context.Background()
+
+	t.Run("fails when storage not registered", func(t *testing.T) {
+		storageError := errors.New("I do not exist")
+		bm := createManager(nil, nil, nil, storageError)
+
+		items, err := bm.ListBackups(ctx, nil, storageName)
+
+		assert.Nil(t, items)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("find storage by name %s", storageName))
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+	})
+
+	t.Run("fails when listing snapshots on storage fails", func(t *testing.T) {
+		storage := &fakeStorage{}
+		storage.On("ListSnapshots", ctx).Return(nil, errors.New("can not list"))
+		bm := createManager(nil, nil, storage, nil)
+
+		items, err := bm.ListBackups(ctx, nil, storageName)
+
+		assert.Nil(t, items)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "can not list")
+	})
+
+	t.Run("successfully lists snapshots oldest first", func(t *testing.T) {
+		now := time.Now()
+		newer := backup.NewSnapshot("newer", []string{"DemoClass"}, now)
+		newer.ParentID = "older"
+		newer.Status = string(backup.CreateSuccess)
+		newer.Size = 10
+		older := backup.NewSnapshot("older", []string{"DemoClass", "DemoClass2"}, now.Add(-time.Hour))
+		older.Status = string(backup.CreateFailed)
+		older.Error = "something went wrong"
+		older.Size = 20
+		storage := &fakeStorage{}
+		storage.On("ListSnapshots", ctx).Return([]*backup.Snapshot{newer, older}, nil)
+		bm := createManager(nil, nil, storage, nil)
+
+		items, err := bm.ListBackups(ctx, nil, storageName)
+
+		require.Nil(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, "older", items[0].ID)
+		assert.Equal(t, []string{"DemoClass", "DemoClass2"}, items[0].Classes)
+		assert.Equal(t, string(backup.CreateFailed), *items[0].Status)
+		assert.Equal(t, "something went wrong", items[0].Error)
+		assert.Equal(t, int64(20), items[0].Size)
+		assert.Equal(t, "newer", items[1].ID)
+		assert.Equal(t, "older", items[1].Parent)
+		assert.Equal(t, string(backup.CreateSuccess), *items[1].Status)
+		assert.Equal(t, int64(10), items[1].Size)
+	})
+}
+
+func TestBackupManager_DeleteBackup(t *testing.T) {
+	storageName := "DemoStorage"
+	snapshotID := "snapshot-id"
+	ctx := context.Background()
+
+	newSnapshot := func(id string) *backup.Snapshot {
+		snap := backup.NewSnapshot(id, []string{"DemoClass"}, time.Now())
+		snap.Status = string(backup.CreateSuccess)
+		return snap
+	}
+
+	t.Run("fails when storage not registered", func(t *testing.T) {
+		storageError := errors.New("I do not exist")
+		bm := createManager(nil, nil, nil, storageError)
+
+		err := bm.DeleteBackup(ctx, nil, storageName, snapshotID)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("find storage by name %s", storageName))
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+	})
+
+	t.Run("fails when snapshot creation in progress", func(t *testing.T) {
+		storage := &fakeStorage{}
+		bm := createManager(nil, nil, storage, nil)
+		bm.backups.setCreateCancel(storageName+"-"+snapshotID, func() {})
+
+		err := bm.DeleteBackup(ctx, nil, storageName, snapshotID)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "cancel it first")
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+	})
+
+	t.Run("fails when snapshot restoration in progress", func(t *testing.T) {
+		storage := &fakeStorage{}
+		bm := createManager(nil, nil, storage, nil)
+		bm.restoreCancels.Store(storageName+"-"+snapshotID, context.CancelFunc(func() {}))
+
+		err := bm.DeleteBackup(ctx, nil, storageName, snapshotID)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "is being restored")
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+	})
+
+	t.Run("fails when snapshot does not exist", func(t *testing.T) {
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(nil, backup.NewErrNotFound(errors.New("not found")))
+		bm := createManager(nil, nil, storage, nil)
+
+		err := bm.DeleteBackup(ctx, nil, storageName, snapshotID)
+
+		assert.NotNil(t, err)
+		assert.IsType(t, backup.ErrNotFound{}, err)
+	})
+
+	t.Run("fails when files of the snapshot are referenced", func(t *testing.T) {
+		child := newSnapshot("child-id")
+		child.Classes[0].Files = []backup.SnapshotFile{{Class: "DemoClass", Path: "file", Snapshot: snapshotID}}
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(newSnapshot(snapshotID), nil)
+		storage.On("ListSnapshots", ctx).Return([]*backup.Snapshot{newSnapshot(snapshotID), child}, nil)
+		bm := createManager(nil, nil, storage, nil)
+
+		err := bm.DeleteBackup(ctx, nil, storageName, snapshotID)
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "referenced by incremental snapshot child-id")
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+		storage.AssertNotCalled(t, "DeleteSnapshot", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails when snapshot is the parent of another one", func(t *testing.T) {
+		child := newSnapshot("child-id")
+		child.ParentID = snapshotID
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(newSnapshot(snapshotID), nil)
+		storage.On("ListSnapshots", ctx).Return([]*backup.Snapshot{child, newSnapshot(snapshotID)}, nil)
+		bm := createManager(nil, nil, storage, nil)
+
+		err := bm.DeleteBackup(ctx, nil, storageName, snapshotID)
+
+		assert.NotNil(t, err)
+		assert.IsType(t, backup.ErrUnprocessable{}, err)
+		storage.AssertNotCalled(t, "DeleteSnapshot", mock.Anything, mock.Anything)
+	})
+
+	t.Run("successfully deletes", func(t *testing.T) {
+		storage := &fakeStorage{}
+		storage.On("GetMeta", ctx, snapshotID).Return(newSnapshot(snapshotID), nil)
+		storage.On("ListSnapshots", ctx).Return([]*backup.Snapshot{newSnapshot(snapshotID), newSnapshot("other-id")}, nil)
+		storage.On("DeleteSnapshot", ctx, snapshotID).Return(nil).Once()
+		bm := createManager(nil, nil, storage, nil)
+
+		err := bm.DeleteBackup(ctx, nil, storageName, snapshotID)
+
+		assert.Nil(t, err)
+		storage.AssertExpectations(t)
+	})
+}
+
+func TestSelectClasses(t *testing.T) {
+	available := []string{"DemoClass", "DemoClass2", "OtherClass"}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+		err      string
+	}{
+		{
+			name:     "all classes by default",
+			expected: available,
+		},
+		{
+			name:     "included classes only",
+			include:  []string{"OtherClass", "DemoClass"},
+			expected: []string{"OtherClass", "DemoClass"},
+		},
+		{
+			name:     "included classes are matched case-insensitively",
+			include:  []string{"democlass2"},
+			expected: []string{"DemoClass2"},
+		},
+		{
+			name:     "all but excluded classes",
+			exclude:  []string{"democlass"},
+			expected: []string{"DemoClass2", "OtherClass"},
+		},
+		{
+			name:    "include and exclude",
+			include: []string{"DemoClass"},
+			exclude: []string{"OtherClass"},
+			err:     "include and exclude are mutually exclusive",
+		},
+		{
+			name:    "unknown included class",
+			include: []string{"UnknownClass"},
+			err:     "class UnknownClass not found",
+		},
+		{
+			name:    "all classes excluded",
+			exclude: available,
+			err:     "no classes selected",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			classes, err := selectClasses(available, test.include, test.exclude)
+			if test.err != "" {
+				require.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, classes)
+		})
+	}
+}
+
+func createManager(snapshotter Sourcer, restorer Restorer,
+	storage modulecapabilities.SnapshotStorage, storageErr error,
 ) *Manager {
 	snapshotters := &fakeSourceFactory{snapshotter}
 	storages := &fakeBackupStorageProvider{storage, storageErr}
-	shardingStateFunc := func(className string) *sharding.State { return shardingState }
+	schema := &fakeSchemaManger{classes: []string{"DemoClass", "DemoClass2"}}
 
 	logger, _ := test.NewNullLogger()
-	return NewManager(logger, &fakeAuthorizer{}, &fakeSchemaManger{}, snapshotters, storages, shardingStateFunc)
+	return NewManager(logger, &fakeAuthorizer{}, schema, snapshotters, restorer, storages)
 }
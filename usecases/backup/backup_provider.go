@@ -13,12 +13,16 @@ package backup
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/errorcompounder"
 	"github.com/semi-technologies/weaviate/entities/modulecapabilities"
+	"github.com/semi-technologies/weaviate/usecases/config"
+	"github.com/semi-technologies/weaviate/usecases/monitoring"
 )
 
 // TODO adjust or make configurable
@@ -29,17 +33,35 @@ const (
 	metaTimeout    = 5 * time.Second
 )
 
+// defaultChunkSize is the amount of uncompressed data after which a new
+// chunk is started when the snapshot is compressed
+const defaultChunkSize = 512 << 20
+
 type backupProvider struct {
-	snapshotter Sourcer
+	sources     map[string]Sourcer // keyed by class name
 	storage     modulecapabilities.SnapshotStorage
-	className   string
+	storageName string
 	snapshotID  string
+	classes     []string
+	parent      *backup.Snapshot // nil unless the snapshot is incremental
+	compression backup.Compression
+	chunkSize   int64
 }
 
-func newBackupProvider(snapshotter Sourcer, storage modulecapabilities.SnapshotStorage,
-	className, snapshotID string,
+func newBackupProvider(sources map[string]Sourcer, storage modulecapabilities.SnapshotStorage,
+	storageName, snapshotID string, classes []string, parent *backup.Snapshot,
+	compression backup.Compression,
 ) *backupProvider {
-	return &backupProvider{snapshotter, storage, className, snapshotID}
+	return &backupProvider{
+		sources:     sources,
+		storage:     storage,
+		storageName: storageName,
+		snapshotID:  snapshotID,
+		classes:     classes,
+		parent:      parent,
+		compression: compression,
+		chunkSize:   defaultChunkSize,
+	}
 }
 
 func (sp *backupProvider) start(ctx context.Context) (*backup.Snapshot, error) {
@@ -50,35 +72,57 @@ func (sp *backupProvider) start(ctx context.Context) (*backup.Snapshot, error) {
 	return snapshot, nil
 }
 
+// backup creates and stores the snapshots of all classes. Canceling ctx
+// aborts the creation and the transfer, the snapshot is then marked as
+// canceled rather than failed.
 func (sp *backupProvider) backup(ctx context.Context, snapshot *backup.Snapshot) error {
 	var ctxCreate, ctxStore, ctxRelease context.Context
 	var cancelCreate, cancelStore, cancelRelease context.CancelFunc
 
-	ctxCreate, cancelCreate = context.WithTimeout(context.Background(), createTimeout)
+	// all classes are snapshotted before any file is copied, so that the
+	// backup reflects a single point in time across all of them
+	ctxCreate, cancelCreate = context.WithTimeout(ctx, createTimeout)
 	defer cancelCreate()
-	snapshot, err := sp.snapshotter.CreateBackup(ctxCreate, snapshot)
+	created, err := sp.createClassSnapshots(ctxCreate)
 	if err != nil {
-		return sp.setMetaFailed(errors.Wrap(err, "create snapshot"))
+		return sp.setMetaFailed(ctx, errors.Wrap(err, "create snapshot"))
 	}
 
-	if err := sp.setMetaStatus(backup.CreateTransferring); err != nil {
-		return err
+	snapshot.Classes = created
+	if sp.parent != nil {
+		snapshot.ParentID = sp.parent.ID
+		referenceUnchangedFiles(snapshot, sp.parent)
+	}
+	snapshot.ServerVersion = config.ServerVersion
+	snapshot.Compression = sp.compression
+	snapshot.Status = string(backup.CreateTransferring)
+	if err := sp.putMeta(snapshot); err != nil {
+		return sp.releaseOnFailure(ctx, snapshot.ClassNames(),
+			errors.Wrapf(err, "update snapshot meta to %s", backup.CreateTransferring))
 	}
 
-	ctxStore, cancelStore = context.WithTimeout(context.Background(), storeTimeout)
+	ctxStore, cancelStore = context.WithTimeout(ctx, storeTimeout)
 	defer cancelStore()
-	if err := sp.storage.StoreSnapshot(ctxStore, snapshot); err != nil {
-		return sp.setMetaFailed(errors.Wrap(err, "store snapshot"))
+	for _, classSnap := range snapshot.Classes {
+		size, err := sp.storeClass(ctxStore, classSnap)
+		if err != nil {
+			return sp.releaseOnFailure(ctx, snapshot.ClassNames(),
+				errors.Wrap(err, "store snapshot"))
+		}
+		snapshot.Size += size
 	}
 
-	if err := sp.setMetaStatus(backup.CreateTransferred); err != nil {
-		return err
+	snapshot.Status = string(backup.CreateTransferred)
+	if err := sp.putMeta(snapshot); err != nil {
+		return errors.Wrapf(err, "update snapshot meta to %s", backup.CreateTransferred)
 	}
 
+	// the classes must be released even if the snapshot got canceled in
+	// the meantime
 	ctxRelease, cancelRelease = context.WithTimeout(context.Background(), releaseTimeout)
 	defer cancelRelease()
-	if err := sp.snapshotter.ReleaseBackup(ctxRelease, sp.snapshotID); err != nil {
-		return sp.setMetaFailed(errors.Wrap(err, "release snapshot"))
+	if err := sp.release(ctxRelease, snapshot.ClassNames()); err != nil {
+		return sp.setMetaFailed(ctx, errors.Wrap(err, "release snapshot"))
 	}
 
 	if err := sp.setMetaStatus(backup.CreateSuccess); err != nil {
@@ -88,11 +132,211 @@ func (sp *backupProvider) backup(ctx context.Context, snapshot *backup.Snapshot)
 	return nil
 }
 
-func (sp *backupProvider) setMetaFailed(err error) error {
+// createClassSnapshots snapshots all classes in order. If any of them fails,
+// the ones already created are released again.
+func (sp *backupProvider) createClassSnapshots(ctx context.Context) ([]*backup.ClassSnapshot, error) {
+	created := make([]*backup.ClassSnapshot, 0, len(sp.classes))
+	for _, className := range sp.classes {
+		classSnap, err := sp.sources[className].CreateBackup(ctx, sp.snapshotID)
+		if err != nil {
+			err = errors.Wrapf(err, "class %s", className)
+			return nil, sp.releaseCreated(created, err)
+		}
+		created = append(created, classSnap)
+	}
+
+	return created, nil
+}
+
+func (sp *backupProvider) releaseCreated(created []*backup.ClassSnapshot, err error) error {
+	if len(created) == 0 {
+		return err
+	}
+
+	names := make([]string, len(created))
+	for i, classSnap := range created {
+		names[i] = classSnap.Name
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	ec := &errorcompounder.ErrorCompounder{}
+	ec.Add(err)
+	ec.Add(sp.release(ctx, names))
+	return ec.ToError()
+}
+
+// storeClass uploads all files of the class which are not referenced from
+// an earlier snapshot and returns the number of bytes uploaded. Compressed
+// files are streamed into chunks, which are recorded on the files.
+func (sp *backupProvider) storeClass(ctx context.Context, classSnap *backup.ClassSnapshot) (int64, error) {
+	timer := prometheus.NewTimer(monitoring.GetMetrics().SnapshotStoreDurations.
+		WithLabelValues(sp.storageName, classSnap.Name))
+	defer timer.ObserveDuration()
+
+	var pending []int
+	for i, file := range classSnap.Files {
+		// files unchanged since the parent snapshot are already stored
+		if file.Snapshot == "" {
+			pending = append(pending, i)
+		}
+	}
+
+	var size int64
+	source := sp.sources[classSnap.Name]
+	if sp.compression == backup.CompressionNone {
+		for _, i := range pending {
+			n, err := sp.storeFile(ctx, source, classSnap.Files[i])
+			if err != nil {
+				return size, err
+			}
+			size += n
+		}
+		return size, nil
+	}
+
+	for n, chunk := range splitChunks(classSnap.Files, pending, sp.chunkSize) {
+		key := chunkKey(classSnap.Name, n, sp.compression)
+		files := make([]backup.SnapshotFile, len(chunk))
+		for j, i := range chunk {
+			files[j] = classSnap.Files[i]
+		}
+
+		written, err := sp.storeChunk(ctx, source, key, files)
+		if err != nil {
+			return size, err
+		}
+		size += written
+
+		for _, i := range chunk {
+			classSnap.Files[i].Chunk = key
+			classSnap.Files[i].Compression = sp.compression
+		}
+	}
+
+	return size, nil
+}
+
+// storeChunk streams the files into a compressed chunk while it is being
+// uploaded and returns the compressed size of the chunk
+func (sp *backupProvider) storeChunk(ctx context.Context, source Sourcer,
+	key string, files []backup.SnapshotFile,
+) (int64, error) {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(writeChunk(ctx, pw, source, files, sp.compression))
+	}()
+
+	counter := &countingReader{r: pr}
+	err := sp.storage.PutObject(ctx, sp.snapshotID, key, counter)
+	// unblocks the writer in case the storage did not read the whole chunk
+	pr.CloseWithError(errors.New("chunk upload finished"))
+	<-done
+	if err != nil {
+		return counter.n, errors.Wrapf(err, "put chunk %s", key)
+	}
+
+	monitoring.GetMetrics().SnapshotStoreDataTransferred.
+		WithLabelValues(sp.storageName, files[0].Class).Add(float64(counter.n))
+	return counter.n, nil
+}
+
+func (sp *backupProvider) storeFile(ctx context.Context, source Sourcer,
+	file backup.SnapshotFile,
+) (int64, error) {
+	r, err := source.ReadBackupFile(ctx, file)
+	if err != nil {
+		return 0, errors.Wrapf(err, "read file %s", file.Path)
+	}
+	defer r.Close()
+
+	counter := &countingReader{r: r}
+	if err := sp.storage.PutObject(ctx, sp.snapshotID, file.Path, counter); err != nil {
+		return counter.n, errors.Wrapf(err, "put file %s", file.Path)
+	}
+
+	monitoring.GetMetrics().SnapshotStoreDataTransferred.
+		WithLabelValues(sp.storageName, file.Class).Add(float64(counter.n))
+	return counter.n, nil
+}
+
+// referenceUnchangedFiles marks all files of the snapshot, which did not
+// change since the parent snapshot, as stored in the snapshot holding their
+// contents. This is not necessarily the parent itself, but any of its
+// ancestors, so a restore never has to walk the chain of snapshots. Files
+// stored in a chunk keep referring to it, whatever its compression.
+func referenceUnchangedFiles(snapshot, parent *backup.Snapshot) {
+	// the node is deliberately not part of the key, contents stored for a
+	// shard which has moved to another node since can still be reused
+	type fileKey struct {
+		class, shard, path, checksum string
+	}
+
+	stored := make(map[fileKey]backup.SnapshotFile)
+	for _, classSnap := range parent.Classes {
+		for _, file := range classSnap.Files {
+			if file.Checksum == "" {
+				continue
+			}
+			key := fileKey{file.Class, file.Shard, file.Path, file.Checksum}
+			stored[key] = file
+		}
+	}
+
+	for _, classSnap := range snapshot.Classes {
+		for i, file := range classSnap.Files {
+			key := fileKey{file.Class, file.Shard, file.Path, file.Checksum}
+			if parentFile, ok := stored[key]; ok {
+				classSnap.Files[i].Snapshot = parentFile.StoredIn(parent.ID)
+				classSnap.Files[i].Chunk = parentFile.Chunk
+				classSnap.Files[i].Compression = parentFile.Compression
+			}
+		}
+	}
+}
+
+func (sp *backupProvider) release(ctx context.Context, classes []string) error {
+	ec := &errorcompounder.ErrorCompounder{}
+	for _, className := range classes {
+		if err := sp.sources[className].ReleaseBackup(ctx, sp.snapshotID); err != nil {
+			ec.Add(errors.Wrapf(err, "class %s", className))
+		}
+	}
+	return ec.ToError()
+}
+
+func (sp *backupProvider) releaseOnFailure(backupCtx context.Context,
+	classes []string, err error,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	ec := &errorcompounder.ErrorCompounder{}
+	ec.Add(err)
+	ec.Add(sp.release(ctx, classes))
+	return sp.setMetaFailed(backupCtx, ec.ToError())
+}
+
+// setMetaFailed marks the snapshot as failed, or as canceled if the context
+// of the backup was canceled
+func (sp *backupProvider) setMetaFailed(backupCtx context.Context, err error) error {
+	if errors.Is(backupCtx.Err(), context.Canceled) {
+		if errMeta := sp.setMetaStatus(backup.CreateCanceled); errMeta != nil {
+			ec := &errorcompounder.ErrorCompounder{}
+			ec.Add(errMeta)
+			ec.Add(err)
+			return ec.ToError()
+		}
+		return errors.Wrap(err, "snapshot canceled")
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), metaTimeout)
 	defer cancel()
 
-	if errMeta := sp.storage.SetMetaError(ctx, sp.className, sp.snapshotID, err); errMeta != nil {
+	if errMeta := sp.storage.SetMetaError(ctx, sp.snapshotID, err); errMeta != nil {
 		ec := &errorcompounder.ErrorCompounder{}
 		ec.Add(errMeta)
 		ec.Add(err)
@@ -105,19 +349,39 @@ func (sp *backupProvider) setMetaStatus(status backup.CreateStatus) error {
 	ctx, cancel := context.WithTimeout(context.Background(), metaTimeout)
 	defer cancel()
 
-	if err := sp.storage.SetMetaStatus(ctx, sp.className, sp.snapshotID, string(status)); err != nil {
+	if err := sp.storage.SetMetaStatus(ctx, sp.snapshotID, string(status)); err != nil {
 		return errors.Wrapf(err, "update snapshot meta to %s", status)
 	}
 	return nil
 }
 
+func (sp *backupProvider) putMeta(snapshot *backup.Snapshot) error {
+	ctx, cancel := context.WithTimeout(context.Background(), metaTimeout)
+	defer cancel()
+
+	return sp.storage.PutMeta(ctx, snapshot)
+}
+
 func (sp *backupProvider) init() (*backup.Snapshot, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), metaTimeout)
 	defer cancel()
 
-	snapshot, err := sp.storage.InitSnapshot(ctx, sp.className, sp.snapshotID)
+	snapshot, err := sp.storage.InitSnapshot(ctx, sp.snapshotID, sp.classes)
 	if err != nil {
 		return nil, errors.Wrap(err, "init snapshot meta")
 	}
 	return snapshot, nil
 }
+
+// countingReader keeps track of the bytes read through it, so the amount of
+// data transferred can be reported without knowing the file size upfront
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -14,22 +14,27 @@ package backup
 import (
 	"context"
 	"errors"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSnapshotProvider_InitSnapshot(t *testing.T) {
 	className := "DemoClass"
-	snapshotID := "SnapshotID"
+	storageName := "DemoStorage"
+	snapshotID := "snapshot-id"
+	classes := []string{className}
 
 	t.Run("fails on storage init snapshot fail", func(t *testing.T) {
-		snapshotter := &fakeSnapshotter{}
 		storage := &fakeStorage{}
-		storage.On("InitSnapshot", mock.Anything, className, snapshotID).Return(nil, errors.New("some kind of error"))
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("InitSnapshot", mock.Anything, snapshotID, classes).Return(nil, errors.New("some kind of error"))
+		sp := newBackupProvider(nil, storage, storageName, snapshotID, classes, nil, backup.CompressionNone)
 
 		snapshot, err := sp.init()
 
@@ -39,16 +44,15 @@ func TestSnapshotProvider_InitSnapshot(t *testing.T) {
 	})
 
 	t.Run("successfully inits", func(t *testing.T) {
-		snap := backup.Snapshot{ClassName: className, ID: snapshotID}
-		snapshotter := &fakeSnapshotter{}
+		snap := backup.NewSnapshot(snapshotID, classes, time.Now())
 		storage := &fakeStorage{}
-		storage.On("InitSnapshot", mock.Anything, className, snapshotID).Return(&snap, nil)
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("InitSnapshot", mock.Anything, snapshotID, classes).Return(snap, nil)
+		sp := newBackupProvider(nil, storage, storageName, snapshotID, classes, nil, backup.CompressionNone)
 
 		snapshot, err := sp.init()
 
 		assert.NotNil(t, snapshot)
-		assert.Equal(t, className, snapshot.ClassName)
+		assert.Equal(t, classes, snapshot.ClassNames())
 		assert.Equal(t, snapshotID, snapshot.ID)
 		assert.Nil(t, err)
 	})
@@ -56,18 +60,33 @@ func TestSnapshotProvider_InitSnapshot(t *testing.T) {
 
 func TestSnapshotProvider_CreatesBackup(t *testing.T) {
 	className := "DemoClass"
-	snapshotID := "SnapshotID"
+	className2 := "DemoClass2"
+	storageName := "DemoStorage"
+	snapshotID := "snapshot-id"
 	ctx := context.Background()
-	snap := backup.Snapshot{ClassName: className, ID: snapshotID}
+	file := backup.SnapshotFile{Class: className, Shard: "shard", Path: "democlass_shard.file"}
+
+	newClassSnapshot := func(className string, files ...backup.SnapshotFile) *backup.ClassSnapshot {
+		classSnap := backup.NewClassSnapshot(className)
+		classSnap.Files = files
+		return classSnap
+	}
+	newReader := func() io.ReadCloser {
+		return io.NopCloser(strings.NewReader("file contents"))
+	}
+	readAll := func(args mock.Arguments) {
+		io.ReadAll(args.Get(3).(io.Reader))
+	}
 
 	t.Run("fails and set meta on create snapshot", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, &snap).Return(nil, errors.New("snapshotter create error"))
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(nil, errors.New("snapshotter create error"))
 		storage := &fakeStorage{}
-		storage.On("SetMetaError", mock.Anything, className, snapshotID, mock.Anything).Return(nil)
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("SetMetaError", mock.Anything, snapshotID, mock.Anything).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
 
-		err := sp.backup(ctx, &snap)
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
 
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "snapshotter create error")
@@ -76,12 +95,13 @@ func TestSnapshotProvider_CreatesBackup(t *testing.T) {
 
 	t.Run("fails and do not set meta on create snapshot", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, &snap).Return(nil, errors.New("snapshotter create error"))
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(nil, errors.New("snapshotter create error"))
 		storage := &fakeStorage{}
-		storage.On("SetMetaError", mock.Anything, className, snapshotID, mock.Anything).Return(errors.New("storage failed error"))
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("SetMetaError", mock.Anything, snapshotID, mock.Anything).Return(errors.New("storage failed error"))
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
 
-		err := sp.backup(ctx, &snap)
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
 
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "snapshotter create error")
@@ -89,46 +109,138 @@ func TestSnapshotProvider_CreatesBackup(t *testing.T) {
 		storage.AssertExpectations(t)
 	})
 
+	t.Run("releases created class snapshots on create snapshot of another class", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{}
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className), nil)
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil).Once()
+		snapshotter2 := &fakeSnapshotter{}
+		snapshotter2.On("CreateBackup", mock.Anything, snapshotID).Return(nil, errors.New("snapshotter create error"))
+		storage := &fakeStorage{}
+		storage.On("SetMetaError", mock.Anything, snapshotID, mock.Anything).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter, className2: snapshotter2},
+			storage, storageName, snapshotID, []string{className, className2}, nil, backup.CompressionNone)
+
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className, className2}, time.Now()))
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "snapshotter create error")
+		snapshotter.AssertExpectations(t)
+		snapshotter2.AssertNotCalled(t, "ReleaseBackup", mock.Anything, mock.Anything)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("marks snapshot as canceled on canceled create snapshot", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		snapshotter := &fakeSnapshotter{}
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(nil, context.Canceled)
+		storage := &fakeStorage{}
+		storage.On("SetMetaStatus", mock.Anything, snapshotID, string(backup.CreateCanceled)).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
+
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "snapshot canceled")
+		storage.AssertExpectations(t)
+		storage.AssertNotCalled(t, "SetMetaError", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("releases and marks snapshot as canceled on canceled store snapshot", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		snapshotter := &fakeSnapshotter{}
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, file).Return(newReader(), nil)
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
+		storage := &fakeStorage{}
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil).Run(func(mock.Arguments) {
+			cancel()
+		})
+		storage.On("PutObject", mock.Anything, snapshotID, file.Path, mock.Anything).Return(context.Canceled)
+		storage.On("SetMetaStatus", mock.Anything, snapshotID, string(backup.CreateCanceled)).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
+
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "snapshot canceled")
+		snapshotter.AssertExpectations(t)
+		storage.AssertExpectations(t)
+		storage.AssertNotCalled(t, "SetMetaError", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("fails to change status to transferring", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, &snap).Return(&snap, nil)
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
 		storage := &fakeStorage{}
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferring)).Return(errors.New("storage transferring error"))
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(errors.New("storage transferring error"))
+		storage.On("SetMetaError", mock.Anything, snapshotID, mock.Anything).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
 
-		err := sp.backup(ctx, &snap)
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
 
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "storage transferring error")
+		snapshotter.AssertExpectations(t)
 		storage.AssertExpectations(t)
 	})
 
 	t.Run("fails and set meta on store snapshot", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, &snap).Return(&snap, nil)
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, file).Return(newReader(), nil)
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
 		storage := &fakeStorage{}
-		storage.On("StoreSnapshot", mock.Anything, &snap).Return(errors.New("storage store error"))
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferring)).Return(nil)
-		storage.On("SetMetaError", mock.Anything, className, snapshotID, mock.Anything).Return(nil)
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, file.Path, mock.Anything).Return(errors.New("storage store error"))
+		storage.On("SetMetaError", mock.Anything, snapshotID, mock.Anything).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
 
-		err := sp.backup(ctx, &snap)
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
 
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "storage store error")
+		snapshotter.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("fails and set meta on read snapshot file", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{}
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, file).Return(nil, errors.New("snapshotter read error"))
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
+		storage := &fakeStorage{}
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("SetMetaError", mock.Anything, snapshotID, mock.Anything).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
+
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "snapshotter read error")
+		snapshotter.AssertExpectations(t)
 		storage.AssertExpectations(t)
 	})
 
 	t.Run("fails and do not set meta on store snapshot", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, &snap).Return(&snap, nil)
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, file).Return(newReader(), nil)
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
 		storage := &fakeStorage{}
-		storage.On("StoreSnapshot", mock.Anything, &snap).Return(errors.New("storage store error"))
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferring)).Return(nil)
-		storage.On("SetMetaError", mock.Anything, className, snapshotID, mock.Anything).Return(errors.New("storage failed error"))
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, file.Path, mock.Anything).Return(errors.New("storage store error"))
+		storage.On("SetMetaError", mock.Anything, snapshotID, mock.Anything).Return(errors.New("storage failed error"))
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
 
-		err := sp.backup(ctx, &snap)
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
 
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "storage store error")
@@ -138,14 +250,18 @@ func TestSnapshotProvider_CreatesBackup(t *testing.T) {
 
 	t.Run("fails to change status to transferred", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, &snap).Return(&snap, nil)
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, file).Return(newReader(), nil)
 		storage := &fakeStorage{}
-		storage.On("StoreSnapshot", mock.Anything, &snap).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferring)).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferred)).Return(errors.New("storage transferred error"))
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("PutMeta", mock.Anything, mock.MatchedBy(func(snap *backup.Snapshot) bool {
+			return snap.Status == string(backup.CreateTransferred)
+		})).Return(errors.New("storage transferred error"))
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, file.Path, mock.Anything).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
 
-		err := sp.backup(ctx, &snap)
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
 
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "storage transferred error")
@@ -154,16 +270,17 @@ func TestSnapshotProvider_CreatesBackup(t *testing.T) {
 
 	t.Run("fails and set meta on release snapshot", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, &snap).Return(&snap, nil)
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, file).Return(newReader(), nil)
 		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(errors.New("snapshotter release error"))
 		storage := &fakeStorage{}
-		storage.On("StoreSnapshot", mock.Anything, &snap).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferring)).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferred)).Return(nil)
-		storage.On("SetMetaError", mock.Anything, className, snapshotID, mock.Anything).Return(nil)
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, file.Path, mock.Anything).Return(nil)
+		storage.On("SetMetaError", mock.Anything, snapshotID, mock.Anything).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
 
-		err := sp.backup(ctx, &snap)
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
 
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "snapshotter release error")
@@ -172,16 +289,17 @@ func TestSnapshotProvider_CreatesBackup(t *testing.T) {
 
 	t.Run("fails and do not set meta on release snapshot", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, &snap).Return(&snap, nil)
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, file).Return(newReader(), nil)
 		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(errors.New("snapshotter release error"))
 		storage := &fakeStorage{}
-		storage.On("StoreSnapshot", mock.Anything, &snap).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferring)).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferred)).Return(nil)
-		storage.On("SetMetaError", mock.Anything, className, snapshotID, mock.Anything).Return(errors.New("storage failed error"))
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, file.Path, mock.Anything).Return(nil)
+		storage.On("SetMetaError", mock.Anything, snapshotID, mock.Anything).Return(errors.New("storage failed error"))
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
 
-		err := sp.backup(ctx, &snap)
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
 
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "snapshotter release error")
@@ -191,37 +309,182 @@ func TestSnapshotProvider_CreatesBackup(t *testing.T) {
 
 	t.Run("fails to change status to success", func(t *testing.T) {
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, &snap).Return(&snap, nil)
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, file).Return(newReader(), nil)
 		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
 		storage := &fakeStorage{}
-		storage.On("StoreSnapshot", mock.Anything, &snap).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferring)).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferred)).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateSuccess)).Return(errors.New("storage success error"))
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, file.Path, mock.Anything).Return(nil)
+		storage.On("SetMetaStatus", mock.Anything, snapshotID, string(backup.CreateSuccess)).Return(errors.New("storage success error"))
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionNone)
 
-		err := sp.backup(ctx, &snap)
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
 
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "storage success error")
 		storage.AssertExpectations(t)
 	})
 
-	t.Run("successfully creates backup", func(t *testing.T) {
+	t.Run("successfully creates backup of multiple classes", func(t *testing.T) {
+		file2 := backup.SnapshotFile{Class: className2, Shard: "shard", Path: "democlass2_shard.file"}
 		snapshotter := &fakeSnapshotter{}
-		snapshotter.On("CreateBackup", mock.Anything, &snap).Return(&snap, nil)
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, file).Return(newReader(), nil)
 		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
+		snapshotter2 := &fakeSnapshotter{}
+		snapshotter2.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className2, file2), nil)
+		snapshotter2.On("ReadBackupFile", mock.Anything, file2).Return(newReader(), nil)
+		snapshotter2.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
 		storage := &fakeStorage{}
-		storage.On("StoreSnapshot", mock.Anything, &snap).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferring)).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateTransferred)).Return(nil)
-		storage.On("SetMetaStatus", mock.Anything, className, snapshotID, string(backup.CreateSuccess)).Return(nil)
-		sp := newBackupProvider(snapshotter, storage, className, snapshotID)
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, file.Path, mock.Anything).Return(nil).Run(readAll)
+		storage.On("PutObject", mock.Anything, snapshotID, file2.Path, mock.Anything).Return(nil).Run(readAll)
+		storage.On("SetMetaStatus", mock.Anything, snapshotID, string(backup.CreateSuccess)).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter, className2: snapshotter2},
+			storage, storageName, snapshotID, []string{className, className2}, nil, backup.CompressionNone)
 
-		err := sp.backup(ctx, &snap)
+		snap := backup.NewSnapshot(snapshotID, []string{className, className2}, time.Now())
+		err := sp.backup(ctx, snap)
 
 		assert.Nil(t, err)
+		assert.Equal(t, []string{className, className2}, snap.ClassNames())
+		assert.Equal(t, string(backup.CreateTransferred), snap.Status)
+		assert.Equal(t, int64(2*len("file contents")), snap.Size)
+		snapshotter.AssertExpectations(t)
+		snapshotter2.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("successfully creates incremental backup", func(t *testing.T) {
+		unchanged := backup.SnapshotFile{Class: className, Shard: "shard", Path: "democlass_shard.unchanged", Checksum: "aaa"}
+		changed := backup.SnapshotFile{Class: className, Shard: "shard", Path: "democlass_shard.changed", Checksum: "bbb"}
+		inherited := backup.SnapshotFile{Class: className, Shard: "shard", Path: "democlass_shard.inherited", Checksum: "ccc"}
+
+		parent := backup.NewSnapshot("parent-id", []string{className}, time.Now())
+		parent.Status = string(backup.CreateSuccess)
+		parentChanged := changed
+		parentChanged.Checksum = "old"
+		parentInherited := inherited
+		parentInherited.Snapshot = "grandparent-id"
+		parent.Classes[0].Files = []backup.SnapshotFile{unchanged, parentChanged, parentInherited}
+
+		snapshotter := &fakeSnapshotter{}
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).
+			Return(newClassSnapshot(className, unchanged, changed, inherited), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, changed).Return(newReader(), nil).Once()
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
+		storage := &fakeStorage{}
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, changed.Path, mock.Anything).Return(nil).Once()
+		storage.On("SetMetaStatus", mock.Anything, snapshotID, string(backup.CreateSuccess)).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, parent, backup.CompressionNone)
+
+		snap := backup.NewSnapshot(snapshotID, []string{className}, time.Now())
+		err := sp.backup(ctx, snap)
+
+		require.Nil(t, err)
+		assert.Equal(t, "parent-id", snap.ParentID)
+		files := snap.Classes[0].Files
+		require.Len(t, files, 3)
+		assert.Equal(t, "parent-id", files[0].Snapshot)
+		assert.Equal(t, "", files[1].Snapshot)
+		assert.Equal(t, "grandparent-id", files[2].Snapshot)
+		snapshotter.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("successfully creates compressed backup in chunks", func(t *testing.T) {
+		size := int64(len("file contents"))
+		file1 := backup.SnapshotFile{Class: className, Shard: "shard", Path: "democlass_shard.file1", Size: size}
+		file2 := backup.SnapshotFile{Class: className, Shard: "shard", Path: "democlass_shard.file2", Size: size}
+		file3 := backup.SnapshotFile{Class: className, Shard: "shard", Path: "democlass_shard.file3", Size: size}
+		snapshotter := &fakeSnapshotter{}
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).
+			Return(newClassSnapshot(className, file1, file2, file3), nil)
+		for _, file := range []backup.SnapshotFile{file1, file2, file3} {
+			snapshotter.On("ReadBackupFile", mock.Anything, file).Return(newReader(), nil).Once()
+		}
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
+		chunk0 := "chunks/" + className + "/0.tar.gz"
+		chunk1 := "chunks/" + className + "/1.tar.gz"
+		storage := &fakeStorage{}
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, chunk0, mock.Anything).Return(nil).Run(readAll).Once()
+		storage.On("PutObject", mock.Anything, snapshotID, chunk1, mock.Anything).Return(nil).Run(readAll).Once()
+		storage.On("SetMetaStatus", mock.Anything, snapshotID, string(backup.CreateSuccess)).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionGzip)
+		sp.chunkSize = 2 * size
+
+		snap := backup.NewSnapshot(snapshotID, []string{className}, time.Now())
+		err := sp.backup(ctx, snap)
+
+		require.Nil(t, err)
+		assert.Equal(t, backup.CompressionGzip, snap.Compression)
+		assert.Greater(t, snap.Size, int64(0))
+		files := snap.Classes[0].Files
+		require.Len(t, files, 3)
+		assert.Equal(t, []string{chunk0, chunk0, chunk1},
+			[]string{files[0].Chunk, files[1].Chunk, files[2].Chunk})
+		for _, file := range files {
+			assert.Equal(t, backup.CompressionGzip, file.Compression)
+		}
 		snapshotter.AssertExpectations(t)
 		storage.AssertExpectations(t)
 	})
+
+	t.Run("fails and set meta on failed chunk upload", func(t *testing.T) {
+		file := backup.SnapshotFile{Class: className, Shard: "shard", Path: "democlass_shard.file", Size: int64(len("file contents"))}
+		snapshotter := &fakeSnapshotter{}
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).Return(newClassSnapshot(className, file), nil)
+		snapshotter.On("ReadBackupFile", mock.Anything, file).Return(newReader(), nil)
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
+		storage := &fakeStorage{}
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("PutObject", mock.Anything, snapshotID, mock.Anything, mock.Anything).
+			Return(errors.New("storage put error"))
+		storage.On("SetMetaError", mock.Anything, snapshotID, mock.Anything).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, nil, backup.CompressionZstd)
+
+		err := sp.backup(ctx, backup.NewSnapshot(snapshotID, []string{className}, time.Now()))
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "storage put error")
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("incremental backup keeps referring to chunks of the parent", func(t *testing.T) {
+		unchanged := backup.SnapshotFile{Class: className, Shard: "shard", Path: "democlass_shard.unchanged", Checksum: "aaa"}
+
+		parent := backup.NewSnapshot("parent-id", []string{className}, time.Now())
+		parent.Status = string(backup.CreateSuccess)
+		parentUnchanged := unchanged
+		parentUnchanged.Chunk = "chunks/" + className + "/0.tar.zst"
+		parentUnchanged.Compression = backup.CompressionZstd
+		parent.Classes[0].Files = []backup.SnapshotFile{parentUnchanged}
+
+		snapshotter := &fakeSnapshotter{}
+		snapshotter.On("CreateBackup", mock.Anything, snapshotID).
+			Return(newClassSnapshot(className, unchanged), nil)
+		snapshotter.On("ReleaseBackup", mock.Anything, snapshotID).Return(nil)
+		storage := &fakeStorage{}
+		storage.On("PutMeta", mock.Anything, mock.Anything).Return(nil)
+		storage.On("SetMetaStatus", mock.Anything, snapshotID, string(backup.CreateSuccess)).Return(nil)
+		sp := newBackupProvider(map[string]Sourcer{className: snapshotter},
+			storage, storageName, snapshotID, []string{className}, parent, backup.CompressionGzip)
+
+		snap := backup.NewSnapshot(snapshotID, []string{className}, time.Now())
+		err := sp.backup(ctx, snap)
+
+		require.Nil(t, err)
+		files := snap.Classes[0].Files
+		require.Len(t, files, 1)
+		assert.Equal(t, "parent-id", files[0].Snapshot)
+		assert.Equal(t, parentUnchanged.Chunk, files[0].Chunk)
+		assert.Equal(t, backup.CompressionZstd, files[0].Compression)
+		storage.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
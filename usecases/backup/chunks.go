@@ -0,0 +1,177 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/backup"
+)
+
+// splitChunks groups the files into chunks of roughly maxSize uncompressed
+// bytes. Files are never split, so a chunk holding a single large file can
+// exceed maxSize. The files are referred to by their index.
+func splitChunks(files []backup.SnapshotFile, indices []int, maxSize int64) [][]int {
+	var chunks [][]int
+	var current []int
+	var size int64
+	for _, i := range indices {
+		if len(current) > 0 && size+files[i].Size > maxSize {
+			chunks = append(chunks, current)
+			current, size = nil, 0
+		}
+		current = append(current, i)
+		size += files[i].Size
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// chunkKey is the key under which a chunk of a class is stored
+func chunkKey(className string, n int, compression backup.Compression) string {
+	return fmt.Sprintf("chunks/%s/%d%s", className, n, compression.Extension())
+}
+
+// writeChunk streams the files into a compressed tar archive. The size
+// recorded for each file must match its contents, as it is needed for the
+// tar header before the file is read.
+func writeChunk(ctx context.Context, w io.Writer, source Sourcer,
+	files []backup.SnapshotFile, compression backup.Compression,
+) error {
+	cw, err := newCompressor(w, compression)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(cw)
+	for _, file := range files {
+		if err := writeChunkFile(ctx, tw, source, file); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "close archive")
+	}
+	if err := cw.Close(); err != nil {
+		return errors.Wrap(err, "close compressor")
+	}
+	return nil
+}
+
+func writeChunkFile(ctx context.Context, tw *tar.Writer, source Sourcer,
+	file backup.SnapshotFile,
+) error {
+	r, err := source.ReadBackupFile(ctx, file)
+	if err != nil {
+		return errors.Wrapf(err, "read file %s", file.Path)
+	}
+	defer r.Close()
+
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     file.Path,
+		Size:     file.Size,
+		Mode:     0o644,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return errors.Wrapf(err, "write header of file %s", file.Path)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return errors.Wrapf(err, "write file %s", file.Path)
+	}
+	// fails if the file was shorter than its recorded size
+	if err := tw.Flush(); err != nil {
+		return errors.Wrapf(err, "write file %s", file.Path)
+	}
+	return nil
+}
+
+func newCompressor(w io.Writer, compression backup.Compression) (io.WriteCloser, error) {
+	switch compression {
+	case backup.CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case backup.CompressionZstd:
+		return zstd.NewWriter(w)
+	case backup.CompressionNone:
+		return nopWriteCloser{w}, nil
+	default:
+		return nil, errors.Errorf("unsupported compression %q", compression)
+	}
+}
+
+func newDecompressor(r io.Reader, compression backup.Compression) (io.ReadCloser, error) {
+	switch compression {
+	case backup.CompressionGzip:
+		return gzip.NewReader(r)
+	case backup.CompressionZstd:
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case backup.CompressionNone:
+		return io.NopCloser(r), nil
+	default:
+		return nil, errors.Errorf("unsupported compression %q", compression)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// checksumReader computes the checksum of everything read through it, so
+// restored files can be verified while they are staged
+type checksumReader struct {
+	r io.Reader
+	h hash.Hash
+}
+
+func newChecksumReader(r io.Reader) *checksumReader {
+	return &checksumReader{r: r, h: sha256.New()}
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.h.Write(p[:n])
+	return n, err
+}
+
+// verify compares the checksum of the contents read so far to the one
+// recorded for the file. Files of snapshots created before checksums were
+// recorded can not be verified.
+func (c *checksumReader) verify(file backup.SnapshotFile) error {
+	if file.Checksum == "" {
+		return nil
+	}
+	if actual := hex.EncodeToString(c.h.Sum(nil)); actual != file.Checksum {
+		return errors.Errorf("checksum mismatch of file %s: expected %s, got %s",
+			file.Path, file.Checksum, actual)
+	}
+	return nil
+}
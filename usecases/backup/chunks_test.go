@@ -0,0 +1,147 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/entities/backup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunks_SplitChunks(t *testing.T) {
+	files := []backup.SnapshotFile{
+		{Path: "a", Size: 10},
+		{Path: "b", Size: 10},
+		{Path: "c", Size: 30},
+		{Path: "d", Size: 5},
+	}
+
+	t.Run("groups files up to the max size", func(t *testing.T) {
+		chunks := splitChunks(files, []int{0, 1, 2, 3}, 20)
+		assert.Equal(t, [][]int{{0, 1}, {2}, {3}}, chunks)
+	})
+
+	t.Run("only considers the specified files", func(t *testing.T) {
+		chunks := splitChunks(files, []int{1, 3}, 20)
+		assert.Equal(t, [][]int{{1, 3}}, chunks)
+	})
+
+	t.Run("no files result in no chunks", func(t *testing.T) {
+		assert.Empty(t, splitChunks(files, nil, 20))
+	})
+}
+
+func TestChunks_WriteChunk(t *testing.T) {
+	ctx := context.Background()
+	contents := map[string]string{
+		"democlass_shard.a": "file contents a",
+		"democlass_shard.b": "file contents of b",
+	}
+	files := []backup.SnapshotFile{
+		{Class: "DemoClass", Path: "democlass_shard.a", Size: int64(len(contents["democlass_shard.a"]))},
+		{Class: "DemoClass", Path: "democlass_shard.b", Size: int64(len(contents["democlass_shard.b"]))},
+	}
+
+	newSource := func() *fakeSnapshotter {
+		source := &fakeSnapshotter{}
+		for _, file := range files {
+			source.On("ReadBackupFile", ctx, file).
+				Return(io.NopCloser(strings.NewReader(contents[file.Path])), nil).Once()
+		}
+		return source
+	}
+
+	for _, compression := range []backup.Compression{
+		backup.CompressionNone, backup.CompressionGzip, backup.CompressionZstd,
+	} {
+		t.Run("round trip with compression "+string(compression), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.Nil(t, writeChunk(ctx, &buf, newSource(), files, compression))
+
+			dr, err := newDecompressor(&buf, compression)
+			require.Nil(t, err)
+			defer dr.Close()
+
+			read := make(map[string]string)
+			tr := tar.NewReader(dr)
+			for {
+				header, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				require.Nil(t, err)
+				b, err := io.ReadAll(tr)
+				require.Nil(t, err)
+				read[header.Name] = string(b)
+			}
+			assert.Equal(t, contents, read)
+		})
+	}
+
+	t.Run("fails if a file does not match its size", func(t *testing.T) {
+		file := backup.SnapshotFile{Class: "DemoClass", Path: "democlass_shard.c", Size: 100}
+		source := &fakeSnapshotter{}
+		source.On("ReadBackupFile", ctx, file).
+			Return(io.NopCloser(strings.NewReader("too short")), nil)
+
+		err := writeChunk(ctx, io.Discard, source, []backup.SnapshotFile{file}, backup.CompressionGzip)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "democlass_shard.c")
+	})
+
+	t.Run("fails on unsupported compression", func(t *testing.T) {
+		err := writeChunk(ctx, io.Discard, &fakeSnapshotter{}, files, "lzma")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestChunks_ChecksumReader(t *testing.T) {
+	sum := sha256.Sum256([]byte("file contents"))
+	file := backup.SnapshotFile{Path: "democlass_shard.file", Checksum: hex.EncodeToString(sum[:])}
+
+	t.Run("accepts matching contents", func(t *testing.T) {
+		r := newChecksumReader(strings.NewReader("file contents"))
+		_, err := io.ReadAll(r)
+		require.Nil(t, err)
+		assert.Nil(t, r.verify(file))
+	})
+
+	t.Run("rejects corrupted contents", func(t *testing.T) {
+		r := newChecksumReader(strings.NewReader("file c0ntents"))
+		_, err := io.ReadAll(r)
+		require.Nil(t, err)
+		err = r.verify(file)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "checksum mismatch")
+	})
+
+	t.Run("rejects partially read contents", func(t *testing.T) {
+		r := newChecksumReader(strings.NewReader("file contents"))
+		_, err := io.ReadFull(r, make([]byte, 4))
+		require.Nil(t, err)
+		assert.NotNil(t, r.verify(file))
+	})
+
+	t.Run("accepts files without checksum", func(t *testing.T) {
+		r := newChecksumReader(strings.NewReader("anything"))
+		assert.Nil(t, r.verify(backup.SnapshotFile{Path: "democlass_shard.file"}))
+	})
+}
@@ -13,7 +13,7 @@ package backup
 
 import (
 	"context"
-	"time"
+	"io"
 
 	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/modulecapabilities"
@@ -41,10 +41,10 @@ type fakeSnapshotter struct {
 	mock.Mock
 }
 
-func (s *fakeSnapshotter) CreateBackup(ctx context.Context, snapshot *backup.Snapshot) (*backup.Snapshot, error) {
-	args := s.Called(ctx, snapshot)
+func (s *fakeSnapshotter) CreateBackup(ctx context.Context, snapshotID string) (*backup.ClassSnapshot, error) {
+	args := s.Called(ctx, snapshotID)
 	if args.Get(0) != nil {
-		return args.Get(0).(*backup.Snapshot), args.Error(1)
+		return args.Get(0).(*backup.ClassSnapshot), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
@@ -54,57 +54,94 @@ func (s *fakeSnapshotter) ReleaseBackup(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
-type fakeStorage struct {
+func (s *fakeSnapshotter) ReadBackupFile(ctx context.Context, file backup.SnapshotFile) (io.ReadCloser, error) {
+	args := s.Called(ctx, file)
+	if args.Get(0) != nil {
+		return args.Get(0).(io.ReadCloser), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type fakeRestorer struct {
 	mock.Mock
+}
 
-	getMetaStatusSleep   time.Duration
-	storeSnapshotSleep   time.Duration
-	restoreSnapshotSleep time.Duration
+func (r *fakeRestorer) RestoreBackupFile(ctx context.Context, className string,
+	file backup.SnapshotFile, rd io.Reader,
+) error {
+	args := r.Called(ctx, className, file, rd)
+	return args.Error(0)
 }
 
-func (s *fakeStorage) StoreSnapshot(ctx context.Context, snapshot *backup.Snapshot) error {
-	time.Sleep(s.storeSnapshotSleep)
-	args := s.Called(ctx, snapshot)
+func (r *fakeRestorer) RestoreShardMetadata(ctx context.Context, className, shardName,
+	nodeName string, meta *backup.ShardMetadata,
+) error {
+	args := r.Called(ctx, className, shardName, nodeName, meta)
 	return args.Error(0)
 }
 
-func (s *fakeStorage) RestoreSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	time.Sleep(s.restoreSnapshotSleep)
-	args := s.Called(ctx, className, snapshotID)
+type fakeStorage struct {
+	mock.Mock
+}
+
+func (s *fakeStorage) PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error {
+	args := s.Called(ctx, snapshotID, key, r)
+	return args.Error(0)
+}
+
+func (s *fakeStorage) GetObject(ctx context.Context, snapshotID, key string) (io.ReadCloser, error) {
+	args := s.Called(ctx, snapshotID, key)
 	if args.Get(0) != nil {
-		return args.Get(0).(*backup.Snapshot), args.Error(1)
+		return args.Get(0).(io.ReadCloser), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (s *fakeStorage) InitSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	args := s.Called(ctx, className, snapshotID)
+func (s *fakeStorage) InitSnapshot(ctx context.Context, snapshotID string, classes []string) (*backup.Snapshot, error) {
+	args := s.Called(ctx, snapshotID, classes)
 	if args.Get(0) != nil {
 		return args.Get(0).(*backup.Snapshot), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (s *fakeStorage) GetMeta(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
-	time.Sleep(s.getMetaStatusSleep)
-	args := s.Called(ctx, className, snapshotID)
+func (s *fakeStorage) GetMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
+	args := s.Called(ctx, snapshotID)
 	if args.Get(0) != nil {
 		return args.Get(0).(*backup.Snapshot), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (s *fakeStorage) SetMetaStatus(ctx context.Context, className, snapshotID, status string) error {
-	args := s.Called(ctx, className, snapshotID, status)
+func (s *fakeStorage) PutMeta(ctx context.Context, snapshot *backup.Snapshot) error {
+	args := s.Called(ctx, snapshot)
+	return args.Error(0)
+}
+
+func (s *fakeStorage) SetMetaStatus(ctx context.Context, snapshotID, status string) error {
+	args := s.Called(ctx, snapshotID, status)
 	return args.Error(0)
 }
 
-func (s *fakeStorage) SetMetaError(ctx context.Context, className, snapshotID string, err error) error {
-	args := s.Called(ctx, className, snapshotID, err)
+func (s *fakeStorage) SetMetaError(ctx context.Context, snapshotID string, err error) error {
+	args := s.Called(ctx, snapshotID, err)
 	return args.Error(0)
 }
 
-func (s *fakeStorage) DestinationPath(className, snapshotID string) string {
-	args := s.Called(className, snapshotID)
+func (s *fakeStorage) DestinationPath(snapshotID string) string {
+	args := s.Called(snapshotID)
 	return args.String(0)
 }
+
+func (s *fakeStorage) ListSnapshots(ctx context.Context) ([]*backup.Snapshot, error) {
+	args := s.Called(ctx)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*backup.Snapshot), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (s *fakeStorage) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	args := s.Called(ctx, snapshotID)
+	return args.Error(0)
+}
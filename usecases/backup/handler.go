@@ -22,6 +22,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/usecases/monitoring"
 	"github.com/sirupsen/logrus"
 )
@@ -32,17 +33,20 @@ type authorizer interface {
 
 type schemaManger interface {
 	RestoreClass(context.Context, *models.Principal,
-		*models.Class, *backup.Snapshot,
+		*models.Class, *backup.ClassSnapshot,
 	) error
+	GetSchemaSkipAuth() schema.Schema
 }
 
 type Manager struct {
-	logger        logrus.FieldLogger
-	authorizer    authorizer
-	schema        schemaManger
-	backups       *backupManager
-	RestoreStatus sync.Map
-	RestoreError  sync.Map
+	logger         logrus.FieldLogger
+	authorizer     authorizer
+	schema         schemaManger
+	backups        *backupManager
+	RestoreStatus  sync.Map
+	RestoreError   sync.Map
+	RestoreClasses sync.Map
+	restoreCancels sync.Map // keyed by snapshot UID
 	sync.Mutex
 }
 
@@ -51,22 +55,27 @@ func NewManager(
 	authorizer authorizer,
 	schema schemaManger,
 	sourceFactory SourceFactory,
+	restorer Restorer,
 	storages BackupStorageProvider,
-	shardingStateFunc shardingStateFunc,
 ) *Manager {
 	m := &Manager{
 		logger:     logger,
 		authorizer: authorizer,
 		schema:     schema,
-		backups:    NewBackupManager(logger, sourceFactory, storages, shardingStateFunc),
+		backups:    NewBackupManager(logger, sourceFactory, restorer, storages),
 	}
 	return m
 }
 
+// CreateBackup starts a backup of the included classes. If no classes are
+// included, all classes of the schema except the excluded ones are backed up.
+// If a parent is specified, the backup is incremental and only contains the
+// files which changed since the parent backup. Unless the compression is
+// none, the files are stored in compressed chunks.
 func (m *Manager) CreateBackup(ctx context.Context, principal *models.Principal,
-	className, storageName, ID string,
+	storageName, ID, parentID, compression string, include, exclude []string,
 ) (*models.BackupCreateMeta, error) {
-	path := fmt.Sprintf("schema/%s/snapshots/%s/%s", className, storageName, ID)
+	path := fmt.Sprintf("backups/%s/%s", storageName, ID)
 	if err := m.authorizer.Authorize(principal, "add", path); err != nil {
 		return nil, err
 	}
@@ -74,13 +83,30 @@ func (m *Manager) CreateBackup(ctx context.Context, principal *models.Principal,
 	if err := validateID(ID); err != nil {
 		return nil, err
 	}
+	if parentID != "" {
+		if err := validateID(parentID); err != nil {
+			return nil, errors.Wrap(err, "parent")
+		}
+	}
+
+	comp, err := backup.ParseCompression(compression)
+	if err != nil {
+		return nil, backup.NewErrUnprocessable(err)
+	}
 
-	if meta, err := m.backups.CreateBackup(ctx, className, storageName, ID); err != nil {
+	classes, err := selectClasses(m.schemaClassNames(), include, exclude)
+	if err != nil {
+		return nil, backup.NewErrUnprocessable(err)
+	}
+
+	if meta, err := m.backups.CreateBackup(ctx, storageName, ID, parentID, comp, classes); err != nil {
 		return nil, err
 	} else {
 		status := string(meta.Status)
 		return &models.BackupCreateMeta{
+			Classes:     classes,
 			ID:          ID,
+			Parent:      parentID,
 			StorageName: storageName,
 			Status:      &status,
 			Path:        meta.Path,
@@ -89,109 +115,211 @@ func (m *Manager) CreateBackup(ctx context.Context, principal *models.Principal,
 }
 
 func (m *Manager) CreateBackupStatus(ctx context.Context, principal *models.Principal,
-	className, storageName, snapshotID string,
+	storageName, snapshotID string,
 ) (*models.BackupCreateMeta, error) {
 	err := m.authorizer.Authorize(principal, "get", fmt.Sprintf(
-		"schema/%s/snapshots/%s/%s", className, storageName, snapshotID))
+		"backups/%s/%s", storageName, snapshotID))
 	if err != nil {
 		return nil, err
 	}
 
-	return m.backups.CreateBackupStatus(ctx, className, storageName, snapshotID)
+	return m.backups.CreateBackupStatus(ctx, storageName, snapshotID)
 }
 
 func (m *Manager) RestoreBackupStatus(ctx context.Context, principal *models.Principal,
-	className, storageName, ID string,
-) (status string, errorString string, path string, err error) {
-	snapshotUID := storageName + "-" + className + "-" + ID
-	path = fmt.Sprintf("schema/%s/snapshots/%s/%s/restore", className, storageName, ID)
+	storageName, ID string,
+) (*models.BackupRestoreMeta, error) {
+	snapshotUID := storageName + "-" + ID
+	path := fmt.Sprintf("backups/%s/%s/restore", storageName, ID)
 	if err := m.authorizer.Authorize(principal, "get", path); err != nil {
-		return "", "", "", err
+		return nil, err
 	}
 
 	statusInterface, ok := m.RestoreStatus.Load(snapshotUID)
 	if !ok {
-		return "", "", "", errors.Errorf("snapshot status not found for %s", snapshotUID)
+		return nil, backup.NewErrNotFound(
+			fmt.Errorf("snapshot status not found for %s", snapshotUID))
 	}
-	status = statusInterface.(string)
-	errInterface, ok := m.RestoreError.Load(snapshotUID)
-	if !ok {
-		return "", "", "", errors.Errorf("snapshot status not found for %s", snapshotUID)
+	status := statusInterface.(string)
+
+	var errorString string
+	if errInterface, _ := m.RestoreError.Load(snapshotUID); errInterface != nil {
+		errorString = errInterface.(error).Error()
 	}
 
-	if errInterface != nil {
-		restoreError := errInterface.(error)
-		errorString = restoreError.Error()
+	var classes []string
+	if classesInterface, ok := m.RestoreClasses.Load(snapshotUID); ok {
+		classes = classesInterface.([]string)
 	}
 
-	path, err = m.backups.DestinationPath(storageName, className, ID)
+	path, err := m.backups.DestinationPath(storageName, ID)
 	if err != nil {
-		return "", "", "", err
+		return nil, err
 	}
 
-	return status, errorString, path, nil
+	return &models.BackupRestoreMeta{
+		Classes:     classes,
+		Error:       errorString,
+		ID:          ID,
+		Path:        path,
+		Status:      &status,
+		StorageName: storageName,
+	}, nil
 }
 
+// RestoreBackup restores the included classes of a backup. If no classes are
+// included, all classes of the backup except the excluded ones are restored.
+// Classes listed in rename are restored under their new name, which must not
+// exist yet. The restore runs in the background, use RestoreBackupStatus to
+// follow it.
 func (m *Manager) RestoreBackup(ctx context.Context, principal *models.Principal,
-	className, storageName, ID string,
+	storageName, ID string, include, exclude []string, rename map[string]string,
 ) (*models.BackupRestoreMeta, error) {
-	snapshotUID := fmt.Sprintf("%s-%s-%s", storageName, className, ID)
-	m.RestoreStatus.Store(snapshotUID, models.BackupRestoreMetaStatusSTARTED)
-	m.RestoreError.Store(snapshotUID, nil)
-	path := fmt.Sprintf("schema/%s/snapshots/%s/%s/restore", className, storageName, ID)
+	path := fmt.Sprintf("backups/%s/%s/restore", storageName, ID)
 	if err := m.authorizer.Authorize(principal, "restore", path); err != nil {
 		return nil, err
 	}
 
-	go func(ctx context.Context, className, snapshotId string) {
-		timer := prometheus.NewTimer(monitoring.GetMetrics().SnapshotRestoreDurations.WithLabelValues(storageName, className))
-		defer timer.ObserveDuration()
+	meta, snapshot, err := m.backups.RestoreBackup(ctx, storageName, ID, include, exclude, rename)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotUID := storageName + "-" + ID
+	classes := snapshot.ClassNames()
+	m.RestoreStatus.Store(snapshotUID, string(meta.Status))
+	m.RestoreError.Store(snapshotUID, nil)
+	m.RestoreClasses.Store(snapshotUID, classes)
+
+	// the restore outlives the request, it can only be stopped through
+	// CancelBackup
+	ctxRestore, cancel := context.WithCancel(context.Background())
+	m.restoreCancels.Store(snapshotUID, cancel)
+
+	go func(ctx context.Context, snapshot *backup.Snapshot) {
+		defer m.backups.ReleaseRestore(classes)
+		defer m.restoreCancels.Delete(snapshotUID)
+		defer cancel()
+
 		m.RestoreStatus.Store(snapshotUID, models.BackupRestoreMetaStatusTRANSFERRING)
-		if meta, snapshot, err := m.backups.RestoreBackup(context.Background(), className, storageName, ID); err != nil {
-			if meta != nil {
-				m.RestoreStatus.Store(snapshotUID, string(meta.Status))
-			} else {
-				m.RestoreStatus.Store(snapshotUID, models.BackupRestoreMetaStatusFAILED)
+		for _, classSnap := range snapshot.Classes {
+			err := ctx.Err()
+			if err == nil {
+				err = m.restoreClass(ctx, principal, storageName, ID, classSnap)
 			}
-			m.RestoreError.Store(snapshotUID, err)
-			return
-		} else {
-			classM := models.Class{}
-			if err := json.Unmarshal([]byte(snapshot.Schema), &classM); err != nil {
-				m.RestoreStatus.Store(snapshotUID, models.BackupRestoreMetaStatusFAILED)
-				m.RestoreError.Store(snapshotUID, err)
-				return
-			}
-
-			err := m.schema.RestoreClass(ctx, principal, &classM, snapshot)
 			if err != nil {
-				m.RestoreStatus.Store(snapshotUID, models.BackupRestoreMetaStatusFAILED)
+				// classes restored before the cancellation are kept
+				status := models.BackupRestoreMetaStatusFAILED
+				if errors.Is(ctx.Err(), context.Canceled) {
+					status = models.BackupRestoreMetaStatusCANCELED
+				}
+				m.RestoreStatus.Store(snapshotUID, status)
 				m.RestoreError.Store(snapshotUID, err)
+				m.logger.WithField("action", "restore_backup").
+					Error(err)
 				return
 			}
+		}
+		m.RestoreStatus.Store(snapshotUID, models.BackupRestoreMetaStatusSUCCESS)
+	}(ctxRestore, snapshot)
 
-			m.RestoreStatus.Store(snapshotUID, models.BackupRestoreMetaStatusSUCCESS)
+	status := string(meta.Status)
+	return &models.BackupRestoreMeta{
+		Classes:     classes,
+		ID:          ID,
+		StorageName: storageName,
+		Status:      &status,
+		Path:        meta.Path,
+	}, nil
+}
 
-		}
-	}(context.Background(), className, ID)
+// CancelBackup stops the creation or restoration of a backup which is
+// currently in progress. Both run in the background, so the backup is only
+// marked as canceled once it actually stopped.
+func (m *Manager) CancelBackup(ctx context.Context, principal *models.Principal,
+	storageName, ID string,
+) error {
+	path := fmt.Sprintf("backups/%s/%s", storageName, ID)
+	if err := m.authorizer.Authorize(principal, "update", path); err != nil {
+		return err
+	}
 
-	statusInterface, ok := m.RestoreStatus.Load(snapshotUID)
-	if !ok {
-		return nil, errors.Errorf("snapshot  not found for %s", snapshotUID)
+	if _, err := m.backups.DestinationPath(storageName, ID); err != nil {
+		return backup.NewErrUnprocessable(errors.Wrapf(err, "find storage by name %s", storageName))
 	}
-	status := statusInterface.(string)
 
-	path, err := m.backups.DestinationPath(storageName, className, ID)
-	if err != nil {
+	canceled := m.backups.CancelCreate(storageName, ID)
+	if cancel, ok := m.restoreCancels.Load(storageName + "-" + ID); ok {
+		cancel.(context.CancelFunc)()
+		canceled = true
+	}
+	if !canceled {
+		return backup.NewErrNotFound(
+			fmt.Errorf("no creation or restoration of backup %s in progress", ID))
+	}
+	return nil
+}
+
+// ListBackups lists all backups which exist on the storage
+func (m *Manager) ListBackups(ctx context.Context, principal *models.Principal,
+	storageName string,
+) ([]*models.BackupListItem, error) {
+	path := fmt.Sprintf("backups/%s", storageName)
+	if err := m.authorizer.Authorize(principal, "list", path); err != nil {
 		return nil, err
 	}
-	returnData := &models.BackupRestoreMeta{
-		ID:          ID,
-		StorageName: storageName,
-		Status:      &status,
-		Path:        path,
+
+	return m.backups.ListBackups(ctx, storageName)
+}
+
+// DeleteBackup removes a backup from the storage. Backups which are in use,
+// either by a running creation or restoration or as the parent of an
+// incremental backup, can not be deleted.
+func (m *Manager) DeleteBackup(ctx context.Context, principal *models.Principal,
+	storageName, ID string,
+) error {
+	path := fmt.Sprintf("backups/%s/%s", storageName, ID)
+	if err := m.authorizer.Authorize(principal, "delete", path); err != nil {
+		return err
+	}
+
+	if _, ok := m.restoreCancels.Load(storageName + "-" + ID); ok {
+		return backup.NewErrUnprocessable(fmt.Errorf("backup %s is being restored", ID))
+	}
+
+	return m.backups.DeleteBackup(ctx, storageName, ID)
+}
+
+func (m *Manager) restoreClass(ctx context.Context, principal *models.Principal,
+	storageName, ID string, classSnap *backup.ClassSnapshot,
+) error {
+	timer := prometheus.NewTimer(monitoring.GetMetrics().SnapshotRestoreDurations.
+		WithLabelValues(storageName, classSnap.Name))
+	defer timer.ObserveDuration()
+
+	if err := m.backups.RestoreClassFiles(ctx, storageName, ID, classSnap); err != nil {
+		return err
+	}
+
+	var class models.Class
+	if err := json.Unmarshal(classSnap.Schema, &class); err != nil {
+		return err
+	}
+
+	return m.schema.RestoreClass(ctx, principal, &class, classSnap)
+}
+
+func (m *Manager) schemaClassNames() []string {
+	s := m.schema.GetSchemaSkipAuth()
+	if s.Objects == nil {
+		return nil
+	}
+
+	classes := make([]string, len(s.Objects.Classes))
+	for i, class := range s.Objects.Classes {
+		classes[i] = class.Class
 	}
-	return returnData, nil
+	return classes
 }
 
 func validateID(snapshotID string) error {
@@ -16,16 +16,27 @@ import (
 
 	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
 )
 
-type fakeSchemaManger struct{}
+type fakeSchemaManger struct {
+	classes []string
+}
 
 func (f *fakeSchemaManger) RestoreClass(context.Context, *models.Principal,
-	*models.Class, *backup.Snapshot,
+	*models.Class, *backup.ClassSnapshot,
 ) error {
 	return nil
 }
 
+func (f *fakeSchemaManger) GetSchemaSkipAuth() schema.Schema {
+	classes := make([]*models.Class, len(f.classes))
+	for i, className := range f.classes {
+		classes[i] = &models.Class{Class: className}
+	}
+	return schema.Schema{Objects: &models.Schema{Classes: classes}}
+}
+
 type fakeAuthorizer struct{}
 
 func (f *fakeAuthorizer) Authorize(principal *models.Principal, verb, resource string) error {
@@ -0,0 +1,112 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/backup"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
+)
+
+// validateRename makes sure that every renamed class is restored, that the
+// new names are valid and that no two classes end up with the same name.
+// Just like include and exclude, the classes to rename are looked up
+// case-insensitively. The returned map is keyed by the class names as
+// spelled in classes.
+func validateRename(classes []string, rename map[string]string) (map[string]string, error) {
+	normalized := make(map[string]string, len(rename))
+	for from, to := range rename {
+		className, ok := findClass(classes, from)
+		if !ok {
+			return nil, fmt.Errorf("class %s to rename is not restored", from)
+		}
+		if _, err := schema.ValidateClassName(to); err != nil {
+			return nil, errors.Wrapf(err, "rename class %s", from)
+		}
+		normalized[className] = to
+	}
+
+	// indexes are named after the lower case class name
+	seen := make(map[string]string, len(classes))
+	for _, className := range classes {
+		target := strings.ToLower(renamedClass(className, normalized))
+		if other, ok := seen[target]; ok {
+			return nil, fmt.Errorf("classes %s and %s would both be restored as %s",
+				other, className, renamedClass(className, normalized))
+		}
+		seen[target] = className
+	}
+
+	return normalized, nil
+}
+
+// renamedClass returns the name under which the class is restored
+func renamedClass(className string, rename map[string]string) string {
+	if target, ok := rename[className]; ok {
+		return target
+	}
+	return className
+}
+
+// renameClass returns a copy of the class snapshot in which the schema and
+// the sharding state are rewritten as if the class had been created under
+// its new name. Cross-references to any of the renamed classes are rewritten
+// as well, even if the class itself keeps its name. The files keep the
+// class they were backed up from, as that is how they are stored.
+func renameClass(classSnap *backup.ClassSnapshot, rename map[string]string) (*backup.ClassSnapshot, error) {
+	if len(rename) == 0 {
+		return classSnap, nil
+	}
+
+	var class models.Class
+	if err := json.Unmarshal(classSnap.Schema, &class); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal schema of %s", classSnap.Name)
+	}
+
+	class.Class = renamedClass(class.Class, rename)
+	for _, prop := range class.Properties {
+		for i, dataType := range prop.DataType {
+			prop.DataType[i] = renamedClass(dataType, rename)
+		}
+	}
+
+	classSchema, err := json.Marshal(&class)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshal schema of %s", classSnap.Name)
+	}
+
+	shardingState := classSnap.ShardingState
+	if target, ok := rename[classSnap.Name]; ok && shardingState != nil {
+		var state sharding.State
+		if err := json.Unmarshal(shardingState, &state); err != nil {
+			return nil, errors.Wrapf(err, "unmarshal sharding state of %s", classSnap.Name)
+		}
+		state.IndexID = target
+		if shardingState, err = json.Marshal(&state); err != nil {
+			return nil, errors.Wrapf(err, "marshal sharding state of %s", classSnap.Name)
+		}
+	}
+
+	return &backup.ClassSnapshot{
+		Name:          renamedClass(classSnap.Name, rename),
+		Files:         classSnap.Files,
+		ShardMetadata: classSnap.ShardMetadata,
+		ShardingState: shardingState,
+		Schema:        classSchema,
+	}, nil
+}
@@ -0,0 +1,156 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package backup
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/entities/backup"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRename(t *testing.T) {
+	classes := []string{"Article", "Author"}
+
+	t.Run("normalizes the renamed classes", func(t *testing.T) {
+		rename, err := validateRename(classes, map[string]string{"article": "Article_restored"})
+		require.Nil(t, err)
+		assert.Equal(t, map[string]string{"Article": "Article_restored"}, rename)
+	})
+
+	t.Run("fails when renamed class is not restored", func(t *testing.T) {
+		_, err := validateRename(classes, map[string]string{"Publication": "Publication_restored"})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "class Publication to rename is not restored")
+	})
+
+	t.Run("fails on invalid class name", func(t *testing.T) {
+		_, err := validateRename(classes, map[string]string{"Article": "article-restored"})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "not a valid class name")
+	})
+
+	t.Run("fails when two classes end up with the same name", func(t *testing.T) {
+		_, err := validateRename(classes, map[string]string{"Article": "AUTHOR"})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "classes Article and Author would both be restored")
+
+		_, err = validateRename(classes, map[string]string{"Article": "Book", "Author": "Book"})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "would both be restored as Book")
+	})
+
+	t.Run("allows swapping class names", func(t *testing.T) {
+		_, err := validateRename(classes, map[string]string{"Article": "Author", "Author": "Article"})
+		assert.Nil(t, err)
+	})
+}
+
+func TestRenameClass(t *testing.T) {
+	newClassSnapshot := func(t *testing.T, class *models.Class) *backup.ClassSnapshot {
+		classSchema, err := json.Marshal(class)
+		require.Nil(t, err)
+		shardingState, err := json.Marshal(sharding.State{
+			IndexID: class.Class,
+			Physical: map[string]sharding.Physical{
+				"shard1": {Name: "shard1", BelongsToNode: "node1"},
+			},
+		})
+		require.Nil(t, err)
+
+		classSnap := backup.NewClassSnapshot(class.Class)
+		classSnap.Schema = classSchema
+		classSnap.ShardingState = shardingState
+		classSnap.AddShard("shard1", &backup.ShardSnapshot{
+			Files: []backup.SnapshotFile{{
+				Class: class.Class, Node: "node1", Shard: "shard1",
+				Path: "article_shard1_lsm/objects/segment-1.db",
+			}},
+			Metadata: &backup.ShardMetadata{ShardVersion: []byte("1")},
+		})
+		return classSnap
+	}
+
+	article := &models.Class{
+		Class: "Article",
+		Properties: []*models.Property{
+			{Name: "title", DataType: []string{"string"}},
+			{Name: "hasAuthors", DataType: []string{"Author", "Publication"}},
+			{Name: "relatedArticles", DataType: []string{"Article"}},
+		},
+	}
+
+	t.Run("keeps the snapshot if nothing is renamed", func(t *testing.T) {
+		classSnap := newClassSnapshot(t, article)
+
+		renamed, err := renameClass(classSnap, nil)
+		require.Nil(t, err)
+		assert.Same(t, classSnap, renamed)
+	})
+
+	t.Run("renames class and references", func(t *testing.T) {
+		classSnap := newClassSnapshot(t, article)
+
+		renamed, err := renameClass(classSnap, map[string]string{
+			"Article": "Article_restored",
+			"Author":  "Author_restored",
+		})
+		require.Nil(t, err)
+		assert.Equal(t, "Article_restored", renamed.Name)
+		assert.Equal(t, classSnap.Files, renamed.Files)
+		assert.Equal(t, "Article", renamed.Files[0].Class)
+		assert.Equal(t, classSnap.ShardMetadata, renamed.ShardMetadata)
+
+		var class models.Class
+		require.Nil(t, json.Unmarshal(renamed.Schema, &class))
+		assert.Equal(t, "Article_restored", class.Class)
+		assert.Equal(t, []string{"string"}, class.Properties[0].DataType)
+		assert.Equal(t, []string{"Author_restored", "Publication"}, class.Properties[1].DataType)
+		assert.Equal(t, []string{"Article_restored"}, class.Properties[2].DataType)
+
+		var state sharding.State
+		require.Nil(t, json.Unmarshal(renamed.ShardingState, &state))
+		assert.Equal(t, "Article_restored", state.IndexID)
+		assert.Equal(t, "node1", state.Physical["shard1"].BelongsToNode)
+
+		// the original snapshot is left untouched
+		require.Nil(t, json.Unmarshal(classSnap.Schema, &class))
+		assert.Equal(t, "Article", class.Class)
+	})
+
+	t.Run("renames references of a class which keeps its name", func(t *testing.T) {
+		classSnap := newClassSnapshot(t, article)
+
+		renamed, err := renameClass(classSnap, map[string]string{"Author": "Author_restored"})
+		require.Nil(t, err)
+		assert.Equal(t, "Article", renamed.Name)
+		assert.Equal(t, classSnap.ShardingState, renamed.ShardingState)
+
+		var class models.Class
+		require.Nil(t, json.Unmarshal(renamed.Schema, &class))
+		assert.Equal(t, "Article", class.Class)
+		assert.Equal(t, []string{"Author_restored", "Publication"}, class.Properties[1].DataType)
+	})
+
+	t.Run("fails on invalid schema", func(t *testing.T) {
+		classSnap := newClassSnapshot(t, article)
+		classSnap.Schema = []byte("{")
+
+		_, err := renameClass(classSnap, map[string]string{"Article": "Article_restored"})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unmarshal schema of Article")
+	})
+}
@@ -13,6 +13,7 @@ package backup
 
 import (
 	"context"
+	"io"
 
 	"github.com/semi-technologies/weaviate/entities/backup"
 )
@@ -21,20 +22,41 @@ import (
 type Sourcer interface { // implemented by the index
 	// CreateBackup creates a snapshot which is metadata referencing files on disk.
 	// While the snapshot exists, the index makes sure that those files are never
-	// changed, for example by stopping compactions.
+	// changed, for example by stopping compactions. The snapshot covers all
+	// shards of the index, including the ones on remote nodes.
 	//
 	// The index stays usable with a snapshot present, it can still accept
 	// reads+writes, as the index is built in an append-only-way.
 	//
 	// Snapshot() fails if another snapshot exists.
-	CreateBackup(ctx context.Context, snapshot *backup.Snapshot) (*backup.Snapshot, error)
+	CreateBackup(ctx context.Context, snapshotID string) (*backup.ClassSnapshot, error)
 
 	// ReleaseBackup signals to the underlying index that the files have been
 	// copied (or the operation aborted), and that it is safe for the index to
 	// change the files, such as start compactions.
-	ReleaseBackup(ctx context.Context, id string) error
+	ReleaseBackup(ctx context.Context, snapshotID string) error
+
+	// ReadBackupFile opens a file referenced by a snapshot created earlier,
+	// no matter which node the file lives on. The caller is responsible for
+	// closing the returned reader.
+	ReadBackupFile(ctx context.Context, file backup.SnapshotFile) (io.ReadCloser, error)
 }
 
 type SourceFactory interface {
 	SourceFactory(className string) Sourcer
 }
+
+// Restorer writes the contents of a backup back to disk, before the
+// restored classes are added to the schema.
+type Restorer interface { // implemented by the db
+	// RestoreBackupFile writes a single file to the node which owned it at
+	// the time of the backup. The file is restored as part of the specified
+	// class, which may differ from the class it was backed up from.
+	RestoreBackupFile(ctx context.Context, className string,
+		file backup.SnapshotFile, r io.Reader) error
+
+	// RestoreShardMetadata writes the metadata of a single shard to the
+	// specified node
+	RestoreShardMetadata(ctx context.Context, className, shardName,
+		nodeName string, meta *backup.ShardMetadata) error
+}
@@ -17,6 +17,7 @@ package classification_integration_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"sync"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
+	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/filters"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
@@ -349,7 +351,7 @@ func (f *fakeRemoteClient) MergeObject(ctx context.Context, hostName, indexName,
 }
 
 func (f *fakeRemoteClient) SearchShard(ctx context.Context, hostName, indexName,
-	shardName string, vector []float32, limit int, filters *filters.LocalFilter,
+	shardName string, vector []float32, targetVector string, limit int, filters *filters.LocalFilter,
 	keywordRanking *searchparams.KeywordRanking, sort []filters.Sort,
 	additional additional.Properties,
 ) ([]*storobj.Object, []float32, error) {
@@ -398,12 +400,42 @@ func (f *fakeRemoteClient) GetShardStatus(ctx context.Context,
 	return "", nil
 }
 
+func (f *fakeRemoteClient) GetShardReindexStatus(ctx context.Context,
+	hostName, indexName, shardName string,
+) ([]*models.PropertyReindexStatus, error) {
+	return nil, nil
+}
+
 func (f *fakeRemoteClient) UpdateShardStatus(ctx context.Context, hostName, indexName, shardName,
 	targetStatus string,
 ) error {
 	return nil
 }
 
+func (f *fakeRemoteClient) CreateShardBackup(ctx context.Context, hostName, indexName,
+	shardName, snapshotID string,
+) (*backup.ShardSnapshot, error) {
+	return nil, nil
+}
+
+func (f *fakeRemoteClient) ReleaseShardBackup(ctx context.Context, hostName, indexName,
+	shardName, snapshotID string,
+) error {
+	return nil
+}
+
+func (f *fakeRemoteClient) GetShardBackupFile(ctx context.Context, hostName, indexName,
+	shardName, relPath string,
+) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (f *fakeRemoteClient) PutShardBackupFile(ctx context.Context, hostName, indexName,
+	shardName, relPath string, r io.Reader,
+) error {
+	return nil
+}
+
 type fakeNodeResolver struct{}
 
 func (f *fakeNodeResolver) NodeHostname(string) (string, bool) {
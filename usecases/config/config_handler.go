@@ -61,6 +61,7 @@ type Config struct {
 	Debug                     bool           `json:"debug" yaml:"debug"`
 	QueryDefaults             QueryDefaults  `json:"query_defaults" yaml:"query_defaults"`
 	QueryMaximumResults       int64          `json:"query_maximum_results" yaml:"query_maximum_results"`
+	HybridCandidates          int64          `json:"hybrid_candidates" yaml:"hybrid_candidates"`
 	Contextionary             Contextionary  `json:"contextionary" yaml:"contextionary"`
 	Authentication            Authentication `json:"authentication" yaml:"authentication"`
 	Authorization             Authorization  `json:"authorization" yaml:"authorization"`
@@ -130,6 +130,17 @@ func FromEnv(config *Config) error {
 		config.QueryMaximumResults = DefaultQueryMaximumResults
 	}
 
+	if v := os.Getenv("HYBRID_CANDIDATES"); v != "" {
+		asInt, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.Wrapf(err, "parse HYBRID_CANDIDATES as int")
+		}
+
+		config.HybridCandidates = int64(asInt)
+	} else {
+		config.HybridCandidates = DefaultHybridCandidates
+	}
+
 	if v := os.Getenv("MAX_IMPORT_GOROUTINES_FACTOR"); v != "" {
 		asFloat, err := strconv.ParseFloat(v, 64)
 		if err != nil {
@@ -217,6 +228,11 @@ func FromEnv(config *Config) error {
 
 const DefaultQueryMaximumResults = int64(10000)
 
+// DefaultHybridCandidates is the number of results of both the keyword and
+// the vector search which are fused by a hybrid search, unless more are
+// needed for the requested page
+const DefaultHybridCandidates = int64(100)
+
 const VectorizerModuleNone = "none"
 
 // TODO: This should be retrieved dynamically from all installed modules
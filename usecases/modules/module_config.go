@@ -17,8 +17,9 @@ import (
 )
 
 type ClassBasedModuleConfig struct {
-	class      *models.Class
-	moduleName string
+	class        *models.Class
+	moduleName   string
+	targetVector string
 }
 
 func NewClassBasedModuleConfig(class *models.Class,
@@ -30,8 +31,25 @@ func NewClassBasedModuleConfig(class *models.Class,
 	}
 }
 
+// NewNamedVectorModuleConfig is like NewClassBasedModuleConfig, but the class
+// config is the vectorizer config of the named vector instead of the module
+// config of the class. The property configs are shared with the class.
+func NewNamedVectorModuleConfig(class *models.Class,
+	moduleName, targetVector string,
+) *ClassBasedModuleConfig {
+	return &ClassBasedModuleConfig{
+		class:        class,
+		moduleName:   moduleName,
+		targetVector: targetVector,
+	}
+}
+
 func (cbmc *ClassBasedModuleConfig) Class() map[string]interface{} {
 	defaultConf := map[string]interface{}{}
+	if cbmc.targetVector != "" {
+		return cbmc.namedVector(defaultConf)
+	}
+
 	asMap, ok := cbmc.class.ModuleConfig.(map[string]interface{})
 	if !ok {
 		return defaultConf
@@ -50,6 +68,15 @@ func (cbmc *ClassBasedModuleConfig) Class() map[string]interface{} {
 	return asMap
 }
 
+func (cbmc *ClassBasedModuleConfig) namedVector(defaultConf map[string]interface{}) map[string]interface{} {
+	moduleName, moduleCfg, err := schema.NamedVectorizer(cbmc.class.VectorConfig[cbmc.targetVector])
+	if err != nil || moduleName != cbmc.moduleName || moduleCfg == nil {
+		return defaultConf
+	}
+
+	return moduleCfg
+}
+
 func (cbmc *ClassBasedModuleConfig) Property(propName string) map[string]interface{} {
 	defaultConf := map[string]interface{}{}
 	prop, err := schema.GetPropertyByName(cbmc.class, propName)
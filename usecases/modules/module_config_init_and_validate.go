@@ -21,24 +21,33 @@ import (
 )
 
 // SetClassDefaults sets the module-specific defaults for the class itself, but
-// also for each prop
+// also for each prop. This applies to the vectorizer of the class as well as
+// to the vectorizers of its named vectors.
 func (p *Provider) SetClassDefaults(class *models.Class) {
-	if class.Vectorizer == "none" {
-		// the class does not use a vectorizer, nothing to do for us
-		return
-	}
+	if cc, ok := p.classConfigurator(class.Vectorizer); ok {
+		cfg := NewClassBasedModuleConfig(class, class.Vectorizer)
 
-	mod := p.GetByName(class.Vectorizer)
-	cc, ok := mod.(modulecapabilities.ClassConfigurator)
-	if !ok {
-		// the module exists, but is not a class configurator, nothing to do for us
-		return
+		p.setPerClassConfigDefaults(class, cfg, cc)
+		p.setPerPropertyConfigDefaults(class, class.Vectorizer, cfg, cc)
 	}
 
-	cfg := NewClassBasedModuleConfig(class, class.Vectorizer)
+	for targetVector, vectorCfg := range class.VectorConfig {
+		moduleName, _, err := schema.NamedVectorizer(vectorCfg)
+		if err != nil {
+			// invalid configs are rejected by the validation
+			continue
+		}
+
+		cc, ok := p.classConfigurator(moduleName)
+		if !ok {
+			continue
+		}
 
-	p.setPerClassConfigDefaults(class, cfg, cc)
-	p.setPerPropertyConfigDefaults(class, cfg, cc)
+		cfg := NewNamedVectorModuleConfig(class, moduleName, targetVector)
+
+		p.setNamedVectorConfigDefaults(class, targetVector, moduleName, cfg, cc)
+		p.setPerPropertyConfigDefaults(class, moduleName, cfg, cc)
+	}
 }
 
 // SetSinglePropertyDefaults can be used when a property is added later, e.g.
@@ -46,26 +55,60 @@ func (p *Provider) SetClassDefaults(class *models.Class) {
 func (p *Provider) SetSinglePropertyDefaults(class *models.Class,
 	prop *models.Property,
 ) {
-	if class.Vectorizer == "none" {
-		// the class does not use a vectorizer, nothing to do for us
-		return
+	if cc, ok := p.classConfigurator(class.Vectorizer); ok {
+		cfg := NewClassBasedModuleConfig(class, class.Vectorizer)
+		p.setSinglePropertyConfigDefaults(class, prop, class.Vectorizer, cfg, cc)
 	}
 
-	mod := p.GetByName(class.Vectorizer)
-	cc, ok := mod.(modulecapabilities.ClassConfigurator)
-	if !ok {
-		// the module exists, but is not a class configurator, nothing to do for us
-		return
+	for targetVector, vectorCfg := range class.VectorConfig {
+		moduleName, _, err := schema.NamedVectorizer(vectorCfg)
+		if err != nil {
+			continue
+		}
+
+		if cc, ok := p.classConfigurator(moduleName); ok {
+			cfg := NewNamedVectorModuleConfig(class, moduleName, targetVector)
+			p.setSinglePropertyConfigDefaults(class, prop, moduleName, cfg, cc)
+		}
 	}
+}
 
-	cfg := NewClassBasedModuleConfig(class, class.Vectorizer)
+// classConfigurator returns the module if it exists and is a class
+// configurator. There is nothing to do for classes which do not use a
+// vectorizer.
+func (p *Provider) classConfigurator(moduleName string) (modulecapabilities.ClassConfigurator, bool) {
+	if moduleName == "none" {
+		return nil, false
+	}
 
-	p.setSinglePropertyConfigDefaults(class, prop, cfg, cc)
+	cc, ok := p.GetByName(moduleName).(modulecapabilities.ClassConfigurator)
+	return cc, ok
 }
 
 func (p *Provider) setPerClassConfigDefaults(class *models.Class,
 	cfg *ClassBasedModuleConfig, cc modulecapabilities.ClassConfigurator,
 ) {
+	if class.ModuleConfig == nil {
+		class.ModuleConfig = map[string]interface{}{}
+	}
+
+	class.ModuleConfig.(map[string]interface{})[class.Vectorizer] = mergedClassConfig(cfg, cc)
+}
+
+func (p *Provider) setNamedVectorConfigDefaults(class *models.Class,
+	targetVector, moduleName string, cfg *ClassBasedModuleConfig,
+	cc modulecapabilities.ClassConfigurator,
+) {
+	vectorCfg := class.VectorConfig[targetVector]
+	vectorCfg.Vectorizer = map[string]interface{}{
+		moduleName: mergedClassConfig(cfg, cc),
+	}
+	class.VectorConfig[targetVector] = vectorCfg
+}
+
+func mergedClassConfig(cfg *ClassBasedModuleConfig,
+	cc modulecapabilities.ClassConfigurator,
+) map[string]interface{} {
 	modDefaults := cc.ClassConfigDefaults()
 	userSpecified := cfg.Class()
 	mergedConfig := map[string]interface{}{}
@@ -77,23 +120,20 @@ func (p *Provider) setPerClassConfigDefaults(class *models.Class,
 		mergedConfig[key] = value
 	}
 
-	if class.ModuleConfig == nil {
-		class.ModuleConfig = map[string]interface{}{}
-	}
-
-	class.ModuleConfig.(map[string]interface{})[class.Vectorizer] = mergedConfig
+	return mergedConfig
 }
 
 func (p *Provider) setPerPropertyConfigDefaults(class *models.Class,
-	cfg *ClassBasedModuleConfig, cc modulecapabilities.ClassConfigurator,
+	moduleName string, cfg *ClassBasedModuleConfig,
+	cc modulecapabilities.ClassConfigurator,
 ) {
 	for _, prop := range class.Properties {
-		p.setSinglePropertyConfigDefaults(class, prop, cfg, cc)
+		p.setSinglePropertyConfigDefaults(class, prop, moduleName, cfg, cc)
 	}
 }
 
 func (p *Provider) setSinglePropertyConfigDefaults(class *models.Class,
-	prop *models.Property, cfg *ClassBasedModuleConfig,
+	prop *models.Property, moduleName string, cfg *ClassBasedModuleConfig,
 	cc modulecapabilities.ClassConfigurator,
 ) {
 	dt, _ := schema.GetPropertyDataType(class, prop.Name)
@@ -112,26 +152,32 @@ func (p *Provider) setSinglePropertyConfigDefaults(class *models.Class,
 		prop.ModuleConfig = map[string]interface{}{}
 	}
 
-	prop.ModuleConfig.(map[string]interface{})[class.Vectorizer] = mergedConfig
+	prop.ModuleConfig.(map[string]interface{})[moduleName] = mergedConfig
 }
 
 func (p *Provider) ValidateClass(ctx context.Context, class *models.Class) error {
-	if class.Vectorizer == "none" {
-		// the class does not use a vectorizer, nothing to do for us
-		return nil
-	}
-
-	mod := p.GetByName(class.Vectorizer)
-	cc, ok := mod.(modulecapabilities.ClassConfigurator)
-	if !ok {
-		// the module exists, but is not a class configurator, nothing to do for us
-		return nil
+	if cc, ok := p.classConfigurator(class.Vectorizer); ok {
+		cfg := NewClassBasedModuleConfig(class, class.Vectorizer)
+		if err := cc.ValidateClass(ctx, class, cfg); err != nil {
+			return errors.Wrapf(err, "module '%s'", class.Vectorizer)
+		}
 	}
 
-	cfg := NewClassBasedModuleConfig(class, class.Vectorizer)
-	err := cc.ValidateClass(ctx, class, cfg)
-	if err != nil {
-		return errors.Wrapf(err, "module '%s'", class.Vectorizer)
+	for targetVector, vectorCfg := range class.VectorConfig {
+		moduleName, _, err := schema.NamedVectorizer(vectorCfg)
+		if err != nil {
+			return errors.Wrapf(err, "named vector %q", targetVector)
+		}
+
+		cc, ok := p.classConfigurator(moduleName)
+		if !ok {
+			continue
+		}
+
+		cfg := NewNamedVectorModuleConfig(class, moduleName, targetVector)
+		if err := cc.ValidateClass(ctx, class, cfg); err != nil {
+			return errors.Wrapf(err, "named vector %q: module '%s'", targetVector, moduleName)
+		}
 	}
 
 	return nil
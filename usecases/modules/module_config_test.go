@@ -90,4 +90,41 @@ func TestClassBasedModuleConfig(t *testing.T) {
 		assert.Equal(t, map[string]interface{}{"propLevel": "bar"},
 			cfg.Property("some-prop"))
 	})
+
+	t.Run("for a named vector", func(t *testing.T) {
+		class := &models.Class{
+			Class: "Test",
+			ModuleConfig: map[string]interface{}{
+				"my-module": map[string]interface{}{
+					"classLevel": "foo",
+				},
+			},
+			VectorConfig: map[string]models.VectorConfig{
+				"title": {
+					Vectorizer: map[string]interface{}{
+						"my-module": map[string]interface{}{
+							"classLevel": "baz",
+						},
+					},
+				},
+			},
+			Properties: []*models.Property{
+				{
+					Name: "some-prop",
+					ModuleConfig: map[string]interface{}{
+						"my-module": map[string]interface{}{
+							"propLevel": "bar",
+						},
+					},
+				},
+			},
+		}
+		cfg := NewNamedVectorModuleConfig(class, "my-module", "title")
+		assert.Equal(t, map[string]interface{}{"classLevel": "baz"}, cfg.Class())
+		assert.Equal(t, map[string]interface{}{"propLevel": "bar"},
+			cfg.Property("some-prop"))
+
+		cfg = NewNamedVectorModuleConfig(class, "other-module", "title")
+		assert.Equal(t, map[string]interface{}{}, cfg.Class())
+	})
 }
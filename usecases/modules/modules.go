@@ -243,7 +243,19 @@ func (m *Provider) moduleProvidesMultipleVectorizers(moduleType modulecapabiliti
 func (m *Provider) shouldIncludeClassArgument(class *models.Class, module string,
 	moduleType modulecapabilities.ModuleType,
 ) bool {
-	return class.Vectorizer == module || !m.isVectorizerModule(moduleType)
+	return class.Vectorizer == module || !m.isVectorizerModule(moduleType) ||
+		usesNamedVectorizer(class, module)
+}
+
+// usesNamedVectorizer is true if any of the named vectors of the class is
+// vectorized by the module
+func usesNamedVectorizer(class *models.Class, module string) bool {
+	for _, cfg := range class.VectorConfig {
+		if name, _, err := schema.NamedVectorizer(cfg); err == nil && name == module {
+			return true
+		}
+	}
+	return false
 }
 
 func (m *Provider) shouldCrossClassIncludeClassArgument(class *models.Class, module string,
@@ -588,12 +600,24 @@ func (m *Provider) VectorFromSearchParam(ctx context.Context,
 		return nil, err
 	}
 
+	targetVector := ""
+	if nearParam, ok := params.(modulecapabilities.NearParam); ok {
+		targetVector = nearParam.GetTargetVector()
+	}
+	vectorizer, err := m.targetVectorizer(class, targetVector)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, mod := range m.GetAll() {
-		if m.shouldIncludeClassArgument(class, mod.Name(), mod.Type()) {
+		if mod.Name() == vectorizer || !m.isVectorizerModule(mod.Type()) {
 			if searcher, ok := mod.(modulecapabilities.Searcher); ok {
 				if vectorSearches := searcher.VectorSearches(); vectorSearches != nil {
 					if searchVectorFn := vectorSearches[param]; searchVectorFn != nil {
 						cfg := NewClassBasedModuleConfig(class, mod.Name())
+						if targetVector != "" && mod.Name() == vectorizer {
+							cfg = NewNamedVectorModuleConfig(class, mod.Name(), targetVector)
+						}
 						vector, err := searchVectorFn(ctx, params, class.Class, findVectorFn, cfg)
 						if err != nil {
 							return nil, errors.Errorf("vectorize params: %v", err)
@@ -605,9 +629,91 @@ func (m *Provider) VectorFromSearchParam(ctx context.Context,
 		}
 	}
 
+	if targetVector != "" {
+		return nil, errors.Errorf("the vectorizer %q of named vector %q does not "+
+			"support %s", vectorizer, targetVector, param)
+	}
+
 	panic("VectorFromParams was called without any known params present")
 }
 
+// VectorFromInput vectorizes a plain text input with the vectorizer of the
+// class, or of the named vector if a target vector is set. This is used by
+// the hybrid search which only receives a query string rather than a
+// module-specific nearText argument
+func (m *Provider) VectorFromInput(ctx context.Context,
+	className, targetVector, input string,
+) ([]float32, error) {
+	class, err := m.getClass(className)
+	if err != nil {
+		return nil, err
+	}
+
+	vectorizer, err := m.targetVectorizer(class, targetVector)
+	if err != nil {
+		return nil, err
+	}
+
+	mod := m.GetByName(vectorizer)
+	if mod == nil {
+		return nil, errors.Errorf("class %q has no vectorizer module to vectorize "+
+			"the input, a vector needs to be provided", class.Class)
+	}
+
+	arguments, ok := mod.(modulecapabilities.GraphQLArguments)
+	if !ok {
+		return nil, errors.Errorf("vectorizer %q cannot vectorize text input", vectorizer)
+	}
+	searcher, ok := mod.(modulecapabilities.Searcher)
+	if !ok {
+		return nil, errors.Errorf("vectorizer %q cannot vectorize text input", vectorizer)
+	}
+
+	arg, ok := arguments.Arguments()["nearText"]
+	searchVectorFn := searcher.VectorSearches()["nearText"]
+	if !ok || arg.ExtractFunction == nil || searchVectorFn == nil {
+		return nil, errors.Errorf("vectorizer %q cannot vectorize text input", vectorizer)
+	}
+
+	params := arg.ExtractFunction(map[string]interface{}{
+		"concepts": []interface{}{input},
+	})
+
+	cfg := NewClassBasedModuleConfig(class, mod.Name())
+	if targetVector != "" {
+		cfg = NewNamedVectorModuleConfig(class, mod.Name(), targetVector)
+	}
+
+	vector, err := searchVectorFn(ctx, params, class.Class, nil, cfg)
+	if err != nil {
+		return nil, errors.Errorf("vectorize input: %v", err)
+	}
+	return vector, nil
+}
+
+// targetVectorizer returns the name of the vectorizer module responsible for
+// the target vector. A search on a named vector has to be vectorized by the
+// vectorizer of that named vector, not the one of the class
+func (m *Provider) targetVectorizer(class *models.Class,
+	targetVector string,
+) (string, error) {
+	if targetVector == "" {
+		return class.Vectorizer, nil
+	}
+
+	vectorCfg, ok := class.VectorConfig[targetVector]
+	if !ok {
+		return "", errors.Errorf("class %q has no named vector %q", class.Class, targetVector)
+	}
+
+	vectorizer, _, err := schema.NamedVectorizer(vectorCfg)
+	if err != nil {
+		return "", errors.Wrapf(err, "named vector %q", targetVector)
+	}
+
+	return vectorizer, nil
+}
+
 // CrossClassVectorFromSearchParam gets a vector for a given argument without
 // being specific to any one class and it's configuration. This is used in
 // Explore() { } for example
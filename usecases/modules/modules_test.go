@@ -14,6 +14,7 @@ package modules
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"testing"
 
@@ -492,30 +493,42 @@ func (m *dummyStorageModuleWithAltNames) Type() modulecapabilities.ModuleType {
 	return modulecapabilities.Storage
 }
 
-func (m *dummyStorageModuleWithAltNames) StoreSnapshot(ctx context.Context, snapshot *backup.Snapshot) error {
+func (m *dummyStorageModuleWithAltNames) PutObject(ctx context.Context, snapshotID, key string, r io.Reader) error {
 	return nil
 }
 
-func (m *dummyStorageModuleWithAltNames) RestoreSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
+func (m *dummyStorageModuleWithAltNames) GetObject(ctx context.Context, snapshotID, key string) (io.ReadCloser, error) {
 	return nil, nil
 }
 
-func (m *dummyStorageModuleWithAltNames) SetMetaStatus(ctx context.Context, className, snapshotID, status string) error {
+func (m *dummyStorageModuleWithAltNames) SetMetaStatus(ctx context.Context, snapshotID, status string) error {
 	return nil
 }
 
-func (m *dummyStorageModuleWithAltNames) SetMetaError(ctx context.Context, className, snapshotID string, err error) error {
+func (m *dummyStorageModuleWithAltNames) SetMetaError(ctx context.Context, snapshotID string, err error) error {
 	return nil
 }
 
-func (m *dummyStorageModuleWithAltNames) GetMeta(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
+func (m *dummyStorageModuleWithAltNames) GetMeta(ctx context.Context, snapshotID string) (*backup.Snapshot, error) {
 	return nil, nil
 }
 
-func (m *dummyStorageModuleWithAltNames) DestinationPath(className, snapshotId string) string {
+func (m *dummyStorageModuleWithAltNames) PutMeta(ctx context.Context, snapshot *backup.Snapshot) error {
+	return nil
+}
+
+func (m *dummyStorageModuleWithAltNames) DestinationPath(snapshotId string) string {
 	return ""
 }
 
-func (m *dummyStorageModuleWithAltNames) InitSnapshot(ctx context.Context, className, snapshotID string) (*backup.Snapshot, error) {
+func (m *dummyStorageModuleWithAltNames) InitSnapshot(ctx context.Context, snapshotID string, classes []string) (*backup.Snapshot, error) {
 	return nil, nil
 }
+
+func (m *dummyStorageModuleWithAltNames) ListSnapshots(ctx context.Context) ([]*backup.Snapshot, error) {
+	return nil, nil
+}
+
+func (m *dummyStorageModuleWithAltNames) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	return nil
+}
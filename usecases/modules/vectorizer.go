@@ -58,6 +58,36 @@ func (m *Provider) Vectorizer(moduleName, className string) (objects.Vectorizer,
 	return NewObjectsVectorizer(vec, cfg), nil
 }
 
+// NamedVectorizer is like Vectorizer, but configures the module with the
+// vectorizer config of the named vector
+func (m *Provider) NamedVectorizer(moduleName, className,
+	targetVector string,
+) (objects.Vectorizer, error) {
+	mod := m.GetByName(moduleName)
+	if mod == nil {
+		return nil, errors.Errorf("no module with name %q present", moduleName)
+	}
+
+	vec, ok := mod.(modulecapabilities.Vectorizer)
+	if !ok {
+		return nil, errors.Errorf("module %q exists, but does not provide the "+
+			"Vectorizer capability", moduleName)
+	}
+
+	sch := m.schemaGetter.GetSchemaSkipAuth()
+	class := sch.FindClassByName(schema.ClassName(className))
+	if class == nil {
+		return nil, errors.Errorf("class %q not found in schema", className)
+	}
+
+	if _, ok := class.VectorConfig[targetVector]; !ok {
+		return nil, errors.Errorf("class %q has no named vector %q", className, targetVector)
+	}
+
+	cfg := NewNamedVectorModuleConfig(class, moduleName, targetVector)
+	return NewObjectsVectorizer(vec, cfg), nil
+}
+
 type ObjectsVectorizer struct {
 	modVectorizer modulecapabilities.Vectorizer
 	cfg           *ClassBasedModuleConfig
@@ -0,0 +1,201 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package nodes
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/config"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
+	"github.com/sirupsen/logrus"
+)
+
+// remoteStatusTimeout limits how long a single node may take to report its
+// status, so that an unresponsive node cannot hold up the entire response
+const remoteStatusTimeout = 10 * time.Second
+
+type authorizer interface {
+	Authorize(principal *models.Principal, verb, resource string) error
+}
+
+type db interface {
+	LocalNodeShards() []*models.NodeShardStatus
+}
+
+type schemaManager interface {
+	GetSchemaSkipAuth() schema.Schema
+	ShardingState(className string) *sharding.State
+}
+
+type cluster interface {
+	AllNames() []string
+	LocalName() string
+	NodeHostname(nodeName string) (string, bool)
+}
+
+type client interface {
+	GetNodeStatus(ctx context.Context, hostName string) (*models.NodeStatus, error)
+}
+
+type Manager struct {
+	logger     logrus.FieldLogger
+	authorizer authorizer
+	db         db
+	schema     schemaManager
+	cluster    cluster
+	client     client
+}
+
+func NewManager(logger logrus.FieldLogger, authorizer authorizer, db db,
+	schema schemaManager, cluster cluster, client client,
+) *Manager {
+	return &Manager{
+		logger:     logger,
+		authorizer: authorizer,
+		db:         db,
+		schema:     schema,
+		cluster:    cluster,
+		client:     client,
+	}
+}
+
+// GetNodeStatuses returns the status of every node which is either a live
+// member of the cluster or holds a shard according to the sharding states.
+// A node holding shards which is no longer a member of the cluster is
+// reported as unavailable, a member which cannot report its status as
+// unhealthy.
+func (m *Manager) GetNodeStatuses(ctx context.Context,
+	principal *models.Principal,
+) (*models.NodesStatusResponse, error) {
+	if err := m.authorizer.Authorize(principal, "list", "nodes"); err != nil {
+		return nil, err
+	}
+
+	live := map[string]struct{}{}
+	for _, name := range m.cluster.AllNames() {
+		live[name] = struct{}{}
+	}
+
+	names := m.nodeNames(live)
+	statuses := make([]*models.NodeStatus, len(names))
+
+	wg := &sync.WaitGroup{}
+	for i, name := range names {
+		if _, ok := live[name]; !ok {
+			statuses[i] = &models.NodeStatus{
+				Name:   name,
+				Status: models.NodeStatusStatusUNAVAILABLE,
+			}
+			continue
+		}
+
+		if name == m.cluster.LocalName() {
+			statuses[i] = m.LocalNodeStatus()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			statuses[i] = m.remoteNodeStatus(ctx, name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	return &models.NodesStatusResponse{Nodes: statuses}, nil
+}
+
+// LocalNodeStatus returns the status of this node. It is not user facing,
+// but served to the other nodes of the cluster which aggregate the statuses.
+func (m *Manager) LocalNodeStatus() *models.NodeStatus {
+	shards := m.db.LocalNodeShards()
+	stats := &models.NodeStats{ShardCount: int64(len(shards))}
+	for _, shard := range shards {
+		stats.ObjectCount += shard.ObjectCount
+	}
+
+	return &models.NodeStatus{
+		Name:    m.cluster.LocalName(),
+		Status:  models.NodeStatusStatusHEALTHY,
+		Version: config.ServerVersion,
+		Stats:   stats,
+		Shards:  shards,
+	}
+}
+
+func (m *Manager) remoteNodeStatus(ctx context.Context,
+	name string,
+) *models.NodeStatus {
+	unhealthy := &models.NodeStatus{
+		Name:   name,
+		Status: models.NodeStatusStatusUNHEALTHY,
+	}
+
+	host, ok := m.cluster.NodeHostname(name)
+	if !ok {
+		// the node left the cluster after the member list was retrieved
+		unhealthy.Status = models.NodeStatusStatusUNAVAILABLE
+		return unhealthy
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteStatusTimeout)
+	defer cancel()
+
+	status, err := m.client.GetNodeStatus(ctx, host)
+	if err != nil {
+		m.logger.WithField("action", "nodes_status").
+			WithField("node", name).
+			WithError(err).
+			Warn("node did not report its status")
+		return unhealthy
+	}
+
+	return status
+}
+
+// nodeNames returns the names of the live nodes and of the nodes holding a
+// replica of any shard, sorted by name
+func (m *Manager) nodeNames(live map[string]struct{}) []string {
+	unique := map[string]struct{}{}
+	for name := range live {
+		unique[name] = struct{}{}
+	}
+
+	sch := m.schema.GetSchemaSkipAuth()
+	if sch.Objects != nil {
+		for _, class := range sch.Objects.Classes {
+			state := m.schema.ShardingState(class.Class)
+			if state == nil {
+				continue
+			}
+
+			for _, physical := range state.Physical {
+				for _, node := range physical.Nodes() {
+					unique[node] = struct{}{}
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(unique))
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
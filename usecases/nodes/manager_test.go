@@ -0,0 +1,173 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package nodes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNodeStatuses(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+
+	states := map[string]*sharding.State{
+		"Article": {
+			Physical: map[string]sharding.Physical{
+				"shard1": {Name: "shard1", BelongsToNode: "node1", ReplicaNodes: []string{"node2"}},
+				"shard2": {Name: "shard2", BelongsToNode: "node3"},
+				"shard3": {Name: "shard3", BelongsToNode: "node4"},
+			},
+		},
+	}
+	cluster := &fakeCluster{
+		local: "node1",
+		live:  []string{"node1", "node2", "node4"},
+	}
+	client := &fakeClient{
+		statuses: map[string]*models.NodeStatus{
+			"node2:7947": {
+				Name:   "node2",
+				Status: models.NodeStatusStatusHEALTHY,
+				Stats:  &models.NodeStats{ShardCount: 1, ObjectCount: 7},
+				Shards: []*models.NodeShardStatus{
+					{Name: "shard1", Class: "Article", ObjectCount: 7},
+				},
+			},
+		},
+	}
+	db := &fakeDB{shards: []*models.NodeShardStatus{
+		{Name: "shard1", Class: "Article", ObjectCount: 7},
+		{Name: "shard4", Class: "Book", ObjectCount: 3},
+	}}
+
+	t.Run("reports the status of every node", func(t *testing.T) {
+		m := NewManager(logger, &fakeAuthorizer{}, db, &fakeSchema{states}, cluster, client)
+
+		res, err := m.GetNodeStatuses(context.Background(), nil)
+		require.Nil(t, err)
+		require.Len(t, res.Nodes, 4)
+
+		local := res.Nodes[0]
+		assert.Equal(t, "node1", local.Name)
+		assert.Equal(t, models.NodeStatusStatusHEALTHY, local.Status)
+		assert.Equal(t, &models.NodeStats{ShardCount: 2, ObjectCount: 10}, local.Stats)
+		assert.Equal(t, db.shards, local.Shards)
+
+		assert.Equal(t, client.statuses["node2:7947"], res.Nodes[1])
+
+		// node3 holds a shard, but is not a member of the cluster anymore
+		assert.Equal(t, &models.NodeStatus{
+			Name:   "node3",
+			Status: models.NodeStatusStatusUNAVAILABLE,
+		}, res.Nodes[2])
+
+		// node4 is a member of the cluster, but did not answer
+		assert.Equal(t, &models.NodeStatus{
+			Name:   "node4",
+			Status: models.NodeStatusStatusUNHEALTHY,
+		}, res.Nodes[3])
+	})
+
+	t.Run("requires authorization", func(t *testing.T) {
+		authorizer := &fakeAuthorizer{err: errors.New("forbidden")}
+		m := NewManager(logger, authorizer, db, &fakeSchema{states}, cluster, client)
+		principal := &models.Principal{}
+
+		_, err := m.GetNodeStatuses(context.Background(), principal)
+		assert.Equal(t, authorizer.err, err)
+		assert.Equal(t, []authorizeCall{{principal, "list", "nodes"}}, authorizer.calls)
+	})
+}
+
+type authorizeCall struct {
+	principal *models.Principal
+	verb      string
+	resource  string
+}
+
+type fakeAuthorizer struct {
+	err   error
+	calls []authorizeCall
+}
+
+func (a *fakeAuthorizer) Authorize(principal *models.Principal, verb, resource string) error {
+	a.calls = append(a.calls, authorizeCall{principal, verb, resource})
+	return a.err
+}
+
+type fakeDB struct {
+	shards []*models.NodeShardStatus
+}
+
+func (f *fakeDB) LocalNodeShards() []*models.NodeShardStatus {
+	return f.shards
+}
+
+type fakeSchema struct {
+	states map[string]*sharding.State
+}
+
+func (f *fakeSchema) GetSchemaSkipAuth() schema.Schema {
+	var classes []*models.Class
+	for name := range f.states {
+		classes = append(classes, &models.Class{Class: name})
+	}
+	return schema.Schema{Objects: &models.Schema{Classes: classes}}
+}
+
+func (f *fakeSchema) ShardingState(className string) *sharding.State {
+	return f.states[className]
+}
+
+type fakeCluster struct {
+	local string
+	live  []string
+}
+
+func (f *fakeCluster) AllNames() []string {
+	return f.live
+}
+
+func (f *fakeCluster) LocalName() string {
+	return f.local
+}
+
+func (f *fakeCluster) NodeHostname(nodeName string) (string, bool) {
+	for _, name := range f.live {
+		if name == nodeName {
+			return name + ":7947", true
+		}
+	}
+	return "", false
+}
+
+type fakeClient struct {
+	statuses map[string]*models.NodeStatus
+}
+
+func (f *fakeClient) GetNodeStatus(ctx context.Context,
+	hostName string,
+) (*models.NodeStatus, error) {
+	status, ok := f.statuses[hostName]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return status, nil
+}
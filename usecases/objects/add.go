@@ -89,6 +89,11 @@ func (m *Manager) addObjectToConnectorAndSchema(ctx context.Context, principal *
 		return nil, NewErrInvalidUserInput("invalid object: %v", err)
 	}
 
+	err = m.validateShardingKey(principal, object, nil, false)
+	if err != nil {
+		return nil, NewErrInvalidUserInput("invalid object: %v", err)
+	}
+
 	now := m.timeSource.Now()
 	object.CreationTimeUnix = now
 	object.LastUpdateTimeUnix = now
@@ -19,8 +19,11 @@ import (
 
 	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/errorcompounder"
 	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/usecases/objects/validation"
 )
 
@@ -156,6 +159,8 @@ func (b *BatchManager) validateObject(ctx context.Context, principal *models.Pri
 	err = validation.New(s, b.vectorRepo.Exists, b.config).Object(ctx, object)
 	ec.Add(err)
 
+	ec.Add(b.validateShardingKey(ctx, s, object, concept.ID != ""))
+
 	err = newVectorObtainer(b.vectorizerProvider, b.schemaManager,
 		b.logger).Do(ctx, object, principal)
 	ec.Add(err)
@@ -169,6 +174,31 @@ func (b *BatchManager) validateObject(ctx context.Context, principal *models.Pri
 	}
 }
 
+// validateShardingKey validates the sharding key of the object like for a
+// single object. An object with an explicitly set id may already exist, in
+// which case its sharding key must not change.
+func (b *BatchManager) validateShardingKey(ctx context.Context, s schema.Schema,
+	object *models.Object, mayExist bool,
+) error {
+	if propertyShardingConfig(s, object.Class) == nil {
+		return nil
+	}
+
+	var existing interface{}
+	if mayExist {
+		res, err := b.vectorRepo.Object(ctx, object.Class, object.ID, nil,
+			additional.Properties{})
+		if err != nil {
+			return errors.Wrap(err, "get existing object")
+		}
+		if res != nil {
+			existing = res.Schema
+		}
+	}
+
+	return validateObjectShardingKey(s, object, existing, false)
+}
+
 func objectsChanToSlice(c chan BatchObject) BatchObjects {
 	result := make([]BatchObject, len(c))
 	for object := range c {
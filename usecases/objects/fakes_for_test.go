@@ -118,6 +118,12 @@ func (f *fakeVectorizerProvider) Vectorizer(modName, className string) (Vectoriz
 	return f.vectorizer, nil
 }
 
+func (f *fakeVectorizerProvider) NamedVectorizer(modName, className,
+	targetVector string,
+) (Vectorizer, error) {
+	return f.vectorizer, nil
+}
+
 type fakeVectorizer struct {
 	mock.Mock
 }
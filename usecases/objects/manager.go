@@ -80,6 +80,7 @@ type timeSource interface {
 
 type VectorizerProvider interface {
 	Vectorizer(moduleName, className string) (Vectorizer, error)
+	NamedVectorizer(moduleName, className, targetVector string) (Vectorizer, error)
 }
 
 type Vectorizer interface {
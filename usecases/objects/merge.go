@@ -30,6 +30,7 @@ type MergeDocument struct {
 	PrimitiveSchema      map[string]interface{}      `json:"primitiveSchema"`
 	References           BatchReferences             `json:"references"`
 	Vector               []float32                   `json:"vector"`
+	Vectors              map[string][]float32        `json:"vectors"`
 	UpdateTime           int64                       `json:"updateTime"`
 	AdditionalProperties models.AdditionalProperties `json:"additionalProperties"`
 }
@@ -60,6 +61,9 @@ func (m *Manager) MergeObject(ctx context.Context, principal *models.Principal,
 	if obj == nil {
 		return &Error{"not found", StatusNotFound, err}
 	}
+	if err := m.validateShardingKey(principal, updates, obj.Schema, true); err != nil {
+		return &Error{"bad request", StatusBadRequest, err}
+	}
 	return m.patchObject(ctx, principal, obj, updates)
 }
 
@@ -68,7 +72,7 @@ func (m *Manager) patchObject(ctx context.Context, principal *models.Principal,
 	cls, id := updates.Class, updates.ID
 	primitive, refs := m.splitPrimitiveAndRefs(updates.Properties.(map[string]interface{}), cls, id)
 	objWithVec, err := m.mergeObjectSchemaAndVectorize(ctx, cls, obj.Schema,
-		primitive, principal, obj.Vector, updates.Vector)
+		primitive, principal, obj.Vector, updates.Vector, obj.Vectors, updates.Vectors)
 	if err != nil {
 		return &Error{"merge and vectorize", StatusInternalServerError, err}
 	}
@@ -78,6 +82,7 @@ func (m *Manager) patchObject(ctx context.Context, principal *models.Principal,
 		PrimitiveSchema: primitive,
 		References:      refs,
 		Vector:          objWithVec.Vector,
+		Vectors:         namedVectors(objWithVec.Vectors),
 		UpdateTime:      m.timeSource.Now(),
 	}
 
@@ -108,13 +113,16 @@ func (m *Manager) validateInputs(updates *models.Object) error {
 func (m *Manager) mergeObjectSchemaAndVectorize(ctx context.Context, className string,
 	old interface{}, new map[string]interface{},
 	principal *models.Principal, oldVec, newVec []float32,
+	oldVecs map[string][]float32, newVecs models.Vectors,
 ) (*models.Object, error) {
 	var merged map[string]interface{}
 	var vector []float32
+	var vectors models.Vectors
 	vecObtainer := newVectorObtainer(m.vectorizerProvider, m.schemaManager, m.logger)
 	if old == nil {
 		merged = new
 		vector = newVec
+		vectors = newVecs
 	} else {
 		oldMap, ok := old.(map[string]interface{})
 		if !ok {
@@ -137,11 +145,22 @@ func (m *Manager) mergeObjectSchemaAndVectorize(ctx context.Context, className s
 				vector = oldVec
 			}
 		}
+
+		class, err := vecObtainer.getClass(className, principal)
+		if err != nil {
+			return nil, err
+		}
+		vectors = mergeNamedVectors(class, oldVecs, newVecs)
 	}
 
 	// Note: vector could be a nil vector in case a vectorizer is configered,
-	// then the obtainer will set it
-	obj := &models.Object{Class: className, Properties: merged, Vector: vector}
+	// then the obtainer will set it. The same applies to the named vectors.
+	obj := &models.Object{
+		Class:      className,
+		Properties: merged,
+		Vector:     vector,
+		Vectors:    vectors,
+	}
 	if err := vecObtainer.Do(ctx, obj, principal); err != nil {
 		return nil, err
 	}
@@ -149,6 +168,51 @@ func (m *Manager) mergeObjectSchemaAndVectorize(ctx context.Context, className s
 	return obj, nil
 }
 
+// mergeNamedVectors keeps the previous named vectors which are not updated,
+// unless they are generated by a vectorizer module. Those are left out, so
+// they are generated again for the merged properties.
+func mergeNamedVectors(class *models.Class, old map[string][]float32,
+	updates models.Vectors,
+) models.Vectors {
+	out := models.Vectors{}
+	for name, vector := range updates {
+		out[name] = vector
+	}
+
+	for name, vector := range old {
+		if _, ok := out[name]; ok {
+			continue
+		}
+
+		cfg, ok := class.VectorConfig[name]
+		if !ok {
+			continue
+		}
+
+		vectorizerName, _, err := schema.NamedVectorizer(cfg)
+		if err == nil && vectorizerName == config.VectorizerModuleNone {
+			out[name] = vector
+		}
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func namedVectors(in models.Vectors) map[string][]float32 {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make(map[string][]float32, len(in))
+	for name, vector := range in {
+		out[name] = vector
+	}
+	return out
+}
+
 func (m *Manager) splitPrimitiveAndRefs(in map[string]interface{}, sourceClass string,
 	sourceID strfmt.UUID,
 ) (map[string]interface{}, BatchReferences) {
@@ -15,6 +15,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
@@ -43,26 +44,43 @@ func newVectorObtainer(vectorizerProvider VectorizerProvider,
 func (vo *vectorObtainer) Do(ctx context.Context, obj *models.Object,
 	principal *models.Principal,
 ) error {
-	vectorizerName, cfg, err := vo.getVectorizerOfClass(obj.Class, principal)
+	class, err := vo.getClass(obj.Class, principal)
 	if err != nil {
 		return err
 	}
 
-	hnswConfig, ok := cfg.(hnsw.UserConfig)
-	if !ok {
-		return errors.Errorf("vector index config (%T) is not of type HNSW, "+
-			"but objects manager is restricted to HNSW", cfg)
+	if err := vo.obtainVector(ctx, obj, class); err != nil {
+		return err
+	}
+
+	return vo.obtainNamedVectors(ctx, obj, class)
+}
+
+func (vo *vectorObtainer) obtainVector(ctx context.Context, obj *models.Object,
+	class *models.Class,
+) error {
+	vectorizerName, cfg := class.Vectorizer, class.VectorIndexConfig
+
+	var skip bool
+	switch typed := cfg.(type) {
+	case hnsw.UserConfig:
+		skip = typed.Skip
+	case flat.UserConfig:
+		// a flat index cannot be skipped
+	default:
+		return errors.Errorf("vector index config (%T) is not of type HNSW or flat, "+
+			"but objects manager is restricted to HNSW and flat", cfg)
 	}
 
 	if vectorizerName == config.VectorizerModuleNone {
-		if err := vo.validateVectorPresent(obj, hnswConfig); err != nil {
+		if err := vo.validateVectorPresent(obj, skip); err != nil {
 			return NewErrInvalidUserInput("%v", err)
 		}
 
 		return nil
 	}
 
-	if hnswConfig.Skip {
+	if skip {
 		vo.logger.WithField("className", obj.Class).
 			WithField("vectorizer", vectorizerName).
 			Warningf("this class is configured to skip vector indexing, "+
@@ -90,28 +108,87 @@ func (vo *vectorObtainer) Do(ctx context.Context, obj *models.Object,
 	return nil
 }
 
-func (vo *vectorObtainer) getVectorizerOfClass(className string,
+// obtainNamedVectors makes sure that every named vector of the class is set
+// on the object. Named vectors with a vectorizer module are generated unless
+// they were provided by the user, those without have to be provided.
+func (vo *vectorObtainer) obtainNamedVectors(ctx context.Context, obj *models.Object,
+	class *models.Class,
+) error {
+	for name := range obj.Vectors {
+		if _, ok := class.VectorConfig[name]; !ok {
+			return NewErrInvalidUserInput("class %s has no named vector %q", class.Class, name)
+		}
+	}
+
+	for name, cfg := range class.VectorConfig {
+		if len(obj.Vectors[name]) > 0 {
+			continue
+		}
+
+		vectorizerName, _, err := schema.NamedVectorizer(cfg)
+		if err != nil {
+			return NewErrInternal("named vector %q: %v", name, err)
+		}
+
+		if vectorizerName == config.VectorizerModuleNone {
+			continue
+		}
+
+		vectorizer, err := vo.vectorizerProvider.NamedVectorizer(vectorizerName,
+			obj.Class, name)
+		if err != nil {
+			return err
+		}
+
+		// the vectorizer sets the regular vector of the object it is passed
+		vectorized := *obj
+		vectorized.Vector = nil
+		if err := vectorizer.UpdateObject(ctx, &vectorized); err != nil {
+			return NewErrInternal("named vector %q: %v", name, err)
+		}
+
+		if obj.Vectors == nil {
+			obj.Vectors = models.Vectors{}
+		}
+		obj.Vectors[name] = vectorized.Vector
+	}
+
+	return nil
+}
+
+func (vo *vectorObtainer) getClass(className string,
 	principal *models.Principal,
-) (string, interface{}, error) {
+) (*models.Class, error) {
 	s, err := vo.schemaManager.GetSchema(principal)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 
 	class := s.FindClassByName(schema.ClassName(className))
 	if class == nil {
 		// this should be impossible by the time this method gets called, but let's
 		// be 100% certain
-		return "", nil, errors.Errorf("class %s not present", className)
+		return nil, errors.Errorf("class %s not present", className)
+	}
+
+	return class, nil
+}
+
+func (vo *vectorObtainer) getVectorizerOfClass(className string,
+	principal *models.Principal,
+) (string, interface{}, error) {
+	class, err := vo.getClass(className, principal)
+	if err != nil {
+		return "", nil, err
 	}
 
 	return class.Vectorizer, class.VectorIndexConfig, nil
 }
 
 func (vo *vectorObtainer) validateVectorPresent(obj *models.Object,
-	hnswConfig hnsw.UserConfig,
+	skip bool,
 ) error {
-	if hnswConfig.Skip && len(obj.Vector) > 0 {
+	if skip && len(obj.Vector) > 0 {
 		vo.logger.WithField("className", obj.Class).
 			Warningf("this class is configured to skip vector indexing, " +
 				"but a vector was explicitly provided. " +
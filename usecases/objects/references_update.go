@@ -140,5 +140,9 @@ func validateReferenceSchema(class, property string, sch schema.Schema) error {
 		return fmt.Errorf("property '%s' is a primitive datatype, not a reference-type", property)
 	}
 
+	if dt.IsNested() {
+		return fmt.Errorf("property '%s' is a nested datatype, not a reference-type", property)
+	}
+
 	return nil
 }
@@ -0,0 +1,102 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package objects
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+)
+
+// propertyShardingConfig returns the sharding config of the class, if its
+// objects are sharded by a property. There is nothing to validate for
+// objects sharded by their id, so it returns nil otherwise.
+func propertyShardingConfig(s schema.Schema, className string) schema.ShardingConfig {
+	class := s.FindClassByName(schema.ClassName(className))
+	if class == nil {
+		return nil
+	}
+
+	cfg, ok := class.ShardingConfig.(schema.ShardingConfig)
+	if !ok || cfg.KeyProperty() == "" {
+		return nil
+	}
+
+	return cfg
+}
+
+// validateObjectShardingKey makes sure that an object of a class which is sharded
+// by a property has a value for that property. The value determines the
+// shard of the object, so it has to be the same as the one of the existing
+// object, if there is one. Partial updates may leave it out.
+func validateObjectShardingKey(s schema.Schema, object *models.Object,
+	existing interface{}, partial bool,
+) error {
+	cfg := propertyShardingConfig(s, object.Class)
+	if cfg == nil {
+		return nil
+	}
+
+	key, ok, err := shardingKey(cfg, object.Properties)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if partial {
+			return nil
+		}
+		return errors.Errorf("no value for sharding key property %q", cfg.KeyProperty())
+	}
+
+	if existing == nil {
+		return nil
+	}
+
+	existingKey, ok, err := shardingKey(cfg, existing)
+	if err != nil {
+		return errors.Wrap(err, "existing object")
+	}
+	if ok && !bytes.Equal(key, existingKey) {
+		return errors.Errorf("sharding key property %q cannot be changed",
+			cfg.KeyProperty())
+	}
+
+	return nil
+}
+
+func (m *Manager) validateShardingKey(principal *models.Principal,
+	object *models.Object, existing interface{}, partial bool,
+) error {
+	s, err := m.schemaManager.GetSchema(principal)
+	if err != nil {
+		return err
+	}
+
+	return validateObjectShardingKey(s, object, existing, partial)
+}
+
+func shardingKey(cfg schema.ShardingConfig, props interface{}) ([]byte, bool, error) {
+	asMap, _ := props.(map[string]interface{})
+	value, ok := asMap[cfg.KeyProperty()]
+	if !ok || value == nil {
+		return nil, false, nil
+	}
+
+	key, err := cfg.PropertyKey(value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return key, true, nil
+}
@@ -0,0 +1,120 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package objects
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateObjectShardingKey(t *testing.T) {
+	newSchema := func(key string) schema.Schema {
+		return schema.Schema{
+			Objects: &models.Schema{
+				Classes: []*models.Class{{
+					Class:          "Tenant",
+					ShardingConfig: fakeShardingConfig{key},
+				}},
+			},
+		}
+	}
+
+	object := func(props map[string]interface{}) *models.Object {
+		return &models.Object{Class: "Tenant", Properties: props}
+	}
+
+	t.Run("sharded by id", func(t *testing.T) {
+		err := validateObjectShardingKey(newSchema(""),
+			object(nil), nil, false)
+		assert.Nil(t, err)
+	})
+
+	t.Run("sharded by a property", func(t *testing.T) {
+		s := newSchema("tenantId")
+		existing := map[string]interface{}{"tenantId": float64(1)}
+
+		tests := []struct {
+			name     string
+			props    map[string]interface{}
+			existing interface{}
+			partial  bool
+			errorMsg string
+		}{
+			{
+				name:  "new object",
+				props: map[string]interface{}{"tenantId": int64(2)},
+			},
+			{
+				name:     "new object without key",
+				props:    map[string]interface{}{},
+				errorMsg: "no value for sharding key property \"tenantId\"",
+			},
+			{
+				name:     "update with the same key",
+				props:    map[string]interface{}{"tenantId": int64(1)},
+				existing: existing,
+			},
+			{
+				name:     "update with another key",
+				props:    map[string]interface{}{"tenantId": int64(2)},
+				existing: existing,
+				errorMsg: "sharding key property \"tenantId\" cannot be changed",
+			},
+			{
+				name:     "partial update without key",
+				props:    map[string]interface{}{"name": "other"},
+				existing: existing,
+				partial:  true,
+			},
+			{
+				name:     "partial update with another key",
+				props:    map[string]interface{}{"tenantId": int64(2)},
+				existing: existing,
+				partial:  true,
+				errorMsg: "sharding key property \"tenantId\" cannot be changed",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				err := validateObjectShardingKey(s, object(test.props),
+					test.existing, test.partial)
+				if test.errorMsg == "" {
+					assert.Nil(t, err)
+				} else {
+					assert.EqualError(t, err, test.errorMsg)
+				}
+			})
+		}
+	})
+}
+
+type fakeShardingConfig struct {
+	key string
+}
+
+func (f fakeShardingConfig) KeyProperty() string {
+	return f.key
+}
+
+func (f fakeShardingConfig) PropertyKey(value interface{}) ([]byte, error) {
+	switch typed := value.(type) {
+	case float64:
+		return []byte(fmt.Sprint(int64(typed))), nil
+	default:
+		return []byte(fmt.Sprint(value)), nil
+	}
+}
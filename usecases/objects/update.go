@@ -71,6 +71,11 @@ func (m *Manager) updateObjectToConnectorAndSchema(ctx context.Context, principa
 		return nil, NewErrInvalidUserInput("invalid object: %v", err)
 	}
 
+	err = m.validateShardingKey(principal, updates, obj.Schema, false)
+	if err != nil {
+		return nil, NewErrInvalidUserInput("invalid object: %v", err)
+	}
+
 	// Set the original creation timestamp before call to put,
 	// otherwise it is lost. This is because `class` is unmarshaled
 	// directly from the request body, therefore `CreationTimeUnix`
@@ -34,6 +34,30 @@ func testSchema() schema.Schema {
 							Name:     "phone",
 							DataType: []string{"phoneNumber"},
 						},
+						{
+							Name:     "address",
+							DataType: []string{"object"},
+							NestedProperties: []*models.Property{
+								{Name: "city", DataType: []string{"string"}},
+								{Name: "zip", DataType: []string{"int"}},
+							},
+						},
+						{
+							Name:     "pets",
+							DataType: []string{"object[]"},
+							NestedProperties: []*models.Property{
+								{Name: "name", DataType: []string{"text"}},
+								{Name: "tags", DataType: []string{"string[]"}},
+							},
+						},
+						{
+							Name:     "ownerId",
+							DataType: []string{"uuid"},
+						},
+						{
+							Name:     "friendIds",
+							DataType: []string{"uuid[]"},
+						},
 					},
 				},
 			},
@@ -0,0 +1,131 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/usecases/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropertyOfTypeObjectValidation(t *testing.T) {
+	type test struct {
+		name           string
+		props          map[string]interface{}
+		expectedErr    error
+		expectedResult map[string]interface{}
+	}
+
+	tests := []test{
+		{
+			name: "object of wrong type",
+			props: map[string]interface{}{
+				"address": "Amsterdam",
+			},
+			expectedErr: errors.New("invalid object property 'address' on class 'Person': " +
+				"not an object, but string"),
+		},
+		{
+			name: "object with an unknown nested prop",
+			props: map[string]interface{}{
+				"address": map[string]interface{}{
+					"country": "NL",
+				},
+			},
+			expectedErr: errors.New("invalid object property 'address' on class 'Person': " +
+				"no such nested property 'country'"),
+		},
+		{
+			name: "object with a nested value of the wrong type",
+			props: map[string]interface{}{
+				"address": map[string]interface{}{
+					"zip": "1234AB",
+				},
+			},
+			expectedErr: errors.New("invalid integer property 'address.zip' on class 'Person': " +
+				"requires an integer, the given value is '1234AB'"),
+		},
+		{
+			name: "valid object",
+			props: map[string]interface{}{
+				"address": map[string]interface{}{
+					"city": "Amsterdam",
+					"zip":  float64(1234),
+				},
+			},
+			expectedResult: map[string]interface{}{
+				"address": map[string]interface{}{
+					"city": "Amsterdam",
+					"zip":  float64(1234),
+				},
+			},
+		},
+		{
+			name: "object array of wrong type",
+			props: map[string]interface{}{
+				"pets": map[string]interface{}{
+					"name": "Bello",
+				},
+			},
+			expectedErr: errors.New("invalid object array property 'pets' on class 'Person': " +
+				"not an array, but map[string]interface {}"),
+		},
+		{
+			name: "object array with an invalid element",
+			props: map[string]interface{}{
+				"pets": []interface{}{
+					map[string]interface{}{"name": "Bello"},
+					map[string]interface{}{"tags": "good boy"},
+				},
+			},
+			expectedErr: errors.New("invalid string array property 'pets[1].tags' on class 'Person': " +
+				"not a string array, but string"),
+		},
+		{
+			name: "valid object array",
+			props: map[string]interface{}{
+				"pets": []interface{}{
+					map[string]interface{}{"name": "Bello", "tags": []interface{}{"good boy"}},
+					map[string]interface{}{"name": "Garfield"},
+				},
+			},
+			expectedResult: map[string]interface{}{
+				"pets": []interface{}{
+					map[string]interface{}{"name": "Bello", "tags": []interface{}{"good boy"}},
+					map[string]interface{}{"name": "Garfield"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &config.WeaviateConfig{}
+			validator := New(testSchema(), fakeExists, config)
+
+			obj := &models.Object{
+				Class:      "Person",
+				Properties: test.props,
+			}
+			err := validator.properties(context.Background(), obj)
+			assert.Equal(t, test.expectedErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.expectedResult, obj.Properties)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/schema/crossref"
@@ -71,9 +72,22 @@ func (v *Validator) properties(ctx context.Context, object interface{}) error {
 			return err
 		}
 
-		data, err := v.extractAndValidateProperty(ctx, propertyKey, propertyValue, className, dataType)
-		if err != nil {
-			return err
+		var data interface{}
+		if *dataType == schema.DataTypeObject || *dataType == schema.DataTypeObjectArray {
+			prop, err := schema.GetPropertyByName(class, propertyKey)
+			if err != nil {
+				return err
+			}
+
+			data, err = v.nestedValue(ctx, propertyKey, propertyValue, className, prop)
+			if err != nil {
+				return err
+			}
+		} else {
+			data, err = v.extractAndValidateProperty(ctx, propertyKey, propertyValue, className, dataType)
+			if err != nil {
+				return err
+			}
 		}
 
 		returnSchema[propertyKey] = data
@@ -144,6 +158,11 @@ func (v *Validator) extractAndValidateProperty(ctx context.Context, propertyName
 		if err != nil {
 			return nil, fmt.Errorf("invalid blob property '%s' on class '%s': %s", propertyName, className, err)
 		}
+	case schema.DataTypeUUID:
+		data, err = uuidVal(pv)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uuid property '%s' on class '%s': %s", propertyName, className, err)
+		}
 	case schema.DataTypeStringArray:
 		data, err = stringArrayVal(pv, "string")
 		if err != nil {
@@ -174,6 +193,11 @@ func (v *Validator) extractAndValidateProperty(ctx context.Context, propertyName
 		if err != nil {
 			return nil, fmt.Errorf("invalid date array property '%s' on class '%s': %s", propertyName, className, err)
 		}
+	case schema.DataTypeUUIDArray:
+		data, err = uuidArrayVal(pv)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uuid array property '%s' on class '%s': %s", propertyName, className, err)
+		}
 
 	default:
 		return nil, fmt.Errorf("unrecognized data type '%s'", *dataType)
@@ -211,6 +235,78 @@ func (v *Validator) cRef(ctx context.Context, propertyName string, pv interface{
 	}
 }
 
+// nestedValue validates the value of an object or object[] prop against its
+// nested props. The values of the nested props are validated and parsed like
+// the ones of top-level props.
+func (v *Validator) nestedValue(ctx context.Context, path string, pv interface{},
+	className string, prop *models.Property,
+) (interface{}, error) {
+	if schema.DataType(prop.DataType[0]) == schema.DataTypeObject {
+		return v.nestedObject(ctx, path, pv, className, prop.NestedProperties)
+	}
+
+	values, ok := pv.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid object array property '%s' on class '%s': not an array, but %T",
+			path, className, pv)
+	}
+
+	out := make([]interface{}, len(values))
+	for i, value := range values {
+		obj, err := v.nestedObject(ctx, fmt.Sprintf("%s[%d]", path, i), value, className,
+			prop.NestedProperties)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = obj
+	}
+
+	return out, nil
+}
+
+func (v *Validator) nestedObject(ctx context.Context, path string, pv interface{},
+	className string, nestedProps []*models.Property,
+) (map[string]interface{}, error) {
+	values, ok := pv.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid object property '%s' on class '%s': not an object, but %T",
+			path, className, pv)
+	}
+
+	out := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		var nested *models.Property
+		for _, candidate := range nestedProps {
+			if candidate.Name == key {
+				nested = candidate
+				break
+			}
+		}
+		if nested == nil {
+			return nil, fmt.Errorf("invalid object property '%s' on class '%s': no such nested property '%s'",
+				path, className, key)
+		}
+
+		var data interface{}
+		var err error
+		nestedPath := schema.NestedPropertyPath(path, key)
+		if schema.IsNestedDataType(nested.DataType) {
+			data, err = v.nestedValue(ctx, nestedPath, value, className, nested)
+		} else {
+			dataType := schema.DataType(nested.DataType[0])
+			data, err = v.extractAndValidateProperty(ctx, nestedPath, value, className, &dataType)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		out[key] = data
+	}
+
+	return out, nil
+}
+
 func stringVal(val interface{}) (string, error) {
 	typed, ok := val.(string)
 	if !ok {
@@ -398,6 +494,25 @@ func blobVal(val interface{}) (string, error) {
 	return typed, nil
 }
 
+// uuidVal parses the uuid, so that it is stored in its binary form
+func uuidVal(val interface{}) (uuid.UUID, error) {
+	if typed, ok := val.(uuid.UUID); ok {
+		return typed, nil
+	}
+
+	typed, ok := val.(string)
+	if !ok {
+		return uuid.UUID{}, fmt.Errorf("not a uuid string, but %T", val)
+	}
+
+	parsed, err := uuid.Parse(typed)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("requires a string with a valid uuid, but the given value is '%s'", typed)
+	}
+
+	return parsed, nil
+}
+
 func (v *Validator) parseAndValidateSingleRef(ctx context.Context, propertyName string,
 	pvcr map[string]interface{}, className string,
 ) (*models.SingleRef, error) {
@@ -535,3 +650,25 @@ func dateArrayVal(val interface{}) ([]interface{}, error) {
 
 	return typed, nil
 }
+
+func uuidArrayVal(val interface{}) ([]uuid.UUID, error) {
+	if typed, ok := val.([]uuid.UUID); ok {
+		return typed, nil
+	}
+
+	typed, ok := val.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not a uuid array, but %T", val)
+	}
+
+	parsed := make([]uuid.UUID, len(typed))
+	for i := range typed {
+		value, err := uuidVal(typed[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid uuid array value: %s", err)
+		}
+		parsed[i] = value
+	}
+
+	return parsed, nil
+}
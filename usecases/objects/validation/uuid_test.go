@@ -0,0 +1,107 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/usecases/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropertyOfTypeUUIDValidation(t *testing.T) {
+	id1 := uuid.MustParse("28f3f61b-b524-45e0-9bbe-2c1550bf73d2")
+	id2 := uuid.MustParse("0ca2a9d9-6d3f-4b0b-8c4e-3f0f3c4b7a5e")
+
+	type test struct {
+		name           string
+		props          map[string]interface{}
+		expectedErr    error
+		expectedResult map[string]interface{}
+	}
+
+	tests := []test{
+		{
+			name: "uuid of wrong type",
+			props: map[string]interface{}{
+				"ownerId": float64(17),
+			},
+			expectedErr: errors.New("invalid uuid property 'ownerId' on class 'Person': " +
+				"not a uuid string, but float64"),
+		},
+		{
+			name: "uuid with an invalid format",
+			props: map[string]interface{}{
+				"ownerId": "not-a-uuid",
+			},
+			expectedErr: errors.New("invalid uuid property 'ownerId' on class 'Person': " +
+				"requires a string with a valid uuid, but the given value is 'not-a-uuid'"),
+		},
+		{
+			name: "valid uuid",
+			props: map[string]interface{}{
+				"ownerId": id1.String(),
+			},
+			expectedResult: map[string]interface{}{
+				"ownerId": id1,
+			},
+		},
+		{
+			name: "uuid array of wrong type",
+			props: map[string]interface{}{
+				"friendIds": id1.String(),
+			},
+			expectedErr: errors.New("invalid uuid array property 'friendIds' on class 'Person': " +
+				"not a uuid array, but string"),
+		},
+		{
+			name: "uuid array with an invalid element",
+			props: map[string]interface{}{
+				"friendIds": []interface{}{id1.String(), "not-a-uuid"},
+			},
+			expectedErr: errors.New("invalid uuid array property 'friendIds' on class 'Person': " +
+				"invalid uuid array value: requires a string with a valid uuid, " +
+				"but the given value is 'not-a-uuid'"),
+		},
+		{
+			name: "valid uuid array",
+			props: map[string]interface{}{
+				"friendIds": []interface{}{id1.String(), id2.String()},
+			},
+			expectedResult: map[string]interface{}{
+				"friendIds": []uuid.UUID{id1, id2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &config.WeaviateConfig{}
+			validator := New(testSchema(), fakeExists, config)
+
+			obj := &models.Object{
+				Class:      "Person",
+				Properties: test.props,
+			}
+			err := validator.properties(context.Background(), obj)
+			assert.Equal(t, test.expectedErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.expectedResult, obj.Properties)
+		})
+	}
+}
@@ -40,8 +40,12 @@ func (m *Manager) AddClass(ctx context.Context, principal *models.Principal,
 	return m.addClass(ctx, principal, class)
 }
 
+// RestoreClass adds a class from a backup. The sharding state is taken over
+// from the snapshot as-is, so that every shard ends up on the node which
+// holds its restored files. The change is broadcast to the cluster just like
+// a regular AddClass.
 func (m *Manager) RestoreClass(ctx context.Context, principal *models.Principal,
-	class *models.Class, snapshot *backup.Snapshot,
+	class *models.Class, snapshot *backup.ClassSnapshot,
 ) error {
 	m.Lock()
 	defer m.Unlock()
@@ -72,28 +76,27 @@ func (m *Manager) RestoreClass(ctx context.Context, principal *models.Principal,
 		return err
 	}
 
-	shardStateB := snapshot.ShardingState
-	var shardState *sharding.State
-	if shardStateB != nil {
-		shardState = &sharding.State{}
-		err := json.Unmarshal(shardStateB, shardState)
-		if err != nil {
-			return errors.Wrap(err, "failed to unmarshal sharding state")
-		}
+	if snapshot.ShardingState == nil {
+		return errors.Errorf("snapshot of class %q has no sharding state", class.Class)
 	}
 
-	semanticSchema := m.state.ObjectSchema
-	semanticSchema.Classes = append(semanticSchema.Classes, class)
+	shardState := &sharding.State{}
+	if err := json.Unmarshal(snapshot.ShardingState, shardState); err != nil {
+		return errors.Wrap(err, "unmarshal sharding state")
+	}
 
-	m.state.ShardingState[class.Class] = shardState
-	m.state.ShardingState[class.Class].SetLocalName(m.clusterState.LocalName())
-	err = m.saveSchema(ctx)
+	tx, err := m.cluster.BeginTransaction(ctx, AddClass,
+		AddClassPayload{class, shardState})
 	if err != nil {
-		return err
+		return errors.Wrap(err, "open cluster-wide transaction")
+	}
+
+	if err := m.cluster.CommitTransaction(ctx, tx); err != nil {
+		return errors.Wrap(err, "commit cluster-wide transaction")
 	}
 
-	out := m.migrator.AddClass(ctx, class, shardState)
-	return out
+	shardState.SetLocalName(m.clusterState.LocalName())
+	return m.addClassApplyChanges(ctx, class, shardState)
 }
 
 func (m *Manager) addClass(ctx context.Context, principal *models.Principal,
@@ -116,6 +119,11 @@ func (m *Manager) addClass(ctx context.Context, principal *models.Principal,
 		return err
 	}
 
+	err = validateShardingKey(class)
+	if err != nil {
+		return err
+	}
+
 	err = m.parseVectorIndexConfig(ctx, class)
 	if err != nil {
 		return err
@@ -154,7 +162,10 @@ func (m *Manager) addClassApplyChanges(ctx context.Context, class *models.Class,
 	semanticSchema := m.state.ObjectSchema
 	semanticSchema.Classes = append(semanticSchema.Classes, class)
 
+	m.shardingStateLock.Lock()
 	m.state.ShardingState[class.Class] = shardState
+	m.shardingStateLock.Unlock()
+
 	err := m.saveSchema(ctx)
 	if err != nil {
 		return err
@@ -173,6 +184,16 @@ func (m *Manager) setClassDefaults(class *models.Class) {
 		class.VectorIndexType = "hnsw"
 	}
 
+	for name, cfg := range class.VectorConfig {
+		if cfg.Vectorizer == nil {
+			cfg.Vectorizer = map[string]interface{}{config.VectorizerModuleNone: map[string]interface{}{}}
+		}
+		if cfg.VectorIndexType == "" {
+			cfg.VectorIndexType = "hnsw"
+		}
+		class.VectorConfig[name] = cfg
+	}
+
 	if class.InvertedIndexConfig == nil {
 		class.InvertedIndexConfig = &models.InvertedIndexConfig{}
 	}
@@ -203,6 +224,22 @@ func (m *Manager) setClassDefaults(class *models.Class) {
 
 func (m *Manager) setPropertyDefaults(prop *models.Property) {
 	m.setPropertyDefaultTokenization(prop)
+	m.setPropertyDefaultAnalyzer(prop)
+
+	for _, nested := range prop.NestedProperties {
+		m.setPropertyDefaults(nested)
+	}
+}
+
+func (m *Manager) setPropertyDefaultAnalyzer(prop *models.Property) {
+	if prop.Analyzer == nil || prop.Analyzer.StopwordPreset != "" {
+		return
+	}
+
+	// a language implies its stopwords, if there is a preset for it
+	if _, ok := stopwords.Presets[prop.Analyzer.Language]; ok {
+		prop.Analyzer.StopwordPreset = prop.Analyzer.Language
+	}
 }
 
 func (m *Manager) setPropertyDefaultTokenization(prop *models.Property) {
@@ -278,6 +315,14 @@ func (m *Manager) validateProperty(property *models.Property, class *models.Clas
 		return err
 	}
 
+	if err := validatePropertyAnalyzer(property.Analyzer, propertyDataType); err != nil {
+		return fmt.Errorf("property '%s': %v", property.Name, err)
+	}
+
+	if err := validateNestedProperties(property, propertyDataType); err != nil {
+		return fmt.Errorf("property '%s': %v", property.Name, err)
+	}
+
 	// all is fine!
 	return nil
 }
@@ -285,19 +330,23 @@ func (m *Manager) validateProperty(property *models.Property, class *models.Clas
 func (m *Manager) parseVectorIndexConfig(ctx context.Context,
 	class *models.Class,
 ) error {
-	if class.VectorIndexType != "hnsw" {
-		return errors.Errorf(
-			"parse vector index config: unsupported vector index type: %q",
-			class.VectorIndexType)
-	}
-
-	parsed, err := m.hnswConfigParser(class.VectorIndexConfig)
+	parsed, err := m.configParser(class.VectorIndexConfig, class.VectorIndexType)
 	if err != nil {
 		return errors.Wrap(err, "parse vector index config")
 	}
 
 	class.VectorIndexConfig = parsed
 
+	for name, cfg := range class.VectorConfig {
+		parsed, err := m.configParser(cfg.VectorIndexConfig, cfg.VectorIndexType)
+		if err != nil {
+			return errors.Wrapf(err, "parse vector index config of named vector %q", name)
+		}
+
+		cfg.VectorIndexConfig = parsed
+		class.VectorConfig[name] = cfg
+	}
+
 	return nil
 }
 
@@ -330,6 +379,7 @@ func upperCaseClassName(name string) string {
 func lowerCaseAllPropertyNames(props []*models.Property) []*models.Property {
 	for i, prop := range props {
 		props[i].Name = lowerCaseFirstLetter(prop.Name)
+		props[i].NestedProperties = lowerCaseAllPropertyNames(prop.NestedProperties)
 	}
 
 	return props
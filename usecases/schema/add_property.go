@@ -44,6 +44,7 @@ func (m *Manager) addClassProperty(ctx context.Context, principal *models.Princi
 	}
 
 	prop.Name = lowerCaseFirstLetter(prop.Name)
+	prop.NestedProperties = lowerCaseAllPropertyNames(prop.NestedProperties)
 
 	m.setNewPropDefaults(class, prop)
 
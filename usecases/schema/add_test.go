@@ -18,6 +18,7 @@ import (
 	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/stopwords"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/usecases/config"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -169,6 +170,16 @@ func TestAddClass(t *testing.T) {
 						DataType:     []string{"text"},
 						Tokenization: "word",
 					},
+					{
+						Name:         "textTrigram",
+						DataType:     []string{"text"},
+						Tokenization: "trigram",
+					},
+					{
+						Name:         "textCjk",
+						DataType:     []string{"text"},
+						Tokenization: "cjk",
+					},
 					{
 						Name:     "textArrayDefault",
 						DataType: []string{"text[]"},
@@ -183,6 +194,16 @@ func TestAddClass(t *testing.T) {
 						DataType:     []string{"text[]"},
 						Tokenization: "word",
 					},
+					{
+						Name:         "textArrayTrigram",
+						DataType:     []string{"text[]"},
+						Tokenization: "trigram",
+					},
+					{
+						Name:         "textArrayCjk",
+						DataType:     []string{"text[]"},
+						Tokenization: "cjk",
+					},
 					{
 						Name:     "IntDefault",
 						DataType: []string{"int"},
@@ -325,6 +346,18 @@ func TestAddClass(t *testing.T) {
 				tokenization: "notExisting",
 				errorMsg:     "Tokenization 'notExisting' is not allowed for data type 'text[]'",
 			},
+			{
+				name:         "stringTrigram",
+				dataType:     []string{"string"},
+				tokenization: "trigram",
+				errorMsg:     "Tokenization 'trigram' is not allowed for data type 'string'",
+			},
+			{
+				name:         "stringArrayCjk",
+				dataType:     []string{"string[]"},
+				tokenization: "cjk",
+				errorMsg:     "Tokenization 'cjk' is not allowed for data type 'string[]'",
+			},
 			{
 				name:         "intWord",
 				dataType:     []string{"int"},
@@ -543,4 +576,376 @@ func TestAddClass(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("with a sharding key", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			key          string
+			dataType     []string
+			tokenization string
+			errorMsg     string
+		}{
+			{
+				name:         "string with field tokenization",
+				key:          "tenantId",
+				dataType:     []string{"string"},
+				tokenization: "field",
+			},
+			{
+				name:     "int",
+				key:      "tenantId",
+				dataType: []string{"int"},
+			},
+			{
+				name:     "string with word tokenization",
+				key:      "tenantId",
+				dataType: []string{"string"},
+				errorMsg: "sharding key property \"tenantId\" must be of data type \"int\", " +
+					"or of data type \"string\" with tokenization \"field\"",
+			},
+			{
+				name:     "text",
+				key:      "tenantId",
+				dataType: []string{"text"},
+				errorMsg: "sharding key property \"tenantId\" must be of data type \"int\", " +
+					"or of data type \"string\" with tokenization \"field\"",
+			},
+			{
+				name:     "not a property",
+				key:      "region",
+				dataType: []string{"int"},
+				errorMsg: "sharding key \"region\" is not a property of class \"NewClass\"",
+			},
+		}
+
+		for _, td := range tests {
+			t.Run(td.name, func(t *testing.T) {
+				err := newSchemaManager().AddClass(context.Background(),
+					nil, &models.Class{
+						Class: "NewClass",
+						Properties: []*models.Property{
+							{
+								Name:         "tenantId",
+								DataType:     td.dataType,
+								Tokenization: td.tokenization,
+							},
+						},
+						ShardingConfig: map[string]interface{}{"key": td.key},
+					})
+
+				if td.errorMsg == "" {
+					require.Nil(t, err)
+				} else {
+					require.EqualError(t, err, td.errorMsg)
+				}
+			})
+		}
+	})
+
+	t.Run("with named vectors", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			vectorConfig map[string]models.VectorConfig
+			errorMsg     string
+		}{
+			{
+				name: "with defaults",
+				vectorConfig: map[string]models.VectorConfig{
+					"title": {},
+				},
+			},
+			{
+				name: "with a vectorizer module and index type",
+				vectorConfig: map[string]models.VectorConfig{
+					"title": {
+						Vectorizer: map[string]interface{}{
+							"text2vec-contextionary": map[string]interface{}{},
+						},
+						VectorIndexType: "flat",
+					},
+					"description": {
+						Vectorizer: map[string]interface{}{"none": nil},
+					},
+				},
+			},
+			{
+				name: "with an invalid name",
+				vectorConfig: map[string]models.VectorConfig{
+					"my-vector": {},
+				},
+				errorMsg: "named vector \"my-vector\": 'my-vector' is not a valid vector name. " +
+					"Vector names in Weaviate are restricted to valid GraphQL names, " +
+					"which must be “/[_A-Za-z][_0-9A-Za-z]*/”.",
+			},
+			{
+				name: "with more than one vectorizer module",
+				vectorConfig: map[string]models.VectorConfig{
+					"title": {
+						Vectorizer: map[string]interface{}{
+							"text2vec-contextionary": map[string]interface{}{},
+							"model1":                 map[string]interface{}{},
+						},
+					},
+				},
+				errorMsg: "named vector \"title\": vectorizer must be an object with exactly " +
+					"one key, the name of the vectorizer module, e.g. {\"none\": {}}",
+			},
+			{
+				name: "with an unknown vectorizer module",
+				vectorConfig: map[string]models.VectorConfig{
+					"title": {
+						Vectorizer: map[string]interface{}{"unknown": map[string]interface{}{}},
+					},
+				},
+				errorMsg: "named vector \"title\": vectorizer: invalid vectorizer \"unknown\"",
+			},
+			{
+				name: "with an unknown vector index type",
+				vectorConfig: map[string]models.VectorConfig{
+					"title": {VectorIndexType: "lsh"},
+				},
+				errorMsg: "named vector \"title\": unrecognized or unsupported vectorIndexType \"lsh\"",
+			},
+		}
+
+		for _, td := range tests {
+			t.Run(td.name, func(t *testing.T) {
+				sm := newSchemaManager()
+				err := sm.AddClass(context.Background(),
+					nil, &models.Class{
+						Class:        "NewClass",
+						VectorConfig: td.vectorConfig,
+					})
+
+				if td.errorMsg != "" {
+					require.EqualError(t, err, td.errorMsg)
+					return
+				}
+
+				require.Nil(t, err)
+				class := sm.getClassByName("NewClass")
+				require.NotNil(t, class)
+				require.Len(t, class.VectorConfig, len(td.vectorConfig))
+				for _, cfg := range class.VectorConfig {
+					assert.NotEmpty(t, cfg.VectorIndexType)
+					assert.NotNil(t, cfg.Vectorizer)
+					assert.IsType(t, fakeVectorConfig{}, cfg.VectorIndexConfig)
+				}
+			})
+		}
+	})
+
+	t.Run("with property analyzers", func(t *testing.T) {
+		type testCase struct {
+			name             string
+			dataType         []string
+			analyzer         *models.PropertyAnalyzerConfig
+			expectedAnalyzer *models.PropertyAnalyzerConfig
+			errorMsg         string
+		}
+
+		tests := []testCase{
+			{
+				name:     "with language, stemming and folding",
+				dataType: []string{"text"},
+				analyzer: &models.PropertyAnalyzerConfig{
+					Language: "de", Stemming: true, ASCIIFolding: true,
+				},
+				expectedAnalyzer: &models.PropertyAnalyzerConfig{
+					Language: "de", Stemming: true, ASCIIFolding: true,
+					StopwordPreset: "de",
+				},
+			},
+			{
+				name:     "with a language without a stopword preset",
+				dataType: []string{"text[]"},
+				analyzer: &models.PropertyAnalyzerConfig{
+					Language: "fi", Stemming: true,
+				},
+				expectedAnalyzer: &models.PropertyAnalyzerConfig{
+					Language: "fi", Stemming: true,
+				},
+			},
+			{
+				name:     "with an explicit stopword preset",
+				dataType: []string{"text"},
+				analyzer: &models.PropertyAnalyzerConfig{
+					Language: "de", StopwordPreset: "none",
+				},
+				expectedAnalyzer: &models.PropertyAnalyzerConfig{
+					Language: "de", StopwordPreset: "none",
+				},
+			},
+			{
+				name:     "with only folding",
+				dataType: []string{"text"},
+				analyzer: &models.PropertyAnalyzerConfig{
+					ASCIIFolding: true,
+				},
+				expectedAnalyzer: &models.PropertyAnalyzerConfig{
+					ASCIIFolding: true,
+				},
+			},
+			{
+				name:     "on a string property",
+				dataType: []string{"string"},
+				analyzer: &models.PropertyAnalyzerConfig{ASCIIFolding: true},
+				errorMsg: "property 'title': analyzer is not allowed for data type " +
+					"'string', only for text and text[]",
+			},
+			{
+				name:     "with stemming but without a language",
+				dataType: []string{"text"},
+				analyzer: &models.PropertyAnalyzerConfig{Stemming: true},
+				errorMsg: "property 'title': analyzer stemming requires a language",
+			},
+			{
+				name:     "with an unsupported language",
+				dataType: []string{"text"},
+				analyzer: &models.PropertyAnalyzerConfig{Language: "xx"},
+				errorMsg: "property 'title': analyzer language 'xx' is not supported, " +
+					"use one of da, de, en, es, fi, fr, hu, it, nl, no, pt, ro, ru, sv, tr",
+			},
+			{
+				name:     "with an unknown stopword preset",
+				dataType: []string{"text"},
+				analyzer: &models.PropertyAnalyzerConfig{StopwordPreset: "xx"},
+				errorMsg: "property 'title': analyzer stopwordPreset 'xx' does not exist",
+			},
+		}
+
+		for _, td := range tests {
+			t.Run(td.name, func(t *testing.T) {
+				sm := newSchemaManager()
+				err := sm.AddClass(context.Background(),
+					nil, &models.Class{
+						Class: "NewClass",
+						Properties: []*models.Property{
+							{
+								Name:     "title",
+								DataType: td.dataType,
+								Analyzer: td.analyzer,
+							},
+						},
+					})
+
+				if td.errorMsg != "" {
+					require.EqualError(t, err, td.errorMsg)
+					return
+				}
+
+				require.Nil(t, err)
+				class := sm.getClassByName("NewClass")
+				require.NotNil(t, class)
+				assert.Equal(t, td.expectedAnalyzer, class.Properties[0].Analyzer)
+			})
+		}
+	})
+
+	t.Run("with nested properties", func(t *testing.T) {
+		type testCase struct {
+			name             string
+			dataType         []string
+			nestedProperties []*models.Property
+			errorMsg         string
+		}
+
+		tests := []testCase{
+			{
+				name:     "object with primitive and nested object props",
+				dataType: []string{"object"},
+				nestedProperties: []*models.Property{
+					{Name: "City", DataType: []string{"text"}},
+					{
+						Name:     "geo",
+						DataType: []string{"object[]"},
+						NestedProperties: []*models.Property{
+							{Name: "zip", DataType: []string{"int"}},
+						},
+					},
+				},
+			},
+			{
+				name:     "object without nested props",
+				dataType: []string{"object"},
+				errorMsg: "property 'address': data type 'object' requires at least one nested property",
+			},
+			{
+				name:     "nested props on a primitive prop",
+				dataType: []string{"text"},
+				nestedProperties: []*models.Property{
+					{Name: "city", DataType: []string{"text"}},
+				},
+				errorMsg: "property 'address': nestedProperties are only allowed for " +
+					"data types 'object' and 'object[]'",
+			},
+			{
+				name:     "duplicate nested prop names",
+				dataType: []string{"object"},
+				nestedProperties: []*models.Property{
+					{Name: "city", DataType: []string{"text"}},
+					{Name: "city", DataType: []string{"string"}},
+				},
+				errorMsg: "property 'address': name 'city' already in use as a nested property name",
+			},
+			{
+				name:     "nested reference prop",
+				dataType: []string{"object"},
+				nestedProperties: []*models.Property{
+					{Name: "city", DataType: []string{"City"}},
+				},
+				errorMsg: "property 'address': nested property 'city': invalid dataType: " +
+					"nested properties cannot be references",
+			},
+			{
+				name:     "nested geo prop",
+				dataType: []string{"object"},
+				nestedProperties: []*models.Property{
+					{Name: "location", DataType: []string{"geoCoordinates"}},
+				},
+				errorMsg: "property 'address': nested property 'location': invalid dataType: " +
+					"data type 'geoCoordinates' is not supported for nested properties",
+			},
+			{
+				name:     "nested object without nested props",
+				dataType: []string{"object[]"},
+				nestedProperties: []*models.Property{
+					{Name: "geo", DataType: []string{"object"}},
+				},
+				errorMsg: "property 'address': nested property 'geo': " +
+					"data type 'object' requires at least one nested property",
+			},
+		}
+
+		for _, td := range tests {
+			t.Run(td.name, func(t *testing.T) {
+				sm := newSchemaManager()
+				err := sm.AddClass(context.Background(),
+					nil, &models.Class{
+						Class: "NewClass",
+						Properties: []*models.Property{
+							{
+								Name:             "address",
+								DataType:         td.dataType,
+								NestedProperties: td.nestedProperties,
+							},
+						},
+					})
+
+				if td.errorMsg != "" {
+					require.EqualError(t, err, td.errorMsg)
+					return
+				}
+
+				require.Nil(t, err)
+				class := sm.getClassByName("NewClass")
+				require.NotNil(t, class)
+				nested := class.Properties[0].NestedProperties
+				require.Len(t, nested, 2)
+				assert.Equal(t, "city", nested[0].Name)
+				assert.Equal(t, "word", nested[0].Tokenization)
+				assert.Equal(t, "zip", nested[1].NestedProperties[0].Name)
+			})
+		}
+	})
 }
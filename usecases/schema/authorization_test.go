@@ -96,6 +96,12 @@ func Test_Schema_Authorization(t *testing.T) {
 			expectedVerb:     "update",
 			expectedResource: "schema/className/shards/shardName",
 		},
+		{
+			methodName:       "RebalanceShards",
+			additionalArgs:   []interface{}{"className"},
+			expectedVerb:     "update",
+			expectedResource: "schema/className/shards",
+		},
 	}
 
 	t.Run("verify that a test for every public method exists", func(t *testing.T) {
@@ -13,9 +13,11 @@ package schema
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
 )
 
 // DeleteClassProperty from existing Schema
@@ -27,7 +29,81 @@ func (m *Manager) DeleteClassProperty(ctx context.Context, principal *models.Pri
 		return err
 	}
 
-	return fmt.Errorf("deleting a property is currently not supported, see " +
-		"https://github.com/semi-technologies/weaviate/issues/973 for details.")
-	// return m.deleteClassProperty(ctx, class, property, kind.Action)
+	return m.deleteClassProperty(ctx, class, property)
+}
+
+func (m *Manager) deleteClassProperty(ctx context.Context, className string,
+	propName string,
+) error {
+	m.Lock()
+	defer m.Unlock()
+
+	semanticSchema := m.state.SchemaFor()
+	class, err := schema.GetClassByName(semanticSchema, className)
+	if err != nil {
+		return err
+	}
+
+	propName = lowerCaseFirstLetter(propName)
+	if _, err := schema.GetPropertyByName(class, propName); err != nil {
+		return err
+	}
+
+	if cfg, ok := class.ShardingConfig.(sharding.Config); ok && cfg.Key == propName {
+		return errors.Errorf("property %q is the sharding key of class %q and cannot "+
+			"be deleted", propName, className)
+	}
+
+	tx, err := m.cluster.BeginTransaction(ctx, DeleteProperty,
+		DeletePropertyPayload{className, propName})
+	if err != nil {
+		// possible causes for errors could be nodes down (we expect every node to
+		// the up for a schema transaction) or concurrent transactions from other
+		// nodes
+		return errors.Wrap(err, "open cluster-wide transaction")
+	}
+
+	if err := m.cluster.CommitTransaction(ctx, tx); err != nil {
+		return errors.Wrap(err, "commit cluster-wide transaction")
+	}
+
+	return m.deleteClassPropertyApplyChanges(ctx, className, propName)
+}
+
+// deleteClassPropertyApplyChanges removes the property from the schema and
+// has the migrator drop its indexes. The values of the property are stripped
+// from the stored objects in the background.
+func (m *Manager) deleteClassPropertyApplyChanges(ctx context.Context,
+	className string, propName string,
+) error {
+	semanticSchema := m.state.SchemaFor()
+	class, err := schema.GetClassByName(semanticSchema, className)
+	if err != nil {
+		return err
+	}
+
+	propIdx := -1
+	for idx, prop := range class.Properties {
+		if prop.Name == propName {
+			propIdx = idx
+			break
+		}
+	}
+
+	if propIdx == -1 {
+		return errors.Errorf("could not find property '%s' in class '%s'",
+			propName, className)
+	}
+
+	// build a new slice, readers might still hold a reference to the old one
+	props := make([]*models.Property, 0, len(class.Properties)-1)
+	props = append(props, class.Properties[:propIdx]...)
+	props = append(props, class.Properties[propIdx+1:]...)
+	class.Properties = props
+
+	if err := m.saveSchema(ctx); err != nil {
+		return err
+	}
+
+	return m.migrator.DropProperty(ctx, className, propName)
 }
@@ -14,3 +14,17 @@ package schema
 import "errors"
 
 var ErrNotFound = errors.New("not found")
+
+// ErrUnprocessable is returned if a request cannot be carried out in the
+// current state of the schema or the cluster
+type ErrUnprocessable struct {
+	err error
+}
+
+func (e ErrUnprocessable) Error() string {
+	return e.err.Error()
+}
+
+func NewErrUnprocessable(err error) ErrUnprocessable {
+	return ErrUnprocessable{err}
+}
@@ -80,6 +80,9 @@ func (m *Manager) getClassByName(name string) *models.Class {
 }
 
 func (m *Manager) ShardingState(className string) *sharding.State {
+	m.shardingStateLock.RLock()
+	defer m.shardingStateLock.RUnlock()
+
 	return m.state.ShardingState[className]
 }
 
@@ -96,12 +99,18 @@ func (m *Manager) GetShardsStatus(ctx context.Context, principal *models.Princip
 		return nil, err
 	}
 
+	reindexStatus, err := m.migrator.GetShardsReindexStatus(ctx, className)
+	if err != nil {
+		return nil, err
+	}
+
 	resp := models.ShardStatusList{}
 
 	for name, status := range shardsStatus {
 		resp = append(resp, &models.ShardStatusGetResponse{
-			Name:   name,
-			Status: status,
+			Name:       name,
+			Status:     status,
+			Reindexing: reindexStatus[name],
 		})
 	}
 
@@ -51,7 +51,11 @@ func (f fakeVectorConfig) IndexType() string {
 	return "fake"
 }
 
-func dummyParseVectorConfig(in interface{}) (schema.VectorIndexConfig, error) {
+func (f fakeVectorConfig) DistanceName() string {
+	return "fake"
+}
+
+func dummyParseVectorConfig(in interface{}, vectorIndexType string) (schema.VectorIndexConfig, error) {
 	return fakeVectorConfig{raw: in}, nil
 }
 
@@ -117,6 +121,7 @@ func (f *fakeModuleConfig) ValidateClass(ctx context.Context, class *models.Clas
 
 type fakeClusterState struct {
 	hosts []string
+	names []string
 }
 
 func (f *fakeClusterState) Hostnames() []string {
@@ -124,6 +129,9 @@ func (f *fakeClusterState) Hostnames() []string {
 }
 
 func (f *fakeClusterState) AllNames() []string {
+	if f.names != nil {
+		return f.names
+	}
 	return []string{"node1"}
 }
 
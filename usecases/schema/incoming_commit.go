@@ -28,6 +28,10 @@ func (m *Manager) handleCommit(ctx context.Context, tx *cluster.Transaction) err
 		return m.handleDeleteClassCommit(ctx, tx)
 	case UpdateClass:
 		return m.handleUpdateClassCommit(ctx, tx)
+	case DeleteProperty:
+		return m.handleDeletePropertyCommit(ctx, tx)
+	case UpdateShardingState:
+		return m.handleUpdateShardingStateCommit(ctx, tx)
 	default:
 		return errors.Errorf("unrecognized commit type %q", tx.Type)
 	}
@@ -109,3 +113,34 @@ func (m *Manager) handleUpdateClassCommit(ctx context.Context,
 
 	return m.updateClassApplyChanges(ctx, pl.ClassName, pl.Class)
 }
+
+func (m *Manager) handleDeletePropertyCommit(ctx context.Context,
+	tx *cluster.Transaction,
+) error {
+	m.Lock()
+	defer m.Unlock()
+
+	pl, ok := tx.Payload.(DeletePropertyPayload)
+	if !ok {
+		return errors.Errorf("expected commit payload to be DeletePropertyPayload, but got %T",
+			tx.Payload)
+	}
+
+	return m.deleteClassPropertyApplyChanges(ctx, pl.ClassName, pl.PropertyName)
+}
+
+func (m *Manager) handleUpdateShardingStateCommit(ctx context.Context,
+	tx *cluster.Transaction,
+) error {
+	m.Lock()
+	defer m.Unlock()
+
+	pl, ok := tx.Payload.(UpdateShardingStatePayload)
+	if !ok {
+		return errors.Errorf("expected commit payload to be UpdateShardingStatePayload, but got %T",
+			tx.Payload)
+	}
+
+	pl.State.SetLocalName(m.clusterState.LocalName())
+	return m.updateShardingStateApplyChanges(ctx, pl.ClassName, pl.State)
+}
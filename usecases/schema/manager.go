@@ -40,14 +40,20 @@ type Manager struct {
 	moduleConfig            ModuleConfig
 	cluster                 *cluster.TxManager
 	clusterState            clusterState
-	hnswConfigParser        VectorConfigParser
+	configParser            VectorConfigParser
 	invertedConfigValidator InvertedConfigValidator
 	RestoreStatus           sync.Map
 	RestoreError            sync.Map
 	sync.Mutex
+
+	// shardingStateLock guards the sharding states, which are read by every
+	// query, while they are replaced by a rebalancing
+	shardingStateLock sync.RWMutex
 }
 
-type VectorConfigParser func(in interface{}) (schema.VectorIndexConfig, error)
+// VectorConfigParser parses the user-specified config of a vector index of
+// the given type
+type VectorConfigParser func(in interface{}, vectorIndexType string) (schema.VectorIndexConfig, error)
 
 type InvertedConfigValidator func(in *models.InvertedIndexConfig) error
 
@@ -89,7 +95,7 @@ type clusterState interface {
 // NewManager creates a new manager
 func NewManager(migrator migrate.Migrator, repo Repo,
 	logger logrus.FieldLogger, authorizer authorizer, config config.Config,
-	hnswConfigParser VectorConfigParser, vectorizerValidator VectorizerValidator,
+	configParser VectorConfigParser, vectorizerValidator VectorizerValidator,
 	invertedConfigValidator InvertedConfigValidator,
 	moduleConfig ModuleConfig, clusterState clusterState,
 	txClient cluster.Client,
@@ -101,7 +107,7 @@ func NewManager(migrator migrate.Migrator, repo Repo,
 		state:                   State{},
 		logger:                  logger,
 		authorizer:              authorizer,
-		hnswConfigParser:        hnswConfigParser,
+		configParser:            configParser,
 		vectorizerValidator:     vectorizerValidator,
 		invertedConfigValidator: invertedConfigValidator,
 		moduleConfig:            moduleConfig,
@@ -51,6 +51,24 @@ func (n *NilMigrator) UpdateShardStatus(ctx context.Context, className, shardNam
 	return nil
 }
 
+func (n *NilMigrator) TransferShards(ctx context.Context, className string,
+	transfers []sharding.Transfer,
+) error {
+	return nil
+}
+
+func (n *NilMigrator) LoadShards(ctx context.Context, className string,
+	state *sharding.State,
+) error {
+	return nil
+}
+
+func (n *NilMigrator) UnloadShards(ctx context.Context, className string,
+	previous *sharding.State,
+) error {
+	return nil
+}
+
 func (n *NilMigrator) AddProperty(ctx context.Context, className string, prop *models.Property) error {
 	return nil
 }
@@ -67,6 +85,14 @@ func (n *NilMigrator) DropProperty(ctx context.Context, className string, propNa
 	return nil
 }
 
+func (n *NilMigrator) ReindexProperty(ctx context.Context, className string, previous, updated *models.Property) error {
+	return nil
+}
+
+func (n *NilMigrator) GetShardsReindexStatus(ctx context.Context, className string) (map[string][]*models.PropertyReindexStatus, error) {
+	return nil, nil
+}
+
 func (n *NilMigrator) ValidateVectorIndexConfigUpdate(ctx context.Context, old, updated schema.VectorIndexConfig) error {
 	return nil
 }
@@ -75,6 +101,10 @@ func (n *NilMigrator) UpdateVectorIndexConfig(ctx context.Context, className str
 	return nil
 }
 
+func (n *NilMigrator) UpdateVectorIndexConfigs(ctx context.Context, className string, updated map[string]schema.VectorIndexConfig) error {
+	return nil
+}
+
 func (n *NilMigrator) ValidateInvertedIndexConfigUpdate(ctx context.Context, old, updated *models.InvertedIndexConfig) error {
 	return nil
 }
@@ -100,6 +130,8 @@ var schemaTests = []struct {
 	{name: "AddInvalidPropertyDuringCreation", fn: testAddInvalidPropertyDuringCreation},
 	{name: "AddInvalidPropertyWithEmptyDataTypeDuringCreation", fn: testAddInvalidPropertyWithEmptyDataTypeDuringCreation},
 	{name: "DropProperty", fn: testDropProperty},
+	{name: "CantDropNonExistingProperty", fn: testCantDropNonExistingProperty},
+	{name: "CantDropShardingKeyProperty", fn: testCantDropShardingKeyProperty},
 }
 
 func testUpdateMeta(t *testing.T, lsm *Manager) {
@@ -417,11 +449,6 @@ func testAddInvalidPropertyWithEmptyDataTypeDuringCreation(t *testing.T, lsm *Ma
 }
 
 func testDropProperty(t *testing.T, lsm *Manager) {
-	// TODO: https://github.com/semi-technologies/weaviate/issues/973
-	// Remove skip
-
-	t.Skip()
-
 	t.Parallel()
 
 	var properties []*models.Property = []*models.Property{
@@ -439,13 +466,59 @@ func testDropProperty(t *testing.T, lsm *Manager) {
 	assert.Len(t, objectClasses[0].Properties, 1)
 
 	// Now drop the property
-	lsm.DeleteClassProperty(context.Background(), nil, "Car", "color")
+	err = lsm.DeleteClassProperty(context.Background(), nil, "Car", "color")
+	assert.Nil(t, err)
 
 	objectClasses = testGetClasses(lsm)
 	require.Len(t, objectClasses, 1)
 	assert.Len(t, objectClasses[0].Properties, 0)
 }
 
+func testCantDropNonExistingProperty(t *testing.T, lsm *Manager) {
+	t.Parallel()
+
+	err := lsm.AddClass(context.Background(), nil, &models.Class{
+		Class: "Car",
+		Properties: []*models.Property{
+			{Name: "color", DataType: []string{"string"}},
+		},
+	})
+	require.Nil(t, err)
+
+	err = lsm.DeleteClassProperty(context.Background(), nil, "Car", "brand")
+	assert.NotNil(t, err)
+
+	objectClasses := testGetClasses(lsm)
+	require.Len(t, objectClasses, 1)
+	assert.Len(t, objectClasses[0].Properties, 1)
+}
+
+func testCantDropShardingKeyProperty(t *testing.T, lsm *Manager) {
+	t.Parallel()
+
+	err := lsm.AddClass(context.Background(), nil, &models.Class{
+		Class: "Car",
+		Properties: []*models.Property{
+			{Name: "color", DataType: []string{"string"}},
+			{Name: "brand", DataType: []string{"int"}},
+		},
+		ShardingConfig: map[string]interface{}{"key": "brand"},
+	})
+	require.Nil(t, err)
+
+	err = lsm.DeleteClassProperty(context.Background(), nil, "Car", "brand")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "sharding key")
+
+	err = lsm.DeleteClassProperty(context.Background(), nil, "Car", "color")
+	require.Nil(t, err)
+
+	objectClasses := testGetClasses(lsm)
+	require.Len(t, objectClasses, 1)
+	require.Len(t, objectClasses[0].Properties, 1)
+	assert.Equal(t, "brand", objectClasses[0].Properties[0].Name)
+}
+
 // This grant parent test setups up the temporary directory needed for the tests.
 func TestSchema(t *testing.T) {
 	// We need this test here to make sure that we wait until all child tests
@@ -30,14 +30,26 @@ type Migrator interface {
 		newClassName *string) error
 	GetShardsStatus(ctx context.Context, className string) (map[string]string, error)
 	UpdateShardStatus(ctx context.Context, className, shardName, targetStatus string) error
+	TransferShards(ctx context.Context, className string,
+		transfers []sharding.Transfer) error
+	LoadShards(ctx context.Context, className string, state *sharding.State) error
+	UnloadShards(ctx context.Context, className string, previous *sharding.State) error
 	AddProperty(ctx context.Context, className string,
 		prop *models.Property) error
 	UpdateProperty(ctx context.Context, className string,
 		propName string, newName *string) error
+	DropProperty(ctx context.Context, className string,
+		propName string) error
+	ReindexProperty(ctx context.Context, className string,
+		previous, updated *models.Property) error
+	GetShardsReindexStatus(ctx context.Context,
+		className string) (map[string][]*models.PropertyReindexStatus, error)
 	ValidateVectorIndexConfigUpdate(ctx context.Context,
 		old, updated schema.VectorIndexConfig) error
 	UpdateVectorIndexConfig(ctx context.Context, className string,
 		updated schema.VectorIndexConfig) error
+	UpdateVectorIndexConfigs(ctx context.Context, className string,
+		updated map[string]schema.VectorIndexConfig) error
 	ValidateInvertedIndexConfigUpdate(ctx context.Context,
 		old, updated *models.InvertedIndexConfig) error
 	UpdateInvertedIndexConfig(ctx context.Context, className string,
@@ -0,0 +1,216 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/storagestate"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
+)
+
+// RebalanceShards spreads the shards of a class evenly across all nodes of
+// the cluster, which is needed once nodes have been added. Shards are split
+// or moved to the new nodes by copying them, see sharding.PlanRebalance.
+//
+// The shards being copied are read-only until the rebalancing completes, so
+// that the copies do not miss any writes. Reads are served by the original
+// shards until the copies take over. The transfers which have been carried
+// out are returned, there are none if the shards were balanced already.
+func (m *Manager) RebalanceShards(ctx context.Context, principal *models.Principal,
+	className string,
+) ([]sharding.Transfer, error) {
+	err := m.authorizer.Authorize(principal, "update",
+		fmt.Sprintf("schema/%s/shards", className))
+	if err != nil {
+		return nil, err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if m.getClassByName(className) == nil {
+		return nil, ErrNotFound
+	}
+
+	initial := m.ShardingState(className)
+	plan, err := initial.PlanRebalance(m.clusterState.AllNames())
+	if err != nil {
+		return nil, NewErrUnprocessable(errors.Wrap(err, "plan rebalance"))
+	}
+
+	if len(plan.Transfers) == 0 {
+		return nil, nil
+	}
+
+	if err := m.requireShardsReady(ctx, className); err != nil {
+		return nil, NewErrUnprocessable(err)
+	}
+
+	sources := transferredShards(plan.Transfers, false)
+	if err := m.updateShardsStatus(ctx, className, sources,
+		storagestate.StatusReadOnly); err != nil {
+		return nil, m.resetShardsStatus(ctx, className, sources, err)
+	}
+
+	if err := m.migrator.TransferShards(ctx, className, plan.Transfers); err != nil {
+		return nil, m.resetShardsStatus(ctx, className, sources,
+			errors.Wrap(err, "transfer shards"))
+	}
+
+	if err := m.updateShardingState(ctx, className, plan.Staged); err != nil {
+		return nil, m.resetShardsStatus(ctx, className, sources,
+			errors.Wrap(err, "stage transferred shards"))
+	}
+
+	if err := m.updateShardingState(ctx, className, plan.Final); err != nil {
+		// the original shards keep serving requests in the staged state, going
+		// back to the initial state only removes the copies
+		if rollbackErr := m.updateShardingState(ctx, className,
+			initial.DeepCopy()); rollbackErr != nil {
+			m.logger.WithField("action", "rebalance_shards").
+				WithField("class", className).
+				WithError(rollbackErr).
+				Error("could not restore sharding state after failed rebalancing")
+		}
+
+		return nil, m.resetShardsStatus(ctx, className, sources,
+			errors.Wrap(err, "switch to transferred shards"))
+	}
+
+	if err := m.updateShardsStatus(ctx, className,
+		transferredShards(plan.Transfers, true), storagestate.StatusReady); err != nil {
+		return nil, err
+	}
+
+	return plan.Transfers, nil
+}
+
+// updateShardingState replaces the sharding state of the class across the
+// cluster. The caller must hold the lock.
+func (m *Manager) updateShardingState(ctx context.Context, className string,
+	state *sharding.State,
+) error {
+	tx, err := m.cluster.BeginTransaction(ctx, UpdateShardingState,
+		UpdateShardingStatePayload{className, state})
+	if err != nil {
+		return errors.Wrap(err, "open cluster-wide transaction")
+	}
+
+	if err := m.cluster.CommitTransaction(ctx, tx); err != nil {
+		return errors.Wrap(err, "commit cluster-wide transaction")
+	}
+
+	state.SetLocalName(m.clusterState.LocalName())
+	return m.updateShardingStateApplyChanges(ctx, className, state)
+}
+
+// updateShardingStateApplyChanges loads the shards which the new state adds
+// to this node before switching over to it, so that they can serve requests
+// right away. Shards which are no longer needed are removed afterwards.
+func (m *Manager) updateShardingStateApplyChanges(ctx context.Context,
+	className string, state *sharding.State,
+) error {
+	class := m.getClassByName(className)
+	if class == nil {
+		return ErrNotFound
+	}
+
+	if err := m.migrator.LoadShards(ctx, className, state); err != nil {
+		return errors.Wrap(err, "load shards")
+	}
+
+	m.shardingStateLock.Lock()
+	previous := m.state.ShardingState[className]
+	m.state.ShardingState[className] = state
+	m.shardingStateLock.Unlock()
+
+	if cfg, ok := class.ShardingConfig.(sharding.Config); ok {
+		cfg.ActualCount = state.Config.ActualCount
+		class.ShardingConfig = cfg
+	}
+
+	if err := m.migrator.UnloadShards(ctx, className, previous); err != nil {
+		return errors.Wrap(err, "unload shards")
+	}
+
+	return m.saveSchema(ctx)
+}
+
+func (m *Manager) requireShardsReady(ctx context.Context, className string) error {
+	status, err := m.migrator.GetShardsStatus(ctx, className)
+	if err != nil {
+		return errors.Wrap(err, "get shards status")
+	}
+
+	for shard, s := range status {
+		if s != storagestate.StatusReady.String() {
+			return errors.Errorf("shard %q is %s, all shards need to be %s",
+				shard, s, storagestate.StatusReady)
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) updateShardsStatus(ctx context.Context, className string,
+	shards []string, status storagestate.Status,
+) error {
+	for _, shard := range shards {
+		if err := m.migrator.UpdateShardStatus(ctx, className, shard,
+			status.String()); err != nil {
+			return errors.Wrapf(err, "mark shard %q as %s", shard, status)
+		}
+	}
+
+	return nil
+}
+
+// resetShardsStatus marks the shards as ready again after a failed
+// rebalancing, the original error is returned either way
+func (m *Manager) resetShardsStatus(ctx context.Context, className string,
+	shards []string, err error,
+) error {
+	if resetErr := m.updateShardsStatus(ctx, className, shards,
+		storagestate.StatusReady); resetErr != nil {
+		m.logger.WithField("action", "rebalance_shards").
+			WithField("class", className).
+			WithError(resetErr).
+			Error("could not reset shards status after failed rebalancing")
+	}
+
+	return err
+}
+
+// transferredShards lists the source shards of the transfers, as well as
+// their target shards if includeTargets is set
+func transferredShards(transfers []sharding.Transfer, includeTargets bool) []string {
+	unique := map[string]struct{}{}
+	for _, transfer := range transfers {
+		unique[transfer.Shard] = struct{}{}
+		if includeTargets {
+			unique[transfer.TargetShard] = struct{}{}
+		}
+	}
+
+	shards := make([]string, 0, len(unique))
+	for shard := range unique {
+		shards = append(shards, shard)
+	}
+	sort.Strings(shards)
+
+	return shards
+}
@@ -0,0 +1,83 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebalanceShards_SwitchFails(t *testing.T) {
+	ctx := context.Background()
+	clusterState := &fakeClusterState{names: []string{"node1"}}
+	sm := newSchemaManager()
+	sm.clusterState = clusterState
+
+	require.Nil(t, sm.AddClass(ctx, nil, &models.Class{Class: "Rebalanced"}))
+	initial := sm.ShardingState("Rebalanced").DeepCopy()
+
+	// the class has a single shard, the new node gets a shard split off it
+	clusterState.names = []string{"node1", "node2"}
+	migrator := &rebalanceMigrator{failLoadShards: 2}
+	sm.migrator = migrator
+
+	_, err := sm.RebalanceShards(ctx, nil, "Rebalanced")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "switch to transferred shards")
+
+	shard := initial.AllPhysicalShards()[0]
+	assert.Equal(t, []shardStatusUpdate{
+		{shard: shard, status: "READONLY"},
+		{shard: shard, status: "READY"},
+	}, migrator.statusUpdates)
+
+	assert.Equal(t, initial.AllPhysicalShards(),
+		sm.ShardingState("Rebalanced").AllPhysicalShards())
+}
+
+type shardStatusUpdate struct {
+	shard  string
+	status string
+}
+
+// rebalanceMigrator fails the n-th call of LoadShards and records the
+// status updates of the shards
+type rebalanceMigrator struct {
+	NilMigrator
+	failLoadShards  int
+	loadShardsCalls int
+	statusUpdates   []shardStatusUpdate
+}
+
+func (m *rebalanceMigrator) LoadShards(ctx context.Context, className string,
+	state *sharding.State,
+) error {
+	m.loadShardsCalls++
+	if m.loadShardsCalls == m.failLoadShards {
+		return errors.Errorf("node2 is unreachable")
+	}
+	return nil
+}
+
+func (m *rebalanceMigrator) UpdateShardStatus(ctx context.Context, className,
+	shardName, targetStatus string,
+) error {
+	m.statusUpdates = append(m.statusUpdates,
+		shardStatusUpdate{shard: shardName, status: targetStatus})
+	return nil
+}
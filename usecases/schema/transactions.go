@@ -25,6 +25,10 @@ const (
 	AddProperty cluster.TransactionType = "add_property"
 	DeleteClass cluster.TransactionType = "delete_class"
 	UpdateClass cluster.TransactionType = "update_class"
+
+	DeleteProperty cluster.TransactionType = "delete_property"
+
+	UpdateShardingState cluster.TransactionType = "update_sharding_state"
 )
 
 type AddClassPayload struct {
@@ -41,6 +45,13 @@ type DeleteClassPayload struct {
 	ClassName string `json:"className"`
 }
 
+// DeletePropertyPayload removes a property from a class, the values of the
+// property are stripped from the objects in the background on every node
+type DeletePropertyPayload struct {
+	ClassName    string `json:"className"`
+	PropertyName string `json:"propertyName"`
+}
+
 type UpdateClassPayload struct {
 	ClassName string        `json:"className"`
 	Class     *models.Class `json:"class"`
@@ -51,6 +62,13 @@ type UpdateClassPayload struct {
 	State *sharding.State `json:"state"`
 }
 
+// UpdateShardingStatePayload replaces the sharding state of a class, it is
+// used to move shards between nodes
+type UpdateShardingStatePayload struct {
+	ClassName string          `json:"className"`
+	State     *sharding.State `json:"state"`
+}
+
 func UnmarshalTransaction(txType cluster.TransactionType,
 	payload json.RawMessage,
 ) (interface{}, error) {
@@ -67,6 +85,12 @@ func UnmarshalTransaction(txType cluster.TransactionType,
 	case UpdateClass:
 		return unmarshalUpdateClass(payload)
 
+	case DeleteProperty:
+		return unmarshalDeleteProperty(payload)
+
+	case UpdateShardingState:
+		return unmarshalUpdateShardingState(payload)
+
 	default:
 		return nil, errors.Errorf("unrecognized schema transaction type %q", txType)
 
@@ -108,3 +132,21 @@ func unmarshalUpdateClass(payload json.RawMessage) (interface{}, error) {
 
 	return pl, nil
 }
+
+func unmarshalDeleteProperty(payload json.RawMessage) (interface{}, error) {
+	var pl DeletePropertyPayload
+	if err := json.Unmarshal(payload, &pl); err != nil {
+		return nil, err
+	}
+
+	return pl, nil
+}
+
+func unmarshalUpdateShardingState(payload json.RawMessage) (interface{}, error) {
+	var pl UpdateShardingStatePayload
+	if err := json.Unmarshal(payload, &pl); err != nil {
+		return nil, err
+	}
+
+	return pl, nil
+}
@@ -60,6 +60,14 @@ func (m *Manager) UpdateClass(ctx context.Context, principal *models.Principal,
 		return errors.Wrap(err, "vector index config")
 	}
 
+	for name, cfg := range updated.VectorConfig {
+		if err := m.migrator.ValidateVectorIndexConfigUpdate(ctx,
+			initial.VectorConfig[name].VectorIndexConfig.(schema.VectorIndexConfig),
+			cfg.VectorIndexConfig.(schema.VectorIndexConfig)); err != nil {
+			return errors.Wrapf(err, "vector index config of named vector %q", name)
+		}
+	}
+
 	if err := m.migrator.ValidateInvertedIndexConfigUpdate(ctx,
 		initial.InvertedIndexConfig, updated.InvertedIndexConfig); err != nil {
 		return errors.Wrap(err, "inverted index config")
@@ -94,6 +102,17 @@ func (m *Manager) updateClassApplyChanges(ctx context.Context, className string,
 		return errors.Wrap(err, "vector index config")
 	}
 
+	if len(updated.VectorConfig) > 0 {
+		configs := make(map[string]schema.VectorIndexConfig, len(updated.VectorConfig))
+		for name, cfg := range updated.VectorConfig {
+			configs[name] = cfg.VectorIndexConfig.(schema.VectorIndexConfig)
+		}
+
+		if err := m.migrator.UpdateVectorIndexConfigs(ctx, className, configs); err != nil {
+			return errors.Wrap(err, "vector index configs of named vectors")
+		}
+	}
+
 	if err := m.migrator.UpdateInvertedIndexConfig(ctx, className,
 		updated.InvertedIndexConfig); err != nil {
 		return errors.Wrap(err, "inverted index config")
@@ -104,6 +123,29 @@ func (m *Manager) updateClassApplyChanges(ctx context.Context, className string,
 		return ErrNotFound
 	}
 
+	// the reindex is started before the updated properties become visible, so
+	// that the previous inverted index keeps being used until it is replaced
+	for _, prop := range updated.Properties {
+		previous, err := schema.GetPropertyByName(initial, prop.Name)
+		if err != nil {
+			return err
+		}
+
+		if !PropertyNeedsReindex(previous, prop) {
+			continue
+		}
+
+		if err := m.migrator.ReindexProperty(ctx, className, previous, prop); err != nil {
+			return errors.Wrapf(err, "reindex property %q", prop.Name)
+		}
+	}
+
+	// the consistency level is the only part of the sharding config which can
+	// change, it takes effect on the next write
+	if shardState := m.state.ShardingState[className]; shardState != nil {
+		shardState.Config.ConsistencyLevel = updated.ShardingConfig.(sharding.Config).ConsistencyLevel
+	}
+
 	*initial = *updated
 
 	return m.saveSchema(ctx)
@@ -131,17 +173,135 @@ func (m *Manager) validateImmutableFields(initial, updated *models.Class) error
 		}
 	}
 
-	if !reflect.DeepEqual(initial.Properties, updated.Properties) {
-		return errors.Errorf(
-			"properties cannot be updated through updating the class. Use the add " +
-				"property feature (e.g. \"POST /v1/schema/{className}/properties\") " +
-				"to add additional properties")
+	if err := m.validateImmutableProperties(initial, updated); err != nil {
+		return err
 	}
 
 	if !reflect.DeepEqual(initial.ModuleConfig, updated.ModuleConfig) {
 		return errors.Errorf("module config is immutable")
 	}
 
+	if err := validateImmutableNamedVectors(initial, updated); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var errPropertiesImmutable = errors.Errorf(
+	"properties cannot be added, removed or renamed through updating the class. " +
+		"Use the add property feature (e.g. \"POST /v1/schema/{className}/properties\") " +
+		"to add additional properties")
+
+// validateImmutableProperties makes sure that no properties are added or
+// removed and that only the tokenization and the indexInverted setting of
+// existing properties are changed. Changing those reindexes the property,
+// which is not possible for the sharding key.
+func (m *Manager) validateImmutableProperties(initial, updated *models.Class) error {
+	updatedProps := map[string]*models.Property{}
+	for _, prop := range updated.Properties {
+		updatedProps[prop.Name] = prop
+	}
+
+	if len(initial.Properties) != len(updatedProps) {
+		return errPropertiesImmutable
+	}
+
+	sch := m.GetSchemaSkipAuth()
+	for _, initialProp := range initial.Properties {
+		updatedProp, ok := updatedProps[initialProp.Name]
+		if !ok {
+			return errPropertiesImmutable
+		}
+
+		// apart from the settings of the inverted index, the property must
+		// not change at all
+		compared := *initialProp
+		compared.Tokenization = updatedProp.Tokenization
+		compared.IndexInverted = updatedProp.IndexInverted
+		if !reflect.DeepEqual(&compared, updatedProp) {
+			return errors.Errorf("property %q: only the tokenization and the "+
+				"indexInverted setting of a property can be updated", initialProp.Name)
+		}
+
+		if !PropertyNeedsReindex(initialProp, updatedProp) {
+			continue
+		}
+
+		// filters on the sharding key are limited to the shard its value is
+		// hashed to, which requires the exact values to stay indexed
+		if cfg, ok := initial.ShardingConfig.(sharding.Config); ok &&
+			cfg.KeyProperty() == initialProp.Name {
+			return errors.Errorf("property %q: the tokenization and the "+
+				"indexInverted setting of the sharding key cannot be changed",
+				initialProp.Name)
+		}
+
+		if schema.IsNestedDataType(updatedProp.DataType) {
+			return errors.Errorf("property %q: the inverted index of object and "+
+				"object[] properties cannot be changed", initialProp.Name)
+		}
+
+		if schema.IsRefDataType(updatedProp.DataType) ||
+			updatedProp.DataType[0] == string(schema.DataTypeGeoCoordinates) {
+			return errors.Errorf("property %q: the inverted index of reference and "+
+				"geoCoordinates properties cannot be changed", initialProp.Name)
+		}
+
+		propertyDataType, err := sch.FindPropertyDataType(updatedProp.DataType)
+		if err != nil {
+			return errors.Errorf("property %q: invalid dataType: %v", initialProp.Name, err)
+		}
+
+		if err := validatePropertyTokenization(updatedProp.Tokenization, propertyDataType); err != nil {
+			return errors.Wrapf(err, "property %q", initialProp.Name)
+		}
+	}
+
+	return nil
+}
+
+// PropertyNeedsReindex is true if the inverted index of the property differs
+// between the two versions of it, either because the property is (no longer)
+// indexed or because its values are tokenized differently
+func PropertyNeedsReindex(previous, updated *models.Property) bool {
+	if propertyIndexInverted(previous) != propertyIndexInverted(updated) {
+		return true
+	}
+
+	return propertyIndexInverted(updated) && previous.Tokenization != updated.Tokenization
+}
+
+func propertyIndexInverted(prop *models.Property) bool {
+	return prop.IndexInverted == nil || *prop.IndexInverted
+}
+
+// validateImmutableNamedVectors makes sure that no named vectors are added or
+// removed and that only their vector index configs are changed
+func validateImmutableNamedVectors(initial, updated *models.Class) error {
+	if len(initial.VectorConfig) != len(updated.VectorConfig) {
+		return errors.Errorf("named vectors are immutable: attempted change "+
+			"from %d to %d named vectors", len(initial.VectorConfig), len(updated.VectorConfig))
+	}
+
+	for name, updatedCfg := range updated.VectorConfig {
+		initialCfg, ok := initial.VectorConfig[name]
+		if !ok {
+			return errors.Errorf("named vectors are immutable: attempted to add "+
+				"named vector %q", name)
+		}
+
+		if !reflect.DeepEqual(initialCfg.Vectorizer, updatedCfg.Vectorizer) {
+			return errors.Errorf("vectorizer of named vector %q is immutable", name)
+		}
+
+		if initialCfg.VectorIndexType != updatedCfg.VectorIndexType {
+			return errors.Errorf("vector index type of named vector %q is immutable: "+
+				"attempted change from %q to %q", name, initialCfg.VectorIndexType,
+				updatedCfg.VectorIndexType)
+		}
+	}
+
 	return nil
 }
 
@@ -79,8 +79,8 @@ func TestClassUpdates(t *testing.T) {
 					},
 				},
 				expectedError: errors.Errorf(
-					"properties cannot be updated through updating the class. Use the add " +
-						"property feature (e.g. \"POST /v1/schema/{className}/properties\") " +
+					"properties cannot be added, removed or renamed through updating the class. " +
+						"Use the add property feature (e.g. \"POST /v1/schema/{className}/properties\") " +
 						"to add additional properties"),
 			},
 			{
@@ -126,10 +126,105 @@ func TestClassUpdates(t *testing.T) {
 					},
 				},
 				expectedError: errors.Errorf(
-					"properties cannot be updated through updating the class. Use the add " +
-						"property feature (e.g. \"POST /v1/schema/{className}/properties\") " +
+					"properties cannot be added, removed or renamed through updating the class. " +
+						"Use the add property feature (e.g. \"POST /v1/schema/{className}/properties\") " +
 						"to add additional properties"),
 			},
+			{
+				name: "changing the tokenization of a property",
+				initial: &models.Class{
+					Class: "InitialName",
+					Properties: []*models.Property{
+						{
+							Name:     "aProp",
+							DataType: []string{"string"},
+						},
+					},
+				},
+				update: &models.Class{
+					Class: "InitialName",
+					Properties: []*models.Property{
+						{
+							Name:         "aProp",
+							DataType:     []string{"string"},
+							Tokenization: "field",
+						},
+					},
+				},
+				expectedError: nil,
+			},
+			{
+				name: "attempting to set a tokenization the data type does not support",
+				initial: &models.Class{
+					Class: "InitialName",
+					Properties: []*models.Property{
+						{
+							Name:     "aProp",
+							DataType: []string{"text"},
+						},
+					},
+				},
+				update: &models.Class{
+					Class: "InitialName",
+					Properties: []*models.Property{
+						{
+							Name:         "aProp",
+							DataType:     []string{"text"},
+							Tokenization: "field",
+						},
+					},
+				},
+				expectedError: errors.Errorf("property \"aProp\": " +
+					"Tokenization 'field' is not allowed for data type 'text'"),
+			},
+			{
+				name: "attempting to change the inverted index of a geo property",
+				initial: &models.Class{
+					Class: "InitialName",
+					Properties: []*models.Property{
+						{
+							Name:     "location",
+							DataType: []string{"geoCoordinates"},
+						},
+					},
+				},
+				update: &models.Class{
+					Class: "InitialName",
+					Properties: []*models.Property{
+						{
+							Name:          "location",
+							DataType:      []string{"geoCoordinates"},
+							IndexInverted: pointerToFalse(),
+						},
+					},
+				},
+				expectedError: errors.Errorf("property \"location\": the inverted index of " +
+					"reference and geoCoordinates properties cannot be changed"),
+			},
+			{
+				name: "attempting to change the description of a property",
+				initial: &models.Class{
+					Class: "InitialName",
+					Properties: []*models.Property{
+						{
+							Name:     "aProp",
+							DataType: []string{"string"},
+						},
+					},
+				},
+				update: &models.Class{
+					Class: "InitialName",
+					Properties: []*models.Property{
+						{
+							Name:        "aProp",
+							DataType:    []string{"string"},
+							Description: "changed",
+						},
+					},
+				},
+				expectedError: errors.Errorf("property \"aProp\": only the tokenization " +
+					"and the indexInverted setting of a property can be updated"),
+			},
 			{
 				name: "attempting to update the inverted index cleanup interval",
 				initial: &models.Class{
@@ -231,6 +326,75 @@ func TestClassUpdates(t *testing.T) {
 				},
 				expectedError: nil,
 			},
+			{
+				name: "attempting to add a named vector",
+				initial: &models.Class{
+					Class:        "InitialName",
+					VectorConfig: map[string]models.VectorConfig{"title": {}},
+				},
+				update: &models.Class{
+					Class:        "InitialName",
+					VectorConfig: map[string]models.VectorConfig{"title": {}, "description": {}},
+				},
+				expectedError: errors.Errorf("named vectors are immutable: " +
+					"attempted change from 1 to 2 named vectors"),
+			},
+			{
+				name: "attempting to rename a named vector",
+				initial: &models.Class{
+					Class:        "InitialName",
+					VectorConfig: map[string]models.VectorConfig{"title": {}},
+				},
+				update: &models.Class{
+					Class:        "InitialName",
+					VectorConfig: map[string]models.VectorConfig{"description": {}},
+				},
+				expectedError: errors.Errorf("named vectors are immutable: " +
+					"attempted to add named vector \"description\""),
+			},
+			{
+				name: "attempting to modify the vectorizer of a named vector",
+				initial: &models.Class{
+					Class:        "InitialName",
+					VectorConfig: map[string]models.VectorConfig{"title": {}},
+				},
+				update: &models.Class{
+					Class: "InitialName",
+					VectorConfig: map[string]models.VectorConfig{"title": {
+						Vectorizer: map[string]interface{}{"model1": map[string]interface{}{}},
+					}},
+				},
+				expectedError: errors.Errorf("vectorizer of named vector \"title\" is immutable"),
+			},
+			{
+				name: "attempting to modify the vector index type of a named vector",
+				initial: &models.Class{
+					Class:        "InitialName",
+					VectorConfig: map[string]models.VectorConfig{"title": {}},
+				},
+				update: &models.Class{
+					Class:        "InitialName",
+					VectorConfig: map[string]models.VectorConfig{"title": {VectorIndexType: "flat"}},
+				},
+				expectedError: errors.Errorf("vector index type of named vector \"title\" " +
+					"is immutable: attempted change from \"hnsw\" to \"flat\""),
+			},
+			{
+				name: "updating the vector index config of a named vector",
+				initial: &models.Class{
+					Class: "InitialName",
+					VectorConfig: map[string]models.VectorConfig{"title": {
+						VectorIndexConfig: map[string]interface{}{"some-setting": "old-value"},
+					}},
+				},
+				update: &models.Class{
+					Class: "InitialName",
+					VectorConfig: map[string]models.VectorConfig{"title": {
+						VectorIndexConfig: map[string]interface{}{"some-setting": "new-value"},
+					}},
+				},
+				expectedError: nil,
+			},
 		}
 
 		for _, test := range tests {
@@ -374,6 +538,137 @@ func TestClassUpdates(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("reindex properties", func(t *testing.T) {
+		sm := newSchemaManager()
+		migrator := &configMigrator{}
+		sm.migrator = migrator
+
+		t.Run("create an initial class", func(t *testing.T) {
+			err := sm.AddClass(context.Background(), nil, &models.Class{
+				Class: "ClassWithProps",
+				Properties: []*models.Property{
+					{Name: "name", DataType: []string{"string"}},
+					{Name: "description", DataType: []string{"text"}},
+					{Name: "count", DataType: []string{"int"}},
+				},
+			})
+
+			require.Nil(t, err)
+		})
+
+		t.Run("change the tokenization of one prop and disable another", func(t *testing.T) {
+			err := sm.UpdateClass(context.Background(), nil,
+				"ClassWithProps", &models.Class{
+					Class: "ClassWithProps",
+					Properties: []*models.Property{
+						{Name: "name", DataType: []string{"string"}, Tokenization: "field"},
+						{Name: "description", DataType: []string{"text"}},
+						{Name: "count", DataType: []string{"int"}, IndexInverted: pointerToFalse()},
+					},
+				})
+			require.Nil(t, err)
+
+			require.Len(t, migrator.reindexCalledWith, 2)
+			assert.Equal(t, "name", migrator.reindexCalledWith[0].previous.Name)
+			assert.Equal(t, "word", migrator.reindexCalledWith[0].previous.Tokenization)
+			assert.Equal(t, "field", migrator.reindexCalledWith[0].updated.Tokenization)
+			assert.Equal(t, "count", migrator.reindexCalledWith[1].previous.Name)
+			assert.Nil(t, migrator.reindexCalledWith[1].previous.IndexInverted)
+			assert.False(t, *migrator.reindexCalledWith[1].updated.IndexInverted)
+		})
+
+		t.Run("the schema holds the updated props", func(t *testing.T) {
+			class := sm.getClassByName("ClassWithProps")
+			require.NotNil(t, class)
+			assert.Equal(t, "field", class.Properties[0].Tokenization)
+			assert.False(t, *class.Properties[2].IndexInverted)
+		})
+
+		t.Run("an update without changes does not reindex", func(t *testing.T) {
+			migrator.reindexCalledWith = nil
+			err := sm.UpdateClass(context.Background(), nil,
+				"ClassWithProps", &models.Class{
+					Class: "ClassWithProps",
+					Properties: []*models.Property{
+						{Name: "name", DataType: []string{"string"}, Tokenization: "field"},
+						{Name: "description", DataType: []string{"text"}},
+						{Name: "count", DataType: []string{"int"}, IndexInverted: pointerToFalse()},
+					},
+				})
+			require.Nil(t, err)
+			assert.Len(t, migrator.reindexCalledWith, 0)
+		})
+	})
+
+	t.Run("reindex the sharding key", func(t *testing.T) {
+		sm := newSchemaManager()
+		migrator := &configMigrator{}
+		sm.migrator = migrator
+
+		t.Run("create an initial class", func(t *testing.T) {
+			err := sm.AddClass(context.Background(), nil, &models.Class{
+				Class: "ClassWithShardingKey",
+				Properties: []*models.Property{
+					{Name: "brand", DataType: []string{"string"}, Tokenization: "field"},
+					{Name: "name", DataType: []string{"string"}},
+				},
+				ShardingConfig: map[string]interface{}{"key": "brand"},
+			})
+
+			require.Nil(t, err)
+		})
+
+		tests := []struct {
+			name  string
+			brand *models.Property
+		}{
+			{
+				name:  "change the tokenization",
+				brand: &models.Property{Name: "brand", DataType: []string{"string"}, Tokenization: "word"},
+			},
+			{
+				name: "disable the inverted index",
+				brand: &models.Property{
+					Name: "brand", DataType: []string{"string"},
+					Tokenization: "field", IndexInverted: pointerToFalse(),
+				},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				err := sm.UpdateClass(context.Background(), nil,
+					"ClassWithShardingKey", &models.Class{
+						Class: "ClassWithShardingKey",
+						Properties: []*models.Property{
+							test.brand,
+							{Name: "name", DataType: []string{"string"}},
+						},
+						ShardingConfig: map[string]interface{}{"key": "brand"},
+					})
+				require.NotNil(t, err)
+				assert.Equal(t, "property \"brand\": the tokenization and the "+
+					"indexInverted setting of the sharding key cannot be changed", err.Error())
+				assert.Len(t, migrator.reindexCalledWith, 0)
+			})
+		}
+
+		t.Run("other props can still be reindexed", func(t *testing.T) {
+			err := sm.UpdateClass(context.Background(), nil,
+				"ClassWithShardingKey", &models.Class{
+					Class: "ClassWithShardingKey",
+					Properties: []*models.Property{
+						{Name: "brand", DataType: []string{"string"}, Tokenization: "field"},
+						{Name: "name", DataType: []string{"string"}, Tokenization: "field"},
+					},
+					ShardingConfig: map[string]interface{}{"key": "brand"},
+				})
+			require.Nil(t, err)
+			require.Len(t, migrator.reindexCalledWith, 1)
+			assert.Equal(t, "name", migrator.reindexCalledWith[0].updated.Name)
+		})
+	})
 }
 
 type configMigrator struct {
@@ -382,6 +677,13 @@ type configMigrator struct {
 	vectorConfigValidateCalledWith schema.VectorIndexConfig
 	vectorConfigUpdateCalled       bool
 	vectorConfigUpdateCalledWith   schema.VectorIndexConfig
+	vectorConfigsUpdateCalledWith  map[string]schema.VectorIndexConfig
+	reindexCalledWith              []reindexedProperty
+}
+
+type reindexedProperty struct {
+	previous *models.Property
+	updated  *models.Property
 }
 
 func (m *configMigrator) ValidateVectorIndexConfigUpdate(ctx context.Context,
@@ -398,3 +700,17 @@ func (m *configMigrator) UpdateVectorIndexConfig(ctx context.Context,
 	m.vectorConfigUpdateCalled = true
 	return nil
 }
+
+func (m *configMigrator) UpdateVectorIndexConfigs(ctx context.Context,
+	className string, updated map[string]schema.VectorIndexConfig,
+) error {
+	m.vectorConfigsUpdateCalledWith = updated
+	return nil
+}
+
+func (m *configMigrator) ReindexProperty(ctx context.Context,
+	className string, previous, updated *models.Property,
+) error {
+	m.reindexCalledWith = append(m.reindexCalledWith, reindexedProperty{previous, updated})
+	return nil
+}
@@ -14,11 +14,15 @@ package schema
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/languages"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted/stopwords"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/usecases/config"
+	"github.com/semi-technologies/weaviate/usecases/sharding"
 )
 
 func (m *Manager) validateClassNameUniqueness(className string) error {
@@ -49,7 +53,8 @@ func validatePropertyTokenization(tokenization string, propertyDataType schema.P
 			}
 		case schema.DataTypeText, schema.DataTypeTextArray:
 			switch tokenization {
-			case models.PropertyTokenizationWord:
+			case models.PropertyTokenizationWord, models.PropertyTokenizationTrigram,
+				models.PropertyTokenizationCjk:
 				return nil
 			}
 		default:
@@ -65,9 +70,123 @@ func validatePropertyTokenization(tokenization string, propertyDataType schema.P
 		return nil
 	}
 
+	if propertyDataType.IsNested() {
+		return fmt.Errorf("Tokenization '%s' is not allowed for data type '%s'",
+			tokenization, propertyDataType.AsNested())
+	}
+
 	return fmt.Errorf("Tokenization '%s' is not allowed for reference data type", tokenization)
 }
 
+func validatePropertyAnalyzer(analyzer *models.PropertyAnalyzerConfig, propertyDataType schema.PropertyDataType) error {
+	if analyzer == nil {
+		return nil
+	}
+
+	if propertyDataType.IsNested() {
+		return fmt.Errorf("analyzer is not allowed for data type '%s'", propertyDataType.AsNested())
+	}
+
+	if !propertyDataType.IsPrimitive() {
+		return fmt.Errorf("analyzer is not allowed for reference data type")
+	}
+
+	switch dt := propertyDataType.AsPrimitive(); dt {
+	case schema.DataTypeText, schema.DataTypeTextArray:
+	default:
+		return fmt.Errorf("analyzer is not allowed for data type '%s', only for text and text[]", dt)
+	}
+
+	if analyzer.Language != "" && !languages.Supported(analyzer.Language) {
+		return fmt.Errorf("analyzer language '%s' is not supported, use one of %s",
+			analyzer.Language, strings.Join(languages.All(), ", "))
+	}
+
+	if analyzer.Stemming && analyzer.Language == "" {
+		return fmt.Errorf("analyzer stemming requires a language")
+	}
+
+	if analyzer.StopwordPreset != "" {
+		if _, ok := stopwords.Presets[analyzer.StopwordPreset]; !ok {
+			return fmt.Errorf("analyzer stopwordPreset '%s' does not exist", analyzer.StopwordPreset)
+		}
+	}
+
+	return nil
+}
+
+// validateNestedProperties makes sure that the nested props of the object
+// and object[] data types describe their values, and that no other data type
+// has nested props. Nested props cannot be references, nor of the data types
+// which are not served by the inverted index.
+func validateNestedProperties(property *models.Property,
+	propertyDataType schema.PropertyDataType,
+) error {
+	if !propertyDataType.IsNested() {
+		if len(property.NestedProperties) > 0 {
+			return fmt.Errorf("nestedProperties are only allowed for data types '%s' and '%s'",
+				schema.DataTypeObject, schema.DataTypeObjectArray)
+		}
+		return nil
+	}
+
+	if len(property.NestedProperties) == 0 {
+		return fmt.Errorf("data type '%s' requires at least one nested property",
+			propertyDataType.AsNested())
+	}
+
+	existingNames := map[string]bool{}
+	for _, nested := range property.NestedProperties {
+		if _, err := schema.ValidatePropertyName(nested.Name); err != nil {
+			return err
+		}
+
+		if existingNames[nested.Name] {
+			return fmt.Errorf("name '%s' already in use as a nested property name", nested.Name)
+		}
+		existingNames[nested.Name] = true
+
+		nestedDataType, err := findNestedPropertyDataType(nested.DataType)
+		if err != nil {
+			return fmt.Errorf("nested property '%s': invalid dataType: %v", nested.Name, err)
+		}
+
+		if err := validatePropertyTokenization(nested.Tokenization, nestedDataType); err != nil {
+			return fmt.Errorf("nested property '%s': %v", nested.Name, err)
+		}
+
+		if err := validatePropertyAnalyzer(nested.Analyzer, nestedDataType); err != nil {
+			return fmt.Errorf("nested property '%s': %v", nested.Name, err)
+		}
+
+		if err := validateNestedProperties(nested, nestedDataType); err != nil {
+			return fmt.Errorf("nested property '%s': %v", nested.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func findNestedPropertyDataType(dataType []string) (schema.PropertyDataType, error) {
+	if len(dataType) > 0 && len(dataType[0]) > 0 && schema.IsRefDataType(dataType) {
+		return nil, fmt.Errorf("nested properties cannot be references")
+	}
+
+	propertyDataType, err := (&schema.Schema{}).FindPropertyDataType(dataType)
+	if err != nil {
+		return nil, err
+	}
+
+	if propertyDataType.IsPrimitive() {
+		switch dt := propertyDataType.AsPrimitive(); dt {
+		case schema.DataTypeGeoCoordinates, schema.DataTypePhoneNumber, schema.DataTypeBlob:
+			return nil, fmt.Errorf("data type '%s' is not supported for nested properties", dt)
+		}
+	}
+
+	return propertyDataType, nil
+}
+
 func (m *Manager) validateVectorSettings(ctx context.Context, class *models.Class) error {
 	if err := m.validateVectorizer(ctx, class); err != nil {
 		return err
@@ -77,9 +196,34 @@ func (m *Manager) validateVectorSettings(ctx context.Context, class *models.Clas
 		return err
 	}
 
+	for name, cfg := range class.VectorConfig {
+		if err := m.validateNamedVector(name, cfg); err != nil {
+			return errors.Wrapf(err, "named vector %q", name)
+		}
+	}
+
 	return nil
 }
 
+func (m *Manager) validateNamedVector(name string, cfg models.VectorConfig) error {
+	if err := schema.ValidateVectorName(name); err != nil {
+		return err
+	}
+
+	moduleName, _, err := schema.NamedVectorizer(cfg)
+	if err != nil {
+		return err
+	}
+
+	if moduleName != config.VectorizerModuleNone {
+		if err := m.vectorizerValidator.ValidateVectorizer(moduleName); err != nil {
+			return errors.Wrap(err, "vectorizer")
+		}
+	}
+
+	return validateVectorIndexType(cfg.VectorIndexType)
+}
+
 func (m *Manager) validateVectorizer(ctx context.Context, class *models.Class) error {
 	if class.Vectorizer == config.VectorizerModuleNone {
 		return nil
@@ -93,11 +237,49 @@ func (m *Manager) validateVectorizer(ctx context.Context, class *models.Class) e
 }
 
 func (m *Manager) validateVectorIndex(ctx context.Context, class *models.Class) error {
-	switch class.VectorIndexType {
-	case "hnsw":
+	return validateVectorIndexType(class.VectorIndexType)
+}
+
+func validateVectorIndexType(vectorIndexType string) error {
+	switch vectorIndexType {
+	case "hnsw", "flat":
 		return nil
 	default:
 		return errors.Errorf("unrecognized or unsupported vectorIndexType %q",
-			class.VectorIndexType)
+			vectorIndexType)
+	}
+}
+
+// validateShardingKey makes sure that the property the objects of the class
+// are sharded by, if any, is one with exact values. Filters on such a
+// property can then be limited to the shard of the value they match.
+func validateShardingKey(class *models.Class) error {
+	key := class.ShardingConfig.(sharding.Config).KeyProperty()
+	if key == "" {
+		return nil
+	}
+
+	for _, prop := range class.Properties {
+		if prop.Name != key {
+			continue
+		}
+
+		if len(prop.DataType) == 1 {
+			switch schema.DataType(prop.DataType[0]) {
+			case schema.DataTypeInt:
+				return nil
+			case schema.DataTypeString:
+				if prop.Tokenization == models.PropertyTokenizationField {
+					return nil
+				}
+			}
+		}
+
+		return errors.Errorf("sharding key property %q must be of data type %q, "+
+			"or of data type %q with tokenization %q", key, schema.DataTypeInt,
+			schema.DataTypeString, models.PropertyTokenizationField)
 	}
+
+	return errors.Errorf("sharding key %q is not a property of class %q",
+		key, class.Class)
 }
@@ -34,6 +34,10 @@ type Config struct {
 	Key                 string `json:"key"`
 	Strategy            string `json:"strategy"`
 	Function            string `json:"function"`
+
+	// Replicas is the number of nodes holding a copy of each shard
+	Replicas         int              `json:"replicas"`
+	ConsistencyLevel ConsistencyLevel `json:"consistencyLevel"`
 }
 
 func (c *Config) setDefaults(nodeCount int) {
@@ -43,6 +47,8 @@ func (c *Config) setDefaults(nodeCount int) {
 	c.Function = DefaultFunction
 	c.Key = DefaultKey
 	c.Strategy = DefaultStrategy
+	c.Replicas = DefaultReplicas
+	c.ConsistencyLevel = DefaultConsistencyLevel
 
 	// these will only differ once there is an async component through replication
 	// or dynamic scaling. For now they have to be the same
@@ -51,9 +57,10 @@ func (c *Config) setDefaults(nodeCount int) {
 }
 
 func (c *Config) validate() error {
-	if c.Key != "_id" {
-		return errors.Errorf("sharding only supported on key '_id' for now, "+
-			"got: %s", c.Key)
+	// any other key is the name of a property, whether the class has it is
+	// validated together with the class
+	if c.Key == "" {
+		return errors.Errorf("sharding key must not be empty")
 	}
 
 	if c.Strategy != "hash" {
@@ -66,6 +73,14 @@ func (c *Config) validate() error {
 			"got: %s", c.Function)
 	}
 
+	if c.Replicas < 1 {
+		return errors.Errorf("replicas must be at least 1, got: %d", c.Replicas)
+	}
+
+	if err := c.ConsistencyLevel.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -120,6 +135,18 @@ func ParseConfig(input interface{}, nodeCount int) (Config, error) {
 		return out, err
 	}
 
+	if err := optionalIntFromMap(asMap, "replicas", func(v int) {
+		out.Replicas = v
+	}); err != nil {
+		return out, err
+	}
+
+	if err := optionalStringFromMap(asMap, "consistencyLevel", func(v string) {
+		out.ConsistencyLevel = ConsistencyLevel(v)
+	}); err != nil {
+		return out, err
+	}
+
 	// these will only differ once there is an async component through replication
 	// or dynamic scaling. For now they have to be the same
 	out.ActualCount = out.DesiredCount
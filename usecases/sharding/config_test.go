@@ -41,6 +41,8 @@ func Test_Config(t *testing.T) {
 				Key:                 DefaultKey,
 				Strategy:            DefaultStrategy,
 				Function:            DefaultFunction,
+				Replicas:            DefaultReplicas,
+				ConsistencyLevel:    DefaultConsistencyLevel,
 			},
 		},
 
@@ -53,6 +55,8 @@ func Test_Config(t *testing.T) {
 				"key":                 "_id",
 				"strategy":            "hash",
 				"function":            "murmur3",
+				"replicas":            json.Number("2"),
+				"consistencyLevel":    "ALL",
 			},
 			expected: Config{
 				VirtualPerPhysical:  64,
@@ -63,6 +67,8 @@ func Test_Config(t *testing.T) {
 				Key:                 "_id",
 				Strategy:            "hash",
 				Function:            "murmur3",
+				Replicas:            2,
+				ConsistencyLevel:    ConsistencyLevelAll,
 			},
 		},
 
@@ -75,6 +81,8 @@ func Test_Config(t *testing.T) {
 				"key":                 "_id",
 				"strategy":            "hash",
 				"function":            "murmur3",
+				"replicas":            float64(2),
+				"consistencyLevel":    "ALL",
 			},
 			expected: Config{
 				VirtualPerPhysical:  64,
@@ -85,18 +93,38 @@ func Test_Config(t *testing.T) {
 				Key:                 "_id",
 				Strategy:            "hash",
 				Function:            "murmur3",
+				Replicas:            2,
+				ConsistencyLevel:    ConsistencyLevelAll,
 			},
 		},
 
 		{
-			name: "unsupported sharding key",
+			name: "sharding on a property",
 			input: map[string]interface{}{
 				"key":      "myCustomField",
 				"strategy": "hash",
 				"function": "murmur3",
 			},
-			expectedErr: errors.New("sharding only supported on key '_id' " +
-				"for now, got: myCustomField"),
+			expected: Config{
+				VirtualPerPhysical:  DefaultVirtualPerPhysical,
+				DesiredCount:        7,
+				DesiredVirtualCount: DefaultVirtualPerPhysical * 7,
+				ActualCount:         7,
+				ActualVirtualCount:  DefaultVirtualPerPhysical * 7,
+				Key:                 "myCustomField",
+				Strategy:            DefaultStrategy,
+				Function:            DefaultFunction,
+				Replicas:            DefaultReplicas,
+				ConsistencyLevel:    DefaultConsistencyLevel,
+			},
+		},
+
+		{
+			name: "empty sharding key",
+			input: map[string]interface{}{
+				"key": "",
+			},
+			expectedErr: errors.New("sharding key must not be empty"),
 		},
 
 		{
@@ -110,6 +138,23 @@ func Test_Config(t *testing.T) {
 				"for now, got: range"),
 		},
 
+		{
+			name: "no replicas",
+			input: map[string]interface{}{
+				"replicas": json.Number("0"),
+			},
+			expectedErr: errors.New("replicas must be at least 1, got: 0"),
+		},
+
+		{
+			name: "unsupported consistency level",
+			input: map[string]interface{}{
+				"consistencyLevel": "TWO",
+			},
+			expectedErr: errors.New("consistency level must be one of \"ONE\", " +
+				"\"QUORUM\" or \"ALL\", got: TWO"),
+		},
+
 		{
 			name: "unsupported sharding function",
 			input: map[string]interface{}{
@@ -20,6 +20,17 @@ func ValidateConfigUpdate(old, updated Config) error {
 			updated.DesiredCount)
 	}
 
+	if old.Replicas != updated.Replicas {
+		return errors.Errorf("re-replication not supported yet: replicas are immutable: "+
+			"attempted change from \"%d\" to \"%d\"", old.Replicas,
+			updated.Replicas)
+	}
+
+	if old.Key != updated.Key {
+		return errors.Errorf("sharding key is immutable: "+
+			"attempted change from %q to %q", old.Key, updated.Key)
+	}
+
 	if old.VirtualPerPhysical != updated.VirtualPerPhysical {
 		return errors.Errorf("virtual shards per physical is immutable: "+
 			"attempted change from \"%d\" to \"%d\"", old.VirtualPerPhysical,
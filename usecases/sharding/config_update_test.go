@@ -37,6 +37,27 @@ func TestConfigUpdates(t *testing.T) {
 					"re-sharding not supported yet: shard count is immutable: " +
 						"attempted change from \"7\" to \"8\""),
 			},
+			{
+				name:    "attempting to change replicas",
+				initial: Config{Replicas: 1},
+				update:  Config{Replicas: 3},
+				expectedError: errors.Errorf(
+					"re-replication not supported yet: replicas are immutable: " +
+						"attempted change from \"1\" to \"3\""),
+			},
+			{
+				name:    "changing the consistency level",
+				initial: Config{Replicas: 3, ConsistencyLevel: ConsistencyLevelQuorum},
+				update:  Config{Replicas: 3, ConsistencyLevel: ConsistencyLevelAll},
+			},
+			{
+				name:    "attempting to change the sharding key",
+				initial: Config{Key: "_id"},
+				update:  Config{Key: "tenantId"},
+				expectedError: errors.Errorf(
+					"sharding key is immutable: " +
+						"attempted change from \"_id\" to \"tenantId\""),
+			},
 			{
 				name:    "attempting to shard count",
 				initial: Config{VirtualPerPhysical: 128},
@@ -0,0 +1,66 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package sharding
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// KeyProperty returns the name of the property the objects are sharded by.
+// It is empty if they are sharded by their id, which is the default.
+func (c Config) KeyProperty() string {
+	if c.Key == DefaultKey {
+		return ""
+	}
+
+	return c.Key
+}
+
+// PropertyKey turns a value of the property the objects are sharded by into
+// the key their shard is determined by. Strings are trimmed like they are by
+// the "field" tokenization, so that an Equal filter on the property always
+// has the same key as the objects it matches. Numbers have the same key, no
+// matter if they were parsed as integers or as floats.
+func (c Config) PropertyKey(value interface{}) ([]byte, error) {
+	switch typed := value.(type) {
+	case string:
+		return []byte(strings.TrimSpace(typed)), nil
+	case int:
+		return intKey(int64(typed)), nil
+	case int64:
+		return intKey(typed), nil
+	case float64:
+		if typed != math.Trunc(typed) {
+			return nil, errors.Errorf("sharding key %q must be an integer, got: %v",
+				c.Key, typed)
+		}
+		return intKey(int64(typed)), nil
+	case json.Number:
+		asInt, err := typed.Int64()
+		if err != nil {
+			return nil, errors.Wrapf(err, "sharding key %q", c.Key)
+		}
+		return intKey(asInt), nil
+	default:
+		return nil, errors.Errorf("sharding key %q must be a string or an integer, "+
+			"got: %T", c.Key, value)
+	}
+}
+
+func intKey(in int64) []byte {
+	return []byte(strconv.FormatInt(in, 10))
+}
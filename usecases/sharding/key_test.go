@@ -0,0 +1,56 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package sharding
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropertyKey(t *testing.T) {
+	cfg := Config{Key: "tenantId"}
+
+	t.Run("the key property", func(t *testing.T) {
+		assert.Equal(t, "tenantId", cfg.KeyProperty())
+		assert.Equal(t, "", Config{Key: DefaultKey}.KeyProperty())
+	})
+
+	t.Run("values with the same key", func(t *testing.T) {
+		tests := [][]interface{}{
+			{"tenant-a", " tenant-a", "tenant-a\t\n"},
+			{7, int64(7), float64(7), json.Number("7")},
+		}
+
+		for _, values := range tests {
+			expected, err := cfg.PropertyKey(values[0])
+			require.Nil(t, err)
+
+			for _, value := range values[1:] {
+				key, err := cfg.PropertyKey(value)
+				require.Nil(t, err)
+				assert.Equal(t, expected, key, "%#v", value)
+			}
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		_, err := cfg.PropertyKey(7.5)
+		assert.EqualError(t, err, "sharding key \"tenantId\" must be an integer, got: 7.5")
+
+		_, err = cfg.PropertyKey(true)
+		assert.EqualError(t, err, "sharding key \"tenantId\" must be a string or an "+
+			"integer, got: bool")
+	})
+}
@@ -0,0 +1,257 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package sharding
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Transfer is the copy of a physical shard to a node which does not hold it
+// yet. If the shard is split, the copy is stored under the name of the new
+// shard and only keeps the objects of the virtual shards assigned to it.
+type Transfer struct {
+	Shard       string `json:"shard"`
+	TargetShard string `json:"targetShard"`
+	Node        string `json:"node"`
+}
+
+// RebalancePlan describes how to get from the current state of a class to
+// one in which its shards are spread evenly across the nodes. It is carried
+// out in two steps: First the Transfers are copied and the Staged state is
+// applied, in which the copies are loaded by the nodes they were copied to,
+// but do not serve any requests yet. Then the Final state is applied, which
+// switches over to the copies and removes what is no longer needed.
+type RebalancePlan struct {
+	Transfers []Transfer
+	Staged    *State
+	Final     *State
+}
+
+// PlanRebalance plans how to spread the shards across the specified nodes.
+// As long as there are fewer shard replicas than nodes, the largest shards
+// are split and the new shards are placed on the least busy nodes. Then
+// replicas are moved from the busiest to the least busy nodes, until all
+// nodes hold roughly the same number of them. The plan has no transfers if
+// the shards are balanced already.
+func (s *State) PlanRebalance(nodeNames []string) (*RebalancePlan, error) {
+	load := make(map[string]int, len(nodeNames))
+	for _, name := range nodeNames {
+		load[name] = 0
+	}
+
+	for _, name := range s.AllPhysicalShards() {
+		for _, node := range s.Physical[name].Nodes() {
+			if _, ok := load[node]; !ok {
+				return nil, errors.Errorf("node %q holding shard %q is not available",
+					node, name)
+			}
+			load[node]++
+		}
+	}
+
+	final := s.DeepCopy()
+
+	// shards split off another shard are copied from the shard which exists
+	// already, even if they are split further
+	origins := map[string]string{}
+	for len(final.Physical)*final.Config.Replicas < len(nodeNames) {
+		source := final.largestShard()
+		if source == "" {
+			break
+		}
+
+		nodes := leastBusyNodes(load, final.Config.Replicas)
+		target := final.splitShard(source, nodes)
+		origins[target] = source
+		if origin, ok := origins[source]; ok {
+			origins[target] = origin
+		}
+
+		for _, node := range nodes {
+			load[node]++
+		}
+	}
+
+	for len(load) > 1 {
+		nodes := leastBusyNodes(load, len(load))
+		from, to := nodes[len(nodes)-1], nodes[0]
+		if load[from]-load[to] <= 1 {
+			break
+		}
+
+		// the busier node holds at least two shards more than the other one, so
+		// at least one of them cannot be held by the other one yet
+		for _, name := range final.AllPhysicalShards() {
+			nodes := final.Physical[name].Nodes()
+			if containsNode(nodes, from) && !containsNode(nodes, to) {
+				final.moveReplica(name, from, to)
+				break
+			}
+		}
+
+		load[from]--
+		load[to]++
+	}
+
+	final.Config.ActualCount = len(final.Physical)
+
+	transfers := s.transfersTo(final, origins)
+
+	return &RebalancePlan{
+		Transfers: transfers,
+		Staged:    s.stagedFor(final, transfers),
+		Final:     final,
+	}, nil
+}
+
+// largestShard returns the shard owning the most virtual shards, provided it
+// owns enough of them to be split
+func (s *State) largestShard() string {
+	largest := ""
+	for _, name := range s.AllPhysicalShards() {
+		owns := len(s.Physical[name].OwnsVirtual)
+		if owns > 1 && (largest == "" || owns > len(s.Physical[largest].OwnsVirtual)) {
+			largest = name
+		}
+	}
+
+	return largest
+}
+
+// splitShard assigns half of the virtual shards of the specified shard to a
+// new shard placed on the specified nodes and returns the new shard's name
+func (s *State) splitShard(name string, nodes []string) string {
+	source := s.Physical[name]
+	half := len(source.OwnsVirtual) / 2
+
+	target := Physical{
+		Name:          generateShardName(),
+		OwnsVirtual:   append([]string(nil), source.OwnsVirtual[half:]...),
+		BelongsToNode: nodes[0],
+		ReplicaNodes:  append([]string(nil), nodes[1:]...),
+	}
+	source.OwnsVirtual = source.OwnsVirtual[:half]
+
+	for _, vid := range target.OwnsVirtual {
+		s.virtualByName(vid).AssignedToPhysical = target.Name
+	}
+
+	s.Physical[name] = s.withOwnsPercentage(source)
+	s.Physical[target.Name] = s.withOwnsPercentage(target)
+
+	return target.Name
+}
+
+func (s *State) withOwnsPercentage(physical Physical) Physical {
+	physical.OwnsPercentage = 0
+	for _, vid := range physical.OwnsVirtual {
+		physical.OwnsPercentage += s.virtualByName(vid).OwnsPercentage
+	}
+
+	return physical
+}
+
+// moveReplica replaces one node holding a replica of the shard with another
+// one, the order of the nodes stays the same
+func (s *State) moveReplica(name, from, to string) {
+	physical := s.Physical[name]
+	if physical.BelongsToNode == from {
+		physical.BelongsToNode = to
+	}
+	for i, node := range physical.ReplicaNodes {
+		if node == from {
+			physical.ReplicaNodes[i] = to
+		}
+	}
+
+	s.Physical[name] = physical
+}
+
+// transfersTo lists the copies needed to get from this state to the final
+// one. Shards which have been split off are copied from their origin.
+func (s *State) transfersTo(final *State, origins map[string]string) []Transfer {
+	var transfers []Transfer
+	for _, name := range final.AllPhysicalShards() {
+		source := name
+		if origin, ok := origins[name]; ok {
+			source = origin
+		}
+
+		current, exists := s.Physical[name]
+		for _, node := range final.Physical[name].Nodes() {
+			if exists && containsNode(current.Nodes(), node) {
+				continue
+			}
+
+			transfers = append(transfers, Transfer{
+				Shard:       source,
+				TargetShard: name,
+				Node:        node,
+			})
+		}
+	}
+
+	return transfers
+}
+
+// stagedFor returns the state in which the copies of the transfers exist in
+// addition to everything which exists already. Moved replicas are added as
+// further replicas of their shard, new shards are added with the virtual
+// shards they are going to own, but none of them are assigned to them yet.
+func (s *State) stagedFor(final *State, transfers []Transfer) *State {
+	staged := s.DeepCopy()
+	for _, transfer := range transfers {
+		if _, ok := s.Physical[transfer.TargetShard]; !ok {
+			physical := final.Physical[transfer.TargetShard]
+			physical.OwnsVirtual = append([]string(nil), physical.OwnsVirtual...)
+			physical.ReplicaNodes = append([]string(nil), physical.ReplicaNodes...)
+			staged.Physical[transfer.TargetShard] = physical
+			continue
+		}
+
+		physical := staged.Physical[transfer.TargetShard]
+		physical.ReplicaNodes = append(physical.ReplicaNodes, transfer.Node)
+		staged.Physical[transfer.TargetShard] = physical
+	}
+
+	return staged
+}
+
+// leastBusyNodes returns the specified number of nodes holding the fewest
+// shard replicas, starting with the least busy one
+func leastBusyNodes(load map[string]int, count int) []string {
+	nodes := make([]string, 0, len(load))
+	for node := range load {
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(a, b int) bool {
+		if load[nodes[a]] != load[nodes[b]] {
+			return load[nodes[a]] < load[nodes[b]]
+		}
+		return nodes[a] < nodes[b]
+	})
+
+	return nodes[:count]
+}
+
+func containsNode(nodes []string, node string) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
+		}
+	}
+
+	return false
+}
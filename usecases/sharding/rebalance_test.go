@@ -0,0 +1,176 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package sharding
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRebalance(t *testing.T) {
+	newState := func(t *testing.T, desiredCount, replicas int, nodes ...string) *State {
+		cfg, err := ParseConfig(map[string]interface{}{
+			"desiredCount": float64(desiredCount),
+			"replicas":     float64(replicas),
+		}, len(nodes))
+		require.Nil(t, err)
+
+		state, err := InitState("my-index", cfg, fakeNodes{nodes})
+		require.Nil(t, err)
+		return state
+	}
+
+	t.Run("splits a single shard across new nodes", func(t *testing.T) {
+		state := newState(t, 1, 1, "node1")
+		original := state.AllPhysicalShards()[0]
+
+		plan, err := state.PlanRebalance([]string{"node1", "node2", "node3"})
+		require.Nil(t, err)
+
+		assertConsistentVirtual(t, plan.Final)
+		assert.Len(t, plan.Final.Physical, 3)
+		assert.Equal(t, 3, plan.Final.Config.ActualCount)
+		assert.Equal(t, map[string]int{"node1": 1, "node2": 1, "node3": 1},
+			shardsPerNode(plan.Final))
+
+		require.Len(t, plan.Transfers, 2)
+		for _, transfer := range plan.Transfers {
+			assert.Equal(t, original, transfer.Shard)
+			assert.NotEqual(t, original, transfer.TargetShard)
+			assert.Equal(t, []string{transfer.Node},
+				plan.Final.Physical[transfer.TargetShard].Nodes())
+		}
+
+		// the copies are known, but the original shard keeps serving everything
+		assert.Len(t, plan.Staged.Physical, 3)
+		assert.Equal(t, []string{original}, plan.Staged.AllServingPhysicalShards())
+		assert.Equal(t, plan.Final.AllPhysicalShards(), plan.Final.AllServingPhysicalShards())
+		for _, transfer := range plan.Transfers {
+			assert.Equal(t, plan.Final.Physical[transfer.TargetShard],
+				plan.Staged.Physical[transfer.TargetShard])
+		}
+
+		for i := 0; i < 100; i++ {
+			key := randomKey()
+			assert.Equal(t, original, plan.Staged.PhysicalShard(key))
+			assert.Equal(t, state.VirtualShard(key), plan.Final.VirtualShard(key))
+		}
+
+		// the state the plan was made for is left untouched
+		assert.Len(t, state.Physical, 1)
+		assertConsistentVirtual(t, state)
+	})
+
+	t.Run("moves shards to new nodes", func(t *testing.T) {
+		state := newState(t, 4, 1, "node1", "node2")
+
+		plan, err := state.PlanRebalance([]string{"node1", "node2", "node3", "node4"})
+		require.Nil(t, err)
+
+		assertConsistentVirtual(t, plan.Final)
+		assert.Equal(t, state.AllPhysicalShards(), plan.Final.AllPhysicalShards())
+		assert.Equal(t, state.Virtual, plan.Final.Virtual)
+		assert.Equal(t, map[string]int{"node1": 1, "node2": 1, "node3": 1, "node4": 1},
+			shardsPerNode(plan.Final))
+
+		require.Len(t, plan.Transfers, 2)
+		for _, transfer := range plan.Transfers {
+			assert.Equal(t, transfer.Shard, transfer.TargetShard)
+			assert.Contains(t, []string{"node3", "node4"}, transfer.Node)
+			assert.Equal(t, []string{transfer.Node}, plan.Final.Physical[transfer.Shard].Nodes())
+
+			// the moved shard is held by both nodes until the plan completes
+			assert.Equal(t, append(state.Physical[transfer.Shard].Nodes(), transfer.Node),
+				plan.Staged.Physical[transfer.Shard].Nodes())
+		}
+		assert.Equal(t, state.AllPhysicalShards(), plan.Staged.AllServingPhysicalShards())
+	})
+
+	t.Run("places every replica of a new shard on a different node", func(t *testing.T) {
+		state := newState(t, 1, 2, "node1", "node2")
+
+		plan, err := state.PlanRebalance([]string{"node1", "node2", "node3", "node4"})
+		require.Nil(t, err)
+
+		assertConsistentVirtual(t, plan.Final)
+		assert.Len(t, plan.Final.Physical, 2)
+		assert.Equal(t, map[string]int{"node1": 1, "node2": 1, "node3": 1, "node4": 1},
+			shardsPerNode(plan.Final))
+		require.Len(t, plan.Transfers, 2)
+		assert.Equal(t, plan.Transfers[0].TargetShard, plan.Transfers[1].TargetShard)
+	})
+
+	t.Run("has nothing to do if the shards are balanced", func(t *testing.T) {
+		state := newState(t, 4, 2, "node1", "node2", "node3")
+
+		plan, err := state.PlanRebalance([]string{"node1", "node2", "node3"})
+		require.Nil(t, err)
+
+		assert.Empty(t, plan.Transfers)
+		assert.Equal(t, state.Physical, plan.Final.Physical)
+	})
+
+	t.Run("fails if a node holding a shard is not available", func(t *testing.T) {
+		state := newState(t, 2, 1, "node1", "node2")
+
+		_, err := state.PlanRebalance([]string{"node1", "node3"})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "node \"node2\" holding shard")
+	})
+}
+
+func TestVirtualShard(t *testing.T) {
+	cfg, err := ParseConfig(map[string]interface{}{"desiredCount": float64(4)}, 2)
+	require.Nil(t, err)
+
+	state, err := InitState("my-index", cfg, fakeNodes{[]string{"node1", "node2"}})
+	require.Nil(t, err)
+
+	for i := 0; i < 100; i++ {
+		key := randomKey()
+		assert.Equal(t, state.PhysicalShard(key),
+			state.virtualByName(state.VirtualShard(key)).AssignedToPhysical)
+	}
+}
+
+// assertConsistentVirtual verifies that every virtual shard is owned by the
+// physical shard it is assigned to, and by no other one
+func assertConsistentVirtual(t *testing.T, state *State) {
+	owned := 0
+	for _, physical := range state.Physical {
+		owned += len(physical.OwnsVirtual)
+	}
+	assert.Equal(t, len(state.Virtual), owned)
+
+	for _, virtual := range state.Virtual {
+		assert.Contains(t, state.Physical[virtual.AssignedToPhysical].OwnsVirtual, virtual.Name)
+	}
+}
+
+func shardsPerNode(state *State) map[string]int {
+	out := map[string]int{}
+	for _, physical := range state.Physical {
+		for _, node := range physical.Nodes() {
+			out[node]++
+		}
+	}
+	return out
+}
+
+func randomKey() []byte {
+	key := make([]byte, 16)
+	rand.Read(key)
+	return key
+}
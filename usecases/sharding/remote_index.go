@@ -13,12 +13,15 @@ package sharding
 
 import (
 	"context"
+	"io"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
+	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/searchparams"
 	"github.com/semi-technologies/weaviate/entities/storobj"
@@ -71,7 +74,7 @@ type RemoteIndexClient interface {
 	MultiGetObjects(ctx context.Context, hostname, indexName, shardName string,
 		ids []strfmt.UUID) ([]*storobj.Object, error)
 	SearchShard(ctx context.Context, hostname, indexName, shardName string,
-		searchVector []float32, limit int, filters *filters.LocalFilter,
+		searchVector []float32, targetVector string, limit int, filters *filters.LocalFilter,
 		keywordRanking *searchparams.KeywordRanking, sort []filters.Sort,
 		additional additional.Properties) ([]*storobj.Object, []float32, error)
 	Aggregate(ctx context.Context, hostname, indexName, shardName string,
@@ -81,24 +84,28 @@ type RemoteIndexClient interface {
 	DeleteObjectBatch(ctx context.Context, hostName, indexName, shardName string,
 		docIDs []uint64, dryRun bool) objects.BatchSimpleObjects
 	GetShardStatus(ctx context.Context, hostName, indexName, shardName string) (string, error)
+	GetShardReindexStatus(ctx context.Context, hostName, indexName,
+		shardName string) ([]*models.PropertyReindexStatus, error)
 	UpdateShardStatus(ctx context.Context, hostName, indexName, shardName,
 		targetStatus string) error
+	CreateShardBackup(ctx context.Context, hostName, indexName, shardName,
+		snapshotID string) (*backup.ShardSnapshot, error)
+	ReleaseShardBackup(ctx context.Context, hostName, indexName, shardName,
+		snapshotID string) error
+	GetShardBackupFile(ctx context.Context, hostName, indexName, shardName,
+		relPath string) (io.ReadCloser, error)
+	PutShardBackupFile(ctx context.Context, hostName, indexName, shardName,
+		relPath string, r io.Reader) error
 }
 
+// PutObject sends the object to all replicas of the shard which are not
+// local. A local replica has to be written by the caller beforehand.
 func (ri *RemoteIndex) PutObject(ctx context.Context, shardName string,
 	obj *storobj.Object,
 ) error {
-	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
-	if !ok {
-		return errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
-	}
-
-	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
-	if !ok {
-		return errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
-	}
-
-	return ri.client.PutObject(ctx, host, ri.class, shardName, obj)
+	return ri.replicate(ctx, shardName, func(ctx context.Context, host string) error {
+		return ri.client.PutObject(ctx, host, ri.class, shardName, obj)
+	})
 }
 
 // helper for single errors that affect the entire batch, assign the error to
@@ -111,158 +118,116 @@ func duplicateErr(in error, count int) []error {
 	return out
 }
 
+// BatchPutObjects sends the objects to all replicas of the shard which are
+// not local. A local replica has to be written by the caller beforehand.
 func (ri *RemoteIndex) BatchPutObjects(ctx context.Context, shardName string,
 	objs []*storobj.Object,
 ) []error {
-	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
-	if !ok {
-		return duplicateErr(errors.Errorf("class %s has no physical shard %q",
-			ri.class, shardName), len(objs))
-	}
-
-	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
-	if !ok {
-		return duplicateErr(errors.Errorf("resolve node name %q to host",
-			shard.BelongsToNode), len(objs))
-	}
-
-	return ri.client.BatchPutObjects(ctx, host, ri.class, shardName, objs)
+	return ri.replicateBatch(ctx, shardName, len(objs),
+		func(ctx context.Context, host string) []error {
+			return ri.client.BatchPutObjects(ctx, host, ri.class, shardName, objs)
+		})
 }
 
+// BatchAddReferences sends the references to all replicas of the shard
+// which are not local. A local replica has to be written by the caller
+// beforehand.
 func (ri *RemoteIndex) BatchAddReferences(ctx context.Context, shardName string,
 	refs objects.BatchReferences,
 ) []error {
-	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
-	if !ok {
-		return duplicateErr(errors.Errorf("class %s has no physical shard %q",
-			ri.class, shardName), len(refs))
-	}
-
-	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
-	if !ok {
-		return duplicateErr(errors.Errorf("resolve node name %q to host",
-			shard.BelongsToNode), len(refs))
-	}
-
-	return ri.client.BatchAddReferences(ctx, host, ri.class, shardName, refs)
+	return ri.replicateBatch(ctx, shardName, len(refs),
+		func(ctx context.Context, host string) []error {
+			return ri.client.BatchAddReferences(ctx, host, ri.class, shardName, refs)
+		})
 }
 
 func (ri *RemoteIndex) Exists(ctx context.Context, shardName string,
 	id strfmt.UUID,
 ) (bool, error) {
-	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
-	if !ok {
-		return false, errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
-	}
-
-	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
-	if !ok {
-		return false, errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
-	}
-
-	return ri.client.Exists(ctx, host, ri.class, shardName, id)
+	var exists bool
+	err := ri.queryReplicas(ctx, shardName, func(ctx context.Context, host string) error {
+		var err error
+		exists, err = ri.client.Exists(ctx, host, ri.class, shardName, id)
+		return err
+	})
+	return exists, err
 }
 
+// DeleteObject deletes the object from all replicas of the shard which are
+// not local. A local replica has to be written by the caller beforehand.
 func (ri *RemoteIndex) DeleteObject(ctx context.Context, shardName string,
 	id strfmt.UUID,
 ) error {
-	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
-	if !ok {
-		return errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
-	}
-
-	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
-	if !ok {
-		return errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
-	}
-
-	return ri.client.DeleteObject(ctx, host, ri.class, shardName, id)
+	return ri.replicate(ctx, shardName, func(ctx context.Context, host string) error {
+		return ri.client.DeleteObject(ctx, host, ri.class, shardName, id)
+	})
 }
 
+// MergeObject merges the document into all replicas of the shard which are
+// not local. A local replica has to be written by the caller beforehand.
 func (ri *RemoteIndex) MergeObject(ctx context.Context, shardName string,
 	mergeDoc objects.MergeDocument,
 ) error {
-	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
-	if !ok {
-		return errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
-	}
-
-	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
-	if !ok {
-		return errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
-	}
-
-	return ri.client.MergeObject(ctx, host, ri.class, shardName, mergeDoc)
+	return ri.replicate(ctx, shardName, func(ctx context.Context, host string) error {
+		return ri.client.MergeObject(ctx, host, ri.class, shardName, mergeDoc)
+	})
 }
 
 func (ri *RemoteIndex) GetObject(ctx context.Context, shardName string,
 	id strfmt.UUID, props search.SelectProperties,
 	additional additional.Properties,
 ) (*storobj.Object, error) {
-	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
-	if !ok {
-		return nil, errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
-	}
-
-	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
-	if !ok {
-		return nil, errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
-	}
-
-	return ri.client.GetObject(ctx, host, ri.class, shardName, id, props, additional)
+	var obj *storobj.Object
+	err := ri.queryReplicas(ctx, shardName, func(ctx context.Context, host string) error {
+		var err error
+		obj, err = ri.client.GetObject(ctx, host, ri.class, shardName, id, props, additional)
+		return err
+	})
+	return obj, err
 }
 
 func (ri *RemoteIndex) MultiGetObjects(ctx context.Context, shardName string,
 	ids []strfmt.UUID,
 ) ([]*storobj.Object, error) {
-	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
-	if !ok {
-		return nil, errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
-	}
-
-	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
-	if !ok {
-		return nil, errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
-	}
-
-	return ri.client.MultiGetObjects(ctx, host, ri.class, shardName, ids)
+	var objs []*storobj.Object
+	err := ri.queryReplicas(ctx, shardName, func(ctx context.Context, host string) error {
+		var err error
+		objs, err = ri.client.MultiGetObjects(ctx, host, ri.class, shardName, ids)
+		return err
+	})
+	return objs, err
 }
 
 func (ri *RemoteIndex) SearchShard(ctx context.Context, shardName string,
-	searchVector []float32, limit int, filters *filters.LocalFilter,
+	searchVector []float32, targetVector string, limit int, filters *filters.LocalFilter,
 	keywordRanking *searchparams.KeywordRanking, sort []filters.Sort,
 	additional additional.Properties,
 ) ([]*storobj.Object, []float32, error) {
-	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
-	if !ok {
-		return nil, nil, errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
-	}
-
-	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
-	if !ok {
-		return nil, nil, errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
-	}
-
-	return ri.client.SearchShard(ctx, host, ri.class, shardName, searchVector, limit,
-		filters, keywordRanking, sort, additional)
+	var objs []*storobj.Object
+	var dists []float32
+	err := ri.queryReplicas(ctx, shardName, func(ctx context.Context, host string) error {
+		var err error
+		objs, dists, err = ri.client.SearchShard(ctx, host, ri.class, shardName,
+			searchVector, targetVector, limit, filters, keywordRanking, sort, additional)
+		return err
+	})
+	return objs, dists, err
 }
 
 func (ri *RemoteIndex) Aggregate(ctx context.Context, shardName string,
 	params aggregation.Params,
 ) (*aggregation.Result, error) {
-	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
-	if !ok {
-		return nil, errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
-	}
-
-	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
-	if !ok {
-		return nil, errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
-	}
-
-	return ri.client.Aggregate(ctx, host, ri.class, shardName, params)
+	var res *aggregation.Result
+	err := ri.queryReplicas(ctx, shardName, func(ctx context.Context, host string) error {
+		var err error
+		res, err = ri.client.Aggregate(ctx, host, ri.class, shardName, params)
+		return err
+	})
+	return res, err
 }
 
+// FindDocIDs is always served by the node the shard originally belongs to,
+// as doc ids differ between the replicas of a shard. See DeleteObjectBatch.
 func (ri *RemoteIndex) FindDocIDs(ctx context.Context, shardName string,
 	filters *filters.LocalFilter,
 ) ([]uint64, error) {
@@ -279,6 +244,10 @@ func (ri *RemoteIndex) FindDocIDs(ctx context.Context, shardName string,
 	return ri.client.FindDocIDs(ctx, host, ri.class, shardName, filters)
 }
 
+// DeleteObjectBatch deletes the objects from the node the shard originally
+// belongs to, the same node which served FindDocIDs. The deletion has to be
+// propagated to the other replicas by the caller, using the ids of the
+// deleted objects.
 func (ri *RemoteIndex) DeleteObjectBatch(ctx context.Context, shardName string,
 	docIDs []uint64, dryRun bool,
 ) objects.BatchSimpleObjects {
@@ -298,20 +267,54 @@ func (ri *RemoteIndex) DeleteObjectBatch(ctx context.Context, shardName string,
 }
 
 func (ri *RemoteIndex) GetShardStatus(ctx context.Context, shardName string) (string, error) {
+	var status string
+	err := ri.queryReplicas(ctx, shardName, func(ctx context.Context, host string) error {
+		var err error
+		status, err = ri.client.GetShardStatus(ctx, host, ri.class, shardName)
+		return err
+	})
+	return status, err
+}
+
+func (ri *RemoteIndex) GetShardReindexStatus(ctx context.Context,
+	shardName string,
+) ([]*models.PropertyReindexStatus, error) {
+	var status []*models.PropertyReindexStatus
+	err := ri.queryReplicas(ctx, shardName, func(ctx context.Context, host string) error {
+		var err error
+		status, err = ri.client.GetShardReindexStatus(ctx, host, ri.class, shardName)
+		return err
+	})
+	return status, err
+}
+
+// UpdateShardStatus updates the status of all replicas of the shard which
+// are not local. A local replica has to be updated by the caller beforehand.
+func (ri *RemoteIndex) UpdateShardStatus(ctx context.Context, shardName, targetStatus string) error {
+	return ri.replicate(ctx, shardName, func(ctx context.Context, host string) error {
+		return ri.client.UpdateShardStatus(ctx, host, ri.class, shardName, targetStatus)
+	})
+}
+
+func (ri *RemoteIndex) CreateShardBackup(ctx context.Context, shardName,
+	snapshotID string,
+) (*backup.ShardSnapshot, error) {
 	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
 	if !ok {
-		return "", errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
+		return nil, errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
 	}
 
 	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
 	if !ok {
-		return "", errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
+		return nil, errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
 	}
 
-	return ri.client.GetShardStatus(ctx, host, ri.class, shardName)
+	return ri.client.CreateShardBackup(ctx, host, ri.class, shardName, snapshotID)
 }
 
-func (ri *RemoteIndex) UpdateShardStatus(ctx context.Context, shardName, targetStatus string) error {
+func (ri *RemoteIndex) ReleaseShardBackup(ctx context.Context, shardName,
+	snapshotID string,
+) error {
 	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
 	if !ok {
 		return errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
@@ -322,5 +325,34 @@ func (ri *RemoteIndex) UpdateShardStatus(ctx context.Context, shardName, targetS
 		return errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
 	}
 
-	return ri.client.UpdateShardStatus(ctx, host, ri.class, shardName, targetStatus)
+	return ri.client.ReleaseShardBackup(ctx, host, ri.class, shardName, snapshotID)
+}
+
+func (ri *RemoteIndex) GetShardBackupFile(ctx context.Context, shardName,
+	relPath string,
+) (io.ReadCloser, error) {
+	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
+	if !ok {
+		return nil, errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
+	}
+
+	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
+	if !ok {
+		return nil, errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
+	}
+
+	return ri.client.GetShardBackupFile(ctx, host, ri.class, shardName, relPath)
+}
+
+// PutShardBackupFile writes a file of the shard to the specified node, which
+// does not need to hold the shard yet
+func (ri *RemoteIndex) PutShardBackupFile(ctx context.Context, nodeName, shardName,
+	relPath string, r io.Reader,
+) error {
+	host, ok := ri.nodeResolver.NodeHostname(nodeName)
+	if !ok {
+		return errors.Errorf("resolve node name %q to host", nodeName)
+	}
+
+	return ri.client.PutShardBackupFile(ctx, host, ri.class, shardName, relPath, r)
 }
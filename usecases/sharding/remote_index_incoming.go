@@ -13,12 +13,15 @@ package sharding
 
 import (
 	"context"
+	"io"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
+	"github.com/semi-technologies/weaviate/entities/backup"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/searchparams"
@@ -28,6 +31,11 @@ import (
 
 type RemoteIncomingRepo interface {
 	GetIndexForIncoming(className schema.ClassName) RemoteIndexIncomingRepo
+
+	// IncomingPutBackupFile is served by the repo rather than the index, as
+	// the index does not exist yet while its files are being restored
+	IncomingPutBackupFile(ctx context.Context, indexName, shardName,
+		relPath string, r io.Reader) error
 }
 
 type RemoteIndexIncomingRepo interface {
@@ -49,7 +57,7 @@ type RemoteIndexIncomingRepo interface {
 	IncomingMultiGetObjects(ctx context.Context, shardName string,
 		ids []strfmt.UUID) ([]*storobj.Object, error)
 	IncomingSearch(ctx context.Context, shardName string,
-		vector []float32, distance float32, limit int, filters *filters.LocalFilter,
+		vector []float32, targetVector string, distance float32, limit int, filters *filters.LocalFilter,
 		keywordRanking *searchparams.KeywordRanking, sort []filters.Sort,
 		additional additional.Properties) ([]*storobj.Object, []float32, error)
 	IncomingAggregate(ctx context.Context, shardName string,
@@ -59,7 +67,15 @@ type RemoteIndexIncomingRepo interface {
 	IncomingDeleteObjectBatch(ctx context.Context, shardName string,
 		docIDs []uint64, dryRun bool) objects.BatchSimpleObjects
 	IncomingGetShardStatus(ctx context.Context, shardName string) (string, error)
+	IncomingGetShardReindexStatus(ctx context.Context,
+		shardName string) ([]*models.PropertyReindexStatus, error)
 	IncomingUpdateShardStatus(ctx context.Context, shardName, targetStatus string) error
+	IncomingCreateShardBackup(ctx context.Context, shardName,
+		snapshotID string) (*backup.ShardSnapshot, error)
+	IncomingReleaseShardBackup(ctx context.Context, shardName,
+		snapshotID string) error
+	IncomingGetShardBackupFile(ctx context.Context, shardName,
+		relPath string) (io.ReadCloser, error)
 }
 
 type RemoteIndexIncoming struct {
@@ -164,7 +180,7 @@ func (rii *RemoteIndexIncoming) MultiGetObjects(ctx context.Context, indexName,
 }
 
 func (rii *RemoteIndexIncoming) Search(ctx context.Context, indexName, shardName string,
-	vector []float32, distance float32, limit int, filters *filters.LocalFilter,
+	vector []float32, targetVector string, distance float32, limit int, filters *filters.LocalFilter,
 	keywordRanking *searchparams.KeywordRanking, sort []filters.Sort,
 	additional additional.Properties,
 ) ([]*storobj.Object, []float32, error) {
@@ -174,7 +190,7 @@ func (rii *RemoteIndexIncoming) Search(ctx context.Context, indexName, shardName
 	}
 
 	return index.IncomingSearch(
-		ctx, shardName, vector, distance, limit, filters, keywordRanking, sort, additional)
+		ctx, shardName, vector, targetVector, distance, limit, filters, keywordRanking, sort, additional)
 }
 
 func (rii *RemoteIndexIncoming) Aggregate(ctx context.Context, indexName, shardName string,
@@ -222,6 +238,17 @@ func (rii *RemoteIndexIncoming) GetShardStatus(ctx context.Context,
 	return index.IncomingGetShardStatus(ctx, shardName)
 }
 
+func (rii *RemoteIndexIncoming) GetShardReindexStatus(ctx context.Context,
+	indexName, shardName string,
+) ([]*models.PropertyReindexStatus, error) {
+	index := rii.repo.GetIndexForIncoming(schema.ClassName(indexName))
+	if index == nil {
+		return nil, errors.Errorf("local index %q not found", indexName)
+	}
+
+	return index.IncomingGetShardReindexStatus(ctx, shardName)
+}
+
 func (rii *RemoteIndexIncoming) UpdateShardStatus(ctx context.Context,
 	indexName, shardName, targetStatus string,
 ) error {
@@ -232,3 +259,42 @@ func (rii *RemoteIndexIncoming) UpdateShardStatus(ctx context.Context,
 
 	return index.IncomingUpdateShardStatus(ctx, shardName, targetStatus)
 }
+
+func (rii *RemoteIndexIncoming) CreateShardBackup(ctx context.Context,
+	indexName, shardName, snapshotID string,
+) (*backup.ShardSnapshot, error) {
+	index := rii.repo.GetIndexForIncoming(schema.ClassName(indexName))
+	if index == nil {
+		return nil, errors.Errorf("local index %q not found", indexName)
+	}
+
+	return index.IncomingCreateShardBackup(ctx, shardName, snapshotID)
+}
+
+func (rii *RemoteIndexIncoming) ReleaseShardBackup(ctx context.Context,
+	indexName, shardName, snapshotID string,
+) error {
+	index := rii.repo.GetIndexForIncoming(schema.ClassName(indexName))
+	if index == nil {
+		return errors.Errorf("local index %q not found", indexName)
+	}
+
+	return index.IncomingReleaseShardBackup(ctx, shardName, snapshotID)
+}
+
+func (rii *RemoteIndexIncoming) GetShardBackupFile(ctx context.Context,
+	indexName, shardName, relPath string,
+) (io.ReadCloser, error) {
+	index := rii.repo.GetIndexForIncoming(schema.ClassName(indexName))
+	if index == nil {
+		return nil, errors.Errorf("local index %q not found", indexName)
+	}
+
+	return index.IncomingGetShardBackupFile(ctx, shardName, relPath)
+}
+
+func (rii *RemoteIndexIncoming) PutShardBackupFile(ctx context.Context,
+	indexName, shardName, relPath string, r io.Reader,
+) error {
+	return rii.repo.IncomingPutBackupFile(ctx, indexName, shardName, relPath, r)
+}
@@ -0,0 +1,221 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package sharding
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ConsistencyLevel determines how many replicas of a shard have to
+// acknowledge a write for the write to succeed. Reads are always served by
+// a single healthy replica.
+type ConsistencyLevel string
+
+const (
+	ConsistencyLevelOne    ConsistencyLevel = "ONE"
+	ConsistencyLevelQuorum ConsistencyLevel = "QUORUM"
+	ConsistencyLevelAll    ConsistencyLevel = "ALL"
+
+	DefaultReplicas         = 1
+	DefaultConsistencyLevel = ConsistencyLevelQuorum
+)
+
+func (l ConsistencyLevel) validate() error {
+	switch l {
+	case ConsistencyLevelOne, ConsistencyLevelQuorum, ConsistencyLevelAll:
+		return nil
+	default:
+		return errors.Errorf("consistency level must be one of %q, %q or %q, got: %s",
+			ConsistencyLevelOne, ConsistencyLevelQuorum, ConsistencyLevelAll, l)
+	}
+}
+
+// requiredAcks returns how many of the specified number of replicas have to
+// acknowledge a write. States persisted before replication was introduced
+// have no consistency level, they are treated like the default.
+func (l ConsistencyLevel) requiredAcks(replicas int) int {
+	switch l {
+	case ConsistencyLevelOne:
+		return 1
+	case ConsistencyLevelAll:
+		return replicas
+	default:
+		return replicas/2 + 1
+	}
+}
+
+// remoteReplicas returns the nodes holding a replica of the shard other than
+// the local node, as well as the number of them which have to acknowledge a
+// write. The local replica, if there is one, is always written by the caller
+// before the remote ones, so it counts as acknowledged.
+func (ri *RemoteIndex) remoteReplicas(shardName string) ([]string, int, error) {
+	state := ri.stateGetter.ShardingState(ri.class)
+	shard, ok := state.Physical[shardName]
+	if !ok {
+		return nil, 0, errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
+	}
+
+	nodes := shard.Nodes()
+	acks := state.Config.ConsistencyLevel.requiredAcks(len(nodes))
+	remote := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if node == state.localNodeName {
+			acks--
+			continue
+		}
+		remote = append(remote, node)
+	}
+
+	return remote, acks, nil
+}
+
+// replicate runs the write against all remote replicas of the shard
+// concurrently. It waits for all of them to respond, even if fewer would
+// suffice, so that a write never outlives the request which caused it.
+func (ri *RemoteIndex) replicate(ctx context.Context, shardName string,
+	write func(ctx context.Context, host string) error,
+) error {
+	nodes, acks, err := ri.remoteReplicas(shardName)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(nodes))
+	wg := &sync.WaitGroup{}
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node string) {
+			defer wg.Done()
+
+			host, ok := ri.nodeResolver.NodeHostname(node)
+			if !ok {
+				errs[i] = errors.Errorf("resolve node name %q to host", node)
+				return
+			}
+			errs[i] = write(ctx, host)
+		}(i, node)
+	}
+	wg.Wait()
+
+	return replicationErr(nodes, errs, acks)
+}
+
+// replicateBatch is the batch equivalent of replicate, the acknowledgements
+// are counted for every item of the batch individually
+func (ri *RemoteIndex) replicateBatch(ctx context.Context, shardName string,
+	count int, write func(ctx context.Context, host string) []error,
+) []error {
+	if count == 0 {
+		return nil
+	}
+
+	nodes, acks, err := ri.remoteReplicas(shardName)
+	if err != nil {
+		return duplicateErr(err, count)
+	}
+
+	errs := make([][]error, len(nodes))
+	wg := &sync.WaitGroup{}
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node string) {
+			defer wg.Done()
+
+			host, ok := ri.nodeResolver.NodeHostname(node)
+			if !ok {
+				errs[i] = duplicateErr(errors.Errorf("resolve node name %q to host",
+					node), count)
+				return
+			}
+			errs[i] = write(ctx, host)
+		}(i, node)
+	}
+	wg.Wait()
+
+	out := make([]error, count)
+	itemErrs := make([]error, len(nodes))
+	for pos := range out {
+		for i := range nodes {
+			itemErrs[i] = nil
+			if pos < len(errs[i]) {
+				itemErrs[i] = errs[i][pos]
+			}
+		}
+		out[pos] = replicationErr(nodes, itemErrs, acks)
+	}
+
+	return out
+}
+
+// replicationErr turns the errors of the individual replicas into a single
+// one, which is nil if enough replicas acknowledged the write
+func replicationErr(nodes []string, errs []error, acks int) error {
+	if len(nodes) == 1 && acks == 1 {
+		// a shard without further replicas, keep its error as is
+		return errs[0]
+	}
+
+	var failed error
+	succeeded := 0
+	for i, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		if failed == nil {
+			failed = errors.Wrapf(err, "replica on node %q", nodes[i])
+		}
+	}
+
+	if succeeded >= acks {
+		return nil
+	}
+	if failed == nil {
+		failed = errors.New("not enough replicas")
+	}
+
+	return errors.Wrapf(failed, "%d of %d remote replicas acknowledged the write, "+
+		"%d required", succeeded, len(nodes), acks)
+}
+
+// queryReplicas runs the query against the replicas of the shard one after
+// another, until one of them succeeds. The node the shard originally belongs
+// to is tried first.
+func (ri *RemoteIndex) queryReplicas(ctx context.Context, shardName string,
+	query func(ctx context.Context, host string) error,
+) error {
+	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
+	if !ok {
+		return errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
+	}
+
+	var err error
+	for _, node := range shard.Nodes() {
+		host, ok := ri.nodeResolver.NodeHostname(node)
+		if !ok {
+			err = errors.Errorf("resolve node name %q to host", node)
+			continue
+		}
+
+		if err = query(ctx, host); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,213 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2022 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package sharding
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/additional"
+	"github.com/semi-technologies/weaviate/entities/search"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsistencyLevel(t *testing.T) {
+	tests := []struct {
+		level    ConsistencyLevel
+		replicas int
+		expected int
+	}{
+		{ConsistencyLevelOne, 3, 1},
+		{ConsistencyLevelQuorum, 1, 1},
+		{ConsistencyLevelQuorum, 2, 2},
+		{ConsistencyLevelQuorum, 3, 2},
+		{ConsistencyLevelQuorum, 4, 3},
+		{ConsistencyLevelAll, 3, 3},
+		{"", 3, 2}, // persisted before replication was introduced
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.level.requiredAcks(test.replicas),
+			"%s of %d replicas", test.level, test.replicas)
+	}
+}
+
+func TestRemoteIndexReplication(t *testing.T) {
+	ctx := context.Background()
+	obj := &storobj.Object{}
+
+	newRemoteIndex := func(level ConsistencyLevel, localNode string,
+		client RemoteIndexClient,
+	) *RemoteIndex {
+		state := &State{
+			Config: Config{Replicas: 3, ConsistencyLevel: level},
+			Physical: map[string]Physical{
+				"shard1": {
+					Name:          "shard1",
+					BelongsToNode: "node1",
+					ReplicaNodes:  []string{"node2", "node3"},
+				},
+			},
+		}
+		state.SetLocalName(localNode)
+
+		return NewRemoteIndex("MyClass", &fakeStateGetter{state},
+			fakeNodeResolver{}, client)
+	}
+
+	t.Run("writes to all remote replicas", func(t *testing.T) {
+		client := &fakeReplicaClient{}
+		ri := newRemoteIndex(ConsistencyLevelAll, "node1", client)
+
+		err := ri.PutObject(ctx, "shard1", obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, []string{"node2-host", "node3-host"}, client.sortedHosts())
+	})
+
+	t.Run("writes to all replicas if none is local", func(t *testing.T) {
+		client := &fakeReplicaClient{}
+		ri := newRemoteIndex(ConsistencyLevelAll, "node4", client)
+
+		err := ri.PutObject(ctx, "shard1", obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, []string{"node1-host", "node2-host", "node3-host"}, client.sortedHosts())
+	})
+
+	t.Run("tolerates failed replicas up to the consistency level", func(t *testing.T) {
+		client := &fakeReplicaClient{failing: map[string]bool{"node3-host": true}}
+
+		err := newRemoteIndex(ConsistencyLevelQuorum, "node1", client).
+			PutObject(ctx, "shard1", obj)
+		assert.Nil(t, err)
+
+		err = newRemoteIndex(ConsistencyLevelAll, "node1", client).
+			PutObject(ctx, "shard1", obj)
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "1 of 2 remote replicas acknowledged the write, 2 required")
+		assert.Contains(t, err.Error(), "replica on node \"node3\"")
+	})
+
+	t.Run("counts acknowledgements per item of a batch", func(t *testing.T) {
+		client := &fakeReplicaClient{failing: map[string]bool{
+			"node1-host": true,
+			"node2-host": true,
+		}}
+		ri := newRemoteIndex(ConsistencyLevelOne, "node4", client)
+
+		errs := ri.BatchPutObjects(ctx, "shard1", []*storobj.Object{obj, obj})
+
+		assert.Equal(t, []error{nil, nil}, errs)
+
+		client.failing["node3-host"] = true
+		errs = ri.BatchPutObjects(ctx, "shard1", []*storobj.Object{obj, obj})
+
+		require.Len(t, errs, 2)
+		for _, err := range errs {
+			require.NotNil(t, err)
+			assert.Contains(t, err.Error(), "0 of 3 remote replicas acknowledged the write, 1 required")
+		}
+	})
+
+	t.Run("reads from the first healthy replica", func(t *testing.T) {
+		client := &fakeReplicaClient{failing: map[string]bool{"node1-host": true}}
+		ri := newRemoteIndex(ConsistencyLevelAll, "node4", client)
+
+		res, err := ri.GetObject(ctx, "shard1", "", nil, additional.Properties{})
+
+		require.Nil(t, err)
+		assert.Equal(t, obj, res)
+		assert.Equal(t, []string{"node1-host", "node2-host"}, client.hosts)
+	})
+
+	t.Run("fails the read if no replica is healthy", func(t *testing.T) {
+		client := &fakeReplicaClient{failing: map[string]bool{
+			"node1-host": true,
+			"node2-host": true,
+			"node3-host": true,
+		}}
+		ri := newRemoteIndex(ConsistencyLevelOne, "node4", client)
+
+		_, err := ri.GetObject(ctx, "shard1", "", nil, additional.Properties{})
+
+		require.NotNil(t, err)
+		assert.Equal(t, "node3-host is down", err.Error())
+	})
+}
+
+type fakeStateGetter struct {
+	state *State
+}
+
+func (f *fakeStateGetter) ShardingState(class string) *State {
+	return f.state
+}
+
+type fakeNodeResolver struct{}
+
+func (f fakeNodeResolver) NodeHostname(nodeName string) (string, bool) {
+	return nodeName + "-host", true
+}
+
+// fakeReplicaClient implements only the methods used by the tests, calling
+// any other method panics
+type fakeReplicaClient struct {
+	RemoteIndexClient
+	failing map[string]bool
+	hosts   []string
+	sync.Mutex
+}
+
+func (f *fakeReplicaClient) call(host string) error {
+	f.Lock()
+	defer f.Unlock()
+
+	f.hosts = append(f.hosts, host)
+	if f.failing[host] {
+		return errors.Errorf("%s is down", host)
+	}
+	return nil
+}
+
+func (f *fakeReplicaClient) sortedHosts() []string {
+	sort.Strings(f.hosts)
+	return f.hosts
+}
+
+func (f *fakeReplicaClient) PutObject(ctx context.Context, hostName, indexName,
+	shardName string, obj *storobj.Object,
+) error {
+	return f.call(hostName)
+}
+
+func (f *fakeReplicaClient) BatchPutObjects(ctx context.Context, hostName, indexName,
+	shardName string, objs []*storobj.Object,
+) []error {
+	return duplicateErr(f.call(hostName), len(objs))
+}
+
+func (f *fakeReplicaClient) GetObject(ctx context.Context, hostName, indexName,
+	shardName string, id strfmt.UUID, props search.SelectProperties,
+	additional additional.Properties,
+) (*storobj.Object, error) {
+	if err := f.call(hostName); err != nil {
+		return nil, err
+	}
+	return &storobj.Object{}, nil
+}
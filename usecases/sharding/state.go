@@ -16,6 +16,7 @@ import (
 	"math/rand"
 	"sort"
 
+	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/usecases/cluster"
 	"github.com/spaolacci/murmur3"
 )
@@ -44,6 +45,15 @@ type Physical struct {
 	OwnsVirtual    []string `json:"ownsVirtual"`
 	OwnsPercentage float64  `json:"ownsPercentage"`
 	BelongsToNode  string   `json:"belongsToNode"`
+
+	// ReplicaNodes hold a copy of the shard in addition to BelongsToNode
+	ReplicaNodes []string `json:"replicaNodes,omitempty"`
+}
+
+// Nodes returns the names of all nodes holding a replica of the shard,
+// starting with the one the shard originally belongs to
+func (p Physical) Nodes() []string {
+	return append([]string{p.BelongsToNode}, p.ReplicaNodes...)
 }
 
 type nodes interface {
@@ -78,13 +88,24 @@ func (s *State) PhysicalShard(in []byte) string {
 		panic("no virtual shards present")
 	}
 
+	return s.virtualByKey(in).AssignedToPhysical
+}
+
+// VirtualShard returns the name of the virtual shard the key belongs to
+func (s *State) VirtualShard(in []byte) string {
+	if len(s.Virtual) == 0 {
+		panic("no virtual shards present")
+	}
+
+	return s.virtualByKey(in).Name
+}
+
+func (s *State) virtualByKey(in []byte) *Virtual {
 	h := murmur3.New64()
 	h.Write(in)
 	token := h.Sum64()
 
-	virtual := s.virtualByToken(token)
-
-	return virtual.AssignedToPhysical
+	return s.virtualByToken(token)
 }
 
 func (s *State) AllPhysicalShards() []string {
@@ -100,6 +121,28 @@ func (s *State) AllPhysicalShards() []string {
 	return names
 }
 
+// AllServingPhysicalShards is like AllPhysicalShards, but leaves out the
+// shards which no virtual shard is assigned to. Those are the copies staged
+// while rebalancing, their objects are still served by the shards they were
+// copied from, so reading from both would return the same objects twice.
+func (s *State) AllServingPhysicalShards() []string {
+	serving := map[string]struct{}{}
+	for _, virtual := range s.Virtual {
+		serving[virtual.AssignedToPhysical] = struct{}{}
+	}
+
+	var names []string
+	for _, physical := range s.Physical {
+		if _, ok := serving[physical.Name]; ok {
+			names = append(names, physical.Name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 func (s *State) AllLocalPhysicalShards() []string {
 	var names []string
 	for _, physical := range s.Physical {
@@ -115,15 +158,47 @@ func (s *State) AllLocalPhysicalShards() []string {
 	return names
 }
 
+// DeepCopy returns a copy of the state which can be modified without
+// affecting the original
+func (s *State) DeepCopy() *State {
+	out := &State{
+		IndexID:       s.IndexID,
+		Config:        s.Config,
+		Physical:      make(map[string]Physical, len(s.Physical)),
+		Virtual:       make([]Virtual, len(s.Virtual)),
+		localNodeName: s.localNodeName,
+	}
+
+	for name, physical := range s.Physical {
+		physical.OwnsVirtual = append([]string(nil), physical.OwnsVirtual...)
+		physical.ReplicaNodes = append([]string(nil), physical.ReplicaNodes...)
+		out.Physical[name] = physical
+	}
+	copy(out.Virtual, s.Virtual)
+
+	return out
+}
+
 func (s *State) SetLocalName(name string) {
 	s.localNodeName = name
 }
 
+// IsShardLocal is true if the local node holds any of the shard's replicas
 func (s *State) IsShardLocal(name string) bool {
-	return s.Physical[name].BelongsToNode == s.localNodeName
+	for _, node := range s.Physical[name].Nodes() {
+		if node == s.localNodeName {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *State) initPhysical(nodes nodes) error {
+	if nodeCount := len(nodes.AllNames()); s.Config.Replicas > nodeCount {
+		return errors.Errorf("cannot place %d replicas of each shard on %d nodes",
+			s.Config.Replicas, nodeCount)
+	}
+
 	it, err := cluster.NewNodeIterator(nodes, cluster.StartRandom)
 	if err != nil {
 		return err
@@ -133,7 +208,15 @@ func (s *State) initPhysical(nodes nodes) error {
 
 	for i := 0; i < s.Config.DesiredCount; i++ {
 		name := generateShardName()
-		s.Physical[name] = Physical{Name: name, BelongsToNode: it.Next()}
+		physical := Physical{Name: name, BelongsToNode: it.Next()}
+
+		// the replicas are placed on the nodes following the one the shard
+		// belongs to, the next shard then starts on the node after that
+		replicaIt := *it
+		for r := 1; r < s.Config.Replicas; r++ {
+			physical.ReplicaNodes = append(physical.ReplicaNodes, replicaIt.Next())
+		}
+		s.Physical[name] = physical
 	}
 
 	return nil
@@ -72,6 +72,54 @@ func TestState(t *testing.T) {
 	assert.Equal(t, physicalCount, physicalCountReloaded)
 }
 
+func TestStateReplicas(t *testing.T) {
+	nodes := fakeNodes{[]string{"node1", "node2", "node3"}}
+
+	t.Run("places every replica on a different node", func(t *testing.T) {
+		cfg, err := ParseConfig(map[string]interface{}{
+			"desiredCount": float64(3),
+			"replicas":     float64(2),
+		}, 3)
+		require.Nil(t, err)
+
+		state, err := InitState("my-index", cfg, nodes)
+		require.Nil(t, err)
+
+		shardsPerNode := map[string]int{}
+		for _, physical := range state.Physical {
+			replicaNodes := physical.Nodes()
+			require.Len(t, replicaNodes, 2)
+			assert.NotEqual(t, replicaNodes[0], replicaNodes[1])
+			for _, node := range replicaNodes {
+				shardsPerNode[node]++
+			}
+		}
+		assert.Equal(t, map[string]int{"node1": 2, "node2": 2, "node3": 2}, shardsPerNode)
+	})
+
+	t.Run("shards are local on every replica", func(t *testing.T) {
+		state := &State{
+			Physical: map[string]Physical{
+				"shard1": {Name: "shard1", BelongsToNode: "node1"},
+				"shard2": {Name: "shard2", BelongsToNode: "node2", ReplicaNodes: []string{"node1"}},
+				"shard3": {Name: "shard3", BelongsToNode: "node2", ReplicaNodes: []string{"node3"}},
+			},
+		}
+		state.SetLocalName("node1")
+
+		assert.Equal(t, []string{"shard1", "shard2"}, state.AllLocalPhysicalShards())
+	})
+
+	t.Run("fails with more replicas than nodes", func(t *testing.T) {
+		cfg, err := ParseConfig(map[string]interface{}{"replicas": float64(4)}, 3)
+		require.Nil(t, err)
+
+		_, err = InitState("my-index", cfg, nodes)
+		require.NotNil(t, err)
+		assert.Equal(t, "cannot place 4 replicas of each shard on 3 nodes", err.Error())
+	})
+}
+
 type fakeNodes struct {
 	nodes []string
 }
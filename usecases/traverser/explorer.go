@@ -23,6 +23,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/schema/crossref"
 	"github.com/semi-technologies/weaviate/entities/search"
+	"github.com/semi-technologies/weaviate/entities/searchparams"
 	"github.com/semi-technologies/weaviate/usecases/floatcomp"
 	uc "github.com/semi-technologies/weaviate/usecases/schema"
 	"github.com/semi-technologies/weaviate/usecases/traverser/grouper"
@@ -52,6 +53,8 @@ type ModulesProvider interface {
 		params interface{}, findVectorFn modulecapabilities.FindVectorFn) ([]float32, error)
 	CrossClassVectorFromSearchParam(ctx context.Context, param string,
 		params interface{}, findVectorFn modulecapabilities.FindVectorFn) ([]float32, error)
+	VectorFromInput(ctx context.Context, className, targetVector,
+		input string) ([]float32, error)
 	GetExploreAdditionalExtend(ctx context.Context, in []search.Result,
 		moduleParams map[string]interface{}, searchVector []float32,
 		argumentModuleParams map[string]interface{}) ([]search.Result, error)
@@ -108,6 +111,10 @@ func (e *Explorer) GetClass(ctx context.Context,
 		return nil, errors.Wrap(err, "invalid 'sort' filter")
 	}
 
+	if params.HybridSearch != nil {
+		return e.getClassHybrid(ctx, params)
+	}
+
 	if params.KeywordRanking != nil {
 		return e.getClassKeywordBased(ctx, params)
 	}
@@ -131,11 +138,11 @@ func (e *Explorer) getClassKeywordBased(ctx context.Context,
 	}
 
 	if len(params.KeywordRanking.Properties) == 0 {
-		return nil, errors.Errorf("keyword search (bm25) requires exactly one property")
+		return nil, errors.Errorf("keyword search (bm25) requires at least one property")
 	}
 
-	if len(params.KeywordRanking.Properties) > 1 {
-		return nil, errors.Errorf("multi-property keyword search (BM25F) not supported yet")
+	if _, err := searchparams.ParsePropertyBoosts(params.KeywordRanking.Properties); err != nil {
+		return nil, errors.Wrap(err, "keyword search (bm25)")
 	}
 
 	if len(params.KeywordRanking.Query) == 0 {
@@ -174,9 +181,125 @@ func (e *Explorer) getClassKeywordBased(ctx context.Context,
 	return e.searchResultsToGetResponse(ctx, res, nil, params)
 }
 
+func (e *Explorer) getClassHybrid(ctx context.Context,
+	params GetParams,
+) ([]interface{}, error) {
+	if params.NearVector != nil || params.NearObject != nil || len(params.ModuleParams) > 0 ||
+		params.KeywordRanking != nil {
+		return nil, errors.Errorf("conflict: hybrid search cannot be combined with " +
+			"near<Media> or keyword-based (bm25) arguments, choose one")
+	}
+
+	if params.Filters != nil {
+		return nil, errors.Errorf("filtered hybrid search not supported yet")
+	}
+
+	if len(params.Sort) > 0 {
+		return nil, errors.Errorf("sorting hybrid search results not supported, " +
+			"results are ordered by their fused score")
+	}
+
+	hybrid := *params.HybridSearch
+	if err := e.validateHybridSearch(params.ClassName, hybrid); err != nil {
+		return nil, errors.Wrap(err, "invalid 'hybrid' argument")
+	}
+
+	if hybrid.FusionType == "" {
+		hybrid.FusionType = searchparams.HybridFusionRanked
+	}
+
+	if hybrid.Alpha > 0 && hybrid.Vector == nil {
+		if e.modulesProvider == nil {
+			return nil, errors.Errorf("explorer: get class: hybrid search " +
+				"without a vectorizer requires a vector")
+		}
+
+		vector, err := e.modulesProvider.VectorFromInput(ctx, params.ClassName,
+			hybrid.TargetVector, hybrid.Query)
+		if err != nil {
+			return nil, errors.Errorf("explorer: get class: vectorize hybrid query: %v", err)
+		}
+		hybrid.Vector = vector
+	}
+
+	params.HybridSearch = &hybrid
+	params.TargetVector = hybrid.TargetVector
+
+	if len(params.AdditionalProperties.ModuleParams) > 0 {
+		// if a module-specific additional prop is set, assume it needs the vector
+		// present for backward-compatibility. This could be improved by actually
+		// asking the module based on specific conditions
+		params.AdditionalProperties.Vector = true
+	}
+
+	res, err := e.search.ClassSearch(ctx, params)
+	if err != nil {
+		return nil, errors.Errorf("explorer: get class: hybrid search: %v", err)
+	}
+
+	if params.Group != nil {
+		grouped, err := grouper.New(e.logger).Group(res, params.Group.Strategy, params.Group.Force)
+		if err != nil {
+			return nil, errors.Errorf("grouper: %v", err)
+		}
+
+		res = grouped
+	}
+
+	if e.modulesProvider != nil {
+		res, err = e.modulesProvider.GetExploreAdditionalExtend(ctx, res,
+			params.AdditionalProperties.ModuleParams, hybrid.Vector, params.ModuleParams)
+		if err != nil {
+			return nil, errors.Errorf("explorer: get class: extend: %v", err)
+		}
+	}
+
+	return e.searchResultsToGetResponse(ctx, res, nil, params)
+}
+
+func (e *Explorer) validateHybridSearch(className string,
+	hybrid searchparams.HybridSearch,
+) error {
+	if len(hybrid.Query) == 0 {
+		return errors.Errorf("hybrid search must have query set")
+	}
+
+	if hybrid.Alpha < 0 || hybrid.Alpha > 1 {
+		return errors.Errorf("alpha must be between 0 and 1, got %v", hybrid.Alpha)
+	}
+
+	switch hybrid.FusionType {
+	case "", searchparams.HybridFusionRanked, searchparams.HybridFusionRelativeScore:
+	default:
+		return errors.Errorf("unrecognized fusion type %q, must be one of %q or %q",
+			hybrid.FusionType, searchparams.HybridFusionRanked,
+			searchparams.HybridFusionRelativeScore)
+	}
+
+	// an alpha of 1 is a pure vector search, the keyword part is skipped
+	if hybrid.Alpha < 1 {
+		if len(hybrid.Properties) == 0 {
+			return errors.Errorf("hybrid search requires at least one property " +
+				"for its keyword-based (bm25) part")
+		}
+
+		if _, err := searchparams.ParsePropertyBoosts(hybrid.Properties); err != nil {
+			return err
+		}
+	}
+
+	return e.validateTargetVector(className, hybrid.TargetVector)
+}
+
 func (e *Explorer) getClassVectorSearch(ctx context.Context,
 	params GetParams,
 ) ([]interface{}, error) {
+	params.TargetVector = targetVectorFromParams(params.NearVector,
+		params.NearObject, params.ModuleParams)
+	if err := e.validateTargetVector(params.ClassName, params.TargetVector); err != nil {
+		return nil, errors.Errorf("explorer: get class: %v", err)
+	}
+
 	searchVector, err := e.vectorFromParams(ctx, params)
 	if err != nil {
 		return nil, errors.Errorf("explorer: get class: vectorize params: %v", err)
@@ -305,7 +428,7 @@ func (e *Explorer) searchResultsToGetResponse(ctx context.Context,
 			}
 
 			if params.AdditionalProperties.Certainty {
-				if err := e.checkCertaintyCompatibility(params.ClassName); err != nil {
+				if err := e.checkCertaintyCompatibility(params.ClassName, params.TargetVector); err != nil {
 					return nil, errors.Errorf("additional: %s", err)
 				}
 				additionalProperties["certainty"] = additional.DistToCertainty(float64(res.Dist))
@@ -316,6 +439,10 @@ func (e *Explorer) searchResultsToGetResponse(ctx context.Context,
 			}
 		}
 
+		if params.AdditionalProperties.Score {
+			additionalProperties["score"] = res.Score
+		}
+
 		if params.AdditionalProperties.ID {
 			additionalProperties["id"] = res.ID
 		}
@@ -324,6 +451,14 @@ func (e *Explorer) searchResultsToGetResponse(ctx context.Context,
 			additionalProperties["vector"] = res.Vector
 		}
 
+		if params.AdditionalProperties.Vectors {
+			vectors := make(map[string]interface{}, len(res.Vectors))
+			for name, vector := range res.Vectors {
+				vectors[name] = vector
+			}
+			additionalProperties["vectors"] = vectors
+		}
+
 		if params.AdditionalProperties.CreationTimeUnix {
 			additionalProperties["creationTimeUnix"] = res.Created
 		}
@@ -451,6 +586,31 @@ func (e *Explorer) validateExploreParams(params ExploreParams) error {
 			"or module search params is required for an exploration")
 	}
 
+	if targetVectorFromParams(params.NearVector, params.NearObject, params.ModuleParams) != "" {
+		return errors.Errorf("targetVector is not supported for an exploration, " +
+			"named vectors can only be searched within their class")
+	}
+
+	return nil
+}
+
+// validateTargetVector makes sure that the class has the named vector which
+// is targeted by a search, an empty target is the regular vector of the class
+func (e *Explorer) validateTargetVector(className, targetVector string) error {
+	if targetVector == "" {
+		return nil
+	}
+
+	s := e.schemaGetter.GetSchemaSkipAuth()
+	class := s.GetClass(schema.ClassName(className))
+	if class == nil {
+		return errors.Errorf("failed to get class: %s", className)
+	}
+
+	if _, ok := class.VectorConfig[targetVector]; !ok {
+		return errors.Errorf("class %s has no named vector %q", className, targetVector)
+	}
+
 	return nil
 }
 
@@ -500,7 +660,7 @@ func (e *Explorer) crossClassVectorFromModules(ctx context.Context,
 ) ([]float32, error) {
 	if e.modulesProvider != nil {
 		vector, err := e.modulesProvider.CrossClassVectorFromSearchParam(ctx,
-			paramName, paramValue, e.nearParamsVector.findVector,
+			paramName, paramValue, e.nearParamsVector.findVectorFn(""),
 		)
 		if err != nil {
 			return nil, errors.Errorf("vectorize params: %v", err)
@@ -510,7 +670,7 @@ func (e *Explorer) crossClassVectorFromModules(ctx context.Context,
 	return nil, errors.New("no modules defined")
 }
 
-func (e *Explorer) checkCertaintyCompatibility(className string) error {
+func (e *Explorer) checkCertaintyCompatibility(className, targetVector string) error {
 	s := e.schemaGetter.GetSchemaSkipAuth()
 	if s.Objects == nil {
 		return errors.Errorf("failed to get schema")
@@ -519,12 +679,12 @@ func (e *Explorer) checkCertaintyCompatibility(className string) error {
 	if class == nil {
 		return errors.Errorf("failed to get class: %s", className)
 	}
-	hnswConfig, err := typeAssertVectorIndex(class)
+	vectorConfig, err := typeAssertTargetVectorIndex(class, targetVector)
 	if err != nil {
 		return err
 	}
-	if hnswConfig.Distance != hnsw.DistanceCosine {
-		return certaintyUnsupportedError(hnswConfig.Distance)
+	if vectorConfig.DistanceName() != hnsw.DistanceCosine {
+		return certaintyUnsupportedError(vectorConfig.DistanceName())
 	}
 
 	return nil
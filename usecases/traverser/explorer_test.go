@@ -2430,6 +2430,198 @@ func Test_Explorer_GetClass_With_Modules(t *testing.T) {
 	})
 }
 
+func Test_Explorer_GetClass_Hybrid(t *testing.T) {
+	searchResults := []search.Result{
+		{
+			ID:    "id1",
+			Score: 0.02,
+			Schema: map[string]interface{}{
+				"name": "Foo",
+			},
+			AdditionalProperties: models.AdditionalProperties{
+				"explainScore": "hybrid (rankedFusion, alpha 0.5) score 0.02",
+			},
+		},
+	}
+
+	t.Run("without a vector the query is vectorized", func(t *testing.T) {
+		params := GetParams{
+			ClassName: "BestClass",
+			HybridSearch: &searchparams.HybridSearch{
+				Query:      "foo",
+				Alpha:      0.5,
+				Properties: []string{"name"},
+			},
+			Pagination: &filters.Pagination{Limit: 100},
+			AdditionalProperties: additional.Properties{
+				Score:        true,
+				ExplainScore: true,
+			},
+		}
+
+		search := &fakeVectorSearcher{}
+		log, _ := test.NewNullLogger()
+		explorer := NewExplorer(search, log, getFakeModulesProvider(), nil)
+		expectedParamsToSearch := params
+		expectedParamsToSearch.HybridSearch = &searchparams.HybridSearch{
+			Query:      "foo",
+			Vector:     []float32{1, 2, 3},
+			Alpha:      0.5,
+			FusionType: searchparams.HybridFusionRanked,
+			Properties: []string{"name"},
+		}
+		search.
+			On("ClassSearch", expectedParamsToSearch).
+			Return(searchResults, nil)
+
+		res, err := explorer.GetClass(context.Background(), params)
+		require.Nil(t, err)
+		search.AssertExpectations(t)
+
+		require.Len(t, res, 1)
+		assert.Equal(t,
+			map[string]interface{}{
+				"name": "Foo",
+				"_additional": map[string]interface{}{
+					"score":        float32(0.02),
+					"explainScore": "hybrid (rankedFusion, alpha 0.5) score 0.02",
+				},
+			}, res[0])
+	})
+
+	t.Run("with a vector the query is not vectorized", func(t *testing.T) {
+		params := GetParams{
+			ClassName: "BestClass",
+			HybridSearch: &searchparams.HybridSearch{
+				Query:      "foo",
+				Vector:     []float32{0.1, 0.2, 0.3},
+				Alpha:      1,
+				FusionType: searchparams.HybridFusionRelativeScore,
+			},
+			Pagination: &filters.Pagination{Limit: 100},
+		}
+
+		search := &fakeVectorSearcher{}
+		log, _ := test.NewNullLogger()
+		explorer := NewExplorer(search, log, getFakeModulesProvider(), nil)
+		search.
+			On("ClassSearch", params).
+			Return(searchResults, nil)
+
+		_, err := explorer.GetClass(context.Background(), params)
+		require.Nil(t, err)
+		search.AssertExpectations(t)
+	})
+
+	t.Run("with invalid params", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			params        GetParams
+			expectedError string
+		}{
+			{
+				name: "without a query",
+				params: GetParams{
+					HybridSearch: &searchparams.HybridSearch{
+						Alpha:      0.5,
+						Properties: []string{"name"},
+					},
+				},
+				expectedError: "hybrid search must have query set",
+			},
+			{
+				name: "with an alpha out of range",
+				params: GetParams{
+					HybridSearch: &searchparams.HybridSearch{
+						Query:      "foo",
+						Alpha:      1.5,
+						Properties: []string{"name"},
+					},
+				},
+				expectedError: "alpha must be between 0 and 1",
+			},
+			{
+				name: "with an unknown fusion type",
+				params: GetParams{
+					HybridSearch: &searchparams.HybridSearch{
+						Query:      "foo",
+						Alpha:      0.5,
+						FusionType: "averageFusion",
+						Properties: []string{"name"},
+					},
+				},
+				expectedError: "unrecognized fusion type",
+			},
+			{
+				name: "without a property for the keyword part",
+				params: GetParams{
+					HybridSearch: &searchparams.HybridSearch{
+						Query: "foo",
+						Alpha: 0.5,
+					},
+				},
+				expectedError: "hybrid search requires at least one property",
+			},
+			{
+				name: "with an invalid property boost",
+				params: GetParams{
+					HybridSearch: &searchparams.HybridSearch{
+						Query:      "foo",
+						Alpha:      0.5,
+						Properties: []string{"name^high"},
+					},
+				},
+				expectedError: "boost must be a number",
+			},
+			{
+				name: "combined with nearVector",
+				params: GetParams{
+					HybridSearch: &searchparams.HybridSearch{
+						Query:      "foo",
+						Alpha:      0.5,
+						Properties: []string{"name"},
+					},
+					NearVector: &searchparams.NearVector{
+						Vector: []float32{0.1, 0.2, 0.3},
+					},
+				},
+				expectedError: "conflict: hybrid search cannot be combined",
+			},
+			{
+				name: "with sorting",
+				params: GetParams{
+					HybridSearch: &searchparams.HybridSearch{
+						Query:      "foo",
+						Alpha:      0.5,
+						Properties: []string{"name"},
+					},
+					Sort: []filters.Sort{{Path: []string{"name"}, Order: "asc"}},
+				},
+				expectedError: "sorting hybrid search results not supported",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				test.params.ClassName = "BestClass"
+				test.params.Pagination = &filters.Pagination{Limit: 100}
+
+				search := &fakeVectorSearcher{}
+				explorer := NewExplorer(search, nil, getFakeModulesProvider(), nil)
+				schemaGetter := newFakeSchemaGetter("BestClass")
+				schemaGetter.schema.Objects.Classes[0].Properties = []*models.Property{
+					{Name: "name", DataType: []string{"string"}},
+				}
+				explorer.SetSchemaGetter(schemaGetter)
+
+				_, err := explorer.GetClass(context.Background(), test.params)
+				require.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+			})
+		}
+	})
+}
+
 func ptFloat32(in float32) *float32 {
 	return &in
 }
@@ -2456,6 +2648,17 @@ func (p *fakeModulesProvider) CrossClassVectorFromSearchParam(ctx context.Contex
 	return vectorForParams(ctx, params, "", findVectorFn, nil)
 }
 
+func (p *fakeModulesProvider) VectorFromInput(ctx context.Context,
+	className, targetVector, input string,
+) ([]float32, error) {
+	txt2vec := p.getFakeT2Vec()
+	params := txt2vec.Arguments()["nearCustomText"].ExtractFunction(map[string]interface{}{
+		"concepts": []interface{}{input},
+	})
+	vectorForParams := txt2vec.VectorSearches()["nearCustomText"]
+	return vectorForParams(ctx, params, className, nil, nil)
+}
+
 func (p *fakeModulesProvider) CrossClassValidateSearchParam(name string, value interface{}) error {
 	return p.ValidateSearchParam(name, value, "")
 }
@@ -266,6 +266,43 @@ func Test_Explorer_GetClass_WithFilters(t *testing.T) {
 		buildInvalidTests(filters.OperatorEqual, []interface{}{"phone_prop"},
 			schema.DataTypePhoneNumber, allValueTypesExcept(schema.DataTypePhoneNumber), "foo"),
 
+		// value lists
+		{
+			{
+				name: "valid contains any search on an array",
+				filters: buildFilter(filters.OperatorContainsAny, []interface{}{"int_array_prop"},
+					schema.DataTypeIntArray, []int{1, 2}),
+				expectedError: nil,
+			},
+			{
+				name: "valid contains all search on the tokens of a text",
+				filters: buildFilter(filters.OperatorContainsAll, []interface{}{"text_prop"},
+					schema.DataTypeTextArray, []string{"foo", "bar"}),
+				expectedError: nil,
+			},
+			{
+				name: "invalid contains all search - using a list of another type",
+				filters: buildFilter(filters.OperatorContainsAll, []interface{}{"int_array_prop"},
+					schema.DataTypeTextArray, []string{"foo", "bar"}),
+				expectedError: errors.Errorf("invalid 'where' filter: operator ContainsAll " +
+					"cannot use \"valueTextArray\" on type \"int[]\", use \"valueIntArray\" instead"),
+			},
+			{
+				name: "invalid contains any search - using a single value",
+				filters: buildFilter(filters.OperatorContainsAny, []interface{}{"int_prop"},
+					schema.DataTypeInt, 1),
+				expectedError: errors.Errorf("invalid 'where' filter: operator ContainsAny " +
+					"cannot use \"valueInt\" on type \"int\", use \"valueIntArray\" instead"),
+			},
+			{
+				name: "invalid equal search - using a value list",
+				filters: buildFilter(filters.OperatorEqual, []interface{}{"int_array_prop"},
+					schema.DataTypeIntArray, []int{1, 2}),
+				expectedError: errors.Errorf("invalid 'where' filter: \"valueIntArray\" " +
+					"can only be used with the operators ContainsAny and ContainsAll"),
+			},
+		},
+
 		// nested filters
 		{
 			{
@@ -379,6 +379,7 @@ type nearCustomTextParams struct {
 	Certainty    float64
 	Distance     float64
 	WithDistance bool
+	TargetVector string
 }
 
 func (p nearCustomTextParams) GetCertainty() float64 {
@@ -393,6 +394,10 @@ func (p nearCustomTextParams) SimilarityMetricProvided() bool {
 	return p.Certainty != 0 || p.WithDistance
 }
 
+func (p nearCustomTextParams) GetTargetVector() string {
+	return p.TargetVector
+}
+
 type nearExploreMove struct {
 	Values  []string
 	Force   float32
@@ -53,7 +53,8 @@ func (v *nearParamsVector) vectorFromParams(ctx context.Context,
 
 	if len(moduleParams) == 1 {
 		for name, value := range moduleParams {
-			return v.vectorFromModules(ctx, className, name, value)
+			return v.vectorFromModules(ctx, className, name, value,
+				targetVectorFromParams(nil, nil, moduleParams))
 		}
 	}
 
@@ -142,11 +143,11 @@ func (v *nearParamsVector) validateNearParams(nearVector *searchparams.NearVecto
 }
 
 func (v *nearParamsVector) vectorFromModules(ctx context.Context,
-	className, paramName string, paramValue interface{},
+	className, paramName string, paramValue interface{}, targetVector string,
 ) ([]float32, error) {
 	if v.modulesProvider != nil {
 		vector, err := v.modulesProvider.VectorFromSearchParam(ctx,
-			className, paramName, paramValue, v.findVector,
+			className, paramName, paramValue, v.findVectorFn(targetVector),
 		)
 		if err != nil {
 			return nil, errors.Errorf("vectorize params: %v", err)
@@ -156,24 +157,46 @@ func (v *nearParamsVector) vectorFromModules(ctx context.Context,
 	return nil, errors.New("no modules defined")
 }
 
-func (v *nearParamsVector) findVector(ctx context.Context, className string, id strfmt.UUID) ([]float32, error) {
+// findVectorFn returns a function which finds the vector of an object, or
+// its named vector if a target vector is set
+func (v *nearParamsVector) findVectorFn(targetVector string) modulecapabilities.FindVectorFn {
+	return func(ctx context.Context, className string, id strfmt.UUID) ([]float32, error) {
+		return v.findVector(ctx, className, id, targetVector)
+	}
+}
+
+func (v *nearParamsVector) findVector(ctx context.Context, className string,
+	id strfmt.UUID, targetVector string,
+) ([]float32, error) {
 	switch className {
 	case "":
 		// Explore cross class searches where we don't have class context
 		return v.crossClassFindVector(ctx, id)
 	default:
-		return v.classFindVector(ctx, className, id)
+		return v.classFindVector(ctx, className, id, targetVector)
 	}
 }
 
-func (v *nearParamsVector) classFindVector(ctx context.Context, className string, id strfmt.UUID) ([]float32, error) {
-	res, err := v.search.Object(ctx, className, id, search.SelectProperties{}, additional.Properties{})
+func (v *nearParamsVector) classFindVector(ctx context.Context, className string,
+	id strfmt.UUID, targetVector string,
+) ([]float32, error) {
+	res, err := v.search.Object(ctx, className, id, search.SelectProperties{},
+		additional.Properties{Vectors: targetVector != ""})
 	if err != nil {
 		return nil, err
 	}
 	if res == nil {
 		return nil, errors.New("vector not found")
 	}
+
+	if targetVector != "" {
+		vector, ok := res.Vectors[targetVector]
+		if !ok {
+			return nil, errors.Errorf("object %s has no named vector %q", id, targetVector)
+		}
+		return vector, nil
+	}
+
 	return res.Vector, nil
 }
 
@@ -220,7 +243,29 @@ func (v *nearParamsVector) vectorFromNearObjectParams(ctx context.Context,
 		id = ref.TargetID
 	}
 
-	return v.findVector(ctx, className, id)
+	return v.findVector(ctx, className, id, params.TargetVector)
+}
+
+// targetVectorFromParams returns the name of the named vector which the near
+// params search, it is empty if the regular vector of the class is searched
+func targetVectorFromParams(nearVector *searchparams.NearVector,
+	nearObject *searchparams.NearObject, moduleParams map[string]interface{},
+) string {
+	if nearVector != nil {
+		return nearVector.TargetVector
+	}
+
+	if nearObject != nil {
+		return nearObject.TargetVector
+	}
+
+	for _, param := range moduleParams {
+		if nearParam, ok := param.(modulecapabilities.NearParam); ok {
+			return nearParam.GetTargetVector()
+		}
+	}
+
+	return ""
 }
 
 func (v *nearParamsVector) extractCertaintyFromParams(nearVector *searchparams.NearVector,
@@ -52,6 +52,8 @@ func (t *Traverser) Aggregate(ctx context.Context, principal *models.Principal,
 			return nil, err
 		}
 		params.SearchVector = searchVector
+		params.TargetVector = targetVectorFromParams(params.NearVector,
+			params.NearObject, params.ModuleParams)
 		certainty := t.nearParamsVector.extractCertaintyFromParams(params.NearVector,
 			params.NearObject, params.ModuleParams)
 
@@ -27,7 +27,9 @@ type GetParams struct {
 	NearVector           *searchparams.NearVector
 	NearObject           *searchparams.NearObject
 	KeywordRanking       *searchparams.KeywordRanking
+	HybridSearch         *searchparams.HybridSearch
 	SearchVector         []float32
+	TargetVector         string
 	Group                *GroupParams
 	ModuleParams         map[string]interface{}
 	AdditionalProperties additional.Properties
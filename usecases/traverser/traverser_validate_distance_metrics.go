@@ -57,14 +57,14 @@ func (t Traverser) validateCrossClassDistanceCompatibility() (distType string, e
 			continue
 		}
 
-		hnswConfig, assertErr := typeAssertVectorIndex(class)
+		vectorConfig, assertErr := typeAssertVectorIndex(class)
 		if assertErr != nil {
 			err = assertErr
 			return
 		}
 
-		distancerTypes[hnswConfig.Distance] = struct{}{}
-		classDistanceConfigs[class.Class] = hnswConfig.Distance
+		distancerTypes[vectorConfig.DistanceName()] = struct{}{}
+		classDistanceConfigs[class.Class] = vectorConfig.DistanceName()
 	}
 
 	if len(distancerTypes) != 1 {
@@ -102,26 +102,51 @@ func (t *Traverser) validateGetDistanceParams(params GetParams) error {
 		return fmt.Errorf("failed to find class '%s' in schema", params.ClassName)
 	}
 
-	hnswConfig, err := typeAssertVectorIndex(class)
+	targetVector := targetVectorFromParams(params.NearVector, params.NearObject,
+		params.ModuleParams)
+	vectorConfig, err := typeAssertTargetVectorIndex(class, targetVector)
 	if err != nil {
 		return err
 	}
 
-	if hnswConfig.Distance != hnsw.DistanceCosine {
-		return certaintyUnsupportedError(hnswConfig.Distance)
+	if vectorConfig.DistanceName() != hnsw.DistanceCosine {
+		return certaintyUnsupportedError(vectorConfig.DistanceName())
 	}
 
 	return nil
 }
 
-func typeAssertVectorIndex(class *models.Class) (hnsw.UserConfig, error) {
-	hnswConfig, ok := class.VectorIndexConfig.(hnsw.UserConfig)
+func typeAssertVectorIndex(class *models.Class) (schema.VectorIndexConfig, error) {
+	vectorConfig, ok := class.VectorIndexConfig.(schema.VectorIndexConfig)
 	if !ok {
-		return hnsw.UserConfig{}, fmt.Errorf("class '%s' vector index: config is not hnsw.UserConfig: %T",
+		return nil, fmt.Errorf("class '%s' vector index: config is not schema.VectorIndexConfig: %T",
 			class.Class, class.VectorIndexConfig)
 	}
 
-	return hnswConfig, nil
+	return vectorConfig, nil
+}
+
+// typeAssertTargetVectorIndex is like typeAssertVectorIndex, but returns the
+// config of the named vector index if a target vector is set
+func typeAssertTargetVectorIndex(class *models.Class,
+	targetVector string,
+) (schema.VectorIndexConfig, error) {
+	if targetVector == "" {
+		return typeAssertVectorIndex(class)
+	}
+
+	namedVector, ok := class.VectorConfig[targetVector]
+	if !ok {
+		return nil, fmt.Errorf("class '%s' has no named vector '%s'", class.Class, targetVector)
+	}
+
+	vectorConfig, ok := namedVector.VectorIndexConfig.(schema.VectorIndexConfig)
+	if !ok {
+		return nil, fmt.Errorf("class '%s' named vector '%s' vector index: config is not "+
+			"schema.VectorIndexConfig: %T", class.Class, targetVector, namedVector.VectorIndexConfig)
+	}
+
+	return vectorConfig, nil
 }
 
 func crossClassDistCompatError(classDistanceConfigs map[string]string) error {